@@ -0,0 +1,58 @@
+package controller_auth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_auth"
+	"github.com/gin-gonic/gin"
+)
+
+type APIKeyController struct {
+	APIKeyUsecase domain_auth.APIKeyUsecase
+}
+
+func (ac *APIKeyController) Create(c *gin.Context) {
+	var request domain_auth.APIKeyRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	key, err := ac.APIKeyUsecase.Create(c, c.GetString(domain.UserIDKey), request.Name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, key)
+}
+
+func (ac *APIKeyController) Fetch(c *gin.Context) {
+	keys, err := ac.APIKeyUsecase.FetchByUserID(c, c.GetString(domain.UserIDKey))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+func (ac *APIKeyController) Delete(c *gin.Context) {
+	err := ac.APIKeyUsecase.Delete(c, c.GetString(domain.UserIDKey), c.Param("id"))
+	if errors.Is(err, domain_auth.ErrInvalidAPIKey) {
+		c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: "API key not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{
+		Message: "API key revoked",
+	})
+}
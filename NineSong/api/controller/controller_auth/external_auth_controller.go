@@ -0,0 +1,134 @@
+package controller_auth
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_auth"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	oidcStateCookie = "ninesong_oidc"
+	oidcCookiePath  = "/user/oidc"
+	oidcStateMaxAge = 600
+)
+
+type ExternalAuthController struct {
+	ExternalAuthUsecase domain_auth.ExternalAuthUsecase
+	Env                 *bootstrap.Env
+}
+
+func (ec *ExternalAuthController) LoginLDAP(c *gin.Context) {
+	var request domain_auth.LDAPLoginRequest
+	if err := c.ShouldBind(&request); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	user, err := ec.ExternalAuthUsecase.LoginLDAP(c, request.Username, request.Password)
+	if err != nil {
+		externalAuthError(c, err)
+		return
+	}
+
+	response, err := ec.tokens(&user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// OIDCLogin 把 state、nonce 与 code_verifier 存入仅限回调路径的 Cookie 后跳转到身份提供方
+func (ec *ExternalAuthController) OIDCLogin(c *gin.Context) {
+	authURL, state, err := ec.ExternalAuthUsecase.OIDCAuthURL(c)
+	if err != nil {
+		externalAuthError(c, err)
+		return
+	}
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookie, strings.Join([]string{state.State, state.Nonce, state.Verifier}, "."),
+		oidcStateMaxAge, oidcCookiePath, "", c.Request.TLS != nil, true)
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// OIDCCallback 配置了 OIDC_POST_LOGIN_URL 时把令牌放在 URL 片段中跳转回前端，否则直接返回 JSON
+func (ec *ExternalAuthController) OIDCCallback(c *gin.Context) {
+	if errParam := c.Query("error"); errParam != "" {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: errParam + ": " + c.Query("error_description")})
+		return
+	}
+
+	cookie, _ := c.Cookie(oidcStateCookie)
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookie, "", -1, oidcCookiePath, "", c.Request.TLS != nil, true)
+
+	parts := strings.Split(cookie, ".")
+	if len(parts) != 3 || parts[0] == "" || parts[0] != c.Query("state") {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid or expired login state"})
+		return
+	}
+	code := c.Query("code")
+	if code == "" {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "code is required"})
+		return
+	}
+
+	user, err := ec.ExternalAuthUsecase.LoginOIDC(c, code, &domain_auth.OIDCLoginState{
+		State:    parts[0],
+		Nonce:    parts[1],
+		Verifier: parts[2],
+	})
+	if err != nil {
+		externalAuthError(c, err)
+		return
+	}
+
+	response, err := ec.tokens(&user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if ec.Env.OIDCPostLoginURL == "" {
+		c.JSON(http.StatusOK, response)
+		return
+	}
+	fragment := url.Values{
+		"access_token":  {response.AccessToken},
+		"refresh_token": {response.RefreshToken},
+	}
+	c.Redirect(http.StatusFound, ec.Env.OIDCPostLoginURL+"#"+fragment.Encode())
+}
+
+func (ec *ExternalAuthController) tokens(user *domain_auth.User) (domain_auth.LoginResponse, error) {
+	accessToken, err := ec.ExternalAuthUsecase.CreateAccessToken(user, ec.Env.AccessTokenSecret, ec.Env.AccessTokenExpiryHour)
+	if err != nil {
+		return domain_auth.LoginResponse{}, err
+	}
+	refreshToken, err := ec.ExternalAuthUsecase.CreateRefreshToken(user, ec.Env.RefreshTokenSecret, ec.Env.RefreshTokenExpiryHour)
+	if err != nil {
+		return domain_auth.LoginResponse{}, err
+	}
+	return domain_auth.LoginResponse{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+func externalAuthError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain_auth.ErrProviderDisabled):
+		c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case errors.Is(err, domain_auth.ErrInvalidLogin):
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: "Invalid credentials"})
+	case errors.Is(err, domain_auth.ErrGroupNotAllowed):
+		c.JSON(http.StatusForbidden, domain.ErrorResponse{Message: err.Error()})
+	case errors.Is(err, domain_auth.ErrEmailTaken):
+		c.JSON(http.StatusConflict, domain.ErrorResponse{Message: err.Error()})
+	default:
+		c.JSON(http.StatusBadGateway, domain.ErrorResponse{Message: err.Error()})
+	}
+}
@@ -25,6 +25,12 @@ func (sc *SignupController) Signup(c *gin.Context) {
 		return
 	}
 
+	roles, err := domain_auth.NormalizeRoles(request.Roles)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
 	_, err = sc.SignupUsecase.GetUserByEmail(c, request.Email)
 	if err == nil {
 		c.JSON(http.StatusConflict, domain.ErrorResponse{Message: "User already exists with the given email"})
@@ -47,8 +53,9 @@ func (sc *SignupController) Signup(c *gin.Context) {
 		Name:     request.Name,
 		Email:    request.Email,
 		Password: request.Password,
-		Admin:    false,
+		Roles:    roles,
 	}
+	user.Admin = user.HasRole(domain_auth.RoleAdmin)
 
 	err = sc.SignupUsecase.Create(c, &user)
 	if err != nil {
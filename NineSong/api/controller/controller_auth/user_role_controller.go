@@ -0,0 +1,49 @@
+package controller_auth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_auth"
+	"github.com/gin-gonic/gin"
+)
+
+type UserRoleController struct {
+	UserRoleUsecase domain_auth.UserRoleUsecase
+}
+
+func (uc *UserRoleController) Fetch(c *gin.Context) {
+	users, err := uc.UserRoleUsecase.FetchUsers(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, users)
+}
+
+func (uc *UserRoleController) UpdateRoles(c *gin.Context) {
+	var request domain_auth.UserRolesRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	user, err := uc.UserRoleUsecase.UpdateRoles(c, c.GetString(domain.UserIDKey), c.Param("id"), request.Roles)
+	switch {
+	case errors.Is(err, domain_auth.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: "User not found"})
+		return
+	case errors.Is(err, domain_auth.ErrLastAdmin):
+		c.JSON(http.StatusConflict, domain.ErrorResponse{Message: err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
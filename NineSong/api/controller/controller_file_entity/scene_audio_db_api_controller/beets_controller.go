@@ -0,0 +1,45 @@
+package scene_audio_db_api_controller
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity"
+	"github.com/gin-gonic/gin"
+)
+
+// beetsMaxBodySize 导出文件上限，按上限曲目数且带内嵌歌词估算
+const beetsMaxBodySize = 64 << 20
+
+type BeetsController struct {
+	usecase *usecase_file_entity.BeetsUsecase
+}
+
+func NewBeetsController(uc *usecase_file_entity.BeetsUsecase) *BeetsController {
+	return &BeetsController{usecase: uc}
+}
+
+// Import 请求体为 `beet export` 的原始输出，JSON 数组与 JSON Lines 均可
+func (ctrl *BeetsController) Import(c *gin.Context) {
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, beetsMaxBodySize))
+	if err != nil {
+		controller.ErrorResponse(c, http.StatusRequestEntityTooLarge, "INVALID_PARAMS", "请求体读取失败: "+err.Error())
+		return
+	}
+
+	report, err := ctrl.usecase.Import(c.Request.Context(), body)
+	if err != nil {
+		if errors.Is(err, domain_file_entity.ErrBeetsInvalidExport) ||
+			errors.Is(err, domain_file_entity.ErrBeetsTooManyItems) ||
+			errors.Is(err, domain_file_entity.ErrIngestEmpty) {
+			controller.ErrorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
+			return
+		}
+		controller.ErrorResponse(c, http.StatusConflict, "INGEST_FAILED", err.Error())
+		return
+	}
+	controller.SuccessResponse(c, "ingest", report, report.Created+report.Updated+report.Unchanged)
+}
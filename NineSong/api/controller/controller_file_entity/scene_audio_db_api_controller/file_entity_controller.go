@@ -96,3 +96,9 @@ func (ctrl *FileController) GetScanProgress(c *gin.Context) {
 		"active_scan_count": activeScanCount,
 	})
 }
+
+// GetScanStatus 返回各运行中扫描任务的遍历、处理与增量跳过计数
+func (ctrl *FileController) GetScanStatus(c *gin.Context) {
+	status := ctrl.usecase.GetScanStatus()
+	controller.SuccessResponse(c, "scan_status", status, len(status.Tasks))
+}
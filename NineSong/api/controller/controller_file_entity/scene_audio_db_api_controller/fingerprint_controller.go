@@ -0,0 +1,73 @@
+package scene_audio_db_api_controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type FingerprintController struct {
+	usecase *usecase_file_entity.FingerprintUsecase
+}
+
+func NewFingerprintController(uc *usecase_file_entity.FingerprintUsecase) *FingerprintController {
+	return &FingerprintController{usecase: uc}
+}
+
+type fingerprintListParams struct {
+	Start int `form:"start,default=0"`
+	End   int `form:"end,default=50"`
+}
+
+// ListReview 低匹配度的声纹识别结果，按匹配度降序
+func (ctrl *FingerprintController) ListReview(c *gin.Context) {
+	var params fingerprintListParams
+	if err := c.ShouldBind(&params); err != nil {
+		controller.ErrorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "参数格式错误")
+		return
+	}
+
+	items, total, err := ctrl.usecase.ListReview(c.Request.Context(), params.Start, params.End)
+	if err != nil {
+		controller.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	controller.SuccessResponse(c, "media_files", items, int(total))
+}
+
+// Accept 确认候选录音，写入曲目的 mbz_track_id
+func (ctrl *FingerprintController) Accept(c *gin.Context) {
+	ctrl.resolve(c, true)
+}
+
+func (ctrl *FingerprintController) Reject(c *gin.Context) {
+	ctrl.resolve(c, false)
+}
+
+func (ctrl *FingerprintController) resolve(c *gin.Context, accept bool) {
+	id := c.Param("id")
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		controller.ErrorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "无效的曲目ID")
+		return
+	}
+
+	if err := ctrl.usecase.Resolve(c.Request.Context(), id, accept); err != nil {
+		if errors.Is(err, scene_audio_db_models.ErrFingerprintReviewNotFound) {
+			controller.ErrorResponse(c, http.StatusNotFound, "NOT_FOUND", "条目不在待确认列表中")
+			return
+		}
+		controller.ErrorResponse(c, http.StatusInternalServerError, "FINGERPRINT_ERROR", err.Error())
+		return
+	}
+
+	status := scene_audio_db_models.FingerprintStatusRejected
+	if accept {
+		status = scene_audio_db_models.FingerprintStatusMatched
+	}
+	controller.SuccessResponse(c, "status", status, 1)
+}
@@ -126,3 +126,55 @@ func (ctrl *LibraryController) GetLibraries(c *gin.Context) {
 
 	controller.SuccessResponse(c, "libraries", libraries, len(libraries))
 }
+
+// UpdateLibraryAccess 设置媒体库的可见用户与角色，均不传时对所有用户开放
+func (ctrl *LibraryController) UpdateLibraryAccess(c *gin.Context) {
+	var params struct {
+		ID    string   `form:"id" binding:"required"`
+		Users []string `form:"users"`
+		Roles []string `form:"roles"`
+	}
+
+	if err := c.ShouldBind(&params); err != nil {
+		controller.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	if err := ctrl.uc.UpdateLibraryAccess(c.Request.Context(), params.ID, params.Users, params.Roles); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, domain_file_entity.ErrLibraryNotFound) {
+			status = http.StatusNotFound
+		}
+		controller.ErrorResponse(c, status, "LIBRARY_ERROR", err.Error())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UpdateLibraryAudiobook 标记或取消有声书库
+func (ctrl *LibraryController) UpdateLibraryAudiobook(c *gin.Context) {
+	var params struct {
+		ID        string `form:"id" binding:"required"`
+		Audiobook bool   `form:"audiobook"`
+	}
+
+	if err := c.ShouldBind(&params); err != nil {
+		controller.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	if err := ctrl.uc.UpdateLibraryAudiobook(c.Request.Context(), params.ID, params.Audiobook); err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, domain_file_entity.ErrLibraryNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, domain_file_entity.ErrLibraryNotMusic):
+			status = http.StatusBadRequest
+		}
+		controller.ErrorResponse(c, status, "LIBRARY_ERROR", err.Error())
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
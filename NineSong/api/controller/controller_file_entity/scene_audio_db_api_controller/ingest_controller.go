@@ -0,0 +1,43 @@
+package scene_audio_db_api_controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity"
+	"github.com/gin-gonic/gin"
+)
+
+type IngestController struct {
+	usecase *usecase_file_entity.IngestUsecase
+}
+
+func NewIngestController(uc *usecase_file_entity.IngestUsecase) *IngestController {
+	return &IngestController{usecase: uc}
+}
+
+type ingestRequest struct {
+	Tracks []domain_file_entity.IngestTrack `json:"tracks"`
+}
+
+// Ingest 接收 JSON 格式的曲目元数据分块，大批量导入时由调用方按 IngestMaxTracks 分块提交
+func (ctrl *IngestController) Ingest(c *gin.Context) {
+	var req ingestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		controller.ErrorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "参数格式错误: "+err.Error())
+		return
+	}
+
+	report, err := ctrl.usecase.Ingest(c.Request.Context(), req.Tracks)
+	if err != nil {
+		if errors.Is(err, domain_file_entity.ErrIngestEmpty) || errors.Is(err, domain_file_entity.ErrIngestTooManyTracks) {
+			controller.ErrorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
+			return
+		}
+		controller.ErrorResponse(c, http.StatusConflict, "INGEST_FAILED", err.Error())
+		return
+	}
+	controller.SuccessResponse(c, "ingest", report, report.Created+report.Updated+report.Unchanged)
+}
@@ -0,0 +1,105 @@
+package scene_audio_db_api_controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity"
+	"github.com/gin-gonic/gin"
+)
+
+type MaintenanceController struct {
+	usecase *usecase_file_entity.MaintenanceUsecase
+}
+
+func NewMaintenanceController(uc *usecase_file_entity.MaintenanceUsecase) *MaintenanceController {
+	return &MaintenanceController{usecase: uc}
+}
+
+// StartRepair 后台执行孤立数据清理，dry_run=true 时只统计并保存完整变更集，完成后可按报告ID应用；
+// 单步删除数超过确认阈值时，需以报告 confirmation.token 作为 confirm 参数重新提交
+func (ctrl *MaintenanceController) StartRepair(c *gin.Context) {
+	dryRun := false
+	if value := c.Query("dry_run"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			controller.ErrorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "dry_run 必须为 true/false")
+			return
+		}
+		dryRun = parsed
+	}
+
+	report, err := ctrl.usecase.Start(dryRun, c.Query("confirm"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, scene_audio_db_models.ErrMaintenanceBusy) {
+			status = http.StatusConflict
+		}
+		controller.ErrorResponse(c, status, "MAINTENANCE_ERROR", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"report": report,
+	})
+}
+
+// GetRepairReport 返回最近一次清理的结果，运行中时为当前进度
+func (ctrl *MaintenanceController) GetRepairReport(c *gin.Context) {
+	report := ctrl.usecase.LastReport()
+	if report == nil {
+		controller.ErrorResponse(c, http.StatusNotFound, "NOT_FOUND", "尚未执行过一致性修复")
+		return
+	}
+	controller.SuccessResponse(c, "report", report, 1)
+}
+
+// ListReports 返回未过期的试运行报告摘要
+func (ctrl *MaintenanceController) ListReports(c *gin.Context) {
+	reports, err := ctrl.usecase.Reports(c.Request.Context())
+	if err != nil {
+		controller.ErrorResponse(c, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+		return
+	}
+	controller.SuccessResponse(c, "reports", reports, len(reports))
+}
+
+// GetReport 返回试运行报告及各步骤将删除的条目
+func (ctrl *MaintenanceController) GetReport(c *gin.Context) {
+	report, err := ctrl.usecase.Report(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		maintenanceReportError(c, err)
+		return
+	}
+	controller.SuccessResponse(c, "report", report, 1)
+}
+
+// ApplyReport 在后台按试运行报告执行删除，进度与结果见 GET /maintenance/repair
+func (ctrl *MaintenanceController) ApplyReport(c *gin.Context) {
+	report, err := ctrl.usecase.Apply(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		maintenanceReportError(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{
+		"report": report,
+	})
+}
+
+func maintenanceReportError(c *gin.Context, err error) {
+	switch {
+	case domain.IsNotFound(err):
+		controller.ErrorResponse(c, http.StatusNotFound, "NOT_FOUND", err.Error())
+	case errors.Is(err, scene_audio_db_models.ErrMaintenanceReportNotDryRun):
+		controller.ErrorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
+	case errors.Is(err, scene_audio_db_models.ErrMaintenanceBusy),
+		errors.Is(err, scene_audio_db_models.ErrMaintenanceReportApplied):
+		controller.ErrorResponse(c, http.StatusConflict, "MAINTENANCE_ERROR", err.Error())
+	default:
+		controller.ErrorResponse(c, http.StatusInternalServerError, "MAINTENANCE_ERROR", err.Error())
+	}
+}
@@ -0,0 +1,40 @@
+package scene_audio_db_api_controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type MetadataController struct {
+	usecase *usecase_file_entity.EnrichmentUsecase
+}
+
+func NewMetadataController(uc *usecase_file_entity.EnrichmentUsecase) *MetadataController {
+	return &MetadataController{usecase: uc}
+}
+
+// RefreshMetadata 重新匹配专辑、艺术家或曲目（刷新其所属专辑）的 MusicBrainz ID
+func (ctrl *MetadataController) RefreshMetadata(c *gin.Context) {
+	id := c.Param("id")
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		controller.ErrorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "无效的条目ID")
+		return
+	}
+
+	result, err := ctrl.usecase.Refresh(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, scene_audio_db_models.ErrEnrichmentItemNotFound) {
+			controller.ErrorResponse(c, http.StatusNotFound, "NOT_FOUND", "条目不存在")
+			return
+		}
+		controller.ErrorResponse(c, http.StatusBadGateway, "METADATA_REFRESH_FAILED", err.Error())
+		return
+	}
+	controller.SuccessResponse(c, "result", result, 1)
+}
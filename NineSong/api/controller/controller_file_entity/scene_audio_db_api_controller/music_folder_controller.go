@@ -0,0 +1,96 @@
+package scene_audio_db_api_controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity"
+	"github.com/gin-gonic/gin"
+)
+
+type MusicFolderController struct {
+	usecase *usecase_file_entity.MusicFolderUsecase
+}
+
+func NewMusicFolderController(uc *usecase_file_entity.MusicFolderUsecase) *MusicFolderController {
+	return &MusicFolderController{usecase: uc}
+}
+
+// AddFolder 新增音乐媒体库，首次扫描在后台执行
+func (ctrl *MusicFolderController) AddFolder(c *gin.Context) {
+	var req struct {
+		Name string `form:"name"`
+		Path string `form:"path" binding:"required"`
+	}
+	if err := c.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	folder, err := ctrl.usecase.AddFolder(c.Request.Context(), req.Name, req.Path)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, domain_file_entity.ErrLibraryDuplicate) {
+			status = http.StatusConflict
+		}
+		controller.ErrorResponse(c, status, "FOLDER_ERROR", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"folder": folder,
+	})
+}
+
+func (ctrl *MusicFolderController) RemoveFolder(c *gin.Context) {
+	if err := ctrl.usecase.RemoveFolder(c.Request.Context(), c.Param("id")); err != nil {
+		ctrl.folderError(c, err)
+		return
+	}
+	ctrl.accepted(c)
+}
+
+// RescanFolder 重扫单个媒体库，full=true 时覆盖全部元数据
+func (ctrl *MusicFolderController) RescanFolder(c *gin.Context) {
+	full := false
+	if value := c.Query("full"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			controller.ErrorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "full 必须为 true/false")
+			return
+		}
+		full = parsed
+	}
+
+	if err := ctrl.usecase.RescanFolder(c.Request.Context(), c.Param("id"), full); err != nil {
+		ctrl.folderError(c, err)
+		return
+	}
+	ctrl.accepted(c)
+}
+
+func (ctrl *MusicFolderController) folderError(c *gin.Context, err error) {
+	status := http.StatusBadRequest
+	switch {
+	case errors.Is(err, domain_file_entity.ErrLibraryNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain_file_entity.ErrLibraryInUse):
+		status = http.StatusConflict
+	}
+	controller.ErrorResponse(c, status, "FOLDER_ERROR", err.Error())
+}
+
+func (ctrl *MusicFolderController) accepted(c *gin.Context) {
+	c.JSON(http.StatusAccepted, gin.H{
+		"ninesong-response": gin.H{
+			"status":        "ok",
+			"version":       controller.APIVersion,
+			"type":          controller.ServiceType,
+			"serverVersion": controller.ServerVersion,
+			"message":       "后台处理已启动",
+		},
+	})
+}
@@ -0,0 +1,87 @@
+package scene_audio_db_api_controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity"
+	"github.com/gin-gonic/gin"
+)
+
+type ReviewController struct {
+	usecase *usecase_file_entity.ReviewUsecase
+}
+
+func NewReviewController(uc *usecase_file_entity.ReviewUsecase) *ReviewController {
+	return &ReviewController{usecase: uc}
+}
+
+type reviewListParams struct {
+	Status string `form:"status"`
+	Start  int    `form:"start,default=0"`
+	End    int    `form:"end,default=50"`
+}
+
+func (ctrl *ReviewController) List(c *gin.Context) {
+	var params reviewListParams
+	if err := c.ShouldBind(&params); err != nil {
+		controller.ErrorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "参数格式错误")
+		return
+	}
+
+	items, total, err := ctrl.usecase.List(c.Request.Context(), params.Status, params.Start, params.End)
+	if err != nil {
+		controller.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	controller.SuccessResponse(c, "media_files", items, int(total))
+}
+
+// Approve 通过审核，media_file_ids 以逗号分隔
+func (ctrl *ReviewController) Approve(c *gin.Context) {
+	ctrl.update(c, ctrl.usecase.Approve)
+}
+
+func (ctrl *ReviewController) Reject(c *gin.Context) {
+	ctrl.update(c, ctrl.usecase.Reject)
+}
+
+func (ctrl *ReviewController) update(c *gin.Context, apply func(ctx context.Context, ids []string) (int64, error)) {
+	var ids []string
+	for _, id := range strings.Split(c.PostForm("media_file_ids"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	updated, err := apply(c.Request.Context(), ids)
+	if err != nil {
+		controller.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	controller.SuccessResponse(c, "updated", updated, int(updated))
+}
+
+// EditTags 修改标签并写回文件，tags 为 JSON 对象，如 {"title":"...","year":"2001"}
+func (ctrl *ReviewController) EditTags(c *gin.Context) {
+	id := c.PostForm("media_file_id")
+	var edits map[string]string
+	if err := json.Unmarshal([]byte(c.PostForm("tags")), &edits); err != nil {
+		controller.ErrorResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "tags 必须是JSON对象")
+		return
+	}
+
+	mediaFile, err := ctrl.usecase.EditTags(c.Request.Context(), id, edits)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			controller.ErrorResponse(c, http.StatusNotFound, "NOT_FOUND", err.Error())
+			return
+		}
+		controller.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	controller.SuccessResponse(c, "media_file", mediaFile, 1)
+}
@@ -0,0 +1,65 @@
+package scene_audio_db_api_controller
+
+import (
+	"mime/multipart"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity"
+	"github.com/gin-gonic/gin"
+)
+
+type UploadController struct {
+	usecase *usecase_file_entity.UploadUsecase
+}
+
+func NewUploadController(uc *usecase_file_entity.UploadUsecase) *UploadController {
+	return &UploadController{usecase: uc}
+}
+
+// Upload 接收 multipart 表单中的 files 字段（可多个）
+func (ctrl *UploadController) Upload(c *gin.Context) {
+	form, err := c.MultipartForm()
+	if err != nil {
+		controller.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "无效的上传表单: "+err.Error())
+		return
+	}
+	headers := form.File["files"]
+	if len(headers) == 0 {
+		controller.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "未提供上传文件")
+		return
+	}
+
+	files := make([]domain_file_entity.UploadFile, 0, len(headers))
+	opened := make([]multipart.File, 0, len(headers))
+	defer func() {
+		for _, f := range opened {
+			_ = f.Close()
+		}
+	}()
+	for _, header := range headers {
+		f, err := header.Open()
+		if err != nil {
+			controller.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", "无法读取上传文件: "+header.Filename)
+			return
+		}
+		opened = append(opened, f)
+		files = append(files, domain_file_entity.UploadFile{
+			Name:   header.Filename,
+			Size:   header.Size,
+			Reader: f,
+		})
+	}
+
+	result, err := ctrl.usecase.Upload(c.Request.Context(), c.GetString("x-user-id"), files)
+	if err != nil {
+		if err == domain_file_entity.ErrUploadInboxNotConfigured {
+			controller.ErrorResponse(c, http.StatusServiceUnavailable, "INBOX_NOT_CONFIGURED", err.Error())
+			return
+		}
+		controller.ErrorResponse(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	controller.SuccessResponse(c, "upload", result, result.Succeeded)
+}
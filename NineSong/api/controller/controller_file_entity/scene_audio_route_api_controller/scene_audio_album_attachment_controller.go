@@ -0,0 +1,85 @@
+package scene_audio_route_api_controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/gin-gonic/gin"
+)
+
+type AlbumAttachmentController struct {
+	AttachmentUsecase scene_audio_route_interface.AlbumAttachmentUsecase
+}
+
+func NewAlbumAttachmentController(uc scene_audio_route_interface.AlbumAttachmentUsecase) *AlbumAttachmentController {
+	return &AlbumAttachmentController{AttachmentUsecase: uc}
+}
+
+func (c *AlbumAttachmentController) GetAttachments(ctx *gin.Context) {
+	attachments, err := c.AttachmentUsecase.List(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		attachmentError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "attachments", attachments, len(attachments))
+}
+
+// DownloadAttachment 以附件形式下发，禁止浏览器按内容重新判断类型
+func (c *AlbumAttachmentController) DownloadAttachment(ctx *gin.Context) {
+	attachment, err := c.AttachmentUsecase.Open(ctx.Request.Context(), ctx.Param("id"), ctx.Param("attachment_id"))
+	if err != nil {
+		attachmentError(ctx, err)
+		return
+	}
+	ctx.Header("Content-Type", attachment.ContentType)
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	ctx.FileAttachment(attachment.Path, attachment.Name)
+}
+
+// UploadAttachment 接收 multipart 表单中的 file 字段
+func (c *AlbumAttachmentController) UploadAttachment(ctx *gin.Context) {
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_REQUEST", "未提供上传文件")
+		return
+	}
+	f, err := header.Open()
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_REQUEST", "无法读取上传文件: "+header.Filename)
+		return
+	}
+	defer f.Close()
+
+	attachment, err := c.AttachmentUsecase.Upload(ctx.Request.Context(), ctx.Param("id"), ctx.GetString("x-user-id"),
+		header.Filename, header.Size, f)
+	if err != nil {
+		attachmentError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "attachment", attachment, 1)
+}
+
+func (c *AlbumAttachmentController) DeleteAttachment(ctx *gin.Context) {
+	if err := c.AttachmentUsecase.Delete(ctx.Request.Context(), ctx.Param("id"), ctx.Param("attachment_id")); err != nil {
+		attachmentError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "deleted", true, 1)
+}
+
+func attachmentError(ctx *gin.Context, err error) {
+	switch {
+	case domain.IsNotFound(err):
+		controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", err.Error())
+	case errors.Is(err, scene_audio_db_models.ErrAttachmentUnsupported):
+		controller.ErrorResponse(ctx, http.StatusUnsupportedMediaType, "UNSUPPORTED_TYPE", "only PDF and plain text attachments are allowed")
+	case errors.Is(err, scene_audio_db_models.ErrAttachmentTooLarge):
+		controller.ErrorResponse(ctx, http.StatusRequestEntityTooLarge, "FILE_TOO_LARGE", err.Error())
+	default:
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+	}
+}
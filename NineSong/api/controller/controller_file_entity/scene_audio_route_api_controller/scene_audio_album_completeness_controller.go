@@ -0,0 +1,57 @@
+package scene_audio_route_api_controller
+
+import (
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/gin-gonic/gin"
+)
+
+type AlbumCompletenessController struct {
+	AlbumCompletenessUsecase scene_audio_route_interface.AlbumCompletenessRepository
+}
+
+func NewAlbumCompletenessController(uc scene_audio_route_interface.AlbumCompletenessRepository) *AlbumCompletenessController {
+	return &AlbumCompletenessController{AlbumCompletenessUsecase: uc}
+}
+
+func (c *AlbumCompletenessController) GetMissingTracks(ctx *gin.Context) {
+	var req struct {
+		AlbumID string `form:"album_id"`
+		Start   string `form:"start"`
+		End     string `form:"end"`
+	}
+
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+
+	if req.AlbumID != "" {
+		album, err := c.AlbumCompletenessUsecase.GetAlbumMissingTracks(ctx.Request.Context(), req.AlbumID)
+		if err != nil {
+			if domain.IsNotFound(err) {
+				controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", "album not found")
+				return
+			}
+			controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+			return
+		}
+		controller.SuccessResponse(ctx, "album", album, len(album.MissingTracks))
+		return
+	}
+
+	if req.Start == "" || req.End == "" {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "MISSING_PARAMS", "必须提供album_id或start和end参数")
+		return
+	}
+
+	albums, err := c.AlbumCompletenessUsecase.GetIncompleteAlbums(ctx.Request.Context(), req.Start, req.End)
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "albums", albums, len(albums))
+}
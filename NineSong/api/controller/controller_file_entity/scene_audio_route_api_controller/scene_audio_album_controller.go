@@ -2,40 +2,52 @@ package scene_audio_route_api_controller
 
 import (
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"strconv"
 )
 
 type AlbumController struct {
 	AlbumUsecase scene_audio_route_interface.AlbumRepository
+	Links        LinkBuilder
 }
 
-func NewAlbumController(uc scene_audio_route_interface.AlbumRepository) *AlbumController {
-	return &AlbumController{AlbumUsecase: uc}
+func NewAlbumController(uc scene_audio_route_interface.AlbumRepository, links LinkBuilder) *AlbumController {
+	return &AlbumController{AlbumUsecase: uc, Links: links}
 }
 
 func (c *AlbumController) GetAlbumItems(ctx *gin.Context) {
 	params := struct {
-		Start    string `form:"start" binding:"required"`
-		End      string `form:"end" binding:"required"`
-		Sort     string `form:"sort"`
-		Order    string `form:"order"`
-		Search   string `form:"search"`
-		Starred  string `form:"starred"`
-		ArtistID string `form:"artist_id"`
-		MinYear  string `form:"min_year"`
-		MaxYear  string `form:"max_year"`
+		Start     string `form:"start" binding:"required"`
+		End       string `form:"end" binding:"required"`
+		Sort      string `form:"sort"`
+		Order     string `form:"order"`
+		Search    string `form:"search"`
+		Starred   string `form:"starred"`
+		ArtistID  string `form:"artist_id"`
+		MinYear   string `form:"min_year"`
+		MaxYear   string `form:"max_year"`
+		Decade    string `form:"decade"` // 按原始发行年份过滤的年代起始年，如 1970
+		FolderID  string `form:"folder_id"`
+		Available string `form:"available"`
+		Custom    string `form:"custom"`
 	}{
-		Start:    ctx.Query("start"),
-		End:      ctx.Query("end"),
-		Sort:     ctx.DefaultQuery("sort", "name"),
-		Order:    ctx.DefaultQuery("order", "asc"),
-		Search:   ctx.Query("search"),
-		Starred:  ctx.Query("starred"),
-		ArtistID: ctx.Query("artist_id"),
-		MinYear:  ctx.Query("min_year"),
-		MaxYear:  ctx.Query("max_year"),
+		Start:     ctx.Query("start"),
+		End:       ctx.Query("end"),
+		Sort:      ctx.DefaultQuery("sort", "name"),
+		Order:     ctx.DefaultQuery("order", "asc"),
+		Search:    ctx.Query("search"),
+		Starred:   ctx.Query("starred"),
+		ArtistID:  ctx.Query("artist_id"),
+		MinYear:   ctx.Query("min_year"),
+		MaxYear:   ctx.Query("max_year"),
+		Decade:    ctx.Query("decade"),
+		FolderID:  ctx.Query("folder_id"),
+		Available: ctx.Query("available"),
+		Custom:    ctx.Query("custom"),
 	}
 
 	if params.Start == "" || params.End == "" {
@@ -43,6 +55,10 @@ func (c *AlbumController) GetAlbumItems(ctx *gin.Context) {
 		return
 	}
 
+	if !controller.CheckListParams(ctx, true, params.Start, params.End, params.Starred) {
+		return
+	}
+
 	albums, err := c.AlbumUsecase.GetAlbumItems(
 		ctx.Request.Context(),
 		params.Start,
@@ -54,6 +70,10 @@ func (c *AlbumController) GetAlbumItems(ctx *gin.Context) {
 		params.ArtistID,
 		params.MinYear,
 		params.MaxYear,
+		params.Decade,
+		params.FolderID,
+		params.Available,
+		params.Custom,
 	)
 
 	if err != nil {
@@ -61,22 +81,37 @@ func (c *AlbumController) GetAlbumItems(ctx *gin.Context) {
 		return
 	}
 
+	c.Links.FillAlbumLinks(ctx, albums)
 	controller.SuccessResponse(ctx, "albums", albums, len(albums))
 }
 
 func (c *AlbumController) GetAlbumFilterCounts(ctx *gin.Context) {
 	params := struct {
-		Search   string `form:"search"`
-		Starred  string `form:"starred"`
-		ArtistID string `form:"artist_id"`
-		MinYear  string `form:"min_year"`
-		MaxYear  string `form:"max_year"`
+		Search    string `form:"search"`
+		Starred   string `form:"starred"`
+		ArtistID  string `form:"artist_id"`
+		MinYear   string `form:"min_year"`
+		MaxYear   string `form:"max_year"`
+		Decade    string `form:"decade"` // 按原始发行年份过滤的年代起始年，如 1970
+		FolderID  string `form:"folder_id"`
+		Available string `form:"available"`
+		Custom    string `form:"custom"`
+		Genres    string `form:"genres"` // 返回前 N 个流派的专辑数
 	}{
-		Search:   ctx.Query("search"),
-		Starred:  ctx.Query("starred"),
-		ArtistID: ctx.Query("artist_id"),
-		MinYear:  ctx.Query("min_year"),
-		MaxYear:  ctx.Query("max_year"),
+		Search:    ctx.Query("search"),
+		Starred:   ctx.Query("starred"),
+		ArtistID:  ctx.Query("artist_id"),
+		MinYear:   ctx.Query("min_year"),
+		MaxYear:   ctx.Query("max_year"),
+		Decade:    ctx.Query("decade"),
+		FolderID:  ctx.Query("folder_id"),
+		Available: ctx.Query("available"),
+		Custom:    ctx.Query("custom"),
+		Genres:    ctx.Query("genres"),
+	}
+
+	if !controller.CheckListParams(ctx, false, "", "", params.Starred) {
+		return
 	}
 
 	counts, err := c.AlbumUsecase.GetAlbumFilterItemsCount(
@@ -86,6 +121,11 @@ func (c *AlbumController) GetAlbumFilterCounts(ctx *gin.Context) {
 		params.ArtistID,
 		params.MinYear,
 		params.MaxYear,
+		params.Decade,
+		params.FolderID,
+		params.Available,
+		params.Custom,
+		params.Genres,
 	)
 
 	if err != nil {
@@ -95,3 +135,112 @@ func (c *AlbumController) GetAlbumFilterCounts(ctx *gin.Context) {
 
 	controller.SuccessResponse(ctx, "albums", counts, 1)
 }
+
+// GetAlbumYears 返回按年份与年代统计的专辑数，用于时间线浏览；年代的 decade 值可直接传给专辑列表过滤
+func (c *AlbumController) GetAlbumYears(ctx *gin.Context) {
+	starred := ctx.Query("starred")
+	if !controller.CheckListParams(ctx, false, "", "", starred) {
+		return
+	}
+
+	counts, err := c.AlbumUsecase.GetAlbumYears(
+		ctx.Request.Context(),
+		ctx.Query("search"),
+		starred,
+		ctx.Query("artist_id"),
+		ctx.Query("folder_id"),
+		ctx.Query("available"),
+		ctx.Query("custom"),
+	)
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+		return
+	}
+
+	controller.SuccessResponse(ctx, "album_years", counts, len(counts.Years))
+}
+
+// GetAlbumShelves 一次返回最近添加、最近播放、最多播放、随机与最高评分专辑，各书架通过 *_limit 参数控制条数
+func (c *AlbumController) GetAlbumShelves(ctx *gin.Context) {
+	limit := func(name string) (int, bool) {
+		value := ctx.Query(name)
+		if value == "" {
+			return scene_audio_route_models.AlbumShelfDefaultLimit, true
+		}
+		n, err := strconv.Atoi(value)
+		return n, err == nil
+	}
+
+	var limits scene_audio_route_models.AlbumShelfLimits
+	for name, target := range map[string]*int{
+		"recently_added_limit":  &limits.RecentlyAdded,
+		"recently_played_limit": &limits.RecentlyPlayed,
+		"most_played_limit":     &limits.MostPlayed,
+		"random_limit":          &limits.Random,
+		"top_rated_limit":       &limits.TopRated,
+	} {
+		n, ok := limit(name)
+		if !ok {
+			controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", name+"必须为整数")
+			return
+		}
+		*target = n
+	}
+
+	shelves, err := c.AlbumUsecase.GetAlbumShelves(ctx.Request.Context(), limits)
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+		return
+	}
+
+	for _, shelf := range [][]scene_audio_route_models.AlbumMetadata{
+		shelves.RecentlyAdded, shelves.RecentlyPlayed, shelves.MostPlayed, shelves.Random, shelves.TopRated,
+	} {
+		c.Links.FillAlbumLinks(ctx, shelf)
+	}
+	controller.SuccessResponse(ctx, "shelves", shelves, 1)
+}
+
+func (c *AlbumController) GetAlbumTracks(ctx *gin.Context) {
+	result, err := c.AlbumUsecase.GetAlbumTracks(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		if domain.IsNotFound(err) {
+			controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", "album not found")
+			return
+		}
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+		return
+	}
+
+	album := []scene_audio_route_models.AlbumMetadata{result.Album}
+	c.Links.FillAlbumLinks(ctx, album)
+	result.Album = album[0]
+	trackCount := 0
+	for _, disc := range result.Discs {
+		c.Links.FillMediaFileLinks(ctx, disc.Tracks)
+		trackCount += len(disc.Tracks)
+	}
+	controller.SuccessResponse(ctx, "album_tracks", result, trackCount)
+}
+
+func (c *AlbumController) GetAlbumDetail(ctx *gin.Context) {
+	result, err := c.AlbumUsecase.GetAlbumDetail(ctx.Request.Context(), ctx.Param("id"), ctx.GetString("x-user-id"))
+	if err != nil {
+		if domain.IsNotFound(err) {
+			controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", "album not found")
+			return
+		}
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+		return
+	}
+
+	album := []scene_audio_route_models.AlbumMetadata{result.Album}
+	c.Links.FillAlbumLinks(ctx, album)
+	result.Album = album[0]
+	for _, disc := range result.Discs {
+		c.Links.FillMediaFileLinks(ctx, disc.Tracks)
+	}
+	c.Links.FillAlbumLinks(ctx, result.SimilarAlbums)
+	c.Links.FillAlbumLinks(ctx, result.Versions)
+	controller.SuccessResponse(ctx, "album_detail", result, result.TrackCount)
+}
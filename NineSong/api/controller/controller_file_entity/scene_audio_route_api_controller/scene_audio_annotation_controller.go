@@ -1,10 +1,13 @@
 package scene_audio_route_api_controller
 
 import (
+	"errors"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"net/http"
 )
 
@@ -18,7 +21,16 @@ func NewAnnotationController(uc scene_audio_route_interface.AnnotationRepository
 
 type BaseAnnotationRequest struct {
 	ItemID   string `form:"item_id" binding:"required"`
-	ItemType string `form:"item_type" binding:"required,oneof=artist album media"`
+	ItemType string `form:"item_type" binding:"required"`
+}
+
+// validItemType item_type 须为已登记的注解条目类型
+func validItemType(ctx *gin.Context, itemType string) bool {
+	if _, err := scene_audio_route_models.ParseAnnotationItemType(itemType); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
+		return false
+	}
+	return true
 }
 
 type UpdateRatingRequest struct {
@@ -26,12 +38,78 @@ type UpdateRatingRequest struct {
 	Rating int `form:"rating" binding:"required,min=0,max=5"`
 }
 
+type SetAnnotationRequest struct {
+	BaseAnnotationRequest
+	Starred  *bool `form:"starred" json:"starred"`
+	Rating   *int  `form:"rating" json:"rating" binding:"omitempty,min=0,max=5"`
+	Played   bool  `form:"played" json:"played"`
+	Complete bool  `form:"complete" json:"complete"`
+}
+
+// GetAnnotation 读取任意已登记条目类型的注解
+func (c *AnnotationController) GetAnnotation(ctx *gin.Context) {
+	var req BaseAnnotationRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
+		return
+	}
+	if !validItemType(ctx, req.ItemType) {
+		return
+	}
+
+	annotation, err := c.usecase.GetAnnotation(ctx.Request.Context(), req.ItemID, scene_audio_route_models.AnnotationItemType(req.ItemType))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", err.Error())
+			return
+		}
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "QUERY_FAILED", err.Error())
+		return
+	}
+
+	controller.SuccessResponse(ctx, "annotation", annotation, 1)
+}
+
+// SetAnnotation 一次写入收藏、评分与播放记录，未传的字段保持不变
+func (c *AnnotationController) SetAnnotation(ctx *gin.Context) {
+	var req SetAnnotationRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
+		return
+	}
+	if !validItemType(ctx, req.ItemType) {
+		return
+	}
+
+	annotation, err := c.usecase.SetAnnotation(ctx.Request.Context(), req.ItemID,
+		scene_audio_route_models.AnnotationItemType(req.ItemType),
+		scene_audio_route_models.AnnotationUpdate{
+			Starred:  req.Starred,
+			Rating:   req.Rating,
+			Played:   req.Played,
+			Complete: req.Complete,
+		})
+	if err != nil {
+		if errors.Is(err, scene_audio_route_models.ErrAnnotationItemNotFound) {
+			controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", err.Error())
+			return
+		}
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	controller.SuccessResponse(ctx, "annotation", annotation, 1)
+}
+
 func (c *AnnotationController) UpdateStarred(ctx *gin.Context) {
 	var req BaseAnnotationRequest
 	if err := ctx.ShouldBind(&req); err != nil {
 		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
 		return
 	}
+	if !validItemType(ctx, req.ItemType) {
+		return
+	}
 
 	result, err := c.usecase.UpdateStarred(ctx, req.ItemID, req.ItemType)
 	if err != nil {
@@ -48,6 +126,9 @@ func (c *AnnotationController) UpdateUnStarred(ctx *gin.Context) {
 		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
 		return
 	}
+	if !validItemType(ctx, req.ItemType) {
+		return
+	}
 
 	result, err := c.usecase.UpdateUnStarred(ctx, req.ItemID, req.ItemType)
 	if err != nil {
@@ -58,12 +139,49 @@ func (c *AnnotationController) UpdateUnStarred(ctx *gin.Context) {
 	controller.SuccessResponse(ctx, "result", result, 1)
 }
 
+// StarItem 返回按路径参数收藏指定类型条目的处理函数
+func (c *AnnotationController) StarItem(itemType scene_audio_route_models.AnnotationItemType) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		c.setStarred(ctx, itemType, true)
+	}
+}
+
+// UnstarItem 返回按路径参数取消收藏指定类型条目的处理函数
+func (c *AnnotationController) UnstarItem(itemType scene_audio_route_models.AnnotationItemType) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		c.setStarred(ctx, itemType, false)
+	}
+}
+
+func (c *AnnotationController) setStarred(ctx *gin.Context, itemType scene_audio_route_models.AnnotationItemType, starred bool) {
+	itemID := ctx.Param("id")
+	if _, err := primitive.ObjectIDFromHex(itemID); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", "invalid item id format")
+		return
+	}
+
+	annotation, err := c.usecase.SetStarred(ctx.Request.Context(), itemID, string(itemType), starred)
+	if err != nil {
+		if errors.Is(err, scene_audio_route_models.ErrAnnotationItemNotFound) {
+			controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", err.Error())
+			return
+		}
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	controller.SuccessResponse(ctx, "annotation", annotation, 1)
+}
+
 func (c *AnnotationController) UpdateRating(ctx *gin.Context) {
 	var req UpdateRatingRequest
 	if err := ctx.ShouldBind(&req); err != nil {
 		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
 		return
 	}
+	if !validItemType(ctx, req.ItemType) {
+		return
+	}
 
 	result, err := c.usecase.UpdateRating(ctx, req.ItemID, req.ItemType, req.Rating)
 	if err != nil {
@@ -74,12 +192,46 @@ func (c *AnnotationController) UpdateRating(ctx *gin.Context) {
 	controller.SuccessResponse(ctx, "result", result, 1)
 }
 
+type RateMediaFileRequest struct {
+	// 指针区分未传与 0 分（清除评分）
+	Rating *int `form:"rating" json:"rating" binding:"required,min=0,max=5"`
+}
+
+// RateMediaFile 为曲目评分，并返回重新计算的专辑与艺术家平均分
+func (c *AnnotationController) RateMediaFile(ctx *gin.Context) {
+	mediaFileID := ctx.Param("id")
+	if _, err := primitive.ObjectIDFromHex(mediaFileID); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", "invalid item id format")
+		return
+	}
+	var req RateMediaFileRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", "rating must be an integer between 0-5")
+		return
+	}
+
+	result, err := c.usecase.RateMediaFile(ctx.Request.Context(), mediaFileID, *req.Rating)
+	if err != nil {
+		if errors.Is(err, scene_audio_route_models.ErrAnnotationItemNotFound) {
+			controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", err.Error())
+			return
+		}
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "UPDATE_FAILED", err.Error())
+		return
+	}
+
+	controller.SuccessResponse(ctx, "rating", result, 1)
+}
+
 func (c *AnnotationController) UpdateScrobble(ctx *gin.Context) {
 	var req BaseAnnotationRequest
 	if err := ctx.ShouldBind(&req); err != nil {
 		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
 		return
 	}
+	if !validItemType(ctx, req.ItemType) {
+		return
+	}
 
 	result, err := c.usecase.UpdateScrobble(ctx, req.ItemID, req.ItemType)
 	if err != nil {
@@ -96,6 +248,9 @@ func (c *AnnotationController) UpdateCompleteScrobble(ctx *gin.Context) {
 		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
 		return
 	}
+	if !validItemType(ctx, req.ItemType) {
+		return
+	}
 
 	result, err := c.usecase.UpdateCompleteScrobble(ctx, req.ItemID, req.ItemType)
 	if err != nil {
@@ -108,13 +263,13 @@ func (c *AnnotationController) UpdateCompleteScrobble(ctx *gin.Context) {
 
 type UpdateTagSourceRequest struct {
 	ItemID   string                               `json:"item_id" form:"item_id" binding:"required"`
-	ItemType string                               `json:"item_type" form:"item_type" binding:"required,oneof=artist album media"`
+	ItemType string                               `json:"item_type" form:"item_type" binding:"required"`
 	Tags     []scene_audio_route_models.TagSource `json:"tags" binding:"required"`
 }
 
 type UpdateWeightedTagRequest struct {
 	ItemID   string                                 `json:"item_id" form:"item_id" binding:"required"`
-	ItemType string                                 `json:"item_type" form:"item_type" binding:"required,oneof=artist album media"`
+	ItemType string                                 `json:"item_type" form:"item_type" binding:"required"`
 	Tags     []scene_audio_route_models.WeightedTag `json:"tags" binding:"required"`
 }
 
@@ -124,6 +279,9 @@ func (c *AnnotationController) UpdateTagSource(ctx *gin.Context) {
 		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
 		return
 	}
+	if !validItemType(ctx, req.ItemType) {
+		return
+	}
 
 	result, err := c.usecase.UpdateTagSource(ctx, req.ItemID, req.ItemType, req.Tags)
 	if err != nil {
@@ -140,6 +298,9 @@ func (c *AnnotationController) UpdateWeightedTag(ctx *gin.Context) {
 		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
 		return
 	}
+	if !validItemType(ctx, req.ItemType) {
+		return
+	}
 
 	result, err := c.usecase.UpdateWeightedTag(ctx, req.ItemID, req.ItemType, req.Tags)
 	if err != nil {
@@ -0,0 +1,114 @@
+package scene_audio_route_api_controller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/gin-gonic/gin"
+)
+
+type ArtistAliasController struct {
+	ArtistAliasUsecase scene_audio_route_interface.ArtistAliasRepository
+}
+
+func NewArtistAliasController(uc scene_audio_route_interface.ArtistAliasRepository) *ArtistAliasController {
+	return &ArtistAliasController{ArtistAliasUsecase: uc}
+}
+
+func (c *ArtistAliasController) GetArtistAliases(ctx *gin.Context) {
+	aliases, err := c.ArtistAliasUsecase.GetArtistAliases(ctx.Request.Context(), ctx.Query("artist_id"))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "aliases", aliases, len(aliases))
+}
+
+func (c *ArtistAliasController) AddArtistAlias(ctx *gin.Context) {
+	var req struct {
+		ArtistID string `form:"artist_id" binding:"required"`
+		Alias    string `form:"alias" binding:"required"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+
+	aliases, err := c.ArtistAliasUsecase.AddArtistAlias(ctx.Request.Context(), req.ArtistID, scene_audio_route_models.ArtistAlias{
+		Name:   req.Alias,
+		Source: scene_audio_route_models.ArtistAliasSourceManual,
+	})
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "aliases", aliases, len(aliases))
+}
+
+func (c *ArtistAliasController) RemoveArtistAlias(ctx *gin.Context) {
+	var req struct {
+		ArtistID string `form:"artist_id" binding:"required"`
+		Alias    string `form:"alias" binding:"required"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+
+	aliases, err := c.ArtistAliasUsecase.RemoveArtistAlias(ctx.Request.Context(), req.ArtistID, req.Alias)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "aliases", aliases, len(aliases))
+}
+
+func (c *ArtistAliasController) SyncMusicBrainzAliases(ctx *gin.Context) {
+	var req struct {
+		ArtistID string `form:"artist_id" binding:"required"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+
+	aliases, err := c.ArtistAliasUsecase.SyncMusicBrainzAliases(ctx.Request.Context(), req.ArtistID)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "aliases", aliases, len(aliases))
+}
+
+func (c *ArtistAliasController) MergeArtists(ctx *gin.Context) {
+	var req struct {
+		SourceID string `form:"source_id" binding:"required"`
+		TargetID string `form:"target_id" binding:"required"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+
+	result, err := c.ArtistAliasUsecase.MergeArtists(ctx.Request.Context(), req.SourceID, req.TargetID)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "merge", result, 1)
+}
+
+func (c *ArtistAliasController) handleError(ctx *gin.Context, err error) {
+	switch {
+	case domain.IsNotFound(err):
+		controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", err.Error())
+	case strings.Contains(err.Error(), scene_audio_route_models.ErrArtistAliasConflict.Error()):
+		controller.ErrorResponse(ctx, http.StatusConflict, "ALIAS_CONFLICT", err.Error())
+	default:
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+	}
+}
@@ -32,6 +32,10 @@ func (c *ArtistController) GetArtists(ctx *gin.Context) {
 		Starred: ctx.Query("starred"),
 	}
 
+	if !controller.CheckListParams(ctx, true, params.Start, params.End, params.Starred) {
+		return
+	}
+
 	artists, err := c.ArtistUsecase.GetArtistItems(
 		ctx.Request.Context(),
 		params.Start,
@@ -59,6 +63,10 @@ func (c *ArtistController) GetArtistFilterCounts(ctx *gin.Context) {
 		Starred: ctx.Query("starred"),
 	}
 
+	if !controller.CheckListParams(ctx, false, "", "", params.Starred) {
+		return
+	}
+
 	counts, err := c.ArtistUsecase.GetArtistFilterItemsCount(
 		ctx.Request.Context(),
 		params.Search,
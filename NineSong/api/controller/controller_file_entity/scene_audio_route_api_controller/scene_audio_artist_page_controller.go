@@ -0,0 +1,48 @@
+package scene_audio_route_api_controller
+
+import (
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/gin-gonic/gin"
+)
+
+type ArtistPageController struct {
+	ArtistPageUsecase scene_audio_route_interface.ArtistPageUsecase
+	Links             LinkBuilder
+}
+
+func NewArtistPageController(uc scene_audio_route_interface.ArtistPageUsecase, links LinkBuilder) *ArtistPageController {
+	return &ArtistPageController{ArtistPageUsecase: uc, Links: links}
+}
+
+// GetArtistPage 合并艺术家页所需的四次请求，减少移动网络下的往返
+func (c *ArtistPageController) GetArtistPage(ctx *gin.Context) {
+	var req struct {
+		Albums   int `form:"albums"`
+		TopSongs int `form:"top_songs"`
+		Similar  int `form:"similar"`
+	}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
+		return
+	}
+
+	page, err := c.ArtistPageUsecase.GetArtistPage(ctx.Request.Context(), ctx.Param("id"),
+		scene_audio_route_models.ArtistPageLimits{Albums: req.Albums, TopSongs: req.TopSongs, Similar: req.Similar})
+	if err != nil {
+		if domain.IsNotFound(err) {
+			controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", "artist not found")
+			return
+		}
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+		return
+	}
+
+	c.Links.FillAlbumLinks(ctx, page.Albums)
+	c.Links.FillMediaFileLinks(ctx, page.TopSongs)
+	controller.SuccessResponse(ctx, "artist_page", page, 1)
+}
@@ -0,0 +1,96 @@
+package scene_audio_route_api_controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/gin-gonic/gin"
+)
+
+type AudiobookController struct {
+	AudiobookUsecase scene_audio_route_interface.AudiobookUsecase
+}
+
+func NewAudiobookController(uc scene_audio_route_interface.AudiobookUsecase) *AudiobookController {
+	return &AudiobookController{AudiobookUsecase: uc}
+}
+
+// GetAudiobooks sort 默认为 reading（作者、年份、书名），recent 为最近收听；start/end 无效时返回全部
+func (c *AudiobookController) GetAudiobooks(ctx *gin.Context) {
+	var start, count int
+	startVal, startErr := strconv.Atoi(ctx.Query("start"))
+	endVal, endErr := strconv.Atoi(ctx.Query("end"))
+	if startErr == nil && endErr == nil && startVal >= 0 && endVal > startVal {
+		start, count = startVal, endVal-startVal
+	}
+
+	books, total, err := c.AudiobookUsecase.GetAudiobooks(ctx.Request.Context(), ctx.GetString(domain.UserIDKey), ctx.Query("sort"), start, count)
+	if err != nil {
+		audiobookError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "audiobooks", books, total)
+}
+
+// GetAudiobook 返回按阅读顺序排列的曲目与内嵌章节，客户端按 progress 续播
+func (c *AudiobookController) GetAudiobook(ctx *gin.Context) {
+	book, err := c.AudiobookUsecase.GetAudiobook(ctx.Request.Context(), ctx.GetString(domain.UserIDKey), ctx.Param("id"))
+	if err != nil {
+		audiobookError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "audiobook", book, 1)
+}
+
+// SaveProgress position 为 media_file_id 对应文件内的秒数
+func (c *AudiobookController) SaveProgress(ctx *gin.Context) {
+	var req struct {
+		MediaFileID string  `form:"media_file_id" binding:"required"`
+		Position    float64 `form:"position"`
+		Finished    bool    `form:"finished"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+
+	progress, err := c.AudiobookUsecase.SaveProgress(
+		ctx.Request.Context(), ctx.GetString(domain.UserIDKey), ctx.Param("id"),
+		req.MediaFileID, req.Position, req.Finished,
+	)
+	if err != nil {
+		audiobookError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "progress", progress, 1)
+}
+
+func (c *AudiobookController) DeleteProgress(ctx *gin.Context) {
+	deleted, err := c.AudiobookUsecase.DeleteProgress(ctx.Request.Context(), ctx.GetString(domain.UserIDKey), ctx.Param("id"))
+	if err != nil {
+		audiobookError(ctx, err)
+		return
+	}
+	if !deleted {
+		controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", "audiobook progress not found")
+		return
+	}
+	controller.SuccessResponse(ctx, "deleted", true, 1)
+}
+
+func audiobookError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, scene_audio_route_models.ErrInvalidAudiobook),
+		errors.Is(err, scene_audio_route_models.ErrAudiobookTrackMismatch):
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
+	case domain.IsNotFound(err):
+		controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", err.Error())
+	default:
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+	}
+}
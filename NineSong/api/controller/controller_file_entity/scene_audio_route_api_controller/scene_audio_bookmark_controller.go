@@ -0,0 +1,94 @@
+package scene_audio_route_api_controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/gin-gonic/gin"
+)
+
+type BookmarkController struct {
+	BookmarkUsecase scene_audio_route_interface.BookmarkUsecase
+}
+
+func NewBookmarkController(uc scene_audio_route_interface.BookmarkUsecase) *BookmarkController {
+	return &BookmarkController{BookmarkUsecase: uc}
+}
+
+// GetBookmarks item_type 为 media 或 podcast_episode 时只返回该类条目；start/end 无效时返回全部
+func (c *BookmarkController) GetBookmarks(ctx *gin.Context) {
+	var start, count int
+	startVal, startErr := strconv.Atoi(ctx.Query("start"))
+	endVal, endErr := strconv.Atoi(ctx.Query("end"))
+	if startErr == nil && endErr == nil && startVal >= 0 && endVal > startVal {
+		start, count = startVal, endVal-startVal
+	}
+
+	bookmarks, total, err := c.BookmarkUsecase.GetBookmarks(ctx.Request.Context(), ctx.GetString(domain.UserIDKey), ctx.Query("item_type"), start, count)
+	if err != nil {
+		bookmarkError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "bookmarks", bookmarks, total)
+}
+
+func (c *BookmarkController) GetBookmark(ctx *gin.Context) {
+	bookmark, err := c.BookmarkUsecase.GetBookmark(ctx.Request.Context(), ctx.GetString(domain.UserIDKey), ctx.Param("item_id"))
+	if err != nil {
+		bookmarkError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "bookmark", bookmark, 1)
+}
+
+// SaveBookmark 创建或覆盖书签，item_type 默认为 media，position 为条目内的秒数
+func (c *BookmarkController) SaveBookmark(ctx *gin.Context) {
+	var req struct {
+		ItemType string  `form:"item_type"`
+		Position float64 `form:"position"`
+		Comment  string  `form:"comment"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+
+	bookmark, err := c.BookmarkUsecase.SaveBookmark(
+		ctx.Request.Context(), ctx.GetString(domain.UserIDKey),
+		req.ItemType, ctx.Param("item_id"), req.Position, req.Comment,
+	)
+	if err != nil {
+		bookmarkError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "bookmark", bookmark, 1)
+}
+
+func (c *BookmarkController) DeleteBookmark(ctx *gin.Context) {
+	deleted, err := c.BookmarkUsecase.DeleteBookmark(ctx.Request.Context(), ctx.GetString(domain.UserIDKey), ctx.Param("item_id"))
+	if err != nil {
+		bookmarkError(ctx, err)
+		return
+	}
+	if !deleted {
+		controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", "bookmark not found")
+		return
+	}
+	controller.SuccessResponse(ctx, "deleted", true, 1)
+}
+
+func bookmarkError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, scene_audio_route_models.ErrInvalidBookmark):
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
+	case domain.IsNotFound(err):
+		controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", err.Error())
+	default:
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+	}
+}
@@ -0,0 +1,125 @@
+package scene_audio_route_api_controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cast_util"
+	"github.com/gin-gonic/gin"
+)
+
+type CastController struct {
+	CastUsecase scene_audio_route_interface.CastUsecase
+}
+
+func NewCastController(uc scene_audio_route_interface.CastUsecase) *CastController {
+	return &CastController{CastUsecase: uc}
+}
+
+// GetDevices refresh=true 时重新搜索局域网，否则使用缓存的设备列表
+func (c *CastController) GetDevices(ctx *gin.Context) {
+	var req struct {
+		Refresh bool `form:"refresh"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+	devices, err := c.CastUsecase.Devices(ctx.Request.Context(), req.Refresh)
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "DISCOVERY_FAILED", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "devices", devices, len(devices))
+}
+
+func (c *CastController) GetStatus(ctx *gin.Context) {
+	status, err := c.CastUsecase.Status(ctx.Request.Context(), ctx.Param("device_id"))
+	castResponse(ctx, status, err)
+}
+
+// Load format 为空时原格式直接推送，设备不支持的格式自动转为 MP3
+func (c *CastController) Load(ctx *gin.Context) {
+	var req struct {
+		MediaFileID string  `form:"media_file_id" binding:"required"`
+		Format      string  `form:"format"`
+		MaxBitRate  int     `form:"maxBitRate"`
+		Position    float64 `form:"position"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+	status, err := c.CastUsecase.Load(ctx.Request.Context(), ctx.GetString(domain.UserIDKey), ctx.Param("device_id"), req.MediaFileID,
+		scene_audio_route_models.CastLoadOptions{
+			Format:     req.Format,
+			MaxBitRate: req.MaxBitRate,
+			Position:   req.Position,
+		})
+	castResponse(ctx, status, err)
+}
+
+func (c *CastController) Play(ctx *gin.Context) {
+	status, err := c.CastUsecase.Play(ctx.Request.Context(), ctx.Param("device_id"))
+	castResponse(ctx, status, err)
+}
+
+func (c *CastController) Pause(ctx *gin.Context) {
+	status, err := c.CastUsecase.Pause(ctx.Request.Context(), ctx.Param("device_id"))
+	castResponse(ctx, status, err)
+}
+
+func (c *CastController) Seek(ctx *gin.Context) {
+	var req struct {
+		Position float64 `form:"position"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+	status, err := c.CastUsecase.Seek(ctx.Request.Context(), ctx.Param("device_id"), req.Position)
+	castResponse(ctx, status, err)
+}
+
+// SetVolume volume 与 muted 至少传一个
+func (c *CastController) SetVolume(ctx *gin.Context) {
+	var req struct {
+		Volume *int  `form:"volume"`
+		Muted  *bool `form:"muted"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+	status, err := c.CastUsecase.SetVolume(ctx.Request.Context(), ctx.Param("device_id"), req.Volume, req.Muted)
+	castResponse(ctx, status, err)
+}
+
+func (c *CastController) Stop(ctx *gin.Context) {
+	if err := c.CastUsecase.Stop(ctx.Request.Context(), ctx.Param("device_id")); err != nil {
+		castResponse(ctx, nil, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "stopped", true, 1)
+}
+
+func castResponse(ctx *gin.Context, status *scene_audio_route_models.CastStatus, err error) {
+	switch {
+	case err == nil:
+		controller.SuccessResponse(ctx, "cast", status, 1)
+	case errors.Is(err, scene_audio_route_models.ErrInvalidCastParams):
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
+	case errors.Is(err, scene_audio_route_models.ErrCastDeviceNotFound),
+		errors.Is(err, scene_audio_route_models.ErrCastNoSession),
+		domain.IsNotFound(err):
+		controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", err.Error())
+	case errors.Is(err, cast_util.ErrDisconnected):
+		controller.ErrorResponse(ctx, http.StatusBadGateway, "DEVICE_UNREACHABLE", err.Error())
+	default:
+		controller.ErrorResponse(ctx, http.StatusBadGateway, "CAST_FAILED", err.Error())
+	}
+}
@@ -0,0 +1,38 @@
+package scene_audio_route_api_controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/gin-gonic/gin"
+)
+
+type ChangesController struct {
+	ChangesUsecase scene_audio_route_interface.ChangesUsecase
+}
+
+func NewChangesController(uc scene_audio_route_interface.ChangesUsecase) *ChangesController {
+	return &ChangesController{ChangesUsecase: uc}
+}
+
+// GetChanges 返回自 since 令牌以来新增、更新、删除的专辑、艺术家、单曲与播放列表ID，has_more 为 true 时需以返回的令牌继续翻页
+func (c *ChangesController) GetChanges(ctx *gin.Context) {
+	feed, err := c.ChangesUsecase.GetChanges(ctx.Request.Context(), ctx.Query("since"))
+	if err != nil {
+		if errors.Is(err, scene_audio_route_models.ErrInvalidSyncToken) {
+			controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_SYNC_TOKEN", err.Error())
+			return
+		}
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+		return
+	}
+
+	count := 0
+	for _, changes := range feed.Changes {
+		count += len(changes.Created) + len(changes.Updated) + len(changes.Deleted)
+	}
+	controller.SuccessResponse(ctx, "changes", feed, count)
+}
@@ -0,0 +1,99 @@
+package scene_audio_route_api_controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/gin-gonic/gin"
+)
+
+type ChartController struct {
+	ChartUsecase scene_audio_route_interface.ChartUsecase
+	Links        LinkBuilder
+}
+
+func NewChartController(uc scene_audio_route_interface.ChartUsecase, links LinkBuilder) *ChartController {
+	return &ChartController{ChartUsecase: uc, Links: links}
+}
+
+type chartRequest struct {
+	Window string `form:"window"`
+	Limit  int    `form:"limit"`
+}
+
+func (c *ChartController) bind(ctx *gin.Context) (chartRequest, bool) {
+	var req chartRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
+		return req, false
+	}
+	return req, true
+}
+
+func (c *ChartController) GetTopMediaFiles(ctx *gin.Context) {
+	req, ok := c.bind(ctx)
+	if !ok {
+		return
+	}
+	result, err := c.ChartUsecase.GetTopMediaFiles(ctx.Request.Context(), req.Window, req.Limit)
+	if err != nil {
+		chartError(ctx, err)
+		return
+	}
+
+	mediaFiles := make([]scene_audio_route_models.MediaFileMetadata, 0, len(result))
+	for _, item := range result {
+		mediaFiles = append(mediaFiles, item.MediaFile)
+	}
+	c.Links.FillMediaFileLinks(ctx, mediaFiles)
+	for i := range result {
+		result[i].MediaFile = mediaFiles[i]
+	}
+	controller.SuccessResponse(ctx, "tracks", result, len(result))
+}
+
+func (c *ChartController) GetTopAlbums(ctx *gin.Context) {
+	req, ok := c.bind(ctx)
+	if !ok {
+		return
+	}
+	result, err := c.ChartUsecase.GetTopAlbums(ctx.Request.Context(), req.Window, req.Limit)
+	if err != nil {
+		chartError(ctx, err)
+		return
+	}
+
+	albums := make([]scene_audio_route_models.AlbumMetadata, 0, len(result))
+	for _, item := range result {
+		albums = append(albums, item.Album)
+	}
+	c.Links.FillAlbumLinks(ctx, albums)
+	for i := range result {
+		result[i].Album = albums[i]
+	}
+	controller.SuccessResponse(ctx, "albums", result, len(result))
+}
+
+func (c *ChartController) GetTopArtists(ctx *gin.Context) {
+	req, ok := c.bind(ctx)
+	if !ok {
+		return
+	}
+	result, err := c.ChartUsecase.GetTopArtists(ctx.Request.Context(), req.Window, req.Limit)
+	if err != nil {
+		chartError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "artists", result, len(result))
+}
+
+func chartError(ctx *gin.Context, err error) {
+	if errors.Is(err, scene_audio_route_models.ErrInvalidChartWindow) {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
+		return
+	}
+	controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+}
@@ -0,0 +1,27 @@
+package scene_audio_route_api_controller
+
+import (
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/gin-gonic/gin"
+)
+
+type DailyMixController struct {
+	DailyMixUsecase scene_audio_route_interface.DailyMixUsecase
+}
+
+func NewDailyMixController(uc scene_audio_route_interface.DailyMixUsecase) *DailyMixController {
+	return &DailyMixController{DailyMixUsecase: uc}
+}
+
+// GetMixes 当前用户最近一次生成的每日推荐歌单及曲目
+func (c *DailyMixController) GetMixes(ctx *gin.Context) {
+	mixes, err := c.DailyMixUsecase.GetMixes(ctx.Request.Context(), ctx.GetString("x-user-id"))
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "mixes", mixes, len(mixes))
+}
@@ -0,0 +1,37 @@
+package scene_audio_route_api_controller
+
+import (
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/gin-gonic/gin"
+)
+
+type DiscoverController struct {
+	DiscoverUsecase scene_audio_route_interface.DiscoverUsecase
+}
+
+func NewDiscoverController(uc scene_audio_route_interface.DiscoverUsecase) *DiscoverController {
+	return &DiscoverController{DiscoverUsecase: uc}
+}
+
+// GetForgotten 常听或已收藏、但最近 months 个月（默认 6）未播放的曲目与专辑
+func (c *DiscoverController) GetForgotten(ctx *gin.Context) {
+	var req struct {
+		Months   int `form:"months"`
+		MinPlays int `form:"min_plays"`
+		Limit    int `form:"limit"`
+	}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
+		return
+	}
+
+	result, err := c.DiscoverUsecase.GetForgotten(ctx.Request.Context(), ctx.GetString("x-user-id"), req.Months, req.MinPlays, req.Limit)
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "forgotten", result, len(result.MediaFiles)+len(result.Albums))
+}
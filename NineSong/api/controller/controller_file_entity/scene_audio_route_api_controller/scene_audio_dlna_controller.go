@@ -0,0 +1,210 @@
+package scene_audio_route_api_controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/dlna_util"
+	"github.com/gin-gonic/gin"
+)
+
+const dlnaXMLContentType = `text/xml; charset="utf-8"`
+
+// dlnaSourceProtocols GetProtocolInfo 声明的可提供格式
+var dlnaSourceProtocols = []string{"mp3", "flac", "m4a", "aac", "ogg", "opus", "wav", "aiff", "wma", "ape", "dsf"}
+
+type DLNAController struct {
+	DLNAUsecase  scene_audio_route_interface.DLNAUsecase
+	uuid         string
+	friendlyName string
+}
+
+func NewDLNAController(uc scene_audio_route_interface.DLNAUsecase, uuid, friendlyName string) *DLNAController {
+	return &DLNAController{DLNAUsecase: uc, uuid: uuid, friendlyName: friendlyName}
+}
+
+func (c *DLNAController) DeviceDescription(ctx *gin.Context) {
+	ctx.Data(http.StatusOK, dlnaXMLContentType, dlna_util.DeviceDescription(c.uuid, c.friendlyName))
+}
+
+func (c *DLNAController) ContentDirectorySCPD(ctx *gin.Context) {
+	ctx.Data(http.StatusOK, dlnaXMLContentType, []byte(dlna_util.ContentDirectorySCPD))
+}
+
+func (c *DLNAController) ConnectionManagerSCPD(ctx *gin.Context) {
+	ctx.Data(http.StatusOK, dlnaXMLContentType, []byte(dlna_util.ConnectionManagerSCPD))
+}
+
+func (c *DLNAController) ContentDirectoryControl(ctx *gin.Context) {
+	action, args, err := dlna_util.ParseSOAPAction(ctx.GetHeader("SOAPACTION"), ctx.Request.Body)
+	if err != nil {
+		dlnaFault(ctx, dlna_util.ErrCodeInvalidAction, err.Error())
+		return
+	}
+
+	switch action {
+	case "Browse":
+		c.browse(ctx, args)
+	case "GetSystemUpdateID":
+		updateID, err := c.DLNAUsecase.GetSystemUpdateID(ctx.Request.Context())
+		if err != nil {
+			dlnaError(ctx, err)
+			return
+		}
+		dlnaRespond(ctx, dlna_util.ContentDirectoryService, action, dlna_util.Arg{Name: "Id", Value: strconv.FormatUint(uint64(updateID), 10)})
+	case "GetSearchCapabilities":
+		dlnaRespond(ctx, dlna_util.ContentDirectoryService, action, dlna_util.Arg{Name: "SearchCaps"})
+	case "GetSortCapabilities":
+		dlnaRespond(ctx, dlna_util.ContentDirectoryService, action, dlna_util.Arg{Name: "SortCaps"})
+	default:
+		dlnaFault(ctx, dlna_util.ErrCodeInvalidAction, "Invalid Action")
+	}
+}
+
+func (c *DLNAController) browse(ctx *gin.Context, args map[string]string) {
+	var children bool
+	switch args["BrowseFlag"] {
+	case "BrowseMetadata":
+	case "BrowseDirectChildren":
+		children = true
+	default:
+		dlnaFault(ctx, dlna_util.ErrCodeInvalidArgs, "Invalid Args")
+		return
+	}
+	start, err1 := strconv.Atoi(strings.TrimSpace(args["StartingIndex"]))
+	count, err2 := strconv.Atoi(strings.TrimSpace(args["RequestedCount"]))
+	if err1 != nil || err2 != nil {
+		dlnaFault(ctx, dlna_util.ErrCodeInvalidArgs, "Invalid Args")
+		return
+	}
+
+	result, err := c.DLNAUsecase.Browse(ctx.Request.Context(), args["ObjectID"], children, start, count)
+	if err != nil {
+		dlnaError(ctx, err)
+		return
+	}
+	dlnaRespond(ctx, dlna_util.ContentDirectoryService, "Browse",
+		dlna_util.Arg{Name: "Result", Value: dlna_util.DIDL(result.Objects, "http://"+ctx.Request.Host)},
+		dlna_util.Arg{Name: "NumberReturned", Value: strconv.Itoa(len(result.Objects))},
+		dlna_util.Arg{Name: "TotalMatches", Value: strconv.Itoa(result.TotalMatches)},
+		dlna_util.Arg{Name: "UpdateID", Value: strconv.FormatUint(uint64(result.UpdateID), 10)},
+	)
+}
+
+func (c *DLNAController) ConnectionManagerControl(ctx *gin.Context) {
+	action, _, err := dlna_util.ParseSOAPAction(ctx.GetHeader("SOAPACTION"), ctx.Request.Body)
+	if err != nil {
+		dlnaFault(ctx, dlna_util.ErrCodeInvalidAction, err.Error())
+		return
+	}
+
+	switch action {
+	case "GetProtocolInfo":
+		source := make([]string, 0, len(dlnaSourceProtocols))
+		for _, suffix := range dlnaSourceProtocols {
+			source = append(source, "http-get:*:"+dlna_util.MimeType(suffix)+":*")
+		}
+		dlnaRespond(ctx, dlna_util.ConnectionManagerService, action,
+			dlna_util.Arg{Name: "Source", Value: strings.Join(source, ",")},
+			dlna_util.Arg{Name: "Sink"},
+		)
+	case "GetCurrentConnectionIDs":
+		dlnaRespond(ctx, dlna_util.ConnectionManagerService, action, dlna_util.Arg{Name: "ConnectionIDs", Value: "0"})
+	case "GetCurrentConnectionInfo":
+		dlnaRespond(ctx, dlna_util.ConnectionManagerService, action,
+			dlna_util.Arg{Name: "RcsID", Value: "-1"},
+			dlna_util.Arg{Name: "AVTransportID", Value: "-1"},
+			dlna_util.Arg{Name: "ProtocolInfo"},
+			dlna_util.Arg{Name: "PeerConnectionManager"},
+			dlna_util.Arg{Name: "PeerConnectionID", Value: "-1"},
+			dlna_util.Arg{Name: "Direction", Value: "Output"},
+			dlna_util.Arg{Name: "Status", Value: "OK"},
+		)
+	default:
+		dlnaFault(ctx, dlna_util.ErrCodeInvalidAction, "Invalid Action")
+	}
+}
+
+// Subscribe 接受事件订阅但不推送事件，部分电视订阅失败时拒绝浏览
+func (c *DLNAController) Subscribe(ctx *gin.Context) {
+	sid := ctx.GetHeader("SID")
+	if sid == "" {
+		sid = dlna_util.SubscriptionID()
+	}
+	ctx.Header("SID", sid)
+	ctx.Header("TIMEOUT", "Second-1800")
+	ctx.Status(http.StatusOK)
+}
+
+func (c *DLNAController) Unsubscribe(ctx *gin.Context) {
+	ctx.Status(http.StatusOK)
+}
+
+// Stream 原始文件直出，支持范围请求以便播放设备跳转
+func (c *DLNAController) Stream(ctx *gin.Context) {
+	path, err := c.DLNAUsecase.GetStreamPath(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		dlnaFileError(ctx, err)
+		return
+	}
+	if _, err := os.Stat(path); err != nil {
+		handleFileError(ctx, path, err)
+		return
+	}
+	suffix := filepath.Ext(path)
+	ctx.Header("Content-Type", dlna_util.MimeType(suffix))
+	ctx.Header("transferMode.dlna.org", "Streaming")
+	ctx.Header("contentFeatures.dlna.org", dlna_util.ContentFeatures(suffix))
+	ctx.File(path)
+}
+
+func (c *DLNAController) Cover(ctx *gin.Context) {
+	path, err := c.DLNAUsecase.GetCoverPath(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil || path == "" {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+	if _, err := os.Stat(path); err != nil {
+		handleFileError(ctx, path, err)
+		return
+	}
+	ctx.Header("Content-Type", detectContentType(path))
+	ctx.File(path)
+}
+
+func dlnaRespond(ctx *gin.Context, serviceType, action string, args ...dlna_util.Arg) {
+	ctx.Header("EXT", "")
+	ctx.Data(http.StatusOK, dlnaXMLContentType, dlna_util.SOAPResponse(serviceType, action, args...))
+}
+
+func dlnaFault(ctx *gin.Context, code int, description string) {
+	ctx.Data(http.StatusInternalServerError, dlnaXMLContentType, dlna_util.SOAPFault(code, description))
+}
+
+func dlnaError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, scene_audio_route_models.ErrDLNANoSuchObject):
+		dlnaFault(ctx, dlna_util.ErrCodeNoSuchObject, "No such object")
+	case errors.Is(err, scene_audio_route_models.ErrDLNAInvalidArgs):
+		dlnaFault(ctx, dlna_util.ErrCodeInvalidArgs, "Invalid Args")
+	default:
+		log.Printf("DLNA 请求处理失败: %v", err)
+		dlnaFault(ctx, dlna_util.ErrCodeActionFailed, "Action Failed")
+	}
+}
+
+func dlnaFileError(ctx *gin.Context, err error) {
+	if errors.Is(err, scene_audio_route_models.ErrDLNANoSuchObject) || domain.IsNotFound(err) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+	ctx.Status(http.StatusInternalServerError)
+}
@@ -0,0 +1,118 @@
+package scene_audio_route_api_controller
+
+import (
+	"archive/zip"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/gin-gonic/gin"
+)
+
+type DownloadController struct {
+	DownloadUsecase scene_audio_route_interface.DownloadUsecase
+}
+
+func NewDownloadController(uc scene_audio_route_interface.DownloadUsecase) *DownloadController {
+	return &DownloadController{DownloadUsecase: uc}
+}
+
+func (c *DownloadController) DownloadAlbum(ctx *gin.Context) {
+	archive, err := c.DownloadUsecase.GetAlbumArchive(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		downloadError(ctx, err)
+		return
+	}
+	streamZipArchive(ctx, archive)
+}
+
+func (c *DownloadController) DownloadPlaylist(ctx *gin.Context) {
+	archive, err := c.DownloadUsecase.GetPlaylistArchive(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		downloadError(ctx, err)
+		return
+	}
+	streamZipArchive(ctx, archive)
+}
+
+// DownloadTrack 返回原始文件，支持范围请求以便断点续传
+func (c *DownloadController) DownloadTrack(ctx *gin.Context) {
+	entry, err := c.DownloadUsecase.GetTrack(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		downloadError(ctx, err)
+		return
+	}
+	if _, err := os.Stat(entry.Path); err != nil {
+		handleFileError(ctx, entry.Path, err)
+		return
+	}
+	ctx.Header("Content-Type", detectContentType(entry.Path))
+	ctx.FileAttachment(entry.Path, entry.Name)
+}
+
+// streamZipArchive 边读边写 ZIP，不生成临时文件；音频本身已压缩，使用 Store 避免无谓的 CPU 开销。
+// 响应开始后无法再返回错误状态，读取失败的文件跳过并记录日志
+func streamZipArchive(ctx *gin.Context, archive *scene_audio_route_models.DownloadArchive) {
+	fileName := archive.Name + ".zip"
+	ctx.Header("Content-Type", "application/zip")
+	ctx.Header("Content-Disposition", `attachment; filename="download.zip"; filename*=UTF-8''`+url.PathEscape(fileName))
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Status(http.StatusOK)
+
+	zw := zip.NewWriter(ctx.Writer)
+	for _, entry := range archive.Entries {
+		if ctx.Request.Context().Err() != nil {
+			// 客户端已断开
+			return
+		}
+		if err := writeZipEntry(zw, entry); err != nil {
+			log.Printf("打包下载跳过文件 %s: %v", entry.Path, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		log.Printf("打包下载写入失败 %s: %v", fileName, err)
+	}
+}
+
+func writeZipEntry(zw *zip.Writer, entry scene_audio_route_models.DownloadEntry) error {
+	file, err := os.Open(entry.Path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	header.Name = entry.Name
+	header.Method = zip.Store
+	w, err := zw.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, file)
+	return err
+}
+
+func downloadError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, scene_audio_route_models.ErrInvalidDownloadID):
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
+	case domain.IsNotFound(err):
+		controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", err.Error())
+	default:
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+	}
+}
@@ -0,0 +1,109 @@
+package scene_audio_route_api_controller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/gin-gonic/gin"
+)
+
+type EqPresetController struct {
+	EqPresetUsecase scene_audio_route_interface.EqPresetRepository
+}
+
+func NewEqPresetController(uc scene_audio_route_interface.EqPresetRepository) *EqPresetController {
+	return &EqPresetController{EqPresetUsecase: uc}
+}
+
+func (c *EqPresetController) GetEqPresets(ctx *gin.Context) {
+	userID := ctx.GetString("x-user-id")
+
+	presets, err := c.EqPresetUsecase.GetEqPresets(ctx.Request.Context(), userID)
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "eq_presets", presets, len(presets))
+}
+
+func (c *EqPresetController) GetEqPreset(ctx *gin.Context) {
+	var req struct {
+		Name string `form:"name" binding:"required"`
+	}
+
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "MISSING_PARAMETER", "缺少必要参数: name")
+		return
+	}
+
+	preset, err := c.EqPresetUsecase.GetEqPreset(ctx.Request.Context(), ctx.GetString("x-user-id"), req.Name)
+	if err != nil {
+		if domain.IsNotFound(err) {
+			controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", err.Error())
+			return
+		}
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "eq_preset", preset, 1)
+}
+
+// SaveEqPreset bands参数为JSON数组，例如 [{"frequency":60,"width":1,"gain":3}]
+func (c *EqPresetController) SaveEqPreset(ctx *gin.Context) {
+	var req struct {
+		Name   string  `form:"name" binding:"required"`
+		Bands  string  `form:"bands" binding:"required"`
+		PreAmp float64 `form:"pre_amp"`
+	}
+
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+
+	var bands []scene_audio_route_models.EqBand
+	if err := json.Unmarshal([]byte(req.Bands), &bands); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMETERS", "bands格式错误: "+err.Error())
+		return
+	}
+
+	preset := scene_audio_route_models.EqPresetMetadata{
+		UserID: ctx.GetString("x-user-id"),
+		Name:   req.Name,
+		Bands:  bands,
+		PreAmp: req.PreAmp,
+	}
+
+	saved, err := c.EqPresetUsecase.SaveEqPreset(ctx.Request.Context(), preset)
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "SAVE_FAILED", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "eq_preset", saved, 1)
+}
+
+func (c *EqPresetController) DeleteEqPreset(ctx *gin.Context) {
+	var req struct {
+		Name string `form:"name" binding:"required"`
+	}
+
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "MISSING_PARAMETER", "缺少必要参数: name")
+		return
+	}
+
+	deleted, err := c.EqPresetUsecase.DeleteEqPreset(ctx.Request.Context(), ctx.GetString("x-user-id"), req.Name)
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "DELETE_FAILED", err.Error())
+		return
+	}
+	if !deleted {
+		controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", "eq preset not found")
+		return
+	}
+	controller.SuccessResponse(ctx, "deleted", true, 1)
+}
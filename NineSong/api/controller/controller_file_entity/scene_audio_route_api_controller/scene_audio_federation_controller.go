@@ -0,0 +1,322 @@
+package scene_audio_route_api_controller
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_federation/scene_audio_federation_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_federation_usecase"
+	"github.com/gin-gonic/gin"
+)
+
+const federationPeerKey = "federation-peer"
+
+// 代理远程音频流时透传的响应头
+var federationStreamHeaders = []string{"Content-Type", "Content-Length", "Content-Range", "Accept-Ranges"}
+
+type FederationController struct {
+	FederationUsecase *scene_audio_federation_usecase.FederationUsecase
+}
+
+func NewFederationController(uc *scene_audio_federation_usecase.FederationUsecase) *FederationController {
+	return &FederationController{FederationUsecase: uc}
+}
+
+// ===== 对方实例调用的接口 =====
+
+// Authenticate 校验对方实例令牌，通过后将关联写入上下文
+func (c *FederationController) Authenticate(ctx *gin.Context) {
+	peer, err := c.FederationUsecase.Authenticate(ctx.Request.Context(), ctx.GetHeader(scene_audio_federation_models.FederationTokenHeader))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, scene_audio_federation_models.ErrInvalidPeerToken) {
+			status = http.StatusUnauthorized
+		}
+		controller.ErrorResponse(ctx, status, "UNAUTHORIZED", err.Error())
+		ctx.Abort()
+		return
+	}
+	ctx.Set(federationPeerKey, peer)
+	ctx.Next()
+}
+
+func (c *FederationController) Handshake(ctx *gin.Context) {
+	var req scene_audio_federation_models.FederationHandshake
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
+		return
+	}
+	if err := c.FederationUsecase.Handshake(ctx.Request.Context(), req); err != nil {
+		federationError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "peer", gin.H{"name": c.FederationUsecase.Name()}, 1)
+}
+
+func (c *FederationController) ExportAlbums(ctx *gin.Context) {
+	c.export(ctx, scene_audio_federation_models.RemoteKindAlbums, "name")
+}
+
+func (c *FederationController) ExportArtists(ctx *gin.Context) {
+	c.export(ctx, scene_audio_federation_models.RemoteKindArtists, "name")
+}
+
+func (c *FederationController) ExportMediaFiles(ctx *gin.Context) {
+	c.export(ctx, scene_audio_federation_models.RemoteKindMediaFiles, "title")
+}
+
+func (c *FederationController) export(ctx *gin.Context, kind, defaultSort string) {
+	items, err := c.FederationUsecase.ListLocal(ctx.Request.Context(), kind, remoteQuery(ctx, defaultSort))
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "items", items, len(items))
+}
+
+// ExportStream 以关联设置的带宽上限向对方提供原始文件，支持 Range 请求
+func (c *FederationController) ExportStream(ctx *gin.Context) {
+	peer := ctx.MustGet(federationPeerKey).(*scene_audio_federation_models.FederationPeer)
+	path, kbps, err := c.FederationUsecase.LocalStreamPath(ctx.Request.Context(), peer, ctx.Query("media_file_id"))
+	if err != nil {
+		federationError(ctx, err)
+		return
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusNotFound, "FILE_NOT_FOUND", "media file not available")
+		return
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", "media file not available")
+		return
+	}
+
+	ctx.Header("Content-Type", detectContentType(path))
+	writer := &throttledResponseWriter{
+		ResponseWriter: ctx.Writer,
+		bytesPerSec:    kbps * 1000 / 8,
+		start:          time.Now(),
+	}
+	http.ServeContent(writer, ctx.Request, info.Name(), info.ModTime(), file)
+}
+
+func (c *FederationController) Unlink(ctx *gin.Context) {
+	peer := ctx.MustGet(federationPeerKey).(*scene_audio_federation_models.FederationPeer)
+	if err := c.FederationUsecase.Unlink(ctx.Request.Context(), peer); err != nil {
+		federationError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "message", "unlinked", 1)
+}
+
+// ===== 本实例用户与管理员使用的接口 =====
+
+func (c *FederationController) CreateInvite(ctx *gin.Context) {
+	allowStream, kbps, err := streamSettings(ctx)
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
+		return
+	}
+	invite, err := c.FederationUsecase.CreateInvite(ctx.Request.Context(), ctx.PostForm("name"), allowStream, kbps)
+	if err != nil {
+		federationError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "invite", invite, 1)
+}
+
+func (c *FederationController) LinkPeer(ctx *gin.Context) {
+	allowStream, kbps, err := streamSettings(ctx)
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
+		return
+	}
+	peer, err := c.FederationUsecase.Link(
+		ctx.Request.Context(),
+		ctx.PostForm("name"),
+		ctx.PostForm("base_url"),
+		ctx.PostForm("invite_token"),
+		allowStream,
+		kbps,
+	)
+	if err != nil {
+		federationError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "peer", peer, 1)
+}
+
+func (c *FederationController) GetPeers(ctx *gin.Context) {
+	peers, err := c.FederationUsecase.GetPeers(ctx.Request.Context())
+	if err != nil {
+		federationError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "peers", peers, len(peers))
+}
+
+func (c *FederationController) UpdatePeer(ctx *gin.Context) {
+	allowStream, kbps, err := streamSettings(ctx)
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
+		return
+	}
+	peer, err := c.FederationUsecase.UpdatePeer(ctx.Request.Context(), ctx.Param("id"), allowStream, kbps)
+	if err != nil {
+		federationError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "peer", peer, 1)
+}
+
+func (c *FederationController) DeletePeer(ctx *gin.Context) {
+	if err := c.FederationUsecase.DeletePeer(ctx.Request.Context(), ctx.Param("id")); err != nil {
+		federationError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "message", "deleted", 1)
+}
+
+func (c *FederationController) BrowseAlbums(ctx *gin.Context) {
+	c.browse(ctx, scene_audio_federation_models.RemoteKindAlbums, "albums", "name")
+}
+
+func (c *FederationController) BrowseArtists(ctx *gin.Context) {
+	c.browse(ctx, scene_audio_federation_models.RemoteKindArtists, "artists", "name")
+}
+
+func (c *FederationController) BrowseMediaFiles(ctx *gin.Context) {
+	c.browse(ctx, scene_audio_federation_models.RemoteKindMediaFiles, "mediaFiles", "title")
+}
+
+func (c *FederationController) browse(ctx *gin.Context, kind, dataKey, defaultSort string) {
+	if ctx.Query("start") == "" || ctx.Query("end") == "" {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", "start and end are required")
+		return
+	}
+	items, err := c.FederationUsecase.Browse(ctx.Request.Context(), ctx.Param("id"), kind, remoteQuery(ctx, defaultSort))
+	if err != nil {
+		federationError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, dataKey, items, len(items))
+}
+
+// StreamRemote 通过本实例代理远程曲目，客户端无需直接访问对方
+func (c *FederationController) StreamRemote(ctx *gin.Context) {
+	resp, err := c.FederationUsecase.StreamRemote(ctx.Request.Context(), ctx.Query("media_file_id"), ctx.Request.Header)
+	if err != nil {
+		federationError(ctx, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	for _, key := range federationStreamHeaders {
+		if value := resp.Header.Get(key); value != "" {
+			ctx.Header(key, value)
+		}
+	}
+	ctx.Status(resp.StatusCode)
+	_, _ = io.Copy(ctx.Writer, resp.Body)
+}
+
+func remoteQuery(ctx *gin.Context, defaultSort string) scene_audio_federation_models.RemoteQuery {
+	return scene_audio_federation_models.RemoteQuery{
+		Start:    ctx.Query("start"),
+		End:      ctx.Query("end"),
+		Sort:     ctx.DefaultQuery("sort", defaultSort),
+		Order:    ctx.DefaultQuery("order", "asc"),
+		Search:   ctx.Query("search"),
+		AlbumID:  ctx.Query("album_id"),
+		ArtistID: ctx.Query("artist_id"),
+	}
+}
+
+func streamSettings(ctx *gin.Context) (bool, int, error) {
+	allowStream := false
+	if raw := ctx.PostForm("allow_stream"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return false, 0, errors.New("invalid allow_stream parameter")
+		}
+		allowStream = parsed
+	}
+	kbps := 0
+	if raw := ctx.PostForm("stream_kbps"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			return false, 0, errors.New("invalid stream_kbps parameter")
+		}
+		kbps = parsed
+	}
+	return allowStream, kbps, nil
+}
+
+func federationError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, scene_audio_federation_models.ErrPeerNotFound):
+		controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", err.Error())
+	case errors.Is(err, scene_audio_federation_models.ErrInvalidInvite),
+		errors.Is(err, scene_audio_federation_models.ErrInvalidPeerToken):
+		controller.ErrorResponse(ctx, http.StatusUnauthorized, "UNAUTHORIZED", err.Error())
+	case errors.Is(err, scene_audio_federation_models.ErrStreamNotAllowed):
+		controller.ErrorResponse(ctx, http.StatusForbidden, "FORBIDDEN", err.Error())
+	case errors.Is(err, scene_audio_federation_models.ErrRemoteUnavailable):
+		controller.ErrorResponse(ctx, http.StatusBadGateway, "REMOTE_UNAVAILABLE", err.Error())
+	case errors.Is(err, scene_audio_federation_models.ErrFederationNotConfigured):
+		controller.ErrorResponse(ctx, http.StatusServiceUnavailable, "NOT_CONFIGURED", err.Error())
+	case errors.Is(err, scene_audio_federation_models.ErrInvalidRemoteID),
+		strings.HasPrefix(err.Error(), "invalid"):
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
+	case strings.Contains(err.Error(), "not found"):
+		controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", err.Error())
+	default:
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+	}
+}
+
+// throttledResponseWriter 按固定速率写出响应体，限制对方串流占用的带宽
+type throttledResponseWriter struct {
+	gin.ResponseWriter
+	bytesPerSec int
+	start       time.Time
+	written     int64
+}
+
+func (w *throttledResponseWriter) Write(p []byte) (int, error) {
+	// 每次最多写出约 100ms 的数据，使速率平滑
+	chunk := w.bytesPerSec / 10
+	if chunk <= 0 {
+		chunk = 1
+	}
+	total := 0
+	for len(p) > 0 {
+		size := chunk
+		if size > len(p) {
+			size = len(p)
+		}
+		n, err := w.ResponseWriter.Write(p[:size])
+		total += n
+		w.written += int64(n)
+		if err != nil {
+			return total, err
+		}
+		p = p[size:]
+
+		expected := time.Duration(float64(w.written) / float64(w.bytesPerSec) * float64(time.Second))
+		if wait := expected - time.Since(w.start); wait > 0 {
+			time.Sleep(wait)
+		}
+	}
+	return total, nil
+}
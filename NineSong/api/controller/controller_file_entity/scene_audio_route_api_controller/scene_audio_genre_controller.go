@@ -0,0 +1,84 @@
+package scene_audio_route_api_controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/gin-gonic/gin"
+)
+
+type GenreController struct {
+	GenreUsecase scene_audio_route_interface.GenreRepository
+}
+
+func NewGenreController(uc scene_audio_route_interface.GenreRepository) *GenreController {
+	return &GenreController{GenreUsecase: uc}
+}
+
+func (c *GenreController) GetGenreTree(ctx *gin.Context) {
+	locale := ctx.Query("locale")
+	if locale == "" {
+		// 未指定时取 Accept-Language 的首选语言
+		locale, _, _ = strings.Cut(ctx.GetHeader("Accept-Language"), ",")
+		locale, _, _ = strings.Cut(locale, ";")
+	}
+
+	tree, err := c.GenreUsecase.GetGenreTree(ctx.Request.Context(), locale)
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "genres", tree, len(tree))
+}
+
+func (c *GenreController) SaveGenre(ctx *gin.Context) {
+	var req struct {
+		Name           string `form:"name" binding:"required"`
+		Parent         string `form:"parent"`
+		LocalizedNames string `form:"localized_names"` // JSON对象，如 {"zh-CN":"摇滚"}
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+
+	var localizedNames map[string]string
+	if req.LocalizedNames != "" {
+		if err := json.Unmarshal([]byte(req.LocalizedNames), &localizedNames); err != nil {
+			controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMETERS", "localized_names 必须是JSON对象")
+			return
+		}
+	}
+
+	genre, err := c.GenreUsecase.SaveGenre(ctx.Request.Context(), scene_audio_route_models.GenreMetadata{
+		Name:           req.Name,
+		Parent:         req.Parent,
+		LocalizedNames: localizedNames,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), scene_audio_route_models.ErrGenreCycle.Error()) {
+			controller.ErrorResponse(ctx, http.StatusConflict, "GENRE_CYCLE", err.Error())
+			return
+		}
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "genre", genre, 1)
+}
+
+func (c *GenreController) DeleteGenre(ctx *gin.Context) {
+	deleted, err := c.GenreUsecase.DeleteGenre(ctx.Request.Context(), ctx.Query("name"))
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	if !deleted {
+		controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", "genre not found")
+		return
+	}
+	controller.SuccessResponse(ctx, "deleted", true, 1)
+}
@@ -0,0 +1,55 @@
+package scene_audio_route_api_controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/gin-gonic/gin"
+)
+
+type HistoryController struct {
+	HistoryUsecase scene_audio_route_interface.HistoryUsecase
+	Links          LinkBuilder
+}
+
+func NewHistoryController(uc scene_audio_route_interface.HistoryUsecase, links LinkBuilder) *HistoryController {
+	return &HistoryController{HistoryUsecase: uc, Links: links}
+}
+
+// GetHistory 当前用户的播放记录，按播放时间倒序；count 为符合条件的总数
+func (c *HistoryController) GetHistory(ctx *gin.Context) {
+	start := ctx.DefaultQuery("start", "0")
+	end := ctx.DefaultQuery("end", strconv.Itoa(scene_audio_route_models.HistoryDefaultLimit))
+
+	items, total, err := c.HistoryUsecase.GetHistory(
+		ctx.Request.Context(),
+		ctx.GetString("x-user-id"),
+		ctx.Query("media_file_id"),
+		ctx.Query("from"),
+		ctx.Query("to"),
+		start, end,
+	)
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
+		return
+	}
+
+	mediaFiles := make([]scene_audio_route_models.MediaFileMetadata, 0, len(items))
+	for _, item := range items {
+		if item.MediaFile != nil {
+			mediaFiles = append(mediaFiles, *item.MediaFile)
+		}
+	}
+	c.Links.FillMediaFileLinks(ctx, mediaFiles)
+	next := 0
+	for i := range items {
+		if items[i].MediaFile != nil {
+			*items[i].MediaFile = mediaFiles[next]
+			next++
+		}
+	}
+	controller.SuccessResponse(ctx, "history", items, int(total))
+}
@@ -0,0 +1,38 @@
+package scene_audio_route_api_controller
+
+import (
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/gin-gonic/gin"
+)
+
+type HomeSectionController struct {
+	usecase scene_audio_route_interface.HomeSectionUsecase
+}
+
+func NewHomeSectionController(uc scene_audio_route_interface.HomeSectionUsecase) *HomeSectionController {
+	return &HomeSectionController{usecase: uc}
+}
+
+// GetHome 一次返回首页全部分区，sections 参数（如 "recently_added:12,favorites"）可覆盖用户保存的布局
+func (c *HomeSectionController) GetHome(ctx *gin.Context) {
+	var sections []scene_audio_route_models.HomeSectionConfig
+	if value := ctx.Query("sections"); value != "" {
+		parsed, err := scene_audio_route_models.ParseHomeSections(value)
+		if err != nil {
+			controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
+			return
+		}
+		sections = parsed
+	}
+
+	result, err := c.usecase.GetHome(ctx.Request.Context(), ctx.GetString("x-user-id"), sections)
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "sections", result, len(result))
+}
@@ -0,0 +1,61 @@
+package scene_audio_route_api_controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/gin-gonic/gin"
+)
+
+type ImageProxyController struct {
+	ImageProxyUsecase scene_audio_route_interface.ImageProxyUsecase
+}
+
+func NewImageProxyController(uc scene_audio_route_interface.ImageProxyUsecase) *ImageProxyController {
+	return &ImageProxyController{ImageProxyUsecase: uc}
+}
+
+// GetImage 代理外部封面与艺术家图片，客户端不直接访问第三方 CDN；size 与 /coverart 含义相同
+func (c *ImageProxyController) GetImage(ctx *gin.Context) {
+	rawURL := ctx.Query("url")
+	if rawURL == "" {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMETERS", "url is required")
+		return
+	}
+	size := 0
+	if value := ctx.Query("size"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMETERS", "size must be a non-negative integer")
+			return
+		}
+		size = min(max(parsed, minCoverArtSize), maxCoverArtSize)
+	}
+
+	path, err := c.ImageProxyUsecase.GetImage(ctx.Request.Context(), rawURL, size)
+	if err != nil {
+		switch {
+		case errors.Is(err, scene_audio_route_models.ErrImageProxyInvalidURL):
+			controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMETERS", err.Error())
+		case errors.Is(err, scene_audio_route_models.ErrImageProxyDisabled),
+			errors.Is(err, scene_audio_route_models.ErrImageProxyHostNotAllowed):
+			controller.ErrorResponse(ctx, http.StatusForbidden, "HOST_NOT_ALLOWED", err.Error())
+		case errors.Is(err, scene_audio_route_models.ErrImageProxyNotImage),
+			errors.Is(err, scene_audio_route_models.ErrImageProxyTooLarge):
+			controller.ErrorResponse(ctx, http.StatusUnprocessableEntity, "INVALID_IMAGE", err.Error())
+		default:
+			log.Printf("代理外部图片失败 %s: %v", rawURL, err)
+			controller.ErrorResponse(ctx, http.StatusBadGateway, "FETCH_FAILED", "failed to fetch remote image")
+		}
+		return
+	}
+
+	ctx.Header("Cache-Control", "public, max-age=86400")
+	ctx.Header("Content-Type", "image/jpeg")
+	ctx.File(path)
+}
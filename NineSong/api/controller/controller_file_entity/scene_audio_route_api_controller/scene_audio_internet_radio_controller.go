@@ -0,0 +1,137 @@
+package scene_audio_route_api_controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type InternetRadioController struct {
+	InternetRadioUsecase scene_audio_route_interface.InternetRadioUsecase
+}
+
+func NewInternetRadioController(uc scene_audio_route_interface.InternetRadioUsecase) *InternetRadioController {
+	return &InternetRadioController{InternetRadioUsecase: uc}
+}
+
+type internetRadioRequest struct {
+	Name        string `form:"name" binding:"required"`
+	StreamURL   string `form:"stream_url" binding:"required"`
+	HomePageURL string `form:"home_page_url"`
+	SkipCheck   bool   `form:"skip_check"` // 跳过串流可达性检测，用于保存暂时离线的电台
+}
+
+func (c *InternetRadioController) GetStations(ctx *gin.Context) {
+	stations, err := c.InternetRadioUsecase.GetStations(ctx.Request.Context(), ctx.GetString(domain.UserIDKey))
+	if err != nil {
+		internetRadioError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "radio_stations", stations, len(stations))
+}
+
+func (c *InternetRadioController) GetStation(ctx *gin.Context) {
+	station, err := c.InternetRadioUsecase.GetStation(ctx.Request.Context(), ctx.GetString(domain.UserIDKey), ctx.Param("id"))
+	if err != nil {
+		internetRadioError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "radio_station", station, 1)
+}
+
+func (c *InternetRadioController) CreateStation(ctx *gin.Context) {
+	var req internetRadioRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+	station, err := c.InternetRadioUsecase.CreateStation(ctx.Request.Context(), scene_audio_route_models.InternetRadioStation{
+		UserID:      ctx.GetString(domain.UserIDKey),
+		Name:        req.Name,
+		StreamURL:   req.StreamURL,
+		HomePageURL: req.HomePageURL,
+	}, req.SkipCheck)
+	if err != nil {
+		internetRadioError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "radio_station", station, 1)
+}
+
+func (c *InternetRadioController) UpdateStation(ctx *gin.Context) {
+	var req internetRadioRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+	id, err := primitive.ObjectIDFromHex(ctx.Param("id"))
+	if err != nil {
+		internetRadioError(ctx, scene_audio_route_models.ErrInvalidRadioStation)
+		return
+	}
+	station, err := c.InternetRadioUsecase.UpdateStation(ctx.Request.Context(), scene_audio_route_models.InternetRadioStation{
+		ID:          id,
+		UserID:      ctx.GetString(domain.UserIDKey),
+		Name:        req.Name,
+		StreamURL:   req.StreamURL,
+		HomePageURL: req.HomePageURL,
+	}, req.SkipCheck)
+	if err != nil {
+		internetRadioError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "radio_station", station, 1)
+}
+
+func (c *InternetRadioController) DeleteStation(ctx *gin.Context) {
+	deleted, err := c.InternetRadioUsecase.DeleteStation(ctx.Request.Context(), ctx.GetString(domain.UserIDKey), ctx.Param("id"))
+	if err != nil {
+		internetRadioError(ctx, err)
+		return
+	}
+	if !deleted {
+		controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", "internet radio station not found")
+		return
+	}
+	controller.SuccessResponse(ctx, "deleted", true, 1)
+}
+
+// CheckStream 保存前检测串流地址是否可以播放
+func (c *InternetRadioController) CheckStream(ctx *gin.Context) {
+	var req struct {
+		StreamURL string `form:"stream_url" binding:"required"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+	check, err := c.InternetRadioUsecase.CheckStream(ctx.Request.Context(), req.StreamURL)
+	if err != nil {
+		internetRadioError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "check", check, 1)
+}
+
+func internetRadioError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, scene_audio_route_models.ErrInvalidRadioStation):
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
+	case errors.Is(err, scene_audio_route_models.ErrRadioStreamUnreachable):
+		controller.ErrorResponse(ctx, http.StatusUnprocessableEntity, "STREAM_UNREACHABLE", err.Error())
+	case errors.Is(err, scene_audio_route_models.ErrRadioStationExists):
+		controller.ErrorResponse(ctx, http.StatusConflict, "NAME_CONFLICT", err.Error())
+	case errors.Is(err, scene_audio_route_models.ErrRadioStationLimit):
+		controller.ErrorResponse(ctx, http.StatusConflict, "QUOTA_EXCEEDED", err.Error())
+	case domain.IsNotFound(err):
+		controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", err.Error())
+	default:
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+	}
+}
@@ -0,0 +1,130 @@
+package scene_audio_route_api_controller
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/jukebox_util"
+	"github.com/gin-gonic/gin"
+)
+
+type JukeboxController struct {
+	JukeboxUsecase scene_audio_route_interface.JukeboxUsecase
+}
+
+func NewJukeboxController(uc scene_audio_route_interface.JukeboxUsecase) *JukeboxController {
+	return &JukeboxController{JukeboxUsecase: uc}
+}
+
+func (c *JukeboxController) GetStatus(ctx *gin.Context) {
+	status, err := c.JukeboxUsecase.Status(ctx.Request.Context())
+	jukeboxResponse(ctx, status, err)
+}
+
+func (c *JukeboxController) SetQueue(ctx *gin.Context) {
+	var req struct {
+		MediaFileIDs string `form:"media_file_ids"`
+		Index        int    `form:"index"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+	status, err := c.JukeboxUsecase.SetQueue(ctx.Request.Context(), splitJukeboxIDs(req.MediaFileIDs), req.Index)
+	jukeboxResponse(ctx, status, err)
+}
+
+func (c *JukeboxController) AddToQueue(ctx *gin.Context) {
+	var req struct {
+		MediaFileIDs string `form:"media_file_ids" binding:"required"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+	status, err := c.JukeboxUsecase.Add(ctx.Request.Context(), splitJukeboxIDs(req.MediaFileIDs))
+	jukeboxResponse(ctx, status, err)
+}
+
+// Play 未传 index 时继续播放当前曲目
+func (c *JukeboxController) Play(ctx *gin.Context) {
+	var req struct {
+		Index *int `form:"index"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+	index := -1
+	if req.Index != nil {
+		if *req.Index < 0 {
+			controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", "index cannot be negative")
+			return
+		}
+		index = *req.Index
+	}
+	status, err := c.JukeboxUsecase.Play(ctx.Request.Context(), index)
+	jukeboxResponse(ctx, status, err)
+}
+
+func (c *JukeboxController) Pause(ctx *gin.Context) {
+	status, err := c.JukeboxUsecase.Pause(ctx.Request.Context())
+	jukeboxResponse(ctx, status, err)
+}
+
+// Skip offset 默认为 1，传 -1 回到上一首
+func (c *JukeboxController) Skip(ctx *gin.Context) {
+	var req struct {
+		Offset *int `form:"offset"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+	offset := 1
+	if req.Offset != nil {
+		offset = *req.Offset
+	}
+	status, err := c.JukeboxUsecase.Skip(ctx.Request.Context(), offset)
+	jukeboxResponse(ctx, status, err)
+}
+
+func (c *JukeboxController) SetVolume(ctx *gin.Context) {
+	var req struct {
+		Volume int `form:"volume"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+	status, err := c.JukeboxUsecase.SetVolume(ctx.Request.Context(), req.Volume)
+	jukeboxResponse(ctx, status, err)
+}
+
+func splitJukeboxIDs(raw string) []string {
+	ids := make([]string, 0)
+	for _, id := range strings.Split(raw, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func jukeboxResponse(ctx *gin.Context, status *scene_audio_route_models.JukeboxStatus, err error) {
+	switch {
+	case err == nil:
+		controller.SuccessResponse(ctx, "jukebox", status, len(status.Queue))
+	case errors.Is(err, scene_audio_route_models.ErrInvalidJukeboxParams),
+		errors.Is(err, scene_audio_route_models.ErrJukeboxQueueEmpty):
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
+	case errors.Is(err, jukebox_util.ErrPlayerUnavailable):
+		controller.ErrorResponse(ctx, http.StatusServiceUnavailable, "PLAYER_UNAVAILABLE", err.Error())
+	default:
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+	}
+}
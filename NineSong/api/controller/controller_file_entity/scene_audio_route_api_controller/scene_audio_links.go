@@ -0,0 +1,113 @@
+package scene_audio_route_api_controller
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/gin-gonic/gin"
+)
+
+// LinkBuilder 为列表条目生成可直接使用的地址
+type LinkBuilder struct {
+	BaseURL string // 为空时按请求的协议与 Host 生成
+	Signed  bool   // 在地址中附带本次请求的 access_token，<audio>、<img> 等无法设置请求头的场景可直接使用
+}
+
+func NewLinkBuilder(baseURL string, signed bool) LinkBuilder {
+	return LinkBuilder{BaseURL: strings.TrimRight(baseURL, "/"), Signed: signed}
+}
+
+// linkContext 单次请求内共用的地址前缀与签名参数
+type linkContext struct {
+	base  string
+	token string
+}
+
+func (b LinkBuilder) context(ctx *gin.Context) linkContext {
+	lc := linkContext{base: b.BaseURL}
+	if lc.base == "" {
+		scheme := "http"
+		if ctx.Request.TLS != nil {
+			scheme = "https"
+		}
+		if proto := ctx.GetHeader("X-Forwarded-Proto"); proto != "" {
+			scheme = strings.TrimSpace(strings.Split(proto, ",")[0])
+		}
+		lc.base = scheme + "://" + ctx.Request.Host
+	}
+	if b.Signed {
+		lc.token = ctx.Query("access_token")
+		if lc.token == "" {
+			if bearer, ok := strings.CutPrefix(ctx.GetHeader("Authorization"), "Bearer "); ok {
+				lc.token = strings.TrimSpace(bearer)
+			}
+		}
+	}
+	return lc
+}
+
+func (lc linkContext) url(path string, query url.Values) string {
+	if lc.token != "" {
+		query.Set("access_token", lc.token)
+	}
+	if len(query) == 0 {
+		return lc.base + path
+	}
+	return lc.base + path + "?" + query.Encode()
+}
+
+func (lc linkContext) cover(id, version string) *scene_audio_route_models.CoverLinks {
+	sized := func(size int) string {
+		query := url.Values{}
+		if size > 0 {
+			query.Set("size", strconv.Itoa(size))
+		}
+		if version != "" {
+			query.Set("v", version)
+		}
+		return lc.url("/coverart/"+url.PathEscape(id), query)
+	}
+	return &scene_audio_route_models.CoverLinks{
+		Original: sized(0),
+		Small:    sized(scene_audio_route_models.CoverLinkSmall),
+		Medium:   sized(scene_audio_route_models.CoverLinkMedium),
+		Large:    sized(scene_audio_route_models.CoverLinkLarge),
+	}
+}
+
+func (lc linkContext) albumTracks(albumID string) string {
+	return lc.url("/album/"+url.PathEscape(albumID)+"/tracks", url.Values{})
+}
+
+// FillAlbumLinks 为专辑填充封面与详情（按光盘分组的曲目）地址
+func (b LinkBuilder) FillAlbumLinks(ctx *gin.Context, albums []scene_audio_route_models.AlbumMetadata) {
+	lc := b.context(ctx)
+	for i := range albums {
+		album := &albums[i]
+		id := album.ID.Hex()
+		album.Links = &scene_audio_route_models.ItemLinks{
+			Detail: lc.albumTracks(id),
+			Cover:  lc.cover(id, album.ArtVersion),
+		}
+	}
+}
+
+// FillMediaFileLinks 为曲目填充播放、下载、封面与所属专辑地址
+func (b LinkBuilder) FillMediaFileLinks(ctx *gin.Context, mediaFiles []scene_audio_route_models.MediaFileMetadata) {
+	lc := b.context(ctx)
+	for i := range mediaFiles {
+		mediaFile := &mediaFiles[i]
+		id := mediaFile.ID.Hex()
+		links := &scene_audio_route_models.ItemLinks{
+			Stream:   lc.url("/media/stream", url.Values{"media_file_id": {id}}),
+			Download: lc.url("/media/download", url.Values{"media_file_id": {id}}),
+			Cover:    lc.cover(id, mediaFile.ArtVersion),
+		}
+		if mediaFile.AlbumID != "" {
+			links.Album = lc.albumTracks(mediaFile.AlbumID)
+		}
+		mediaFile.Links = links
+	}
+}
@@ -0,0 +1,37 @@
+package scene_audio_route_api_controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/gin-gonic/gin"
+)
+
+type LookupController struct {
+	LookupUsecase scene_audio_route_interface.LookupUsecase
+	Links         LinkBuilder
+}
+
+func NewLookupController(uc scene_audio_route_interface.LookupUsecase, links LinkBuilder) *LookupController {
+	return &LookupController{LookupUsecase: uc, Links: links}
+}
+
+// Lookup 按 isrc 查找曲目、按 barcode 查找专辑，未命中时返回空列表
+func (c *LookupController) Lookup(ctx *gin.Context) {
+	result, err := c.LookupUsecase.Lookup(ctx.Request.Context(), ctx.Query("isrc"), ctx.Query("barcode"))
+	if err != nil {
+		if errors.Is(err, scene_audio_route_models.ErrInvalidLookup) {
+			controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", "isrc必须为12位ISRC，barcode必须为8、12、13或14位数字，至少提供其一")
+			return
+		}
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+		return
+	}
+
+	c.Links.FillMediaFileLinks(ctx, result.MediaFiles)
+	c.Links.FillAlbumLinks(ctx, result.Albums)
+	controller.SuccessResponse(ctx, "lookup", result, len(result.MediaFiles)+len(result.Albums))
+}
@@ -0,0 +1,89 @@
+package scene_audio_route_api_controller
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type LyricsController struct {
+	LyricsUsecase scene_audio_route_interface.LyricsRepository
+}
+
+func NewLyricsController(uc scene_audio_route_interface.LyricsRepository) *LyricsController {
+	return &LyricsController{LyricsUsecase: uc}
+}
+
+// GetLyrics 同时返回纯文本与按时间排序的同步歌词
+func (c *LyricsController) GetLyrics(ctx *gin.Context) {
+	mediaFileId := ctx.Param("mediaFileId")
+	if _, err := primitive.ObjectIDFromHex(mediaFileId); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMETERS", "mediaFileId必须为24位十六进制字符串")
+		return
+	}
+
+	lyrics, err := c.LyricsUsecase.GetLyrics(ctx.Request.Context(), mediaFileId)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			controller.ErrorResponse(ctx, http.StatusNotFound, "LYRICS_NOT_FOUND", "未找到关联的歌词内容")
+			return
+		}
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "lyrics", lyrics, 1)
+}
+
+type PinLyricsRequest struct {
+	Lyrics string `form:"lyrics" json:"lyrics" binding:"required"`
+}
+
+// PinLyrics 手动固定曲目歌词（LRC 或纯文本），用于修正错误的内嵌或在线歌词
+func (c *LyricsController) PinLyrics(ctx *gin.Context) {
+	mediaFileId := ctx.Param("mediaFileId")
+	if _, err := primitive.ObjectIDFromHex(mediaFileId); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMETERS", "mediaFileId必须为24位十六进制字符串")
+		return
+	}
+	var req PinLyricsRequest
+	if err := ctx.ShouldBind(&req); err != nil || strings.TrimSpace(req.Lyrics) == "" {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMETERS", "lyrics不能为空")
+		return
+	}
+
+	lyrics, err := c.LyricsUsecase.PinLyrics(ctx.Request.Context(), mediaFileId, req.Lyrics)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			controller.ErrorResponse(ctx, http.StatusNotFound, "MEDIA_NOT_FOUND", "曲目不存在")
+			return
+		}
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "lyrics", lyrics, 1)
+}
+
+// UnpinLyrics 取消手动固定，恢复使用本地或在线歌词
+func (c *LyricsController) UnpinLyrics(ctx *gin.Context) {
+	mediaFileId := ctx.Param("mediaFileId")
+	if _, err := primitive.ObjectIDFromHex(mediaFileId); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMETERS", "mediaFileId必须为24位十六进制字符串")
+		return
+	}
+
+	if err := c.LyricsUsecase.UnpinLyrics(ctx.Request.Context(), mediaFileId); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			controller.ErrorResponse(ctx, http.StatusNotFound, "LYRICS_NOT_FOUND", "曲目没有手动固定的歌词")
+			return
+		}
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "result", true, 1)
+}
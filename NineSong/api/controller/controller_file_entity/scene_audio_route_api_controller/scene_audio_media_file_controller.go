@@ -10,33 +10,49 @@ import (
 
 type MediaFileController struct {
 	MediaFileUsecase scene_audio_route_interface.MediaFileRepository
+	Links            LinkBuilder
 }
 
-func NewMediaFileController(uc scene_audio_route_interface.MediaFileRepository) *MediaFileController {
-	return &MediaFileController{MediaFileUsecase: uc}
+func NewMediaFileController(uc scene_audio_route_interface.MediaFileRepository, links LinkBuilder) *MediaFileController {
+	return &MediaFileController{MediaFileUsecase: uc, Links: links}
 }
 
 func (c *MediaFileController) GetMediaFiles(ctx *gin.Context) {
 	params := struct {
-		Start    string `form:"start" binding:"required"`
-		End      string `form:"end" binding:"required"`
-		Sort     string `form:"sort"`
-		Order    string `form:"order"`
-		Search   string `form:"search"`
-		Starred  string `form:"starred"`
-		AlbumID  string `form:"album_id"`
-		ArtistID string `form:"artist_id"`
-		Year     string `form:"year"`
+		Start     string `form:"start" binding:"required"`
+		End       string `form:"end" binding:"required"`
+		Sort      string `form:"sort"`
+		Order     string `form:"order"`
+		Search    string `form:"search"`
+		Starred   string `form:"starred"`
+		AlbumID   string `form:"album_id"`
+		ArtistID  string `form:"artist_id"`
+		Year      string `form:"year"`
+		FolderID  string `form:"folder_id"`
+		Available string `form:"available"`
+		Custom    string `form:"custom"`
 	}{
-		Start:    ctx.Query("start"),
-		End:      ctx.Query("end"),
-		Sort:     ctx.DefaultQuery("sort", "title"),
-		Order:    ctx.DefaultQuery("order", "asc"),
-		Search:   ctx.Query("search"),
-		Starred:  ctx.Query("starred"),
-		AlbumID:  ctx.Query("album_id"),
-		ArtistID: ctx.Query("artist_id"),
-		Year:     ctx.Query("year"),
+		Start:     ctx.Query("start"),
+		End:       ctx.Query("end"),
+		Sort:      ctx.Query("sort"),
+		Order:     ctx.DefaultQuery("order", "asc"),
+		Search:    ctx.Query("search"),
+		Starred:   ctx.Query("starred"),
+		AlbumID:   ctx.Query("album_id"),
+		ArtistID:  ctx.Query("artist_id"),
+		Year:      ctx.Query("year"),
+		FolderID:  ctx.Query("folder_id"),
+		Available: ctx.Query("available"),
+		Custom:    ctx.Query("custom"),
+	}
+
+	if !controller.CheckListParams(ctx, true, params.Start, params.End, params.Starred) {
+		return
+	}
+
+	// 单专辑内未指定排序时按光盘号与音轨号排列
+	if params.Sort == "" && params.AlbumID == "" {
+		params.Sort = "title"
 	}
 
 	mediaFiles, err := c.MediaFileUsecase.GetMediaFileItems(
@@ -50,6 +66,9 @@ func (c *MediaFileController) GetMediaFiles(ctx *gin.Context) {
 		params.AlbumID,
 		params.ArtistID,
 		params.Year,
+		params.FolderID,
+		params.Available,
+		params.Custom,
 	)
 
 	if err != nil {
@@ -57,22 +76,33 @@ func (c *MediaFileController) GetMediaFiles(ctx *gin.Context) {
 		return
 	}
 
+	c.Links.FillMediaFileLinks(ctx, mediaFiles)
 	controller.SuccessResponse(ctx, "mediaFiles", mediaFiles, len(mediaFiles))
 }
 
 func (c *MediaFileController) GetMediaFilterCounts(ctx *gin.Context) {
 	params := struct {
-		Search   string `form:"search"`
-		Starred  string `form:"starred"`
-		AlbumID  string `form:"album_id"`
-		ArtistID string `form:"artist_id"`
-		Year     string `form:"year"`
+		Search    string `form:"search"`
+		Starred   string `form:"starred"`
+		AlbumID   string `form:"album_id"`
+		ArtistID  string `form:"artist_id"`
+		Year      string `form:"year"`
+		FolderID  string `form:"folder_id"`
+		Available string `form:"available"`
+		Custom    string `form:"custom"`
 	}{
-		Search:   ctx.Query("search"),
-		Starred:  ctx.Query("starred"),
-		AlbumID:  ctx.Query("album_id"),
-		ArtistID: ctx.Query("artist_id"),
-		Year:     ctx.Query("year"),
+		Search:    ctx.Query("search"),
+		Starred:   ctx.Query("starred"),
+		AlbumID:   ctx.Query("album_id"),
+		ArtistID:  ctx.Query("artist_id"),
+		Year:      ctx.Query("year"),
+		FolderID:  ctx.Query("folder_id"),
+		Available: ctx.Query("available"),
+		Custom:    ctx.Query("custom"),
+	}
+
+	if !controller.CheckListParams(ctx, false, "", "", params.Starred) {
+		return
 	}
 
 	counts, err := c.MediaFileUsecase.GetMediaFileFilterItemsCount(
@@ -82,6 +112,9 @@ func (c *MediaFileController) GetMediaFilterCounts(ctx *gin.Context) {
 		params.AlbumID,
 		params.ArtistID,
 		params.Year,
+		params.FolderID,
+		params.Available,
+		params.Custom,
 	)
 
 	if err != nil {
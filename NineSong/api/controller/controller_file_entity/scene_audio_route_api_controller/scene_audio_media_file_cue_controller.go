@@ -39,6 +39,10 @@ func (c *MediaFileCueController) GetMediaFileCues(ctx *gin.Context) {
 		Year:     ctx.Query("year"),
 	}
 
+	if !controller.CheckListParams(ctx, true, params.Start, params.End, params.Starred) {
+		return
+	}
+
 	cueFiles, err := c.MediaFileUsecase.GetMediaFileCueItems(
 		ctx.Request.Context(),
 		params.Start,
@@ -75,6 +79,10 @@ func (c *MediaFileCueController) GetMediaCueFilterCounts(ctx *gin.Context) {
 		Year:     ctx.Query("year"),
 	}
 
+	if !controller.CheckListParams(ctx, false, "", "", params.Starred) {
+		return
+	}
+
 	counts, err := c.MediaFileUsecase.GetMediaFileCueFilterItemsCount(
 		ctx.Request.Context(),
 		params.Search,
@@ -0,0 +1,27 @@
+package scene_audio_route_api_controller
+
+import (
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/gin-gonic/gin"
+)
+
+type MilestoneController struct {
+	MilestoneUsecase scene_audio_route_interface.MilestoneRepository
+}
+
+func NewMilestoneController(uc scene_audio_route_interface.MilestoneRepository) *MilestoneController {
+	return &MilestoneController{MilestoneUsecase: uc}
+}
+
+// GetMyMilestones 当前用户达成的收听里程碑，type 可按类型筛选
+func (c *MilestoneController) GetMyMilestones(ctx *gin.Context) {
+	milestones, err := c.MilestoneUsecase.GetMilestones(ctx.Request.Context(), ctx.GetString("x-user-id"), ctx.Query("type"))
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "milestones", milestones, len(milestones))
+}
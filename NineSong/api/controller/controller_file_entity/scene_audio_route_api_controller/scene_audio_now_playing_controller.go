@@ -0,0 +1,79 @@
+package scene_audio_route_api_controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/gin-gonic/gin"
+)
+
+type NowPlayingController struct {
+	NowPlayingUsecase scene_audio_route_interface.NowPlayingUsecase
+}
+
+func NewNowPlayingController(uc scene_audio_route_interface.NowPlayingUsecase) *NowPlayingController {
+	return &NowPlayingController{NowPlayingUsecase: uc}
+}
+
+func (c *NowPlayingController) GetNowPlaying(ctx *gin.Context) {
+	entries, err := c.NowPlayingUsecase.GetNowPlaying(ctx.Request.Context())
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "now_playing", entries, len(entries))
+}
+
+// ReportNowPlaying 客户端在切歌、暂停、拖动进度时上报，播放中应在 NowPlayingTTL 内定期重复上报
+func (c *NowPlayingController) ReportNowPlaying(ctx *gin.Context) {
+	var req struct {
+		MediaFileID string `form:"media_file_id" binding:"required"`
+		Position    int64  `form:"position"`
+		State       string `form:"state"`
+		Client      string `form:"client"`
+	}
+
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+
+	client := req.Client
+	if client == "" {
+		client = "default"
+	}
+
+	saved, err := c.NowPlayingUsecase.Report(
+		ctx.Request.Context(),
+		ctx.GetString("x-user-id"),
+		client,
+		req.MediaFileID,
+		req.State,
+		req.Position,
+	)
+	if err != nil {
+		if errors.Is(err, scene_audio_route_models.ErrInvalidNowPlaying) {
+			controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMETERS", err.Error())
+			return
+		}
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "now_playing", saved, 1)
+}
+
+// ClearNowPlaying 停止播放时清除，client 为空时清除该用户所有客户端
+func (c *NowPlayingController) ClearNowPlaying(ctx *gin.Context) {
+	if err := c.NowPlayingUsecase.Clear(
+		ctx.Request.Context(),
+		ctx.GetString("x-user-id"),
+		ctx.Query("client"),
+	); err != nil {
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "message", "now playing cleared", 1)
+}
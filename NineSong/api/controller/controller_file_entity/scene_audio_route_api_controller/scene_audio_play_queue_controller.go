@@ -0,0 +1,85 @@
+package scene_audio_route_api_controller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type PlayQueueController struct {
+	PlayQueueUsecase scene_audio_route_interface.PlayQueueRepository
+}
+
+func NewPlayQueueController(uc scene_audio_route_interface.PlayQueueRepository) *PlayQueueController {
+	return &PlayQueueController{PlayQueueUsecase: uc}
+}
+
+func (c *PlayQueueController) GetPlayQueue(ctx *gin.Context) {
+	queue, err := c.PlayQueueUsecase.GetPlayQueue(
+		ctx.Request.Context(),
+		ctx.GetString("x-user-id"),
+		ctx.Query("client"),
+	)
+	if err != nil {
+		if domain.IsNotFound(err) {
+			controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", "play queue not found")
+			return
+		}
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "play_queue", queue, len(queue.Items))
+}
+
+func (c *PlayQueueController) SavePlayQueue(ctx *gin.Context) {
+	var req struct {
+		MediaFileIDs string `form:"media_file_ids"`
+		Current      int    `form:"current"`
+		Position     int64  `form:"position"`
+		Client       string `form:"client"`
+	}
+
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+
+	ids := make([]primitive.ObjectID, 0)
+	for _, idStr := range strings.Split(req.MediaFileIDs, ",") {
+		idStr = strings.TrimSpace(idStr)
+		if idStr == "" {
+			continue
+		}
+		objID, err := primitive.ObjectIDFromHex(idStr)
+		if err != nil {
+			controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMETERS", "无效的媒体文件ID: "+idStr)
+			return
+		}
+		ids = append(ids, objID)
+	}
+
+	client := req.Client
+	if client == "" {
+		client = "default"
+	}
+
+	saved, err := c.PlayQueueUsecase.SavePlayQueue(ctx.Request.Context(), scene_audio_route_models.PlayQueueMetadata{
+		UserID:       ctx.GetString("x-user-id"),
+		Client:       client,
+		MediaFileIDs: ids,
+		Current:      req.Current,
+		Position:     req.Position,
+		ChangedBy:    client,
+	})
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "SAVE_FAILED", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "play_queue", saved, len(saved.MediaFileIDs))
+}
@@ -2,6 +2,7 @@ package scene_audio_route_api_controller
 
 import (
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
 	"github.com/gin-gonic/gin"
@@ -19,7 +20,11 @@ func NewPlaylistController(uc scene_audio_route_interface.PlaylistRepository) *P
 }
 
 func (c *PlaylistController) GetPlaylists(ctx *gin.Context) {
-	playlists, err := c.PlaylistUsecase.GetPlaylistsAll(ctx.Request.Context())
+	playlists, err := c.PlaylistUsecase.GetPlaylistsAll(
+		ctx.Request.Context(),
+		ctx.Query("start"),
+		ctx.Query("end"),
+	)
 	if err != nil {
 		controller.ErrorResponse(ctx, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
 		return
@@ -59,6 +64,7 @@ func (c *PlaylistController) CreatePlaylist(ctx *gin.Context) {
 	newPlaylist := scene_audio_route_models.PlaylistMetadata{
 		Name:    req.Name,
 		Comment: req.Comment,
+		OwnerID: ctx.GetString("x-user-id"),
 	}
 
 	created, err := c.PlaylistUsecase.CreatePlaylist(ctx.Request.Context(), newPlaylist)
@@ -112,6 +118,10 @@ func (c *PlaylistController) DeletePlaylist(ctx *gin.Context) {
 	}
 
 	success, err := c.PlaylistUsecase.DeletePlaylist(ctx.Request.Context(), req.ID)
+	if err != nil && domain.IsNotFound(err) {
+		controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", "playlist not found")
+		return
+	}
 	if err != nil || !success {
 		controller.ErrorResponse(ctx, http.StatusInternalServerError, "DELETION_FAILED", "Delete failed")
 		return
@@ -2,6 +2,7 @@ package scene_audio_route_api_controller
 
 import (
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
 	"github.com/gin-gonic/gin"
 	"net/http"
@@ -35,6 +36,10 @@ func (c *PlaylistTrackController) GetPlaylistTracks(ctx *gin.Context) {
 		return
 	}
 
+	if !controller.CheckListParams(ctx, true, params.Start, params.End, params.Starred) {
+		return
+	}
+
 	results, err := c.PlaylistTrackUsecase.GetPlaylistTrackItems(
 		ctx.Request.Context(),
 		params.Start,
@@ -50,6 +55,10 @@ func (c *PlaylistTrackController) GetPlaylistTracks(ctx *gin.Context) {
 	)
 
 	if err != nil {
+		if domain.IsNotFound(err) {
+			controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", err.Error())
+			return
+		}
 		controller.ErrorResponse(ctx, http.StatusInternalServerError, "DATABASE_ERROR", err.Error())
 		return
 	}
@@ -107,6 +116,8 @@ func (c *PlaylistTrackController) AddPlaylistTracks(ctx *gin.Context) {
 		statusCode := http.StatusInternalServerError
 		if strings.Contains(err.Error(), "invalid") {
 			statusCode = http.StatusBadRequest
+		} else if domain.IsNotFound(err) {
+			statusCode = http.StatusNotFound
 		}
 		controller.ErrorResponse(ctx, statusCode, "OPERATION_FAILED", err.Error())
 		return
@@ -136,6 +147,8 @@ func (c *PlaylistTrackController) RemovePlaylistTracks(ctx *gin.Context) {
 		statusCode := http.StatusInternalServerError
 		if strings.Contains(err.Error(), "invalid") {
 			statusCode = http.StatusBadRequest
+		} else if domain.IsNotFound(err) {
+			statusCode = http.StatusNotFound
 		}
 		controller.ErrorResponse(ctx, statusCode, "OPERATION_FAILED", err.Error())
 		return
@@ -165,6 +178,8 @@ func (c *PlaylistTrackController) SortPlaylistTracks(ctx *gin.Context) {
 		statusCode := http.StatusInternalServerError
 		if strings.Contains(err.Error(), "invalid") {
 			statusCode = http.StatusBadRequest
+		} else if domain.IsNotFound(err) {
+			statusCode = http.StatusNotFound
 		}
 		controller.ErrorResponse(ctx, statusCode, "OPERATION_FAILED", err.Error())
 		return
@@ -0,0 +1,195 @@
+package scene_audio_route_api_controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/gin-gonic/gin"
+)
+
+type PodcastController struct {
+	PodcastUsecase scene_audio_route_interface.PodcastUsecase
+}
+
+func NewPodcastController(uc scene_audio_route_interface.PodcastUsecase) *PodcastController {
+	return &PodcastController{PodcastUsecase: uc}
+}
+
+func (c *PodcastController) GetChannels(ctx *gin.Context) {
+	channels, err := c.PodcastUsecase.GetChannels(ctx.Request.Context(), ctx.GetString(domain.UserIDKey))
+	if err != nil {
+		podcastError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "podcasts", channels, len(channels))
+}
+
+func (c *PodcastController) GetChannel(ctx *gin.Context) {
+	channel, err := c.PodcastUsecase.GetChannel(ctx.Request.Context(), ctx.GetString(domain.UserIDKey), ctx.Param("id"))
+	if err != nil {
+		podcastError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "podcast", channel, 1)
+}
+
+// Subscribe 抓取订阅源成功后才保存，auto_download 为刷新后自动下载的最新单集数
+func (c *PodcastController) Subscribe(ctx *gin.Context) {
+	var req struct {
+		FeedURL      string `form:"feed_url" binding:"required"`
+		AutoDownload int    `form:"auto_download"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+	channel, err := c.PodcastUsecase.Subscribe(ctx.Request.Context(), ctx.GetString(domain.UserIDKey), req.FeedURL, req.AutoDownload)
+	if err != nil {
+		podcastError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "podcast", channel, 1)
+}
+
+func (c *PodcastController) UpdateChannel(ctx *gin.Context) {
+	var req struct {
+		AutoDownload int `form:"auto_download"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+	channel, err := c.PodcastUsecase.UpdateChannel(ctx.Request.Context(), ctx.GetString(domain.UserIDKey), ctx.Param("id"), req.AutoDownload)
+	if err != nil {
+		podcastError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "podcast", channel, 1)
+}
+
+func (c *PodcastController) Unsubscribe(ctx *gin.Context) {
+	deleted, err := c.PodcastUsecase.Unsubscribe(ctx.Request.Context(), ctx.GetString(domain.UserIDKey), ctx.Param("id"))
+	if err != nil {
+		podcastError(ctx, err)
+		return
+	}
+	if !deleted {
+		controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", "podcast not found")
+		return
+	}
+	controller.SuccessResponse(ctx, "deleted", true, 1)
+}
+
+func (c *PodcastController) RefreshChannel(ctx *gin.Context) {
+	channel, err := c.PodcastUsecase.RefreshChannel(ctx.Request.Context(), ctx.GetString(domain.UserIDKey), ctx.Param("id"))
+	if err != nil {
+		podcastError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "podcast", channel, 1)
+}
+
+// GetEpisodes 按发布时间倒序分页，played 为空时不按播放状态过滤；start/end 无效时返回全部
+func (c *PodcastController) GetEpisodes(ctx *gin.Context) {
+	query := scene_audio_route_models.PodcastEpisodeQuery{ChannelID: ctx.Param("id")}
+	if raw := ctx.Query("played"); raw != "" {
+		played, err := strconv.ParseBool(raw)
+		if err != nil {
+			controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", "played 必须为 true/false")
+			return
+		}
+		query.Played = &played
+	}
+	start, startErr := strconv.Atoi(ctx.Query("start"))
+	end, endErr := strconv.Atoi(ctx.Query("end"))
+	if startErr == nil && endErr == nil && start >= 0 && end > start {
+		query.Start, query.Count = start, end-start
+	}
+
+	episodes, total, err := c.PodcastUsecase.GetEpisodes(ctx.Request.Context(), ctx.GetString(domain.UserIDKey), query)
+	if err != nil {
+		podcastError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "episodes", episodes, total)
+}
+
+func (c *PodcastController) GetEpisode(ctx *gin.Context) {
+	episode, err := c.PodcastUsecase.GetEpisode(ctx.Request.Context(), ctx.GetString(domain.UserIDKey), ctx.Param("id"))
+	if err != nil {
+		podcastError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "episode", episode, 1)
+}
+
+// UpdateProgress position 为秒，played 标记是否听完；两者至少提供一个
+func (c *PodcastController) UpdateProgress(ctx *gin.Context) {
+	var progress scene_audio_route_models.PodcastProgress
+	if raw := ctx.PostForm("position"); raw != "" {
+		position, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", "position 必须为秒数")
+			return
+		}
+		progress.Position = &position
+	}
+	if raw := ctx.PostForm("played"); raw != "" {
+		played, err := strconv.ParseBool(raw)
+		if err != nil {
+			controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", "played 必须为 true/false")
+			return
+		}
+		progress.Played = &played
+	}
+
+	episode, err := c.PodcastUsecase.UpdateProgress(ctx.Request.Context(), ctx.GetString(domain.UserIDKey), ctx.Param("id"), progress)
+	if err != nil {
+		podcastError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "episode", episode, 1)
+}
+
+// DownloadEpisode 下载在后台进行，进度见单集的 download_status
+func (c *PodcastController) DownloadEpisode(ctx *gin.Context) {
+	episode, err := c.PodcastUsecase.DownloadEpisode(ctx.Request.Context(), ctx.GetString(domain.UserIDKey), ctx.Param("id"))
+	if err != nil {
+		podcastError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "episode", episode, 1)
+}
+
+func (c *PodcastController) DeleteDownload(ctx *gin.Context) {
+	episode, err := c.PodcastUsecase.DeleteDownload(ctx.Request.Context(), ctx.GetString(domain.UserIDKey), ctx.Param("id"))
+	if err != nil {
+		podcastError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "episode", episode, 1)
+}
+
+func podcastError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, scene_audio_route_models.ErrInvalidPodcast):
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
+	case errors.Is(err, scene_audio_route_models.ErrPodcastFeedUnavailable):
+		controller.ErrorResponse(ctx, http.StatusBadGateway, "REMOTE_UNAVAILABLE", err.Error())
+	case errors.Is(err, scene_audio_route_models.ErrPodcastExists):
+		controller.ErrorResponse(ctx, http.StatusConflict, "NAME_CONFLICT", err.Error())
+	case errors.Is(err, scene_audio_route_models.ErrPodcastLimit):
+		controller.ErrorResponse(ctx, http.StatusConflict, "QUOTA_EXCEEDED", err.Error())
+	case errors.Is(err, scene_audio_route_models.ErrPodcastDownloadDisabled):
+		controller.ErrorResponse(ctx, http.StatusServiceUnavailable, "NOT_CONFIGURED", err.Error())
+	case domain.IsNotFound(err):
+		controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", err.Error())
+	default:
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+	}
+}
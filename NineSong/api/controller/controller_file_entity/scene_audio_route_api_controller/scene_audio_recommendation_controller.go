@@ -0,0 +1,67 @@
+package scene_audio_route_api_controller
+
+import (
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/gin-gonic/gin"
+)
+
+type RecommendationController struct {
+	RecommendationUsecase scene_audio_route_interface.RecommendationUsecase
+	Links                 LinkBuilder
+}
+
+func NewRecommendationController(uc scene_audio_route_interface.RecommendationUsecase, links LinkBuilder) *RecommendationController {
+	return &RecommendationController{RecommendationUsecase: uc, Links: links}
+}
+
+func (c *RecommendationController) GetSimilarArtists(ctx *gin.Context) {
+	var req struct {
+		Limit int `form:"limit"`
+	}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
+		return
+	}
+
+	result, err := c.RecommendationUsecase.GetSimilarArtists(ctx.Request.Context(), ctx.Param("id"), req.Limit)
+	if err != nil {
+		if domain.IsNotFound(err) {
+			controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", "artist not found")
+			return
+		}
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "similar_artists", result, len(result))
+}
+
+func (c *RecommendationController) GetRecommendations(ctx *gin.Context) {
+	var req struct {
+		Limit int `form:"limit"`
+	}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
+		return
+	}
+
+	result, err := c.RecommendationUsecase.GetRecommendations(ctx.Request.Context(), ctx.GetString("x-user-id"), req.Limit)
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+		return
+	}
+
+	albums := make([]scene_audio_route_models.AlbumMetadata, 0, len(result.Albums))
+	for _, album := range result.Albums {
+		albums = append(albums, album.Album)
+	}
+	c.Links.FillAlbumLinks(ctx, albums)
+	for i := range result.Albums {
+		result.Albums[i].Album = albums[i]
+	}
+	controller.SuccessResponse(ctx, "recommendations", result, len(result.Artists)+len(result.Albums))
+}
@@ -4,7 +4,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_transcode/scene_audio_transcode_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_transcode/scene_audio_transcode_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/http_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/image_util"
 	"github.com/gin-gonic/gin"
 	ffmpeggo "github.com/u2takey/ffmpeg-go"
 	"io"
@@ -13,16 +20,46 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 )
 
 type RetrievalController struct {
-	RetrievalUsecase scene_audio_route_interface.RetrievalRepository
+	RetrievalUsecase      scene_audio_route_interface.RetrievalRepository
+	EqPresetUsecase       scene_audio_route_interface.EqPresetRepository
+	UserPreferenceUsecase scene_audio_route_interface.UserPreferenceRepository
+	TranscodeService      scene_audio_transcode_interface.TranscodeService
 }
 
-func NewRetrievalController(uc scene_audio_route_interface.RetrievalRepository) *RetrievalController {
-	return &RetrievalController{RetrievalUsecase: uc}
+func NewRetrievalController(
+	uc scene_audio_route_interface.RetrievalRepository,
+	eqUc scene_audio_route_interface.EqPresetRepository,
+	prefUc scene_audio_route_interface.UserPreferenceRepository,
+	transcoder scene_audio_transcode_interface.TranscodeService,
+) *RetrievalController {
+	return &RetrievalController{
+		RetrievalUsecase:      uc,
+		EqPresetUsecase:       eqUc,
+		UserPreferenceUsecase: prefUc,
+		TranscodeService:      transcoder,
+	}
+}
+
+// 流媒体转码参数，format为空或raw且无音效处理时返回原始文件
+type streamTranscodeParams struct {
+	Format        string `form:"format"`
+	MaxBitRate    int    `form:"maxBitRate"`
+	EqPreset      string `form:"eq_preset"`
+	ChannelLayout string `form:"channel_layout"` // mono | stereo | left | right
+	ReplayGain    string `form:"replay_gain"`    // track | album | off，为空时使用用户偏好
+}
+
+// 均衡器、声道转换与音量标准化（参数或用户偏好）都需要重新编码
+func (p streamTranscodeParams) requiresProcessing() bool {
+	replayGain := strings.ToLower(p.ReplayGain)
+	return len(p.EqPreset) > 0 || len(p.ChannelLayout) > 0 ||
+		(replayGain != "" && replayGain != scene_audio_transcode_models.ReplayGainModeOff)
 }
 
 func (c *RetrievalController) FixedStreamHandler(ctx *gin.Context) {
@@ -30,6 +67,8 @@ func (c *RetrievalController) FixedStreamHandler(ctx *gin.Context) {
 		MediaFileID       string `form:"media_file_id" binding:"required"`
 		PlayComponentType string `form:"play_component_type"`
 		CueModel          bool   `form:"cue_model"`
+		CueTrack          int    `form:"cue_track"` // 配合 cue_model 只播放整轨中的该音轨
+		streamTranscodeParams
 	}
 
 	if err := ctx.ShouldBind(&req); err != nil {
@@ -55,7 +94,17 @@ func (c *RetrievalController) FixedStreamHandler(ctx *gin.Context) {
 		})
 		return
 	}
+	if isRemoteStream(filePath) {
+		proxyRemoteStream(ctx, filePath)
+		return
+	}
 	tempSteamFolderPath, _ := c.RetrievalUsecase.GetStreamTempPath(ctx.Request.Context(), "stream")
+	filePath = cachedSourceFallback(filePath, req.MediaFileID, tempSteamFolderPath)
+	if c.serveTranscodedIfRequested(ctx, filePath, req.MediaFileID, req.CueModel, req.CueTrack, tempSteamFolderPath, req.streamTranscodeParams) {
+		return
+	}
+	c.setReplayGainHeaders(ctx, req.MediaFileID, req.CueModel)
+	c.setGaplessHeaders(ctx, req.MediaFileID, req.CueModel)
 	serveFixedMediaFile(ctx, filePath, req.MediaFileID, tempSteamFolderPath, req.PlayComponentType)
 }
 
@@ -64,6 +113,8 @@ func (c *RetrievalController) RealStreamHandler(ctx *gin.Context) {
 		MediaFileID       string `form:"media_file_id" binding:"required"`
 		PlayComponentType string `form:"play_component_type"`
 		CueModel          bool   `form:"cue_model"`
+		CueTrack          int    `form:"cue_track"` // 配合 cue_model 只播放整轨中的该音轨
+		streamTranscodeParams
 	}
 
 	if err := ctx.ShouldBind(&req); err != nil {
@@ -89,7 +140,17 @@ func (c *RetrievalController) RealStreamHandler(ctx *gin.Context) {
 		})
 		return
 	}
+	if isRemoteStream(filePath) {
+		proxyRemoteStream(ctx, filePath)
+		return
+	}
 	tempSteamFolderPath, _ := c.RetrievalUsecase.GetStreamTempPath(ctx.Request.Context(), "stream")
+	filePath = cachedSourceFallback(filePath, req.MediaFileID, tempSteamFolderPath)
+	if c.serveTranscodedIfRequested(ctx, filePath, req.MediaFileID, req.CueModel, req.CueTrack, tempSteamFolderPath, req.streamTranscodeParams) {
+		return
+	}
+	c.setReplayGainHeaders(ctx, req.MediaFileID, req.CueModel)
+	c.setGaplessHeaders(ctx, req.MediaFileID, req.CueModel)
 	realStreamMediaFile(ctx, filePath, req.MediaFileID, tempSteamFolderPath, req.PlayComponentType)
 }
 
@@ -115,6 +176,10 @@ func (c *RetrievalController) DownloadHandler(ctx *gin.Context) {
 		})
 		return
 	}
+	if isRemoteStream(filePath) {
+		proxyRemoteStream(ctx, filePath)
+		return
+	}
 	tempSteamFolderPath, _ := c.RetrievalUsecase.GetStreamTempPath(ctx.Request.Context(), "stream")
 	serveFixedMediaFile(ctx, filePath, req.MediaFileID, tempSteamFolderPath, "")
 }
@@ -146,6 +211,84 @@ func (c *RetrievalController) CoverArtIDHandler(ctx *gin.Context) {
 	ctx.File(filePath)
 }
 
+// 缩略图尺寸范围，超出时取边界值
+const (
+	minCoverArtSize = 32
+	maxCoverArtSize = 2048
+)
+
+// CoverArtHandler 按条目ID返回封面，size 指定最长边像素；缩略图缓存在封面目录的 thumbs 下，按 id+size 复用。
+// 携带 v（条目的 art_version）时封面变化后 URL 随之变化，允许客户端永久缓存
+func (c *RetrievalController) CoverArtHandler(ctx *gin.Context) {
+	targetID := ctx.Param("id")
+	size := 0
+	if value := ctx.Query("size"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"code":    "INVALID_PARAMETERS",
+				"message": "参数格式错误: size必须为非负整数",
+			})
+			return
+		}
+		size = min(max(parsed, minCoverArtSize), maxCoverArtSize)
+	}
+
+	filePath, err := c.RetrievalUsecase.GetCoverArtByID(ctx.Request.Context(), targetID)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"code":    "COVER_NOT_FOUND",
+			"message": "封面文件不存在",
+		})
+		return
+	}
+	if ctx.Query("v") != "" {
+		ctx.Header("Cache-Control", "public, max-age=31536000, immutable")
+	} else {
+		ctx.Header("Cache-Control", "public, max-age=3600")
+	}
+	if size == 0 {
+		ctx.Header("Content-Type", detectContentType(filePath))
+		ctx.File(filePath)
+		return
+	}
+
+	coverFolderPath, err := c.RetrievalUsecase.GetStreamTempPath(ctx.Request.Context(), "cover")
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"code":    "SERVER_ERROR",
+			"message": "封面缓存目录未配置",
+		})
+		return
+	}
+	thumbPath := filepath.Join(coverFolderPath, "thumbs", fmt.Sprintf("%s_%d.jpg", targetID, size))
+	if thumbnailStale(filePath, thumbPath) {
+		if err := image_util.ResizeToJPEG(filePath, thumbPath, size); err != nil {
+			log.Printf("生成封面缩略图失败 %s: %v", filePath, err)
+			// 无法解码的图片直接返回原图
+			ctx.Header("Content-Type", detectContentType(filePath))
+			ctx.File(filePath)
+			return
+		}
+	}
+
+	ctx.Header("Content-Type", "image/jpeg")
+	ctx.File(thumbPath)
+}
+
+// thumbnailStale 缩略图不存在或早于原图修改时间时需要重新生成
+func thumbnailStale(sourcePath, thumbPath string) bool {
+	thumbInfo, err := os.Stat(thumbPath)
+	if err != nil {
+		return true
+	}
+	sourceInfo, err := os.Stat(sourcePath)
+	if err != nil {
+		return false
+	}
+	return thumbInfo.ModTime().Before(sourceInfo.ModTime())
+}
+
 func (c *RetrievalController) CoverArtPathHandler(ctx *gin.Context) {
 	var req struct {
 		Type     string `form:"type" binding:"required,oneof=back cover disc"`
@@ -322,6 +465,8 @@ func detectContentType(path string) string {
 		return "image/png"
 	case ".mp3":
 		return "audio/mpeg"
+	case ".aac":
+		return "audio/aac"
 	case ".lrc":
 		return "text/plain; charset=utf-8"
 	default:
@@ -394,9 +539,70 @@ func isALACEncoded(path string) bool {
 	return false
 }
 
+// cachedSourceFallback 源文件不可访问时改用流媒体缓存中的转码副本
+func cachedSourceFallback(filePath, mediaFileID, tempSteamFolderPath string) string {
+	if _, err := os.Stat(filePath); err == nil || tempSteamFolderPath == "" {
+		return filePath
+	}
+	cachedPath := filepath.Join(tempSteamFolderPath, scene_audio_db_models.CachedStreamFileName(mediaFileID))
+	if info, err := os.Stat(cachedPath); err == nil && info.Size() > 0 {
+		return cachedPath
+	}
+	return filePath
+}
+
+// remoteStreamClient 只限制等待响应头的时间，不限制整体传输时长；附件地址来自用户订阅的播客源，
+// 只允许连接公网地址，重定向同样检查
+var remoteStreamClient = func() *http.Client {
+	transport := http_util.PublicTransport()
+	transport.ResponseHeaderTimeout = 30 * time.Second
+	return &http.Client{Transport: transport, CheckRedirect: http_util.CheckPublicRedirect}
+}()
+
+// isRemoteStream 未下载的播客单集以附件地址作为流路径
+func isRemoteStream(path string) bool {
+	return strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://")
+}
+
+// proxyRemoteStream 透传 Range 请求与相关响应头，远程附件不做转码
+func proxyRemoteStream(ctx *gin.Context, remoteURL string) {
+	req, err := http.NewRequestWithContext(ctx.Request.Context(), http.MethodGet, remoteURL, nil)
+	if err != nil {
+		ctx.JSON(http.StatusBadGateway, gin.H{
+			"code":    "REMOTE_UNAVAILABLE",
+			"message": "远程音频地址无效",
+		})
+		return
+	}
+	if value := ctx.GetHeader("Range"); value != "" {
+		req.Header.Set("Range", value)
+	}
+	req.Header.Set("User-Agent", http_util.DefaultUserAgent)
+
+	resp, err := remoteStreamClient.Do(req)
+	if err != nil {
+		// 连接错误可能含有解析出的内网地址，只记录日志
+		log.Printf("远程音频连接失败 %s: %v", remoteURL, err)
+		ctx.JSON(http.StatusBadGateway, gin.H{
+			"code":    "REMOTE_UNAVAILABLE",
+			"message": "无法连接远程音频",
+		})
+		return
+	}
+	defer resp.Body.Close()
+
+	for _, key := range federationStreamHeaders {
+		if value := resp.Header.Get(key); value != "" {
+			ctx.Header(key, value)
+		}
+	}
+	ctx.Status(resp.StatusCode)
+	_, _ = io.Copy(ctx.Writer, resp.Body)
+}
+
 // ALAC转AAC转码函数
 func transcodeALACtoAAC(inputPath string, mediaFileID string, tempSteamFolderPath string) (string, error) {
-	fileName := "transcoded_" + mediaFileID + ".aac"
+	fileName := scene_audio_db_models.CachedStreamFileName(mediaFileID)
 
 	tmpPath := filepath.Join(tempSteamFolderPath, fileName)
 
@@ -444,3 +650,237 @@ func transcodeALACtoAAC(inputPath string, mediaFileID string, tempSteamFolderPat
 	}
 	return tmpPath, nil
 }
+
+// 请求携带转码参数或指定了 CUE 音轨时走转码流程，返回true表示响应已处理
+func (c *RetrievalController) serveTranscodedIfRequested(
+	ctx *gin.Context,
+	path string,
+	mediaFileID string,
+	cueModel bool,
+	cueTrack int,
+	tempSteamFolderPath string,
+	params streamTranscodeParams,
+) bool {
+	var segment *scene_audio_route_models.RetrievalCueSegment
+	if cueModel && cueTrack > 0 {
+		var err error
+		segment, err = c.RetrievalUsecase.GetCueSegment(ctx.Request.Context(), mediaFileID, cueTrack)
+		if err != nil {
+			ctx.JSON(http.StatusNotFound, gin.H{
+				"code":    "CUE_TRACK_NOT_FOUND",
+				"message": "CUE音轨不存在: " + strconv.Itoa(cueTrack),
+			})
+			return true
+		}
+	}
+
+	// 整轨 CUE 播放不做音量标准化，无需读取用户偏好
+	if !cueModel || segment != nil {
+		params.ReplayGain = c.resolveReplayGainMode(ctx, params.ReplayGain)
+	}
+
+	format := params.Format
+	if !scene_audio_transcode_models.IsTranscodeFormat(format) {
+		switch {
+		case segment != nil:
+			// 截取整轨时默认输出FLAC，保持无损
+			format = "flac"
+		case params.requiresProcessing():
+			// 未指定格式时默认输出AAC
+			format = "aac"
+		default:
+			return false
+		}
+	}
+
+	profile, err := scene_audio_transcode_models.NewTranscodeProfile(format, params.MaxBitRate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"code":    "INVALID_PARAMETERS",
+			"message": "不支持的转码格式: " + format,
+		})
+		return true
+	}
+
+	if err := profile.ApplyChannelLayout(params.ChannelLayout); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"code":    "INVALID_PARAMETERS",
+			"message": "channel_layout必须为mono、stereo、left或right",
+		})
+		return true
+	}
+
+	if len(params.EqPreset) > 0 {
+		preset, err := c.EqPresetUsecase.GetEqPreset(ctx.Request.Context(), ctx.GetString("x-user-id"), params.EqPreset)
+		if err != nil {
+			if !domain.IsNotFound(err) {
+				ctx.JSON(http.StatusInternalServerError, gin.H{
+					"code":    "SERVER_ERROR",
+					"message": "读取均衡器预设失败: " + err.Error(),
+				})
+				return true
+			}
+			ctx.JSON(http.StatusNotFound, gin.H{
+				"code":    "EQ_PRESET_NOT_FOUND",
+				"message": "均衡器预设不存在: " + params.EqPreset,
+			})
+			return true
+		}
+		profile.AudioFilters = append(profile.AudioFilters, buildEqualizerFilter(preset))
+	}
+
+	if segment != nil {
+		profile.ApplySegment(segment.Start, segment.Duration)
+	}
+
+	if !c.applyReplayGain(ctx, &profile, mediaFileID, cueModel, segment, params.ReplayGain) {
+		return true
+	}
+
+	serveTranscodedMediaFile(ctx, c.TranscodeService, path, tempSteamFolderPath, profile)
+	return true
+}
+
+// resolveReplayGainMode 参数优先，未指定时使用用户偏好
+func (c *RetrievalController) resolveReplayGainMode(ctx *gin.Context, mode string) string {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if mode != "" {
+		return mode
+	}
+	pref, err := c.UserPreferenceUsecase.GetUserPreference(ctx.Request.Context(), ctx.GetString("x-user-id"))
+	if err != nil || pref == nil {
+		return ""
+	}
+	return strings.ToLower(pref.ReplayGainMode)
+}
+
+// 音量标准化：mode 已由 resolveReplayGainMode 合并用户偏好；CUE音轨使用CUE中的曲目增益，整轨播放时跳过
+func (c *RetrievalController) applyReplayGain(
+	ctx *gin.Context,
+	profile *scene_audio_transcode_models.TranscodeProfile,
+	mediaFileID string,
+	cueModel bool,
+	segment *scene_audio_route_models.RetrievalCueSegment,
+	mode string,
+) bool {
+	if mode == "" || mode == scene_audio_transcode_models.ReplayGainModeOff {
+		return true
+	}
+	if !scene_audio_transcode_models.IsValidReplayGainMode(mode) {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"code":    "INVALID_PARAMETERS",
+			"message": "replay_gain必须为track、album或off",
+		})
+		return false
+	}
+	if cueModel {
+		if segment != nil {
+			profile.ApplyReplayGain(segment.Gain, segment.Peak)
+		}
+		return true
+	}
+
+	gain, err := c.RetrievalUsecase.GetReplayGain(ctx.Request.Context(), mediaFileID)
+	if err != nil {
+		log.Printf("读取ReplayGain失败: %v", err)
+		return true
+	}
+	if mode == scene_audio_transcode_models.ReplayGainModeAlbum && gain.RGAlbumGain != 0 {
+		profile.ApplyReplayGain(gain.RGAlbumGain, gain.RGAlbumPeak)
+	} else {
+		profile.ApplyReplayGain(gain.RGTrackGain, gain.RGTrackPeak)
+	}
+	return true
+}
+
+// 原始音频流通过响应头返回 ReplayGain，由客户端自行标准化音量
+func (c *RetrievalController) setReplayGainHeaders(ctx *gin.Context, mediaFileID string, cueModel bool) {
+	if cueModel {
+		return
+	}
+	gain, err := c.RetrievalUsecase.GetReplayGain(ctx.Request.Context(), mediaFileID)
+	if err != nil {
+		return
+	}
+	for header, value := range map[string]float64{
+		"X-ReplayGain-Track-Gain": gain.RGTrackGain,
+		"X-ReplayGain-Track-Peak": gain.RGTrackPeak,
+		"X-ReplayGain-Album-Gain": gain.RGAlbumGain,
+		"X-ReplayGain-Album-Peak": gain.RGAlbumPeak,
+	} {
+		if value != 0 {
+			ctx.Header(header, strconv.FormatFloat(value, 'f', -1, 64))
+		}
+	}
+}
+
+// 原始音频流通过响应头返回编码器延迟、填充与精确采样数，供支持无缝播放的客户端拼接曲目
+func (c *RetrievalController) setGaplessHeaders(ctx *gin.Context, mediaFileID string, cueModel bool) {
+	if cueModel {
+		return
+	}
+	info, err := c.RetrievalUsecase.GetGaplessInfo(ctx.Request.Context(), mediaFileID)
+	if err != nil || info.TotalSamples <= 0 {
+		return
+	}
+	ctx.Header("X-Encoder-Delay", strconv.Itoa(info.EncoderDelay))
+	ctx.Header("X-Encoder-Padding", strconv.Itoa(info.EncoderPadding))
+	ctx.Header("X-Total-Samples", strconv.FormatInt(info.TotalSamples, 10))
+	if info.SampleRate > 0 {
+		ctx.Header("X-Sample-Rate", strconv.Itoa(info.SampleRate))
+		ctx.Header("X-Content-Duration", strconv.FormatFloat(float64(info.TotalSamples)/float64(info.SampleRate), 'f', 6, 64))
+	}
+}
+
+// 命中缓存时按文件返回（支持范围请求），否则将ffmpeg输出直接写入响应
+func serveTranscodedMediaFile(
+	ctx *gin.Context,
+	transcoder scene_audio_transcode_interface.TranscodeService,
+	path string,
+	tempSteamFolderPath string,
+	profile scene_audio_transcode_models.TranscodeProfile,
+) {
+	cachedPath, hit, err := transcoder.CachedPath(ctx.Request.Context(), path, tempSteamFolderPath, profile)
+	if err != nil {
+		handleFileError(ctx, path, err)
+		return
+	}
+
+	ctx.Header("Content-Type", profile.Format.MimeType)
+	ctx.Header("Cache-Control", "public, max-age=86400")
+
+	if hit {
+		ctx.Header("X-Transcode-Cache", "hit")
+		ctx.File(cachedPath)
+		return
+	}
+
+	ctx.Header("X-Transcode-Cache", "miss")
+	ctx.Status(http.StatusOK)
+	if err := transcoder.Stream(ctx.Request.Context(), ctx.Writer, path, tempSteamFolderPath, profile); err != nil {
+		log.Printf("转码输出失败: %v", err)
+		if !ctx.Writer.Written() {
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"code":    "TRANSCODE_FAILED",
+				"message": "音频转码失败",
+			})
+		}
+	}
+}
+
+// 构建ffmpeg均衡器滤镜链
+func buildEqualizerFilter(preset *scene_audio_route_models.EqPresetMetadata) string {
+	filters := make([]string, 0, len(preset.Bands)+1)
+	if preset.PreAmp != 0 {
+		filters = append(filters, fmt.Sprintf("volume=%.2fdB", preset.PreAmp))
+	}
+	for _, band := range preset.Bands {
+		filters = append(filters, fmt.Sprintf(
+			"equalizer=f=%.1f:t=q:w=%.2f:g=%.2f",
+			band.Frequency, band.Width, band.Gain,
+		))
+	}
+	// 防止增益叠加导致削波
+	filters = append(filters, "alimiter=limit=0.97")
+	return strings.Join(filters, ",")
+}
@@ -0,0 +1,67 @@
+package scene_audio_route_api_controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ScrobbleController struct {
+	ScrobbleUsecase scene_audio_route_interface.ScrobbleRepository
+}
+
+func NewScrobbleController(uc scene_audio_route_interface.ScrobbleRepository) *ScrobbleController {
+	return &ScrobbleController{ScrobbleUsecase: uc}
+}
+
+func (c *ScrobbleController) Scrobble(ctx *gin.Context) {
+	var req struct {
+		MediaFileID string `form:"media_file_id" binding:"required"`
+		Complete    bool   `form:"complete"`
+		Client      string `form:"client"`
+		// DurationPlayed 实际播放秒数；PlayedAt 为 Unix 秒，离线播放补报时使用
+		DurationPlayed float64 `form:"duration_played"`
+		PlayedAt       int64   `form:"played_at"`
+	}
+
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+
+	mediaFileID, err := primitive.ObjectIDFromHex(req.MediaFileID)
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMETERS", "无效的媒体文件ID")
+		return
+	}
+
+	var playedAt time.Time
+	if req.PlayedAt > 0 {
+		playedAt = time.Unix(req.PlayedAt, 0).UTC()
+	}
+
+	history, err := c.ScrobbleUsecase.Scrobble(ctx.Request.Context(), scene_audio_route_models.PlayHistoryMetadata{
+		UserID:      ctx.GetString("x-user-id"),
+		MediaFileID: mediaFileID,
+		Client:      req.Client,
+		Complete:    req.Complete,
+
+		DurationPlayed: req.DurationPlayed,
+		PlayedAt:       playedAt,
+	})
+	if err != nil {
+		if domain.IsNotFound(err) {
+			controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", "media file not found")
+			return
+		}
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "play_history", history, 1)
+}
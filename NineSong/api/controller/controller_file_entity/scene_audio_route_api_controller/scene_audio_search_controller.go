@@ -0,0 +1,89 @@
+package scene_audio_route_api_controller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/gin-gonic/gin"
+)
+
+type SearchController struct {
+	SearchUsecase scene_audio_route_interface.SearchRepository
+}
+
+func NewSearchController(uc scene_audio_route_interface.SearchRepository) *SearchController {
+	return &SearchController{SearchUsecase: uc}
+}
+
+func (c *SearchController) Search(ctx *gin.Context) {
+	// 各分组数量未传时使用默认值，传 0 表示不检索该分组
+	req := struct {
+		Query        string `form:"query" binding:"required"`
+		SongOffset   int    `form:"song_offset"`
+		SongCount    *int   `form:"song_count"`
+		AlbumOffset  int    `form:"album_offset"`
+		AlbumCount   *int   `form:"album_count"`
+		ArtistOffset int    `form:"artist_offset"`
+		ArtistCount  *int   `form:"artist_count"`
+		RadioOffset  int    `form:"radio_offset"`
+		RadioCount   *int   `form:"radio_count"`
+	}{}
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+
+	result, err := c.SearchUsecase.Search(ctx.Request.Context(), req.Query, scene_audio_route_models.SearchPaging{
+		SongOffset:   req.SongOffset,
+		SongCount:    searchCountOrDefault(req.SongCount),
+		AlbumOffset:  req.AlbumOffset,
+		AlbumCount:   searchCountOrDefault(req.AlbumCount),
+		ArtistOffset: req.ArtistOffset,
+		ArtistCount:  searchCountOrDefault(req.ArtistCount),
+		RadioOffset:  req.RadioOffset,
+		RadioCount:   searchCountOrDefault(req.RadioCount),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "search failed") {
+			controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+			return
+		}
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMETERS", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "search", result, result.SongTotal+result.AlbumTotal+result.ArtistTotal+result.RadioTotal)
+}
+
+// SearchLyrics 按歌词片段查找曲目，返回命中行及高亮区间；同步歌词附带命中行的时间
+func (c *SearchController) SearchLyrics(ctx *gin.Context) {
+	req := struct {
+		Query  string `form:"q" binding:"required"`
+		Offset int    `form:"offset"`
+		Count  *int   `form:"count"`
+	}{}
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+
+	result, err := c.SearchUsecase.SearchLyrics(ctx.Request.Context(), req.Query, req.Offset, searchCountOrDefault(req.Count))
+	if err != nil {
+		if strings.Contains(err.Error(), "search failed") {
+			controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+			return
+		}
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMETERS", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "lyrics_search", result, result.Total)
+}
+
+func searchCountOrDefault(count *int) int {
+	if count == nil {
+		return scene_audio_route_models.SearchDefaultCount
+	}
+	return *count
+}
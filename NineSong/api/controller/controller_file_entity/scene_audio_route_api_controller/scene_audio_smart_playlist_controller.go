@@ -0,0 +1,185 @@
+package scene_audio_route_api_controller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type SmartPlaylistController struct {
+	SmartPlaylistUsecase scene_audio_route_interface.SmartPlaylistRepository
+}
+
+func NewSmartPlaylistController(uc scene_audio_route_interface.SmartPlaylistRepository) *SmartPlaylistController {
+	return &SmartPlaylistController{SmartPlaylistUsecase: uc}
+}
+
+func (c *SmartPlaylistController) GetSmartPlaylists(ctx *gin.Context) {
+	playlists, err := c.SmartPlaylistUsecase.GetSmartPlaylists(ctx.Request.Context())
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "smart_playlists", playlists, len(playlists))
+}
+
+func (c *SmartPlaylistController) GetSmartPlaylist(ctx *gin.Context) {
+	var req struct {
+		ID string `form:"id" binding:"required"`
+	}
+
+	if err := ctx.ShouldBindWith(&req, binding.Form); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "MISSING_PARAMETER", "Missing ID parameter")
+		return
+	}
+
+	playlist, err := c.SmartPlaylistUsecase.GetSmartPlaylist(ctx.Request.Context(), req.ID)
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", "smart playlist not found")
+		return
+	}
+	controller.SuccessResponse(ctx, "smart_playlist", playlist, 1)
+}
+
+// CreateSmartPlaylist rules参数为规则JSON文档
+func (c *SmartPlaylistController) CreateSmartPlaylist(ctx *gin.Context) {
+	var req struct {
+		Name    string `form:"name" binding:"required"`
+		Comment string `form:"comment"`
+		Rules   string `form:"rules" binding:"required"`
+	}
+
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+
+	rules, ok := parseSmartRules(ctx, req.Rules)
+	if !ok {
+		return
+	}
+
+	created, err := c.SmartPlaylistUsecase.CreateSmartPlaylist(ctx.Request.Context(), scene_audio_route_models.SmartPlaylistMetadata{
+		Name:    req.Name,
+		Comment: req.Comment,
+		OwnerID: ctx.GetString("x-user-id"),
+		Rules:   rules,
+	})
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "CREATION_FAILED", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "smart_playlist", created, 1)
+}
+
+func (c *SmartPlaylistController) UpdateSmartPlaylist(ctx *gin.Context) {
+	var req struct {
+		ID      string `form:"id" binding:"required"`
+		Name    string `form:"name" binding:"required"`
+		Comment string `form:"comment"`
+		Rules   string `form:"rules" binding:"required"`
+	}
+
+	if err := ctx.ShouldBindWith(&req, binding.Form); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+
+	rules, ok := parseSmartRules(ctx, req.Rules)
+	if !ok {
+		return
+	}
+
+	updated, err := c.SmartPlaylistUsecase.UpdateSmartPlaylist(ctx.Request.Context(), req.ID, scene_audio_route_models.SmartPlaylistMetadata{
+		Name:    req.Name,
+		Comment: req.Comment,
+		Rules:   rules,
+	})
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "UPDATE_FAILED", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "smart_playlist", updated, 1)
+}
+
+func (c *SmartPlaylistController) DeleteSmartPlaylist(ctx *gin.Context) {
+	var req struct {
+		ID string `form:"id" binding:"required"`
+	}
+
+	if err := ctx.ShouldBindWith(&req, binding.Form); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "MISSING_PARAMETER", "Missing ID parameter")
+		return
+	}
+
+	success, err := c.SmartPlaylistUsecase.DeleteSmartPlaylist(ctx.Request.Context(), req.ID)
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "DELETE_FAILED", err.Error())
+		return
+	}
+	if !success {
+		controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", "smart playlist not found")
+		return
+	}
+	controller.SuccessResponse(ctx, "success", success, 1)
+}
+
+func (c *SmartPlaylistController) GetSmartPlaylistTracks(ctx *gin.Context) {
+	var req struct {
+		ID    string `form:"id" binding:"required"`
+		Start string `form:"start"`
+		End   string `form:"end"`
+	}
+
+	if err := ctx.ShouldBindWith(&req, binding.Form); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "MISSING_PARAMETER", "Missing ID parameter")
+		return
+	}
+
+	tracks, err := c.SmartPlaylistUsecase.GetSmartPlaylistTracks(ctx.Request.Context(), req.ID, req.Start, req.End)
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "media_files", tracks, len(tracks))
+}
+
+// PreviewSmartPlaylist 按未保存的规则即时求值
+func (c *SmartPlaylistController) PreviewSmartPlaylist(ctx *gin.Context) {
+	var req struct {
+		Rules string `form:"rules" binding:"required"`
+		Start string `form:"start"`
+		End   string `form:"end"`
+	}
+
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "BINDING_ERROR", err.Error())
+		return
+	}
+
+	rules, ok := parseSmartRules(ctx, req.Rules)
+	if !ok {
+		return
+	}
+
+	tracks, err := c.SmartPlaylistUsecase.PreviewSmartPlaylist(ctx.Request.Context(), rules, req.Start, req.End)
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "PREVIEW_FAILED", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "media_files", tracks, len(tracks))
+}
+
+func parseSmartRules(ctx *gin.Context, raw string) (scene_audio_route_models.SmartPlaylistRules, bool) {
+	var rules scene_audio_route_models.SmartPlaylistRules
+	if err := json.Unmarshal([]byte(raw), &rules); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMETERS", "rules格式错误: "+err.Error())
+		return rules, false
+	}
+	return rules, true
+}
@@ -0,0 +1,33 @@
+package scene_audio_route_api_controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/gin-gonic/gin"
+)
+
+type StatsOverviewController struct {
+	StatsOverviewUsecase scene_audio_route_interface.StatsOverviewUsecase
+}
+
+func NewStatsOverviewController(uc scene_audio_route_interface.StatsOverviewUsecase) *StatsOverviewController {
+	return &StatsOverviewController{StatsOverviewUsecase: uc}
+}
+
+// GetOverview 当前用户的收听概览：总时长、去重数、流派分布、按小时热力图与连续收听天数
+func (c *StatsOverviewController) GetOverview(ctx *gin.Context) {
+	overview, err := c.StatsOverviewUsecase.GetOverview(ctx.Request.Context(), ctx.GetString("x-user-id"), ctx.Query("window"))
+	if err != nil {
+		if errors.Is(err, scene_audio_route_models.ErrInvalidChartWindow) {
+			controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
+			return
+		}
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "overview", overview, 1)
+}
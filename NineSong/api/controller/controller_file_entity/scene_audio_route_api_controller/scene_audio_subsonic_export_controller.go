@@ -0,0 +1,76 @@
+package scene_audio_route_api_controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_subsonic/scene_audio_subsonic_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_subsonic/scene_audio_subsonic_models"
+	"github.com/gin-gonic/gin"
+)
+
+type SubsonicExportController struct {
+	SubsonicExportUsecase scene_audio_subsonic_interface.SubsonicExportUsecase
+}
+
+func NewSubsonicExportController(uc scene_audio_subsonic_interface.SubsonicExportUsecase) *SubsonicExportController {
+	return &SubsonicExportController{SubsonicExportUsecase: uc}
+}
+
+// GetPlaylist 输出与 Subsonic getPlaylist 一致的 JSON，id 可为 ObjectID 或已分配的整数ID
+func (c *SubsonicExportController) GetPlaylist(ctx *gin.Context) {
+	playlist, err := c.SubsonicExportUsecase.GetPlaylist(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		subsonicExportError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{
+		"subsonic-response": gin.H{
+			"status":   "ok",
+			"version":  scene_audio_subsonic_models.SubsonicAPIVersion,
+			"playlist": playlist,
+		},
+	})
+}
+
+// GetInternetRadioStations 输出与 Subsonic getInternetRadioStations 一致的 JSON
+func (c *SubsonicExportController) GetInternetRadioStations(ctx *gin.Context) {
+	stations, err := c.SubsonicExportUsecase.GetInternetRadioStations(ctx.Request.Context(), ctx.GetString(domain.UserIDKey))
+	if err != nil {
+		subsonicExportError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{
+		"subsonic-response": gin.H{
+			"status":  "ok",
+			"version": scene_audio_subsonic_models.SubsonicAPIVersion,
+			"internetRadioStations": gin.H{
+				"internetRadioStation": stations,
+			},
+		},
+	})
+}
+
+// ResolveID 将客户端回传的整数ID还原为 ObjectID
+func (c *SubsonicExportController) ResolveID(ctx *gin.Context) {
+	itemType := ctx.DefaultQuery("type", scene_audio_subsonic_models.NumericItemMedia)
+	itemId, err := c.SubsonicExportUsecase.ResolveItemID(ctx.Request.Context(), itemType, ctx.Param("id"))
+	if err != nil {
+		subsonicExportError(ctx, err)
+		return
+	}
+	controller.SuccessResponse(ctx, "item", gin.H{"id": itemId, "type": itemType}, 1)
+}
+
+func subsonicExportError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, scene_audio_subsonic_models.ErrInvalidNumericID):
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", err.Error())
+	case domain.IsNotFound(err):
+		controller.ErrorResponse(ctx, http.StatusNotFound, "NOT_FOUND", err.Error())
+	default:
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+	}
+}
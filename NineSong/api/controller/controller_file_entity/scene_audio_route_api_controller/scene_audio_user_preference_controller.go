@@ -0,0 +1,63 @@
+package scene_audio_route_api_controller
+
+import (
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/gin-gonic/gin"
+)
+
+type UserPreferenceController struct {
+	UserPreferenceUsecase scene_audio_route_interface.UserPreferenceRepository
+}
+
+func NewUserPreferenceController(uc scene_audio_route_interface.UserPreferenceRepository) *UserPreferenceController {
+	return &UserPreferenceController{UserPreferenceUsecase: uc}
+}
+
+func (c *UserPreferenceController) GetUserPreference(ctx *gin.Context) {
+	pref, err := c.UserPreferenceUsecase.GetUserPreference(ctx.Request.Context(), ctx.GetString("x-user-id"))
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "preference", pref, 1)
+}
+
+func (c *UserPreferenceController) UpdateReplayGainMode(ctx *gin.Context) {
+	var req struct {
+		Mode string `form:"replay_gain_mode" binding:"required"`
+	}
+
+	if err := ctx.ShouldBind(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "MISSING_PARAMETER", "缺少必要参数: replay_gain_mode")
+		return
+	}
+
+	pref, err := c.UserPreferenceUsecase.UpdateReplayGainMode(ctx.Request.Context(), ctx.GetString("x-user-id"), req.Mode)
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "UPDATE_FAILED", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "preference", pref, 1)
+}
+
+// UpdateHomeSections 保存首页布局：sections 的顺序即展示顺序，未列出的分区视为关闭，空列表恢复默认布局
+func (c *UserPreferenceController) UpdateHomeSections(ctx *gin.Context) {
+	var req struct {
+		Sections []scene_audio_route_models.HomeSectionConfig `json:"sections"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS", "请求体格式错误: "+err.Error())
+		return
+	}
+
+	pref, err := c.UserPreferenceUsecase.UpdateHomeSections(ctx.Request.Context(), ctx.GetString("x-user-id"), req.Sections)
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusBadRequest, "UPDATE_FAILED", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "preference", pref, 1)
+}
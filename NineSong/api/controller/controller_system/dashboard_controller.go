@@ -0,0 +1,27 @@
+package controller_system
+
+import (
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_system"
+	"github.com/gin-gonic/gin"
+)
+
+type DashboardController struct {
+	usecase domain_system.DashboardUsecase
+}
+
+func NewDashboardController(uc domain_system.DashboardUsecase) *DashboardController {
+	return &DashboardController{usecase: uc}
+}
+
+// Get 一次返回管理面板首页所需的服务状态
+func (c *DashboardController) Get(ctx *gin.Context) {
+	dashboard, err := c.usecase.Get(ctx.Request.Context())
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "dashboard", dashboard, 1)
+}
@@ -0,0 +1,79 @@
+package controller_system
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_system"
+	"github.com/gin-gonic/gin"
+)
+
+type EventController struct {
+	usecase domain_system.EventUsecase
+}
+
+func NewEventController(uc domain_system.EventUsecase) *EventController {
+	return &EventController{usecase: uc}
+}
+
+// Stream 以 Server-Sent Events 推送库与播放事件，topics 可用逗号分隔只订阅部分主题；
+// 浏览器 EventSource 无法设置请求头，可通过 access_token 查询参数认证
+func (c *EventController) Stream(ctx *gin.Context) {
+	var topics []string
+	for _, t := range strings.Split(ctx.Query("topics"), ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			topics = append(topics, t)
+		}
+	}
+
+	events, cancel, err := c.usecase.Subscribe(ctx.GetString(domain.UserIDKey), topics)
+	if err != nil {
+		if errors.Is(err, domain_system.ErrInvalidEventTopic) {
+			controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS",
+				"topics must be a comma-separated subset of: "+strings.Join(domain_system.EventTopics, ","))
+			return
+		}
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+		return
+	}
+	defer cancel()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Header("Connection", "keep-alive")
+	// 关闭 nginx 的响应缓冲
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+	ctx.Writer.Flush()
+
+	heartbeat := time.NewTicker(domain_system.EventHeartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(ctx.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			if _, err := fmt.Fprintf(ctx.Writer, "event: %s\ndata: %s\n\n", event.Topic, data); err != nil {
+				return
+			}
+		}
+		ctx.Writer.Flush()
+	}
+}
@@ -0,0 +1,61 @@
+package controller_system
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_system"
+	"github.com/gin-gonic/gin"
+)
+
+type ExportController struct {
+	usecase domain_system.ExportUsecase
+}
+
+func NewExportController(uc domain_system.ExportUsecase) *ExportController {
+	return &ExportController{usecase: uc}
+}
+
+// Export 以 NDJSON 流式输出全部艺术家、专辑与曲目，types 可用逗号分隔只导出部分类型
+func (c *ExportController) Export(ctx *gin.Context) {
+	types := domain_system.ExportTypes
+	if raw := strings.TrimSpace(ctx.Query("types")); raw != "" {
+		types = nil
+		for _, t := range strings.Split(raw, ",") {
+			t = strings.TrimSpace(t)
+			if !slices.Contains(domain_system.ExportTypes, t) {
+				controller.ErrorResponse(ctx, http.StatusBadRequest, "INVALID_PARAMS",
+					"types must be a comma-separated subset of: "+strings.Join(domain_system.ExportTypes, ","))
+				return
+			}
+			if !slices.Contains(types, t) {
+				types = append(types, t)
+			}
+		}
+	}
+
+	ctx.Header("Content-Type", "application/x-ndjson")
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Status(http.StatusOK)
+
+	encoder := json.NewEncoder(ctx.Writer)
+	err := c.usecase.Export(ctx.Request.Context(), types, func(records []domain_system.ExportRecord) error {
+		for _, record := range records {
+			if err := encoder.Encode(record); err != nil {
+				return err
+			}
+		}
+		ctx.Writer.Flush()
+		return nil
+	})
+	if err != nil {
+		// 响应头已发出，只能以最后一行告知客户端导出不完整
+		log.Printf("catalog export aborted: %v", err)
+		_ = encoder.Encode(gin.H{"type": "error", "error": err.Error()})
+		ctx.Writer.Flush()
+	}
+}
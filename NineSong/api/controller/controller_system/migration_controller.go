@@ -0,0 +1,33 @@
+package controller_system
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_system"
+	"github.com/gin-gonic/gin"
+)
+
+type MigrationController struct {
+	provider domain_system.MigrationStatusProvider
+	timeout  time.Duration
+}
+
+func NewMigrationController(provider domain_system.MigrationStatusProvider, timeout time.Duration) *MigrationController {
+	return &MigrationController{provider: provider, timeout: timeout}
+}
+
+// Get 列出全部索引与数据迁移版本的执行情况
+func (c *MigrationController) Get(ctx *gin.Context) {
+	reqCtx, cancel := context.WithTimeout(ctx.Request.Context(), c.timeout)
+	defer cancel()
+
+	report, err := c.provider.MigrationStatus(reqCtx)
+	if err != nil {
+		controller.ErrorResponse(ctx, http.StatusInternalServerError, "SERVER_ERROR", err.Error())
+		return
+	}
+	controller.SuccessResponse(ctx, "migrations", report, len(report.Migrations))
+}
@@ -0,0 +1,33 @@
+package controller_system
+
+import (
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/internal_system/i18n_util"
+	"github.com/gin-gonic/gin"
+)
+
+// ServerCapabilitiesController 能力信息在启动时确定，请求时直接返回
+type ServerCapabilitiesController struct {
+	capabilities domain_system.ServerCapabilities
+}
+
+func NewServerCapabilitiesController(capabilities domain_system.ServerCapabilities) *ServerCapabilitiesController {
+	return &ServerCapabilitiesController{capabilities: capabilities}
+}
+
+func (c *ServerCapabilitiesController) Get(ctx *gin.Context) {
+	controller.SuccessResponse(ctx, "info", c.capabilities, 1)
+}
+
+// GetErrorMessages 返回错误码文案目录，语言取 lang 参数，未指定时按 Accept-Language 协商
+func (c *ServerCapabilitiesController) GetErrorMessages(ctx *gin.Context) {
+	lang := ctx.Query("lang")
+	if lang == "" {
+		lang = ctx.GetHeader("Accept-Language")
+	}
+	lang = i18n_util.Negotiate(lang)
+	messages := i18n_util.Catalog(lang)
+	ctx.Header("Content-Language", lang)
+	controller.SuccessResponse(ctx, "errorMessages", gin.H{"locale": lang, "messages": messages}, len(messages))
+}
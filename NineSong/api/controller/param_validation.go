@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ParamIssue 单个无效参数及宽松模式下的处理方式
+type ParamIssue struct {
+	Param  string `json:"param"`
+	Value  string `json:"value"`
+	Reason string `json:"reason"`
+}
+
+// CheckListParams 校验列表接口的分页与 starred 参数；paged 为 false 时不检查 start/end。
+// 严格模式下存在无效参数时返回 400 与明细并返回 false；宽松模式记录被忽略的参数后继续
+func CheckListParams(c *gin.Context, paged bool, start, end, starred string) bool {
+	var issues []ParamIssue
+	if paged {
+		issues = append(issues, paginationIssues(start, end)...)
+	}
+	if starred != "" {
+		if _, err := strconv.ParseBool(starred); err != nil {
+			issues = append(issues, ParamIssue{Param: "starred", Value: starred, Reason: "必须为true或false，宽松模式下忽略该条件"})
+		}
+	}
+	if len(issues) == 0 {
+		return true
+	}
+
+	if c.GetBool("x-strict-params") {
+		ErrorDetailsResponse(c, http.StatusBadRequest, "INVALID_PARAMS", "参数无效", issues)
+		return false
+	}
+	reasons := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		reasons = append(reasons, issue.Param+"="+strconv.Quote(issue.Value)+": "+issue.Reason)
+	}
+	log.Printf("宽松模式忽略无效参数 %s %s: %s", c.Request.Method, c.FullPath(), strings.Join(reasons, "; "))
+	return true
+}
+
+// paginationIssues 与仓储层一致：start/end 无效时不分页
+func paginationIssues(start, end string) []ParamIssue {
+	const disabled = "，宽松模式下返回全部结果"
+	startInt, startErr := strconv.Atoi(start)
+	endInt, endErr := strconv.Atoi(end)
+
+	var issues []ParamIssue
+	if startErr != nil || startInt < 0 {
+		issues = append(issues, ParamIssue{Param: "start", Value: start, Reason: "必须为非负整数" + disabled})
+	}
+	if endErr != nil {
+		issues = append(issues, ParamIssue{Param: "end", Value: end, Reason: "必须为整数" + disabled})
+	} else if startErr == nil && endInt <= startInt {
+		issues = append(issues, ParamIssue{Param: "end", Value: end, Reason: "必须大于start" + disabled})
+	}
+	return issues
+}
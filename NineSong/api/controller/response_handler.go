@@ -1,11 +1,19 @@
 package controller
 
-import "github.com/gin-gonic/gin"
+import (
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/internal_system/i18n_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/internal_system/status_util"
+	"github.com/gin-gonic/gin"
+)
 
 const (
 	APIVersion    = "1.0.0"
 	ServerVersion = "1.0.0"
 	ServiceType   = "NSMusicS"
+	// APIRevision 新增或变更接口时递增，客户端据此判断可用功能
+	APIRevision = 1
 )
 
 func SuccessResponse(c *gin.Context, dataKey string, data interface{}, count int) {
@@ -21,17 +29,63 @@ func SuccessResponse(c *gin.Context, dataKey string, data interface{}, count int
 	})
 }
 
+// ErrorResponse 的 message 为原始错误信息，localized_message 为按 Accept-Language 选出的面向用户文案
 func ErrorResponse(c *gin.Context, statusCode int, errorCode string, message string) {
+	recordServerError(c, statusCode, errorCode, message)
 	c.JSON(statusCode, gin.H{
 		"ninesong-response": gin.H{
 			"status":        "error",
 			"version":       APIVersion,
 			"type":          ServiceType,
 			"serverVersion": ServerVersion,
-			"error": gin.H{
-				"code":    errorCode,
-				"message": message,
-			},
+			"error":         errorBody(c, statusCode, errorCode, message),
 		},
 	})
 }
+
+// ErrorDetailsResponse 与 ErrorResponse 相同，额外返回 details 说明具体的错误项
+func ErrorDetailsResponse(c *gin.Context, statusCode int, errorCode string, message string, details interface{}) {
+	recordServerError(c, statusCode, errorCode, message)
+	body := errorBody(c, statusCode, errorCode, message)
+	body["details"] = details
+	c.JSON(statusCode, gin.H{
+		"ninesong-response": gin.H{
+			"status":        "error",
+			"version":       APIVersion,
+			"type":          ServiceType,
+			"serverVersion": ServerVersion,
+			"error":         body,
+		},
+	})
+}
+
+func errorBody(c *gin.Context, statusCode int, errorCode string, message string) gin.H {
+	body := gin.H{
+		"code":    errorCode,
+		"message": message,
+	}
+	if c.Request == nil {
+		return body
+	}
+	lang := i18n_util.Negotiate(c.GetHeader("Accept-Language"))
+	if localized, ok := i18n_util.Message(errorCode, statusCode, lang); ok {
+		body["localized_message"] = localized
+		body["locale"] = lang
+		c.Header("Content-Language", lang)
+	}
+	return body
+}
+
+// recordServerError 5xx 错误记入最近错误列表，供管理面板展示
+func recordServerError(c *gin.Context, statusCode int, errorCode string, message string) {
+	if statusCode < http.StatusInternalServerError || c.Request == nil {
+		return
+	}
+	status_util.RecordError(status_util.RecentError{
+		Method:  c.Request.Method,
+		Path:    c.Request.URL.Path,
+		Status:  statusCode,
+		Code:    errorCode,
+		Message: message,
+	})
+}
@@ -0,0 +1,54 @@
+package middleware_system
+
+import (
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_auth"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminOnlyMiddleware 需在 JwtAuthMiddleware 之后使用，仅允许管理员访问
+func AdminOnlyMiddleware(userRepo domain_auth.UserRepository) gin.HandlerFunc {
+	return RequireRoleMiddleware(userRepo, domain_auth.RoleAdmin)
+}
+
+// RequireRoleMiddleware 需在 JwtAuthMiddleware 之后使用，用户具备任一角色即放行
+func RequireRoleMiddleware(userRepo domain_auth.UserRepository, roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := requestUser(c, userRepo)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: "Not authorized"})
+			c.Abort()
+			return
+		}
+		for _, role := range roles {
+			if user.HasRole(role) {
+				c.Next()
+				return
+			}
+		}
+		message := "Insufficient privileges"
+		if len(roles) == 1 && roles[0] == domain_auth.RoleAdmin {
+			message = "Admin privileges required"
+		}
+		c.JSON(http.StatusForbidden, domain.ErrorResponse{Message: message})
+		c.Abort()
+	}
+}
+
+// requestUserKey 本次请求已解析的用户在 gin.Context 中的键
+const requestUserKey = "x-request-user"
+
+// requestUser 同一请求内媒体库范围与角色校验共用一次用户查询，首次查询后写入 gin.Context
+func requestUser(c *gin.Context, userRepo domain_auth.UserRepository) (*domain_auth.User, error) {
+	if user, ok := c.Get(requestUserKey); ok {
+		return user.(*domain_auth.User), nil
+	}
+	user, err := userRepo.GetByID(c.Request.Context(), c.GetString(domain.UserIDKey))
+	if err != nil {
+		return nil, err
+	}
+	c.Set(requestUserKey, &user)
+	return &user, nil
+}
@@ -0,0 +1,76 @@
+package middleware_system
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_auth"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity"
+	"github.com/gin-gonic/gin"
+)
+
+// folderListTTL 媒体库列表在每个请求都要读取，短暂缓存；可见性设置变更最迟在该时长后生效
+const folderListTTL = 30 * time.Second
+
+// FolderScopeMiddleware 需在 JwtAuthMiddleware 之后使用，按媒体库的可见用户与角色计算调用方可访问的媒体库；
+// 管理员或全部媒体库均可见时不设置范围，仓库层不追加过滤条件
+func FolderScopeMiddleware(userRepo domain_auth.UserRepository, folderRepo domain_file_entity.FolderRepository) gin.HandlerFunc {
+	folderList := &folderListCache{repo: folderRepo}
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		user, err := requestUser(c, userRepo)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: "Not authorized"})
+			c.Abort()
+			return
+		}
+		if user.HasRole(domain_auth.RoleAdmin) {
+			c.Next()
+			return
+		}
+
+		folders, err := folderList.get(ctx)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+			c.Abort()
+			return
+		}
+		scope := &domain.FolderScope{IDs: []string{}, Paths: []string{}}
+		for _, folder := range folders {
+			if folder.VisibleTo(user.ID.Hex(), user.Roles) {
+				scope.IDs = append(scope.IDs, folder.ID.Hex())
+				scope.Paths = append(scope.Paths, folder.FolderPath)
+			}
+		}
+		if len(scope.IDs) < len(folders) {
+			c.Set(domain.FolderScopeKey, scope)
+			c.Request = c.Request.WithContext(domain.WithFolderScope(ctx, scope))
+		}
+		c.Next()
+	}
+}
+
+// folderListCache 缓存音乐媒体库列表，查询失败时不缓存
+type folderListCache struct {
+	repo      domain_file_entity.FolderRepository
+	mu        sync.Mutex
+	folders   []*domain_file_entity.LibraryFolderMetadata
+	expiresAt time.Time
+}
+
+func (f *folderListCache) get(ctx context.Context) ([]*domain_file_entity.LibraryFolderMetadata, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if time.Now().Before(f.expiresAt) {
+		return f.folders, nil
+	}
+	folders, err := f.repo.GetAllByType(ctx, int(domain_file_entity.MusicLibrary))
+	if err != nil {
+		return nil, err
+	}
+	f.folders, f.expiresAt = folders, time.Now().Add(folderListTTL)
+	return folders, nil
+}
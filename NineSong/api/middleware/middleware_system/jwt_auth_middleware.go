@@ -1,7 +1,9 @@
 package middleware_system
 
 import (
+	"errors"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_auth"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/internal_system/token_util"
 	"net/http"
 	"strings"
@@ -9,12 +11,16 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func JwtAuthMiddleware(secret string) gin.HandlerFunc {
+// JwtAuthMiddleware 校验 JWT 访问令牌；以 domain_auth.APIKeyPrefix 开头的令牌或 X-API-Key 请求头按 API 密钥校验
+func JwtAuthMiddleware(secret string, apiKeys domain_auth.APIKeyUsecase) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// 优先从URL参数获取access_token
+		// 优先从URL参数获取access_token，其次是 X-API-Key 请求头
 		authToken := c.Query("access_token")
+		if authToken == "" {
+			authToken = c.GetHeader("X-API-Key")
+		}
 
-		// 如果URL参数没有，尝试从Header获取
+		// 如果都没有，尝试从Header获取
 		if authToken == "" {
 			authHeader := c.GetHeader("Authorization")
 			if authHeader == "" {
@@ -33,24 +39,42 @@ func JwtAuthMiddleware(secret string) gin.HandlerFunc {
 			authToken = tokenParts[1]
 		}
 
-		// 验证令牌
-		authorized, err := token_util.IsAuthorized(authToken, secret)
-		if !authorized || err != nil {
-			c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: "Invalid token"})
-			c.Abort()
-			return
-		}
+		var userID string
+		if strings.HasPrefix(authToken, domain_auth.APIKeyPrefix) {
+			// 验证API密钥
+			id, err := apiKeys.Authenticate(c.Request.Context(), authToken)
+			if errors.Is(err, domain_auth.ErrInvalidAPIKey) {
+				c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: "Invalid API key"})
+				c.Abort()
+				return
+			}
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+				c.Abort()
+				return
+			}
+			userID = id
+		} else {
+			// 验证令牌
+			authorized, err := token_util.IsAuthorized(authToken, secret)
+			if !authorized || err != nil {
+				c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: "Invalid token"})
+				c.Abort()
+				return
+			}
 
-		// 提取用户信息
-		userID, err := token_util.ExtractIDFromToken(authToken, secret)
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: err.Error()})
-			c.Abort()
-			return
+			// 提取用户信息
+			userID, err = token_util.ExtractIDFromToken(authToken, secret)
+			if err != nil {
+				c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: err.Error()})
+				c.Abort()
+				return
+			}
 		}
 
 		// 设置上下文信息
-		c.Set("x-user-id", userID)
+		c.Set(domain.UserIDKey, userID)
+		c.Request = c.Request.WithContext(domain.WithUserID(c.Request.Context(), userID))
 		c.Next()
 	}
 }
@@ -0,0 +1,23 @@
+package middleware_system
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// PrivateNetworkMiddleware 仅允许局域网与本机地址访问，用于无法登录的设备直连的接口；
+// 按连接的对端地址判断，不信任 X-Forwarded-For
+func PrivateNetworkMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ip := net.ParseIP(c.RemoteIP())
+		if ip == nil || !(ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast()) {
+			c.JSON(http.StatusForbidden, domain.ErrorResponse{Message: "Local network access only"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
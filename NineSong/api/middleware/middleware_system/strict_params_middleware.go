@@ -0,0 +1,25 @@
+package middleware_system
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StrictParamsHeader 客户端可通过该请求头覆盖服务端默认的参数校验模式
+const StrictParamsHeader = "X-Strict-Params"
+
+// StrictParamsMiddleware 在上下文中写入 x-strict-params；严格模式下无效参数返回 400，
+// 默认的宽松模式保持旧行为（忽略无效参数）并记录日志
+func StrictParamsMiddleware(strictByDefault bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		strict := strictByDefault
+		if value := c.GetHeader(StrictParamsHeader); value != "" {
+			if parsed, err := strconv.ParseBool(value); err == nil {
+				strict = parsed
+			}
+		}
+		c.Set("x-strict-params", strict)
+		c.Next()
+	}
+}
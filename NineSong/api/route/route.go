@@ -1,12 +1,26 @@
 package route
 
 import (
+	"context"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/route/route_app/route_app_config"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/route/route_app/route_app_library"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/route/route_auth"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/route/route_file_entity/scene_audio_db_api_route"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/route/route_file_entity/scene_audio_route_api_route"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/route/route_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_subsonic/scene_audio_subsonic_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_auth"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_db_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_subsonic_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_auth"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_subsonic_usecase"
+	"log"
+	"strconv"
 	"time"
 
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/middleware/middleware_system"
@@ -17,28 +31,51 @@ import (
 )
 
 func Setup(env *bootstrap.Env, timeout time.Duration, db mongo.Database, gin *gin.Engine) {
+	// 参数校验模式，须在创建路由组之前注册
+	gin.Use(middleware_system.StrictParamsMiddleware(env.StrictParams))
+
 	// All Public APIs
 	publicRouter := gin.Group("")
 	RouterPublic(env, timeout, db, publicRouter)
 
 	// All Private APIs
 	protectedRouter := gin.Group("")
-	// Middleware to verify AccessToken
-	protectedRouter.Use(middleware_system.JwtAuthMiddleware(env.AccessTokenSecret))
+	// Middleware to verify AccessToken or API key
+	apiKeys := usecase_auth.NewAPIKeyUsecase(repository_auth.NewAPIKeyRepository(db, domain.CollectionAPIKey), timeout)
+	protectedRouter.Use(middleware_system.JwtAuthMiddleware(env.AccessTokenSecret, apiKeys))
+	// 按媒体库权限限定后续查询可见的曲目、专辑与艺术家
+	protectedRouter.Use(middleware_system.FolderScopeMiddleware(
+		repository_auth.NewUserRepository(db, domain.CollectionUser),
+		repository_file_entity.NewFolderRepo(db, domain.CollectionFileEntityFolderInfo),
+	))
+	route_auth.NewAPIKeyRouter(apiKeys, protectedRouter)
 	RouterPrivate(env, timeout, db, protectedRouter)
+
+	if env.CachePrimeOnStartup {
+		go primeListCaches(env, timeout, db)
+	}
 }
 
 func RouterPublic(env *bootstrap.Env, timeout time.Duration, db mongo.Database, publicRouter *gin.RouterGroup) {
 	route_auth.NewLoginRouter(env, timeout, db, publicRouter)
+	route_auth.NewExternalAuthRouter(env, timeout, db, publicRouter)
+	// 访问令牌过期后客户端仍需凭刷新令牌换取新令牌
+	route_auth.NewRefreshTokenRouter(env, timeout, db, publicRouter)
+	route_system.NewServerCapabilitiesRouter(env, timeout, publicRouter)
+	scene_audio_route_api_route.NewFederationPublicRouter(env, timeout, db, publicRouter)
+	scene_audio_route_api_route.NewDLNARouter(env, timeout, db, publicRouter)
 }
 
 func RouterPrivate(env *bootstrap.Env, timeout time.Duration, db mongo.Database, protectedRouter *gin.RouterGroup) {
+	forwarder := newSubsonicForwarder(env, timeout, db)
+	startAvailabilityChecker(timeout, db)
+
 	// auth
 	route_auth.NewSignupRouter(env, timeout, db, protectedRouter)
 	route_auth.NewUpdateUserRouter(env, timeout, db, protectedRouter)
-	route_auth.NewRefreshTokenRouter(env, timeout, db, protectedRouter)
 	// auth_other
 	route_auth.NewProfileRouter(timeout, db, protectedRouter)
+	route_auth.NewUserRoleRouter(timeout, db, protectedRouter)
 	route_auth.NewTaskRouter(timeout, db, protectedRouter)
 	// system
 	route_system.NewSystemInfoRouter(timeout, db, protectedRouter)
@@ -55,15 +92,101 @@ func RouterPrivate(env *bootstrap.Env, timeout time.Duration, db mongo.Database,
 	// folder entity
 	scene_audio_db_api_route.NewFolderEntityRouter(timeout, db, protectedRouter)
 	// file entity
-	scene_audio_db_api_route.NewFileEntityRouter(timeout, db, protectedRouter)
+	fileUsecase := scene_audio_db_api_route.NewFileEntityRouter(env, timeout, db, protectedRouter)
+	scene_audio_db_api_route.NewMetadataRouter(env, timeout, db, protectedRouter)
 	// scene audio
-	scene_audio_route_api_route.NewArtistRouter(timeout, db, protectedRouter)
-	scene_audio_route_api_route.NewAlbumRouter(timeout, db, protectedRouter)
-	scene_audio_route_api_route.NewMediaFileRouter(timeout, db, protectedRouter)
+	scene_audio_route_api_route.NewArtistRouter(env, timeout, db, protectedRouter)
+	scene_audio_route_api_route.NewAlbumRouter(env, timeout, db, protectedRouter)
+	scene_audio_route_api_route.NewMediaFileRouter(env, timeout, db, protectedRouter)
 	scene_audio_route_api_route.NewMediaFileCueRouter(timeout, db, protectedRouter)
 	scene_audio_route_api_route.NewPlaylistRouter(timeout, db, protectedRouter)
 	scene_audio_route_api_route.NewPlaylistTrackRouter(timeout, db, protectedRouter)
-	scene_audio_route_api_route.NewAnnotationRouter(timeout, db, protectedRouter)
-	scene_audio_route_api_route.NewHomeRouter(timeout, db, protectedRouter)
+	scene_audio_route_api_route.NewSmartPlaylistRouter(timeout, db, protectedRouter)
+	scene_audio_route_api_route.NewAnnotationRouter(timeout, db, protectedRouter, forwarder)
+	scene_audio_route_api_route.NewHomeRouter(env, timeout, db, protectedRouter)
+	scene_audio_route_api_route.NewDiscoverRouter(timeout, db, protectedRouter)
+	scene_audio_route_api_route.NewRecommendationRouter(env, timeout, db, protectedRouter)
 	scene_audio_route_api_route.NewRetrievalRouter(timeout, db, protectedRouter)
+	scene_audio_route_api_route.NewDownloadRouter(timeout, db, protectedRouter)
+	scene_audio_route_api_route.NewSubsonicExportRouter(timeout, db, protectedRouter)
+	scene_audio_route_api_route.NewInternetRadioRouter(timeout, db, protectedRouter)
+	scene_audio_route_api_route.NewPodcastRouter(env, timeout, db, protectedRouter)
+	scene_audio_route_api_route.NewAudiobookRouter(timeout, db, protectedRouter)
+	scene_audio_route_api_route.NewBookmarkRouter(timeout, db, protectedRouter)
+	scene_audio_route_api_route.NewLyricsRouter(env, timeout, db, protectedRouter)
+	scene_audio_route_api_route.NewImageProxyRouter(env, timeout, db, protectedRouter)
+	scene_audio_route_api_route.NewEqPresetRouter(timeout, db, protectedRouter)
+	scene_audio_route_api_route.NewUserPreferenceRouter(timeout, db, protectedRouter)
+	scene_audio_route_api_route.NewPlayQueueRouter(timeout, db, protectedRouter)
+	scene_audio_route_api_route.NewNowPlayingRouter(timeout, db, protectedRouter)
+	scene_audio_route_api_route.NewJukeboxRouter(env, timeout, db, protectedRouter)
+	scene_audio_route_api_route.NewCastRouter(env, timeout, db, protectedRouter)
+	scene_audio_route_api_route.NewScrobbleRouter(timeout, db, protectedRouter, forwarder)
+	scene_audio_route_api_route.NewHistoryRouter(env, timeout, db, protectedRouter)
+	scene_audio_route_api_route.NewStatsRouter(env, timeout, db, protectedRouter)
+	scene_audio_route_api_route.NewChartRouter(env, timeout, db, protectedRouter)
+	scene_audio_route_api_route.NewGenreRouter(timeout, db, protectedRouter)
+	scene_audio_route_api_route.NewSearchRouter(timeout, db, protectedRouter)
+	scene_audio_route_api_route.NewLookupRouter(env, timeout, db, protectedRouter)
+	scene_audio_route_api_route.NewChangesRouter(timeout, db, protectedRouter)
+	scene_audio_route_api_route.NewFederationRouter(env, timeout, db, protectedRouter)
+	// admin
+	route_system.NewDashboardRouter(timeout, db, protectedRouter, fileUsecase)
+	route_system.NewMigrationRouter(timeout, db, protectedRouter)
+	route_system.NewExportRouter(timeout, db, protectedRouter)
+	route_system.NewEventRouter(protectedRouter)
+}
+
+// newSubsonicForwarder 配置了上游服务器时启动转发协程，否则返回空实现
+func newSubsonicForwarder(env *bootstrap.Env, timeout time.Duration, db mongo.Database) scene_audio_subsonic_interface.SubsonicForwarder {
+	if env.UpstreamSubsonicURL == "" {
+		return scene_audio_subsonic_usecase.NewNoopForwarder()
+	}
+	forwarder := scene_audio_subsonic_usecase.NewSubsonicForwardUsecase(
+		scene_audio_subsonic_repository.NewSubsonicForwardRepository(db),
+		scene_audio_subsonic_usecase.NewSubsonicClient(env.UpstreamSubsonicURL, env.UpstreamSubsonicUser, env.UpstreamSubsonicPassword, timeout),
+		timeout,
+	)
+	forwarder.Start(context.Background())
+	log.Printf("播放与收藏将转发至上游 Subsonic: %s", env.UpstreamSubsonicURL)
+	return forwarder
+}
+
+// startAvailabilityChecker 启动媒体源可用性的后台检查
+func startAvailabilityChecker(timeout time.Duration, db mongo.Database) {
+	usecase_file_entity.NewAvailabilityUsecase(
+		repository_file_entity.NewFolderRepo(db, domain.CollectionFileEntityFolderInfo),
+		scene_audio_db_repository.NewAvailabilityRepository(db),
+		scene_audio_db_repository.NewTempRepository(db, domain.CollectionFileEntityAudioSceneTempMetadata),
+		timeout,
+	).Start(context.Background())
+}
+
+// primeListCaches 以不带用户的请求预先执行常用列表查询，写入按媒体库范围共享的目录缓存，
+// 不受限的用户请求参数与之一致时直接命中，再叠加各自的注解
+func primeListCaches(env *bootstrap.Env, timeout time.Duration, db mongo.Database) {
+	pageSize := env.CachePrimePageSize
+	if pageSize <= 0 {
+		pageSize = 50
+	}
+	end := strconv.Itoa(pageSize)
+	ctx := context.Background()
+	started := time.Now()
+
+	albums := scene_audio_route_usecase.NewAlbumUsecase(
+		scene_audio_route_repository.NewAlbumRepository(db, domain.CollectionFileEntityAudioSceneAlbum), timeout)
+	if _, err := albums.GetAlbumItems(ctx, "0", end, "created_at", "desc", "", "", "", "", "", "", "", "", ""); err != nil {
+		log.Printf("预热最近添加专辑失败: %v", err)
+	}
+	artists := scene_audio_route_usecase.NewArtistUsecase(
+		scene_audio_route_repository.NewArtistRepository(db, domain.CollectionFileEntityAudioSceneArtist), timeout)
+	if _, err := artists.GetArtistItems(ctx, "0", end, "name", "asc", "", ""); err != nil {
+		log.Printf("预热艺术家索引失败: %v", err)
+	}
+	genres := scene_audio_route_usecase.NewGenreUsecase(
+		scene_audio_route_repository.NewGenreRepository(db, domain.CollectionFileEntityAudioSceneGenre), timeout)
+	if _, err := genres.GetGenreTree(ctx, ""); err != nil {
+		log.Printf("预热流派列表失败: %v", err)
+	}
+	log.Printf("列表缓存预热完成，耗时 %s", time.Since(started).Round(time.Millisecond))
 }
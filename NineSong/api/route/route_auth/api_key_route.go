@@ -0,0 +1,18 @@
+package route_auth
+
+import (
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_auth"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewAPIKeyRouter 与认证中间件共用同一用例
+func NewAPIKeyRouter(uc domain_auth.APIKeyUsecase, group *gin.RouterGroup) {
+	ac := &controller_auth.APIKeyController{
+		APIKeyUsecase: uc,
+	}
+	group.GET("/user/api-keys", ac.Fetch)
+	group.POST("/user/api-keys", ac.Create)
+	group.DELETE("/user/api-keys/:id", ac.Delete)
+}
@@ -0,0 +1,75 @@
+package route_auth
+
+import (
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_auth"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/internal_system/ldap_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/internal_system/oidc_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_auth"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_auth"
+	"github.com/gin-gonic/gin"
+)
+
+// NewExternalAuthRouter 注册在公开路由上；未配置的登录方式返回 404
+func NewExternalAuthRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, group *gin.RouterGroup) {
+	ur := repository_auth.NewUserRepository(db, domain.CollectionUser)
+	ec := &controller_auth.ExternalAuthController{
+		ExternalAuthUsecase: usecase_auth.NewExternalAuthUsecase(ur, externalAuthConfig(env, timeout), timeout),
+		Env:                 env,
+	}
+	group.POST("/user/login/ldap", ec.LoginLDAP)
+	group.GET("/user/oidc/login", ec.OIDCLogin)
+	group.GET("/user/oidc/callback", ec.OIDCCallback)
+}
+
+func externalAuthConfig(env *bootstrap.Env, timeout time.Duration) usecase_auth.ExternalAuthConfig {
+	cfg := usecase_auth.ExternalAuthConfig{
+		AdminGroups:   splitGroups(env.AuthAdminGroups),
+		AllowedGroups: splitGroups(env.AuthAllowedGroups),
+	}
+	if env.LDAPURL != "" {
+		cfg.LDAP = &ldap_util.Config{
+			URL:          env.LDAPURL,
+			BindDN:       env.LDAPBindDN,
+			BindPassword: env.LDAPBindPassword,
+			BaseDN:       env.LDAPBaseDN,
+			UserFilter:   valueOr(env.LDAPUserFilter, "(uid=%s)"),
+			EmailAttr:    valueOr(env.LDAPEmailAttr, "mail"),
+			NameAttr:     valueOr(env.LDAPNameAttr, "cn"),
+			GroupAttr:    valueOr(env.LDAPGroupAttr, "memberOf"),
+		}
+	}
+	if env.OIDCIssuer != "" {
+		cfg.OIDC = oidc_util.NewClient(oidc_util.Config{
+			Issuer:       env.OIDCIssuer,
+			ClientID:     env.OIDCClientID,
+			ClientSecret: env.OIDCClientSecret,
+			RedirectURL:  env.OIDCRedirectURL,
+			Scopes:       strings.Fields(valueOr(env.OIDCScopes, "openid profile email groups")),
+			GroupsClaim:  valueOr(env.OIDCGroupsClaim, "groups"),
+		}, timeout)
+	}
+	return cfg
+}
+
+func splitGroups(value string) []string {
+	var groups []string
+	for _, group := range strings.Split(value, ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -2,6 +2,7 @@ package route_auth
 
 import (
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_auth"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/middleware/middleware_system"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_auth"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_auth"
@@ -18,5 +19,6 @@ func NewSignupRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Databas
 		SignupUsecase: usecase_auth.NewSignupUsecase(ur, timeout),
 		Env:           env,
 	}
-	group.POST("/user/signup", sc.Signup)
+	// 仅管理员可创建账号并分配角色
+	group.POST("/user/signup", middleware_system.AdminOnlyMiddleware(ur), sc.Signup)
 }
@@ -0,0 +1,25 @@
+package route_auth
+
+import (
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_auth"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/middleware/middleware_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_auth"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_auth"
+	"github.com/gin-gonic/gin"
+)
+
+func NewUserRoleRouter(timeout time.Duration, db mongo.Database, group *gin.RouterGroup) {
+	ur := repository_auth.NewUserRepository(db, domain.CollectionUser)
+	uc := &controller_auth.UserRoleController{
+		UserRoleUsecase: usecase_auth.NewUserRoleUsecase(ur, timeout),
+	}
+
+	admin := group.Group("/admin")
+	admin.Use(middleware_system.AdminOnlyMiddleware(ur))
+	admin.GET("/users", uc.Fetch)
+	admin.PUT("/users/:id/roles", uc.UpdateRoles)
+}
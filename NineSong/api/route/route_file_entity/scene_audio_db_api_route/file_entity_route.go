@@ -3,17 +3,26 @@ package scene_audio_db_api_route
 import (
 	"fmt"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_db_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/middleware/middleware_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_app/repository_app_config"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_auth"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_db_repository"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_acoustid_usecase"
 	"github.com/gin-gonic/gin"
 	"time"
 )
 
-func NewFileEntityRouter(timeout time.Duration, db mongo.Database, group *gin.RouterGroup) {
+// 声纹计算需要解码整段音频，单个文件的识别耗时远超普通查询
+const fingerprintTimeout = time.Minute
+
+// NewFileEntityRouter 返回扫描用例，供管理面板读取扫描状态
+func NewFileEntityRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, group *gin.RouterGroup) *usecase_file_entity.FileUsecase {
 	// 初始化仓库
 	fileRepo := repository_file_entity.NewFileRepo(db, domain.CollectionFileEntityFileInfo)
 	folderRepo := repository_file_entity.NewFolderRepo(db, domain.CollectionFileEntityFolderInfo)
@@ -24,6 +33,17 @@ func NewFileEntityRouter(timeout time.Duration, db mongo.Database, group *gin.Ro
 	mediaRepo := scene_audio_db_repository.NewMediaFileRepository(db, domain.CollectionFileEntityAudioSceneMediaFile)
 	tempRepo := scene_audio_db_repository.NewTempRepository(db, domain.CollectionFileEntityAudioSceneTempMetadata)
 	mediaCueRepo := scene_audio_db_repository.NewMediaFileCueRepository(db, domain.CollectionFileEntityAudioSceneMediaFileCue)
+	fingerprintUc := usecase_file_entity.NewFingerprintUsecase(
+		scene_audio_db_repository.NewFingerprintRepository(db),
+		scene_audio_acoustid_usecase.NewAcoustIDUsecase(env.AcoustIDAPIKey, timeout),
+		env.FpcalcPath,
+		fingerprintTimeout,
+	)
+	// 未配置 AcoustID 密钥时扫描不做声纹识别，审核接口仍可处理已有结果
+	scanFingerprintUc := fingerprintUc
+	if env.AcoustIDAPIKey == "" {
+		scanFingerprintUc = nil
+	}
 	// 构建用例（新增超时参数）
 	uc := usecase_file_entity.NewFileUsecase(
 		fileRepo,
@@ -35,15 +55,72 @@ func NewFileEntityRouter(timeout time.Duration, db mongo.Database, group *gin.Ro
 		mediaRepo,
 		tempRepo,
 		mediaCueRepo,
+		scene_audio_db_repository.NewMediaLyricsRepository(db, domain.CollectionFileEntityAudioSceneMediaLyricsMetadata),
+		scene_audio_db_repository.NewAlbumAttachmentRepository(db, domain.CollectionFileEntityAudioSceneAlbumAttachment),
+		repository_app_config.NewAppConfigRepository(db, domain.CollectionFileEntityAudioAppConfigs),
+		scanFingerprintUc,
+		scene_audio_db_repository.NewDeletionProtectionRepository(db),
 	)
 
+	// 上传与扫描共用同一用例，保证与全局扫描互斥
+	uploadUc := usecase_file_entity.NewUploadUsecase(uc, folderRepo, tempRepo, detector)
+	reviewUc := usecase_file_entity.NewReviewUsecase(uc, folderRepo, mediaRepo)
+	ingestUc := usecase_file_entity.NewIngestUsecase(uc, folderRepo)
+	beetsUc := usecase_file_entity.NewBeetsUsecase(ingestUc, env.BeetsPathMap)
+	musicFolderUc := usecase_file_entity.NewMusicFolderUsecase(uc, folderRepo)
+	maintenanceUc := usecase_file_entity.NewMaintenanceUsecase(uc, folderRepo, scene_audio_db_repository.NewMaintenanceRepository(db))
+
 	// 注册控制器
 	ctrl := scene_audio_db_api_controller.NewFileController(uc)
+	uploadCtrl := scene_audio_db_api_controller.NewUploadController(uploadUc)
+	reviewCtrl := scene_audio_db_api_controller.NewReviewController(reviewUc)
+	ingestCtrl := scene_audio_db_api_controller.NewIngestController(ingestUc)
+	beetsCtrl := scene_audio_db_api_controller.NewBeetsController(beetsUc)
+	musicFolderCtrl := scene_audio_db_api_controller.NewMusicFolderController(musicFolderUc)
+	maintenanceCtrl := scene_audio_db_api_controller.NewMaintenanceController(maintenanceUc)
+	fingerprintCtrl := scene_audio_db_api_controller.NewFingerprintController(fingerprintUc)
+
+	adminOnly := middleware_system.AdminOnlyMiddleware(repository_auth.NewUserRepository(db, domain.CollectionUser))
 
 	// 路由配置
 	group.Use(requestLogger())
-	group.POST("/scan", ctrl.ScanDirectory)
+	group.POST("/scan", adminOnly, ctrl.ScanDirectory)
 	group.GET("/scan_progress", ctrl.GetScanProgress)
+	group.GET("/scan_status", ctrl.GetScanStatus)
+	group.POST("/upload", uploadCtrl.Upload)
+	// 外部打标工具直接提交元数据，跳过标签读取
+	group.POST("/ingest", adminOnly, ingestCtrl.Ingest)
+	group.POST("/beets/import", adminOnly, beetsCtrl.Import)
+
+	// 音乐媒体库根目录管理，GET /folders 为目录浏览
+	group.POST("/folders", adminOnly, musicFolderCtrl.AddFolder)
+	group.DELETE("/folders/:id", adminOnly, musicFolderCtrl.RemoveFolder)
+	group.POST("/folders/:id/rescan", adminOnly, musicFolderCtrl.RescanFolder)
+
+	// 审核队列仅管理员可操作
+	review := group.Group("/review")
+	review.Use(adminOnly)
+	review.GET("", reviewCtrl.List)
+	review.POST("/approve", reviewCtrl.Approve)
+	review.POST("/reject", reviewCtrl.Reject)
+	review.PUT("/tags", reviewCtrl.EditTags)
+
+	// 孤立数据清理与一致性修复
+	maintenance := group.Group("/maintenance")
+	maintenance.Use(adminOnly)
+	maintenance.POST("/repair", maintenanceCtrl.StartRepair)
+	maintenance.GET("/repair", maintenanceCtrl.GetRepairReport)
+	maintenance.GET("/reports", maintenanceCtrl.ListReports)
+	maintenance.GET("/reports/:id", maintenanceCtrl.GetReport)
+	maintenance.POST("/reports/:id/apply", maintenanceCtrl.ApplyReport)
+
+	// 低匹配度的声纹识别结果由管理员确认
+	fingerprints := group.Group("/fingerprints")
+	fingerprints.Use(adminOnly)
+	fingerprints.GET("/review", fingerprintCtrl.ListReview)
+	fingerprints.POST("/review/:id/accept", fingerprintCtrl.Accept)
+	fingerprints.POST("/review/:id/reject", fingerprintCtrl.Reject)
+	return uc
 }
 
 func requestLogger() gin.HandlerFunc {
@@ -2,8 +2,10 @@ package scene_audio_db_api_route
 
 import (
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_db_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/middleware/middleware_system"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_auth"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity"
 	"github.com/gin-gonic/gin"
@@ -20,10 +22,14 @@ func NewFolderEntityRouter(timeout time.Duration, db mongo.Database, group *gin.
 	// 注册控制器
 	libCtrl := scene_audio_db_api_controller.NewLibraryController(libraryUsecase)
 
+	adminOnly := middleware_system.AdminOnlyMiddleware(repository_auth.NewUserRepository(db, domain.CollectionUser))
+
 	// 新增路由
-	group.GET("/folders", libCtrl.BrowseFolders)
-	group.POST("/libraries", libCtrl.CreateLibrary)
-	group.PUT("/libraries", libCtrl.UpdateLibrary)
-	group.DELETE("/libraries", libCtrl.DeleteLibrary)
+	group.GET("/folders", adminOnly, libCtrl.BrowseFolders)
+	group.POST("/libraries", adminOnly, libCtrl.CreateLibrary)
+	group.PUT("/libraries", adminOnly, libCtrl.UpdateLibrary)
+	group.DELETE("/libraries", adminOnly, libCtrl.DeleteLibrary)
+	group.PUT("/libraries/access", adminOnly, libCtrl.UpdateLibraryAccess)
+	group.PUT("/libraries/audiobook", adminOnly, libCtrl.UpdateLibraryAudiobook)
 	group.GET("/libraries", libCtrl.GetLibraries)
 }
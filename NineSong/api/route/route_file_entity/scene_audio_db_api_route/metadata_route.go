@@ -0,0 +1,39 @@
+package scene_audio_db_api_route
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_db_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/middleware/middleware_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_auth"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_db_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_musicbrainz_usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// 单个专辑的匹配需要依次搜索并获取发行版，受 MusicBrainz 限速影响耗时较长
+const metadataRefreshTimeout = 2 * time.Minute
+
+func NewMetadataRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, group *gin.RouterGroup) {
+	uc := usecase_file_entity.NewEnrichmentUsecase(
+		scene_audio_db_repository.NewEnrichmentRepository(db),
+		scene_audio_musicbrainz_usecase.NewMusicBrainzUsecase(timeout),
+		metadataRefreshTimeout,
+	)
+	if env.MusicBrainzEnrichment {
+		uc.Start(context.Background())
+	}
+	if env.LoudnessAnalysis {
+		usecase_file_entity.NewLoudnessUsecase(scene_audio_db_repository.NewLoudnessRepository(db)).Start(context.Background())
+	}
+	ctrl := scene_audio_db_api_controller.NewMetadataController(uc)
+
+	metadata := group.Group("/metadata")
+	metadata.Use(middleware_system.AdminOnlyMiddleware(repository_auth.NewUserRepository(db, domain.CollectionUser)))
+	metadata.POST("/refresh/:id", ctrl.RefreshMetadata)
+}
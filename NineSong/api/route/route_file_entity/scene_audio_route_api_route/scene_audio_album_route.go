@@ -2,8 +2,12 @@ package scene_audio_route_api_route
 
 import (
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/middleware/middleware_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_auth"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_musicbrainz_usecase"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
 	"time"
 
@@ -11,7 +15,11 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// 曲目比对需要逐个请求 MusicBrainz，单次请求耗时远超普通查询
+const albumCompletenessTimeout = 2 * time.Minute
+
 func NewAlbumRouter(
+	env *bootstrap.Env,
 	timeout time.Duration,
 	db mongo.Database,
 	group *gin.RouterGroup,
@@ -19,11 +27,34 @@ func NewAlbumRouter(
 	repo := scene_audio_route_repository.NewAlbumRepository(db, domain.CollectionFileEntityAudioSceneAlbum)
 
 	usecase := scene_audio_route_usecase.NewAlbumUsecase(repo, timeout)
-	ctrl := scene_audio_route_api_controller.NewAlbumController(usecase)
+	ctrl := scene_audio_route_api_controller.NewAlbumController(usecase,
+		scene_audio_route_api_controller.NewLinkBuilder(env.PublicBaseURL, env.MediaLinksSigned))
+
+	musicBrainz := scene_audio_musicbrainz_usecase.NewMusicBrainzUsecase(timeout)
+	completenessRepo := scene_audio_route_repository.NewAlbumCompletenessRepository(db, domain.CollectionFileEntityAudioSceneAlbum, musicBrainz)
+	completenessUsecase := scene_audio_route_usecase.NewAlbumCompletenessUsecase(completenessRepo, albumCompletenessTimeout)
+	completenessCtrl := scene_audio_route_api_controller.NewAlbumCompletenessController(completenessUsecase)
+
+	attachmentUsecase := scene_audio_route_usecase.NewAlbumAttachmentUsecase(
+		scene_audio_route_repository.NewAlbumAttachmentRepository(db),
+		scene_audio_route_repository.NewRetrievalRepository(db),
+		timeout,
+	)
+	attachmentCtrl := scene_audio_route_api_controller.NewAlbumAttachmentController(attachmentUsecase)
+	adminOnly := middleware_system.AdminOnlyMiddleware(repository_auth.NewUserRepository(db, domain.CollectionUser))
 
 	albumGroup := group.Group("/albums")
 	{
 		albumGroup.GET("", ctrl.GetAlbumItems)
 		albumGroup.GET("/filter_counts", ctrl.GetAlbumFilterCounts)
+		albumGroup.GET("/years", ctrl.GetAlbumYears)
+		albumGroup.GET("/shelves", ctrl.GetAlbumShelves)
+		albumGroup.GET("/missing_tracks", completenessCtrl.GetMissingTracks)
 	}
+	group.GET("/album/:id", ctrl.GetAlbumDetail)
+	group.GET("/album/:id/tracks", ctrl.GetAlbumTracks)
+	group.GET("/album/:id/attachments", attachmentCtrl.GetAttachments)
+	group.GET("/album/:id/attachments/:attachment_id", attachmentCtrl.DownloadAttachment)
+	group.POST("/album/:id/attachments", adminOnly, attachmentCtrl.UploadAttachment)
+	group.DELETE("/album/:id/attachments/:attachment_id", adminOnly, attachmentCtrl.DeleteAttachment)
 }
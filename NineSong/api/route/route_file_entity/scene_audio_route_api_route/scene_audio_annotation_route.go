@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_subsonic/scene_audio_subsonic_interface"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
 	"github.com/gin-gonic/gin"
 )
@@ -14,13 +16,16 @@ func NewAnnotationRouter(
 	timeout time.Duration,
 	db mongo.Database,
 	group *gin.RouterGroup,
+	forwarder scene_audio_subsonic_interface.SubsonicForwarder,
 ) {
 	repo := scene_audio_route_repository.NewAnnotationRepository(db)
-	uc := scene_audio_route_usecase.NewAnnotationUsecase(repo, timeout)
+	uc := scene_audio_route_usecase.NewAnnotationUsecase(repo, timeout, forwarder)
 	ctrl := scene_audio_route_api_controller.NewAnnotationController(uc)
 
 	router := group.Group("/annotations")
 	{
+		router.GET("", ctrl.GetAnnotation)
+		router.POST("", ctrl.SetAnnotation)
 		router.POST("/star", ctrl.UpdateStarred)
 		router.POST("/unstar", ctrl.UpdateUnStarred)
 		router.POST("/rating", ctrl.UpdateRating)
@@ -29,4 +34,16 @@ func NewAnnotationRouter(
 		router.POST("/tags", ctrl.UpdateTagSource)
 		router.POST("/weights", ctrl.UpdateWeightedTag)
 	}
+
+	// 按条目路径收藏，响应直接返回更新后的注解
+	for prefix, itemType := range map[string]scene_audio_route_models.AnnotationItemType{
+		"/medias":    scene_audio_route_models.AnnotationItemMedia,
+		"/albums":    scene_audio_route_models.AnnotationItemAlbum,
+		"/artists":   scene_audio_route_models.AnnotationItemArtist,
+		"/playlists": scene_audio_route_models.AnnotationItemPlaylist,
+	} {
+		group.POST(prefix+"/:id/star", ctrl.StarItem(itemType))
+		group.POST(prefix+"/:id/unstar", ctrl.UnstarItem(itemType))
+	}
+	group.POST("/medias/:id/rate", ctrl.RateMediaFile)
 }
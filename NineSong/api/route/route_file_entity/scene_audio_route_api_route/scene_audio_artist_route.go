@@ -2,8 +2,12 @@ package scene_audio_route_api_route
 
 import (
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/middleware/middleware_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_auth"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_musicbrainz_usecase"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
 	"time"
 
@@ -12,6 +16,7 @@ import (
 )
 
 func NewArtistRouter(
+	env *bootstrap.Env,
 	timeout time.Duration,
 	db mongo.Database,
 	group *gin.RouterGroup,
@@ -21,9 +26,33 @@ func NewArtistRouter(
 	usecase := scene_audio_route_usecase.NewArtistUsecase(repo, timeout)
 	ctrl := scene_audio_route_api_controller.NewArtistController(usecase)
 
+	musicBrainz := scene_audio_musicbrainz_usecase.NewMusicBrainzUsecase(timeout)
+	aliasRepo := scene_audio_route_repository.NewArtistAliasRepository(db, domain.CollectionFileEntityAudioSceneArtist, musicBrainz)
+	aliasUsecase := scene_audio_route_usecase.NewArtistAliasUsecase(aliasRepo, timeout)
+	aliasCtrl := scene_audio_route_api_controller.NewArtistAliasController(aliasUsecase)
+
+	// 艺术家页合并接口复用推荐用例获取相似艺术家
+	pageUsecase := scene_audio_route_usecase.NewArtistPageUsecase(
+		repo,
+		scene_audio_route_repository.NewAlbumRepository(db, domain.CollectionFileEntityAudioSceneAlbum),
+		scene_audio_route_repository.NewMediaFileRepository(db, domain.CollectionFileEntityAudioSceneMediaFile),
+		newRecommendationUsecase(env, timeout, db),
+		max(timeout, recommendationTimeout),
+	)
+	pageCtrl := scene_audio_route_api_controller.NewArtistPageController(pageUsecase,
+		scene_audio_route_api_controller.NewLinkBuilder(env.PublicBaseURL, env.MediaLinksSigned))
+
+	adminOnly := middleware_system.AdminOnlyMiddleware(repository_auth.NewUserRepository(db, domain.CollectionUser))
+
 	artistGroup := group.Group("/artists")
 	{
 		artistGroup.GET("", ctrl.GetArtists)
 		artistGroup.GET("/filter_counts", ctrl.GetArtistFilterCounts)
+		artistGroup.GET("/:id/full", pageCtrl.GetArtistPage)
+		artistGroup.GET("/aliases", aliasCtrl.GetArtistAliases)
+		artistGroup.POST("/aliases", adminOnly, aliasCtrl.AddArtistAlias)
+		artistGroup.DELETE("/aliases", adminOnly, aliasCtrl.RemoveArtistAlias)
+		artistGroup.POST("/aliases/sync", adminOnly, aliasCtrl.SyncMusicBrainzAliases)
+		artistGroup.POST("/merge", adminOnly, aliasCtrl.MergeArtists)
 	}
 }
@@ -0,0 +1,26 @@
+package scene_audio_route_api_route
+
+import (
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// NewAudiobookRouter 有声书来自 PUT /libraries/audiobook 标记的媒体库，每张专辑为一本书
+func NewAudiobookRouter(timeout time.Duration, db mongo.Database, group *gin.RouterGroup) {
+	repo := scene_audio_route_repository.NewAudiobookRepository(db)
+	usecase := scene_audio_route_usecase.NewAudiobookUsecase(repo, timeout)
+	ctrl := scene_audio_route_api_controller.NewAudiobookController(usecase)
+
+	audiobookGroup := group.Group("/audiobooks")
+	{
+		audiobookGroup.GET("", ctrl.GetAudiobooks)
+		audiobookGroup.GET("/:id", ctrl.GetAudiobook)
+		audiobookGroup.PUT("/:id/progress", ctrl.SaveProgress)
+		audiobookGroup.DELETE("/:id/progress", ctrl.DeleteProgress)
+	}
+}
@@ -0,0 +1,26 @@
+package scene_audio_route_api_route
+
+import (
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// NewBookmarkRouter 书签按条目ID（曲目或播客单集）寻址，每个用户每个条目一条
+func NewBookmarkRouter(timeout time.Duration, db mongo.Database, group *gin.RouterGroup) {
+	repo := scene_audio_route_repository.NewBookmarkRepository(db)
+	usecase := scene_audio_route_usecase.NewBookmarkUsecase(repo, timeout)
+	ctrl := scene_audio_route_api_controller.NewBookmarkController(usecase)
+
+	bookmarkGroup := group.Group("/bookmarks")
+	{
+		bookmarkGroup.GET("", ctrl.GetBookmarks)
+		bookmarkGroup.GET("/:item_id", ctrl.GetBookmark)
+		bookmarkGroup.PUT("/:item_id", ctrl.SaveBookmark)
+		bookmarkGroup.DELETE("/:item_id", ctrl.DeleteBookmark)
+	}
+}
@@ -0,0 +1,50 @@
+package scene_audio_route_api_route
+
+import (
+	"log"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/dlna_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// NewCastRouter 未开启投屏时不注册路由；设备直接从服务器拉取音频，需要能访问 CAST_BASE_URL
+func NewCastRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, group *gin.RouterGroup) {
+	if !env.CastEnabled {
+		return
+	}
+	baseURL := env.CastBaseURL
+	if baseURL == "" {
+		var err error
+		if baseURL, err = dlna_util.LocalBaseURL(env.ServerAddress); err != nil {
+			log.Printf("投屏无法确定本机地址，未开启: %v", err)
+			return
+		}
+	}
+
+	usecase := scene_audio_route_usecase.NewCastUsecase(
+		scene_audio_route_repository.NewCastRepository(db),
+		baseURL,
+		env.AccessTokenSecret,
+		timeout,
+	)
+	ctrl := scene_audio_route_api_controller.NewCastController(usecase)
+
+	castGroup := group.Group("/cast")
+	{
+		castGroup.GET("/devices", ctrl.GetDevices)
+		castGroup.GET("/devices/:device_id", ctrl.GetStatus)
+		castGroup.POST("/devices/:device_id/load", ctrl.Load)
+		castGroup.POST("/devices/:device_id/play", ctrl.Play)
+		castGroup.POST("/devices/:device_id/pause", ctrl.Pause)
+		castGroup.POST("/devices/:device_id/seek", ctrl.Seek)
+		castGroup.PUT("/devices/:device_id/volume", ctrl.SetVolume)
+		castGroup.POST("/devices/:device_id/stop", ctrl.Stop)
+	}
+	log.Printf("投屏已开启，设备访问地址: %s", baseURL)
+}
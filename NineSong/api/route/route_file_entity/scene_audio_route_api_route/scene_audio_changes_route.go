@@ -0,0 +1,25 @@
+package scene_audio_route_api_route
+
+import (
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+func NewChangesRouter(
+	timeout time.Duration,
+	db mongo.Database,
+	group *gin.RouterGroup,
+) {
+	repo := scene_audio_route_repository.NewChangesRepository(db)
+	usecase := scene_audio_route_usecase.NewChangesUsecase(repo, timeout)
+	ctrl := scene_audio_route_api_controller.NewChangesController(usecase)
+
+	changesGroup := group.Group("/changes")
+	{
+		changesGroup.GET("", ctrl.GetChanges)
+	}
+}
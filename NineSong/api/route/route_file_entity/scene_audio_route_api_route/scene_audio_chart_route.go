@@ -0,0 +1,26 @@
+package scene_audio_route_api_route
+
+import (
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
+	"github.com/gin-gonic/gin"
+)
+
+func NewChartRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, group *gin.RouterGroup) {
+	repo := scene_audio_route_repository.NewChartRepository(db)
+	usecase := scene_audio_route_usecase.NewChartUsecase(repo, timeout)
+	ctrl := scene_audio_route_api_controller.NewChartController(usecase,
+		scene_audio_route_api_controller.NewLinkBuilder(env.PublicBaseURL, env.MediaLinksSigned))
+
+	chartGroup := group.Group("/charts")
+	{
+		chartGroup.GET("/tracks", ctrl.GetTopMediaFiles)
+		chartGroup.GET("/albums", ctrl.GetTopAlbums)
+		chartGroup.GET("/artists", ctrl.GetTopArtists)
+	}
+}
@@ -0,0 +1,28 @@
+package scene_audio_route_api_route
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
+	"github.com/gin-gonic/gin"
+)
+
+func NewDiscoverRouter(timeout time.Duration, db mongo.Database, group *gin.RouterGroup) {
+	repo := scene_audio_route_repository.NewDiscoverRepository(db)
+	usecase := scene_audio_route_usecase.NewDiscoverUsecase(repo, timeout)
+	ctrl := scene_audio_route_api_controller.NewDiscoverController(usecase)
+
+	mixUsecase := scene_audio_route_usecase.NewDailyMixUsecase(scene_audio_route_repository.NewDailyMixRepository(db), repo, timeout)
+	mixUsecase.Start(context.Background())
+	mixCtrl := scene_audio_route_api_controller.NewDailyMixController(mixUsecase)
+
+	discoverGroup := group.Group("/discover")
+	{
+		discoverGroup.GET("/forgotten", ctrl.GetForgotten)
+		discoverGroup.GET("/mixes", mixCtrl.GetMixes)
+	}
+}
@@ -0,0 +1,71 @@
+package scene_audio_route_api_route
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/middleware/middleware_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/dlna_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// NewDLNARouter 未开启 DLNA 时不注册路由；电视与网络音箱无法登录，接口不鉴权，仅允许局域网访问
+func NewDLNARouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, group *gin.RouterGroup) {
+	if !env.DLNAEnabled {
+		return
+	}
+	friendlyName := env.DLNAFriendlyName
+	if friendlyName == "" {
+		host, _ := os.Hostname()
+		friendlyName = "NineSong (" + host + ")"
+	}
+	uuid := dlna_util.DeviceUUID(friendlyName)
+
+	usecase := scene_audio_route_usecase.NewDLNAUsecase(
+		scene_audio_route_repository.NewDLNARepository(db),
+		scene_audio_route_repository.NewAlbumRepository(db, domain.CollectionFileEntityAudioSceneAlbum),
+		scene_audio_route_repository.NewArtistRepository(db, domain.CollectionFileEntityAudioSceneArtist),
+		scene_audio_route_repository.NewMediaFileRepository(db, domain.CollectionFileEntityAudioSceneMediaFile),
+		scene_audio_route_repository.NewRetrievalRepository(db),
+		timeout,
+	)
+	ctrl := scene_audio_route_api_controller.NewDLNAController(usecase, uuid, friendlyName)
+
+	dlnaGroup := group.Group("")
+	dlnaGroup.Use(middleware_system.PrivateNetworkMiddleware())
+	{
+		dlnaGroup.GET(dlna_util.DescriptionPath, ctrl.DeviceDescription)
+		dlnaGroup.GET(dlna_util.ContentDirectorySCPDPath, ctrl.ContentDirectorySCPD)
+		dlnaGroup.GET(dlna_util.ConnectionManagerSCPDPath, ctrl.ConnectionManagerSCPD)
+		dlnaGroup.POST(dlna_util.ContentDirectoryControlPath, ctrl.ContentDirectoryControl)
+		dlnaGroup.POST(dlna_util.ConnectionMgrControlPath, ctrl.ConnectionManagerControl)
+		for _, path := range []string{dlna_util.ContentDirectoryEventPath, dlna_util.ConnectionMgrEventPath} {
+			dlnaGroup.Handle("SUBSCRIBE", path, ctrl.Subscribe)
+			dlnaGroup.Handle("UNSUBSCRIBE", path, ctrl.Unsubscribe)
+		}
+		dlnaGroup.GET(dlna_util.StreamPath+":id", ctrl.Stream)
+		dlnaGroup.HEAD(dlna_util.StreamPath+":id", ctrl.Stream)
+		dlnaGroup.GET(dlna_util.CoverPath+":id", ctrl.Cover)
+	}
+
+	baseURL := env.DLNABaseURL
+	if baseURL == "" {
+		var err error
+		if baseURL, err = dlna_util.LocalBaseURL(env.ServerAddress); err != nil {
+			log.Printf("DLNA 无法确定本机地址，未启动设备发现: %v", err)
+			return
+		}
+	}
+	if err := dlna_util.NewSSDP(uuid, baseURL+dlna_util.DescriptionPath).Start(); err != nil {
+		log.Printf("DLNA 设备发现启动失败: %v", err)
+		return
+	}
+	log.Printf("DLNA 已开启: %s (%s)", friendlyName, baseURL)
+}
@@ -0,0 +1,30 @@
+package scene_audio_route_api_route
+
+import (
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
+	"github.com/gin-gonic/gin"
+)
+
+func NewDownloadRouter(timeout time.Duration, db mongo.Database, group *gin.RouterGroup) {
+	usecase := scene_audio_route_usecase.NewDownloadUsecase(
+		scene_audio_route_repository.NewAlbumRepository(db, domain.CollectionFileEntityAudioSceneAlbum),
+		scene_audio_route_repository.NewPlaylistRepository(db, domain.CollectionFileEntityAudioScenePlaylist),
+		scene_audio_route_repository.NewPlaylistTrackRepository(db, domain.CollectionFileEntityAudioScenePlaylistTrack),
+		scene_audio_route_repository.NewRetrievalRepository(db),
+		timeout,
+	)
+	ctrl := scene_audio_route_api_controller.NewDownloadController(usecase)
+
+	downloadGroup := group.Group("/download")
+	{
+		downloadGroup.GET("/album/:id", ctrl.DownloadAlbum)
+		downloadGroup.GET("/playlist/:id", ctrl.DownloadPlaylist)
+		downloadGroup.GET("/track/:id", ctrl.DownloadTrack)
+	}
+}
@@ -0,0 +1,29 @@
+package scene_audio_route_api_route
+
+import (
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+func NewEqPresetRouter(
+	timeout time.Duration,
+	db mongo.Database,
+	group *gin.RouterGroup,
+) {
+	repo := scene_audio_route_repository.NewEqPresetRepository(db, domain.CollectionFileEntityAudioSceneEqPreset)
+	usecase := scene_audio_route_usecase.NewEqPresetUsecase(repo, timeout)
+	ctrl := scene_audio_route_api_controller.NewEqPresetController(usecase)
+
+	eqPresetGroup := group.Group("/eq_presets")
+	{
+		eqPresetGroup.GET("", ctrl.GetEqPresets)
+		eqPresetGroup.GET("/detail", ctrl.GetEqPreset)
+		eqPresetGroup.POST("", ctrl.SaveEqPreset)
+		eqPresetGroup.DELETE("", ctrl.DeleteEqPreset)
+	}
+}
@@ -0,0 +1,88 @@
+package scene_audio_route_api_route
+
+import (
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/middleware/middleware_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_auth"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_db_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_federation_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_federation_usecase"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// NewFederationRouter 本实例用户浏览远程媒体库，关联管理仅限管理员
+func NewFederationRouter(
+	env *bootstrap.Env,
+	timeout time.Duration,
+	db mongo.Database,
+	group *gin.RouterGroup,
+) {
+	ctrl := newFederationController(env, timeout, db)
+
+	federationGroup := group.Group("/federation")
+	{
+		federationGroup.GET("/peers", ctrl.GetPeers)
+		federationGroup.GET("/peers/:id/albums", ctrl.BrowseAlbums)
+		federationGroup.GET("/peers/:id/artists", ctrl.BrowseArtists)
+		federationGroup.GET("/peers/:id/media_files", ctrl.BrowseMediaFiles)
+		federationGroup.GET("/stream", ctrl.StreamRemote)
+	}
+
+	adminGroup := federationGroup.Group("")
+	adminGroup.Use(middleware_system.AdminOnlyMiddleware(repository_auth.NewUserRepository(db, domain.CollectionUser)))
+	{
+		adminGroup.POST("/invites", ctrl.CreateInvite)
+		adminGroup.POST("/peers", ctrl.LinkPeer)
+		adminGroup.PUT("/peers/:id", ctrl.UpdatePeer)
+		adminGroup.DELETE("/peers/:id", ctrl.DeletePeer)
+	}
+}
+
+// NewFederationPublicRouter 对方实例调用的接口，以联邦令牌代替用户登录
+func NewFederationPublicRouter(
+	env *bootstrap.Env,
+	timeout time.Duration,
+	db mongo.Database,
+	group *gin.RouterGroup,
+) {
+	ctrl := newFederationController(env, timeout, db)
+
+	federationGroup := group.Group("/federation")
+	federationGroup.POST("/handshake", ctrl.Handshake)
+
+	peerGroup := federationGroup.Group("/api")
+	peerGroup.Use(ctrl.Authenticate)
+	{
+		peerGroup.GET("/albums", ctrl.ExportAlbums)
+		peerGroup.GET("/artists", ctrl.ExportArtists)
+		peerGroup.GET("/media_files", ctrl.ExportMediaFiles)
+		peerGroup.GET("/stream", ctrl.ExportStream)
+		peerGroup.DELETE("/link", ctrl.Unlink)
+	}
+}
+
+func newFederationController(env *bootstrap.Env, timeout time.Duration, db mongo.Database) *scene_audio_route_api_controller.FederationController {
+	albumRepo := scene_audio_route_repository.NewAlbumRepository(db, domain.CollectionFileEntityAudioSceneAlbum)
+	artistRepo := scene_audio_route_repository.NewArtistRepository(db, domain.CollectionFileEntityAudioSceneArtist)
+	mediaFileRepo := scene_audio_route_repository.NewMediaFileRepository(db, domain.CollectionFileEntityAudioSceneMediaFile)
+
+	uc := scene_audio_federation_usecase.NewFederationUsecase(
+		scene_audio_federation_repository.NewFederationPeerRepository(db),
+		scene_audio_federation_usecase.NewFederationClient(timeout),
+		scene_audio_route_usecase.NewAlbumUsecase(albumRepo, timeout),
+		scene_audio_route_usecase.NewArtistUsecase(artistRepo, timeout),
+		scene_audio_route_usecase.NewMediaFileUsecase(mediaFileRepo, timeout),
+		scene_audio_db_repository.NewMediaFileRepository(db, domain.CollectionFileEntityAudioSceneMediaFile),
+		env.FederationPublicURL,
+		env.FederationName,
+		timeout,
+	)
+	return scene_audio_route_api_controller.NewFederationController(uc)
+}
@@ -0,0 +1,32 @@
+package scene_audio_route_api_route
+
+import (
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/middleware/middleware_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_auth"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+func NewGenreRouter(
+	timeout time.Duration,
+	db mongo.Database,
+	group *gin.RouterGroup,
+) {
+	repo := scene_audio_route_repository.NewGenreRepository(db, domain.CollectionFileEntityAudioSceneGenre)
+	usecase := scene_audio_route_usecase.NewGenreUsecase(repo, timeout)
+	ctrl := scene_audio_route_api_controller.NewGenreController(usecase)
+
+	adminOnly := middleware_system.AdminOnlyMiddleware(repository_auth.NewUserRepository(db, domain.CollectionUser))
+
+	genreGroup := group.Group("/genres")
+	{
+		genreGroup.GET("", ctrl.GetGenreTree)
+		genreGroup.PUT("", adminOnly, ctrl.SaveGenre)
+		genreGroup.DELETE("", adminOnly, ctrl.DeleteGenre)
+	}
+}
@@ -0,0 +1,21 @@
+package scene_audio_route_api_route
+
+import (
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
+	"github.com/gin-gonic/gin"
+)
+
+func NewHistoryRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, group *gin.RouterGroup) {
+	repo := scene_audio_route_repository.NewHistoryRepository(db)
+	usecase := scene_audio_route_usecase.NewHistoryUsecase(repo, timeout)
+	ctrl := scene_audio_route_api_controller.NewHistoryController(usecase,
+		scene_audio_route_api_controller.NewLinkBuilder(env.PublicBaseURL, env.MediaLinksSigned))
+
+	group.GET("/history", ctrl.GetHistory)
+}
@@ -1,6 +1,11 @@
 package scene_audio_route_api_route
 
 import (
+	"log"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
 	"time"
@@ -11,6 +16,7 @@ import (
 )
 
 func NewHomeRouter(
+	env *bootstrap.Env,
 	timeout time.Duration,
 	db mongo.Database,
 	group *gin.RouterGroup,
@@ -19,6 +25,22 @@ func NewHomeRouter(
 	uc := scene_audio_route_usecase.NewHomeUsecase(repo, timeout)
 	ctrl := scene_audio_route_api_controller.NewHomeController(uc)
 
+	defaultSections, err := scene_audio_route_models.ParseHomeSections(env.HomeSections)
+	if err != nil || len(defaultSections) == 0 {
+		if err != nil {
+			log.Printf("HOME_SECTIONS 配置无效，使用默认首页布局: %v", err)
+		}
+		defaultSections, _ = scene_audio_route_models.ParseHomeSections(scene_audio_route_models.DefaultHomeSections)
+	}
+	sectionUc := scene_audio_route_usecase.NewHomeSectionUsecase(
+		scene_audio_route_repository.NewHomeSectionRepository(db),
+		scene_audio_route_repository.NewUserPreferenceRepository(db, domain.CollectionFileEntityAudioSceneUserPreference),
+		defaultSections,
+		timeout,
+	)
+	sectionCtrl := scene_audio_route_api_controller.NewHomeSectionController(sectionUc)
+	group.GET("/home", sectionCtrl.GetHome)
+
 	router := group.Group("/homes")
 	{
 		router.GET("/artists/random", ctrl.GetRandomArtistList)
@@ -0,0 +1,24 @@
+package scene_audio_route_api_route
+
+import (
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+func NewImageProxyRouter(
+	env *bootstrap.Env,
+	timeout time.Duration,
+	db mongo.Database,
+	group *gin.RouterGroup,
+) {
+	repo := scene_audio_route_repository.NewRetrievalRepository(db)
+	uc := scene_audio_route_usecase.NewImageProxyUsecase(repo, env.ImageProxyHosts, timeout)
+	ctrl := scene_audio_route_api_controller.NewImageProxyController(uc)
+
+	group.GET("/imageproxy", ctrl.GetImage)
+}
@@ -0,0 +1,26 @@
+package scene_audio_route_api_route
+
+import (
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
+	"github.com/gin-gonic/gin"
+)
+
+func NewInternetRadioRouter(timeout time.Duration, db mongo.Database, group *gin.RouterGroup) {
+	usecase := scene_audio_route_usecase.NewInternetRadioUsecase(scene_audio_route_repository.NewInternetRadioRepository(db), timeout)
+	ctrl := scene_audio_route_api_controller.NewInternetRadioController(usecase)
+
+	radioGroup := group.Group("/radio")
+	{
+		radioGroup.GET("", ctrl.GetStations)
+		radioGroup.POST("", ctrl.CreateStation)
+		radioGroup.POST("/check", ctrl.CheckStream)
+		radioGroup.GET("/:id", ctrl.GetStation)
+		radioGroup.PUT("/:id", ctrl.UpdateStation)
+		radioGroup.DELETE("/:id", ctrl.DeleteStation)
+	}
+}
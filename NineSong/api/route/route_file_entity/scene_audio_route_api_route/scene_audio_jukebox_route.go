@@ -0,0 +1,50 @@
+package scene_audio_route_api_route
+
+import (
+	"log"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/middleware/middleware_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/jukebox_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_auth"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// NewJukeboxRouter 未开启点唱机时不注册路由；点唱机控制服务器本机的音频输出，仅管理员可用
+func NewJukeboxRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, group *gin.RouterGroup) {
+	if !env.JukeboxEnabled {
+		return
+	}
+	usecase := scene_audio_route_usecase.NewJukeboxUsecase(
+		scene_audio_route_repository.NewJukeboxRepository(db),
+		jukebox_util.NewMPV(env.JukeboxPlayerPath, env.JukeboxAudioDevice),
+		timeout,
+	)
+	ctrl := scene_audio_route_api_controller.NewJukeboxController(usecase)
+
+	jukeboxGroup := group.Group("/jukebox")
+	jukeboxGroup.Use(middleware_system.AdminOnlyMiddleware(repository_auth.NewUserRepository(db, domain.CollectionUser)))
+	{
+		jukeboxGroup.GET("", ctrl.GetStatus)
+		jukeboxGroup.PUT("/queue", ctrl.SetQueue)
+		jukeboxGroup.POST("/queue", ctrl.AddToQueue)
+		jukeboxGroup.POST("/play", ctrl.Play)
+		jukeboxGroup.POST("/pause", ctrl.Pause)
+		jukeboxGroup.POST("/skip", ctrl.Skip)
+		jukeboxGroup.PUT("/volume", ctrl.SetVolume)
+	}
+	log.Printf("点唱机已开启，音频输出: %s", jukeboxDeviceName(env.JukeboxAudioDevice))
+}
+
+func jukeboxDeviceName(device string) string {
+	if device == "" {
+		return "默认设备"
+	}
+	return device
+}
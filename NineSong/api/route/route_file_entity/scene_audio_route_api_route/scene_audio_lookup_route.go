@@ -0,0 +1,20 @@
+package scene_audio_route_api_route
+
+import (
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
+	"github.com/gin-gonic/gin"
+)
+
+func NewLookupRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, group *gin.RouterGroup) {
+	usecase := scene_audio_route_usecase.NewLookupUsecase(scene_audio_route_repository.NewLookupRepository(db), timeout)
+	ctrl := scene_audio_route_api_controller.NewLookupController(usecase,
+		scene_audio_route_api_controller.NewLinkBuilder(env.PublicBaseURL, env.MediaLinksSigned))
+
+	group.GET("/lookup", ctrl.Lookup)
+}
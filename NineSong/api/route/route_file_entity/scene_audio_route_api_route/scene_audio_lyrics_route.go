@@ -0,0 +1,33 @@
+package scene_audio_route_api_route
+
+import (
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/middleware/middleware_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_auth"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_lyrics_usecase"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+func NewLyricsRouter(
+	env *bootstrap.Env,
+	timeout time.Duration,
+	db mongo.Database,
+	group *gin.RouterGroup,
+) {
+	providers := scene_audio_lyrics_usecase.NewLyricsProviders(env.LyricsProviders, timeout, env.GeniusAccessToken)
+	repo := scene_audio_route_repository.NewLyricsRepository(db, domain.CollectionFileEntityAudioSceneMediaLyricsMetadata, providers)
+	usecase := scene_audio_route_usecase.NewLyricsUsecase(repo, timeout)
+	ctrl := scene_audio_route_api_controller.NewLyricsController(usecase)
+	adminOnly := middleware_system.AdminOnlyMiddleware(repository_auth.NewUserRepository(db, domain.CollectionUser))
+
+	group.GET("/lyrics/:mediaFileId", ctrl.GetLyrics)
+	// 固定的歌词对所有用户生效，与曲目元数据编辑一样仅管理员可改
+	group.PUT("/lyrics/:mediaFileId", adminOnly, ctrl.PinLyrics)
+	group.DELETE("/lyrics/:mediaFileId", adminOnly, ctrl.UnpinLyrics)
+}
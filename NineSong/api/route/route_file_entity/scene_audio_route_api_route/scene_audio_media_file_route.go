@@ -2,6 +2,7 @@ package scene_audio_route_api_route
 
 import (
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
@@ -12,13 +13,15 @@ import (
 )
 
 func NewMediaFileRouter(
+	env *bootstrap.Env,
 	timeout time.Duration,
 	db mongo.Database,
 	group *gin.RouterGroup,
 ) {
 	repo := scene_audio_route_repository.NewMediaFileRepository(db, domain.CollectionFileEntityAudioSceneMediaFile)
 	usecase := scene_audio_route_usecase.NewMediaFileUsecase(repo, timeout)
-	ctrl := scene_audio_route_api_controller.NewMediaFileController(usecase)
+	ctrl := scene_audio_route_api_controller.NewMediaFileController(usecase,
+		scene_audio_route_api_controller.NewLinkBuilder(env.PublicBaseURL, env.MediaLinksSigned))
 
 	mediaGroup := group.Group("/medias")
 	{
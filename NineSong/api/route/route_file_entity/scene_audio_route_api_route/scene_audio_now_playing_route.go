@@ -0,0 +1,28 @@
+package scene_audio_route_api_route
+
+import (
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+func NewNowPlayingRouter(
+	timeout time.Duration,
+	db mongo.Database,
+	group *gin.RouterGroup,
+) {
+	repo := scene_audio_route_repository.NewNowPlayingRepository(db, domain.CollectionFileEntityAudioSceneNowPlaying)
+	usecase := scene_audio_route_usecase.NewNowPlayingUsecase(repo, timeout)
+	ctrl := scene_audio_route_api_controller.NewNowPlayingController(usecase)
+
+	nowPlayingGroup := group.Group("/nowplaying")
+	{
+		nowPlayingGroup.GET("", ctrl.GetNowPlaying)
+		nowPlayingGroup.POST("", ctrl.ReportNowPlaying)
+		nowPlayingGroup.DELETE("", ctrl.ClearNowPlaying)
+	}
+}
@@ -0,0 +1,43 @@
+package scene_audio_route_api_route
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// NewPodcastRouter 单集通过 /media/stream?media_file_id=单集ID 播放，已下载的走本地文件，否则转发远程附件
+func NewPodcastRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, group *gin.RouterGroup) {
+	usecase := scene_audio_route_usecase.NewPodcastUsecase(
+		scene_audio_route_repository.NewPodcastRepository(db),
+		env.PodcastDownloadDir,
+		time.Duration(env.PodcastRefreshMinutes)*time.Minute,
+		timeout,
+	)
+	usecase.Start(context.Background())
+	ctrl := scene_audio_route_api_controller.NewPodcastController(usecase)
+
+	channelGroup := group.Group("/podcast/channels")
+	{
+		channelGroup.GET("", ctrl.GetChannels)
+		channelGroup.POST("", ctrl.Subscribe)
+		channelGroup.GET("/:id", ctrl.GetChannel)
+		channelGroup.PUT("/:id", ctrl.UpdateChannel)
+		channelGroup.DELETE("/:id", ctrl.Unsubscribe)
+		channelGroup.POST("/:id/refresh", ctrl.RefreshChannel)
+		channelGroup.GET("/:id/episodes", ctrl.GetEpisodes)
+	}
+	episodeGroup := group.Group("/podcast/episodes")
+	{
+		episodeGroup.GET("/:id", ctrl.GetEpisode)
+		episodeGroup.PUT("/:id/progress", ctrl.UpdateProgress)
+		episodeGroup.POST("/:id/download", ctrl.DownloadEpisode)
+		episodeGroup.DELETE("/:id/download", ctrl.DeleteDownload)
+	}
+}
@@ -0,0 +1,37 @@
+package scene_audio_route_api_route
+
+import (
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_lastfm/scene_audio_lastfm_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_lastfm_usecase"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// 个性化推荐对每位种子艺术家各执行数次聚合，并可能请求 Last.fm
+const recommendationTimeout = 30 * time.Second
+
+func NewRecommendationRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, group *gin.RouterGroup) {
+	usecase := newRecommendationUsecase(env, timeout, db)
+	ctrl := scene_audio_route_api_controller.NewRecommendationController(usecase,
+		scene_audio_route_api_controller.NewLinkBuilder(env.PublicBaseURL, env.MediaLinksSigned))
+
+	group.GET("/artist/:id/similar", ctrl.GetSimilarArtists)
+	group.GET("/recommendations", ctrl.GetRecommendations)
+}
+
+func newRecommendationUsecase(env *bootstrap.Env, timeout time.Duration, db mongo.Database) scene_audio_route_interface.RecommendationUsecase {
+	// 未配置 Last.fm 密钥时只使用已保存的相似艺术家
+	var lastFM scene_audio_lastfm_interface.LastFMClient
+	if env.LastFMAPIKey != "" {
+		lastFM = scene_audio_lastfm_usecase.NewLastFMUsecase(env.LastFMAPIKey, timeout)
+	}
+	repo := scene_audio_route_repository.NewRecommendationRepository(db, lastFM)
+	return scene_audio_route_usecase.NewRecommendationUsecase(repo, max(timeout, recommendationTimeout))
+}
@@ -2,13 +2,18 @@ package scene_audio_route_api_route
 
 import (
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_transcode_usecase"
 	"github.com/gin-gonic/gin"
 	"time"
 )
 
+// 转码按播放进度输出，超时需覆盖整首曲目的时长
+const transcodeTimeout = 2 * time.Hour
+
 func NewRetrievalRouter(
 	timeout time.Duration,
 	db mongo.Database,
@@ -16,7 +21,12 @@ func NewRetrievalRouter(
 ) {
 	repo := scene_audio_route_repository.NewRetrievalRepository(db)
 	uc := scene_audio_route_usecase.NewRetrievalUsecase(repo, timeout)
-	ctrl := scene_audio_route_api_controller.NewRetrievalController(uc)
+	eqRepo := scene_audio_route_repository.NewEqPresetRepository(db, domain.CollectionFileEntityAudioSceneEqPreset)
+	eqUc := scene_audio_route_usecase.NewEqPresetUsecase(eqRepo, timeout)
+	prefRepo := scene_audio_route_repository.NewUserPreferenceRepository(db, domain.CollectionFileEntityAudioSceneUserPreference)
+	prefUc := scene_audio_route_usecase.NewUserPreferenceUsecase(prefRepo, timeout)
+	transcoder := scene_audio_transcode_usecase.NewTranscodeUsecase(transcodeTimeout)
+	ctrl := scene_audio_route_api_controller.NewRetrievalController(uc, eqUc, prefUc, transcoder)
 
 	retrievalGroup := group.Group("/media")
 	{
@@ -27,4 +37,5 @@ func NewRetrievalRouter(
 		retrievalGroup.GET("/cover/path", ctrl.CoverArtPathHandler)
 		retrievalGroup.GET("/lyrics", ctrl.LyricsHandlerMetadata)
 	}
+	group.GET("/coverart/:id", ctrl.CoverArtHandler)
 }
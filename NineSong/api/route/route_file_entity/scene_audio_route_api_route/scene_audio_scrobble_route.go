@@ -0,0 +1,28 @@
+package scene_audio_route_api_route
+
+import (
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_subsonic/scene_audio_subsonic_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+func NewScrobbleRouter(
+	timeout time.Duration,
+	db mongo.Database,
+	group *gin.RouterGroup,
+	forwarder scene_audio_subsonic_interface.SubsonicForwarder,
+) {
+	repo := scene_audio_route_repository.NewScrobbleRepository(db, domain.CollectionFileEntityAudioScenePlayHistory)
+	usecase := scene_audio_route_usecase.NewScrobbleUsecase(repo, timeout, forwarder)
+	ctrl := scene_audio_route_api_controller.NewScrobbleController(usecase)
+
+	scrobbleGroup := group.Group("/scrobble")
+	{
+		scrobbleGroup.POST("", ctrl.Scrobble)
+	}
+}
@@ -0,0 +1,26 @@
+package scene_audio_route_api_route
+
+import (
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+func NewSearchRouter(
+	timeout time.Duration,
+	db mongo.Database,
+	group *gin.RouterGroup,
+) {
+	repo := scene_audio_route_repository.NewSearchRepository(db)
+	usecase := scene_audio_route_usecase.NewSearchUsecase(repo, timeout)
+	ctrl := scene_audio_route_api_controller.NewSearchController(usecase)
+
+	searchGroup := group.Group("/search")
+	{
+		searchGroup.GET("", ctrl.Search)
+		searchGroup.GET("/lyrics", ctrl.SearchLyrics)
+	}
+}
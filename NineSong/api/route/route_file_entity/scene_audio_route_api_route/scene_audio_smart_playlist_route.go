@@ -0,0 +1,32 @@
+package scene_audio_route_api_route
+
+import (
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+func NewSmartPlaylistRouter(
+	timeout time.Duration,
+	db mongo.Database,
+	group *gin.RouterGroup,
+) {
+	repo := scene_audio_route_repository.NewSmartPlaylistRepository(db, domain.CollectionFileEntityAudioScenePlaylist)
+	usecase := scene_audio_route_usecase.NewSmartPlaylistUsecase(repo, timeout)
+	ctrl := scene_audio_route_api_controller.NewSmartPlaylistController(usecase)
+
+	smartPlaylistGroup := group.Group("/smart_playlists")
+	{
+		smartPlaylistGroup.GET("", ctrl.GetSmartPlaylists)
+		smartPlaylistGroup.POST("", ctrl.CreateSmartPlaylist)
+		smartPlaylistGroup.GET("/detail", ctrl.GetSmartPlaylist)
+		smartPlaylistGroup.PUT("", ctrl.UpdateSmartPlaylist)
+		smartPlaylistGroup.DELETE("", ctrl.DeleteSmartPlaylist)
+		smartPlaylistGroup.GET("/tracks", ctrl.GetSmartPlaylistTracks)
+		smartPlaylistGroup.POST("/preview", ctrl.PreviewSmartPlaylist)
+	}
+}
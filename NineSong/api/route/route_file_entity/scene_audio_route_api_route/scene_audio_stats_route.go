@@ -0,0 +1,31 @@
+package scene_audio_route_api_route
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
+	"github.com/gin-gonic/gin"
+)
+
+func NewStatsRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, group *gin.RouterGroup) {
+	repo := scene_audio_route_repository.NewMilestoneRepository(db, domain.CollectionFileEntityAudioSceneMilestone)
+	usecase := scene_audio_route_usecase.NewMilestoneUsecase(repo, timeout, env.MilestoneWebhookURL)
+	usecase.Start(context.Background())
+	ctrl := scene_audio_route_api_controller.NewMilestoneController(usecase)
+
+	overviewRepo := scene_audio_route_repository.NewStatsOverviewRepository(db)
+	overviewUsecase := scene_audio_route_usecase.NewStatsOverviewUsecase(overviewRepo, timeout)
+	overviewCtrl := scene_audio_route_api_controller.NewStatsOverviewController(overviewUsecase)
+
+	statsGroup := group.Group("/stats")
+	{
+		statsGroup.GET("/me/milestones", ctrl.GetMyMilestones)
+		statsGroup.GET("/overview", overviewCtrl.GetOverview)
+	}
+}
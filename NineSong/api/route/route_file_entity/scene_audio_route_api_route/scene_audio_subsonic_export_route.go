@@ -0,0 +1,31 @@
+package scene_audio_route_api_route
+
+import (
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_subsonic_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_subsonic_usecase"
+	"github.com/gin-gonic/gin"
+)
+
+func NewSubsonicExportRouter(timeout time.Duration, db mongo.Database, group *gin.RouterGroup) {
+	usecase := scene_audio_subsonic_usecase.NewSubsonicExportUsecase(
+		scene_audio_subsonic_repository.NewNumericIDRepository(db),
+		scene_audio_route_repository.NewPlaylistRepository(db, domain.CollectionFileEntityAudioScenePlaylist),
+		scene_audio_route_repository.NewPlaylistTrackRepository(db, domain.CollectionFileEntityAudioScenePlaylistTrack),
+		scene_audio_route_repository.NewInternetRadioRepository(db),
+		timeout,
+	)
+	ctrl := scene_audio_route_api_controller.NewSubsonicExportController(usecase)
+
+	subsonicGroup := group.Group("/subsonic")
+	{
+		subsonicGroup.GET("/playlist/:id", ctrl.GetPlaylist)
+		subsonicGroup.GET("/resolve/:id", ctrl.ResolveID)
+		subsonicGroup.GET("/getInternetRadioStations", ctrl.GetInternetRadioStations)
+	}
+}
@@ -0,0 +1,28 @@
+package scene_audio_route_api_route
+
+import (
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_file_entity/scene_audio_route_api_controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_route_usecase"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+func NewUserPreferenceRouter(
+	timeout time.Duration,
+	db mongo.Database,
+	group *gin.RouterGroup,
+) {
+	repo := scene_audio_route_repository.NewUserPreferenceRepository(db, domain.CollectionFileEntityAudioSceneUserPreference)
+	usecase := scene_audio_route_usecase.NewUserPreferenceUsecase(repo, timeout)
+	ctrl := scene_audio_route_api_controller.NewUserPreferenceController(usecase)
+
+	preferenceGroup := group.Group("/preferences")
+	{
+		preferenceGroup.GET("", ctrl.GetUserPreference)
+		preferenceGroup.PUT("/replay_gain", ctrl.UpdateReplayGainMode)
+	}
+	group.PUT("/users/me/home-sections", ctrl.UpdateHomeSections)
+}
@@ -0,0 +1,27 @@
+package route_system
+
+import (
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/middleware/middleware_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_auth"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_system"
+	"github.com/gin-gonic/gin"
+)
+
+// NewDashboardRouter scan 为扫描路由使用的同一用例，面板才能读到运行中的任务
+func NewDashboardRouter(timeout time.Duration, db mongo.Database, group *gin.RouterGroup, scan domain_system.ScanStatusProvider) {
+	repo := repository_system.NewDashboardRepository(db)
+	uc := usecase_system.NewDashboardUsecase(repo, scan, controller.ServerVersion, controller.APIVersion, timeout)
+	ctrl := controller_system.NewDashboardController(uc)
+
+	admin := group.Group("/admin")
+	admin.Use(middleware_system.AdminOnlyMiddleware(repository_auth.NewUserRepository(db, domain.CollectionUser)))
+	admin.GET("/dashboard", ctrl.Get)
+}
@@ -0,0 +1,13 @@
+package route_system
+
+import (
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_system"
+	"github.com/gin-gonic/gin"
+)
+
+// NewEventRouter 事件经 event_util 共享总线分发，多实例部署时任一实例上的连接都能收到全部事件
+func NewEventRouter(group *gin.RouterGroup) {
+	ctrl := controller_system.NewEventController(usecase_system.NewEventUsecase())
+	group.GET("/events", ctrl.Stream)
+}
@@ -0,0 +1,24 @@
+package route_system
+
+import (
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/middleware/middleware_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_auth"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_system"
+	"github.com/gin-gonic/gin"
+)
+
+func NewExportRouter(timeout time.Duration, db mongo.Database, group *gin.RouterGroup) {
+	repo := repository_system.NewExportRepository(db)
+	uc := usecase_system.NewExportUsecase(repo, timeout)
+	ctrl := controller_system.NewExportController(uc)
+
+	admin := group.Group("/admin")
+	admin.Use(middleware_system.AdminOnlyMiddleware(repository_auth.NewUserRepository(db, domain.CollectionUser)))
+	admin.GET("/export/catalog", ctrl.Export)
+}
@@ -0,0 +1,22 @@
+package route_system
+
+import (
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/middleware/middleware_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_auth"
+	"github.com/gin-gonic/gin"
+)
+
+// NewMigrationRouter 迁移在启动时执行，接口只查询状态
+func NewMigrationRouter(timeout time.Duration, db mongo.Database, group *gin.RouterGroup) {
+	ctrl := controller_system.NewMigrationController(bootstrap.NewMigrationStatusProvider(db), timeout)
+
+	admin := group.Group("/admin")
+	admin.Use(middleware_system.AdminOnlyMiddleware(repository_auth.NewUserRepository(db, domain.CollectionUser)))
+	admin.GET("/migrations", ctrl.Get)
+}
@@ -0,0 +1,67 @@
+package route_system
+
+import (
+	"os/exec"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_auth"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_transcode/scene_audio_transcode_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_lyrics_usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// NewServerCapabilitiesRouter 注册在公开路由上，客户端登录前即可探测功能
+func NewServerCapabilitiesRouter(env *bootstrap.Env, timeout time.Duration, group *gin.RouterGroup) {
+	ctrl := controller_system.NewServerCapabilitiesController(serverCapabilities(env, timeout))
+	group.GET("/api/info", ctrl.Get)
+	group.GET("/api/error-messages", ctrl.GetErrorMessages)
+}
+
+func serverCapabilities(env *bootstrap.Env, timeout time.Duration) domain_system.ServerCapabilities {
+	_, ffmpegErr := exec.LookPath("ffmpeg")
+
+	lyricsProviders := make([]string, 0)
+	for _, provider := range scene_audio_lyrics_usecase.NewLyricsProviders(env.LyricsProviders, timeout, env.GeniusAccessToken) {
+		lyricsProviders = append(lyricsProviders, provider.Name())
+	}
+
+	authProviders := []string{domain_auth.AuthSourceLocal}
+	if env.LDAPURL != "" {
+		authProviders = append(authProviders, domain_auth.AuthSourceLDAP)
+	}
+	if env.OIDCIssuer != "" {
+		authProviders = append(authProviders, domain_auth.AuthSourceOIDC)
+	}
+
+	return domain_system.ServerCapabilities{
+		Name:        controller.ServiceType,
+		Version:     controller.ServerVersion,
+		APIVersion:  controller.APIVersion,
+		APIRevision: controller.APIRevision,
+		Features: domain_system.ServerFeatures{
+			Transcoding:        ffmpegErr == nil,
+			Lyrics:             true, // 内嵌与本地歌词始终可用，在线歌词源见 LyricsProviders
+			LyricsProviders:    lyricsProviders,
+			Podcasts:           true,
+			Subsonic:           false,
+			SubsonicForwarding: env.UpstreamSubsonicURL != "",
+			Federation:         env.FederationPublicURL != "",
+			Fingerprinting:     env.AcoustIDAPIKey != "",
+			MusicBrainz:        env.MusicBrainzEnrichment,
+			LoudnessAnalysis:   env.LoudnessAnalysis,
+			ImageProxy:         env.ImageProxyHosts != "",
+			StrictParams:       env.StrictParams,
+			Jukebox:            env.JukeboxEnabled,
+			DLNA:               env.DLNAEnabled,
+			Cast:               env.CastEnabled,
+			AuthProviders:      authProviders,
+		},
+		AudioFormats:     domain_file_entity.AudioExtensions,
+		TranscodeFormats: scene_audio_transcode_models.FormatNames(),
+	}
+}
@@ -2,8 +2,10 @@ package route_system
 
 import (
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller/controller_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/middleware/middleware_system"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_auth"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_system"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_system"
 	"github.com/gin-gonic/gin"
@@ -16,5 +18,5 @@ func NewSystemConfigurationRouter(timeout time.Duration, db mongo.Database, grou
 	ctrl := controller_system.NewSystemConfigurationController(uc)
 
 	group.GET("/system/config", ctrl.Get)
-	group.PUT("/system/config", ctrl.Update)
+	group.PUT("/system/config", middleware_system.AdminOnlyMiddleware(repository_auth.NewUserRepository(db, domain.CollectionUser)), ctrl.Update)
 }
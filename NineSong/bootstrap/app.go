@@ -1,6 +1,17 @@
 package bootstrap
 
-import "github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+import (
+	"log"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cache_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/event_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/lock_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_db_usecase"
+)
 
 type Application struct {
 	Env   *Env
@@ -11,6 +22,20 @@ func App() Application {
 	app := &Application{}
 	app.Env = NewEnv()
 	app.Mongo = NewMongoDatabase(app.Env)
+	cache_util.Configure(NewCache(app.Env), time.Duration(app.Env.CacheTTLSeconds)*time.Second)
+	event_util.Configure(NewEventBus(app.Env))
+	lock_util.Configure(NewLocker(app.Env))
+	if err := scene_audio_route_repository.ConfigureSearchStrategies(app.Env.SearchStrategy, app.Env.SearchStrategyOverrides); err != nil {
+		log.Printf("搜索策略配置无效，使用默认正则匹配: %v", err)
+	}
+	if err := scene_audio_route_repository.ConfigureSortFields(app.Env.SortFields, app.Env.SortDefaults, app.Env.CustomTagFields); err != nil {
+		log.Printf("排序字段配置无效，使用内置排序白名单: %v", err)
+	}
+	scene_audio_db_usecase.ConfigureCustomTags(app.Env.CustomTags)
+	scene_audio_db_usecase.ConfigureAlbumEditions(app.Env.AlbumSplitEditions)
+	if err := scene_audio_route_models.ConfigureAnnotationItemTypes(app.Env.AnnotationItemTypes); err != nil {
+		log.Printf("注解条目类型配置无效，仅使用内置类型: %v", err)
+	}
 	return *app
 }
 
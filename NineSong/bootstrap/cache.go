@@ -0,0 +1,20 @@
+package bootstrap
+
+import (
+	"log"
+	"strings"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cache_util"
+)
+
+// NewCache 按配置创建聚合结果缓存，未配置 Redis 地址时回退到进程内缓存
+func NewCache(env *Env) cache_util.Cache {
+	if strings.EqualFold(env.CacheBackend, "redis") {
+		if env.RedisAddr != "" {
+			log.Printf("聚合缓存使用 Redis: %s", env.RedisAddr)
+			return cache_util.NewRedisCache(cache_util.NewRedisClient(env.RedisAddr, env.RedisPassword, env.RedisDB))
+		}
+		log.Println("CACHE_BACKEND=redis 但未配置 REDIS_ADDR，使用进程内缓存")
+	}
+	return cache_util.NewMemoryCache(env.CacheCapacity)
+}
@@ -43,6 +43,21 @@ func NewMongoDatabase(env *Env) mongo.Client {
 	return client
 }
 
+// NewResilientDatabase 按配置为数据库调用增加重试与熔断，未配置的项使用默认值
+func NewResilientDatabase(env *Env, client mongo.Client) mongo.Database {
+	opts := mongo.DefaultResilienceOptions()
+	if env.DBMaxRetries != 0 {
+		opts.MaxRetries = env.DBMaxRetries
+	}
+	if env.DBBreakerThreshold > 0 {
+		opts.FailureThreshold = env.DBBreakerThreshold
+	}
+	if env.DBBreakerOpenSeconds > 0 {
+		opts.OpenTimeout = time.Duration(env.DBBreakerOpenSeconds) * time.Second
+	}
+	return mongo.NewResilientDatabase(client.Database(env.DBName), opts)
+}
+
 func CloseMongoDBConnection(client mongo.Client) {
 	if client == nil {
 		return
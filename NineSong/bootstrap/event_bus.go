@@ -0,0 +1,33 @@
+package bootstrap
+
+import (
+	"log"
+	"strings"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cache_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/event_util"
+)
+
+// NewEventBus 按配置创建事件总线，缺少连接配置时回退到进程内总线
+func NewEventBus(env *Env) event_util.Bus {
+	switch strings.ToLower(env.EventBusBackend) {
+	case "redis":
+		if env.RedisAddr != "" {
+			log.Printf("事件总线使用 Redis: %s", env.RedisAddr)
+			return event_util.NewRedisBus(cache_util.NewRedisClient(env.RedisAddr, env.RedisPassword, env.RedisDB))
+		}
+		log.Println("EVENT_BUS_BACKEND=redis 但未配置 REDIS_ADDR，使用进程内事件总线")
+	case "nats":
+		if env.NatsURL != "" {
+			bus, err := event_util.NewNatsBus(env.NatsURL)
+			if err == nil {
+				log.Printf("事件总线使用 NATS: %s", env.NatsURL)
+				return bus
+			}
+			log.Printf("NATS_URL 无效，使用进程内事件总线: %v", err)
+		} else {
+			log.Println("EVENT_BUS_BACKEND=nats 但未配置 NATS_URL，使用进程内事件总线")
+		}
+	}
+	return event_util.NewMemoryBus()
+}
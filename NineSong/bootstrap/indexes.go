@@ -0,0 +1,613 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_system"
+	"go.mongodb.org/mongo-driver/bson"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// indexMigration 一个版本的索引变更，按版本号递增追加，已发布的版本不要再修改
+type indexMigration struct {
+	version     int
+	description string
+	indexes     map[string][]driver.IndexModel
+}
+
+func ascIndex(name string, keys ...string) driver.IndexModel {
+	d := make(bson.D, 0, len(keys))
+	for _, key := range keys {
+		d = append(d, bson.E{Key: key, Value: 1})
+	}
+	return driver.IndexModel{Keys: d, Options: options.Index().SetName(name)}
+}
+
+// textIndex 按字段权重生成文本索引；default_language 设为 none，避免英文词干化影响中日文等非英文标题
+func textIndex(weights bson.D) driver.IndexModel {
+	keys := make(bson.D, 0, len(weights))
+	for _, w := range weights {
+		keys = append(keys, bson.E{Key: w.Key, Value: "text"})
+	}
+	return driver.IndexModel{
+		Keys: keys,
+		Options: options.Index().
+			SetName(scene_audio_route_models.SearchTextIndexName).
+			SetWeights(weights).
+			SetDefaultLanguage("none"),
+	}
+}
+
+// denormalizedAnnotationIndexes 注解字段冗余到条目文档后，播放与收藏排序直接走条目集合索引
+func denormalizedAnnotationIndexes() map[string][]driver.IndexModel {
+	desc := func(name string, keys ...string) driver.IndexModel {
+		d := make(bson.D, 0, len(keys)+1)
+		for _, key := range keys {
+			d = append(d, bson.E{Key: key, Value: -1})
+		}
+		d = append(d, bson.E{Key: "_id", Value: 1})
+		return driver.IndexModel{Keys: d, Options: options.Index().SetName(name)}
+	}
+	indexes := make(map[string][]driver.IndexModel)
+	for _, collName := range []string{
+		domain.CollectionFileEntityAudioSceneMediaFile,
+		domain.CollectionFileEntityAudioSceneAlbum,
+		domain.CollectionFileEntityAudioSceneArtist,
+	} {
+		indexes[collName] = []driver.IndexModel{
+			desc("idx_play_count", "play_count"),
+			desc("idx_play_date", "play_date"),
+			desc("idx_rating", "rating"),
+			desc("idx_starred", "starred", "starred_at"),
+		}
+	}
+	return indexes
+}
+
+var indexMigrations = []indexMigration{
+	{
+		version:     1,
+		description: "统一搜索文本索引",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioSceneMediaFile: {textIndex(bson.D{
+				{Key: "title", Value: 10}, {Key: "artist", Value: 5}, {Key: "album", Value: 3},
+				{Key: "album_artist", Value: 3}, {Key: "composer", Value: 1},
+			})},
+			domain.CollectionFileEntityAudioSceneAlbum: {textIndex(bson.D{
+				{Key: "name", Value: 10}, {Key: "artist", Value: 5}, {Key: "album_artist", Value: 3},
+			})},
+			domain.CollectionFileEntityAudioSceneArtist: {textIndex(bson.D{
+				{Key: "name", Value: 10}, {Key: "aliases.name", Value: 5},
+			})},
+		},
+	},
+	{
+		version:     2,
+		description: "浏览排序与过滤索引",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioSceneMediaFile: {
+				ascIndex("idx_path", "path"),
+				ascIndex("idx_library_path", "library_path"),
+				ascIndex("idx_album_file_name", "album_id", "file_name"),
+				ascIndex("idx_artist_id", "artist_id"),
+				ascIndex("idx_all_artist_ids", "all_artist_ids.artist_id"),
+				ascIndex("idx_album_artist_id", "album_artist_id"),
+				ascIndex("idx_order_title", "order_title", "_id"),
+				ascIndex("idx_order_album_name", "order_album_name", "_id"),
+				ascIndex("idx_order_artist_name", "order_artist_name", "_id"),
+				ascIndex("idx_order_album_artist_name", "order_album_artist_name", "_id"),
+				ascIndex("idx_year", "year", "_id"),
+				ascIndex("idx_genre", "genre"),
+				ascIndex("idx_created_at", "created_at", "_id"),
+				ascIndex("idx_review_status", "review_status", "created_at", "_id"),
+			},
+			domain.CollectionFileEntityAudioSceneMediaFileCue: {
+				ascIndex("idx_path", "path"),
+				ascIndex("idx_performer_id", "performer_id"),
+			},
+			domain.CollectionFileEntityAudioSceneAlbum: {
+				ascIndex("idx_artist_id", "artist_id"),
+				ascIndex("idx_all_artist_ids", "all_artist_ids.artist_id"),
+				ascIndex("idx_order_album_name", "order_album_name", "_id"),
+				ascIndex("idx_min_year", "min_year", "_id"),
+				ascIndex("idx_genre", "genre"),
+				ascIndex("idx_created_at", "created_at", "_id"),
+			},
+			domain.CollectionFileEntityAudioSceneArtist: {
+				ascIndex("idx_name", "name"),
+				ascIndex("idx_order_artist_name", "order_artist_name", "_id"),
+				ascIndex("idx_created_at", "created_at", "_id"),
+			},
+			// 列表接口通过 $lookup 按 item_id/item_type 关联注解，play_count 等排序字段都来自这里
+			domain.CollectionFileEntityAudioSceneAnnotation: {
+				ascIndex("idx_item", "item_id", "item_type"),
+				{
+					Keys:    bson.D{{Key: "item_type", Value: 1}, {Key: "play_count", Value: -1}},
+					Options: options.Index().SetName("idx_type_play_count"),
+				},
+				{
+					Keys:    bson.D{{Key: "item_type", Value: 1}, {Key: "play_date", Value: -1}},
+					Options: options.Index().SetName("idx_type_play_date"),
+				},
+				{
+					Keys:    bson.D{{Key: "item_type", Value: 1}, {Key: "starred", Value: 1}, {Key: "starred_at", Value: -1}},
+					Options: options.Index().SetName("idx_type_starred"),
+				},
+			},
+			domain.CollectionFileEntityAudioScenePlaylistTrack: {
+				ascIndex("idx_playlist_index", "playlist_id", "index"),
+				ascIndex("idx_media_file_id", "media_file_id"),
+			},
+			domain.CollectionFileEntityAudioScenePlayHistory: {
+				{
+					Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "played_at", Value: -1}},
+					Options: options.Index().SetName("idx_user_played_at"),
+				},
+				ascIndex("idx_media_file_id", "media_file_id"),
+			},
+		},
+	},
+	{
+		version:     3,
+		description: "上游 Subsonic 转发队列与映射索引",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioSceneSubsonicForwardQueue: {
+				ascIndex("idx_status_next_attempt", "status", "next_attempt_at"),
+			},
+			domain.CollectionFileEntityAudioSceneSubsonicMapping: {
+				{
+					Keys:    bson.D{{Key: "item_type", Value: 1}, {Key: "item_id", Value: 1}},
+					Options: options.Index().SetName("idx_item").SetUnique(true),
+				},
+			},
+		},
+	},
+	{
+		version:     4,
+		description: "联邦实例令牌索引",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioSceneFederationPeer: {
+				ascIndex("idx_inbound_token_hash", "inbound_token_hash"),
+			},
+		},
+	},
+	{
+		version:     5,
+		description: "条目冗余注解字段排序索引",
+		indexes:     denormalizedAnnotationIndexes(),
+	},
+	{
+		version:     6,
+		description: "前缀搜索策略字段索引",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioSceneMediaFile: {
+				ascIndex("idx_title", "title"),
+				ascIndex("idx_artist", "artist"),
+				ascIndex("idx_album", "album"),
+			},
+			domain.CollectionFileEntityAudioSceneAlbum: {
+				ascIndex("idx_name", "name"),
+				ascIndex("idx_artist", "artist"),
+				ascIndex("idx_album_artist", "album_artist"),
+			},
+			domain.CollectionFileEntityAudioSceneArtist: {
+				ascIndex("idx_aliases_name", "aliases.name"),
+			},
+		},
+	},
+	{
+		version:     7,
+		description: "增量同步变更流索引",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioSceneMediaFile: {ascIndex("idx_updated_at", "updated_at")},
+			domain.CollectionFileEntityAudioSceneAlbum:     {ascIndex("idx_updated_at", "updated_at")},
+			domain.CollectionFileEntityAudioSceneArtist:    {ascIndex("idx_updated_at", "updated_at")},
+		},
+	},
+	{
+		version:     8,
+		description: "删除日志过期索引",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioSceneDeletionLog: {
+				{
+					Keys: bson.D{{Key: "deleted_at", Value: 1}},
+					Options: options.Index().SetName("idx_deleted_at_ttl").
+						SetExpireAfterSeconds(int32(scene_audio_route_models.DeletionLogRetention / time.Second)),
+				},
+			},
+			domain.CollectionFileEntityAudioScenePlaylist: {ascIndex("idx_updated_at", "updated_at")},
+		},
+	},
+	{
+		version:     9,
+		description: "媒体库范围过滤索引",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioSceneMediaFile: {ascIndex("idx_folder_id_album_id", "folder_id", "album_id")},
+		},
+	},
+	{
+		version:     10,
+		description: "歌词按曲目唯一索引",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioSceneMediaLyricsMetadata: {
+				{
+					Keys:    bson.D{{Key: "media_id", Value: 1}},
+					Options: options.Index().SetName("idx_media_id").SetUnique(true),
+				},
+			},
+		},
+	},
+	{
+		version:     11,
+		description: "首次播放时间排序索引",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioSceneMediaFile: {
+				{
+					Keys:    bson.D{{Key: "first_play_date", Value: -1}, {Key: "_id", Value: 1}},
+					Options: options.Index().SetName("idx_first_play_date"),
+				},
+			},
+			domain.CollectionFileEntityAudioSceneAlbum: {
+				{
+					Keys:    bson.D{{Key: "first_play_date", Value: -1}, {Key: "_id", Value: 1}},
+					Options: options.Index().SetName("idx_first_play_date"),
+				},
+			},
+		},
+	},
+	{
+		version:     12,
+		description: "声纹识别状态索引",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioSceneMediaFile: {
+				{
+					Keys:    bson.D{{Key: "acoustid_status", Value: 1}, {Key: "acoustid_score", Value: -1}},
+					Options: options.Index().SetName("idx_acoustid_status").SetSparse(true),
+				},
+			},
+		},
+	},
+	{
+		version:     13,
+		description: "收听里程碑唯一索引",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioSceneMilestone: {
+				{
+					Keys: bson.D{
+						{Key: "user_id", Value: 1}, {Key: "type", Value: 1},
+						{Key: "item_id", Value: 1}, {Key: "threshold", Value: 1},
+					},
+					Options: options.Index().SetName("idx_user_type_item_threshold").SetUnique(true),
+				},
+				{
+					Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "achieved_at", Value: -1}},
+					Options: options.Index().SetName("idx_user_achieved_at"),
+				},
+			},
+			domain.CollectionFileEntityAudioScenePlayHistory: {
+				ascIndex("idx_created_at", "created_at"),
+			},
+		},
+	},
+	{
+		version:     14,
+		description: "按用户与条目查询播放记录",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioScenePlayHistory: {
+				ascIndex("idx_user_media_file_id", "user_id", "media_file_id"),
+				ascIndex("idx_user_album_id", "user_id", "album_id"),
+			},
+		},
+	},
+	{
+		version:     15,
+		description: "响度分析状态索引",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioSceneMediaFile: {
+				ascIndex("idx_loudness_analyzed_at", "loudness_analyzed_at"),
+			},
+		},
+	},
+	{
+		version:     16,
+		description: "专辑内按光盘与音轨号排序",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioSceneMediaFile: {
+				ascIndex("idx_album_disc_track", "album_id", "disc_number", "track_number", "file_name"),
+			},
+		},
+	},
+	{
+		version:     17,
+		description: "相似艺术家的共同收听统计",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioScenePlayHistory: {
+				ascIndex("idx_artist_user", "artist_id", "user_id"),
+			},
+		},
+	},
+	{
+		version:     18,
+		description: "每日推荐系统播放列表",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioScenePlaylist: {
+				ascIndex("idx_owner_system", "owner_id", "system"),
+			},
+			domain.CollectionFileEntityAudioScenePlayHistory: {
+				ascIndex("idx_played_at", "played_at"),
+			},
+		},
+	},
+	{
+		version:     19,
+		description: "专辑附件",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioSceneAlbumAttachment: {
+				ascIndex("idx_album_path", "album_id", "path"),
+			},
+		},
+	},
+	{
+		version:     20,
+		description: "按用户关联注解",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioSceneAnnotation: {
+				ascIndex("idx_user_item", "user_id", "item_id", "item_type"),
+				{
+					Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "item_type", Value: 1}, {Key: "starred", Value: 1}, {Key: "starred_at", Value: -1}},
+					Options: options.Index().SetName("idx_user_type_starred"),
+				},
+			},
+		},
+	},
+	{
+		version:     21,
+		description: "API 密钥",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionAPIKey: {
+				{
+					Keys:    bson.D{{Key: "key_hash", Value: 1}},
+					Options: options.Index().SetName("idx_key_hash").SetUnique(true),
+				},
+				ascIndex("idx_user_created_at", "user_id", "created_at"),
+			},
+		},
+	},
+	{
+		version:     22,
+		description: "外部认证账号",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionUser: {
+				{
+					Keys: bson.D{{Key: "source", Value: 1}, {Key: "external_id", Value: 1}},
+					Options: options.Index().SetName("idx_source_external_id").SetUnique(true).
+						SetPartialFilterExpression(bson.M{"external_id": bson.M{"$exists": true}}),
+				},
+			},
+		},
+	},
+	{
+		version:     23,
+		description: "按原始发行年份排序",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioSceneAlbum: {
+				ascIndex("idx_original_year", "original_year", "_id"),
+			},
+		},
+	},
+	{
+		version:     24,
+		description: "按 ISRC 与条码精确查找",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioSceneMediaFile: {
+				ascIndex("idx_isrc", "isrc"),
+			},
+			domain.CollectionFileEntityAudioSceneAlbum: {
+				ascIndex("idx_barcode", "barcode"),
+			},
+		},
+	},
+	{
+		version:     25,
+		description: "正在播放按用户+客户端上报并自动过期",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioSceneNowPlaying: {
+				{
+					Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "client", Value: 1}},
+					Options: options.Index().SetName("idx_user_client").SetUnique(true),
+				},
+				{
+					Keys:    bson.D{{Key: "expires_at", Value: 1}},
+					Options: options.Index().SetName("idx_expires_at_ttl").SetExpireAfterSeconds(0),
+				},
+			},
+		},
+	},
+	{
+		version:     26,
+		description: "整数ID映射按条目反查",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioSceneNumericID: {
+				{
+					Keys:    bson.D{{Key: "item_type", Value: 1}, {Key: "item_id", Value: 1}},
+					Options: options.Index().SetName("idx_item").SetUnique(true),
+				},
+			},
+		},
+	},
+	{
+		version:     27,
+		description: "网络电台同一用户下名称唯一",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioSceneInternetRadio: {
+				{
+					Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "name", Value: 1}},
+					Options: options.Index().SetName("idx_user_name").SetUnique(true),
+				},
+			},
+		},
+	},
+	{
+		version:     28,
+		description: "试运行报告按时间列出并自动过期",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioSceneMaintenanceReport: {
+				{
+					Keys:    bson.D{{Key: "started_at", Value: -1}},
+					Options: options.Index().SetName("idx_started_at"),
+				},
+				{
+					Keys:    bson.D{{Key: "expires_at", Value: 1}},
+					Options: options.Index().SetName("idx_expires_at_ttl").SetExpireAfterSeconds(0),
+				},
+			},
+		},
+	},
+	{
+		version:     29,
+		description: "播客订阅按用户去重，单集按订阅去重并按发布时间排序",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioScenePodcastChannel: {
+				{
+					Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "feed_url", Value: 1}},
+					Options: options.Index().SetName("idx_user_feed").SetUnique(true),
+				},
+			},
+			domain.CollectionFileEntityAudioScenePodcastEpisode: {
+				{
+					Keys:    bson.D{{Key: "channel_id", Value: 1}, {Key: "guid", Value: 1}},
+					Options: options.Index().SetName("idx_channel_guid").SetUnique(true),
+				},
+				{
+					Keys:    bson.D{{Key: "channel_id", Value: 1}, {Key: "published_at", Value: -1}},
+					Options: options.Index().SetName("idx_channel_published"),
+				},
+			},
+		},
+	},
+	{
+		version:     30,
+		description: "有声书进度按用户与书唯一，书架按最近收听排序",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioSceneAudiobookProgress: {
+				{
+					Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "book_id", Value: 1}},
+					Options: options.Index().SetName("idx_user_book").SetUnique(true),
+				},
+				{
+					Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "updated_at", Value: -1}},
+					Options: options.Index().SetName("idx_user_updated"),
+				},
+			},
+		},
+	},
+	{
+		version:     31,
+		description: "专辑详情按版本分组查询其他发行版",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioSceneAlbum: {
+				{
+					Keys:    bson.D{{Key: "version_group_id", Value: 1}},
+					Options: options.Index().SetName("idx_version_group"),
+				},
+			},
+		},
+	},
+	{
+		version:     32,
+		description: "书签按用户与条目唯一，列表按最近更新排序",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioSceneBookmark: {
+				{
+					Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "item_id", Value: 1}},
+					Options: options.Index().SetName("idx_user_item").SetUnique(true),
+				},
+				{
+					Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "updated_at", Value: -1}},
+					Options: options.Index().SetName("idx_user_updated"),
+				},
+			},
+		},
+	},
+	{
+		version:     33,
+		description: "歌词全文检索索引",
+		indexes: map[string][]driver.IndexModel{
+			domain.CollectionFileEntityAudioSceneMediaLyricsMetadata: {textIndex(bson.D{{Key: "lyrics", Value: 1}})},
+		},
+	},
+}
+
+// ensureIndexes 依次执行未应用的索引迁移，并校验已应用版本的索引是否仍然存在；失败时仅记录日志，不阻断启动
+func (si *Initializer) ensureIndexes(ctx context.Context) {
+	applied, err := appliedMigrationVersion(ctx, si.db, domain_system.MigrationScopeIndex)
+	if err != nil {
+		log.Printf("读取索引迁移记录失败: %v", err)
+		return
+	}
+
+	for _, m := range indexMigrations {
+		if m.version <= applied {
+			si.verifyIndexes(ctx, m)
+			continue
+		}
+		started := time.Now()
+		if err := si.applyIndexMigration(ctx, m); err != nil {
+			// 后续版本可能依赖本版本，下次启动重试
+			log.Printf("索引迁移 v%d 失败: %v", m.version, err)
+			recordMigrationFailure(ctx, si.db, domain_system.MigrationScopeIndex, m.version, m.description, err)
+			return
+		}
+		if err := recordMigration(ctx, si.db, domain_system.MigrationScopeIndex, m.version, m.description, time.Since(started)); err != nil {
+			log.Printf("索引迁移 v%d 失败: %v", m.version, err)
+			return
+		}
+		log.Printf("已应用索引迁移 v%d: %s", m.version, m.description)
+	}
+}
+
+func (si *Initializer) applyIndexMigration(ctx context.Context, m indexMigration) error {
+	for collName, models := range m.indexes {
+		if _, err := si.db.Collection(collName).CreateIndexes(ctx, models); err != nil {
+			return fmt.Errorf("创建索引失败 %s: %w", collName, err)
+		}
+	}
+	return nil
+}
+
+// verifyIndexes 补建被手动删除的索引
+func (si *Initializer) verifyIndexes(ctx context.Context, m indexMigration) {
+	for collName, models := range m.indexes {
+		coll := si.db.Collection(collName)
+		names, err := coll.ListIndexNames(ctx)
+		if err != nil {
+			log.Printf("读取索引列表失败 %s: %v", collName, err)
+			continue
+		}
+		existing := make(map[string]bool, len(names))
+		for _, name := range names {
+			existing[name] = true
+		}
+
+		var missing []driver.IndexModel
+		for _, model := range models {
+			if name := model.Options.Name; name != nil && !existing[*name] {
+				missing = append(missing, model)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+		if _, err := coll.CreateIndexes(ctx, missing); err != nil {
+			log.Printf("补建索引失败 %s: %v", collName, err)
+			continue
+		}
+		log.Printf("已补建 %s 缺失索引 %d 个", collName, len(missing))
+	}
+}
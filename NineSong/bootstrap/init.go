@@ -7,6 +7,7 @@ import (
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_app/domain_app_config"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
 	"golang.org/x/crypto/bcrypt"
 	"log"
 	"os"
@@ -36,8 +37,10 @@ func NewInitializer(env *Env, db mongo.Database) *Initializer {
 			"system_init",
 			domain.CollectionUser,
 			domain.CollectionTask,
+			domain.CollectionAPIKey,
 			domain.CollectionSystemInfo,
 			domain.CollectionSystemConfiguration,
+			domain.CollectionSystemMigrations,
 			domain.CollectionFileEntityFileInfo,
 			domain.CollectionFileEntityFolderInfo,
 			domain.CollectionFileEntityAudioAppConfigs,
@@ -59,6 +62,25 @@ func NewInitializer(env *Env, db mongo.Database) *Initializer {
 			domain.CollectionFileEntityAudioScenePlaylist,
 			domain.CollectionFileEntityAudioScenePlaylistTrack,
 			domain.CollectionFileEntityAudioSceneTempMetadata,
+			domain.CollectionFileEntityAudioSceneEqPreset,
+			domain.CollectionFileEntityAudioSceneUserPreference,
+			domain.CollectionFileEntityAudioScenePlayQueue,
+			domain.CollectionFileEntityAudioSceneMusicBrainzRelease,
+			domain.CollectionFileEntityAudioScenePlayHistory,
+			domain.CollectionFileEntityAudioSceneGenre,
+			domain.CollectionFileEntityAudioSceneSubsonicForwardQueue,
+			domain.CollectionFileEntityAudioSceneSubsonicMapping,
+			domain.CollectionFileEntityAudioSceneFederationPeer,
+			domain.CollectionFileEntityAudioSceneDeletionLog,
+			domain.CollectionFileEntityAudioSceneMilestone,
+			domain.CollectionFileEntityAudioSceneNowPlaying,
+			domain.CollectionFileEntityAudioSceneNumericID,
+			domain.CollectionFileEntityAudioSceneInternetRadio,
+			domain.CollectionFileEntityAudioSceneMaintenanceReport,
+			domain.CollectionFileEntityAudioScenePodcastChannel,
+			domain.CollectionFileEntityAudioScenePodcastEpisode,
+			domain.CollectionFileEntityAudioSceneAudiobookProgress,
+			domain.CollectionFileEntityAudioSceneBookmark,
 		},
 	}
 }
@@ -77,6 +99,14 @@ func (si *Initializer) CheckAndInitialize(ctx context.Context) error {
 	if err := si.checkAndCreateCollections(ctx); err != nil {
 		return err
 	}
+	background := si.runMigrations(ctx)
+	// 后台数据迁移可能耗时较长，期间列表查询自动回退为关联注解集合；迁移完成后由冗余修复统一重新同步
+	go func() {
+		background(context.Background())
+		if err := scene_audio_route_repository.RepairAnnotationDenormalization(context.Background(), si.db); err != nil {
+			log.Printf("补写冗余注解字段失败: %v", err)
+		}
+	}()
 
 	if si.isSystemInitialized(ctx) {
 		return nil
@@ -186,6 +216,7 @@ func (si *Initializer) initAdminUser(ctx context.Context) (primitive.ObjectID, e
 		Email:    "admin@gmail.com",
 		Password: "admin123",
 		Admin:    true,
+		Roles:    []string{domain_auth.RoleAdmin},
 	}
 	encryptedPassword, err := bcrypt.GenerateFromPassword(
 		[]byte(user.Password),
@@ -646,6 +677,11 @@ func (si *Initializer) initFileEntityAudioTempMetadata(ctx context.Context) erro
 			MetadataType: "stream",
 			FolderPath:   filepath.Join(basePath, "Stream"),
 		},
+		{
+			ID:           primitive.NewObjectID(),
+			MetadataType: "upload",
+			FolderPath:   filepath.Join(basePath, "Upload"),
+		},
 	}
 
 	// 批量插入优化
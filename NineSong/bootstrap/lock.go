@@ -0,0 +1,21 @@
+package bootstrap
+
+import (
+	"log"
+	"strings"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cache_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/lock_util"
+)
+
+// NewLocker 按配置创建扫描锁与节流器，未配置 Redis 地址时回退到进程内实现
+func NewLocker(env *Env) lock_util.Locker {
+	if strings.EqualFold(env.LockBackend, "redis") {
+		if env.RedisAddr != "" {
+			log.Printf("扫描锁使用 Redis: %s", env.RedisAddr)
+			return lock_util.NewRedisLocker(cache_util.NewRedisClient(env.RedisAddr, env.RedisPassword, env.RedisDB))
+		}
+		log.Println("LOCK_BACKEND=redis 但未配置 REDIS_ADDR，使用进程内锁")
+	}
+	return lock_util.NewMemoryLocker()
+}
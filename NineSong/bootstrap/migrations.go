@@ -0,0 +1,268 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/lock_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_auth"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_db_repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_file_entity/scene_audio/scene_audio_route_repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// dataMigration 一个版本的数据变更，按版本号递增追加，已发布的版本不要再修改；
+// run 中断后会在下次启动时整体重跑，需可重复执行
+type dataMigration struct {
+	version     int
+	description string
+	// background 耗时较长的迁移在启动后于后台执行，其后的版本也随之转入后台，保证按版本顺序执行
+	background bool
+	run        func(ctx context.Context, db mongo.Database) error
+}
+
+var dataMigrations = []dataMigration{
+	{
+		version:     1,
+		description: "为只有 admin 标记的旧用户补写角色",
+		run:         repository_auth.BackfillUserRoles,
+	},
+	{
+		version:     2,
+		description: "升级前没有用户的注解归属最早的管理员",
+		background:  true,
+		run:         scene_audio_route_repository.AssignLegacyAnnotations,
+	},
+	{
+		version:     3,
+		description: "为已有播放的注解补写首次播放时间",
+		background:  true,
+		run:         scene_audio_route_repository.BackfillFirstPlayDates,
+	},
+	{
+		version:     4,
+		description: "按 library_path 为旧媒体文件补写所属媒体库ID",
+		background:  true,
+		run:         repository_file_entity.BackfillMediaFolderIDs,
+	},
+	{
+		version:     5,
+		description: "为旧专辑补写版本分组ID",
+		background:  true,
+		run:         scene_audio_db_repository.BackfillAlbumVersionGroups,
+	},
+	{
+		version:     6,
+		description: "CUE 虚拟曲目时长由秒换算为纳秒",
+		background:  true,
+		run:         scene_audio_db_repository.NormalizeCueTrackDurations,
+	},
+}
+
+// migrationRecord 迁移记录，以“范围_v版本”为主键；applied_at 为空表示尚未成功
+type migrationRecord struct {
+	ID          string    `bson:"_id"`
+	Scope       string    `bson:"scope"`
+	Version     int       `bson:"version"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"applied_at,omitempty"`
+	DurationMs  int64     `bson:"duration_ms,omitempty"`
+	LastError   string    `bson:"last_error,omitempty"`
+	FailedAt    time.Time `bson:"failed_at,omitempty"`
+}
+
+func migrationRecordID(scope string, version int) string {
+	return fmt.Sprintf("%s_v%d", scope, version)
+}
+
+// runMigrations 持有迁移锁执行索引迁移与前台数据迁移，返回的函数在后台继续执行剩余数据迁移后释放锁；
+// 其他实例正在迁移时直接跳过，由其完成
+func (si *Initializer) runMigrations(ctx context.Context) (background func(ctx context.Context)) {
+	release, err := lock_util.Acquire(ctx, lock_util.KeyMigrations)
+	if err != nil {
+		if errors.Is(err, lock_util.ErrLocked) {
+			log.Printf("其他实例正在执行迁移，本实例跳过")
+		} else {
+			log.Printf("获取迁移锁失败: %v", err)
+		}
+		return func(context.Context) {}
+	}
+
+	si.ensureIndexes(ctx)
+	pending := si.ensureDataMigrations(ctx)
+	return func(ctx context.Context) {
+		defer release()
+		for _, m := range pending {
+			if !si.applyDataMigration(ctx, m) {
+				return
+			}
+		}
+	}
+}
+
+// ensureDataMigrations 依次执行未应用的前台数据迁移，遇到后台迁移时返回其及之后的版本；失败时仅记录日志，不阻断启动
+func (si *Initializer) ensureDataMigrations(ctx context.Context) []dataMigration {
+	applied, err := appliedMigrationVersion(ctx, si.db, domain_system.MigrationScopeData)
+	if err != nil {
+		log.Printf("读取数据迁移记录失败: %v", err)
+		return nil
+	}
+
+	for i, m := range dataMigrations {
+		if m.version <= applied {
+			continue
+		}
+		if m.background {
+			return dataMigrations[i:]
+		}
+		if !si.applyDataMigration(ctx, m) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// applyDataMigration 失败时记录错误并返回 false，后续版本可能依赖本版本，下次启动重试
+func (si *Initializer) applyDataMigration(ctx context.Context, m dataMigration) bool {
+	started := time.Now()
+	if err := m.run(ctx, si.db); err != nil {
+		log.Printf("数据迁移 v%d 失败: %v", m.version, err)
+		recordMigrationFailure(ctx, si.db, domain_system.MigrationScopeData, m.version, m.description, err)
+		return false
+	}
+	if err := recordMigration(ctx, si.db, domain_system.MigrationScopeData, m.version, m.description, time.Since(started)); err != nil {
+		log.Printf("数据迁移 v%d 失败: %v", m.version, err)
+		return false
+	}
+	log.Printf("已应用数据迁移 v%d: %s", m.version, m.description)
+	return true
+}
+
+func appliedMigrationVersion(ctx context.Context, db mongo.Database, scope string) (int, error) {
+	cursor, err := db.Collection(domain.CollectionSystemMigrations).Find(ctx,
+		bson.M{"scope": scope, "applied_at": bson.M{"$exists": true}},
+		options.Find().SetSort(bson.D{{Key: "version", Value: -1}}).SetLimit(1),
+	)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var records []migrationRecord
+	if err := cursor.All(ctx, &records); err != nil {
+		return 0, err
+	}
+	if len(records) == 0 {
+		return 0, nil
+	}
+	return records[0].Version, nil
+}
+
+func recordMigration(ctx context.Context, db mongo.Database, scope string, version int, description string, took time.Duration) error {
+	if _, err := db.Collection(domain.CollectionSystemMigrations).UpdateOne(ctx,
+		bson.M{"_id": migrationRecordID(scope, version)},
+		bson.M{
+			"$set": bson.M{
+				"scope":       scope,
+				"version":     version,
+				"description": description,
+				"applied_at":  time.Now().UTC(),
+				"duration_ms": took.Milliseconds(),
+			},
+			"$unset": bson.M{"last_error": "", "failed_at": ""},
+		},
+		options.Update().SetUpsert(true),
+	); err != nil {
+		return fmt.Errorf("保存迁移记录失败: %w", err)
+	}
+	return nil
+}
+
+// recordMigrationFailure 保存最近一次失败原因，写入失败只记录日志
+func recordMigrationFailure(ctx context.Context, db mongo.Database, scope string, version int, description string, cause error) {
+	if _, err := db.Collection(domain.CollectionSystemMigrations).UpdateOne(ctx,
+		bson.M{"_id": migrationRecordID(scope, version)},
+		bson.M{
+			"$set": bson.M{
+				"scope":       scope,
+				"version":     version,
+				"description": description,
+				"last_error":  cause.Error(),
+				"failed_at":   time.Now().UTC(),
+			},
+		},
+		options.Update().SetUpsert(true),
+	); err != nil {
+		log.Printf("保存迁移失败记录失败: %v", err)
+	}
+}
+
+type migrationStatusProvider struct {
+	db mongo.Database
+}
+
+// NewMigrationStatusProvider 按代码中的迁移定义与迁移记录汇总执行情况
+func NewMigrationStatusProvider(db mongo.Database) domain_system.MigrationStatusProvider {
+	return &migrationStatusProvider{db: db}
+}
+
+func (p *migrationStatusProvider) MigrationStatus(ctx context.Context) (*domain_system.MigrationReport, error) {
+	cursor, err := p.db.Collection(domain.CollectionSystemMigrations).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("读取迁移记录失败: %w", err)
+	}
+	var records []migrationRecord
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, fmt.Errorf("解码迁移记录失败: %w", err)
+	}
+	byID := make(map[string]migrationRecord, len(records))
+	for _, record := range records {
+		byID[record.ID] = record
+	}
+
+	running, err := lock_util.Held(ctx, lock_util.KeyMigrations)
+	if err != nil {
+		return nil, fmt.Errorf("读取迁移锁失败: %w", err)
+	}
+	report := &domain_system.MigrationReport{
+		Running:    running,
+		Migrations: make([]domain_system.MigrationStatus, 0, len(indexMigrations)+len(dataMigrations)),
+	}
+	add := func(scope string, version int, description string, background bool) {
+		status := domain_system.MigrationStatus{
+			Scope:       scope,
+			Version:     version,
+			Description: description,
+			Background:  background,
+		}
+		if record, ok := byID[migrationRecordID(scope, version)]; ok {
+			if !record.AppliedAt.IsZero() {
+				appliedAt := record.AppliedAt
+				status.Applied, status.AppliedAt, status.DurationMs = true, &appliedAt, record.DurationMs
+			}
+			if record.LastError != "" {
+				failedAt := record.FailedAt
+				status.LastError, status.FailedAt = record.LastError, &failedAt
+			}
+		}
+		if !status.Applied {
+			report.Pending++
+		}
+		report.Migrations = append(report.Migrations, status)
+	}
+	for _, m := range indexMigrations {
+		add(domain_system.MigrationScopeIndex, m.version, m.description, false)
+	}
+	for _, m := range dataMigrations {
+		add(domain_system.MigrationScopeData, m.version, m.description, m.background)
+	}
+	return report, nil
+}
@@ -13,7 +13,7 @@ import (
 func main() {
 	app := bootstrap.App()
 	env := app.Env
-	db := app.Mongo.Database(env.DBName)
+	db := bootstrap.NewResilientDatabase(env, app.Mongo)
 	defer app.CloseDBConnection()
 
 	initializer := bootstrap.NewInitializer(env, db)
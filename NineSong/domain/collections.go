@@ -6,6 +6,9 @@ const (
 const (
 	CollectionTask = "system_auth_tasks"
 )
+const (
+	CollectionAPIKey = "system_auth_api_keys"
+)
 
 const (
 	CollectionSystemInfo = "system_info"
@@ -13,6 +16,9 @@ const (
 const (
 	CollectionSystemConfiguration = "system_configuration"
 )
+const (
+	CollectionSystemMigrations = "system_migrations"
+)
 
 const (
 	CollectionFileEntityFileInfo = "file_entity_file_info"
@@ -64,6 +70,9 @@ const (
 const (
 	CollectionFileEntityAudioSceneAlbum = "file_entity_audio_scene_album"
 )
+const (
+	CollectionFileEntityAudioSceneAlbumAttachment = "file_entity_audio_scene_album_attachment"
+)
 const (
 	CollectionFileEntityAudioSceneArtist = "file_entity_audio_scene_artist"
 )
@@ -79,3 +88,58 @@ const (
 const (
 	CollectionFileEntityAudioSceneTempMetadata = "file_entity_audio_scene_temp_metadata"
 )
+const (
+	CollectionFileEntityAudioSceneEqPreset = "file_entity_audio_scene_eq_preset"
+)
+const (
+	CollectionFileEntityAudioSceneUserPreference = "file_entity_audio_scene_user_preference"
+)
+const (
+	CollectionFileEntityAudioScenePlayQueue = "file_entity_audio_scene_play_queue"
+)
+const (
+	CollectionFileEntityAudioSceneMusicBrainzRelease = "file_entity_audio_scene_musicbrainz_release"
+)
+const (
+	CollectionFileEntityAudioScenePlayHistory = "file_entity_audio_scene_play_history"
+)
+const (
+	CollectionFileEntityAudioSceneGenre = "file_entity_audio_scene_genre"
+)
+const (
+	CollectionFileEntityAudioSceneSubsonicForwardQueue = "file_entity_audio_scene_subsonic_forward_queue"
+)
+const (
+	CollectionFileEntityAudioSceneSubsonicMapping = "file_entity_audio_scene_subsonic_mapping"
+)
+const (
+	CollectionFileEntityAudioSceneFederationPeer = "file_entity_audio_scene_federation_peer"
+)
+const (
+	CollectionFileEntityAudioSceneDeletionLog = "file_entity_audio_scene_deletion_log"
+)
+const (
+	CollectionFileEntityAudioSceneMilestone = "file_entity_audio_scene_milestone"
+)
+const (
+	CollectionFileEntityAudioSceneNowPlaying = "file_entity_audio_scene_now_playing"
+)
+const (
+	CollectionFileEntityAudioSceneNumericID = "file_entity_audio_scene_numeric_id"
+)
+const (
+	CollectionFileEntityAudioSceneInternetRadio = "file_entity_audio_scene_internet_radio"
+)
+const (
+	CollectionFileEntityAudioSceneMaintenanceReport = "file_entity_audio_scene_maintenance_report"
+)
+const (
+	CollectionFileEntityAudioScenePodcastChannel = "file_entity_audio_scene_podcast_channel"
+	CollectionFileEntityAudioScenePodcastEpisode = "file_entity_audio_scene_podcast_episode"
+)
+const (
+	CollectionFileEntityAudioSceneAudiobookProgress = "file_entity_audio_scene_audiobook_progress"
+)
+const (
+	CollectionFileEntityAudioSceneBookmark = "file_entity_audio_scene_bookmark"
+)
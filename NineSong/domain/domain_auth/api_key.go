@@ -0,0 +1,54 @@
+package domain_auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// APIKeyPrefix 以此开头的令牌按 API 密钥校验，其余按 JWT 校验
+const APIKeyPrefix = "nsk_"
+
+// APIKeyTouchInterval 最近使用时间的最小刷新间隔，避免每个请求都写库
+const APIKeyTouchInterval = time.Minute
+
+var ErrInvalidAPIKey = errors.New("invalid api key")
+
+// APIKey 供无界面客户端长期使用的访问密钥，库中只保存哈希
+type APIKey struct {
+	ID         primitive.ObjectID `bson:"_id" json:"id"`
+	UserID     primitive.ObjectID `bson:"user_id" json:"-"`
+	Name       string             `bson:"name" json:"name"`
+	Hint       string             `bson:"hint" json:"hint"` // 明文末尾几位，用于在列表中辨认
+	KeyHash    string             `bson:"key_hash" json:"-"`
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+	LastUsedAt time.Time          `bson:"last_used_at" json:"last_used_at"`
+}
+
+type APIKeyRequest struct {
+	Name string `form:"name" json:"name" binding:"required"`
+}
+
+// APIKeyCreateResponse 明文密钥只在创建时返回一次
+type APIKeyCreateResponse struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+type APIKeyRepository interface {
+	Create(c context.Context, key *APIKey) error
+	FetchByUserID(c context.Context, userID string) ([]APIKey, error)
+	GetByHash(c context.Context, hash string) (APIKey, error)
+	Delete(c context.Context, userID, id string) error
+	TouchLastUsed(c context.Context, id primitive.ObjectID, at time.Time) error
+}
+
+type APIKeyUsecase interface {
+	Create(c context.Context, userID, name string) (*APIKeyCreateResponse, error)
+	FetchByUserID(c context.Context, userID string) ([]APIKey, error)
+	Delete(c context.Context, userID, id string) error
+	// Authenticate 返回密钥所属用户ID，密钥不存在时返回 ErrInvalidAPIKey
+	Authenticate(c context.Context, key string) (string, error)
+}
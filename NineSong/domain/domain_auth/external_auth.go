@@ -0,0 +1,51 @@
+package domain_auth
+
+import (
+	"context"
+	"errors"
+)
+
+// 外部认证来源
+const (
+	AuthSourceLocal = "local"
+	AuthSourceLDAP  = "ldap"
+	AuthSourceOIDC  = "oidc"
+)
+
+var (
+	ErrProviderDisabled = errors.New("authentication provider is not configured")
+	ErrInvalidLogin     = errors.New("invalid credentials")
+	// ErrGroupNotAllowed 用户不在允许登录的组中
+	ErrGroupNotAllowed = errors.New("user is not a member of an allowed group")
+	// ErrEmailTaken 外部账号的邮箱已被其他来源的账号使用
+	ErrEmailTaken = errors.New("email is already used by another account")
+)
+
+// ExternalIdentity 外部认证后端返回的身份
+type ExternalIdentity struct {
+	Source  string
+	Subject string // 来源内唯一且不变的标识，LDAP 为 DN，OIDC 为 sub
+	Email   string
+	Name    string
+	Groups  []string
+}
+
+type LDAPLoginRequest struct {
+	Username string `form:"username" json:"username" binding:"required"`
+	Password string `form:"password" json:"password" binding:"required"`
+}
+
+// OIDCLoginState 授权跳转前生成，回调时校验
+type OIDCLoginState struct {
+	State    string
+	Nonce    string
+	Verifier string
+}
+
+type ExternalAuthUsecase interface {
+	LoginLDAP(c context.Context, username, password string) (User, error)
+	OIDCAuthURL(c context.Context) (string, *OIDCLoginState, error)
+	LoginOIDC(c context.Context, code string, state *OIDCLoginState) (User, error)
+	CreateAccessToken(user *User, secret string, expiry int) (accessToken string, err error)
+	CreateRefreshToken(user *User, secret string, expiry int) (refreshToken string, err error)
+}
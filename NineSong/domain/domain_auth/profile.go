@@ -3,8 +3,9 @@ package domain_auth
 import "context"
 
 type Profile struct {
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	Name  string   `json:"name"`
+	Email string   `json:"email"`
+	Roles []string `json:"roles"`
 }
 
 type ProfileUsecase interface {
@@ -5,9 +5,10 @@ import (
 )
 
 type SignupRequest struct {
-	Name     string `form:"name" binding:"required"`
-	Email    string `form:"email" binding:"required,email"`
-	Password string `form:"password" binding:"required"`
+	Name     string   `form:"name" binding:"required"`
+	Email    string   `form:"email" binding:"required,email"`
+	Password string   `form:"password" binding:"required"`
+	Roles    []string `form:"roles"` // 为空时为普通用户
 }
 
 type SignupResponse struct {
@@ -2,16 +2,63 @@ package domain_auth
 
 import (
 	"context"
+	"fmt"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// 用户角色
+const (
+	RoleAdmin = "admin" // 扫描、编辑标签、管理媒体库与删除内容
+	RoleUser  = "user"  // 浏览、播放与个人注解
+)
+
 type User struct {
 	ID       primitive.ObjectID `bson:"_id"`
 	Name     string             `bson:"name"`
 	Email    string             `bson:"email"`
 	Password string             `bson:"password"`
-	Admin    bool               `bson:"admin"`
+	Admin    bool               `bson:"admin"` // 旧版管理员标记，与 Roles 同步写入
+	Roles    []string           `bson:"roles"`
+	// 外部认证来源，本地账号为空
+	Source     string `bson:"source,omitempty"`
+	ExternalID string `bson:"external_id,omitempty"`
+}
+
+// HasRole 旧版数据只有 Admin 标记时同样视为管理员
+func (u User) HasRole(role string) bool {
+	if role == RoleAdmin && u.Admin {
+		return true
+	}
+	for _, r := range u.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeRoles 校验并去重，为空时返回 RoleUser
+func NormalizeRoles(roles []string) ([]string, error) {
+	seen := make(map[string]bool, len(roles))
+	normalized := make([]string, 0, len(roles))
+	for _, role := range roles {
+		if role != RoleAdmin && role != RoleUser {
+			return nil, fmt.Errorf("unknown role %q", role)
+		}
+		if !seen[role] {
+			seen[role] = true
+			normalized = append(normalized, role)
+		}
+	}
+	if len(normalized) == 0 {
+		normalized = append(normalized, RoleUser)
+	}
+	return normalized, nil
+}
+
+type UserRolesRequest struct {
+	Roles []string `json:"roles" binding:"required"`
 }
 
 type UserRepository interface {
@@ -19,4 +66,6 @@ type UserRepository interface {
 	Fetch(c context.Context) ([]User, error)
 	GetByEmail(c context.Context, email string) (User, error)
 	GetByID(c context.Context, id string) (User, error)
+	UpdateRoles(c context.Context, id string, roles []string) error
+	GetByExternalID(c context.Context, source, externalID string) (User, error)
 }
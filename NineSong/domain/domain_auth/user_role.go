@@ -0,0 +1,25 @@
+package domain_auth
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrUserNotFound = errors.New("user not found")
+	// ErrLastAdmin 移除自身管理员角色会导致无人可管理
+	ErrLastAdmin = errors.New("cannot remove your own admin role")
+)
+
+type UserSummary struct {
+	ID    string   `json:"id"`
+	Name  string   `json:"name"`
+	Email string   `json:"email"`
+	Roles []string `json:"roles"`
+}
+
+type UserRoleUsecase interface {
+	FetchUsers(c context.Context) ([]UserSummary, error)
+	// UpdateRoles operatorID 为发起修改的管理员
+	UpdateRoles(c context.Context, operatorID, userID string, roles []string) (*UserSummary, error)
+}
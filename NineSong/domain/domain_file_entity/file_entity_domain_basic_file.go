@@ -4,6 +4,7 @@ import (
 	"context"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 )
@@ -152,15 +153,19 @@ type FileDetector interface {
 
 type FileDetectorImpl struct{}
 
+// AudioExtensions 扫描时识别为音频的扩展名（补充无损格式和现代编码）
+var AudioExtensions = []string{
+	".mp3", ".wav", ".flac", ".aac", ".ogg", ".m4a", ".wma", ".ape",
+	".opus", ".dsd", ".dff", ".aiff", ".m4b",
+	".cue",
+}
+
 func (fd *FileDetectorImpl) DetectMediaType(filePath string) (FileTypeNo, error) {
 	ext := strings.ToLower(filepath.Ext(filePath))
-	switch ext {
-	// 音频类型（补充无损格式和现代编码）
-	case
-		".mp3", ".wav", ".flac", ".aac", ".ogg", ".m4a", ".wma", ".ape",
-		".opus", ".dsd", ".dff", ".aiff",
-		".cue":
+	if slices.Contains(AudioExtensions, ext) {
 		return Audio, nil
+	}
+	switch ext {
 
 	// 视频类型（补充主流封装格式）
 	case ".mp4", ".avi", ".mkv", ".mov", ".flv", ".webm", ".wmv", ".ts":
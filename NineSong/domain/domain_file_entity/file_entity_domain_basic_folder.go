@@ -39,12 +39,38 @@ type LibraryFolderMetadata struct {
 	UpdatedAt   time.Time          `bson:"updated_at"`
 	LastScanned time.Time          `bson:"last_scanned"`
 	FileCount   int                `bson:"file_count"`
+	// AllowedUsers 与 AllowedRoles 均为空时所有用户可见，否则仅列出的用户与具备任一角色的用户可见；管理员始终可见
+	AllowedUsers []string `bson:"allowed_users,omitempty"`
+	AllowedRoles []string `bson:"allowed_roles,omitempty"`
+	// Audiobook 标记为有声书库，其中的专辑按书展示，曲目按阅读顺序排列并记录收听进度
+	Audiobook bool `bson:"audiobook,omitempty"`
+}
+
+// VisibleTo 判断普通用户能否访问该媒体库
+func (f *LibraryFolderMetadata) VisibleTo(userID string, roles []string) bool {
+	if len(f.AllowedUsers) == 0 && len(f.AllowedRoles) == 0 {
+		return true
+	}
+	for _, id := range f.AllowedUsers {
+		if id == userID {
+			return true
+		}
+	}
+	for _, allowed := range f.AllowedRoles {
+		for _, role := range roles {
+			if allowed == role {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 var (
 	ErrLibraryNotFound  = errors.New("media library not found")
 	ErrLibraryDuplicate = errors.New("duplicate media library")
 	ErrLibraryInUse     = errors.New("library is currently in use")
+	ErrLibraryNotMusic  = errors.New("only music libraries can hold audiobooks")
 )
 
 type FolderRepository interface {
@@ -64,4 +90,6 @@ type FolderRepository interface {
 	GetByID(ctx context.Context, id primitive.ObjectID) (*LibraryFolderMetadata, error)
 	DetectingDuplicates(ctx context.Context, folderPath string, folderType int) (*LibraryFolderMetadata, error)
 	UpdateStatus(ctx context.Context, id primitive.ObjectID, status LibraryStatus) error
+	UpdateAccess(ctx context.Context, id primitive.ObjectID, users, roles []string) error
+	UpdateAudiobook(ctx context.Context, id primitive.ObjectID, audiobook bool) error
 }
@@ -0,0 +1,54 @@
+package domain_file_entity
+
+import "errors"
+
+// BeetsMaxItems 单次同步的曲目上限，内部按 IngestMaxTracks 分块写入
+const BeetsMaxItems = 10000
+
+var (
+	ErrBeetsInvalidExport = errors.New("invalid beets export")
+	ErrBeetsTooManyItems  = errors.New("too many items in one beets export")
+)
+
+// BeetsItem `beet export` 输出的单曲字段，键名与 beets 数据库字段一致；
+// 未列出的字段忽略，path 为 beets 所在机器上的路径，写入前按 BEETS_PATH_MAP 改写
+type BeetsItem struct {
+	Path          string   `json:"path"`
+	Title         string   `json:"title"`
+	Artist        string   `json:"artist"`
+	AlbumArtist   string   `json:"albumartist"`
+	Album         string   `json:"album"`
+	Genre         string   `json:"genre"`
+	Genres        []string `json:"genres"` // beets 2.x 多流派字段，存在时优先于 genre
+	Composer      string   `json:"composer"`
+	Comments      string   `json:"comments"`
+	Lyrics        string   `json:"lyrics"`
+	Year          int      `json:"year"`
+	OriginalYear  int      `json:"original_year"`
+	OriginalMonth int      `json:"original_month"`
+	OriginalDay   int      `json:"original_day"`
+	Track         int      `json:"track"`
+	TrackTotal    int      `json:"tracktotal"`
+	Disc          int      `json:"disc"`
+	DiscTotal     int      `json:"disctotal"`
+	ISRC          string   `json:"isrc"`
+	Barcode       string   `json:"barcode"`
+	Label         string   `json:"label"`
+	Length        float64  `json:"length"`     // 秒
+	BitRate       int      `json:"bitrate"`    // bps
+	SampleRate    int      `json:"samplerate"` // Hz
+	Channels      int      `json:"channels"`
+	Comp          bool     `json:"comp"`
+	AlbumType     string   `json:"albumtype"`
+
+	MBTrackID        string `json:"mb_trackid"`
+	MBReleaseTrackID string `json:"mb_releasetrackid"`
+	MBAlbumID        string `json:"mb_albumid"`
+	MBArtistID       string `json:"mb_artistid"`
+	MBAlbumArtistID  string `json:"mb_albumartistid"`
+
+	RGTrackGain *float64 `json:"rg_track_gain"`
+	RGTrackPeak *float64 `json:"rg_track_peak"`
+	RGAlbumGain *float64 `json:"rg_album_gain"`
+	RGAlbumPeak *float64 `json:"rg_album_peak"`
+}
@@ -0,0 +1,81 @@
+package domain_file_entity
+
+import "errors"
+
+// IngestMaxTracks 单次请求的曲目上限，整库导入由调用方分块提交
+const IngestMaxTracks = 500
+
+// 单曲导入结果
+const (
+	IngestStatusCreated   = "created"
+	IngestStatusUpdated   = "updated"
+	IngestStatusUnchanged = "unchanged"
+	IngestStatusFailed    = "failed"
+)
+
+var (
+	ErrIngestEmpty         = errors.New("no tracks to ingest")
+	ErrIngestTooManyTracks = errors.New("too many tracks in one ingest request")
+)
+
+// IngestTrack 外部标签工具（beets、MusicBee 导出等）提交的单曲元数据；
+// Path 为服务器上已登记媒体库内的文件路径，导入时不再读取文件标签
+type IngestTrack struct {
+	Path         string  `json:"path"`
+	Title        string  `json:"title"`
+	Artist       string  `json:"artist"`
+	AlbumArtist  string  `json:"album_artist"`
+	Album        string  `json:"album"`
+	Genre        string  `json:"genre"`
+	Composer     string  `json:"composer"`
+	Comment      string  `json:"comment"`
+	Lyrics       string  `json:"lyrics"`
+	Year         int     `json:"year"`
+	OriginalDate string  `json:"original_date"` // YYYY 或 YYYY-MM-DD
+	TrackNumber  int     `json:"track_number"`
+	TrackTotal   int     `json:"track_total"`
+	DiscNumber   int     `json:"disc_number"`
+	DiscTotal    int     `json:"disc_total"`
+	ISRC         string  `json:"isrc"`
+	Barcode      string  `json:"barcode"`
+	Duration     float64 `json:"duration"`    // 秒
+	BitRate      int     `json:"bit_rate"`    // kbps
+	SampleRate   int     `json:"sample_rate"` // Hz
+	Channels     int     `json:"channels"`
+	Compilation  bool    `json:"compilation"`
+
+	// MusicBrainz 标识，提供时覆盖单曲、专辑与艺术家上的对应字段
+	MBZTrackID        string `json:"mbz_track_id"`
+	MBZReleaseTrackID string `json:"mbz_release_track_id"`
+	MBZAlbumID        string `json:"mbz_album_id"`
+	MBZArtistID       string `json:"mbz_artist_id"`
+	MBZAlbumArtistID  string `json:"mbz_album_artist_id"`
+	MBZAlbumType      string `json:"mbz_album_type"`
+
+	// ReplayGain 未提供时保持为空，由响度分析补全
+	RGTrackGain *float64 `json:"rg_track_gain"`
+	RGTrackPeak *float64 `json:"rg_track_peak"`
+	RGAlbumGain *float64 `json:"rg_album_gain"`
+	RGAlbumPeak *float64 `json:"rg_album_peak"`
+
+	// Tags 其他原始标签，键名与文件标签一致（如 LABEL），自定义标签需在 CUSTOM_TAGS 中登记才会保存
+	Tags map[string]string `json:"tags"`
+}
+
+// IngestItemResult 单曲的导入结果，Changes 为更新前后不同的主要字段
+type IngestItemResult struct {
+	Path        string   `json:"path"`
+	Status      string   `json:"status"`
+	MediaFileID string   `json:"media_file_id,omitempty"`
+	Changes     []string `json:"changes,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+// IngestReport 一次导入请求的对账报告，Results 与提交顺序一致
+type IngestReport struct {
+	Results   []IngestItemResult `json:"results"`
+	Created   int                `json:"created"`
+	Updated   int                `json:"updated"`
+	Unchanged int                `json:"unchanged"`
+	Failed    int                `json:"failed"`
+}
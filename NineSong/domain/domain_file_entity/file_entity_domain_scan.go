@@ -0,0 +1,36 @@
+package domain_file_entity
+
+import "time"
+
+// ScanTaskStatus 单个扫描任务的实时状态
+type ScanTaskStatus struct {
+	ID             string    `json:"id"`
+	Status         string    `json:"status"`
+	Incremental    bool      `json:"incremental"` // 大小与修改时间未变的文件不重新读取标签
+	StartedAt      time.Time `json:"started_at"`
+	TotalFiles     int       `json:"total_files"`
+	WalkedFiles    int       `json:"walked_files"`
+	ProcessedFiles int       `json:"processed_files"`
+	SkippedFiles   int       `json:"skipped_files"`
+}
+
+// ScanStatus 扫描子系统状态，Tasks 为正在运行的任务
+type ScanStatus struct {
+	Scanning  bool             `json:"scanning"`
+	Progress  float32          `json:"progress"`
+	StartTime time.Time        `json:"start_time"`
+	Tasks     []ScanTaskStatus `json:"tasks"`
+}
+
+// ScanEventInterval 扫描进行中推送进度事件的间隔
+const ScanEventInterval = 2 * time.Second
+
+// MediaAddedEvent 扫描新入库单曲后发布到事件总线的载荷，已存在的单曲重新扫描不发布
+type MediaAddedEvent struct {
+	MediaFileID string `json:"media_file_id"`
+	Title       string `json:"title"`
+	Artist      string `json:"artist"`
+	Album       string `json:"album"`
+	ArtistID    string `json:"artist_id"`
+	AlbumID     string `json:"album_id"`
+}
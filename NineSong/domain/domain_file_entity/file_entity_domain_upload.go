@@ -0,0 +1,47 @@
+package domain_file_entity
+
+import (
+	"errors"
+	"io"
+)
+
+const (
+	UploadTempMetadataType = "upload"   // 上传收件箱在临时元数据中的类型
+	UploadMaxFileSize      = 1 << 30    // 单个文件上限 1GiB
+	UploadUserQuota        = 20 << 30   // 每个用户收件箱上限 20GiB
+	UploadMaxFiles         = 100        // 单次请求文件数上限
+	UploadPartSuffix       = ".partial" // 写入中的临时后缀，扫描前重命名
+)
+
+var (
+	ErrUploadQuotaExceeded      = errors.New("upload quota exceeded")
+	ErrUploadTooLarge           = errors.New("upload file too large")
+	ErrUploadUnsupported        = errors.New("unsupported upload file type")
+	ErrUploadInboxNotConfigured = errors.New("upload inbox is not configured")
+)
+
+// UploadFile 待写入收件箱的单个文件
+type UploadFile struct {
+	Name   string
+	Size   int64
+	Reader io.Reader
+}
+
+// UploadedFile 单个文件的上传与扫描结果
+type UploadedFile struct {
+	Name        string `json:"name"`
+	Path        string `json:"path,omitempty"`
+	Size        int64  `json:"size"`
+	MediaFileID string `json:"media_file_id,omitempty"`
+	Error       string `json:"error,omitempty"`
+	ErrorCode   string `json:"error_code,omitempty"` // 如 QUOTA_EXCEEDED，文案见 /api/error-messages
+}
+
+// UploadResult 一次上传请求的汇总
+type UploadResult struct {
+	Files      []UploadedFile `json:"files"`
+	Succeeded  int            `json:"succeeded"`
+	Failed     int            `json:"failed"`
+	QuotaUsed  int64          `json:"quota_used"`
+	QuotaLimit int64          `json:"quota_limit"`
+}
@@ -0,0 +1,12 @@
+package scene_audio_acoustid_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_acoustid/scene_audio_acoustid_models"
+)
+
+type AcoustIDClient interface {
+	// Lookup 按 Chromaprint 声纹与时长（秒）查询录音，按匹配度降序
+	Lookup(ctx context.Context, fingerprint string, duration int) ([]scene_audio_acoustid_models.AcoustIDMatch, error)
+}
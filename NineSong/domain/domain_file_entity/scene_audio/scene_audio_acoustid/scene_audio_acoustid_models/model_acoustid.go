@@ -0,0 +1,10 @@
+package scene_audio_acoustid_models
+
+// AcoustIDMatch 声纹查询结果中的一条录音，Score 为 AcoustID 给出的匹配度（0-1）
+type AcoustIDMatch struct {
+	AcoustID    string
+	Score       float64
+	RecordingID string // MusicBrainz 录音ID（对应 MUSICBRAINZ_TRACKID）
+	Title       string
+	Artist      string
+}
@@ -38,6 +38,8 @@ type AlbumRepository interface {
 	GuestAlbumCountByArtist(ctx context.Context, artistID string) (int64, error)
 
 	InspectAlbumMediaCountByAlbum(ctx context.Context, albumID string, operand int) (bool, error)
+	// RebuildStatistics 按专辑现有单曲重新计算曲目数、大小、时长与年份范围，返回单曲数
+	RebuildStatistics(ctx context.Context, albumID primitive.ObjectID) (int, error)
 }
 
 // 查询参数结构
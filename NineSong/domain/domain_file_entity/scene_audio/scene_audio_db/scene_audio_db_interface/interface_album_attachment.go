@@ -0,0 +1,13 @@
+package scene_audio_db_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+)
+
+// AlbumAttachmentRepository 扫描时同步专辑目录中的附件
+type AlbumAttachmentRepository interface {
+	// SyncFolder 按路径写入 dir 下发现的附件，并删除该专辑在 dir 下已不存在的目录附件；上传的附件不受影响
+	SyncFolder(ctx context.Context, albumID, dir string, found []scene_audio_db_models.AlbumAttachmentMetadata) error
+}
@@ -29,6 +29,9 @@ type ArtistRepository interface {
 
 	GetByMbzID(ctx context.Context, mbzID string) (*scene_audio_db_models.ArtistMetadata, error)
 
+	// GetAliasMap 返回 小写别名 -> 艺术家名称 的映射，供扫描时归一艺术家
+	GetAliasMap(ctx context.Context) (map[string]string, error)
+
 	InspectAlbumCountByArtist(ctx context.Context, artistID string, operand int) (int, error)
 	InspectGuestAlbumCountByArtist(ctx context.Context, artistID string, operand int) (int, error)
 	InspectMediaCountByArtist(ctx context.Context, artistID string, operand int) (int, error)
@@ -0,0 +1,16 @@
+package scene_audio_db_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AvailabilityRepository 媒体源可用状态的读取与回写
+type AvailabilityRepository interface {
+	GetMediaSources(ctx context.Context, libraryPath string) ([]scene_audio_db_models.MediaSource, error)
+	SetMediaAvailability(ctx context.Context, ids []primitive.ObjectID, availability string) (int64, error)
+	// RefreshAlbumAvailability 按曲目状态重新计算专辑状态，返回变更数量
+	RefreshAlbumAvailability(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,32 @@
+package scene_audio_db_interface
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// EnrichmentRepository MusicBrainz 元数据匹配的读取与回写
+type EnrichmentRepository interface {
+	// GetPendingAlbums 没有 mbz_album_id 且在 checkedBefore 之后未检查过的专辑
+	GetPendingAlbums(ctx context.Context, checkedBefore time.Time, limit int) ([]scene_audio_db_models.EnrichmentAlbum, error)
+	GetPendingArtists(ctx context.Context, checkedBefore time.Time, limit int) ([]scene_audio_db_models.EnrichmentArtist, error)
+
+	// GetItemType 判断 ID 属于专辑、艺术家还是曲目，均不存在时返回 ErrEnrichmentItemNotFound
+	GetItemType(ctx context.Context, id primitive.ObjectID) (string, error)
+	GetAlbum(ctx context.Context, id primitive.ObjectID) (*scene_audio_db_models.EnrichmentAlbum, error)
+	GetArtist(ctx context.Context, id primitive.ObjectID) (*scene_audio_db_models.EnrichmentArtist, error)
+	// GetMediaAlbumID 曲目所属专辑
+	GetMediaAlbumID(ctx context.Context, id primitive.ObjectID) (primitive.ObjectID, error)
+	GetAlbumTracks(ctx context.Context, albumID primitive.ObjectID) ([]scene_audio_db_models.EnrichmentTrack, error)
+
+	// LinkAlbum 写入专辑的发行版ID，artistID 非空时同时写入专辑艺术家ID，edition 只写入非空字段
+	LinkAlbum(ctx context.Context, id primitive.ObjectID, releaseID, artistID string, edition scene_audio_db_models.EnrichmentAlbumEdition) error
+	LinkArtist(ctx context.Context, id primitive.ObjectID, artistID string) error
+	// LinkTracks 写入曲目的录音ID、发行版曲目ID与发行版ID，返回更新数量
+	LinkTracks(ctx context.Context, releaseID string, matches []scene_audio_db_models.EnrichmentTrackMatch) (int64, error)
+	// MarkChecked 记录未匹配成功的检查时间
+	MarkChecked(ctx context.Context, itemType string, id primitive.ObjectID) error
+}
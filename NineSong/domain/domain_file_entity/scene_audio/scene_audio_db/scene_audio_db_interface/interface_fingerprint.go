@@ -0,0 +1,20 @@
+package scene_audio_db_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FingerprintRepository 曲目声纹识别结果的读写与人工确认
+type FingerprintRepository interface {
+	// IsFingerprinted 曲目是否已有识别结果，已识别的曲目重新扫描时不再计算
+	IsFingerprinted(ctx context.Context, mediaID primitive.ObjectID) (bool, error)
+	// SaveResult 保存识别结果，状态为 matched 且曲目缺少 mbz_track_id 时一并写入录音ID
+	SaveResult(ctx context.Context, mediaID primitive.ObjectID, result *scene_audio_db_models.FingerprintResult) error
+	// GetReviewItems 按匹配度降序返回待确认的识别结果及总数
+	GetReviewItems(ctx context.Context, skip, limit int64) ([]scene_audio_db_models.FingerprintReviewItem, int64, error)
+	// ResolveReview 确认时写入 mbz_track_id，否决时只更新状态；条目不在待确认状态时返回 ErrFingerprintReviewNotFound
+	ResolveReview(ctx context.Context, mediaID primitive.ObjectID, accept bool) error
+}
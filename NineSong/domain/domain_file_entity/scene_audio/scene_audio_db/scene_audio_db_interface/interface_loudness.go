@@ -0,0 +1,23 @@
+package scene_audio_db_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// LoudnessRepository 响度分析的读取与回写，只补全标签中缺失的 ReplayGain 值
+type LoudnessRepository interface {
+	// GetPendingAlbumIDs 含未分析曲目的专辑ID，无专辑的曲目以空字符串表示
+	GetPendingAlbumIDs(ctx context.Context, limit int) ([]string, error)
+	// GetAlbumTracks 专辑的全部曲目；albumID 为空时返回未分析的无专辑曲目，最多 limit 条
+	GetAlbumTracks(ctx context.Context, albumID string, limit int) ([]scene_audio_db_models.LoudnessTrack, error)
+
+	// SaveTrackLoudness 保存分析结果，writeGain 为 true 时同时写入 rg_track_gain 与 rg_track_peak
+	SaveTrackLoudness(ctx context.Context, id primitive.ObjectID, loudness, truePeak float64, writeGain bool) error
+	// MarkFailed 记录分析失败，避免每轮重复分析
+	MarkFailed(ctx context.Context, id primitive.ObjectID, reason string) error
+	// SaveAlbumGain 写入专辑内没有专辑增益标签的曲目，返回更新数量
+	SaveAlbumGain(ctx context.Context, albumID string, gain, peak float64) (int64, error)
+}
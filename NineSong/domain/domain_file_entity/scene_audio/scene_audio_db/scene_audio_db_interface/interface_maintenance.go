@@ -0,0 +1,41 @@
+package scene_audio_db_interface
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MaintenanceRepository 孤立数据的查找与删除，删除的曲目、专辑与艺术家写入删除日志
+type MaintenanceRepository interface {
+	GetMediaSources(ctx context.Context, libraryPath string) ([]scene_audio_db_models.MediaSource, error)
+	FindEmptyAlbums(ctx context.Context) ([]primitive.ObjectID, error)
+	FindEmptyArtists(ctx context.Context) ([]primitive.ObjectID, error)
+	FindDanglingAnnotations(ctx context.Context) ([]primitive.ObjectID, error)
+
+	DeleteMediaFiles(ctx context.Context, ids []primitive.ObjectID) (int64, error)
+	DeleteAlbums(ctx context.Context, ids []primitive.ObjectID) (int64, error)
+	DeleteArtists(ctx context.Context, ids []primitive.ObjectID) (int64, error)
+	DeleteAnnotations(ctx context.Context, ids []primitive.ObjectID) (int64, error)
+
+	SaveReport(ctx context.Context, report *scene_audio_db_models.MaintenanceReport) error
+	// GetReport 不存在或已过期时返回 domain.ErrNotFound
+	GetReport(ctx context.Context, id primitive.ObjectID) (*scene_audio_db_models.MaintenanceReport, error)
+	// ListReports 按时间倒序返回报告摘要，不含变更集
+	ListReports(ctx context.Context, limit int) ([]scene_audio_db_models.MaintenanceReport, error)
+	// MarkApplied 仅在报告尚未应用时写入应用时间，已应用返回 false
+	MarkApplied(ctx context.Context, id primitive.ObjectID, appliedAt time.Time) (bool, error)
+}
+
+// DeletionProtectionRepository 删除保护规则所需的查询，itemType 为 "media" 或 "media_cue"
+type DeletionProtectionRepository interface {
+	// FindStarred 返回 ids 中已收藏的条目，itemType 为注解中的条目类型
+	FindStarred(ctx context.Context, itemType string, ids []primitive.ObjectID) ([]primitive.ObjectID, error)
+	GetStarredPaths(ctx context.Context, itemType string) ([]string, error)
+	// GetPathsOutside 返回 library_path 不在 libraryPaths 中的条目路径，libraryPaths 为空时返回全部
+	GetPathsOutside(ctx context.Context, itemType string, libraryPaths []string) ([]string, error)
+	// CountInvalid 统计路径不在 validPaths 中的条目数
+	CountInvalid(ctx context.Context, itemType string, validPaths []string) (int64, error)
+}
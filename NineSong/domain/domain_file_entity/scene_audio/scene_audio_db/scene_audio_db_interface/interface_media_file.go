@@ -27,6 +27,10 @@ type MediaFileRepository interface {
 	GetByID(ctx context.Context, id primitive.ObjectID) (*scene_audio_db_models.MediaFileMetadata, error)
 	GetByPath(ctx context.Context, path string) (*scene_audio_db_models.MediaFileMetadata, error)
 	GetByFolder(ctx context.Context, folderPath string) ([]string, error)
+	GetByReviewStatus(ctx context.Context, status string, skip, limit int64) ([]*scene_audio_db_models.MediaFileMetadata, int64, error)
+
+	// SetReviewStatus 批量设置审核状态，status 为空时清除状态（审核通过）
+	SetReviewStatus(ctx context.Context, ids []primitive.ObjectID, status string) (int64, error)
 
 	MediaCountByArtist(ctx context.Context, artistID string) (int64, error)
 	GuestMediaCountByArtist(ctx context.Context, artistID string) (int64, error)
@@ -0,0 +1,15 @@
+package scene_audio_db_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+)
+
+// MediaLyricsRepository 扫描时写入的歌词，每首曲目一条
+type MediaLyricsRepository interface {
+	// Upsert 写入本地歌词，手动固定的歌词保持不变
+	Upsert(ctx context.Context, lyrics *scene_audio_db_models.MediaLyricsMetadata) error
+	// DeleteLocalByMediaID 删除扫描得到的本地歌词，在线缓存与手动固定的歌词保留
+	DeleteLocalByMediaID(ctx context.Context, mediaID string) error
+}
@@ -25,6 +25,8 @@ type AlbumMetadata struct {
 	Size              int      `bson:"size"`                // 专辑文件总大小（字节）
 	MinYear           int      `bson:"min_year"`            // 专辑中歌曲的最早发行年份
 	MaxYear           int      `bson:"max_year"`            // 专辑中歌曲的最晚发行年份
+	OriginalDate      string   `bson:"original_date"`       // 原始发行日期（TDOR/ORIGINALDATE 标签），再版专辑与 min_year/max_year 不同
+	OriginalYear      int      `bson:"original_year"`       // 原始发行年份，无原始日期标签时取发行年份
 	Compilation       bool     `bson:"compilation"`         // 是否为合辑（多艺术家作品合集）
 
 	// 关系ID索引
@@ -49,13 +51,21 @@ type AlbumMetadata struct {
 	LargeImageURL  string `bson:"large_image_url"`  // 大尺寸封面图的 URL 地址
 
 	// MusicBrainz元数据 (github.com/michiwend/gomusicbrainz)
-	MBZAlbumID       string `bson:"mbz_album_id"`        // MusicBrainz 专辑唯一标识符
-	MBZAlbumArtistID string `bson:"mbz_album_artist_id"` // MusicBrainz 专辑艺术家唯一标识符
-	MBZAlbumType     string `bson:"mbz_album_type"`      // 专辑类型（如专辑、单曲等）
-	MBZAlbumComment  string `bson:"mbz_album_comment"`   // MusicBrainz 专辑评论信息
-	Paths            string `bson:"paths"`               // 抽象专辑所处文件系统目录路径
-	Description      string `bson:"description"`         // 专辑描述信息
-	CatalogNum       string `bson:"catalog_num"`         // 唱片目录编号（发行方的内部编号）
+	MBZAlbumID       string `bson:"mbz_album_id"`                // MusicBrainz 专辑唯一标识符
+	MBZAlbumArtistID string `bson:"mbz_album_artist_id"`         // MusicBrainz 专辑艺术家唯一标识符
+	MBZAlbumType     string `bson:"mbz_album_type"`              // 专辑类型（如专辑、单曲等）
+	MBZAlbumComment  string `bson:"mbz_album_comment,omitempty"` // 发行版附注（如“Japanese edition”），为空时不覆盖补全写入的值
+	Paths            string `bson:"paths"`                       // 抽象专辑所处文件系统目录路径
+	Description      string `bson:"description"`                 // 专辑描述信息
+	CatalogNum       string `bson:"catalog_num,omitempty"`       // 唱片目录编号（发行方的内部编号），为空时不覆盖补全写入的值
+	Barcode          string `bson:"barcode"`                     // 发行版条码（UPC/EAN，统一为 13 位 EAN）
+	ReleaseCountry   string `bson:"release_country,omitempty"`   // 发行国家（ISO 3166-1 代码，如 JP、XE），为空时不覆盖补全写入的值
+
+	// 版本分组：同一艺术家同名专辑的各发行版共享，取自不区分发行版时的专辑ID
+	VersionGroupID string `bson:"version_group_id"`
+
+	// 扩展存储，取自专辑内曲目的自定义标签；为空时不覆盖已有值
+	CustomTags map[string]string `bson:"custom_tags,omitempty"`
 
 	// 外部信息
 	ExternalURL           string    `bson:"external_url"`             // 外部链接 URL
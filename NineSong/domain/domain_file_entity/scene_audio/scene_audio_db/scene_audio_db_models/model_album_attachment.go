@@ -0,0 +1,64 @@
+package scene_audio_db_models
+
+import (
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 专辑附件来源
+const (
+	AttachmentSourceFolder = "folder" // 扫描时在专辑目录中发现，文件删除后随下次扫描移除
+	AttachmentSourceUpload = "upload" // 管理员上传，保存在封面缓存目录下
+)
+
+// AttachmentMaxSize 单个附件上限
+const AttachmentMaxSize = 100 << 20
+
+var (
+	ErrAttachmentUnsupported = errors.New("unsupported attachment type")
+	ErrAttachmentTooLarge    = errors.New("attachment too large")
+)
+
+// attachmentTypes 允许的扩展名、响应的 Content-Type 及内容嗅探结果须具备的前缀
+var attachmentTypes = map[string]struct {
+	contentType string
+	sniffPrefix string
+}{
+	".pdf": {"application/pdf", "application/pdf"},
+	".txt": {"text/plain; charset=utf-8", "text/plain"},
+	".nfo": {"text/plain; charset=utf-8", "text/plain"},
+}
+
+// IsAttachmentExt 仅按扩展名判断，扫描时用于筛选候选文件
+func IsAttachmentExt(name string) bool {
+	_, ok := attachmentTypes[strings.ToLower(filepath.Ext(name))]
+	return ok
+}
+
+// DetectAttachmentType 扩展名与文件头嗅探结果同时匹配时返回 Content-Type，避免改名的可执行文件或网页被当作附件下发
+func DetectAttachmentType(name string, head []byte) (string, error) {
+	t, ok := attachmentTypes[strings.ToLower(filepath.Ext(name))]
+	if !ok || !strings.HasPrefix(http.DetectContentType(head), t.sniffPrefix) {
+		return "", ErrAttachmentUnsupported
+	}
+	return t.contentType, nil
+}
+
+// AlbumAttachmentMetadata 专辑附带的电子内页、说明文本等
+type AlbumAttachmentMetadata struct {
+	ID          primitive.ObjectID `bson:"_id" json:"id"`
+	AlbumID     string             `bson:"album_id" json:"album_id"`
+	Name        string             `bson:"name" json:"name"`
+	Path        string             `bson:"path" json:"-"`
+	ContentType string             `bson:"content_type" json:"content_type"`
+	Size        int64              `bson:"size" json:"size"`
+	Source      string             `bson:"source" json:"source"` // 见 AttachmentSourceFolder
+	UploadedBy  string             `bson:"uploaded_by,omitempty" json:"uploaded_by,omitempty"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+}
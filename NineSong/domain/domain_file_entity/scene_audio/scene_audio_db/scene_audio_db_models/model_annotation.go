@@ -12,11 +12,13 @@ type AnnotationMetadata struct {
 	ItemType          string             `bson:"item_type"`  // 媒体项目类型（如音乐、视频、图片等）
 	PlayCount         int                `bson:"play_count"` // 播放次数，记录该媒体项目被播放的次数
 	PlayCompleteCount int                `bson:"play_complete_count"`
-	PlayDate          time.Time          `bson:"play_date"`  // 播放日期，最近一次播放此媒体项目的日期和时间
-	Rating            int                `bson:"rating"`     // 评分，用户对此媒体项目的评分（如1-5分）
-	Starred           bool               `bson:"starred"`    // 是否收藏，标识该媒体项目是否被用户收藏
-	StarredAt         time.Time          `bson:"starred_at"` // 收藏时间，媒体项目被收藏的日期和时间
-	UpdatedAt         time.Time          `bson:"updated_at"` // 词云最后更新时间
+	PlayDate          time.Time          `bson:"play_date"`       // 播放日期，最近一次播放此媒体项目的日期和时间
+	FirstPlayDate     time.Time          `bson:"first_play_date"` // 首次播放时间，首次记录播放时写入
+	Rating            int                `bson:"rating"`          // 评分，用户对此媒体项目的评分（如1-5分）
+	RatedAt           time.Time          `bson:"rated_at"`        // 评分时间，由服务端在写入评分时记录
+	Starred           bool               `bson:"starred"`         // 是否收藏，标识该媒体项目是否被用户收藏
+	StarredAt         time.Time          `bson:"starred_at"`      // 收藏时间，媒体项目被收藏的日期和时间
+	UpdatedAt         time.Time          `bson:"updated_at"`      // 词云最后更新时间
 
 	WordCloudTags []TagSource   `bson:"word_cloud_tags"` // 标签及来源
 	WeightedTags  []WeightedTag `bson:"weighted_tags"`   // 带权重的标签（用于推荐）
@@ -26,6 +26,9 @@ type ArtistMetadata struct {
 	// 关系ID索引
 	AllArtistIDs []ArtistIDPair `bson:"all_artist_ids"` // 所有参与艺术家的唯一标识符列表
 
+	// 别名（扫描时 $set 整个文档，omitempty 避免覆盖已维护的别名）
+	Aliases []ArtistAlias `bson:"aliases,omitempty"`
+
 	// 索引排序信息
 	OrderArtistName string `bson:"order_artist_name"`
 	SortArtistName  string `bson:"sort_artist_name"`
@@ -48,3 +51,9 @@ type ArtistMetadata struct {
 	ExternalURL           string    `bson:"external_url"`             // 外部链接 URL
 	ExternalInfoUpdatedAt time.Time `bson:"external_info_updated_at"` // 外部信息最后更新时间
 }
+
+// ArtistAlias 艺术家别名，Source 为 manual / musicbrainz / merge
+type ArtistAlias struct {
+	Name   string `bson:"name"`
+	Source string `bson:"source"`
+}
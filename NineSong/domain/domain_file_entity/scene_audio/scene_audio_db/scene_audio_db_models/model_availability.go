@@ -0,0 +1,23 @@
+package scene_audio_db_models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// 媒体源可用状态，由后台检查存储层后写入条目文档
+const (
+	AvailabilityOnline  = "online"  // 源文件可读
+	AvailabilityMissing = "missing" // 源文件或所在媒体库不可访问（如外置硬盘未挂载）
+	AvailabilityRemote  = "remote"  // 来自联邦实例，由对方提供
+	AvailabilityCached  = "cached"  // 源文件不可访问，但流媒体缓存中有转码副本可播放
+)
+
+// CachedStreamFileName 流媒体临时目录中转码副本的文件名
+func CachedStreamFileName(mediaFileID string) string {
+	return "transcoded_" + mediaFileID + ".aac"
+}
+
+// MediaSource 可用性检查所需的最少字段
+type MediaSource struct {
+	ID           primitive.ObjectID `bson:"_id"`
+	Path         string             `bson:"path"`
+	Availability string             `bson:"availability"`
+}
@@ -0,0 +1,65 @@
+package scene_audio_db_models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 应用配置中的删除保护规则，未配置时使用 DefaultDeletionProtection
+const (
+	// DeletionProtectStarredKey 值为 "false" 时允许清理已收藏的曲目、专辑与艺术家
+	DeletionProtectStarredKey = "deletion_protect_starred"
+	// DeletionLibraryOnlyKey 值为 "false" 时允许清理路径不在可访问媒体库内的曲目
+	DeletionLibraryOnlyKey = "deletion_library_only"
+	// DeletionConfirmThresholdKey 单步清理超过该数量时需要确认令牌，"0" 表示不限制
+	DeletionConfirmThresholdKey = "deletion_confirm_threshold"
+)
+
+// DeletionProtection 扫描清理与一致性修复共同遵守的删除保护规则
+type DeletionProtection struct {
+	ProtectStarred   bool `json:"protect_starred"`
+	LibraryOnly      bool `json:"library_only"`
+	ConfirmThreshold int  `json:"confirm_threshold"`
+}
+
+func DefaultDeletionProtection() DeletionProtection {
+	return DeletionProtection{ProtectStarred: true, LibraryOnly: true, ConfirmThreshold: 100}
+}
+
+// ParseDeletionProtection 按配置键覆盖默认规则，无法解析的值保持默认
+func ParseDeletionProtection(configs map[string]string) DeletionProtection {
+	rules := DefaultDeletionProtection()
+	if value, ok := configs[DeletionProtectStarredKey]; ok {
+		rules.ProtectStarred = !strings.EqualFold(strings.TrimSpace(value), "false")
+	}
+	if value, ok := configs[DeletionLibraryOnlyKey]; ok {
+		rules.LibraryOnly = !strings.EqualFold(strings.TrimSpace(value), "false")
+	}
+	if value, ok := configs[DeletionConfirmThresholdKey]; ok {
+		if n, err := strconv.Atoi(strings.TrimSpace(value)); err == nil && n >= 0 {
+			rules.ConfirmThreshold = n
+		}
+	}
+	return rules
+}
+
+// RequiresConfirmation 删除数量超过阈值时需要确认
+func (p DeletionProtection) RequiresConfirmation(count int) bool {
+	return p.ConfirmThreshold > 0 && count > p.ConfirmThreshold
+}
+
+// DeletionConfirmToken 由待删除条目集合计算，集合变化后旧令牌自动失效
+func DeletionConfirmToken(step string, ids []primitive.ObjectID) string {
+	hexIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		hexIDs = append(hexIDs, id.Hex())
+	}
+	sort.Strings(hexIDs)
+	sum := sha256.Sum256([]byte(step + ":" + strings.Join(hexIDs, ",")))
+	return hex.EncodeToString(sum[:8])
+}
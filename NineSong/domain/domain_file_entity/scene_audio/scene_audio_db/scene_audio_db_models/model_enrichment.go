@@ -0,0 +1,66 @@
+package scene_audio_db_models
+
+import (
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrEnrichmentItemNotFound = errors.New("enrichment item not found")
+
+// MBZCheckedField 条目最近一次 MusicBrainz 匹配的时间，未匹配成功的条目在一段时间内不再重复查询
+const MBZCheckedField = "mbz_checked_at"
+
+// 可刷新 MusicBrainz 元数据的条目类型
+const (
+	EnrichmentItemAlbum  = "album"
+	EnrichmentItemArtist = "artist"
+	EnrichmentItemMedia  = "media"
+)
+
+type EnrichmentAlbum struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	Name        string             `bson:"name"`
+	AlbumArtist string             `bson:"album_artist"`
+	SongCount   int                `bson:"song_count"`
+	MBZAlbumID  string             `bson:"mbz_album_id"`
+
+	ReleaseCountry  string `bson:"release_country"`
+	MBZAlbumComment string `bson:"mbz_album_comment"`
+	CatalogNum      string `bson:"catalog_num"`
+}
+
+// EnrichmentAlbumEdition 补全写入的发行版信息，空字段不写入，标签中已有的值不被覆盖
+type EnrichmentAlbumEdition struct {
+	ReleaseCountry  string
+	MBZAlbumComment string
+	CatalogNum      string
+}
+
+type EnrichmentArtist struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	Name        string             `bson:"name"`
+	MBZArtistID string             `bson:"mbz_artist_id"`
+}
+
+type EnrichmentTrack struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	Title       string             `bson:"title"`
+	DiscNumber  int                `bson:"disc_number"`
+	TrackNumber int                `bson:"track_number"`
+}
+
+// EnrichmentTrackMatch 本地曲目与发行版曲目的对应关系
+type EnrichmentTrackMatch struct {
+	ID             primitive.ObjectID
+	RecordingID    string
+	ReleaseTrackID string
+}
+
+// EnrichmentResult 单个条目的匹配结果，MBID 为空表示未找到可信的匹配
+type EnrichmentResult struct {
+	ItemID       string `json:"item_id"`
+	ItemType     string `json:"item_type"`
+	MBID         string `json:"mbid"`
+	TracksLinked int64  `json:"tracks_linked"`
+}
@@ -0,0 +1,41 @@
+package scene_audio_db_models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrFingerprintReviewNotFound = errors.New("fingerprint review item not found")
+
+// 声纹识别状态（acoustid_status），字段缺失表示未识别过
+const (
+	FingerprintStatusMatched   = "matched"   // 匹配度足够，已写入 mbz_track_id
+	FingerprintStatusReview    = "review"    // 匹配度较低，等待管理员确认
+	FingerprintStatusUnmatched = "unmatched" // AcoustID 中没有对应录音
+	FingerprintStatusRejected  = "rejected"  // 管理员已否决候选录音
+)
+
+// FingerprintResult 曲目的声纹与 AcoustID 识别结果，单独写入曲目文档，重新扫描不覆盖
+type FingerprintResult struct {
+	Fingerprint string    `bson:"acoustid_fingerprint" json:"-"`
+	Duration    int       `bson:"acoustid_duration" json:"duration"`
+	AcoustID    string    `bson:"acoustid_id" json:"acoustid_id"`
+	RecordingID string    `bson:"acoustid_recording_id" json:"recording_id"`
+	Score       float64   `bson:"acoustid_score" json:"score"`
+	Title       string    `bson:"acoustid_title" json:"matched_title"`
+	Artist      string    `bson:"acoustid_artist" json:"matched_artist"`
+	Status      string    `bson:"acoustid_status" json:"status"`
+	CheckedAt   time.Time `bson:"acoustid_checked_at" json:"checked_at"`
+}
+
+// FingerprintReviewItem 待确认的低匹配度识别结果，附带曲目当前标签供比对
+type FingerprintReviewItem struct {
+	ID                primitive.ObjectID `bson:"_id" json:"id"`
+	Path              string             `bson:"path" json:"path"`
+	Title             string             `bson:"title" json:"title"`
+	Artist            string             `bson:"artist" json:"artist"`
+	Album             string             `bson:"album" json:"album"`
+	FingerprintResult `bson:",inline"`
+}
@@ -0,0 +1,37 @@
+package scene_audio_db_models
+
+import "strings"
+
+// NormalizeISRC 去除分隔符并转为大写，标签中常见 "US-RC1-76-07839" 与 "usrc17607839" 两种写法；
+// 长度不是 12 位的值视为无效并返回空字符串
+func NormalizeISRC(value string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(value) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() != 12 {
+		return ""
+	}
+	return b.String()
+}
+
+// NormalizeBarcode 只保留数字；UPC-A 补零为 13 位 EAN，使同一发行版的两种写法可以精确匹配
+func NormalizeBarcode(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	digits := b.String()
+	switch len(digits) {
+	case 12:
+		return "0" + digits
+	case 8, 13, 14:
+		return digits
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,38 @@
+package scene_audio_db_models
+
+import (
+	"math"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 响度分析写入曲目文档的字段，不在 MediaFileMetadata 中，重新扫描时保留
+const (
+	LoudnessAnalyzedField      = "loudness_analyzed_at"
+	LoudnessIntegratedField    = "r128_loudness"          // EBU R128 综合响度（LUFS）
+	LoudnessTruePeakField      = "r128_true_peak"         // 真峰值（线性，1.0 为满幅）
+	LoudnessErrorField         = "loudness_error"         // 分析失败原因，失败的曲目不再重试
+	LoudnessAlbumComputedField = "rg_album_gain_computed" // 专辑增益由分析计算而非标签提供
+)
+
+// LoudnessReferenceLUFS ReplayGain 2.0 参考响度
+const LoudnessReferenceLUFS = -18.0
+
+// ReplayGainFromLoudness 由综合响度换算 ReplayGain 增益（dB），保留两位小数
+func ReplayGainFromLoudness(lufs float64) float64 {
+	return math.Round((LoudnessReferenceLUFS-lufs)*100) / 100
+}
+
+// LoudnessTrack 响度分析所需的曲目字段，Loudness 为空表示尚未分析
+type LoudnessTrack struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	AlbumID     string             `bson:"album_id"`
+	Path        string             `bson:"path"`
+	Duration    float64            `bson:"duration"`
+	RGTrackGain float64            `bson:"rg_track_gain"`
+	RGTrackPeak float64            `bson:"rg_track_peak"`
+	RGAlbumGain float64            `bson:"rg_album_gain"`
+	Loudness    *float64           `bson:"r128_loudness"`
+	TruePeak    *float64           `bson:"r128_true_peak"`
+	Error       string             `bson:"loudness_error"`
+}
@@ -0,0 +1,73 @@
+package scene_audio_db_models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	// ErrMaintenanceBusy 扫描或修复任务运行中
+	ErrMaintenanceBusy = errors.New("scan or maintenance task is running")
+	// ErrMaintenanceReportNotDryRun 只有试运行报告可以应用
+	ErrMaintenanceReportNotDryRun = errors.New("maintenance report is not a dry run")
+	// ErrMaintenanceReportApplied 同一份试运行报告只能应用一次
+	ErrMaintenanceReportApplied = errors.New("maintenance report already applied")
+)
+
+const (
+	// MaintenanceJobRepair 孤立数据清理，包括源文件缺失的曲目
+	MaintenanceJobRepair = "repair"
+
+	// MaintenanceReportRetention 试运行报告保留时长，过期后需重新试运行
+	MaintenanceReportRetention = 7 * 24 * time.Hour
+	// MaintenanceReportListLimit 报告列表最多返回的条数
+	MaintenanceReportListLimit = 50
+)
+
+// MaintenanceReport 一次孤立数据清理与一致性修复的结果；试运行报告连同完整变更集持久化，审阅后可按ID应用
+type MaintenanceReport struct {
+	ID         primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	Job        string             `json:"job" bson:"job"`
+	DryRun     bool               `json:"dry_run" bson:"dry_run"` // 仅统计，不删除
+	StartedAt  time.Time          `json:"started_at" bson:"started_at"`
+	FinishedAt time.Time          `json:"finished_at,omitempty" bson:"finished_at"`
+	Running    bool               `json:"running" bson:"running"`
+
+	MissingMediaFiles   int64 `json:"missing_media_files" bson:"missing_media_files"`   // 源文件已不存在的曲目
+	EmptyAlbums         int64 `json:"empty_albums" bson:"empty_albums"`                 // 没有曲目的专辑
+	EmptyArtists        int64 `json:"empty_artists" bson:"empty_artists"`               // 没有曲目的艺术家
+	DanglingAnnotations int64 `json:"dangling_annotations" bson:"dangling_annotations"` // 指向已删除条目的注解
+
+	// ProtectedStarred 因已收藏而保留的曲目、专辑与艺术家
+	ProtectedStarred int64 `json:"protected_starred" bson:"protected_starred"`
+	// Confirmation 首个超过确认阈值的步骤；非试运行时该步骤及之后的步骤均未执行
+	Confirmation *DeletionConfirmation `json:"confirmation,omitempty" bson:"confirmation,omitempty"`
+
+	// Changes 试运行时各步骤将删除的条目；报告列表中不返回
+	Changes []MaintenanceChange `json:"changes,omitempty" bson:"changes,omitempty"`
+	// AppliedAt 试运行报告被应用的时间
+	AppliedAt *time.Time `json:"applied_at,omitempty" bson:"applied_at,omitempty"`
+	// SourceReportID 应用试运行报告时为该报告的ID
+	SourceReportID string    `json:"source_report_id,omitempty" bson:"source_report_id,omitempty"`
+	ExpiresAt      time.Time `json:"expires_at,omitempty" bson:"expires_at,omitempty"`
+
+	// 根目录不可访问的媒体库不检查源文件，避免外置存储未挂载时误删
+	SkippedLibraries []string `json:"skipped_libraries" bson:"skipped_libraries"`
+	Errors           []string `json:"errors" bson:"errors"`
+}
+
+// MaintenanceChange 单个步骤的变更集，Paths 仅对曲目给出，与 IDs 一一对应
+type MaintenanceChange struct {
+	Step  string               `json:"step" bson:"step"`
+	IDs   []primitive.ObjectID `json:"ids" bson:"ids"`
+	Paths []string             `json:"paths,omitempty" bson:"paths,omitempty"`
+}
+
+// DeletionConfirmation 以 confirm=Token 重新执行修复即可删除该步骤的 Count 个条目
+type DeletionConfirmation struct {
+	Step  string `json:"step" bson:"step"`
+	Count int    `json:"count" bson:"count"`
+	Token string `json:"token" bson:"token"`
+}
@@ -6,6 +6,13 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// MediaChapter 音频内嵌章节，起止时间单位为秒
+type MediaChapter struct {
+	Title string  `bson:"title"`
+	Start float64 `bson:"start"`
+	End   float64 `bson:"end"`
+}
+
 // MediaFileMetadata 核心元数据结构
 type MediaFileMetadata struct {
 	// 系统保留字段 (综合)
@@ -18,6 +25,12 @@ type MediaFileMetadata struct {
 	Size        int                `bson:"size"`         // 文件大小（字节）
 	FileName    string             `bson:"file_name"`    // 文件名（不包含路径）
 	LibraryPath string             `bson:"library_path"` // 音频文件所在的音乐库路径
+	FolderID    string             `bson:"folder_id"`    // 所属媒体库ID
+
+	// 审核状态，为空表示无需审核（见 ReviewStatusPending）
+	ReviewStatus string `bson:"review_status,omitempty"`
+	// 源文件可用状态（见 AvailabilityOnline），由后台检查维护
+	Availability string `bson:"availability,omitempty"`
 
 	// 基础元数据 (github.com/dhowden/tag、go.senan.xyz/taglib)
 	Title             string   `bson:"title"`               // 标准曲目标题
@@ -30,6 +43,8 @@ type MediaFileMetadata struct {
 	AlbumArtistPinyin []string `bson:"album_artist_pinyin"` // 专辑艺术家名称的拼音表示（用于搜索和排序）
 	Genre             string   `bson:"genre"`               // 音乐流派（如流行、摇滚等）
 	Year              int      `bson:"year"`                // 发行年份
+	OriginalDate      string   `bson:"original_date"`       // 原始发行日期（TDOR/ORIGINALDATE 标签）
+	OriginalYear      int      `bson:"original_year"`       // 原始发行年份，无原始日期标签时取发行年份
 	TrackNumber       int      `bson:"track_number"`        // 轨道序号（曲目在专辑中的编号）
 	DiscNumber        int      `bson:"disc_number"`         // 光盘编号（多光盘专辑中的编号）
 	TotalTracks       int      `bson:"total_tracks"`        // 专辑总轨道数
@@ -103,6 +118,14 @@ type MediaFileMetadata struct {
 	BitRate    int     `bson:"bit_rate"`    // 比特率（bps）
 	Channels   int     `bson:"channels"`    // 音频通道数（如 2 表示立体声）
 
+	// 无缝播放 (ffprobe)
+	EncoderDelay   int   `bson:"encoder_delay"`   // 编码器延迟（开头需跳过的采样数）
+	EncoderPadding int   `bson:"encoder_padding"` // 编码器填充（结尾需丢弃的采样数）
+	TotalSamples   int64 `bson:"total_samples"`   // 去除延迟与填充后的精确采样数
+
+	// 内嵌章节 (ffprobe)，有声书等长音频使用
+	Chapters []MediaChapter `bson:"chapters"`
+
 	// 高级音频参数 (github.com/go-audio/audio)
 	BitDepth       int    `bson:"bit_depth"`       // 音频位深（位）
 	ChannelLayout  string `bson:"channel_layout"`  // 声道布局（如立体声、环绕声等）
@@ -39,7 +39,7 @@ type MediaFileCueMetadata struct {
 
 	// 音频分析 (综合)
 	CueSampleRate  int     `bson:"cue_sample_rate"` // 音频采样率（Hz）
-	CueDuration    float64 `bson:"cue_duration"`    // 音频时长（秒）
+	CueDuration    float64 `bson:"cue_duration"`    // 音频时长（纳秒）
 	CueBitRate     int     `bson:"cue_bit_rate"`    // 比特率（bps）
 	CueChannels    int     `bson:"cue_channels"`    // 音频通道数（如 2 表示立体声）
 	EncodingFormat string  `bson:"encoding_format"` // 编码格式（如 PCM、MP3、AAC 等）
@@ -98,4 +98,8 @@ type CueTrack struct {
 	GAIN        float64           `bson:"track_gain"`
 	PEAK        float64           `bson:"track_peak"`
 	Extended    MediaFileMetadata `bson:"cue_track_extended"` // 嵌入 MediaFileMetadata 以复用通用字段
+
+	// 在整轨音频中的位置（秒），由 INDEX 01 推算，最后一轨截至文件结尾
+	StartSeconds    float64 `bson:"track_start_seconds"`
+	DurationSeconds float64 `bson:"track_duration_seconds"`
 }
@@ -1,13 +1,28 @@
 package scene_audio_db_models
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 歌词来源
+const (
+	LyricsTypeEmbedded = "embedded" // 音频标签中的 USLT/LYRICS
+	LyricsTypeSidecar  = "sidecar"  // 与音频同名的 .lrc 文件
+	LyricsTypeOnline   = "online"   // 本地无歌词时从在线歌词源获取，歌词为空表示未找到
+	LyricsTypeManual   = "manual"   // 手动固定的歌词，扫描与在线获取均不覆盖
+)
 
 type MediaLyricsMetadata struct {
 	ID         primitive.ObjectID `bson:"_id"`
 	MediaID    string             `bson:"media_id"`
 	Hash       string             `bson:"lyrics_hash"`
-	Type       string             `bson:"lyrics_type"`
-	Path       string             `bson:"lyrics_path"`
+	Type       string             `bson:"lyrics_type"`               // 见 LyricsTypeEmbedded
+	Path       string             `bson:"lyrics_path"`               // 外挂歌词文件路径，内嵌歌词为空
+	Provider   string             `bson:"lyrics_provider,omitempty"` // 在线歌词源，仅 online 类型
 	ClimaxTime string             `bson:"lyrics_climax_time"`
 	Lyrics     string             `bson:"lyrics"`
+	Synced     bool               `bson:"synced"` // 是否含 [mm:ss.xx] 时间标签
+	UpdatedAt  time.Time          `bson:"updated_at"`
 }
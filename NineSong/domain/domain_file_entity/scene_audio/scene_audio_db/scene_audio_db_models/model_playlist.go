@@ -18,4 +18,6 @@ type PlaylistMetadata struct {
 	Size        int                `bson:"size"`
 	Rules       string             `bson:"rules"`
 	EvaluatedAt time.Time          `bson:"evaluated_at"`
+	OwnerID     string             `bson:"owner_id"`
+	System      string             `bson:"system"` // 系统生成的播放列表类型（如每日推荐），用户创建的为空
 }
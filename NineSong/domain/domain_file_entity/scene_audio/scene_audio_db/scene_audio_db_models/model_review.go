@@ -0,0 +1,23 @@
+package scene_audio_db_models
+
+const (
+	ReviewStatusPending  = "pending"  // 待审核，普通浏览中隐藏
+	ReviewStatusRejected = "rejected" // 已拒绝，重新扫描也不会再次出现
+
+	// ReviewConfigKey 应用配置中控制是否启用审核队列的键，值为 "true" 时新入库歌曲进入待审核状态
+	ReviewConfigKey = "review_queue_enabled"
+)
+
+// ReviewTagFields 审核时允许修改的标签 -> 对应的 taglib 标签名
+var ReviewTagFields = map[string]string{
+	"title":        "TITLE",
+	"artist":       "ARTIST",
+	"album":        "ALBUM",
+	"album_artist": "ALBUMARTIST",
+	"genre":        "GENRE",
+	"year":         "DATE",
+	"track_number": "TRACKNUMBER",
+	"disc_number":  "DISCNUMBER",
+	"composer":     "COMPOSER",
+	"comment":      "COMMENT",
+}
@@ -0,0 +1,62 @@
+package scene_audio_db_models
+
+import (
+	"fmt"
+)
+
+// cueFramesPerSecond CUE 时间 mm:ss:ff 中每秒的帧数（CD 扇区）
+const cueFramesPerSecond = 75
+
+// ParseCueTime 将 CUE 的 mm:ss:ff 转换为秒
+func ParseCueTime(value string) (float64, error) {
+	var minutes, seconds, frames int
+	if _, err := fmt.Sscanf(value, "%d:%d:%d", &minutes, &seconds, &frames); err != nil {
+		return 0, fmt.Errorf("invalid cue time %q: %w", value, err)
+	}
+	if minutes < 0 || seconds < 0 || seconds >= 60 || frames < 0 || frames >= cueFramesPerSecond {
+		return 0, fmt.Errorf("invalid cue time %q", value)
+	}
+	return float64(minutes*60+seconds) + float64(frames)/cueFramesPerSecond, nil
+}
+
+// CueTrackStart 音轨起点取 INDEX 01，缺失时退回到第一个 INDEX
+func CueTrackStart(track CueTrack) (float64, bool) {
+	for _, index := range track.INDEXES {
+		if index.INDEX == 1 {
+			if start, err := ParseCueTime(index.TIME); err == nil {
+				return start, true
+			}
+		}
+	}
+	for _, index := range track.INDEXES {
+		if start, err := ParseCueTime(index.TIME); err == nil {
+			return start, true
+		}
+	}
+	return 0, false
+}
+
+// ApplyCueTrackOffsets 根据各轨起点与整轨时长（秒）写入每轨的起止位置，
+// 音轨截至下一轨起点，最后一轨截至文件结尾；时长未知时最后一轨的时长为 0，表示播放到结尾
+func ApplyCueTrackOffsets(tracks []CueTrack, totalSeconds float64) {
+	for i := range tracks {
+		start, ok := CueTrackStart(tracks[i])
+		if !ok {
+			continue
+		}
+		tracks[i].StartSeconds = start
+
+		end := totalSeconds
+		for j := i + 1; j < len(tracks); j++ {
+			if next, ok := CueTrackStart(tracks[j]); ok {
+				end = next
+				break
+			}
+		}
+		if end > start {
+			tracks[i].DurationSeconds = end - start
+		} else {
+			tracks[i].DurationSeconds = 0
+		}
+	}
+}
@@ -2,6 +2,7 @@ package scene_audio_db_models
 
 import (
 	"go.mongodb.org/mongo-driver/bson"
+	"strings"
 	"time"
 )
 
@@ -12,6 +13,22 @@ func (m *MediaFileMetadata) ToUpdateDoc() bson.M {
 
 	delete(raw, "_id")
 	delete(raw, "created_at")
+	// 审核状态只在插入时写入，重新扫描不覆盖
+	delete(raw, "review_status")
+	// 标签中没有的 MusicBrainz ID 不覆盖元数据匹配与声纹识别写入的值
+	for key, value := range raw {
+		if strings.HasPrefix(key, "mbz_") && value == "" {
+			delete(raw, key)
+		}
+	}
+	// 标签中没有的 ReplayGain 不覆盖响度分析写入的值
+	for key, value := range raw {
+		if strings.HasPrefix(key, "rg_") && value == 0.0 {
+			delete(raw, key)
+		}
+	}
+	// 能被扫描到说明源文件可读
+	raw["availability"] = AvailabilityOnline
 
 	raw["thumbnail_url"] = m.ThumbnailURL
 	raw["medium_image_url"] = m.MediumImageURL
@@ -0,0 +1,26 @@
+package scene_audio_federation_interface
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_federation/scene_audio_federation_models"
+)
+
+type FederationPeerRepository interface {
+	Insert(ctx context.Context, peer *scene_audio_federation_models.FederationPeer) error
+	Update(ctx context.Context, peer *scene_audio_federation_models.FederationPeer) error
+	Delete(ctx context.Context, id string) (bool, error)
+	GetByID(ctx context.Context, id string) (*scene_audio_federation_models.FederationPeer, error)
+	GetByInboundTokenHash(ctx context.Context, hash string) (*scene_audio_federation_models.FederationPeer, error)
+	GetAll(ctx context.Context) ([]scene_audio_federation_models.FederationPeer, error)
+}
+
+// FederationClient 调用远程实例的联邦接口
+type FederationClient interface {
+	Handshake(ctx context.Context, baseURL string, req scene_audio_federation_models.FederationHandshake) error
+	List(ctx context.Context, peer *scene_audio_federation_models.FederationPeer, kind string, query scene_audio_federation_models.RemoteQuery) ([]scene_audio_federation_models.RemoteItem, int, error)
+	// Stream 由调用方负责关闭返回的响应体
+	Stream(ctx context.Context, peer *scene_audio_federation_models.FederationPeer, remoteID string, header http.Header) (*http.Response, error)
+	Unlink(ctx context.Context, peer *scene_audio_federation_models.FederationPeer) error
+}
@@ -0,0 +1,97 @@
+package scene_audio_federation_models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// FederationTokenHeader 实例之间互相调用时携带的令牌
+	FederationTokenHeader = "X-Federation-Token"
+	// RemoteIDPrefix 远程条目ID前缀，格式 remote:<peer_id>:<remote_id>
+	RemoteIDPrefix = "remote"
+	// DefaultStreamKbps 未配置限速时对方串流本实例音频的带宽上限
+	DefaultStreamKbps = 2048
+)
+
+const (
+	PeerStatusPending = "pending" // 已生成邀请，等待对方握手
+	PeerStatusActive  = "active"
+)
+
+// 可浏览的远程条目类型，同时作为对方接口的路径
+const (
+	RemoteKindAlbums     = "albums"
+	RemoteKindArtists    = "artists"
+	RemoteKindMediaFiles = "media_files"
+)
+
+var (
+	ErrFederationNotConfigured = errors.New("federation public url is not configured")
+	ErrPeerNotFound            = errors.New("federation peer not found")
+	ErrInvalidInvite           = errors.New("invalid or used federation invite")
+	ErrInvalidPeerToken        = errors.New("invalid federation token")
+	ErrStreamNotAllowed        = errors.New("streaming is not allowed for this peer")
+	ErrInvalidRemoteID         = errors.New("invalid remote item id")
+	ErrRemoteUnavailable       = errors.New("remote instance unavailable")
+)
+
+// FederationPeer 已关联的远程实例，双方各保存一条
+type FederationPeer struct {
+	ID               primitive.ObjectID `bson:"_id" json:"id"`
+	Name             string             `bson:"name" json:"name"`
+	BaseURL          string             `bson:"base_url" json:"base_url"`
+	Status           string             `bson:"status" json:"status"`
+	OutboundToken    string             `bson:"outbound_token" json:"-"`     // 访问对方时携带
+	InboundTokenHash string             `bson:"inbound_token_hash" json:"-"` // 对方访问本实例所用令牌的摘要
+	AllowStream      bool               `bson:"allow_stream" json:"allow_stream"`
+	StreamKbps       int                `bson:"stream_kbps" json:"stream_kbps"`
+	CreatedAt        time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt        time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// FederationInvite 交给对方管理员的邀请，对方据此发起握手
+type FederationInvite struct {
+	PeerID  string `json:"peer_id"`
+	BaseURL string `json:"base_url"`
+	Token   string `json:"token"`
+}
+
+// FederationHandshake 发起方提交给邀请方的握手请求
+type FederationHandshake struct {
+	InviteToken string `json:"invite_token"`
+	Token       string `json:"token"` // 邀请方今后访问发起方时携带
+	Name        string `json:"name"`
+	BaseURL     string `json:"base_url"`
+}
+
+// RemoteQuery 浏览远程媒体库的查询参数，原样转发给对方
+type RemoteQuery struct {
+	Start    string
+	End      string
+	Sort     string
+	Order    string
+	Search   string
+	AlbumID  string
+	ArtistID string
+}
+
+// RemoteItem 远程条目保留对方返回的全部字段，ID类字段改写为带命名空间的远程ID
+type RemoteItem map[string]interface{}
+
+func RemoteItemID(peerID, remoteID string) string {
+	return fmt.Sprintf("%s:%s:%s", RemoteIDPrefix, peerID, remoteID)
+}
+
+// ParseRemoteItemID 解析远程ID，返回实例ID与对方的原始ID
+func ParseRemoteItemID(id string) (string, string, error) {
+	parts := strings.SplitN(id, ":", 3)
+	if len(parts) != 3 || parts[0] != RemoteIDPrefix || parts[1] == "" || parts[2] == "" {
+		return "", "", ErrInvalidRemoteID
+	}
+	return parts[1], parts[2], nil
+}
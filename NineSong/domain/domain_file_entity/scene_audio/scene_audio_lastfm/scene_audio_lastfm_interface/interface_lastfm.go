@@ -0,0 +1,12 @@
+package scene_audio_lastfm_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_lastfm/scene_audio_lastfm_models"
+)
+
+type LastFMClient interface {
+	// SimilarArtists 优先按 MusicBrainz ID 查询，mbid 为空时按名称查询，按相似度降序
+	SimilarArtists(ctx context.Context, name, mbid string, limit int) ([]scene_audio_lastfm_models.LastFMSimilarArtist, error)
+}
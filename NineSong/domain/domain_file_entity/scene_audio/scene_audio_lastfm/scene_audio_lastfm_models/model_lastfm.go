@@ -0,0 +1,8 @@
+package scene_audio_lastfm_models
+
+// LastFMSimilarArtist artist.getSimilar 返回的一位相似艺术家，Match 为 Last.fm 给出的相似度（0-1）
+type LastFMSimilarArtist struct {
+	Name  string
+	MBID  string
+	Match float64
+}
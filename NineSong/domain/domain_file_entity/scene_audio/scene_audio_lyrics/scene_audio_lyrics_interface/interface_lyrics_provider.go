@@ -0,0 +1,15 @@
+package scene_audio_lyrics_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_lyrics/scene_audio_lyrics_models"
+)
+
+type LyricsProvider interface {
+	// Name 歌词源名称，见 scene_audio_lyrics_models.ProviderLrcLib
+	Name() string
+
+	// FetchLyrics 返回 LRC 或纯文本歌词，未找到时返回 ErrNotFound
+	FetchLyrics(ctx context.Context, query scene_audio_lyrics_models.LyricsQuery) (string, error)
+}
@@ -0,0 +1,20 @@
+package scene_audio_lyrics_models
+
+import "errors"
+
+var ErrNotFound = errors.New("lyrics not found on provider")
+
+// 在线歌词源，LYRICS_PROVIDERS 按顺序依次尝试
+const (
+	ProviderLrcLib  = "lrclib"
+	ProviderNetEase = "netease"
+	ProviderGenius  = "genius"
+)
+
+// LyricsQuery 按曲目元数据检索歌词，Duration 为秒，0 表示未知
+type LyricsQuery struct {
+	Title    string
+	Artist   string
+	Album    string
+	Duration float64
+}
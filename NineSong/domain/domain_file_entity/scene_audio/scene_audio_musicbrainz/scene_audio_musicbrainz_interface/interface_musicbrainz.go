@@ -0,0 +1,21 @@
+package scene_audio_musicbrainz_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_musicbrainz/scene_audio_musicbrainz_models"
+)
+
+type MusicBrainzClient interface {
+	// GetRelease 查询发行版及其全部曲目，发行版不存在时返回 ErrNotFound
+	GetRelease(ctx context.Context, releaseID string) (*scene_audio_musicbrainz_models.MusicBrainzRelease, error)
+
+	// GetArtistAliases 查询艺术家的别名（含各语言名称）
+	GetArtistAliases(ctx context.Context, artistID string) ([]string, error)
+
+	// SearchReleases 按专辑名与艺术家搜索发行版，按匹配度降序
+	SearchReleases(ctx context.Context, title, artist string) ([]scene_audio_musicbrainz_models.MusicBrainzSearchResult, error)
+
+	// SearchArtists 按名称搜索艺术家，按匹配度降序
+	SearchArtists(ctx context.Context, name string) ([]scene_audio_musicbrainz_models.MusicBrainzSearchResult, error)
+}
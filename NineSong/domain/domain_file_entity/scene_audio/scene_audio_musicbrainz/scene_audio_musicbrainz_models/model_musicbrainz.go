@@ -0,0 +1,45 @@
+package scene_audio_musicbrainz_models
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidMBID = errors.New("invalid musicbrainz id")
+	ErrNotFound    = errors.New("musicbrainz entity not found")
+)
+
+// MusicBrainzTrack 发行版中的单条曲目
+type MusicBrainzTrack struct {
+	ID          string `bson:"id" json:"id"`                     // 发行版曲目ID（对应 MUSICBRAINZ_RELEASETRACKID）
+	RecordingID string `bson:"recording_id" json:"recording_id"` // 录音ID（对应 MUSICBRAINZ_TRACKID）
+	DiscNumber  int    `bson:"disc_number" json:"disc_number"`
+	TrackNumber int    `bson:"track_number" json:"track_number"`
+	Title       string `bson:"title" json:"title"`
+	Length      int    `bson:"length" json:"length"` // 毫秒
+}
+
+// MusicBrainzRelease 发行版（对应本地专辑的 mbz_album_id）
+type MusicBrainzRelease struct {
+	ID         string             `bson:"mbz_album_id"`
+	Title      string             `bson:"title"`
+	TrackCount int                `bson:"track_count"`
+	Tracks     []MusicBrainzTrack `bson:"tracks"`
+	FetchedAt  time.Time          `bson:"fetched_at"`
+
+	// 发行版信息，用于区分同名专辑的不同版本
+	Country        string `bson:"country"`        // 发行国家，如 JP、XE
+	Disambiguation string `bson:"disambiguation"` // 发行版附注，如 Japanese edition
+	CatalogNumber  string `bson:"catalog_number"` // 首个厂牌的目录编号
+}
+
+// MusicBrainzSearchResult 发行版或艺术家搜索结果，Score 为 MusicBrainz 给出的匹配度（0-100）
+type MusicBrainzSearchResult struct {
+	ID         string
+	Title      string // 发行版标题或艺术家名称
+	ArtistID   string // 发行版的首位署名艺术家，艺术家搜索时为空
+	Score      int
+	TrackCount int
+	Country    string // 发行版的发行国家，艺术家搜索时为空
+}
@@ -12,12 +12,31 @@ type AlbumRepository interface {
 		start, end, sort, order,
 		search, starred,
 		artistId,
-		minYear, maxYear string,
+		minYear, maxYear, decade,
+		folderId, available, custom string,
 	) ([]scene_audio_route_models.AlbumMetadata, error)
 
 	GetAlbumFilterItemsCount(
 		ctx context.Context,
 		search, starred, artistId,
-		minYear, maxYear string,
+		minYear, maxYear, decade,
+		folderId, available, custom string,
+		genres string,
 	) (*scene_audio_route_models.AlbumFilterCounts, error)
+
+	// GetAlbumYears 按原始发行年份与年代统计专辑数，过滤条件与 GetAlbumFilterItemsCount 相同
+	GetAlbumYears(
+		ctx context.Context,
+		search, starred, artistId,
+		folderId, available, custom string,
+	) (*scene_audio_route_models.AlbumYearCounts, error)
+
+	GetAlbumShelves(
+		ctx context.Context,
+		limits scene_audio_route_models.AlbumShelfLimits,
+	) (*scene_audio_route_models.AlbumShelves, error)
+
+	GetAlbumTracks(ctx context.Context, albumId string) (*scene_audio_route_models.AlbumTracks, error)
+
+	GetAlbumDetail(ctx context.Context, albumId, userId string) (*scene_audio_route_models.AlbumDetail, error)
 }
@@ -0,0 +1,26 @@
+package scene_audio_route_interface
+
+import (
+	"context"
+	"io"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+)
+
+type AlbumAttachmentRepository interface {
+	AlbumExists(ctx context.Context, albumId string) (bool, error)
+	GetByAlbum(ctx context.Context, albumId string) ([]scene_audio_db_models.AlbumAttachmentMetadata, error)
+	// Get 附件不属于该专辑时同样返回 domain.ErrNotFound
+	Get(ctx context.Context, albumId, attachmentId string) (*scene_audio_db_models.AlbumAttachmentMetadata, error)
+	Insert(ctx context.Context, attachment *scene_audio_db_models.AlbumAttachmentMetadata) error
+	Delete(ctx context.Context, attachmentId string) error
+}
+
+type AlbumAttachmentUsecase interface {
+	List(ctx context.Context, albumId string) ([]scene_audio_db_models.AlbumAttachmentMetadata, error)
+	// Open 返回可下载的附件，下发前重新校验文件类型
+	Open(ctx context.Context, albumId, attachmentId string) (*scene_audio_db_models.AlbumAttachmentMetadata, error)
+	Upload(ctx context.Context, albumId, userId, name string, size int64, r io.Reader) (*scene_audio_db_models.AlbumAttachmentMetadata, error)
+	// Delete 只能删除上传的附件，目录中的附件随文件删除后的下次扫描移除
+	Delete(ctx context.Context, albumId, attachmentId string) error
+}
@@ -0,0 +1,14 @@
+package scene_audio_route_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+type AlbumCompletenessRepository interface {
+	// GetIncompleteAlbums 在带有 mbz_album_id 的专辑中按start/end分页比对，仅返回曲目不全的专辑
+	GetIncompleteAlbums(ctx context.Context, start, end string) ([]scene_audio_route_models.AlbumCompletenessMetadata, error)
+
+	GetAlbumMissingTracks(ctx context.Context, albumId string) (*scene_audio_route_models.AlbumCompletenessMetadata, error)
+}
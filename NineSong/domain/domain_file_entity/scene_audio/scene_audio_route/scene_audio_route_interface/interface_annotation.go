@@ -9,8 +9,15 @@ type AnnotationRepository interface {
 	UpdateStarred(ctx context.Context, itemId string, itemType string) (bool, error)
 	UpdateUnStarred(ctx context.Context, itemId string, itemType string) (bool, error)
 	UpdateRating(ctx context.Context, itemId string, itemType string, rating int) (bool, error)
+	RateMediaFile(ctx context.Context, mediaFileId string, rating int) (*scene_audio_route_models.MediaFileRating, error)
 	UpdateScrobble(ctx context.Context, itemId string, itemType string) (bool, error)
 	UpdateCompleteScrobble(ctx context.Context, itemId string, itemType string) (bool, error)
+	// SetStarred 收藏或取消收藏并返回更新后的注解，注解不存在时创建
+	SetStarred(ctx context.Context, itemId string, itemType string, starred bool) (*scene_audio_route_models.AnnotationMetadata, error)
+	// GetAnnotation 读取任意已登记条目类型的注解，不存在时返回 domain.ErrNotFound
+	GetAnnotation(ctx context.Context, itemId string, itemType scene_audio_route_models.AnnotationItemType) (*scene_audio_route_models.AnnotationMetadata, error)
+	// SetAnnotation 写入任意已登记条目类型的注解并返回更新后的注解，注解不存在时创建
+	SetAnnotation(ctx context.Context, itemId string, itemType scene_audio_route_models.AnnotationItemType, update scene_audio_route_models.AnnotationUpdate) (*scene_audio_route_models.AnnotationMetadata, error)
 
 	UpdateTagSource(ctx context.Context, itemId string, itemType string, tags []scene_audio_route_models.TagSource) (bool, error)
 	UpdateWeightedTag(ctx context.Context, itemId string, itemType string, tags []scene_audio_route_models.WeightedTag) (bool, error)
@@ -17,4 +17,11 @@ type ArtistRepository interface {
 		ctx context.Context,
 		search, starred string,
 	) (*scene_audio_route_models.ArtistFilterCounts, error)
+
+	GetArtist(ctx context.Context, artistId string) (*scene_audio_route_models.ArtistMetadata, error)
+}
+
+// ArtistPageUsecase 艺术家页一次返回艺术家信息、专辑、热门歌曲与相似艺术家
+type ArtistPageUsecase interface {
+	GetArtistPage(ctx context.Context, artistId string, limits scene_audio_route_models.ArtistPageLimits) (*scene_audio_route_models.ArtistPage, error)
 }
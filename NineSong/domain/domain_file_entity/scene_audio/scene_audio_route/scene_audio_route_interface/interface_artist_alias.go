@@ -0,0 +1,19 @@
+package scene_audio_route_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+type ArtistAliasRepository interface {
+	GetArtistAliases(ctx context.Context, artistId string) ([]scene_audio_route_models.ArtistAlias, error)
+	AddArtistAlias(ctx context.Context, artistId string, alias scene_audio_route_models.ArtistAlias) ([]scene_audio_route_models.ArtistAlias, error)
+	RemoveArtistAlias(ctx context.Context, artistId string, name string) ([]scene_audio_route_models.ArtistAlias, error)
+
+	// SyncMusicBrainzAliases 按艺术家的 mbz_artist_id 拉取别名并合并到已有别名
+	SyncMusicBrainzAliases(ctx context.Context, artistId string) ([]scene_audio_route_models.ArtistAlias, error)
+
+	// MergeArtists 将源艺术家的专辑、曲目、CUE及注释归并到目标艺术家，源名称及别名转为目标别名后删除源艺术家
+	MergeArtists(ctx context.Context, sourceId string, targetId string) (*scene_audio_route_models.ArtistMergeResult, error)
+}
@@ -0,0 +1,24 @@
+package scene_audio_route_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+type AudiobookRepository interface {
+	// GetAudiobooks 只统计标记为有声书库且调用方可访问的媒体库，同时返回总数；count 不大于 0 时不分页
+	GetAudiobooks(ctx context.Context, userId, sort string, start, count int) ([]scene_audio_route_models.Audiobook, int, error)
+	// GetAudiobook 返回书的信息、按阅读顺序排列的曲目与收听进度
+	GetAudiobook(ctx context.Context, userId, id string) (*scene_audio_route_models.Audiobook, error)
+	SaveProgress(ctx context.Context, progress scene_audio_route_models.AudiobookProgress) (*scene_audio_route_models.AudiobookProgress, error)
+	DeleteProgress(ctx context.Context, userId, bookId string) (bool, error)
+}
+
+type AudiobookUsecase interface {
+	GetAudiobooks(ctx context.Context, userId, sort string, start, count int) ([]scene_audio_route_models.Audiobook, int, error)
+	GetAudiobook(ctx context.Context, userId, id string) (*scene_audio_route_models.Audiobook, error)
+	// SaveProgress 位置超出文件时长时截断，最后一个文件接近结尾时自动标记为听完
+	SaveProgress(ctx context.Context, userId, bookId, mediaFileId string, position float64, finished bool) (*scene_audio_route_models.AudiobookProgress, error)
+	DeleteProgress(ctx context.Context, userId, bookId string) (bool, error)
+}
@@ -0,0 +1,25 @@
+package scene_audio_route_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+type BookmarkRepository interface {
+	// GetBookmarks 按最近更新倒序，同时返回总数；条目已删除或调用方不可见的书签不返回；count 不大于 0 时不分页
+	GetBookmarks(ctx context.Context, userId, itemType string, start, count int) ([]scene_audio_route_models.Bookmark, int, error)
+	GetBookmark(ctx context.Context, userId, itemId string) (*scene_audio_route_models.Bookmark, error)
+	// GetItemDuration 返回调用方可见条目的时长（秒），条目不存在时返回 ErrNotFound
+	GetItemDuration(ctx context.Context, userId, itemType, itemId string) (float64, error)
+	SaveBookmark(ctx context.Context, bookmark scene_audio_route_models.Bookmark) (*scene_audio_route_models.Bookmark, error)
+	DeleteBookmark(ctx context.Context, userId, itemId string) (bool, error)
+}
+
+type BookmarkUsecase interface {
+	GetBookmarks(ctx context.Context, userId, itemType string, start, count int) ([]scene_audio_route_models.Bookmark, int, error)
+	GetBookmark(ctx context.Context, userId, itemId string) (*scene_audio_route_models.Bookmark, error)
+	// SaveBookmark 创建或覆盖条目的书签，位置超出条目时长时截断
+	SaveBookmark(ctx context.Context, userId, itemType, itemId string, position float64, comment string) (*scene_audio_route_models.Bookmark, error)
+	DeleteBookmark(ctx context.Context, userId, itemId string) (bool, error)
+}
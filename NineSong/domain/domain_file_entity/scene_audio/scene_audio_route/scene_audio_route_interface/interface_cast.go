@@ -0,0 +1,26 @@
+package scene_audio_route_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+type CastRepository interface {
+	GetMediaFile(ctx context.Context, mediaFileId string) (*scene_audio_route_models.MediaFileMetadata, error)
+}
+
+type CastUsecase interface {
+	// Devices refresh 为 true 时忽略缓存重新搜索局域网
+	Devices(ctx context.Context, refresh bool) ([]scene_audio_route_models.CastDevice, error)
+	Status(ctx context.Context, deviceId string) (*scene_audio_route_models.CastStatus, error)
+	// Load 在设备上播放单曲，设备通过带 userId 临时令牌的串流地址直接从服务器拉取音频
+	Load(ctx context.Context, userId, deviceId, mediaFileId string, opts scene_audio_route_models.CastLoadOptions) (*scene_audio_route_models.CastStatus, error)
+	Play(ctx context.Context, deviceId string) (*scene_audio_route_models.CastStatus, error)
+	Pause(ctx context.Context, deviceId string) (*scene_audio_route_models.CastStatus, error)
+	Seek(ctx context.Context, deviceId string, position float64) (*scene_audio_route_models.CastStatus, error)
+	// SetVolume volume 取值 0-100，muted 为 nil 时不改变静音状态
+	SetVolume(ctx context.Context, deviceId string, volume *int, muted *bool) (*scene_audio_route_models.CastStatus, error)
+	// Stop 结束投屏并断开与设备的连接
+	Stop(ctx context.Context, deviceId string) error
+}
@@ -0,0 +1,33 @@
+package scene_audio_route_interface
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+type ChangesRepository interface {
+	// GetChangedItems 返回 updated_at 位于 [since, until) 且排在 after 之后的调用方可见条目，按 (updated_at, _id) 升序，最多 limit 条
+	GetChangedItems(
+		ctx context.Context,
+		itemType string,
+		since, until time.Time,
+		after *scene_audio_route_models.ChangeCursor,
+		limit int,
+	) ([]scene_audio_route_models.ChangedItem, error)
+	// GetDeletedItems 返回删除日志中 deleted_at 位于 [since, until) 且排在 after 之后的记录，按 (deleted_at, _id) 升序，最多 limit 条
+	GetDeletedItems(
+		ctx context.Context,
+		since, until time.Time,
+		after *scene_audio_route_models.ChangeCursor,
+		limit int,
+	) ([]scene_audio_route_models.DeletionRecord, error)
+	// GetExistingItems 返回 ids 中仍存在的条目，用于排除重建后以相同ID写回的条目
+	GetExistingItems(ctx context.Context, itemType string, ids []string) (map[string]bool, error)
+}
+
+type ChangesUsecase interface {
+	// GetChanges since 为空时只返回当前令牌并要求全量同步
+	GetChanges(ctx context.Context, since string) (*scene_audio_route_models.ChangeFeed, error)
+}
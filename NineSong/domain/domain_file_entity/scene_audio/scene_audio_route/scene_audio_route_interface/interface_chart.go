@@ -0,0 +1,21 @@
+package scene_audio_route_interface
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+// ChartRepository 由播放记录（每次播放一条）按窗口统计，since 为零值时统计全部时间；已删除的条目跳过
+type ChartRepository interface {
+	TopMediaFiles(ctx context.Context, since time.Time, limit int) ([]scene_audio_route_models.ChartMediaFile, error)
+	TopAlbums(ctx context.Context, since time.Time, limit int) ([]scene_audio_route_models.ChartAlbum, error)
+	TopArtists(ctx context.Context, since time.Time, limit int) ([]scene_audio_route_models.ChartArtist, error)
+}
+
+type ChartUsecase interface {
+	GetTopMediaFiles(ctx context.Context, window string, limit int) ([]scene_audio_route_models.ChartMediaFile, error)
+	GetTopAlbums(ctx context.Context, window string, limit int) ([]scene_audio_route_models.ChartAlbum, error)
+	GetTopArtists(ctx context.Context, window string, limit int) ([]scene_audio_route_models.ChartArtist, error)
+}
@@ -0,0 +1,30 @@
+package scene_audio_route_interface
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DailyMixRepository 每日推荐歌单以系统播放列表（system 字段非空）的形式保存，每位用户每种歌单只保留最新一份
+type DailyMixRepository interface {
+	// ActiveUsers since 之后有播放记录的用户
+	ActiveUsers(ctx context.Context, since time.Time) ([]string, error)
+	// TopGenres 按用户 since 之后的播放次数排序的流派
+	TopGenres(ctx context.Context, userId string, since time.Time, limit int) ([]string, error)
+	// GenreMediaFiles 从流派的可见曲目中随机抽取
+	GenreMediaFiles(ctx context.Context, genre string, limit int) ([]primitive.ObjectID, error)
+	// NewMediaFiles since 之后入库且用户从未播放过的曲目，按入库时间倒序
+	NewMediaFiles(ctx context.Context, userId string, since time.Time, limit int) ([]primitive.ObjectID, error)
+	// ReplaceMixes 删除用户原有的系统播放列表及曲目后写入新歌单
+	ReplaceMixes(ctx context.Context, userId string, mixes []scene_audio_route_models.DailyMixDraft) error
+	GetMixes(ctx context.Context, userId string) ([]scene_audio_route_models.DailyMix, error)
+	// LastGeneratedAt 最近一次生成系统播放列表的时间，从未生成时为零值
+	LastGeneratedAt(ctx context.Context) (time.Time, error)
+}
+
+type DailyMixUsecase interface {
+	GetMixes(ctx context.Context, userId string) ([]scene_audio_route_models.DailyMix, error)
+}
@@ -0,0 +1,18 @@
+package scene_audio_route_interface
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+// DiscoverRepository 查询已收藏或用户播放次数不少于 minPlays、且 since 之后该用户未再播放的条目
+type DiscoverRepository interface {
+	GetForgottenMediaFiles(ctx context.Context, userId string, since time.Time, minPlays, limit int) ([]scene_audio_route_models.ForgottenMediaFile, error)
+	GetForgottenAlbums(ctx context.Context, userId string, since time.Time, minPlays, limit int) ([]scene_audio_route_models.ForgottenAlbum, error)
+}
+
+type DiscoverUsecase interface {
+	GetForgotten(ctx context.Context, userId string, months, minPlays, limit int) (*scene_audio_route_models.ForgottenResult, error)
+}
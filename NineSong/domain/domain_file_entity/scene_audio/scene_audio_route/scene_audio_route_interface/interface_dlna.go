@@ -0,0 +1,21 @@
+package scene_audio_route_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+type DLNARepository interface {
+	GetMediaFile(ctx context.Context, mediaFileId string) (*scene_audio_route_models.MediaFileMetadata, error)
+	// GetSystemUpdateID 媒体库最近一次变更的时间戳，媒体库变化后客户端据此刷新缓存的目录
+	GetSystemUpdateID(ctx context.Context) (uint32, error)
+}
+
+type DLNAUsecase interface {
+	// Browse children 为 false 时返回对象自身（BrowseMetadata），为 true 时返回其子对象（BrowseDirectChildren）
+	Browse(ctx context.Context, objectId string, children bool, start, count int) (*scene_audio_route_models.DLNABrowseResult, error)
+	GetSystemUpdateID(ctx context.Context) (uint32, error)
+	GetStreamPath(ctx context.Context, mediaFileId string) (string, error)
+	GetCoverPath(ctx context.Context, albumId string) (string, error)
+}
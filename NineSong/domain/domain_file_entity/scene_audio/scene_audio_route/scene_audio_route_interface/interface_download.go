@@ -0,0 +1,14 @@
+package scene_audio_route_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+// DownloadUsecase 整理下载所需的原始文件路径与包内命名，受媒体库权限限制时不可见的曲目不会出现在结果中
+type DownloadUsecase interface {
+	GetAlbumArchive(ctx context.Context, albumId string) (*scene_audio_route_models.DownloadArchive, error)
+	GetPlaylistArchive(ctx context.Context, playlistId string) (*scene_audio_route_models.DownloadArchive, error)
+	GetTrack(ctx context.Context, mediaFileId string) (*scene_audio_route_models.DownloadEntry, error)
+}
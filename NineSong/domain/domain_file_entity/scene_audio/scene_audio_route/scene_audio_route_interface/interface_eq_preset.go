@@ -0,0 +1,17 @@
+package scene_audio_route_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+type EqPresetRepository interface {
+	GetEqPresets(ctx context.Context, userId string) ([]scene_audio_route_models.EqPresetMetadata, error)
+
+	GetEqPreset(ctx context.Context, userId string, name string) (*scene_audio_route_models.EqPresetMetadata, error)
+
+	SaveEqPreset(ctx context.Context, preset scene_audio_route_models.EqPresetMetadata) (*scene_audio_route_models.EqPresetMetadata, error)
+
+	DeleteEqPreset(ctx context.Context, userId string, name string) (bool, error)
+}
@@ -0,0 +1,17 @@
+package scene_audio_route_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+type GenreRepository interface {
+	// GetGenreTree 返回流派树，媒体库中未归类的流派作为顶层节点
+	GetGenreTree(ctx context.Context, locale string) ([]scene_audio_route_models.GenreNode, error)
+
+	SaveGenre(ctx context.Context, genre scene_audio_route_models.GenreMetadata) (*scene_audio_route_models.GenreMetadata, error)
+
+	// DeleteGenre 删除节点，其子流派挂到被删节点的父流派下
+	DeleteGenre(ctx context.Context, name string) (bool, error)
+}
@@ -0,0 +1,17 @@
+package scene_audio_route_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+type HistoryRepository interface {
+	// GetHistory 按播放时间倒序返回当前页记录及符合条件的总数
+	GetHistory(ctx context.Context, filter scene_audio_route_models.PlayHistoryFilter) ([]scene_audio_route_models.PlayHistoryItem, int64, error)
+}
+
+type HistoryUsecase interface {
+	// GetHistory from/to 接受 YYYY-MM-DD 或 RFC3339，to 为日期时包含当天
+	GetHistory(ctx context.Context, userId, mediaFileId, from, to, start, end string) ([]scene_audio_route_models.PlayHistoryItem, int64, error)
+}
@@ -2,6 +2,7 @@ package scene_audio_route_interface
 
 import (
 	"context"
+	"time"
 
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
 )
@@ -23,3 +24,19 @@ type HomeRepository interface {
 		start string,
 	) ([]scene_audio_route_models.MediaFileMetadata, error)
 }
+
+// HomeSectionRepository 首页各分区的数据查询，userId 用于按播放历史生成的分区
+type HomeSectionRepository interface {
+	GetRecentlyAddedAlbums(ctx context.Context, limit int) ([]scene_audio_route_models.AlbumMetadata, error)
+	GetContinueListeningAlbums(ctx context.Context, userId string, limit int) ([]scene_audio_route_models.AlbumMetadata, error)
+	GetFavoriteMediaFiles(ctx context.Context, userId string, limit int) ([]scene_audio_route_models.MediaFileMetadata, error)
+	// GetOnThisDayMediaFiles 往年与 day 同月同日播放过的曲目，按当天播放次数降序
+	GetOnThisDayMediaFiles(ctx context.Context, userId string, day time.Time, limit int) ([]scene_audio_route_models.MediaFileMetadata, error)
+	// GetDailyMixes 以近期最常听的艺术家为种子生成歌单，同一用户同一天结果不变
+	GetDailyMixes(ctx context.Context, userId string, day time.Time, limit int) ([]scene_audio_route_models.HomeMix, error)
+}
+
+type HomeSectionUsecase interface {
+	// GetHome 并行组装各分区，sections 为空时使用用户保存的布局或默认布局；单个分区失败时该分区返回空列表
+	GetHome(ctx context.Context, userId string, sections []scene_audio_route_models.HomeSectionConfig) ([]scene_audio_route_models.HomeSection, error)
+}
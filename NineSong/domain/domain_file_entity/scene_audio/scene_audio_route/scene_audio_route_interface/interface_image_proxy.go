@@ -0,0 +1,8 @@
+package scene_audio_route_interface
+
+import "context"
+
+type ImageProxyUsecase interface {
+	// GetImage 返回外部图片转为 JPEG 后的本地缓存路径，size 为最长边像素，0 表示保持原尺寸
+	GetImage(ctx context.Context, rawURL string, size int) (string, error)
+}
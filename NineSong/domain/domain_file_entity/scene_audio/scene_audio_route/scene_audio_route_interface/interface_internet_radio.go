@@ -0,0 +1,27 @@
+package scene_audio_route_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+type InternetRadioRepository interface {
+	GetStations(ctx context.Context, userId string) ([]scene_audio_route_models.InternetRadioStation, error)
+	GetStation(ctx context.Context, userId, id string) (*scene_audio_route_models.InternetRadioStation, error)
+	CountStations(ctx context.Context, userId string) (int64, error)
+	// CreateStation 同一用户下名称重复时返回 ErrRadioStationExists
+	CreateStation(ctx context.Context, station scene_audio_route_models.InternetRadioStation) (*scene_audio_route_models.InternetRadioStation, error)
+	UpdateStation(ctx context.Context, station scene_audio_route_models.InternetRadioStation) (*scene_audio_route_models.InternetRadioStation, error)
+	DeleteStation(ctx context.Context, userId, id string) (bool, error)
+}
+
+type InternetRadioUsecase interface {
+	GetStations(ctx context.Context, userId string) ([]scene_audio_route_models.InternetRadioStation, error)
+	GetStation(ctx context.Context, userId, id string) (*scene_audio_route_models.InternetRadioStation, error)
+	// CreateStation skipCheck 为 false 时先检测串流可达，不可达返回 ErrRadioStreamUnreachable
+	CreateStation(ctx context.Context, station scene_audio_route_models.InternetRadioStation, skipCheck bool) (*scene_audio_route_models.InternetRadioStation, error)
+	UpdateStation(ctx context.Context, station scene_audio_route_models.InternetRadioStation, skipCheck bool) (*scene_audio_route_models.InternetRadioStation, error)
+	DeleteStation(ctx context.Context, userId, id string) (bool, error)
+	CheckStream(ctx context.Context, streamURL string) (*scene_audio_route_models.RadioStreamCheck, error)
+}
@@ -0,0 +1,26 @@
+package scene_audio_route_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type JukeboxRepository interface {
+	// GetMediaFiles 按传入顺序返回媒体文件，已删除的文件被忽略
+	GetMediaFiles(ctx context.Context, ids []primitive.ObjectID) ([]scene_audio_route_models.MediaFileMetadata, error)
+}
+
+type JukeboxUsecase interface {
+	Status(ctx context.Context) (*scene_audio_route_models.JukeboxStatus, error)
+	// SetQueue 替换播放队列并定位到 index，正在播放时立即切换
+	SetQueue(ctx context.Context, mediaFileIds []string, index int) (*scene_audio_route_models.JukeboxStatus, error)
+	Add(ctx context.Context, mediaFileIds []string) (*scene_audio_route_models.JukeboxStatus, error)
+	// Play index 小于 0 时继续播放当前曲目
+	Play(ctx context.Context, index int) (*scene_audio_route_models.JukeboxStatus, error)
+	Pause(ctx context.Context) (*scene_audio_route_models.JukeboxStatus, error)
+	// Skip offset 为 1 时下一首，-1 时上一首
+	Skip(ctx context.Context, offset int) (*scene_audio_route_models.JukeboxStatus, error)
+	SetVolume(ctx context.Context, volume int) (*scene_audio_route_models.JukeboxStatus, error)
+}
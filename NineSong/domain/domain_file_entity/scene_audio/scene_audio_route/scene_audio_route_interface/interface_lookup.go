@@ -0,0 +1,17 @@
+package scene_audio_route_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+// LookupRepository 参数为已规范化的标识符，按媒体库权限过滤
+type LookupRepository interface {
+	GetMediaFilesByISRC(ctx context.Context, isrc string) ([]scene_audio_route_models.MediaFileMetadata, error)
+	GetAlbumsByBarcode(ctx context.Context, barcode string) ([]scene_audio_route_models.AlbumMetadata, error)
+}
+
+type LookupUsecase interface {
+	Lookup(ctx context.Context, isrc, barcode string) (*scene_audio_route_models.LookupResult, error)
+}
@@ -0,0 +1,15 @@
+package scene_audio_route_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+type LyricsRepository interface {
+	GetLyrics(ctx context.Context, mediaFileId string) (*scene_audio_route_models.MediaLyrics, error)
+	// PinLyrics 手动固定曲目歌词，之后扫描与在线获取均不覆盖；曲目不存在时返回 domain.ErrNotFound
+	PinLyrics(ctx context.Context, mediaFileId, lyrics string) (*scene_audio_route_models.MediaLyrics, error)
+	// UnpinLyrics 取消手动固定，未固定时返回 domain.ErrNotFound
+	UnpinLyrics(ctx context.Context, mediaFileId string) error
+}
@@ -11,11 +11,11 @@ type MediaFileRepository interface {
 		start, end, sort, order,
 		search, starred,
 		albumId, artistId,
-		year string,
+		year, folderId, available, custom string,
 	) ([]scene_audio_route_models.MediaFileMetadata, error)
 
 	GetMediaFileFilterItemsCount(
 		ctx context.Context,
-		search, starred, albumId, artistId, year string,
+		search, starred, albumId, artistId, year, folderId, available, custom string,
 	) (*scene_audio_route_models.MediaFileFilterCounts, error)
 }
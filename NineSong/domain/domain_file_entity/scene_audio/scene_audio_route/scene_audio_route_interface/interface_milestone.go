@@ -0,0 +1,16 @@
+package scene_audio_route_interface
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+type MilestoneRepository interface {
+	// GetMilestones 按达成时间倒序返回用户的里程碑，milestoneType 为空时返回全部类型
+	GetMilestones(ctx context.Context, userId, milestoneType string) ([]scene_audio_route_models.MilestoneMetadata, error)
+
+	// RefreshMilestones 为 since 之后有播放记录的用户重新计算里程碑，返回本次新达成的里程碑
+	RefreshMilestones(ctx context.Context, since time.Time) ([]scene_audio_route_models.MilestoneMetadata, error)
+}
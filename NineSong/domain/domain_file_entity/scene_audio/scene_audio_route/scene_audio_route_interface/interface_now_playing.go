@@ -0,0 +1,25 @@
+package scene_audio_route_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+type NowPlayingRepository interface {
+	// Report 按用户+客户端覆盖上报状态并顺延过期时间
+	Report(ctx context.Context, entry scene_audio_route_models.NowPlayingMetadata) (*scene_audio_route_models.NowPlayingMetadata, error)
+
+	Clear(ctx context.Context, userId string, client string) error
+
+	// GetNowPlaying 返回所有未过期的记录，附带曲目与用户名
+	GetNowPlaying(ctx context.Context) ([]scene_audio_route_models.NowPlayingMetadata, error)
+}
+
+type NowPlayingUsecase interface {
+	Report(ctx context.Context, userId, client, mediaFileId, state string, position int64) (*scene_audio_route_models.NowPlayingMetadata, error)
+
+	Clear(ctx context.Context, userId string, client string) error
+
+	GetNowPlaying(ctx context.Context) ([]scene_audio_route_models.NowPlayingMetadata, error)
+}
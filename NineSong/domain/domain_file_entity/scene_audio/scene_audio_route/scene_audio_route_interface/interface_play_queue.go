@@ -0,0 +1,14 @@
+package scene_audio_route_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+type PlayQueueRepository interface {
+	// GetPlayQueue client为空时返回该用户最近更新的队列，用于跨设备恢复
+	GetPlayQueue(ctx context.Context, userId string, client string) (*scene_audio_route_models.PlayQueueMetadata, error)
+
+	SavePlayQueue(ctx context.Context, queue scene_audio_route_models.PlayQueueMetadata) (*scene_audio_route_models.PlayQueueMetadata, error)
+}
@@ -9,6 +9,8 @@ import (
 type PlaylistRepository interface {
 	GetPlaylistsAll(
 		ctx context.Context,
+		start string,
+		end string,
 	) ([]scene_audio_route_models.PlaylistMetadata, error)
 
 	GetPlaylist(
@@ -0,0 +1,58 @@
+package scene_audio_route_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+type PodcastRepository interface {
+	GetChannels(ctx context.Context, userId string) ([]scene_audio_route_models.PodcastChannel, error)
+	// AllChannels 返回全部用户的订阅，供后台刷新使用
+	AllChannels(ctx context.Context) ([]scene_audio_route_models.PodcastChannel, error)
+	GetChannel(ctx context.Context, userId, id string) (*scene_audio_route_models.PodcastChannel, error)
+	CountChannels(ctx context.Context, userId string) (int64, error)
+	// CreateChannel 同一用户重复订阅同一订阅源时返回 ErrPodcastExists
+	CreateChannel(ctx context.Context, channel scene_audio_route_models.PodcastChannel) (*scene_audio_route_models.PodcastChannel, error)
+	// SaveRefresh 写入刷新得到的频道信息与刷新结果
+	SaveRefresh(ctx context.Context, channel scene_audio_route_models.PodcastChannel) error
+	UpdateAutoDownload(ctx context.Context, userId, id string, autoDownload int) (*scene_audio_route_models.PodcastChannel, error)
+	// DeleteChannel 同时删除该订阅的全部单集记录
+	DeleteChannel(ctx context.Context, userId, id string) (bool, error)
+
+	// UpsertEpisodes 按 channel_id+guid 新增或更新单集元数据，不覆盖播放进度与下载状态；返回该订阅的单集总数
+	UpsertEpisodes(ctx context.Context, channel scene_audio_route_models.PodcastChannel, episodes []scene_audio_route_models.PodcastEpisode) (int, error)
+	// GetEpisodes 按发布时间倒序返回，同时返回符合条件的总数
+	GetEpisodes(ctx context.Context, userId string, query scene_audio_route_models.PodcastEpisodeQuery) ([]scene_audio_route_models.PodcastEpisode, int, error)
+	GetEpisode(ctx context.Context, userId, id string) (*scene_audio_route_models.PodcastEpisode, error)
+	// LatestEpisodes 返回订阅中发布时间最新的 n 个单集
+	LatestEpisodes(ctx context.Context, channelId string, n int) ([]scene_audio_route_models.PodcastEpisode, error)
+	// DownloadedPaths 返回订阅中已下载单集的本地路径
+	DownloadedPaths(ctx context.Context, channelId string) ([]string, error)
+	UpdateProgress(ctx context.Context, userId, id string, progress scene_audio_route_models.PodcastProgress) (*scene_audio_route_models.PodcastEpisode, error)
+	// ClaimDownload 仅在单集未排队、未下载中且未下载完成时改为排队状态，返回是否成功
+	ClaimDownload(ctx context.Context, id string) (bool, error)
+	// SetDownloadState 单集已随订阅删除时返回 domain.ErrNotFound
+	SetDownloadState(ctx context.Context, id, status, localPath, errMsg string) error
+}
+
+type PodcastUsecase interface {
+	GetChannels(ctx context.Context, userId string) ([]scene_audio_route_models.PodcastChannel, error)
+	GetChannel(ctx context.Context, userId, id string) (*scene_audio_route_models.PodcastChannel, error)
+	// Subscribe 立即抓取订阅源，无法抓取或解析时返回 ErrPodcastFeedUnavailable
+	Subscribe(ctx context.Context, userId, feedURL string, autoDownload int) (*scene_audio_route_models.PodcastChannel, error)
+	UpdateChannel(ctx context.Context, userId, id string, autoDownload int) (*scene_audio_route_models.PodcastChannel, error)
+	// Unsubscribe 删除订阅、单集记录与已下载的文件
+	Unsubscribe(ctx context.Context, userId, id string) (bool, error)
+	RefreshChannel(ctx context.Context, userId, id string) (*scene_audio_route_models.PodcastChannel, error)
+
+	GetEpisodes(ctx context.Context, userId string, query scene_audio_route_models.PodcastEpisodeQuery) ([]scene_audio_route_models.PodcastEpisode, int, error)
+	GetEpisode(ctx context.Context, userId, id string) (*scene_audio_route_models.PodcastEpisode, error)
+	UpdateProgress(ctx context.Context, userId, id string, progress scene_audio_route_models.PodcastProgress) (*scene_audio_route_models.PodcastEpisode, error)
+	// DownloadEpisode 在后台下载单集，未配置下载目录时返回 ErrPodcastDownloadDisabled
+	DownloadEpisode(ctx context.Context, userId, id string) (*scene_audio_route_models.PodcastEpisode, error)
+	DeleteDownload(ctx context.Context, userId, id string) (*scene_audio_route_models.PodcastEpisode, error)
+
+	// Start 启动定时刷新全部订阅的后台协程
+	Start(ctx context.Context)
+}
@@ -0,0 +1,28 @@
+package scene_audio_route_interface
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+type RecommendationRepository interface {
+	GetArtistExternalInfo(ctx context.Context, artistId string) (*scene_audio_route_models.ArtistExternalInfo, error)
+	// GenreNeighbours 曲目流派与该艺术家重合的艺术家，得分为重合流派占比
+	GenreNeighbours(ctx context.Context, artistId string, limit int) ([]scene_audio_route_models.ArtistScore, error)
+	// CoPlayNeighbours 播放过该艺术家的用户中也播放过候选艺术家的比例
+	CoPlayNeighbours(ctx context.Context, artistId string, limit int) ([]scene_audio_route_models.ArtistScore, error)
+	// LastFMNeighbours 与 Last.fm 相似艺术家同名的本地艺术家，未配置 Last.fm 时只使用已保存的结果
+	LastFMNeighbours(ctx context.Context, artist *scene_audio_route_models.ArtistExternalInfo, limit int) ([]scene_audio_route_models.ArtistScore, error)
+	// TopPlayedArtists 用户 since 之后最常播放的艺术家，得分为播放次数
+	TopPlayedArtists(ctx context.Context, userId string, since time.Time, limit int) ([]scene_audio_route_models.ArtistScore, error)
+	GetArtistsByIDs(ctx context.Context, ids []string) ([]scene_audio_route_models.ArtistMetadata, error)
+	// UnplayedAlbumsByArtists 这些艺术家的专辑中用户从未播放过的，按播放次数降序
+	UnplayedAlbumsByArtists(ctx context.Context, userId string, artistIds []string, limit int) ([]scene_audio_route_models.AlbumMetadata, error)
+}
+
+type RecommendationUsecase interface {
+	GetSimilarArtists(ctx context.Context, artistId string, limit int) ([]scene_audio_route_models.SimilarArtist, error)
+	GetRecommendations(ctx context.Context, userId string, limit int) (*scene_audio_route_models.Recommendations, error)
+}
@@ -2,6 +2,8 @@ package scene_audio_route_interface
 
 import (
 	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
 )
 
 type RetrievalRepository interface {
@@ -9,10 +11,18 @@ type RetrievalRepository interface {
 
 	GetStreamTempPath(ctx context.Context, metadataType string) (string, error)
 
+	GetReplayGain(ctx context.Context, mediaFileId string) (*scene_audio_route_models.RetrievalReplayGainMetadata, error)
+
+	GetGaplessInfo(ctx context.Context, mediaFileId string) (*scene_audio_route_models.RetrievalGaplessMetadata, error)
+
+	GetCueSegment(ctx context.Context, mediaFileCueId string, track int) (*scene_audio_route_models.RetrievalCueSegment, error)
+
 	GetDownloadPath(ctx context.Context, mediaFileId string) (string, error)
 
 	GetCoverArtID(ctx context.Context, fileType string, targetID string) (string, error)
 
+	GetCoverArtByID(ctx context.Context, targetID string) (string, error)
+
 	GetLyricsLrcMetaData(ctx context.Context, mediaFileId string) (string, error)
 
 	GetLyricsLrcFile(ctx context.Context, mediaFileId string) (string, error)
@@ -0,0 +1,12 @@
+package scene_audio_route_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+type ScrobbleRepository interface {
+	// Scrobble 一次性更新媒体文件、所属专辑及艺术家的播放统计，并写入播放记录
+	Scrobble(ctx context.Context, history scene_audio_route_models.PlayHistoryMetadata) (*scene_audio_route_models.PlayHistoryMetadata, error)
+}
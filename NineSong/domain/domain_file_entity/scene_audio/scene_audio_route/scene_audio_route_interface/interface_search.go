@@ -0,0 +1,14 @@
+package scene_audio_route_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+type SearchRepository interface {
+	// Search 在歌曲、专辑、艺术家三个集合中全文检索，三个集合均建有 Atlas Search 索引时优先使用 Atlas Search
+	Search(ctx context.Context, query string, paging scene_audio_route_models.SearchPaging) (*scene_audio_route_models.SearchResult, error)
+	// SearchLyrics 在已入库的歌词中检索，只返回调用方可见的曲目；含中日韩文字的检索词按包含匹配，其余走文本索引
+	SearchLyrics(ctx context.Context, query string, offset, count int) (*scene_audio_route_models.LyricsSearchResult, error)
+}
@@ -0,0 +1,48 @@
+package scene_audio_route_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+type SmartPlaylistRepository interface {
+	GetSmartPlaylists(
+		ctx context.Context,
+	) ([]scene_audio_route_models.SmartPlaylistMetadata, error)
+
+	GetSmartPlaylist(
+		ctx context.Context,
+		playlistId string,
+	) (*scene_audio_route_models.SmartPlaylistMetadata, error)
+
+	CreateSmartPlaylist(
+		ctx context.Context,
+		playlist scene_audio_route_models.SmartPlaylistMetadata,
+	) (*scene_audio_route_models.SmartPlaylistMetadata, error)
+
+	UpdateSmartPlaylist(
+		ctx context.Context,
+		playlistId string,
+		playlist scene_audio_route_models.SmartPlaylistMetadata,
+	) (*scene_audio_route_models.SmartPlaylistMetadata, error)
+
+	DeleteSmartPlaylist(
+		ctx context.Context,
+		playlistId string,
+	) (bool, error)
+
+	GetSmartPlaylistTracks(
+		ctx context.Context,
+		playlistId string,
+		start string,
+		end string,
+	) ([]scene_audio_route_models.MediaFileMetadata, error)
+
+	PreviewSmartPlaylist(
+		ctx context.Context,
+		rules scene_audio_route_models.SmartPlaylistRules,
+		start string,
+		end string,
+	) ([]scene_audio_route_models.MediaFileMetadata, error)
+}
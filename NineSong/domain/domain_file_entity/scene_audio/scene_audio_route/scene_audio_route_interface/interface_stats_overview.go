@@ -0,0 +1,17 @@
+package scene_audio_route_interface
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+type StatsOverviewRepository interface {
+	// GetOverview 汇总用户 since 之后的播放记录，since 为零值时统计全部；日期与小时按 loc 划分
+	GetOverview(ctx context.Context, userId string, since time.Time, loc *time.Location) (*scene_audio_route_models.StatsOverviewRaw, error)
+}
+
+type StatsOverviewUsecase interface {
+	GetOverview(ctx context.Context, userId, window string) (*scene_audio_route_models.StatsOverview, error)
+}
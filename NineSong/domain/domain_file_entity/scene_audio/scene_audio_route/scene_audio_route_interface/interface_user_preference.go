@@ -0,0 +1,16 @@
+package scene_audio_route_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+type UserPreferenceRepository interface {
+	GetUserPreference(ctx context.Context, userId string) (*scene_audio_route_models.UserPreferenceMetadata, error)
+
+	UpdateReplayGainMode(ctx context.Context, userId string, mode string) (*scene_audio_route_models.UserPreferenceMetadata, error)
+
+	// UpdateHomeSections 保存用户的首页布局，sections 为空时恢复默认布局
+	UpdateHomeSections(ctx context.Context, userId string, sections []scene_audio_route_models.HomeSectionConfig) (*scene_audio_route_models.UserPreferenceMetadata, error)
+}
@@ -3,6 +3,7 @@ package scene_audio_route_models
 import (
 	"time"
 
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -13,9 +14,13 @@ type AlbumMetadata struct {
 	Artist      string             `bson:"artist"`
 	AlbumArtist string             `bson:"album_artist"`
 	HasCoverArt bool               `bson:"has_cover_art"`
+	ArtVersion  string             `bson:"art_version"` // 封面内容哈希，用作 /coverart/:id?v= 参数
 
 	MinYear       int       `bson:"min_year"`
 	MaxYear       int       `bson:"max_year"`
+	OriginalDate  string    `bson:"original_date"`
+	OriginalYear  int       `bson:"original_year"`
+	Barcode       string    `bson:"barcode"` // UPC/EAN 条码，统一为 13 位 EAN
 	SongCount     int       `bson:"song_count"`
 	Duration      float64   `bson:"duration"`
 	Size          int       `bson:"size"`
@@ -26,6 +31,12 @@ type AlbumMetadata struct {
 	Comment       string    `bson:"comment"`
 	ImageFiles    string    `bson:"image_files"` // 为空则不存在cover封面，从媒体文件中提取
 
+	// 发行版信息，取自标签或 MusicBrainz 补全
+	CatalogNum     string `bson:"catalog_num"`
+	ReleaseCountry string `bson:"release_country"`   // 发行国家，如 JP、XE
+	Edition        string `bson:"mbz_album_comment"` // 发行版附注，如 Japanese edition
+	VersionGroupID string `bson:"version_group_id"`  // 同一专辑的各发行版共享，详情中的 versions 即同组专辑
+
 	Compilation       bool           `bson:"compilation"`          // 是否为合辑（多艺术家作品合集）
 	AllArtistIDs      []ArtistIDPair `bson:"all_artist_ids"`       // 所有参与艺术家的唯一标识符列表
 	AllAlbumArtistIDs []ArtistIDPair `bson:"all_album_artist_ids"` // 所有参与专辑艺术家的唯一标识符列表
@@ -33,15 +44,123 @@ type AlbumMetadata struct {
 	PlayCount         int       `bson:"play_count"`
 	PlayCompleteCount int       `bson:"play_complete_count"`
 	PlayDate          time.Time `bson:"play_date"`
+	FirstPlayDate     time.Time `bson:"first_play_date"`
 	Rating            int       `bson:"rating"`
 	Starred           bool      `bson:"starred"`
 	StarredAt         time.Time `bson:"starred_at"`
+	RatedAt           time.Time `bson:"rated_at"`
+	AverageRating     float64   `bson:"average_rating"` // 已评分曲目的平均分，未评分为 0
+
+	Availability string `bson:"availability"` // 见 scene_audio_db_models.AvailabilityOnline 等，任一曲目可播放即视为可用
+
+	CustomTags map[string]string `bson:"custom_tags"` // CUSTOM_TAGS 配置提取的自定义标签
+
+	Links *ItemLinks `bson:"-" json:",omitempty"` // 仅接口响应时填充
 }
 
 type AlbumFilterCounts struct {
-	Total      int `json:"total"`
-	Starred    int `json:"starred"`
-	RecentPlay int `json:"recent_play"`
+	Total      int               `json:"total"`
+	Starred    int               `json:"starred"`
+	RecentPlay int               `json:"recent_play"`
+	Genres     []AlbumGenreCount `json:"genres,omitempty"` // 仅在请求 genres 时返回
+}
+
+// AlbumGenreCount 当前过滤条件下某流派的专辑数
+type AlbumGenreCount struct {
+	Genre string `bson:"_id" json:"genre"`
+	Count int    `bson:"count" json:"count"`
+}
+
+// AlbumFilterGenresMax 过滤计数中流派细分的最大条数
+const AlbumFilterGenresMax = 50
+
+// AlbumYearCount 某原始发行年份的专辑数
+type AlbumYearCount struct {
+	Year  int `bson:"_id" json:"year"`
+	Count int `bson:"count" json:"count"`
+}
+
+// AlbumDecadeCount 某年代的专辑数，Decade 为年代起始年，可直接作为专辑列表的 decade 参数
+type AlbumDecadeCount struct {
+	Decade int `bson:"_id" json:"decade"`
+	Count  int `bson:"count" json:"count"`
+}
+
+// AlbumYearCounts 时间线浏览数据，按原始发行年份统计（与 decade 过滤一致），年份与年代均升序
+type AlbumYearCounts struct {
+	Years   []AlbumYearCount   `json:"years"`
+	Decades []AlbumDecadeCount `json:"decades"`
+	Unknown int                `json:"unknown"` // 没有发行年份的专辑数
+}
+
+// AlbumDecadeFirst 年代分桶的起始年，更早的年份只出现在 years 中
+const AlbumDecadeFirst = 1000
+
+// 专辑书架，每个书架的条数上限
+const (
+	AlbumShelfDefaultLimit = 10
+	AlbumShelfMaxLimit     = 50
+)
+
+// AlbumShelfLimits 各书架条数，0 表示不返回该书架
+type AlbumShelfLimits struct {
+	RecentlyAdded  int
+	RecentlyPlayed int
+	MostPlayed     int
+	Random         int
+	TopRated       int
+}
+
+// AlbumShelves 首页常用的几组专辑列表，一次查询返回
+type AlbumShelves struct {
+	RecentlyAdded  []AlbumMetadata `bson:"recently_added" json:"recently_added"`
+	RecentlyPlayed []AlbumMetadata `bson:"recently_played" json:"recently_played"`
+	MostPlayed     []AlbumMetadata `bson:"most_played" json:"most_played"`
+	Random         []AlbumMetadata `bson:"random" json:"random"`
+	TopRated       []AlbumMetadata `bson:"top_rated" json:"top_rated"`
+}
+
+// AlbumDisc 专辑中的一张光盘，曲目按音轨号排序
+type AlbumDisc struct {
+	DiscNumber int                 `json:"disc_number"`
+	Subtitle   string              `json:"subtitle,omitempty"`
+	Duration   float64             `json:"duration"`
+	Tracks     []MediaFileMetadata `json:"tracks"`
+}
+
+// AlbumTracks 专辑详情：专辑信息与按光盘分组的曲目
+type AlbumTracks struct {
+	Album AlbumMetadata `json:"album"`
+	Discs []AlbumDisc   `json:"discs"`
+}
+
+// AlbumDetailSimilarLimit 专辑详情中相似专辑的数量
+const AlbumDetailSimilarLimit = 12
+
+// AlbumUserAnnotation 专辑注解（收藏、评分、总播放）与当前用户自己的播放统计
+type AlbumUserAnnotation struct {
+	Starred        bool       `bson:"starred" json:"starred"`
+	StarredAt      time.Time  `bson:"starred_at" json:"starred_at"`
+	Rating         int        `bson:"rating" json:"rating"`
+	RatedAt        time.Time  `bson:"rated_at" json:"rated_at"`
+	PlayCount      int        `bson:"play_count" json:"play_count"`
+	PlayDate       time.Time  `bson:"play_date" json:"play_date"`
+	UserPlayCount  int        `bson:"user_play_count" json:"user_play_count"`
+	UserLastPlayed *time.Time `bson:"user_last_played_at" json:"user_last_played_at,omitempty"`
+}
+
+// AlbumDetail 专辑页所需的全部数据：专辑、按光盘分组的曲目、参与艺术家、统计、注解与相似专辑
+type AlbumDetail struct {
+	Album         AlbumMetadata                                   `json:"album"`
+	Discs         []AlbumDisc                                     `json:"discs"`
+	Artists       []ArtistMetadata                                `json:"artists"`
+	TrackCount    int                                             `json:"track_count"`
+	Duration      float64                                         `json:"duration"`
+	Size          int                                             `json:"size"`
+	Annotation    AlbumUserAnnotation                             `json:"annotation"`
+	SimilarAlbums []AlbumMetadata                                 `json:"similar_albums"`
+	Versions      []AlbumMetadata                                 `json:"versions"` // 同一版本分组的其他发行版
+	Attachments   []scene_audio_db_models.AlbumAttachmentMetadata `json:"attachments"`
 }
 
 type AlbumListResponse struct {
@@ -0,0 +1,18 @@
+package scene_audio_route_models
+
+import (
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_musicbrainz/scene_audio_musicbrainz_models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AlbumCompletenessMetadata 本地专辑与 MusicBrainz 发行版的曲目比对结果
+type AlbumCompletenessMetadata struct {
+	AlbumID           primitive.ObjectID `bson:"_id"`
+	Name              string             `bson:"name"`
+	Artist            string             `bson:"artist"`
+	MBZAlbumID        string             `bson:"mbz_album_id"`
+	LocalTrackCount   int                `bson:"local_track_count"`
+	ReleaseTrackCount int                `bson:"release_track_count"`
+
+	MissingTracks []scene_audio_musicbrainz_models.MusicBrainzTrack `bson:"missing_tracks"`
+}
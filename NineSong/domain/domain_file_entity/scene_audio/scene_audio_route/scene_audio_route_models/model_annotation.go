@@ -1,10 +1,39 @@
 package scene_audio_route_models
 
 import (
+	"errors"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"time"
 )
 
+var ErrAnnotationItemNotFound = errors.New("annotation item not found")
+
+// MediaFileRating 曲目评分后的注解及重新计算的专辑、艺术家平均分
+type MediaFileRating struct {
+	Annotation          *AnnotationMetadata
+	AlbumID             string
+	AlbumAverageRating  float64
+	ArtistID            string
+	ArtistAverageRating float64
+}
+
+// AnnotationEvent 收藏或评分变更后发布到事件总线的载荷，未变更的字段为空
+type AnnotationEvent struct {
+	ItemID   string `json:"item_id"`
+	ItemType string `json:"item_type"`
+	Starred  *bool  `json:"starred,omitempty"`
+	Rating   *int   `json:"rating,omitempty"`
+}
+
+// AnnotationUpdate 通用注解写入，nil 字段保持不变；Played 为 true 时累加播放次数并更新播放时间，
+// Complete 同时累加完整播放次数
+type AnnotationUpdate struct {
+	Starred  *bool
+	Rating   *int
+	Played   bool
+	Complete bool
+}
+
 type AnnotationMetadata struct {
 	ID                primitive.ObjectID `bson:"_id"`        // 文档唯一标识符
 	UserID            string             `bson:"user_id"`    // 用户唯一标识符，标识创建此注释的用户
@@ -12,11 +41,13 @@ type AnnotationMetadata struct {
 	ItemType          string             `bson:"item_type"`  // 媒体项目类型（如音乐、视频、图片等）
 	PlayCount         int                `bson:"play_count"` // 播放次数，记录该媒体项目被播放的次数
 	PlayCompleteCount int                `bson:"play_complete_count"`
-	PlayDate          time.Time          `bson:"play_date"`  // 播放日期，最近一次播放此媒体项目的日期和时间
-	Rating            int                `bson:"rating"`     // 评分，用户对此媒体项目的评分（如1-5分）
-	Starred           bool               `bson:"starred"`    // 是否收藏，标识该媒体项目是否被用户收藏
-	StarredAt         time.Time          `bson:"starred_at"` // 收藏时间，媒体项目被收藏的日期和时间
-	UpdatedAt         time.Time          `bson:"updated_at"` // 词云最后更新时间
+	PlayDate          time.Time          `bson:"play_date"`       // 播放日期，最近一次播放此媒体项目的日期和时间
+	FirstPlayDate     time.Time          `bson:"first_play_date"` // 首次播放时间，首次记录播放时写入
+	Rating            int                `bson:"rating"`          // 评分，用户对此媒体项目的评分（如1-5分）
+	RatedAt           time.Time          `bson:"rated_at"`        // 评分时间，由服务端在写入评分时记录
+	Starred           bool               `bson:"starred"`         // 是否收藏，标识该媒体项目是否被用户收藏
+	StarredAt         time.Time          `bson:"starred_at"`      // 收藏时间，媒体项目被收藏的日期和时间
+	UpdatedAt         time.Time          `bson:"updated_at"`      // 词云最后更新时间
 
 	WordCloudTags []TagSource   `bson:"word_cloud_tags"` // 标签及来源
 	WeightedTags  []WeightedTag `bson:"weighted_tags"`   // 带权重的标签（用于推荐）
@@ -0,0 +1,95 @@
+package scene_audio_route_models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+)
+
+// AnnotationItemType 注解文档中的 item_type
+type AnnotationItemType string
+
+const (
+	AnnotationItemMedia    AnnotationItemType = "media"
+	AnnotationItemMediaCue AnnotationItemType = "media_cue"
+	AnnotationItemAlbum    AnnotationItemType = "album"
+	AnnotationItemArtist   AnnotationItemType = "artist"
+	AnnotationItemPlaylist AnnotationItemType = "playlist"
+)
+
+// builtinAnnotationItemTypes 内置注解条目类型及其条目集合，ANNOTATION_ITEM_TYPES 在此基础上扩展
+var builtinAnnotationItemTypes = map[AnnotationItemType]string{
+	AnnotationItemMedia:    domain.CollectionFileEntityAudioSceneMediaFile,
+	AnnotationItemMediaCue: domain.CollectionFileEntityAudioSceneMediaFileCue,
+	AnnotationItemAlbum:    domain.CollectionFileEntityAudioSceneAlbum,
+	AnnotationItemArtist:   domain.CollectionFileEntityAudioSceneArtist,
+	AnnotationItemPlaylist: domain.CollectionFileEntityAudioScenePlaylist,
+}
+
+var annotationItemTypePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+var (
+	annotationItemTypesMu sync.RWMutex
+	annotationItemTypes   = cloneAnnotationItemTypes(builtinAnnotationItemTypes)
+)
+
+func cloneAnnotationItemTypes(types map[AnnotationItemType]string) map[AnnotationItemType]string {
+	c := make(map[AnnotationItemType]string, len(types))
+	for itemType, collection := range types {
+		c[itemType] = collection
+	}
+	return c
+}
+
+// ConfigureAnnotationItemTypes 在内置类型上追加注解条目类型，value 形如 "podcast=file_entity_audio_scene_podcast"；
+// 登记后即可收藏、评分与记录播放，条目文档上同步冗余注解字段。配置无效时返回错误且保持原配置
+func ConfigureAnnotationItemTypes(value string) error {
+	types := cloneAnnotationItemTypes(builtinAnnotationItemTypes)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		name, collection, ok := strings.Cut(item, "=")
+		itemType := AnnotationItemType(strings.ToLower(strings.TrimSpace(name)))
+		collection = strings.TrimSpace(collection)
+		if !ok || collection == "" || !annotationItemTypePattern.MatchString(string(itemType)) {
+			return fmt.Errorf("invalid annotation item type: %s", item)
+		}
+		if _, exists := types[itemType]; exists {
+			return fmt.Errorf("annotation item type already registered: %s", item)
+		}
+		types[itemType] = collection
+	}
+
+	annotationItemTypesMu.Lock()
+	defer annotationItemTypesMu.Unlock()
+	annotationItemTypes = types
+	return nil
+}
+
+// ParseAnnotationItemType 校验请求中的 item_type，未登记的类型返回错误
+func ParseAnnotationItemType(value string) (AnnotationItemType, error) {
+	itemType := AnnotationItemType(value)
+	if _, ok := itemType.Collection(); !ok {
+		return "", fmt.Errorf("invalid item_type: %s", value)
+	}
+	return itemType, nil
+}
+
+// Collection 返回条目类型对应的集合，未登记时返回 false
+func (t AnnotationItemType) Collection() (string, bool) {
+	annotationItemTypesMu.RLock()
+	defer annotationItemTypesMu.RUnlock()
+	collection, ok := annotationItemTypes[t]
+	return collection, ok
+}
+
+// AnnotationItemTypes 返回当前登记的全部注解条目类型及其集合
+func AnnotationItemTypes() map[AnnotationItemType]string {
+	annotationItemTypesMu.RLock()
+	defer annotationItemTypesMu.RUnlock()
+	return cloneAnnotationItemTypes(annotationItemTypes)
+}
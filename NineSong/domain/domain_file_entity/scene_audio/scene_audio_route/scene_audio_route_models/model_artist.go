@@ -1,6 +1,7 @@
 package scene_audio_route_models
 
 import (
+	"errors"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"time"
 )
@@ -23,21 +24,75 @@ type ArtistMetadata struct {
 
 	ImageFiles string `bson:"image_files"` // 为空则不存在cover封面，从媒体文件中提取
 
+	Aliases []ArtistAlias `bson:"aliases"` // 别名，搜索时与名称一同匹配
+
 	PlayCount         int       `bson:"play_count"`
 	PlayCompleteCount int       `bson:"play_complete_count"`
 	PlayDate          time.Time `bson:"play_date"`
 	Rating            int       `bson:"rating"`
 	Starred           bool      `bson:"starred"`
 	StarredAt         time.Time `bson:"starred_at"`
+	RatedAt           time.Time `bson:"rated_at"`
+	AverageRating     float64   `bson:"average_rating"` // 已评分曲目的平均分，未评分为 0
+}
+
+// 艺术家页各部分的默认与最大数量
+const (
+	ArtistPageDefaultAlbums   = 50
+	ArtistPageMaxAlbums       = 200
+	ArtistPageDefaultTopSongs = 10
+	ArtistPageMaxTopSongs     = 50
+)
+
+// ArtistPageLimits 为 0 时取默认值，相似艺术家沿用推荐接口的默认值与上限
+type ArtistPageLimits struct {
+	Albums   int
+	TopSongs int
+	Similar  int
+}
+
+// ArtistPage GET /artists/:id/full 的聚合结果，专辑按最近发行年份降序，热门歌曲按播放次数降序
+type ArtistPage struct {
+	Artist         ArtistMetadata      `json:"artist"`
+	Albums         []AlbumMetadata     `json:"albums"`
+	TopSongs       []MediaFileMetadata `json:"top_songs"`
+	SimilarArtists []SimilarArtist     `json:"similar_artists"`
 }
 
 type ArtistFilterCounts struct {
 	Total      int `json:"total"`
 	Starred    int `json:"starred"`
 	RecentPlay int `json:"recent_play"`
+	Albums     int `json:"albums"` // 过滤后艺术家名下的专辑总数
+	Songs      int `json:"songs"`  // 过滤后艺术家名下的单曲总数
 }
 
 type ArtistListResponse struct {
 	Artists []ArtistMetadata `json:"artists"`
 	Count   int              `json:"count"`
 }
+
+const (
+	ArtistAliasSourceManual      = "manual"
+	ArtistAliasSourceMusicBrainz = "musicbrainz"
+	ArtistAliasSourceMerge       = "merge"
+)
+
+var ErrArtistAliasConflict = errors.New("alias already belongs to another artist")
+
+// ArtistAlias 艺术家别名
+type ArtistAlias struct {
+	Name   string `bson:"name" json:"name"`
+	Source string `bson:"source" json:"source"`
+}
+
+// ArtistMergeResult 艺术家合并结果，统计被重新指向目标艺术家的文档数
+type ArtistMergeResult struct {
+	SourceID          string        `bson:"source_id"`
+	TargetID          string        `bson:"target_id"`
+	AlbumsUpdated     int64         `bson:"albums_updated"`
+	MediaFilesUpdated int64         `bson:"media_files_updated"`
+	CueFilesUpdated   int64         `bson:"cue_files_updated"`
+	AnnotationMerged  bool          `bson:"annotation_merged"`
+	Aliases           []ArtistAlias `bson:"aliases"`
+}
@@ -0,0 +1,20 @@
+package scene_audio_route_models
+
+import "strings"
+
+// ArtistFilterMaxIDs 列表接口 artist_id 参数一次最多指定的艺术家数量
+const ArtistFilterMaxIDs = 50
+
+// ParseArtistIDs 解析逗号分隔的艺术家ID，去除空白与重复项
+func ParseArtistIDs(value string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, id := range strings.Split(value, ",") {
+		if id = strings.TrimSpace(id); id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids
+}
@@ -0,0 +1,50 @@
+package scene_audio_route_models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// AudiobookSortReading 默认排序：按作者、年份、书名排列，同一作者的系列作品按出版顺序相邻
+	AudiobookSortReading = "reading"
+	// AudiobookSortRecent 按最近收听时间倒序，只返回有进度的书
+	AudiobookSortRecent = "recent"
+	// AudiobookFinishThreshold 最后一个文件剩余不足该秒数时视为听完
+	AudiobookFinishThreshold = 30
+)
+
+var (
+	ErrInvalidAudiobook       = errors.New("invalid audiobook")
+	ErrAudiobookTrackMismatch = errors.New("media file does not belong to the audiobook")
+)
+
+// Audiobook 有声书库中的一张专辑即一本书，ID 为专辑ID
+type Audiobook struct {
+	ID           string  `bson:"_id" json:"id"`
+	Title        string  `bson:"title" json:"title"`
+	Author       string  `bson:"author" json:"author"` // 专辑艺术家
+	AuthorID     string  `bson:"author_id" json:"author_id"`
+	Year         int     `bson:"year" json:"year"`
+	HasCoverArt  bool    `bson:"has_cover_art" json:"has_cover_art"`
+	TrackCount   int     `bson:"track_count" json:"track_count"`
+	ChapterCount int     `bson:"chapter_count" json:"chapter_count"` // 文件内嵌章节总数，文件无章节时按一章计
+	Duration     float64 `bson:"duration" json:"duration"`           // 全书总秒数
+
+	Progress *AudiobookProgress `bson:"-" json:"progress,omitempty"`
+	// Tracks 仅详情返回，按碟号、音轨号、文件路径排列
+	Tracks []MediaFileMetadata `bson:"-" json:"tracks,omitempty"`
+}
+
+// AudiobookProgress 每个用户每本书一条收听进度，Position 为 MediaFileID 对应文件内的秒数
+type AudiobookProgress struct {
+	ID          primitive.ObjectID `bson:"_id" json:"-"`
+	UserID      string             `bson:"user_id" json:"-"`
+	BookID      string             `bson:"book_id" json:"book_id"`
+	MediaFileID string             `bson:"media_file_id" json:"media_file_id"`
+	Position    float64            `bson:"position" json:"position"`
+	Finished    bool               `bson:"finished" json:"finished"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+}
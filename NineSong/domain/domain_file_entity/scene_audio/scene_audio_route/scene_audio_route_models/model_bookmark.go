@@ -0,0 +1,35 @@
+package scene_audio_route_models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 可添加书签的条目类型
+const (
+	BookmarkItemMedia          = "media"
+	BookmarkItemPodcastEpisode = "podcast_episode"
+)
+
+// BookmarkCommentMaxLength 书签备注的最大字符数
+const BookmarkCommentMaxLength = 500
+
+var ErrInvalidBookmark = errors.New("invalid bookmark")
+
+// Bookmark 每个用户每个条目一条书签，Position 为条目内的秒数，任一客户端可据此续播
+type Bookmark struct {
+	ID        primitive.ObjectID `bson:"_id" json:"id"`
+	UserID    string             `bson:"user_id" json:"-"`
+	ItemID    string             `bson:"item_id" json:"item_id"`
+	ItemType  string             `bson:"item_type" json:"item_type"`
+	Position  float64            `bson:"position" json:"position"`
+	Comment   string             `bson:"comment" json:"comment"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+
+	// 条目标题与时长，查询时取自曲目或播客单集
+	Title    string  `bson:"title,omitempty" json:"title"`
+	Duration float64 `bson:"duration,omitempty" json:"duration"`
+}
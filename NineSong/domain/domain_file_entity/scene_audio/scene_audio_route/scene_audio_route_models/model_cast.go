@@ -0,0 +1,51 @@
+package scene_audio_route_models
+
+import "errors"
+
+const (
+	// CastDiscoveryTimeout 单次 mDNS 搜索等待设备应答的时间（秒）
+	CastDiscoveryTimeout = 2
+	// CastDeviceCacheTTL 设备列表缓存时间（秒），过期或传 refresh 时重新搜索
+	CastDeviceCacheTTL = 60
+	// CastStreamTokenExpiryHour 投屏地址中临时访问令牌的有效期
+	CastStreamTokenExpiryHour = 12
+)
+
+var (
+	ErrInvalidCastParams  = errors.New("invalid cast parameters")
+	ErrCastDeviceNotFound = errors.New("cast device not found")
+	ErrCastNoSession      = errors.New("no active cast session on device")
+)
+
+// CastDevice 局域网内发现的 Chromecast 设备
+type CastDevice struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Model   string `json:"model"`
+	Host    string `json:"host"`
+	Port    int    `json:"port"`
+	Session bool   `json:"session"` // 是否已建立投屏会话
+}
+
+// CastLoadOptions 载入时的转码参数，Format 为空且设备支持原格式时直接推送原文件
+type CastLoadOptions struct {
+	Format     string
+	MaxBitRate int
+	Position   float64 // 起始秒数
+}
+
+// CastStatus 设备上的投屏会话状态；Position、Duration 单位为秒，Volume 取值 0-100
+type CastStatus struct {
+	DeviceID    string  `json:"device_id"`
+	DeviceName  string  `json:"device_name"`
+	MediaFileID string  `json:"media_file_id,omitempty"`
+	Title       string  `json:"title,omitempty"`
+	Artist      string  `json:"artist,omitempty"`
+	PlayerState string  `json:"player_state"` // IDLE | PLAYING | PAUSED | BUFFERING
+	IdleReason  string  `json:"idle_reason,omitempty"`
+	Position    float64 `json:"position"`
+	Duration    float64 `json:"duration"`
+	Volume      int     `json:"volume"`
+	Muted       bool    `json:"muted"`
+	Transcoded  bool    `json:"transcoded"`
+}
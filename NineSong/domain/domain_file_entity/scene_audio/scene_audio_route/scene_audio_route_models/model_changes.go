@@ -0,0 +1,59 @@
+package scene_audio_route_models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// DeletionLogRetention 删除日志保留时长，早于此时长的同步令牌无法保证删除完整，需要全量同步
+	DeletionLogRetention = 30 * 24 * time.Hour
+	// ChangesClockSkew 查询下界向前重叠的时长，避免令牌生成时仍在写入的文档被漏掉
+	ChangesClockSkew = 5 * time.Second
+	// ChangesPageSize 单次响应最多包含的变更ID数（新增、更新与删除合计），超出时通过续页令牌继续
+	ChangesPageSize = 5000
+)
+
+var ErrInvalidSyncToken = errors.New("invalid sync token")
+
+// ChangeFeedTypes 变更流覆盖的实体类型，与删除日志的 item_type 一致
+var ChangeFeedTypes = []string{"album", "artist", "media", "playlist"}
+
+// DeletionRecord 删除日志中的一条记录，超过 DeletionLogRetention 后由 TTL 索引清理
+type DeletionRecord struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	ItemType  string             `bson:"item_type"`
+	ItemID    string             `bson:"item_id"`
+	DeletedAt time.Time          `bson:"deleted_at"`
+}
+
+// ChangedItem 变更查询结果，CreatedAt 用于区分新增与更新
+type ChangedItem struct {
+	ID        primitive.ObjectID `bson:"_id"`
+	CreatedAt time.Time          `bson:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at"`
+}
+
+// ChangeCursor 分页位置，按 (时间, _id) 升序，下一页从其后开始
+type ChangeCursor struct {
+	Time time.Time
+	ID   primitive.ObjectID
+}
+
+// EntityChanges 单个实体类型自令牌以来的变更ID
+type EntityChanges struct {
+	Created []string `json:"created"`
+	Updated []string `json:"updated"`
+	Deleted []string `json:"deleted"`
+}
+
+// ChangeFeed 增量同步结果；FullSync 为 true 时客户端应丢弃本地缓存重新下载，Changes 为空；
+// HasMore 为 true 时本页被截断，客户端应立即以 Token 继续请求，直到 HasMore 为 false
+type ChangeFeed struct {
+	Token    string                   `json:"token"` // 下次请求使用的 since
+	FullSync bool                     `json:"full_sync"`
+	HasMore  bool                     `json:"has_more"`
+	Changes  map[string]EntityChanges `json:"changes"` // 键为实体类型
+}
@@ -0,0 +1,64 @@
+package scene_audio_route_models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// 排行榜统计窗口
+const (
+	ChartWindowWeek    = "7d"
+	ChartWindowMonth   = "30d"
+	ChartWindowYear    = "365d"
+	ChartWindowAllTime = "all"
+)
+
+const (
+	ChartDefaultWindow = ChartWindowMonth
+	ChartDefaultLimit  = 50
+	ChartMaxLimit      = 100
+)
+
+var ErrInvalidChartWindow = errors.New("invalid chart window")
+
+var chartWindows = map[string]time.Duration{
+	ChartWindowWeek:    7 * 24 * time.Hour,
+	ChartWindowMonth:   30 * 24 * time.Hour,
+	ChartWindowYear:    365 * 24 * time.Hour,
+	ChartWindowAllTime: 0,
+}
+
+// ChartSince 返回窗口的起始时间，全部时间返回零值
+func ChartSince(window string, now time.Time) (time.Time, error) {
+	d, ok := chartWindows[window]
+	if !ok {
+		return time.Time{}, fmt.Errorf("%w: %s, must be one of 7d/30d/365d/all", ErrInvalidChartWindow, window)
+	}
+	if d == 0 {
+		return time.Time{}, nil
+	}
+	return now.Add(-d), nil
+}
+
+// ChartStats 窗口内的播放次数与收听人数（去重用户）
+type ChartStats struct {
+	Rank      int `bson:"-" json:"rank"`
+	Plays     int `bson:"plays" json:"plays"`
+	Listeners int `bson:"listeners" json:"listeners"`
+}
+
+type ChartMediaFile struct {
+	ChartStats `bson:",inline"`
+	MediaFile  MediaFileMetadata `bson:"item" json:"media_file"`
+}
+
+type ChartAlbum struct {
+	ChartStats `bson:",inline"`
+	Album      AlbumMetadata `bson:"item" json:"album"`
+}
+
+type ChartArtist struct {
+	ChartStats `bson:",inline"`
+	Artist     ArtistMetadata `bson:"item" json:"artist"`
+}
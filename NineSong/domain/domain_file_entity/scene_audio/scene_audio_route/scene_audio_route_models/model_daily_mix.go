@@ -0,0 +1,45 @@
+package scene_audio_route_models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 每日推荐歌单类型，同时写入系统播放列表的 system 字段
+const (
+	DailyMixTypeGenre        = "genre"         // 用户常听流派
+	DailyMixTypeRediscover   = "rediscover"    // 常听或已收藏、但很久未播放的曲目
+	DailyMixTypeNewAdditions = "new_additions" // 最近入库且用户未播放过的曲目
+)
+
+const (
+	DailyMixTracks = 30
+	DailyMixGenres = 3
+	// DailyMixWindow 常听流派与活跃用户只参考近期的播放历史
+	DailyMixWindow = 90 * 24 * time.Hour
+	// DailyMixRediscoverMonths 超过该月数未播放才视为被遗忘
+	DailyMixRediscoverMonths = 3
+	// DailyMixNewAdditionsWindow 入库时间在此范围内的曲目计入新入库歌单
+	DailyMixNewAdditionsWindow = 30 * 24 * time.Hour
+)
+
+// DailyMixDraft 生成阶段的歌单，MediaFileIDs 按播放顺序排列
+type DailyMixDraft struct {
+	Type         string
+	Name         string
+	Comment      string
+	MediaFileIDs []primitive.ObjectID
+}
+
+// DailyMix 已落库的每日推荐歌单及其曲目，ID 为对应系统播放列表的ID
+type DailyMix struct {
+	ID          primitive.ObjectID  `json:"id"`
+	Type        string              `json:"type"`
+	Name        string              `json:"name"`
+	Comment     string              `json:"comment"`
+	SongCount   int                 `json:"song_count"`
+	Duration    float64             `json:"duration"`
+	GeneratedAt time.Time           `json:"generated_at"`
+	MediaFiles  []MediaFileMetadata `json:"media_files"`
+}
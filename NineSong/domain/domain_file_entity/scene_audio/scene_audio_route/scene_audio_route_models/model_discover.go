@@ -0,0 +1,31 @@
+package scene_audio_route_models
+
+import "time"
+
+// 被遗忘的收藏：常听或已收藏、但最近 months 个月未播放的曲目与专辑
+const (
+	ForgottenDefaultMonths   = 6
+	ForgottenMaxMonths       = 120
+	ForgottenDefaultMinPlays = 5
+	ForgottenDefaultLimit    = 20
+	ForgottenMaxLimit        = 100
+)
+
+// ForgottenMediaFile 曲目及当前用户的收听记录，LastPlayedAt 为空表示已收藏但从未播放
+type ForgottenMediaFile struct {
+	MediaFileMetadata `bson:",inline"`
+	UserPlayCount     int        `bson:"user_play_count" json:"user_play_count"`
+	LastPlayedAt      *time.Time `bson:"last_played_at" json:"last_played_at"`
+}
+
+// ForgottenAlbum 专辑及当前用户的收听记录
+type ForgottenAlbum struct {
+	AlbumMetadata `bson:",inline"`
+	UserPlayCount int        `bson:"user_play_count" json:"user_play_count"`
+	LastPlayedAt  *time.Time `bson:"last_played_at" json:"last_played_at"`
+}
+
+type ForgottenResult struct {
+	MediaFiles []ForgottenMediaFile `json:"media_files"`
+	Albums     []ForgottenAlbum     `json:"albums"`
+}
@@ -0,0 +1,61 @@
+package scene_audio_route_models
+
+import "errors"
+
+// DLNA 内容目录的对象ID：根目录为 "0"，其下为专辑、艺术家与全部单曲三个固定容器，
+// 专辑、艺术家与单曲以“前缀 + ObjectID”表示
+const (
+	DLNARootID       = "0"
+	DLNAAlbumsID     = "albums"
+	DLNAArtistsID    = "artists"
+	DLNATracksID     = "tracks"
+	DLNAAlbumPrefix  = "album/"
+	DLNAArtistPrefix = "artist/"
+	DLNATrackPrefix  = "track/"
+)
+
+// upnp:class 取值
+const (
+	DLNAClassFolder = "object.container.storageFolder"
+	DLNAClassAlbum  = "object.container.album.musicAlbum"
+	DLNAClassArtist = "object.container.person.musicArtist"
+	DLNAClassTrack  = "object.item.audioItem.musicTrack"
+)
+
+// DLNAMaxBrowseCount 单次 Browse 最多返回的对象数，请求数量为 0 时同样按此上限返回
+const DLNAMaxBrowseCount = 500
+
+var (
+	ErrDLNANoSuchObject = errors.New("no such object")
+	ErrDLNAInvalidArgs  = errors.New("invalid args")
+)
+
+// DLNAObject Browse 结果中的容器或条目，由控制器渲染为 DIDL-Lite
+type DLNAObject struct {
+	ID          string
+	ParentID    string
+	Title       string
+	Class       string
+	Container   bool
+	ChildCount  int
+	Artist      string
+	AlbumArtist string
+	Album       string
+	Genre       string
+	Year        int
+	TrackNumber int
+	Duration    float64 // 秒
+	Size        int
+	BitRate     int // kbps
+	Channels    int
+	Suffix      string
+	MediaFileID string // 条目对应的单曲，用于生成流地址
+	CoverID     string // 有封面时为专辑ID，用于生成封面地址
+}
+
+// DLNABrowseResult TotalMatches 为分页前的对象总数
+type DLNABrowseResult struct {
+	Objects      []DLNAObject
+	TotalMatches int
+	UpdateID     uint32
+}
@@ -0,0 +1,18 @@
+package scene_audio_route_models
+
+import "errors"
+
+// ErrInvalidDownloadID 专辑、播放列表或曲目ID不是合法的 ObjectID
+var ErrInvalidDownloadID = errors.New("invalid download id")
+
+// DownloadEntry 打包下载中的单个文件，Name 为压缩包内的相对路径
+type DownloadEntry struct {
+	Path string
+	Name string
+}
+
+// DownloadArchive 专辑或播放列表的打包内容，Name 为不含扩展名的压缩包文件名
+type DownloadArchive struct {
+	Name    string
+	Entries []DownloadEntry
+}
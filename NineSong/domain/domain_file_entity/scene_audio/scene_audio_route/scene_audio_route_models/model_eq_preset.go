@@ -0,0 +1,24 @@
+package scene_audio_route_models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// EqBand 单个均衡器频段（对应ffmpeg equalizer滤镜参数）
+type EqBand struct {
+	Frequency float64 `bson:"frequency" json:"frequency"` // 中心频率(Hz)
+	Width     float64 `bson:"width" json:"width"`         // 带宽(Q值)
+	Gain      float64 `bson:"gain" json:"gain"`           // 增益(dB)
+}
+
+type EqPresetMetadata struct {
+	ID        primitive.ObjectID `bson:"_id"`
+	UserID    string             `bson:"user_id"`
+	Name      string             `bson:"name"`
+	Bands     []EqBand           `bson:"bands"`
+	PreAmp    float64            `bson:"pre_amp"`
+	CreatedAt time.Time          `bson:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at"`
+}
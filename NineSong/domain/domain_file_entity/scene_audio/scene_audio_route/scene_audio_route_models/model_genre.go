@@ -0,0 +1,32 @@
+package scene_audio_route_models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrGenreCycle = errors.New("genre parent would create a cycle")
+
+// GenreMetadata 流派层级节点，例如 Rock -> Indie Rock -> Shoegaze
+type GenreMetadata struct {
+	ID             primitive.ObjectID `bson:"_id"`
+	Name           string             `bson:"name"`            // 与媒体文件 genre 字段匹配（忽略大小写）
+	Parent         string             `bson:"parent"`          // 父流派名称，为空表示顶层
+	LocalizedNames map[string]string  `bson:"localized_names"` // locale -> 显示名称
+	CreatedAt      time.Time          `bson:"created_at"`
+	UpdatedAt      time.Time          `bson:"updated_at"`
+}
+
+// GenreNode 流派浏览树节点，Total* 为包含全部子流派的汇总数
+type GenreNode struct {
+	Name            string      `json:"name"`
+	DisplayName     string      `json:"display_name"`
+	Parent          string      `json:"parent"`
+	SongCount       int         `json:"song_count"`
+	AlbumCount      int         `json:"album_count"`
+	TotalSongCount  int         `json:"total_song_count"`
+	TotalAlbumCount int         `json:"total_album_count"`
+	Children        []GenreNode `json:"children"`
+}
@@ -0,0 +1,116 @@
+package scene_audio_route_models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 首页分区
+const (
+	HomeSectionRecentlyAdded     = "recently_added"     // 最近添加的专辑
+	HomeSectionContinueListening = "continue_listening" // 最近播放过的专辑
+	HomeSectionDailyMixes        = "daily_mixes"        // 按常听艺术家生成的每日歌单，limit 为歌单数量
+	HomeSectionFavorites         = "favorites"          // 最近收藏的曲目
+	HomeSectionOnThisDay         = "on_this_day"        // 往年今日播放过的曲目
+)
+
+const (
+	HomeSectionMaxLimit     = 50
+	HomeDailyMixTracks      = 25
+	homeSectionDefaultLimit = 12
+)
+
+// homeSectionLimits 各分区未指定数量时的默认值
+var homeSectionLimits = map[string]int{
+	HomeSectionRecentlyAdded:     homeSectionDefaultLimit,
+	HomeSectionContinueListening: homeSectionDefaultLimit,
+	HomeSectionDailyMixes:        4,
+	HomeSectionFavorites:         homeSectionDefaultLimit,
+	HomeSectionOnThisDay:         homeSectionDefaultLimit,
+}
+
+// DefaultHomeSections HOME_SECTIONS 未配置时的首页布局
+const DefaultHomeSections = "recently_added,continue_listening,daily_mixes,favorites,on_this_day"
+
+// HomeSectionConfig 首页分区及其条目数量
+type HomeSectionConfig struct {
+	ID    string `bson:"id" json:"id"`
+	Limit int    `bson:"limit" json:"limit"`
+}
+
+// HomeSection 首页单个分区的内容，按分区类型填充其中一个列表
+type HomeSection struct {
+	ID         string              `json:"id"`
+	Albums     []AlbumMetadata     `json:"albums,omitempty"`
+	MediaFiles []MediaFileMetadata `json:"media_files,omitempty"`
+	Mixes      []HomeMix           `json:"mixes,omitempty"`
+}
+
+// HomeMix 以一位常听艺术家为种子的每日歌单
+type HomeMix struct {
+	ArtistID   string              `json:"artist_id"`
+	ArtistName string              `json:"artist_name"`
+	MediaFiles []MediaFileMetadata `json:"media_files"`
+}
+
+// ParseHomeSections 解析形如 "recently_added:12,favorites" 的分区列表，未写数量时取默认值，重复分区只保留第一个
+func ParseHomeSections(value string) ([]HomeSectionConfig, error) {
+	var sections []HomeSectionConfig
+	seen := make(map[string]bool)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		id, limitStr, hasLimit := strings.Cut(item, ":")
+		section := HomeSectionConfig{ID: id}
+		if hasLimit {
+			n, err := strconv.Atoi(strings.TrimSpace(limitStr))
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("home section limit must be between 1-%d: %s", HomeSectionMaxLimit, item)
+			}
+			section.Limit = n
+		}
+		if err := section.normalize(); err != nil {
+			return nil, err
+		}
+		if seen[section.ID] {
+			continue
+		}
+		seen[section.ID] = true
+		sections = append(sections, section)
+	}
+	return sections, nil
+}
+
+// NormalizeHomeSections 校验用户提交的首页布局，limit 为 0 时取默认值，分区不允许重复
+func NormalizeHomeSections(sections []HomeSectionConfig) ([]HomeSectionConfig, error) {
+	normalized := make([]HomeSectionConfig, 0, len(sections))
+	seen := make(map[string]bool, len(sections))
+	for _, section := range sections {
+		if err := section.normalize(); err != nil {
+			return nil, err
+		}
+		if seen[section.ID] {
+			return nil, fmt.Errorf("duplicate home section: %s", section.ID)
+		}
+		seen[section.ID] = true
+		normalized = append(normalized, section)
+	}
+	return normalized, nil
+}
+
+func (s *HomeSectionConfig) normalize() error {
+	s.ID = strings.ToLower(strings.TrimSpace(s.ID))
+	defaultLimit, ok := homeSectionLimits[s.ID]
+	if !ok {
+		return fmt.Errorf("invalid home section: %s", s.ID)
+	}
+	if s.Limit == 0 {
+		s.Limit = defaultLimit
+	}
+	if s.Limit < 0 || s.Limit > HomeSectionMaxLimit {
+		return fmt.Errorf("home section limit must be between 1-%d: %s", HomeSectionMaxLimit, s.ID)
+	}
+	return nil
+}
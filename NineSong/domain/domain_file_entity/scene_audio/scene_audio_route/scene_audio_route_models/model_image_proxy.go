@@ -0,0 +1,21 @@
+package scene_audio_route_models
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrImageProxyDisabled       = errors.New("image proxy is disabled")
+	ErrImageProxyInvalidURL     = errors.New("invalid image url")
+	ErrImageProxyHostNotAllowed = errors.New("image host is not allowed")
+	ErrImageProxyNotImage       = errors.New("remote resource is not a supported image")
+	ErrImageProxyTooLarge       = errors.New("remote image is too large")
+)
+
+const (
+	// ImageProxyMaxBytes 下载的原图大小上限
+	ImageProxyMaxBytes = 10 << 20
+	// ImageProxyCacheTTL 缓存的图片超过该时长后重新下载
+	ImageProxyCacheTTL = 7 * 24 * time.Hour
+)
@@ -0,0 +1,43 @@
+package scene_audio_route_models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	RadioNameMaxLength = 100
+	RadioURLMaxLength  = 2048
+	// RadioProbeTimeout 检测串流可达性时等待响应头的时间（秒）
+	RadioProbeTimeout = 8
+	// RadioMaxStations 单个用户可保存的电台数量上限
+	RadioMaxStations = 500
+)
+
+var (
+	ErrInvalidRadioStation    = errors.New("invalid internet radio station")
+	ErrRadioStationExists     = errors.New("internet radio station name already exists")
+	ErrRadioStreamUnreachable = errors.New("internet radio stream is unreachable")
+	ErrRadioStationLimit      = errors.New("too many internet radio stations")
+)
+
+// InternetRadioStation 用户自建的网络电台，StreamURL 可为直接音频流或 m3u/pls 播放列表
+type InternetRadioStation struct {
+	ID          primitive.ObjectID `bson:"_id" json:"id"`
+	UserID      string             `bson:"user_id" json:"-"`
+	Name        string             `bson:"name" json:"name"`
+	StreamURL   string             `bson:"stream_url" json:"stream_url"`
+	HomePageURL string             `bson:"home_page_url" json:"home_page_url,omitempty"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// RadioStreamCheck 串流可达性检测结果，只读取响应头，不下载音频
+type RadioStreamCheck struct {
+	Reachable   bool   `json:"reachable"`
+	StatusCode  int    `json:"status_code,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
@@ -0,0 +1,31 @@
+package scene_audio_route_models
+
+import "errors"
+
+const (
+	JukeboxMaxQueueSize  = 1000
+	JukeboxDefaultVolume = 50
+)
+
+var (
+	ErrInvalidJukeboxParams = errors.New("invalid jukebox parameters")
+	ErrJukeboxQueueEmpty    = errors.New("jukebox queue is empty")
+)
+
+// JukeboxStatus 服务器本机播放状态，Position 为当前曲目已播放秒数
+type JukeboxStatus struct {
+	Playing      bool                `json:"playing"`
+	CurrentIndex int                 `json:"current_index"`
+	Position     float64             `json:"position"`
+	Volume       int                 `json:"volume"`
+	Queue        []MediaFileMetadata `json:"queue"`
+}
+
+// JukeboxEvent 点唱机状态变化后发布到事件总线的载荷，不含完整队列
+type JukeboxEvent struct {
+	Playing      bool   `json:"playing"`
+	CurrentIndex int    `json:"current_index"`
+	MediaFileID  string `json:"media_file_id,omitempty"`
+	Volume       int    `json:"volume"`
+	QueueSize    int    `json:"queue_size"`
+}
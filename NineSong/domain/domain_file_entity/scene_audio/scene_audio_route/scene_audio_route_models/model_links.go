@@ -0,0 +1,25 @@
+package scene_audio_route_models
+
+// 封面链接的标准尺寸（像素，最长边），与 /coverart/:id?size= 对应
+const (
+	CoverLinkSmall  = 150
+	CoverLinkMedium = 300
+	CoverLinkLarge  = 600
+)
+
+// CoverLinks 各标准尺寸的封面地址，带 art_version 时可被客户端永久缓存
+type CoverLinks struct {
+	Original string `json:"original"`
+	Small    string `json:"small"`
+	Medium   string `json:"medium"`
+	Large    string `json:"large"`
+}
+
+// ItemLinks 列表条目可直接使用的地址，由服务端按 PUBLIC_BASE_URL 生成，客户端无需拼接路由
+type ItemLinks struct {
+	Stream   string      `json:"stream,omitempty"`
+	Download string      `json:"download,omitempty"`
+	Detail   string      `json:"detail,omitempty"` // 专辑：按光盘分组的曲目
+	Album    string      `json:"album,omitempty"`  // 曲目：所属专辑的详情
+	Cover    *CoverLinks `json:"cover,omitempty"`
+}
@@ -0,0 +1,12 @@
+package scene_audio_route_models
+
+import "errors"
+
+// ErrInvalidLookup 未提供 isrc 或 barcode，或其格式无效
+var ErrInvalidLookup = errors.New("isrc or barcode required")
+
+// LookupResult GET /lookup 的精确匹配结果，同一录音可能对应多个发行版中的曲目
+type LookupResult struct {
+	MediaFiles []MediaFileMetadata `json:"media_files"`
+	Albums     []AlbumMetadata     `json:"albums"`
+}
@@ -0,0 +1,17 @@
+package scene_audio_route_models
+
+// LyricsLine 同步歌词的一行，Time 为毫秒
+type LyricsLine struct {
+	Time int64  `json:"time"`
+	Text string `json:"text"`
+}
+
+// MediaLyrics Synced 为 false 时 Lines 为空，仅有纯文本
+type MediaLyrics struct {
+	MediaFileID string       `json:"media_file_id"`
+	Source      string       `json:"source"`             // embedded | sidecar | online | manual，见 scene_audio_db_models.LyricsTypeEmbedded
+	Provider    string       `json:"provider,omitempty"` // 在线歌词源，仅 online
+	Synced      bool         `json:"synced"`
+	Plain       string       `json:"plain"`
+	Lines       []LyricsLine `json:"lines"`
+}
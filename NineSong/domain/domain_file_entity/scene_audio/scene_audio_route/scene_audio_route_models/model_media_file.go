@@ -3,6 +3,7 @@ package scene_audio_route_models
 import (
 	"time"
 
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -16,12 +17,19 @@ type MediaFileMetadata struct {
 	AlbumArtist    string             `bson:"album_artist"`
 	AlbumID        string             `bson:"album_id"`
 	HasCoverArt    bool               `bson:"has_cover_art"`
+	ArtVersion     string             `bson:"art_version"` // 封面内容哈希，用作 /coverart/:id?v= 参数
 	Year           int                `bson:"year"`
+	OriginalDate   string             `bson:"original_date"`
+	OriginalYear   int                `bson:"original_year"`
 	Size           int                `bson:"size"`
 	Suffix         string             `bson:"suffix"`       // 文件后缀
 	FileName       string             `bson:"file_name"`    // 文件名（不包含路径）
 	LibraryPath    string             `bson:"library_path"` // 音频文件所在的音乐库路径
+	FolderID       string             `bson:"folder_id"`    // 所属媒体库ID
 	Duration       float64            `bson:"duration"`
+	TrackNumber    int                `bson:"track_number"`
+	DiscNumber     int                `bson:"disc_number"`
+	DiscSubtitle   string             `bson:"disc_subtitle"` // 光盘副标题，多碟专辑中区分各碟
 	BitRate        int                `bson:"bit_rate"`
 	EncodingFormat string             `bson:"encoding_format"` // 编码格式（如 PCM、MP3、AAC 等）
 	Genre          string             `bson:"genre"`
@@ -29,6 +37,12 @@ type MediaFileMetadata struct {
 	UpdatedAt      time.Time          `bson:"updated_at"`
 	AlbumArtistID  string             `bson:"album_artist_id"`
 	Channels       int                `bson:"channels"`
+	ISRC           string             `bson:"isrc"` // 国际标准录音代码，统一为无分隔符的大写形式
+
+	RGAlbumGain float64 `bson:"rg_album_gain"` // ReplayGain 专辑增益（dB），来自标签或响度分析
+	RGAlbumPeak float64 `bson:"rg_album_peak"`
+	RGTrackGain float64 `bson:"rg_track_gain"`
+	RGTrackPeak float64 `bson:"rg_track_peak"`
 
 	Compilation       bool           `bson:"compilation"`          // 是否为合辑（多艺术家作品合集）
 	AllArtistIDs      []ArtistIDPair `bson:"all_artist_ids"`       // 所有参与艺术家的唯一标识符列表
@@ -37,11 +51,21 @@ type MediaFileMetadata struct {
 	PlayCount         int       `bson:"play_count"`
 	PlayCompleteCount int       `bson:"play_complete_count"`
 	PlayDate          time.Time `bson:"play_date"`
+	FirstPlayDate     time.Time `bson:"first_play_date"`
 	Rating            int       `bson:"rating"`
 	Starred           bool      `bson:"starred"`
 	StarredAt         time.Time `bson:"starred_at"`
+	RatedAt           time.Time `bson:"rated_at"`
 
 	Index int `bson:"index" json:"Index"`
+
+	Availability string `bson:"availability"` // 见 scene_audio_db_models.AvailabilityOnline 等
+
+	CustomTags map[string]string `bson:"custom_tags"` // CUSTOM_TAGS 配置提取的自定义标签
+
+	Chapters []scene_audio_db_models.MediaChapter `bson:"chapters" json:",omitempty"` // 内嵌章节，扫描时由 ffprobe 读取
+
+	Links *ItemLinks `bson:"-" json:",omitempty"` // 仅接口响应时填充
 }
 
 type MediaFileFilterCounts struct {
@@ -38,7 +38,7 @@ type MediaFileCueMetadata struct {
 
 	// 音频分析 (综合)
 	CueSampleRate  int     `bson:"cue_sample_rate"` // 音频采样率（Hz）
-	CueDuration    float64 `bson:"cue_duration"`    // 音频时长（秒）
+	CueDuration    float64 `bson:"cue_duration"`    // 音频时长（纳秒）
 	CueBitRate     int     `bson:"cue_bit_rate"`    // 比特率（bps）
 	CueChannels    int     `bson:"cue_channels"`    // 音频通道数（如 2 表示立体声）
 	EncodingFormat string  `bson:"encoding_format"` // 编码格式（如 PCM、MP3、AAC 等）
@@ -52,6 +52,7 @@ type MediaFileCueMetadata struct {
 	Rating            int       `bson:"rating"`
 	Starred           bool      `bson:"starred"`
 	StarredAt         time.Time `bson:"starred_at"`
+	RatedAt           time.Time `bson:"rated_at"`
 
 	Index int `bson:"index" json:"Index"`
 }
@@ -92,4 +93,7 @@ type CueTrack struct {
 	ISRC        string     `bson:"track_isrc"`
 	GAIN        float64    `bson:"track_gain"`
 	PEAK        float64    `bson:"track_peak"`
+
+	StartSeconds    float64 `bson:"track_start_seconds"`    // 在整轨音频中的起点（秒）
+	DurationSeconds float64 `bson:"track_duration_seconds"` // 0 表示播放到文件结尾
 }
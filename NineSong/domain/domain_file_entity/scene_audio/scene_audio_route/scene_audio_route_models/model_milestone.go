@@ -0,0 +1,33 @@
+package scene_audio_route_models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 里程碑类型
+const (
+	MilestoneArtistPlays    = "artist_plays"    // 某位艺术家的第 N 次播放
+	MilestoneArtistStreak   = "artist_streak"   // 连续 N 天收听同一位艺术家
+	MilestoneListeningHours = "listening_hours" // 累计收听 N 小时
+)
+
+// MilestoneThresholds 各类型里程碑的达成门槛，按升序排列
+var MilestoneThresholds = map[string][]int{
+	MilestoneArtistPlays:    {100, 500, 1000},
+	MilestoneArtistStreak:   {7, 30, 100},
+	MilestoneListeningHours: {100, 500, 1000},
+}
+
+// MilestoneMetadata 用户达成的里程碑，同一用户、类型、条目与门槛只记录一次
+type MilestoneMetadata struct {
+	ID         primitive.ObjectID `bson:"_id" json:"id"`
+	UserID     string             `bson:"user_id" json:"-"`
+	Type       string             `bson:"type" json:"type"`
+	ItemID     string             `bson:"item_id" json:"item_id,omitempty"` // 艺术家类里程碑为艺术家ID，累计时长为空
+	ItemName   string             `bson:"item_name" json:"item_name,omitempty"`
+	Threshold  int                `bson:"threshold" json:"threshold"`
+	AchievedAt time.Time          `bson:"achieved_at" json:"achieved_at"` // 达成门槛的那次播放时间
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+}
@@ -0,0 +1,46 @@
+package scene_audio_route_models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// NowPlayingTTL 客户端超过该时长未上报即视为已停止播放，记录由 TTL 索引清理
+const NowPlayingTTL = 10 * time.Minute
+
+const (
+	NowPlayingStatePlaying = "playing"
+	NowPlayingStatePaused  = "paused"
+)
+
+var ErrInvalidNowPlaying = errors.New("invalid now playing report")
+
+// NowPlayingMetadata 客户端上报的正在播放状态，按用户+客户端各保留一条
+type NowPlayingMetadata struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	UserID      string             `bson:"user_id"`
+	Client      string             `bson:"client"`
+	MediaFileID primitive.ObjectID `bson:"media_file_id"`
+	State       string             `bson:"state"`
+	Position    int64              `bson:"position"` // 上报时的播放位置(毫秒)
+	UpdatedAt   time.Time          `bson:"updated_at"`
+	ExpiresAt   time.Time          `bson:"expires_at"`
+
+	UserName          string             `bson:"-"`
+	EstimatedPosition int64              `bson:"-"` // 查询时推算的当前位置(毫秒)
+	Item              *MediaFileMetadata `bson:"-"`
+}
+
+// Estimate 播放中时按上报后经过的时间推算当前位置，不超过曲目时长
+func (n *NowPlayingMetadata) Estimate(now time.Time) int64 {
+	if n.State != NowPlayingStatePlaying {
+		return n.Position
+	}
+	pos := n.Position + now.Sub(n.UpdatedAt).Milliseconds()
+	if n.Item != nil && n.Item.Duration > 0 {
+		pos = min(pos, time.Duration(n.Item.Duration).Milliseconds())
+	}
+	return pos
+}
@@ -0,0 +1,59 @@
+package scene_audio_route_models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNowPlayingEstimate(t *testing.T) {
+	updatedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	// 曲目时长与扫描写入一致，按纳秒存储
+	threeMinutes := &scene_audio_route_models.MediaFileMetadata{Duration: float64(3 * time.Minute)}
+
+	tests := []struct {
+		name  string
+		entry scene_audio_route_models.NowPlayingMetadata
+		now   time.Time
+		want  int64
+	}{
+		{
+			name:  "playing advances with wall clock",
+			entry: scene_audio_route_models.NowPlayingMetadata{State: scene_audio_route_models.NowPlayingStatePlaying, Position: 10_000, UpdatedAt: updatedAt, Item: threeMinutes},
+			now:   updatedAt.Add(5 * time.Second),
+			want:  15_000,
+		},
+		{
+			name:  "playing capped at track duration",
+			entry: scene_audio_route_models.NowPlayingMetadata{State: scene_audio_route_models.NowPlayingStatePlaying, Position: 170_000, UpdatedAt: updatedAt, Item: threeMinutes},
+			now:   updatedAt.Add(time.Minute),
+			want:  180_000,
+		},
+		{
+			name:  "paused keeps reported position",
+			entry: scene_audio_route_models.NowPlayingMetadata{State: scene_audio_route_models.NowPlayingStatePaused, Position: 42_000, UpdatedAt: updatedAt, Item: threeMinutes},
+			now:   updatedAt.Add(time.Minute),
+			want:  42_000,
+		},
+		{
+			name:  "unknown duration is not capped",
+			entry: scene_audio_route_models.NowPlayingMetadata{State: scene_audio_route_models.NowPlayingStatePlaying, Position: 0, UpdatedAt: updatedAt, Item: &scene_audio_route_models.MediaFileMetadata{}},
+			now:   updatedAt.Add(10 * time.Minute),
+			want:  600_000,
+		},
+		{
+			name:  "missing item is not capped",
+			entry: scene_audio_route_models.NowPlayingMetadata{State: scene_audio_route_models.NowPlayingStatePlaying, Position: 1_000, UpdatedAt: updatedAt},
+			now:   updatedAt.Add(2 * time.Second),
+			want:  3_000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.entry.Estimate(tt.now))
+		})
+	}
+}
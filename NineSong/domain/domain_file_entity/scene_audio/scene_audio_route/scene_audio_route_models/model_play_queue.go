@@ -0,0 +1,31 @@
+package scene_audio_route_models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PlayQueueMetadata 服务端播放队列，按用户+客户端保存
+type PlayQueueMetadata struct {
+	ID           primitive.ObjectID   `bson:"_id"`
+	UserID       string               `bson:"user_id"`
+	Client       string               `bson:"client"`
+	MediaFileIDs []primitive.ObjectID `bson:"media_file_ids"`
+	Current      int                  `bson:"current"`  // 当前播放索引
+	Position     int64                `bson:"position"` // 当前播放位置(毫秒)
+	ChangedBy    string               `bson:"changed_by"`
+	CreatedAt    time.Time            `bson:"created_at"`
+	UpdatedAt    time.Time            `bson:"updated_at"`
+
+	Items []MediaFileMetadata `bson:"-"`
+}
+
+// NowPlayingEvent 保存播放队列后发布到事件总线的载荷，MediaFileID 为队列当前曲目
+type NowPlayingEvent struct {
+	UserID      string    `json:"user_id"`
+	Client      string    `json:"client"`
+	MediaFileID string    `json:"media_file_id"`
+	Position    int64     `json:"position"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -16,7 +16,8 @@ type PlaylistMetadata struct {
 	UpdatedAt time.Time          `bson:"updated_at"`
 	Path      string             `bson:"path"`
 	Size      int                `bson:"size"`
-	//OwnerID   string             `bson:"owner_id"`
+	OwnerID   string             `bson:"owner_id"`
+	System    string             `bson:"system"`
 }
 
 type PlaylistListResponse struct {
@@ -0,0 +1,96 @@
+package scene_audio_route_models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// PodcastDefaultRefreshInterval 未配置 PODCAST_REFRESH_MINUTES 时的订阅源刷新间隔
+	PodcastDefaultRefreshInterval = time.Hour
+	// PodcastFeedMaxSize 订阅源正文上限，超出部分截断
+	PodcastFeedMaxSize = 16 << 20
+	// PodcastMaxEpisodes 单个订阅源保留的条目上限，只取订阅源中最靠前的条目
+	PodcastMaxEpisodes = 2000
+	// PodcastMaxAutoDownload 自动下载最新条目数的上限
+	PodcastMaxAutoDownload = 50
+	// PodcastMaxChannels 每个用户的订阅数上限
+	PodcastMaxChannels = 500
+	// PodcastDownloadConcurrency 同时进行的下载数
+	PodcastDownloadConcurrency = 2
+)
+
+// 下载状态
+const (
+	PodcastDownloadNone        = "none"
+	PodcastDownloadQueued      = "queued"
+	PodcastDownloadDownloading = "downloading"
+	PodcastDownloadCompleted   = "completed"
+	PodcastDownloadFailed      = "failed"
+)
+
+var (
+	ErrInvalidPodcast          = errors.New("invalid podcast parameters")
+	ErrPodcastExists           = errors.New("podcast feed already subscribed")
+	ErrPodcastFeedUnavailable  = errors.New("podcast feed could not be fetched or parsed")
+	ErrPodcastLimit            = errors.New("podcast subscription limit reached")
+	ErrPodcastDownloadDisabled = errors.New("podcast downloads are not configured")
+)
+
+// PodcastChannel 用户订阅的播客，同一订阅源被多个用户订阅时各自保存
+type PodcastChannel struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID      string             `json:"-" bson:"user_id"`
+	FeedURL     string             `json:"feed_url" bson:"feed_url"`
+	Title       string             `json:"title" bson:"title"`
+	Description string             `json:"description" bson:"description"`
+	Author      string             `json:"author" bson:"author"`
+	ImageURL    string             `json:"image_url" bson:"image_url"`
+	SiteURL     string             `json:"site_url" bson:"site_url"`
+	// AutoDownload 刷新后自动下载最新的 N 个条目，0 表示不自动下载
+	AutoDownload    int       `json:"auto_download" bson:"auto_download"`
+	EpisodeCount    int       `json:"episode_count" bson:"episode_count"`
+	LastRefreshedAt time.Time `json:"last_refreshed_at" bson:"last_refreshed_at"`
+	// LastError 最近一次刷新失败的原因，刷新成功后清空
+	LastError string    `json:"last_error,omitempty" bson:"last_error,omitempty"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+// PodcastEpisode 订阅源中的单集，以 channel_id+guid 去重；播放进度随订阅归属用户保存
+type PodcastEpisode struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	ChannelID    primitive.ObjectID `json:"channel_id" bson:"channel_id"`
+	UserID       string             `json:"-" bson:"user_id"`
+	GUID         string             `json:"guid" bson:"guid"`
+	Title        string             `json:"title" bson:"title"`
+	Description  string             `json:"description" bson:"description"`
+	PublishedAt  time.Time          `json:"published_at" bson:"published_at"`
+	Duration     float64            `json:"duration" bson:"duration"` // 秒
+	EnclosureURL string             `json:"enclosure_url" bson:"enclosure_url"`
+	MimeType     string             `json:"mime_type" bson:"mime_type"`
+	Size         int64              `json:"size" bson:"size"`
+
+	DownloadStatus string `json:"download_status" bson:"download_status"`
+	DownloadError  string `json:"download_error,omitempty" bson:"download_error,omitempty"`
+	LocalPath      string `json:"-" bson:"local_path,omitempty"`
+
+	Played   bool       `json:"played" bson:"played"`
+	Position float64    `json:"position" bson:"position"` // 秒
+	PlayedAt *time.Time `json:"played_at,omitempty" bson:"played_at,omitempty"`
+}
+
+// PodcastEpisodeQuery 单集列表过滤条件，Played 为空时不过滤
+type PodcastEpisodeQuery struct {
+	ChannelID string
+	Played    *bool
+	Start     int
+	Count     int
+}
+
+// PodcastProgress 客户端上报的播放进度，字段为空表示不修改
+type PodcastProgress struct {
+	Position *float64
+	Played   *bool
+}
@@ -0,0 +1,68 @@
+package scene_audio_route_models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 相似艺术家的依据，出现在 SimilarArtist.Reasons 中
+const (
+	RecommendReasonGenre  = "shared_genre" // 曲目流派重合
+	RecommendReasonCoPlay = "co_play"      // 播放过该艺术家的用户也常听
+	RecommendReasonLastFM = "lastfm"       // Last.fm 相似艺术家
+)
+
+// 各依据的得分权重，单项得分已归一化到 0-1
+const (
+	RecommendWeightGenre  = 1.0
+	RecommendWeightCoPlay = 1.5
+	RecommendWeightLastFM = 1.0
+)
+
+const (
+	SimilarArtistsDefaultLimit = 20
+	SimilarArtistsMaxLimit     = 100
+	// RecommendationSeedArtists 个性化推荐以用户近期最常听的几位艺术家为种子
+	RecommendationSeedArtists = 5
+	RecommendationWindow      = 90 * 24 * time.Hour
+	RecommendationAlbumLimit  = 20
+	// RecommendationCoPlayUsers 计算共同收听时最多参考的用户数
+	RecommendationCoPlayUsers = 1000
+	// LastFMSimilarRefresh 保存的 Last.fm 相似艺术家超过该时长后重新获取
+	LastFMSimilarRefresh = 30 * 24 * time.Hour
+)
+
+// ArtistScore 单项依据下的候选艺术家及其得分
+type ArtistScore struct {
+	ArtistID string  `bson:"_id"`
+	Score    float64 `bson:"score"`
+}
+
+// ArtistExternalInfo 计算相似艺术家所需的艺术家外部信息
+type ArtistExternalInfo struct {
+	ID                    primitive.ObjectID `bson:"_id"`
+	Name                  string             `bson:"name"`
+	MBZArtistID           string             `bson:"mbz_artist_id"`
+	SimilarArtistsIDs     []string           `bson:"similar_artists_ids"`
+	ExternalInfoUpdatedAt time.Time          `bson:"external_info_updated_at"`
+}
+
+type SimilarArtist struct {
+	Artist  ArtistMetadata `json:"artist"`
+	Score   float64        `json:"score"`
+	Reasons []string       `json:"reasons"`
+}
+
+type RecommendedAlbum struct {
+	Album     AlbumMetadata `json:"album"`
+	Score     float64       `json:"score"`
+	BecauseOf []string      `json:"because_of"` // 推荐来源的种子艺术家名称
+}
+
+// Recommendations 按用户播放历史生成的推荐，Seeds 为作为依据的常听艺术家
+type Recommendations struct {
+	Seeds   []ArtistMetadata   `json:"seeds"`
+	Artists []SimilarArtist    `json:"artists"`
+	Albums  []RecommendedAlbum `json:"albums"`
+}
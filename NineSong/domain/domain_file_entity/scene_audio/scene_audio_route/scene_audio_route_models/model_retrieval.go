@@ -37,3 +37,27 @@ type RetrievalLyricsMetadata struct {
 	UpdatedAt   time.Time          `bson:"updated_at"`
 	Path        string             `bson:"path"` // 多歌词文件管理
 }
+type RetrievalGaplessMetadata struct {
+	ID             primitive.ObjectID `bson:"_id"`
+	SampleRate     int                `bson:"sample_rate"`
+	EncoderDelay   int                `bson:"encoder_delay"`
+	EncoderPadding int                `bson:"encoder_padding"`
+	TotalSamples   int64              `bson:"total_samples"`
+}
+
+// RetrievalCueSegment CUE 音轨在整轨音频中的截取范围（秒），Duration 为 0 表示截至文件结尾
+type RetrievalCueSegment struct {
+	Track    int
+	Start    float64
+	Duration float64
+	Gain     float64
+	Peak     float64
+}
+
+type RetrievalReplayGainMetadata struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	RGAlbumGain float64            `bson:"rg_album_gain"`
+	RGAlbumPeak float64            `bson:"rg_album_peak"`
+	RGTrackGain float64            `bson:"rg_track_gain"`
+	RGTrackPeak float64            `bson:"rg_track_peak"`
+}
@@ -0,0 +1,54 @@
+package scene_audio_route_models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PlayHistoryMetadata 单次播放记录
+type PlayHistoryMetadata struct {
+	ID             primitive.ObjectID `bson:"_id"`
+	UserID         string             `bson:"user_id"`
+	MediaFileID    primitive.ObjectID `bson:"media_file_id"`
+	AlbumID        string             `bson:"album_id"`
+	ArtistID       string             `bson:"artist_id"`
+	Client         string             `bson:"client"`
+	Complete       bool               `bson:"complete"`        // 是否完整播放
+	DurationPlayed float64            `bson:"duration_played"` // 实际播放秒数，客户端未上报时为 0
+	PlayedAt       time.Time          `bson:"played_at"`       // 客户端上报的播放时间，未上报时为写入时间
+	CreatedAt      time.Time          `bson:"created_at"`
+}
+
+// ScrobbleEvent 播放记录写入后发布到事件总线的载荷
+type ScrobbleEvent struct {
+	UserID      string    `json:"user_id"`
+	MediaFileID string    `json:"media_file_id"`
+	AlbumID     string    `json:"album_id"`
+	ArtistID    string    `json:"artist_id"`
+	Client      string    `json:"client"`
+	Complete    bool      `json:"complete"`
+	PlayedAt    time.Time `json:"played_at"`
+}
+
+// 播放历史分页
+const (
+	HistoryDefaultLimit = 50
+	HistoryMaxLimit     = 500
+)
+
+// PlayHistoryItem 播放历史中的一条记录及对应单曲，单曲已删除时为空
+type PlayHistoryItem struct {
+	PlayHistoryMetadata `bson:",inline"`
+	MediaFile           *MediaFileMetadata `bson:"media_file,omitempty" json:"media_file"`
+}
+
+// PlayHistoryFilter 播放历史查询条件，From/To 为零值时不限
+type PlayHistoryFilter struct {
+	UserID      string
+	MediaFileID string
+	From        time.Time
+	To          time.Time
+	Skip        int
+	Limit       int
+}
@@ -0,0 +1,78 @@
+package scene_audio_route_models
+
+const (
+	SearchTextIndexName  = "search_text" // MongoDB 文本索引名称
+	SearchAtlasIndexName = "default"     // Atlas Search 索引名称
+
+	SearchEngineAtlas = "atlas"
+	SearchEngineText  = "text"
+
+	SearchDefaultCount = 20
+	SearchMaxCount     = 100
+)
+
+// 列表接口的 search 参数匹配策略：小曲库用正则包含匹配更灵活，大曲库需走索引
+const (
+	SearchStrategyRegex  = "regex"  // 不区分大小写的包含匹配，默认
+	SearchStrategyPrefix = "prefix" // 锚定前缀匹配，区分大小写以便使用普通索引
+	SearchStrategyText   = "text"   // 全文检索，使用 SearchTextIndexName 文本索引
+
+	SearchEndpointAlbums     = "albums"
+	SearchEndpointArtists    = "artists"
+	SearchEndpointMediaFiles = "media_files"
+)
+
+// search 的字段级语法，如 "artist=Beatles;title^=Let"，每个字段单独选择匹配方式，多个条件同时满足；
+// 不符合该语法时仍按接口配置的搜索策略处理
+const (
+	SearchMatchExact      = "="  // 完全相等
+	SearchMatchPrefix     = "^=" // 锚定前缀，区分大小写
+	SearchMatchContains   = "~=" // 不区分大小写的包含
+	SearchClauseSeparator = ";"
+)
+
+// SearchPaging 各分组独立分页
+type SearchPaging struct {
+	SongOffset   int
+	SongCount    int
+	AlbumOffset  int
+	AlbumCount   int
+	ArtistOffset int
+	ArtistCount  int
+	RadioOffset  int
+	RadioCount   int
+}
+
+// SearchResult 统一搜索结果，各分组按相关度降序
+type SearchResult struct {
+	Songs       []MediaFileMetadata `json:"songs"`
+	SongTotal   int                 `json:"song_total"`
+	Albums      []AlbumMetadata     `json:"albums"`
+	AlbumTotal  int                 `json:"album_total"`
+	Artists     []ArtistMetadata    `json:"artists"`
+	ArtistTotal int                 `json:"artist_total"`
+	// RadioStations 当前用户的网络电台，按名称包含匹配
+	RadioStations []InternetRadioStation `json:"radio_stations"`
+	RadioTotal    int                    `json:"radio_total"`
+	Engine        string                 `json:"engine"` // atlas 或 text
+}
+
+// LyricsHighlight 片段中命中检索词的字符区间 [Start, End)，按字符（rune）计
+type LyricsHighlight struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// LyricsSearchMatch 歌词命中的曲目，Snippet 为命中行及其下一行
+type LyricsSearchMatch struct {
+	MediaFile  MediaFileMetadata `json:"media_file"`
+	Snippet    string            `json:"snippet"`
+	Highlights []LyricsHighlight `json:"highlights"`
+	Time       *int64            `json:"time,omitempty"` // 同步歌词中命中行的时间（毫秒），可直接跳转播放
+}
+
+// LyricsSearchResult 歌词检索结果，按相关度降序
+type LyricsSearchResult struct {
+	Matches []LyricsSearchMatch `json:"matches"`
+	Total   int                 `json:"total"`
+}
@@ -0,0 +1,102 @@
+package scene_audio_route_models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SmartPlaylistRule 单条规则，例如 {"field":"genre","operator":"contains","value":"rock"}
+type SmartPlaylistRule struct {
+	Field    string      `json:"field"`
+	Operator string      `json:"operator"`
+	Value    interface{} `json:"value"`
+}
+
+// SmartPlaylistRuleGroup 规则组，combinator为all(且)或any(或)，可嵌套
+type SmartPlaylistRuleGroup struct {
+	Combinator string                   `json:"combinator"`
+	Rules      []SmartPlaylistRule      `json:"rules,omitempty"`
+	Groups     []SmartPlaylistRuleGroup `json:"groups,omitempty"`
+}
+
+// SmartPlaylistRules 规则文档，以JSON形式存储于播放列表的rules字段
+type SmartPlaylistRules struct {
+	SmartPlaylistRuleGroup
+	Sort  string `json:"sort,omitempty"`
+	Order string `json:"order,omitempty"`
+	Limit int    `json:"limit,omitempty"`
+}
+
+type SmartPlaylistMetadata struct {
+	ID          primitive.ObjectID
+	Name        string
+	Comment     string
+	OwnerID     string
+	Rules       SmartPlaylistRules
+	SongCount   float64
+	Duration    float64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	EvaluatedAt time.Time
+}
+
+// 规则字段类型
+const (
+	SmartFieldString = "string"
+	SmartFieldNumber = "number"
+	SmartFieldBool   = "bool"
+	SmartFieldDate   = "date"
+)
+
+// SmartPlaylistFields 规则字段 -> 媒体文件字段及类型
+var SmartPlaylistFields = map[string]struct {
+	Key  string
+	Type string
+}{
+	"title":           {"title", SmartFieldString},
+	"album":           {"album", SmartFieldString},
+	"artist":          {"artist", SmartFieldString},
+	"album_artist":    {"album_artist", SmartFieldString},
+	"genre":           {"genre", SmartFieldString},
+	"composer":        {"composer", SmartFieldString},
+	"comment":         {"comment", SmartFieldString},
+	"suffix":          {"suffix", SmartFieldString},
+	"path":            {"path", SmartFieldString},
+	"year":            {"year", SmartFieldNumber},
+	"track_number":    {"track_number", SmartFieldNumber},
+	"disc_number":     {"disc_number", SmartFieldNumber},
+	"duration":        {"duration", SmartFieldNumber},
+	"bit_rate":        {"bit_rate", SmartFieldNumber},
+	"sample_rate":     {"sample_rate", SmartFieldNumber},
+	"channels":        {"channels", SmartFieldNumber},
+	"size":            {"size", SmartFieldNumber},
+	"play_count":      {"play_count", SmartFieldNumber},
+	"rating":          {"rating", SmartFieldNumber},
+	"starred":         {"starred", SmartFieldBool},
+	"compilation":     {"compilation", SmartFieldBool},
+	"created_at":      {"created_at", SmartFieldDate},
+	"updated_at":      {"updated_at", SmartFieldDate},
+	"play_date":       {"play_date", SmartFieldDate},
+	"first_play_date": {"first_play_date", SmartFieldDate},
+	"starred_at":      {"starred_at", SmartFieldDate},
+	"rated_at":        {"rated_at", SmartFieldDate},
+}
+
+// SmartPlaylistOperators 规则运算符 -> 适用的字段类型
+var SmartPlaylistOperators = map[string][]string{
+	"is":              {SmartFieldString, SmartFieldNumber, SmartFieldBool},
+	"is_not":          {SmartFieldString, SmartFieldNumber, SmartFieldBool},
+	"contains":        {SmartFieldString},
+	"not_contains":    {SmartFieldString},
+	"starts_with":     {SmartFieldString},
+	"ends_with":       {SmartFieldString},
+	"gt":              {SmartFieldNumber, SmartFieldDate},
+	"lt":              {SmartFieldNumber, SmartFieldDate},
+	"in_range":        {SmartFieldNumber, SmartFieldDate},
+	"in_the_last":     {SmartFieldDate},
+	"not_in_the_last": {SmartFieldDate},
+	"in_genre_tree":   {SmartFieldString}, // 匹配流派及其全部子流派，仅用于 genre 字段
+}
+
+const SmartPlaylistMaxLimit = 5000
@@ -0,0 +1,10 @@
+package scene_audio_route_models
+
+// 可排序的列表接口，SORT_FIELDS 与 SORT_DEFAULTS 配置按此名称扩展排序白名单
+const (
+	SortEntityMediaFiles     = "media_files"
+	SortEntityAlbums         = "albums"
+	SortEntityArtists        = "artists"
+	SortEntityPlaylistTracks = "playlist_tracks"
+	SortEntityMediaFileCues  = "media_file_cues"
+)
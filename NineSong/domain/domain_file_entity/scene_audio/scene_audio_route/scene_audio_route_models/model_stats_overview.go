@@ -0,0 +1,58 @@
+package scene_audio_route_models
+
+import "time"
+
+const (
+	StatsOverviewTopGenres = 10
+	// StatsOverviewTTL 概览缓存时间，播放后不立即失效
+	StatsOverviewTTL = 10 * time.Minute
+)
+
+// StatsGenreShare 窗口内某流派的播放次数及占比
+type StatsGenreShare struct {
+	Genre string  `bson:"_id" json:"genre"`
+	Plays int     `bson:"plays" json:"plays"`
+	Share float64 `bson:"-" json:"share"`
+}
+
+// StatsHeatmapCell 按星期（0 为周日）与小时统计的播放次数，时间按服务器时区
+type StatsHeatmapCell struct {
+	Weekday int `bson:"weekday" json:"weekday"`
+	Hour    int `bson:"hour" json:"hour"`
+	Plays   int `bson:"plays" json:"plays"`
+}
+
+// StatsStreaks 连续收听天数；Current 截止今天或昨天，否则为 0
+type StatsStreaks struct {
+	Current      int    `json:"current"`
+	Longest      int    `json:"longest"`
+	LongestStart string `json:"longest_start,omitempty"` // YYYY-MM-DD
+	LongestEnd   string `json:"longest_end,omitempty"`
+}
+
+// StatsOverviewRaw 仓储层聚合结果，连续天数在用例层计算
+type StatsOverviewRaw struct {
+	Plays            int                `bson:"plays"`
+	ListeningSeconds float64            `bson:"listening_seconds"`
+	UniqueMediaFiles int                `bson:"unique_media_files"`
+	UniqueAlbums     int                `bson:"unique_albums"`
+	UniqueArtists    int                `bson:"unique_artists"`
+	Genres           []StatsGenreShare  `bson:"genres"`
+	Heatmap          []StatsHeatmapCell `bson:"heatmap"`
+	Days             []string           `bson:"days"` // 有播放的日期，升序
+}
+
+// StatsOverview 用户在窗口内的收听概览
+type StatsOverview struct {
+	Window           string             `json:"window"`
+	Since            *time.Time         `json:"since,omitempty"`
+	Plays            int                `json:"plays"`
+	ListeningSeconds float64            `json:"listening_seconds"`
+	UniqueMediaFiles int                `json:"unique_media_files"`
+	UniqueAlbums     int                `json:"unique_albums"`
+	UniqueArtists    int                `json:"unique_artists"`
+	ActiveDays       int                `json:"active_days"`
+	Genres           []StatsGenreShare  `json:"genres"`
+	Heatmap          []StatsHeatmapCell `json:"heatmap"`
+	Streaks          StatsStreaks       `json:"streaks"`
+}
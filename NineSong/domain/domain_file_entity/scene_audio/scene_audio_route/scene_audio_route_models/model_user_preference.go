@@ -0,0 +1,17 @@
+package scene_audio_route_models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UserPreferenceMetadata 用户级播放偏好，每个用户一条记录
+type UserPreferenceMetadata struct {
+	ID             primitive.ObjectID  `bson:"_id"`
+	UserID         string              `bson:"user_id"`
+	ReplayGainMode string              `bson:"replay_gain_mode"`        // track | album | off
+	HomeSections   []HomeSectionConfig `bson:"home_sections,omitempty"` // 启用的首页分区及顺序，为空时使用服务端默认布局
+	CreatedAt      time.Time           `bson:"created_at"`
+	UpdatedAt      time.Time           `bson:"updated_at"`
+}
@@ -0,0 +1,36 @@
+package scene_audio_subsonic_interface
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_subsonic/scene_audio_subsonic_models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SubsonicClient 上游 Subsonic 兼容服务器的最小客户端
+type SubsonicClient interface {
+	Search(ctx context.Context, query string) (*scene_audio_subsonic_models.SubsonicSearchResult, error)
+	Scrobble(ctx context.Context, upstreamID string, playedAt time.Time) error
+	// Star/Unstar 的 itemType 为 media/album/artist
+	Star(ctx context.Context, itemType, upstreamID string) error
+	Unstar(ctx context.Context, itemType, upstreamID string) error
+}
+
+type SubsonicForwardRepository interface {
+	EnqueueTask(ctx context.Context, task *scene_audio_subsonic_models.SubsonicForwardTask) error
+	GetDueTasks(ctx context.Context, now time.Time, limit int64) ([]scene_audio_subsonic_models.SubsonicForwardTask, error)
+	// ClaimTask 以 next_attempt_at 作乐观锁推迟任务，多实例下只有一个能领取成功
+	ClaimTask(ctx context.Context, task scene_audio_subsonic_models.SubsonicForwardTask, until time.Time) (bool, error)
+	UpdateTask(ctx context.Context, task scene_audio_subsonic_models.SubsonicForwardTask) error
+	DeleteTask(ctx context.Context, id primitive.ObjectID) error
+
+	GetMapping(ctx context.Context, itemID, itemType string) (*scene_audio_subsonic_models.SubsonicItemMapping, error)
+	SaveMapping(ctx context.Context, mapping scene_audio_subsonic_models.SubsonicItemMapping) error
+	GetLocalItem(ctx context.Context, itemID, itemType string) (*scene_audio_subsonic_models.SubsonicLocalItem, error)
+}
+
+// SubsonicForwarder 业务层调用入口，未配置上游时为空实现
+type SubsonicForwarder interface {
+	Forward(ctx context.Context, action, itemID, itemType string, playedAt time.Time)
+}
@@ -0,0 +1,23 @@
+package scene_audio_subsonic_interface
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_subsonic/scene_audio_subsonic_models"
+)
+
+type NumericIDRepository interface {
+	// GetOrCreate 返回各条目的整数ID，尚未映射的按序分配
+	GetOrCreate(ctx context.Context, itemType string, itemIDs []string) (map[string]int64, error)
+	// Resolve 反查整数ID对应的条目，不存在时返回 domain.ErrNotFound
+	Resolve(ctx context.Context, id int64) (*scene_audio_subsonic_models.NumericIDMapping, error)
+}
+
+type SubsonicExportUsecase interface {
+	// ResolveItemID 同时接受 ObjectID 与整数ID，统一返回 ObjectID 字符串
+	ResolveItemID(ctx context.Context, itemType, rawID string) (string, error)
+	// GetPlaylist 以 Subsonic getPlaylist 结构导出单个播放列表，播放列表与曲目均使用整数ID
+	GetPlaylist(ctx context.Context, rawID string) (*scene_audio_subsonic_models.SubsonicPlaylist, error)
+	// GetInternetRadioStations 以 getInternetRadioStations 结构导出用户的网络电台
+	GetInternetRadioStations(ctx context.Context, userId string) ([]scene_audio_subsonic_models.SubsonicInternetRadioStation, error)
+}
@@ -0,0 +1,93 @@
+package scene_audio_subsonic_models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SubsonicAPIVersion 请求上游与对外导出时声明的协议版本
+const SubsonicAPIVersion = "1.16.1"
+
+var (
+	ErrUpstreamNotFound = errors.New("item not found on upstream subsonic server")
+	ErrUpstreamFailed   = errors.New("upstream subsonic request failed")
+)
+
+// 转发动作
+const (
+	ForwardActionScrobble = "scrobble"
+	ForwardActionStar     = "star"
+	ForwardActionUnstar   = "unstar"
+)
+
+// 队列状态
+const (
+	ForwardStatusPending = "pending"
+	ForwardStatusFailed  = "failed" // 超过最大重试次数，保留待人工处理
+)
+
+// ForwardTaskMaxAttempts 单个任务最大尝试次数
+const ForwardTaskMaxAttempts = 10
+
+// 匹配方式
+const (
+	MatchedByMBID = "mbid"
+	MatchedByISRC = "isrc"
+	MatchedByPath = "path"
+	MatchedByTags = "tags"
+)
+
+// SubsonicForwardTask 待转发到上游的播放或收藏操作
+type SubsonicForwardTask struct {
+	ID            primitive.ObjectID `bson:"_id" json:"id"`
+	Action        string             `bson:"action" json:"action"`
+	ItemID        string             `bson:"item_id" json:"item_id"`
+	ItemType      string             `bson:"item_type" json:"item_type"` // media/album/artist
+	PlayedAt      time.Time          `bson:"played_at,omitempty" json:"played_at,omitempty"`
+	Status        string             `bson:"status" json:"status"`
+	Attempts      int                `bson:"attempts" json:"attempts"`
+	LastError     string             `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	NextAttemptAt time.Time          `bson:"next_attempt_at" json:"next_attempt_at"`
+	CreatedAt     time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// SubsonicItemMapping 本地条目与上游ID的对应关系
+type SubsonicItemMapping struct {
+	ItemID     string    `bson:"item_id"`
+	ItemType   string    `bson:"item_type"`
+	UpstreamID string    `bson:"upstream_id"`
+	MatchedBy  string    `bson:"matched_by"`
+	UpdatedAt  time.Time `bson:"updated_at"`
+}
+
+// SubsonicLocalItem 用于在上游匹配的本地元数据
+type SubsonicLocalItem struct {
+	ItemID   string
+	ItemType string
+	Title    string // 歌曲标题、专辑名或艺术家名
+	Artist   string
+	Album    string
+	Path     string
+	MBID     string
+	ISRC     string // 仅歌曲，已规范化
+}
+
+// SubsonicSearchResult search3 返回的候选条目
+type SubsonicSearchResult struct {
+	Songs   []SubsonicItem
+	Albums  []SubsonicItem
+	Artists []SubsonicItem
+}
+
+type SubsonicItem struct {
+	ID            string   `json:"id"`
+	Title         string   `json:"title"`
+	Name          string   `json:"name"`
+	Artist        string   `json:"artist"`
+	Album         string   `json:"album"`
+	Path          string   `json:"path"`
+	MusicBrainzID string   `json:"musicBrainzId"`
+	ISRC          []string `json:"isrc"` // OpenSubsonic 扩展字段，Navidrome 等服务器返回
+}
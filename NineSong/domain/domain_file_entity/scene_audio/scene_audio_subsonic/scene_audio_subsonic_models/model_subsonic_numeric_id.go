@@ -0,0 +1,70 @@
+package scene_audio_subsonic_models
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidNumericID = errors.New("invalid item id")
+
+// 数字ID映射的条目类型
+const (
+	NumericItemMedia    = "media"
+	NumericItemAlbum    = "album"
+	NumericItemArtist   = "artist"
+	NumericItemPlaylist = "playlist"
+	NumericItemRadio    = "internet_radio"
+)
+
+// NumericIDAllocateAttempts 并发分配时序号冲突的最大重试次数
+const NumericIDAllocateAttempts = 5
+
+// NumericIDMapping ObjectID 与整数ID的对应关系，供只接受整数ID的 Subsonic 客户端使用；一经分配不再变更
+type NumericIDMapping struct {
+	ID        int64     `bson:"_id" json:"id"`
+	ItemID    string    `bson:"item_id" json:"item_id"`
+	ItemType  string    `bson:"item_type" json:"item_type"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// SubsonicPlaylist getPlaylist 响应中的 playlist 节点，ID 均为映射后的整数
+type SubsonicPlaylist struct {
+	ID        string         `json:"id"`
+	Name      string         `json:"name"`
+	Comment   string         `json:"comment,omitempty"`
+	SongCount int            `json:"songCount"`
+	Duration  int            `json:"duration"`
+	Created   time.Time      `json:"created"`
+	Changed   time.Time      `json:"changed"`
+	Entry     []SubsonicSong `json:"entry"`
+}
+
+type SubsonicSong struct {
+	ID         string `json:"id"`
+	Parent     string `json:"parent,omitempty"`
+	IsDir      bool   `json:"isDir"`
+	Title      string `json:"title"`
+	Album      string `json:"album,omitempty"`
+	Artist     string `json:"artist,omitempty"`
+	Track      int    `json:"track,omitempty"`
+	DiscNumber int    `json:"discNumber,omitempty"`
+	Year       int    `json:"year,omitempty"`
+	Genre      string `json:"genre,omitempty"`
+	Size       int64  `json:"size,omitempty"`
+	Suffix     string `json:"suffix,omitempty"`
+	Duration   int    `json:"duration"`
+	BitRate    int    `json:"bitRate,omitempty"`
+	Path       string `json:"path,omitempty"`
+	AlbumID    string `json:"albumId,omitempty"`
+	ArtistID   string `json:"artistId,omitempty"`
+	Type       string `json:"type"`
+	IsVideo    bool   `json:"isVideo"`
+}
+
+// SubsonicInternetRadioStation getInternetRadioStations 响应中的单个电台
+type SubsonicInternetRadioStation struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	StreamURL   string `json:"streamUrl"`
+	HomePageURL string `json:"homePageUrl,omitempty"`
+}
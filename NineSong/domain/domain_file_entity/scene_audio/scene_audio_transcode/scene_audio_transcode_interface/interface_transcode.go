@@ -0,0 +1,16 @@
+package scene_audio_transcode_interface
+
+import (
+	"context"
+	"io"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_transcode/scene_audio_transcode_models"
+)
+
+type TranscodeService interface {
+	// CachedPath 返回已缓存的转码文件路径，未命中时返回false
+	CachedPath(ctx context.Context, inputPath string, cacheDir string, profile scene_audio_transcode_models.TranscodeProfile) (string, bool, error)
+
+	// Stream 将ffmpeg输出写入w，同时落盘缓存，完整结束后才生效
+	Stream(ctx context.Context, w io.Writer, inputPath string, cacheDir string, profile scene_audio_transcode_models.TranscodeProfile) error
+}
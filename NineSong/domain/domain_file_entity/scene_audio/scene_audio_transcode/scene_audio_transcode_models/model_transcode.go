@@ -0,0 +1,200 @@
+package scene_audio_transcode_models
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrUnsupportedFormat        = errors.New("unsupported transcode format")
+	ErrUnsupportedChannelLayout = errors.New("unsupported channel layout")
+)
+
+// TranscodeFormat 目标编码格式定义
+type TranscodeFormat struct {
+	Name           string
+	Codec          string // ffmpeg编码器
+	Container      string // ffmpeg输出封装格式
+	Extension      string
+	MimeType       string
+	DefaultBitRate int // kbps
+	MaxBitRate     int // kbps
+}
+
+var transcodeFormats = map[string]TranscodeFormat{
+	"mp3": {
+		Name:           "mp3",
+		Codec:          "libmp3lame",
+		Container:      "mp3",
+		Extension:      ".mp3",
+		MimeType:       "audio/mpeg",
+		DefaultBitRate: 320,
+		MaxBitRate:     320,
+	},
+	"opus": {
+		Name:           "opus",
+		Codec:          "libopus",
+		Container:      "ogg",
+		Extension:      ".opus",
+		MimeType:       "audio/ogg",
+		DefaultBitRate: 192,
+		MaxBitRate:     512,
+	},
+	"flac": {
+		Name:           "flac",
+		Codec:          "flac",
+		Container:      "flac",
+		Extension:      ".flac",
+		MimeType:       "audio/flac",
+		DefaultBitRate: 1411, // 无损编码忽略码率，仅用于缓存键
+		MaxBitRate:     1411,
+	},
+	"aac": {
+		Name:           "aac",
+		Codec:          "aac",
+		Container:      "adts",
+		Extension:      ".aac",
+		MimeType:       "audio/aac",
+		DefaultBitRate: 256,
+		MaxBitRate:     512,
+	},
+}
+
+// FormatNames 可转码的目标格式名，按字母排序
+func FormatNames() []string {
+	names := make([]string, 0, len(transcodeFormats))
+	for name := range transcodeFormats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+const minBitRate = 32
+
+// 声道布局转换：mono/stereo为标准下混（含5.1→立体声），left/right为单声道提取
+const (
+	ChannelLayoutMono   = "mono"
+	ChannelLayoutStereo = "stereo"
+	ChannelLayoutLeft   = "left"
+	ChannelLayoutRight  = "right"
+)
+
+// 音量标准化模式（ReplayGain）
+const (
+	ReplayGainModeTrack = "track"
+	ReplayGainModeAlbum = "album"
+	ReplayGainModeOff   = "off"
+)
+
+func IsValidReplayGainMode(mode string) bool {
+	switch mode {
+	case ReplayGainModeTrack, ReplayGainModeAlbum, ReplayGainModeOff:
+		return true
+	}
+	return false
+}
+
+// TranscodeProfile 一次转码请求的完整参数，Key() 用于磁盘缓存
+type TranscodeProfile struct {
+	Format       TranscodeFormat
+	BitRate      int      // kbps
+	SampleRate   int      // 0表示保持原采样率
+	Channels     int      // 0表示保持原声道
+	AudioFilters []string // ffmpeg -af 滤镜链（按顺序拼接）
+
+	// 只截取输入的一段（秒），用于整轨 CUE 的单曲播放；Duration 为 0 表示截至结尾
+	StartTime float64
+	Duration  float64
+}
+
+// NewTranscodeProfile 根据请求参数构建转码配置，maxBitRate为0时使用格式默认码率
+func NewTranscodeProfile(format string, maxBitRate int) (TranscodeProfile, error) {
+	f, ok := transcodeFormats[strings.ToLower(strings.TrimSpace(format))]
+	if !ok {
+		return TranscodeProfile{}, fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+
+	bitRate := f.DefaultBitRate
+	if maxBitRate > 0 {
+		bitRate = maxBitRate
+	}
+	if bitRate > f.MaxBitRate {
+		bitRate = f.MaxBitRate
+	}
+	if bitRate < minBitRate {
+		bitRate = minBitRate
+	}
+
+	profile := TranscodeProfile{
+		Format:  f,
+		BitRate: bitRate,
+	}
+	// opus仅支持48k采样率
+	if f.Name == "opus" {
+		profile.SampleRate = 48000
+	}
+	return profile, nil
+}
+
+// IsTranscodeFormat 判断格式参数是否需要转码（空值与raw表示原始文件）
+func IsTranscodeFormat(format string) bool {
+	format = strings.ToLower(strings.TrimSpace(format))
+	return format != "" && format != "raw"
+}
+
+func (p TranscodeProfile) Key() string {
+	key := fmt.Sprintf("%s_%dk_%d_%d_%s",
+		p.Format.Name, p.BitRate, p.SampleRate, p.Channels, strings.Join(p.AudioFilters, ","))
+	if p.HasSegment() {
+		key += fmt.Sprintf("_%.3f_%.3f", p.StartTime, p.Duration)
+	}
+	return key
+}
+
+// ApplySegment 只转码输入中从 start 起的 duration 秒
+func (p *TranscodeProfile) ApplySegment(start, duration float64) {
+	p.StartTime = math.Max(start, 0)
+	p.Duration = math.Max(duration, 0)
+}
+
+func (p TranscodeProfile) HasSegment() bool {
+	return p.StartTime > 0 || p.Duration > 0
+}
+
+// ApplyChannelLayout 将声道布局转换写入配置，空值保持原声道
+func (p *TranscodeProfile) ApplyChannelLayout(layout string) error {
+	switch strings.ToLower(strings.TrimSpace(layout)) {
+	case "":
+		return nil
+	case ChannelLayoutMono:
+		p.Channels = 1
+	case ChannelLayoutStereo:
+		p.Channels = 2
+	case ChannelLayoutLeft:
+		p.Channels = 1
+		p.AudioFilters = append(p.AudioFilters, "pan=mono|c0=FL")
+	case ChannelLayoutRight:
+		p.Channels = 1
+		p.AudioFilters = append(p.AudioFilters, "pan=mono|c0=FR")
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedChannelLayout, layout)
+	}
+	return nil
+}
+
+// ApplyReplayGain 按增益(dB)与峰值追加音量滤镜，峰值有效时限制增益避免削波
+func (p *TranscodeProfile) ApplyReplayGain(gain float64, peak float64) {
+	if gain == 0 {
+		return
+	}
+	if peak > 0 {
+		if maxGain := -20 * math.Log10(peak); gain > maxGain {
+			gain = maxGain
+		}
+	}
+	p.AudioFilters = append(p.AudioFilters, fmt.Sprintf("volume=%.2fdB", gain))
+}
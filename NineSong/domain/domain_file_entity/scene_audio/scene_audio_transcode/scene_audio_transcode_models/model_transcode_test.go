@@ -0,0 +1,112 @@
+package scene_audio_transcode_models_test
+
+import (
+	"testing"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_transcode/scene_audio_transcode_models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranscodeProfileKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		bitRate int
+		apply   func(p *scene_audio_transcode_models.TranscodeProfile)
+		want    string
+	}{
+		{
+			name:   "default bitrate",
+			format: "mp3",
+			want:   "mp3_320k_0_0_",
+		},
+		{
+			name:    "bitrate clamped to format maximum",
+			format:  "mp3",
+			bitRate: 999,
+			want:    "mp3_320k_0_0_",
+		},
+		{
+			name:    "bitrate raised to minimum",
+			format:  "aac",
+			bitRate: 8,
+			want:    "aac_32k_0_0_",
+		},
+		{
+			name:   "opus forces 48k sample rate",
+			format: "opus",
+			want:   "opus_192k_48000_0_",
+		},
+		{
+			name:   "channel layout and filters",
+			format: "flac",
+			apply: func(p *scene_audio_transcode_models.TranscodeProfile) {
+				_ = p.ApplyChannelLayout("left")
+				p.ApplyReplayGain(-3, 0)
+			},
+			want: "flac_1411k_0_1_pan=mono|c0=FL,volume=-3.00dB",
+		},
+		{
+			name:   "segment appended",
+			format: "flac",
+			apply: func(p *scene_audio_transcode_models.TranscodeProfile) {
+				p.ApplySegment(12.5, 180)
+			},
+			want: "flac_1411k_0_0__12.500_180.000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, err := scene_audio_transcode_models.NewTranscodeProfile(tt.format, tt.bitRate)
+			assert.NoError(t, err)
+			if tt.apply != nil {
+				tt.apply(&profile)
+			}
+			assert.Equal(t, tt.want, profile.Key())
+		})
+	}
+}
+
+func TestTranscodeProfileKeyDistinguishesSegments(t *testing.T) {
+	whole, _ := scene_audio_transcode_models.NewTranscodeProfile("flac", 0)
+	first, _ := scene_audio_transcode_models.NewTranscodeProfile("flac", 0)
+	first.ApplySegment(0, 200)
+	second, _ := scene_audio_transcode_models.NewTranscodeProfile("flac", 0)
+	second.ApplySegment(200, 200)
+
+	assert.NotEqual(t, whole.Key(), first.Key())
+	assert.NotEqual(t, first.Key(), second.Key())
+}
+
+func TestTranscodeProfileApplySegment(t *testing.T) {
+	tests := []struct {
+		name         string
+		start        float64
+		duration     float64
+		wantStart    float64
+		wantDuration float64
+		wantSegment  bool
+	}{
+		{name: "regular segment", start: 30, duration: 240, wantStart: 30, wantDuration: 240, wantSegment: true},
+		{name: "first track until end", start: 0, duration: 0, wantSegment: false},
+		{name: "first track with duration", start: 0, duration: 180, wantDuration: 180, wantSegment: true},
+		{name: "last track until end", start: 600, duration: 0, wantStart: 600, wantSegment: true},
+		{name: "negative values clamped", start: -5, duration: -1, wantSegment: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var profile scene_audio_transcode_models.TranscodeProfile
+			profile.ApplySegment(tt.start, tt.duration)
+			assert.Equal(t, tt.wantStart, profile.StartTime)
+			assert.Equal(t, tt.wantDuration, profile.Duration)
+			assert.Equal(t, tt.wantSegment, profile.HasSegment())
+		})
+	}
+}
+
+func TestNewTranscodeProfileUnsupportedFormat(t *testing.T) {
+	_, err := scene_audio_transcode_models.NewTranscodeProfile("wma", 0)
+	assert.ErrorIs(t, err, scene_audio_transcode_models.ErrUnsupportedFormat)
+}
@@ -0,0 +1,103 @@
+package domain_system
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity"
+)
+
+// DashboardActiveWindow 播放队列在此时间内有更新的客户端视为活跃会话
+const DashboardActiveWindow = 10 * time.Minute
+
+// LibraryCounts 媒体库各类条目数量
+type LibraryCounts struct {
+	Artists        int64 `json:"artists"`
+	Albums         int64 `json:"albums"`
+	MediaFiles     int64 `json:"media_files"`
+	MediaFileCues  int64 `json:"media_file_cues"`
+	Genres         int64 `json:"genres"`
+	Playlists      int64 `json:"playlists"`
+	Users          int64 `json:"users"`
+	PendingReviews int64 `json:"pending_reviews"`
+}
+
+// ActiveSession 最近更新过播放队列的用户与客户端
+type ActiveSession struct {
+	UserID    string    `json:"user_id" bson:"user_id"`
+	Client    string    `json:"client" bson:"client"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// DiskCacheUsage 临时目录（串流缓存、封面与缩略图）的占用
+type DiskCacheUsage struct {
+	Type  string `json:"type"`
+	Path  string `json:"path"`
+	Files int64  `json:"files"`
+	Bytes int64  `json:"bytes"`
+}
+
+// ResponseCacheUsage 列表响应缓存的条目统计，Entries 为 -1 表示无法获取
+type ResponseCacheUsage struct {
+	Backend  string `json:"backend"`
+	Entries  int64  `json:"entries"`
+	Capacity int    `json:"capacity"`
+}
+
+type DashboardCaches struct {
+	Response ResponseCacheUsage `json:"response"`
+	Disk     []DiskCacheUsage   `json:"disk"`
+}
+
+// ExternalAPIUsage 外部元数据服务自进程启动以来的调用统计
+type ExternalAPIUsage struct {
+	Provider     string    `json:"provider"`
+	Requests     int64     `json:"requests"`
+	CacheHits    int64     `json:"cache_hits"`
+	Retries      int64     `json:"retries"`
+	RateLimited  int64     `json:"rate_limited"`
+	Failures     int64     `json:"failures"`
+	AvgLatencyMs int64     `json:"avg_latency_ms"`
+	LastError    string    `json:"last_error,omitempty"`
+	LastErrorAt  time.Time `json:"last_error_at,omitempty"`
+}
+
+// DashboardError 最近一次返回 5xx 的请求
+type DashboardError struct {
+	Time    time.Time `json:"time"`
+	Method  string    `json:"method"`
+	Path    string    `json:"path"`
+	Status  int       `json:"status"`
+	Code    string    `json:"code"`
+	Message string    `json:"message"`
+}
+
+// Dashboard 管理面板首页所需的全部状态
+type Dashboard struct {
+	Version        string                        `json:"version"`
+	APIVersion     string                        `json:"api_version"`
+	StartedAt      time.Time                     `json:"started_at"`
+	UptimeSeconds  int64                         `json:"uptime_seconds"`
+	Scan           domain_file_entity.ScanStatus `json:"scan"`
+	ActiveSessions []ActiveSession               `json:"active_sessions"`
+	Caches         DashboardCaches               `json:"caches"`
+	RecentErrors   []DashboardError              `json:"recent_errors"`
+	ExternalAPIs   []ExternalAPIUsage            `json:"external_apis"`
+	Library        LibraryCounts                 `json:"library"`
+}
+
+// ScanStatusProvider 由扫描用例实现，面板只读取状态
+type ScanStatusProvider interface {
+	GetScanStatus() domain_file_entity.ScanStatus
+}
+
+type DashboardRepository interface {
+	GetLibraryCounts(ctx context.Context) (LibraryCounts, error)
+	GetActiveSessions(ctx context.Context, since time.Time) ([]ActiveSession, error)
+	// GetCacheFolders 返回临时目录类型到路径的映射，未配置的类型不返回
+	GetCacheFolders(ctx context.Context, types ...string) (map[string]string, error)
+}
+
+type DashboardUsecase interface {
+	Get(ctx context.Context) (*Dashboard, error)
+}
@@ -0,0 +1,27 @@
+package domain_system
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// EventHeartbeatInterval SSE 连接空闲时发送注释行的间隔，防止反向代理因超时断开
+const EventHeartbeatInterval = 25 * time.Second
+
+// EventTopics /events 可订阅的主题；scrobble 与 now_playing 只推送当前用户自己的事件
+var EventTopics = []string{"scan", "media_added", "annotation", "now_playing", "scrobble", "jukebox"}
+
+var ErrInvalidEventTopic = errors.New("invalid event topic")
+
+// StreamEvent 推送给客户端的单个事件，Data 为发布方序列化的载荷
+type StreamEvent struct {
+	Topic string          `json:"topic"`
+	Data  json.RawMessage `json:"data"`
+	Time  time.Time       `json:"time"`
+}
+
+type EventUsecase interface {
+	// Subscribe topics 为空时订阅全部主题；调用方读取完毕后必须调用返回的 cancel
+	Subscribe(userID string, topics []string) (<-chan StreamEvent, func(), error)
+}
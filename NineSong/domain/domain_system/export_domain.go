@@ -0,0 +1,30 @@
+package domain_system
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ExportPageSize 导出时每次按 _id 游标读取的文档数
+const ExportPageSize = 500
+
+// ExportTypes 全量导出支持的实体类型，按此顺序依次输出
+var ExportTypes = []string{"artist", "album", "media"}
+
+// ExportRecord NDJSON 中的一行，Data 为去掉索引辅助字段后的原始文档
+type ExportRecord struct {
+	Type string `json:"type"`
+	Data bson.M `json:"data"`
+}
+
+type ExportRepository interface {
+	// GetPage 返回 _id 大于 after 的前 limit 条文档，after 为零值时从头开始
+	GetPage(ctx context.Context, itemType string, after primitive.ObjectID, limit int) ([]bson.M, error)
+}
+
+type ExportUsecase interface {
+	// Export 按类型逐页读取并交给 emit，emit 返回错误时停止导出
+	Export(ctx context.Context, types []string, emit func([]ExportRecord) error) error
+}
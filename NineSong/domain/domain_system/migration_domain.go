@@ -0,0 +1,36 @@
+package domain_system
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	MigrationScopeIndex = "index" // 索引变更
+	MigrationScopeData  = "data"  // 字段补写、冗余字段与计数修复等数据变更
+)
+
+// MigrationStatus 单个迁移版本的执行情况，失败的版本在下次启动时重试
+type MigrationStatus struct {
+	Scope       string     `json:"scope"`
+	Version     int        `json:"version"`
+	Description string     `json:"description"`
+	Background  bool       `json:"background"` // 启动后在后台执行，不阻塞服务启动
+	Applied     bool       `json:"applied"`
+	AppliedAt   *time.Time `json:"applied_at,omitempty"`
+	DurationMs  int64      `json:"duration_ms,omitempty"`
+	LastError   string     `json:"last_error,omitempty"`
+	FailedAt    *time.Time `json:"failed_at,omitempty"`
+}
+
+// MigrationReport Running 表示集群内有实例正持有迁移锁
+type MigrationReport struct {
+	Running    bool              `json:"running"`
+	Pending    int               `json:"pending"`
+	Migrations []MigrationStatus `json:"migrations"`
+}
+
+// MigrationStatusProvider 由启动迁移实现，管理接口只读取状态
+type MigrationStatusProvider interface {
+	MigrationStatus(ctx context.Context) (*MigrationReport, error)
+}
@@ -0,0 +1,32 @@
+package domain_system
+
+// ServerFeatures 各可选功能是否可用，取决于配置与运行环境
+type ServerFeatures struct {
+	Transcoding        bool     `json:"transcoding"` // 运行环境中找到 ffmpeg
+	Lyrics             bool     `json:"lyrics"`
+	LyricsProviders    []string `json:"lyrics_providers"`
+	Podcasts           bool     `json:"podcasts"`
+	Subsonic           bool     `json:"subsonic"`            // 是否提供 Subsonic 兼容接口
+	SubsonicForwarding bool     `json:"subsonic_forwarding"` // 播放与收藏转发至上游 Subsonic
+	Federation         bool     `json:"federation"`
+	Fingerprinting     bool     `json:"fingerprinting"`
+	MusicBrainz        bool     `json:"musicbrainz"`
+	LoudnessAnalysis   bool     `json:"loudness_analysis"`
+	ImageProxy         bool     `json:"image_proxy"`
+	StrictParams       bool     `json:"strict_params"`
+	Jukebox            bool     `json:"jukebox"` // 服务器本机播放，由 /jukebox 控制
+	DLNA               bool     `json:"dlna"`
+	Cast               bool     `json:"cast"`           // Chromecast 投屏，由 /cast 控制
+	AuthProviders      []string `json:"auth_providers"` // local、ldap、oidc
+}
+
+// ServerCapabilities 客户端用于功能探测的服务信息
+type ServerCapabilities struct {
+	Name             string         `json:"name"`
+	Version          string         `json:"version"`
+	APIVersion       string         `json:"api_version"`
+	APIRevision      int            `json:"api_revision"`
+	Features         ServerFeatures `json:"features"`
+	AudioFormats     []string       `json:"audio_formats"`     // 可扫描入库的扩展名
+	TranscodeFormats []string       `json:"transcode_formats"` // 可请求的转码目标格式
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// FolderScopeKey 可见媒体库中间件写入 gin.Context 的键
+const FolderScopeKey = "x-folder-scope"
+
+type folderScopeContextKey struct{}
+
+// FolderScope 调用方可访问的媒体库，IDs 与 Paths 一一对应；两者为空表示没有可访问的媒体库
+type FolderScope struct {
+	IDs   []string
+	Paths []string
+}
+
+// Allows 判断指定媒体库ID是否在可访问范围内
+func (s *FolderScope) Allows(folderID string) bool {
+	if s == nil {
+		return true
+	}
+	for _, id := range s.IDs {
+		if id == folderID {
+			return true
+		}
+	}
+	return false
+}
+
+// Key 可见范围的缓存键片段，与媒体库顺序无关；不受限时为 "all"
+func (s *FolderScope) Key() string {
+	if s == nil {
+		return "all"
+	}
+	ids := append([]string(nil), s.IDs...)
+	sort.Strings(ids)
+	return "folders:" + strings.Join(ids, ",")
+}
+
+// WithFolderScope scope 为 nil 表示不限制，管理员与后台任务均不设置
+func WithFolderScope(ctx context.Context, scope *FolderScope) context.Context {
+	return context.WithValue(ctx, folderScopeContextKey{}, scope)
+}
+
+// FolderScopeFromContext 依次读取请求 context 与 gin.Context 中的可见媒体库，返回 nil 表示可访问全部媒体库
+func FolderScopeFromContext(ctx context.Context) *FolderScope {
+	if ctx == nil {
+		return nil
+	}
+	if scope, ok := ctx.Value(folderScopeContextKey{}).(*FolderScope); ok {
+		return scope
+	}
+	scope, _ := ctx.Value(FolderScopeKey).(*FolderScope)
+	return scope
+}
@@ -93,6 +93,41 @@ func (_m *UserRepository) GetByID(c context.Context, id string) (domain.User, er
 	return r0, r1
 }
 
+// UpdateRoles provides a mock function with given fields: c, id, roles
+func (_m *UserRepository) UpdateRoles(c context.Context, id string, roles []string) error {
+	ret := _m.Called(c, id, roles)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = rf(c, id, roles)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByExternalID provides a mock function with given fields: c, source, externalID
+func (_m *UserRepository) GetByExternalID(c context.Context, source string, externalID string) (domain.User, error) {
+	ret := _m.Called(c, source, externalID)
+
+	var r0 domain.User
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) domain.User); ok {
+		r0 = rf(c, source, externalID)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(c, source, externalID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewUserRepository interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,25 @@
+package domain
+
+import "context"
+
+// UserIDKey 认证中间件写入 gin.Context 的用户ID键
+const UserIDKey = "x-user-id"
+
+type userIDContextKey struct{}
+
+// WithUserID 将用户ID写入请求 context，使仓库层在只拿到 Request.Context() 时也能按用户过滤
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDContextKey{}, userID)
+}
+
+// UserIDFromContext 依次读取请求 context 与 gin.Context 中的用户ID，未认证时返回空字符串
+func UserIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if userID, ok := ctx.Value(userIDContextKey{}).(string); ok {
+		return userID
+	}
+	userID, _ := ctx.Value(UserIDKey).(string)
+	return userID
+}
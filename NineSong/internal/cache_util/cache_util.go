@@ -0,0 +1,185 @@
+package cache_util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// 缓存命名空间，失效时整体作废
+const (
+	NamespaceFilterCounts = "filter_counts"
+	// NamespaceLists 专辑、艺术家列表与流派树的响应缓存，与筛选计数同时失效
+	NamespaceLists = "lists"
+	// NamespaceStaleLists 列表查询最近一次成功的结果，仅在数据库不可用时作为降级响应，不随数据变更失效
+	NamespaceStaleLists = "stale_lists"
+	// NamespaceStats 用户收听统计，只按 TTL 过期，不随播放失效
+	NamespaceStats = "stats"
+	// NamespaceExternal 外部元数据服务的原始响应，只按各服务的 TTL 过期
+	NamespaceExternal = "external"
+)
+
+// StaleListTTL 降级副本的保留时间
+const StaleListTTL = 24 * time.Hour
+
+// Cache 按命名空间分代的键值缓存，Invalidate 递增代号使旧键全部失效
+type Cache interface {
+	Get(ctx context.Context, namespace, key string) ([]byte, bool)
+	Set(ctx context.Context, namespace, key string, value []byte, ttl time.Duration)
+	Invalidate(ctx context.Context, namespace string)
+}
+
+// Stats 缓存实例的条目统计，Capacity 为 0 表示不限
+type Stats struct {
+	Backend  string `json:"backend"`
+	Entries  int64  `json:"entries"`
+	Capacity int    `json:"capacity"`
+}
+
+// StatsReporter 可选接口，实现后管理面板可展示缓存占用
+type StatsReporter interface {
+	Stats(ctx context.Context) (Stats, error)
+}
+
+var (
+	defaultMu    sync.RWMutex
+	defaultCache Cache = NewMemoryCache(1024)
+	defaultTTL         = 5 * time.Minute
+)
+
+// Configure 替换进程内共享的缓存实例，启动时调用
+func Configure(c Cache, ttl time.Duration) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultCache = c
+	if ttl > 0 {
+		defaultTTL = ttl
+	}
+}
+
+func Default() Cache {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultCache
+}
+
+func DefaultTTL() time.Duration {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultTTL
+}
+
+// CurrentStats 返回共享缓存实例的统计，未实现 StatsReporter 时 Entries 为 -1
+func CurrentStats(ctx context.Context) (Stats, error) {
+	if reporter, ok := Default().(StatsReporter); ok {
+		return reporter.Stats(ctx)
+	}
+	return Stats{Backend: "unknown", Entries: -1}, nil
+}
+
+// Invalidate 作废命名空间下的全部缓存
+func Invalidate(ctx context.Context, namespaces ...string) {
+	for _, ns := range namespaces {
+		Default().Invalidate(ctx, ns)
+	}
+}
+
+// Key 将参数拼接为缓存键
+func Key(parts ...string) string {
+	return strings.Join(parts, "|")
+}
+
+// GetOrLoad 命中时直接解码返回，否则调用 load 并写回缓存；缓存读写失败不影响结果
+func GetOrLoad[T any](
+	ctx context.Context,
+	namespace, key string,
+	ttl time.Duration,
+	load func() (T, error),
+) (T, error) {
+	c := Default()
+	if raw, ok := c.Get(ctx, namespace, key); ok {
+		var cached T
+		if err := json.Unmarshal(raw, &cached); err == nil {
+			return cached, nil
+		}
+	}
+
+	value, err := coalesce(namespace, key, load)
+	if err != nil {
+		return value, err
+	}
+	if raw, err := json.Marshal(value); err == nil {
+		c.Set(ctx, namespace, key, raw, ttl)
+	} else {
+		log.Printf("缓存序列化失败 %s: %v", namespace, err)
+	}
+	return value, nil
+}
+
+// GetOrStale 总是调用 load 并保存成功结果；load 因 stale(err) 判定的错误失败时返回已保存的副本
+func GetOrStale[T any](
+	ctx context.Context,
+	namespace, key string,
+	ttl time.Duration,
+	load func() (T, error),
+	stale func(error) bool,
+) (T, error) {
+	c := Default()
+	value, err := coalesce(namespace, key, load)
+	if err == nil {
+		if raw, err := json.Marshal(value); err == nil {
+			c.Set(ctx, namespace, key, raw, ttl)
+		}
+		return value, nil
+	}
+	if !stale(err) {
+		return value, err
+	}
+
+	// 原请求的 ctx 可能已超时，读取副本改用独立的短超时
+	readCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Second)
+	defer cancel()
+	if raw, ok := c.Get(readCtx, namespace, key); ok {
+		var cached T
+		if json.Unmarshal(raw, &cached) == nil {
+			log.Printf("数据库不可用，返回降级数据 %s: %v", namespace, err)
+			return cached, nil
+		}
+	}
+	return value, err
+}
+
+// flights 合并进行中的相同查询，如界面发布后大量客户端同时请求首页专辑
+var flights singleflight.Group
+
+// coalesce 相同 namespace 与 key 的并发 load 只执行一次；同一 namespace 与 key 须对应同一类型。
+// 共享结果时每个调用方得到独立副本，避免调用方原地修改同一切片
+func coalesce[T any](namespace, key string, load func() (T, error)) (T, error) {
+	v, err, shared := flights.Do(namespace+"\x00"+key, func() (interface{}, error) {
+		return load()
+	})
+	value, _ := v.(T)
+	if err != nil || !shared {
+		return value, err
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return value, nil
+	}
+	var copied T
+	if err := json.Unmarshal(raw, &copied); err != nil {
+		return value, nil
+	}
+	return copied, nil
+}
+
+func namespacedKey(namespace string, generation int64, key string) string {
+	return fmt.Sprintf("%s:%d:%s", namespace, generation, key)
+}
@@ -0,0 +1,92 @@
+package cache_util
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// memoryCache 带过期时间的LRU缓存
+type memoryCache struct {
+	mu          sync.Mutex
+	capacity    int
+	order       *list.List
+	items       map[string]*list.Element
+	generations map[string]int64
+}
+
+func NewMemoryCache(capacity int) Cache {
+	if capacity <= 0 {
+		capacity = 1024
+	}
+	return &memoryCache{
+		capacity:    capacity,
+		order:       list.New(),
+		items:       make(map[string]*list.Element),
+		generations: make(map[string]int64),
+	}
+}
+
+func (c *memoryCache) Get(_ context.Context, namespace, key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	k := namespacedKey(namespace, c.generations[namespace], key)
+	elem, ok := c.items[k]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*memoryEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.removeElement(elem)
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+func (c *memoryCache) Set(_ context.Context, namespace, key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	k := namespacedKey(namespace, c.generations[namespace], key)
+	expiresAt := time.Now().Add(ttl)
+	if elem, ok := c.items[k]; ok {
+		entry := elem.Value.(*memoryEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.items[k] = c.order.PushFront(&memoryEntry{key: k, value: value, expiresAt: expiresAt})
+	for c.order.Len() > c.capacity {
+		c.removeElement(c.order.Back())
+	}
+}
+
+// Stats 条目数包含已失效但尚未淘汰的旧代号条目
+func (c *memoryCache) Stats(_ context.Context) (Stats, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Stats{Backend: "memory", Entries: int64(len(c.items)), Capacity: c.capacity}, nil
+}
+
+// Invalidate 旧代号的条目不再可达，随LRU淘汰或过期清理
+func (c *memoryCache) Invalidate(_ context.Context, namespace string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generations[namespace]++
+}
+
+func (c *memoryCache) removeElement(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.items, elem.Value.(*memoryEntry).key)
+}
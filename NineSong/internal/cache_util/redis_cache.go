@@ -0,0 +1,78 @@
+package cache_util
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+)
+
+const redisKeyPrefix = "ninesong:cache:"
+
+// redisCache 多实例部署时共享缓存与失效代号；Redis 不可用时按未命中处理
+type redisCache struct {
+	client *RedisClient
+}
+
+func NewRedisCache(client *RedisClient) Cache {
+	return &redisCache{client: client}
+}
+
+func (c *redisCache) Get(ctx context.Context, namespace, key string) ([]byte, bool) {
+	generation, err := c.generation(ctx, namespace)
+	if err != nil {
+		log.Printf("读取缓存代号失败 %s: %v", namespace, err)
+		return nil, false
+	}
+	reply, err := c.client.Do(ctx, "GET", redisKeyPrefix+namespacedKey(namespace, generation, key))
+	if err != nil {
+		if !errors.Is(err, ErrRedisNil) {
+			log.Printf("读取缓存失败 %s: %v", namespace, err)
+		}
+		return nil, false
+	}
+	value, ok := reply.(string)
+	return []byte(value), ok
+}
+
+func (c *redisCache) Set(ctx context.Context, namespace, key string, value []byte, ttl time.Duration) {
+	generation, err := c.generation(ctx, namespace)
+	if err != nil {
+		log.Printf("读取缓存代号失败 %s: %v", namespace, err)
+		return
+	}
+	if _, err := c.client.Do(ctx, "SET", redisKeyPrefix+namespacedKey(namespace, generation, key), string(value),
+		"PX", strconv.FormatInt(ttl.Milliseconds(), 10)); err != nil {
+		log.Printf("写入缓存失败 %s: %v", namespace, err)
+	}
+}
+
+// Invalidate 旧代号的键由 TTL 自然过期
+func (c *redisCache) Invalidate(ctx context.Context, namespace string) {
+	if _, err := c.client.Do(ctx, "INCR", redisKeyPrefix+"gen:"+namespace); err != nil {
+		log.Printf("缓存失效失败 %s: %v", namespace, err)
+	}
+}
+
+// Stats 条目数为所在 Redis 库的键总数，与其他用途共用库时偏大
+func (c *redisCache) Stats(ctx context.Context) (Stats, error) {
+	reply, err := c.client.Do(ctx, "DBSIZE")
+	if err != nil {
+		return Stats{Backend: "redis", Entries: -1}, err
+	}
+	entries, _ := reply.(int64)
+	return Stats{Backend: "redis", Entries: entries}, nil
+}
+
+func (c *redisCache) generation(ctx context.Context, namespace string) (int64, error) {
+	reply, err := c.client.Do(ctx, "GET", redisKeyPrefix+"gen:"+namespace)
+	if errors.Is(err, ErrRedisNil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	value, _ := reply.(string)
+	return strconv.ParseInt(value, 10, 64)
+}
@@ -0,0 +1,222 @@
+package cache_util
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+// ErrRedisNil 键不存在
+var ErrRedisNil = errors.New("redis: nil")
+
+// RedisClient 仅实现 RESP2 协议下本项目用到的命令，带简单连接池
+type RedisClient struct {
+	addr     string
+	password string
+	db       int
+	timeout  time.Duration
+	pool     chan *redisConn
+}
+
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func NewRedisClient(addr, password string, db int) *RedisClient {
+	return &RedisClient{
+		addr:     addr,
+		password: password,
+		db:       db,
+		timeout:  3 * time.Second,
+		pool:     make(chan *redisConn, 8),
+	}
+}
+
+// Do 执行单条命令，返回 string、int64、nil 或 []interface{}
+func (c *RedisClient) Do(ctx context.Context, args ...string) (interface{}, error) {
+	conn, err := c.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(c.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = conn.conn.SetDeadline(deadline)
+
+	reply, err := conn.roundTrip(args)
+	if err != nil && !errors.Is(err, ErrRedisNil) {
+		var redisErr redisError
+		if !errors.As(err, &redisErr) {
+			// 网络错误后连接状态不可知，直接丢弃
+			_ = conn.conn.Close()
+			return nil, err
+		}
+	}
+	c.put(conn)
+	return reply, err
+}
+
+func (c *RedisClient) get(ctx context.Context) (*redisConn, error) {
+	select {
+	case conn := <-c.pool:
+		return conn, nil
+	default:
+	}
+
+	dialer := net.Dialer{Timeout: c.timeout}
+	nc, err := dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return nil, fmt.Errorf("连接redis失败: %w", err)
+	}
+	conn := &redisConn{conn: nc, reader: bufio.NewReader(nc)}
+	_ = nc.SetDeadline(time.Now().Add(c.timeout))
+	if c.password != "" {
+		if _, err := conn.roundTrip([]string{"AUTH", c.password}); err != nil {
+			_ = nc.Close()
+			return nil, fmt.Errorf("redis认证失败: %w", err)
+		}
+	}
+	if c.db != 0 {
+		if _, err := conn.roundTrip([]string{"SELECT", strconv.Itoa(c.db)}); err != nil {
+			_ = nc.Close()
+			return nil, fmt.Errorf("redis选择数据库失败: %w", err)
+		}
+	}
+	return conn, nil
+}
+
+func (c *RedisClient) put(conn *redisConn) {
+	select {
+	case c.pool <- conn:
+	default:
+		_ = conn.conn.Close()
+	}
+}
+
+type redisError string
+
+func (e redisError) Error() string { return "redis: " + string(e) }
+
+func (rc *redisConn) roundTrip(args []string) (interface{}, error) {
+	buf := make([]byte, 0, 64)
+	buf = append(buf, '*')
+	buf = strconv.AppendInt(buf, int64(len(args)), 10)
+	buf = append(buf, '\r', '\n')
+	for _, arg := range args {
+		buf = append(buf, '$')
+		buf = strconv.AppendInt(buf, int64(len(arg)), 10)
+		buf = append(buf, '\r', '\n')
+		buf = append(buf, arg...)
+		buf = append(buf, '\r', '\n')
+	}
+	if _, err := rc.conn.Write(buf); err != nil {
+		return nil, err
+	}
+	return rc.readReply()
+}
+
+func (rc *redisConn) readReply() (interface{}, error) {
+	line, err := rc.reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 {
+		return nil, errors.New("redis: invalid reply")
+	}
+	body := line[1 : len(line)-2]
+
+	switch line[0] {
+	case '+':
+		return body, nil
+	case '-':
+		return nil, redisError(body)
+	case ':':
+		return strconv.ParseInt(body, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, ErrRedisNil
+		}
+		data := make([]byte, n+2)
+		if _, err := io.ReadFull(rc.reader, data); err != nil {
+			return nil, err
+		}
+		return string(data[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, ErrRedisNil
+		}
+		items := make([]interface{}, 0, n)
+		for i := 0; i < n; i++ {
+			item, err := rc.readReply()
+			if err != nil && !errors.Is(err, ErrRedisNil) {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	}
+	return nil, fmt.Errorf("redis: unknown reply type %q", line[0])
+}
+
+// RedisSubscription 独占一条连接的 PSUBSCRIBE 会话，连接断开后需重新订阅
+type RedisSubscription struct {
+	conn *redisConn
+}
+
+// PSubscribe 新建连接并按模式订阅频道，连接不进入连接池
+func (c *RedisClient) PSubscribe(ctx context.Context, patterns ...string) (*RedisSubscription, error) {
+	conn, err := c.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	_ = conn.conn.SetDeadline(time.Now().Add(c.timeout))
+	for _, pattern := range patterns {
+		if _, err := conn.roundTrip([]string{"PSUBSCRIBE", pattern}); err != nil {
+			_ = conn.conn.Close()
+			return nil, fmt.Errorf("redis订阅失败: %w", err)
+		}
+	}
+	// 订阅连接长期阻塞读取推送
+	_ = conn.conn.SetDeadline(time.Time{})
+	return &RedisSubscription{conn: conn}, nil
+}
+
+// Receive 阻塞读取下一条消息，跳过订阅确认等非消息推送
+func (s *RedisSubscription) Receive() (channel, payload string, err error) {
+	for {
+		reply, err := s.conn.readReply()
+		if err != nil {
+			return "", "", err
+		}
+		items, ok := reply.([]interface{})
+		if !ok || len(items) != 4 {
+			continue
+		}
+		if kind, _ := items[0].(string); kind != "pmessage" {
+			continue
+		}
+		channel, _ = items[2].(string)
+		payload, _ = items[3].(string)
+		return channel, payload, nil
+	}
+}
+
+func (s *RedisSubscription) Close() error {
+	return s.conn.conn.Close()
+}
@@ -0,0 +1,490 @@
+package cast_util
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultMediaReceiver Google 提供的默认媒体接收端应用
+	defaultMediaReceiver = "CC1AD845"
+
+	dialTimeout       = 5 * time.Second
+	heartbeatInterval = 5 * time.Second
+	// heartbeatTimeout 超过该时间未收到设备任何消息即视为断开
+	heartbeatTimeout = 3 * heartbeatInterval
+)
+
+// 播放状态，取值与 Cast 媒体协议一致
+const (
+	PlayerStateIdle      = "IDLE"
+	PlayerStatePlaying   = "PLAYING"
+	PlayerStatePaused    = "PAUSED"
+	PlayerStateBuffering = "BUFFERING"
+)
+
+var (
+	ErrDisconnected = errors.New("cast device disconnected")
+	ErrNoMedia      = errors.New("no media loaded on cast device")
+)
+
+// Media LOAD 请求中的媒体信息，Duration 单位为秒
+type Media struct {
+	ContentID   string
+	ContentType string
+	Title       string
+	Artist      string
+	Album       string
+	ImageURL    string
+	Duration    float64
+}
+
+// Status 设备当前状态，由设备主动推送的 RECEIVER_STATUS 与 MEDIA_STATUS 合并得到
+type Status struct {
+	PlayerState string
+	IdleReason  string
+	ContentID   string
+	CurrentTime float64
+	Duration    float64
+	Volume      float64 // 0-1
+	Muted       bool
+	UpdatedAt   time.Time
+}
+
+type receiverStatus struct {
+	Applications []struct {
+		AppID       string `json:"appId"`
+		SessionID   string `json:"sessionId"`
+		TransportID string `json:"transportId"`
+	} `json:"applications"`
+	Volume struct {
+		Level *float64 `json:"level"`
+		Muted *bool    `json:"muted"`
+	} `json:"volume"`
+}
+
+type mediaStatus struct {
+	MediaSessionID int     `json:"mediaSessionId"`
+	PlayerState    string  `json:"playerState"`
+	IdleReason     string  `json:"idleReason"`
+	CurrentTime    float64 `json:"currentTime"`
+	Media          *struct {
+		ContentID string  `json:"contentId"`
+		Duration  float64 `json:"duration"`
+	} `json:"media"`
+}
+
+type response struct {
+	Type      string          `json:"type"`
+	RequestID int             `json:"requestId"`
+	Status    json.RawMessage `json:"status"`
+	Reason    string          `json:"reason"`
+}
+
+// Client 与单台设备的 CastV2 长连接；读循环处理心跳并把推送的状态合并到 Status，
+// 命令按 requestId 等待对应应答
+type Client struct {
+	conn *tls.Conn
+
+	writeMu sync.Mutex
+
+	mu             sync.Mutex
+	nextID         int
+	pending        map[int]chan response
+	transportID    string
+	appSessionID   string
+	mediaSessionID int
+	status         Status
+
+	done     chan struct{}
+	closed   sync.Once
+	lastSeen time.Time
+}
+
+// Dial 设备使用自签名证书，按 Cast 发送端的惯例不校验证书
+func Dial(ctx context.Context, host string, port int) (*Client, error) {
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: dialTimeout},
+		Config:    &tls.Config{InsecureSkipVerify: true},
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return nil, fmt.Errorf("cast dial: %w", err)
+	}
+
+	c := &Client{
+		conn:     conn.(*tls.Conn),
+		pending:  make(map[int]chan response),
+		done:     make(chan struct{}),
+		lastSeen: time.Now(),
+	}
+	if err := c.send(receiverID, namespaceConnection, map[string]any{"type": "CONNECT"}); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	go c.readLoop()
+	go c.heartbeat()
+	return c, nil
+}
+
+// Done 连接断开后关闭
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+func (c *Client) Close() {
+	c.closed.Do(func() {
+		close(c.done)
+		_ = c.conn.Close()
+		c.mu.Lock()
+		for id, ch := range c.pending {
+			close(ch)
+			delete(c.pending, id)
+		}
+		c.mu.Unlock()
+	})
+}
+
+func (c *Client) Status() Status {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	status := c.status
+	// 播放中按上次推送后经过的时间推算进度，避免频繁查询设备
+	if status.PlayerState == PlayerStatePlaying && !status.UpdatedAt.IsZero() {
+		status.CurrentTime += time.Since(status.UpdatedAt).Seconds()
+		if status.Duration > 0 && status.CurrentTime > status.Duration {
+			status.CurrentTime = status.Duration
+		}
+	}
+	return status
+}
+
+// Load 启动默认媒体接收端（已在运行时直接复用）并载入媒体，startTime 为起始秒数
+func (c *Client) Load(ctx context.Context, media Media, startTime float64) error {
+	if err := c.ensureApp(ctx); err != nil {
+		return err
+	}
+
+	metadata := map[string]any{
+		"metadataType": 3, // MusicTrackMediaMetadata
+		"title":        media.Title,
+		"artist":       media.Artist,
+		"albumName":    media.Album,
+	}
+	if media.ImageURL != "" {
+		metadata["images"] = []map[string]any{{"url": media.ImageURL}}
+	}
+	mediaInfo := map[string]any{
+		"contentId":   media.ContentID,
+		"contentType": media.ContentType,
+		"streamType":  "BUFFERED",
+		"metadata":    metadata,
+	}
+	if media.Duration > 0 {
+		mediaInfo["duration"] = media.Duration
+	}
+
+	c.mu.Lock()
+	transportID, appSessionID := c.transportID, c.appSessionID
+	c.mu.Unlock()
+	_, err := c.request(ctx, transportID, namespaceMedia, map[string]any{
+		"type":        "LOAD",
+		"sessionId":   appSessionID,
+		"media":       mediaInfo,
+		"autoplay":    true,
+		"currentTime": startTime,
+	})
+	return err
+}
+
+func (c *Client) Play(ctx context.Context) error {
+	return c.mediaCommand(ctx, map[string]any{"type": "PLAY"})
+}
+
+func (c *Client) Pause(ctx context.Context) error {
+	return c.mediaCommand(ctx, map[string]any{"type": "PAUSE"})
+}
+
+func (c *Client) Seek(ctx context.Context, seconds float64) error {
+	return c.mediaCommand(ctx, map[string]any{"type": "SEEK", "currentTime": seconds})
+}
+
+// Stop 停止播放并退出接收端应用，电视回到待机画面
+func (c *Client) Stop(ctx context.Context) error {
+	c.mu.Lock()
+	appSessionID := c.appSessionID
+	c.mu.Unlock()
+	if appSessionID == "" {
+		return nil
+	}
+	_, err := c.request(ctx, receiverID, namespaceReceiver, map[string]any{"type": "STOP", "sessionId": appSessionID})
+	return err
+}
+
+// SetVolume level 取值 0-1
+func (c *Client) SetVolume(ctx context.Context, level float64) error {
+	_, err := c.request(ctx, receiverID, namespaceReceiver, map[string]any{
+		"type":   "SET_VOLUME",
+		"volume": map[string]any{"level": level},
+	})
+	return err
+}
+
+func (c *Client) SetMuted(ctx context.Context, muted bool) error {
+	_, err := c.request(ctx, receiverID, namespaceReceiver, map[string]any{
+		"type":   "SET_VOLUME",
+		"volume": map[string]any{"muted": muted},
+	})
+	return err
+}
+
+// Refresh 主动查询接收端与媒体状态
+func (c *Client) Refresh(ctx context.Context) error {
+	if _, err := c.request(ctx, receiverID, namespaceReceiver, map[string]any{"type": "GET_STATUS"}); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	transportID := c.transportID
+	c.mu.Unlock()
+	if transportID == "" {
+		return nil
+	}
+	_, err := c.request(ctx, transportID, namespaceMedia, map[string]any{"type": "GET_STATUS"})
+	return err
+}
+
+func (c *Client) mediaCommand(ctx context.Context, payload map[string]any) error {
+	c.mu.Lock()
+	transportID, mediaSessionID := c.transportID, c.mediaSessionID
+	c.mu.Unlock()
+	if transportID == "" || mediaSessionID == 0 {
+		return ErrNoMedia
+	}
+	payload["mediaSessionId"] = mediaSessionID
+	_, err := c.request(ctx, transportID, namespaceMedia, payload)
+	return err
+}
+
+// ensureApp 确保默认媒体接收端在运行并已建立到该应用的虚拟连接
+func (c *Client) ensureApp(ctx context.Context) error {
+	c.mu.Lock()
+	running := c.transportID != ""
+	c.mu.Unlock()
+	if !running {
+		if _, err := c.request(ctx, receiverID, namespaceReceiver, map[string]any{"type": "LAUNCH", "appId": defaultMediaReceiver}); err != nil {
+			return err
+		}
+	}
+
+	c.mu.Lock()
+	transportID := c.transportID
+	c.mu.Unlock()
+	if transportID == "" {
+		return errors.New("cast: media receiver did not start")
+	}
+	return c.send(transportID, namespaceConnection, map[string]any{"type": "CONNECT"})
+}
+
+// request 发送带 requestId 的命令并等待应答；LOAD_FAILED、INVALID_REQUEST 等应答转为错误
+func (c *Client) request(ctx context.Context, destination, namespace string, payload map[string]any) (response, error) {
+	ch := make(chan response, 1)
+	c.mu.Lock()
+	c.nextID++
+	id := c.nextID
+	c.pending[id] = ch
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+	}()
+
+	payload["requestId"] = id
+	if err := c.send(destination, namespace, payload); err != nil {
+		return response{}, err
+	}
+
+	select {
+	case resp, ok := <-ch:
+		if !ok {
+			return response{}, ErrDisconnected
+		}
+		switch resp.Type {
+		case "LOAD_FAILED", "LOAD_CANCELLED", "INVALID_REQUEST", "INVALID_PLAYER_STATE", "LAUNCH_ERROR":
+			if resp.Reason != "" {
+				return resp, fmt.Errorf("cast: %s (%s)", resp.Type, resp.Reason)
+			}
+			return resp, fmt.Errorf("cast: %s", resp.Type)
+		}
+		return resp, nil
+	case <-c.done:
+		return response{}, ErrDisconnected
+	case <-ctx.Done():
+		return response{}, ctx.Err()
+	}
+}
+
+func (c *Client) send(destination, namespace string, payload map[string]any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	select {
+	case <-c.done:
+		return ErrDisconnected
+	default:
+	}
+	_ = c.conn.SetWriteDeadline(time.Now().Add(dialTimeout))
+	if err := writeFrame(c.conn, castMessage{
+		SourceID:      senderID,
+		DestinationID: destination,
+		Namespace:     namespace,
+		Payload:       string(data),
+	}); err != nil {
+		c.Close()
+		return fmt.Errorf("%w: %v", ErrDisconnected, err)
+	}
+	return nil
+}
+
+func (c *Client) heartbeat() {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			c.mu.Lock()
+			silent := time.Since(c.lastSeen)
+			c.mu.Unlock()
+			if silent > heartbeatTimeout {
+				log.Printf("Cast 设备心跳超时: %s", c.conn.RemoteAddr())
+				c.Close()
+				return
+			}
+			_ = c.send(receiverID, namespaceHeartbeat, map[string]any{"type": "PING"})
+		}
+	}
+}
+
+func (c *Client) readLoop() {
+	defer c.Close()
+	for {
+		msg, err := readFrame(c.conn)
+		if err != nil {
+			return
+		}
+		c.mu.Lock()
+		c.lastSeen = time.Now()
+		c.mu.Unlock()
+
+		var resp response
+		if err := json.Unmarshal([]byte(msg.Payload), &resp); err != nil {
+			continue
+		}
+
+		switch msg.Namespace {
+		case namespaceHeartbeat:
+			if resp.Type == "PING" {
+				_ = c.send(msg.SourceID, namespaceHeartbeat, map[string]any{"type": "PONG"})
+			}
+			continue
+		case namespaceConnection:
+			// 接收端应用关闭了虚拟连接，之后的媒体命令需要重新启动应用
+			if resp.Type == "CLOSE" {
+				c.mu.Lock()
+				if msg.SourceID == c.transportID {
+					c.clearAppLocked()
+				}
+				c.mu.Unlock()
+			}
+			continue
+		case namespaceReceiver:
+			if resp.Type == "RECEIVER_STATUS" {
+				c.applyReceiverStatus(resp.Status)
+			}
+		case namespaceMedia:
+			if resp.Type == "MEDIA_STATUS" {
+				c.applyMediaStatus(resp.Status)
+			}
+		}
+
+		if resp.RequestID != 0 {
+			c.mu.Lock()
+			if ch, ok := c.pending[resp.RequestID]; ok {
+				select {
+				case ch <- resp:
+				default:
+				}
+			}
+			c.mu.Unlock()
+		}
+	}
+}
+
+func (c *Client) applyReceiverStatus(raw json.RawMessage) {
+	var status receiverStatus
+	if err := json.Unmarshal(raw, &status); err != nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if status.Volume.Level != nil {
+		c.status.Volume = *status.Volume.Level
+	}
+	if status.Volume.Muted != nil {
+		c.status.Muted = *status.Volume.Muted
+	}
+	for _, app := range status.Applications {
+		if app.AppID == defaultMediaReceiver {
+			c.transportID, c.appSessionID = app.TransportID, app.SessionID
+			return
+		}
+	}
+	// 默认媒体接收端已退出，或被其他应用取代
+	c.clearAppLocked()
+}
+
+func (c *Client) applyMediaStatus(raw json.RawMessage) {
+	var statuses []mediaStatus
+	if err := json.Unmarshal(raw, &statuses); err != nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(statuses) == 0 {
+		c.mediaSessionID = 0
+		c.status.PlayerState = PlayerStateIdle
+		c.status.UpdatedAt = time.Now()
+		return
+	}
+	s := statuses[0]
+	c.mediaSessionID = s.MediaSessionID
+	c.status.PlayerState = s.PlayerState
+	c.status.IdleReason = s.IdleReason
+	c.status.CurrentTime = s.CurrentTime
+	c.status.UpdatedAt = time.Now()
+	if s.Media != nil {
+		c.status.ContentID = s.Media.ContentID
+		if s.Media.Duration > 0 {
+			c.status.Duration = s.Media.Duration
+		}
+	}
+}
+
+func (c *Client) clearAppLocked() {
+	c.transportID, c.appSessionID, c.mediaSessionID = "", "", 0
+	c.status.PlayerState = PlayerStateIdle
+	c.status.UpdatedAt = time.Now()
+}
@@ -0,0 +1,173 @@
+package cast_util
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	mdnsAddress = "224.0.0.251:5353"
+	castService = "_googlecast._tcp.local."
+	// DefaultPort 设备未通告 SRV 记录时使用的 CastV2 端口
+	DefaultPort = 8009
+)
+
+// Device 局域网内通过 mDNS 发现的 Chromecast 设备，ID 取 TXT 记录中的 id
+type Device struct {
+	ID    string
+	Name  string
+	Model string
+	Host  string
+	Port  int
+}
+
+// Discover 发送一次 mDNS 查询并收集 timeout 内的应答；
+// 查询从临时端口发出，设备按 RFC 6762 的传统单播方式直接回复，无需加入组播组
+func Discover(ctx context.Context, timeout time.Duration) ([]Device, error) {
+	group, err := net.ResolveUDPAddr("udp4", mdnsAddress)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4zero})
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	query, err := buildQuery()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.WriteToUDP(query, group); err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetReadDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	records := newRecordSet()
+	buf := make([]byte, 9000)
+	for {
+		n, from, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				break
+			}
+			return nil, err
+		}
+		records.add(buf[:n], from.IP)
+	}
+	return records.devices(), nil
+}
+
+func buildQuery() ([]byte, error) {
+	name, err := dnsmessage.NewName(castService)
+	if err != nil {
+		return nil, err
+	}
+	msg := dnsmessage.Message{
+		Questions: []dnsmessage.Question{{Name: name, Type: dnsmessage.TypePTR, Class: dnsmessage.ClassINET}},
+	}
+	return msg.Pack()
+}
+
+type srvRecord struct {
+	target string
+	port   int
+}
+
+// recordSet 汇总多个应答包中的记录，设备可能把 PTR、SRV、TXT、A 分在不同的包中发送
+type recordSet struct {
+	instances map[string]struct{}
+	srv       map[string]srvRecord
+	txt       map[string]map[string]string
+	addr      map[string]net.IP
+	source    map[string]net.IP // 实例名对应的应答来源地址，缺少 A 记录时使用
+}
+
+func newRecordSet() *recordSet {
+	return &recordSet{
+		instances: make(map[string]struct{}),
+		srv:       make(map[string]srvRecord),
+		txt:       make(map[string]map[string]string),
+		addr:      make(map[string]net.IP),
+		source:    make(map[string]net.IP),
+	}
+}
+
+func (s *recordSet) add(packet []byte, from net.IP) {
+	var msg dnsmessage.Message
+	if err := msg.Unpack(packet); err != nil {
+		return
+	}
+	resources := append(append(msg.Answers, msg.Additionals...), msg.Authorities...)
+	for _, rr := range resources {
+		name := strings.ToLower(rr.Header.Name.String())
+		switch body := rr.Body.(type) {
+		case *dnsmessage.PTRResource:
+			if name == castService {
+				instance := strings.ToLower(body.PTR.String())
+				s.instances[instance] = struct{}{}
+				s.source[instance] = from
+			}
+		case *dnsmessage.SRVResource:
+			s.srv[name] = srvRecord{target: strings.ToLower(body.Target.String()), port: int(body.Port)}
+		case *dnsmessage.TXTResource:
+			fields := make(map[string]string, len(body.TXT))
+			for _, entry := range body.TXT {
+				if key, value, ok := strings.Cut(entry, "="); ok {
+					fields[strings.ToLower(key)] = value
+				}
+			}
+			s.txt[name] = fields
+		case *dnsmessage.AResource:
+			s.addr[name] = net.IP(body.A[:])
+		}
+	}
+}
+
+func (s *recordSet) devices() []Device {
+	devices := make([]Device, 0, len(s.instances))
+	seen := make(map[string]bool)
+	for instance := range s.instances {
+		txt := s.txt[instance]
+		device := Device{
+			ID:    txt["id"],
+			Name:  txt["fn"],
+			Model: txt["md"],
+			Port:  DefaultPort,
+		}
+		if device.ID == "" {
+			device.ID = strings.TrimSuffix(instance, "."+castService)
+		}
+		if device.Name == "" {
+			device.Name = device.ID
+		}
+
+		host := s.source[instance]
+		if srv, ok := s.srv[instance]; ok {
+			device.Port = srv.port
+			if ip, ok := s.addr[srv.target]; ok {
+				host = ip
+			}
+		}
+		if host == nil || seen[device.ID] {
+			continue
+		}
+		device.Host = host.String()
+		seen[device.ID] = true
+		devices = append(devices, device)
+	}
+	return devices
+}
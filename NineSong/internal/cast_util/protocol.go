@@ -0,0 +1,112 @@
+package cast_util
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// CastV2 协议命名空间
+const (
+	namespaceConnection = "urn:x-cast:com.google.cast.tp.connection"
+	namespaceHeartbeat  = "urn:x-cast:com.google.cast.tp.heartbeat"
+	namespaceReceiver   = "urn:x-cast:com.google.cast.receiver"
+	namespaceMedia      = "urn:x-cast:com.google.cast.media"
+
+	senderID   = "sender-0"
+	receiverID = "receiver-0"
+
+	// maxFrameSize 单条消息上限，超出视为协议错误
+	maxFrameSize = 64 << 10
+)
+
+// castMessage 对应 cast_channel.proto 中的 CastMessage，只使用字符串载荷
+type castMessage struct {
+	SourceID      string
+	DestinationID string
+	Namespace     string
+	Payload       string
+}
+
+// CastMessage 字段较少，按 protobuf 线格式手工编解码，避免引入 protobuf 依赖
+func (m castMessage) marshal() []byte {
+	buf := make([]byte, 0, 32+len(m.SourceID)+len(m.DestinationID)+len(m.Namespace)+len(m.Payload))
+	buf = append(buf, 0x08, 0x00) // protocol_version = CASTV2_1_0
+	buf = appendField(buf, 2, m.SourceID)
+	buf = appendField(buf, 3, m.DestinationID)
+	buf = appendField(buf, 4, m.Namespace)
+	buf = append(buf, 0x28, 0x00) // payload_type = STRING
+	buf = appendField(buf, 6, m.Payload)
+	return buf
+}
+
+func appendField(buf []byte, field int, value string) []byte {
+	buf = binary.AppendUvarint(buf, uint64(field<<3|2))
+	buf = binary.AppendUvarint(buf, uint64(len(value)))
+	return append(buf, value...)
+}
+
+func unmarshalCastMessage(data []byte) (castMessage, error) {
+	var m castMessage
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return m, errors.New("cast: malformed field key")
+		}
+		data = data[n:]
+		field, wireType := key>>3, key&7
+
+		switch wireType {
+		case 0:
+			if _, n = binary.Uvarint(data); n <= 0 {
+				return m, errors.New("cast: malformed varint")
+			}
+			data = data[n:]
+		case 2:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < length {
+				return m, errors.New("cast: malformed length")
+			}
+			value := string(data[n : n+int(length)])
+			data = data[n+int(length):]
+			switch field {
+			case 2:
+				m.SourceID = value
+			case 3:
+				m.DestinationID = value
+			case 4:
+				m.Namespace = value
+			case 6:
+				m.Payload = value
+			}
+		default:
+			return m, fmt.Errorf("cast: unsupported wire type %d", wireType)
+		}
+	}
+	return m, nil
+}
+
+func writeFrame(w io.Writer, m castMessage) error {
+	body := m.marshal()
+	frame := make([]byte, 4, 4+len(body))
+	binary.BigEndian.PutUint32(frame, uint32(len(body)))
+	_, err := w.Write(append(frame, body...))
+	return err
+}
+
+func readFrame(r io.Reader) (castMessage, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return castMessage{}, err
+	}
+	size := binary.BigEndian.Uint32(header[:])
+	if size > maxFrameSize {
+		return castMessage{}, fmt.Errorf("cast: frame too large (%d bytes)", size)
+	}
+	body := make([]byte, size)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return castMessage{}, err
+	}
+	return unmarshalCastMessage(body)
+}
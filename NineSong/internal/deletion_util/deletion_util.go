@@ -0,0 +1,94 @@
+package deletion_util
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const batchSize = 1000
+
+// DeleteMany 按过滤条件删除条目并写入删除日志，供 /changes 向客户端下发删除；
+// 先查出ID再按ID删除，保证日志与实际删除的条目一致
+func DeleteMany(ctx context.Context, db mongo.Database, collection, itemType string, filter interface{}) (int64, error) {
+	coll := db.Collection(collection)
+	cursor, err := coll.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return 0, fmt.Errorf("query deleting %s failed: %w", itemType, err)
+	}
+	var docs []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	err = cursor.All(ctx, &docs)
+	_ = cursor.Close(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("decode deleting %s failed: %w", itemType, err)
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+	return DeleteByIDs(ctx, db, collection, itemType, ids)
+}
+
+// DeleteOne 删除第一条匹配的条目并写入删除日志，返回删除数量
+func DeleteOne(ctx context.Context, db mongo.Database, collection, itemType string, filter interface{}) (int64, error) {
+	var doc struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := db.Collection(collection).FindOne(ctx, filter).Decode(&doc); err != nil {
+		if domain.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("query deleting %s failed: %w", itemType, err)
+	}
+	return DeleteByIDs(ctx, db, collection, itemType, []primitive.ObjectID{doc.ID})
+}
+
+// DeleteByIDs 分批删除并记录，已删除的批次即使后续失败也已写入日志
+func DeleteByIDs(ctx context.Context, db mongo.Database, collection, itemType string, ids []primitive.ObjectID) (int64, error) {
+	coll := db.Collection(collection)
+	var total int64
+	for i := 0; i < len(ids); i += batchSize {
+		end := i + batchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		batch := ids[i:end]
+		deleted, err := coll.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": batch}})
+		if err != nil {
+			return total, fmt.Errorf("delete %s failed: %w", itemType, err)
+		}
+		total += deleted
+		Record(ctx, db, itemType, batch...)
+	}
+	return total, nil
+}
+
+// Record 写入删除日志；写入失败只记录日志，不影响删除结果
+func Record(ctx context.Context, db mongo.Database, itemType string, ids ...primitive.ObjectID) {
+	if len(ids) == 0 {
+		return
+	}
+	now := time.Now().UTC()
+	records := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		records = append(records, scene_audio_route_models.DeletionRecord{
+			ItemType:  itemType,
+			ItemID:    id.Hex(),
+			DeletedAt: now,
+		})
+	}
+	if _, err := db.Collection(domain.CollectionFileEntityAudioSceneDeletionLog).InsertMany(ctx, records); err != nil {
+		log.Printf("删除日志写入失败 %s x%d: %v", itemType, len(ids), err)
+	}
+}
@@ -0,0 +1,372 @@
+package dlna_util
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+const (
+	DeviceType               = "urn:schemas-upnp-org:device:MediaServer:1"
+	ContentDirectoryService  = "urn:schemas-upnp-org:service:ContentDirectory:1"
+	ConnectionManagerService = "urn:schemas-upnp-org:service:ConnectionManager:1"
+)
+
+// 设备描述中引用的地址，与路由注册保持一致
+const (
+	DescriptionPath             = "/dlna/device.xml"
+	ContentDirectorySCPDPath    = "/dlna/ContentDirectory.xml"
+	ConnectionManagerSCPDPath   = "/dlna/ConnectionManager.xml"
+	ContentDirectoryControlPath = "/dlna/control/ContentDirectory"
+	ConnectionMgrControlPath    = "/dlna/control/ConnectionManager"
+	ContentDirectoryEventPath   = "/dlna/event/ContentDirectory"
+	ConnectionMgrEventPath      = "/dlna/event/ConnectionManager"
+	StreamPath                  = "/dlna/media/"
+	CoverPath                   = "/dlna/cover/"
+)
+
+// UPnP 错误码
+const (
+	ErrCodeInvalidAction = 401
+	ErrCodeInvalidArgs   = 402
+	ErrCodeActionFailed  = 501
+	ErrCodeNoSuchObject  = 701
+)
+
+var ErrInvalidSOAPRequest = errors.New("invalid soap request")
+
+// DeviceUUID 由主机名与显示名称确定性生成，重启后不变，电视等设备不会把同一服务器识别为新设备
+func DeviceUUID(friendlyName string) string {
+	host, _ := os.Hostname()
+	sum := sha1.Sum([]byte("ninesong-dlna:" + host + ":" + friendlyName))
+	sum[6] = (sum[6] & 0x0f) | 0x50
+	sum[8] = (sum[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
+
+// LocalBaseURL 监听地址未指定主机时取第一个非回环的 IPv4 地址，供 SSDP 通告设备描述地址
+func LocalBaseURL(serverAddress string) (string, error) {
+	host, port, err := net.SplitHostPort(serverAddress)
+	if err != nil {
+		return "", fmt.Errorf("invalid server address %q: %w", serverAddress, err)
+	}
+	if ip := net.ParseIP(host); host != "" && (ip == nil || !ip.IsUnspecified()) {
+		return "http://" + net.JoinHostPort(host, port), nil
+	}
+
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return "", err
+	}
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.To4() != nil {
+				return "http://" + net.JoinHostPort(ipNet.IP.String(), port), nil
+			}
+		}
+	}
+	return "", errors.New("no usable network interface")
+}
+
+// DeviceDescription 服务地址均为相对路径，由客户端按 LOCATION 解析
+func DeviceDescription(uuid, friendlyName string) []byte {
+	return []byte(xml.Header + `<root xmlns="urn:schemas-upnp-org:device-1-0" xmlns:dlna="urn:schemas-dlna-org:device-1-0">
+<specVersion><major>1</major><minor>0</minor></specVersion>
+<device>
+<deviceType>` + DeviceType + `</deviceType>
+<friendlyName>` + escape(friendlyName) + `</friendlyName>
+<manufacturer>NineSong</manufacturer>
+<modelName>NineSong</modelName>
+<modelDescription>NineSong Media Server</modelDescription>
+<UDN>uuid:` + uuid + `</UDN>
+<dlna:X_DLNADOC>DMS-1.50</dlna:X_DLNADOC>
+<serviceList>
+<service>
+<serviceType>` + ContentDirectoryService + `</serviceType>
+<serviceId>urn:upnp-org:serviceId:ContentDirectory</serviceId>
+<SCPDURL>` + ContentDirectorySCPDPath + `</SCPDURL>
+<controlURL>` + ContentDirectoryControlPath + `</controlURL>
+<eventSubURL>` + ContentDirectoryEventPath + `</eventSubURL>
+</service>
+<service>
+<serviceType>` + ConnectionManagerService + `</serviceType>
+<serviceId>urn:upnp-org:serviceId:ConnectionManager</serviceId>
+<SCPDURL>` + ConnectionManagerSCPDPath + `</SCPDURL>
+<controlURL>` + ConnectionMgrControlPath + `</controlURL>
+<eventSubURL>` + ConnectionMgrEventPath + `</eventSubURL>
+</service>
+</serviceList>
+</device>
+</root>`)
+}
+
+// ContentDirectorySCPD 只实现浏览，不支持搜索与修改
+const ContentDirectorySCPD = xml.Header + `<scpd xmlns="urn:schemas-upnp-org:service-1-0">
+<specVersion><major>1</major><minor>0</minor></specVersion>
+<actionList>
+<action><name>Browse</name><argumentList>
+<argument><name>ObjectID</name><direction>in</direction><relatedStateVariable>A_ARG_TYPE_ObjectID</relatedStateVariable></argument>
+<argument><name>BrowseFlag</name><direction>in</direction><relatedStateVariable>A_ARG_TYPE_BrowseFlag</relatedStateVariable></argument>
+<argument><name>Filter</name><direction>in</direction><relatedStateVariable>A_ARG_TYPE_Filter</relatedStateVariable></argument>
+<argument><name>StartingIndex</name><direction>in</direction><relatedStateVariable>A_ARG_TYPE_Index</relatedStateVariable></argument>
+<argument><name>RequestedCount</name><direction>in</direction><relatedStateVariable>A_ARG_TYPE_Count</relatedStateVariable></argument>
+<argument><name>SortCriteria</name><direction>in</direction><relatedStateVariable>A_ARG_TYPE_SortCriteria</relatedStateVariable></argument>
+<argument><name>Result</name><direction>out</direction><relatedStateVariable>A_ARG_TYPE_Result</relatedStateVariable></argument>
+<argument><name>NumberReturned</name><direction>out</direction><relatedStateVariable>A_ARG_TYPE_Count</relatedStateVariable></argument>
+<argument><name>TotalMatches</name><direction>out</direction><relatedStateVariable>A_ARG_TYPE_Count</relatedStateVariable></argument>
+<argument><name>UpdateID</name><direction>out</direction><relatedStateVariable>A_ARG_TYPE_UpdateID</relatedStateVariable></argument>
+</argumentList></action>
+<action><name>GetSearchCapabilities</name><argumentList>
+<argument><name>SearchCaps</name><direction>out</direction><relatedStateVariable>SearchCapabilities</relatedStateVariable></argument>
+</argumentList></action>
+<action><name>GetSortCapabilities</name><argumentList>
+<argument><name>SortCaps</name><direction>out</direction><relatedStateVariable>SortCapabilities</relatedStateVariable></argument>
+</argumentList></action>
+<action><name>GetSystemUpdateID</name><argumentList>
+<argument><name>Id</name><direction>out</direction><relatedStateVariable>SystemUpdateID</relatedStateVariable></argument>
+</argumentList></action>
+</actionList>
+<serviceStateTable>
+<stateVariable sendEvents="no"><name>A_ARG_TYPE_ObjectID</name><dataType>string</dataType></stateVariable>
+<stateVariable sendEvents="no"><name>A_ARG_TYPE_BrowseFlag</name><dataType>string</dataType><allowedValueList><allowedValue>BrowseMetadata</allowedValue><allowedValue>BrowseDirectChildren</allowedValue></allowedValueList></stateVariable>
+<stateVariable sendEvents="no"><name>A_ARG_TYPE_Filter</name><dataType>string</dataType></stateVariable>
+<stateVariable sendEvents="no"><name>A_ARG_TYPE_Index</name><dataType>ui4</dataType></stateVariable>
+<stateVariable sendEvents="no"><name>A_ARG_TYPE_Count</name><dataType>ui4</dataType></stateVariable>
+<stateVariable sendEvents="no"><name>A_ARG_TYPE_SortCriteria</name><dataType>string</dataType></stateVariable>
+<stateVariable sendEvents="no"><name>A_ARG_TYPE_Result</name><dataType>string</dataType></stateVariable>
+<stateVariable sendEvents="no"><name>A_ARG_TYPE_UpdateID</name><dataType>ui4</dataType></stateVariable>
+<stateVariable sendEvents="no"><name>SearchCapabilities</name><dataType>string</dataType></stateVariable>
+<stateVariable sendEvents="no"><name>SortCapabilities</name><dataType>string</dataType></stateVariable>
+<stateVariable sendEvents="yes"><name>SystemUpdateID</name><dataType>ui4</dataType></stateVariable>
+</serviceStateTable>
+</scpd>`
+
+const ConnectionManagerSCPD = xml.Header + `<scpd xmlns="urn:schemas-upnp-org:service-1-0">
+<specVersion><major>1</major><minor>0</minor></specVersion>
+<actionList>
+<action><name>GetProtocolInfo</name><argumentList>
+<argument><name>Source</name><direction>out</direction><relatedStateVariable>SourceProtocolInfo</relatedStateVariable></argument>
+<argument><name>Sink</name><direction>out</direction><relatedStateVariable>SinkProtocolInfo</relatedStateVariable></argument>
+</argumentList></action>
+<action><name>GetCurrentConnectionIDs</name><argumentList>
+<argument><name>ConnectionIDs</name><direction>out</direction><relatedStateVariable>CurrentConnectionIDs</relatedStateVariable></argument>
+</argumentList></action>
+<action><name>GetCurrentConnectionInfo</name><argumentList>
+<argument><name>ConnectionID</name><direction>in</direction><relatedStateVariable>A_ARG_TYPE_ConnectionID</relatedStateVariable></argument>
+<argument><name>RcsID</name><direction>out</direction><relatedStateVariable>A_ARG_TYPE_RcsID</relatedStateVariable></argument>
+<argument><name>AVTransportID</name><direction>out</direction><relatedStateVariable>A_ARG_TYPE_AVTransportID</relatedStateVariable></argument>
+<argument><name>ProtocolInfo</name><direction>out</direction><relatedStateVariable>A_ARG_TYPE_ProtocolInfo</relatedStateVariable></argument>
+<argument><name>PeerConnectionManager</name><direction>out</direction><relatedStateVariable>A_ARG_TYPE_ConnectionManager</relatedStateVariable></argument>
+<argument><name>PeerConnectionID</name><direction>out</direction><relatedStateVariable>A_ARG_TYPE_ConnectionID</relatedStateVariable></argument>
+<argument><name>Direction</name><direction>out</direction><relatedStateVariable>A_ARG_TYPE_Direction</relatedStateVariable></argument>
+<argument><name>Status</name><direction>out</direction><relatedStateVariable>A_ARG_TYPE_ConnectionStatus</relatedStateVariable></argument>
+</argumentList></action>
+</actionList>
+<serviceStateTable>
+<stateVariable sendEvents="yes"><name>SourceProtocolInfo</name><dataType>string</dataType></stateVariable>
+<stateVariable sendEvents="yes"><name>SinkProtocolInfo</name><dataType>string</dataType></stateVariable>
+<stateVariable sendEvents="yes"><name>CurrentConnectionIDs</name><dataType>string</dataType></stateVariable>
+<stateVariable sendEvents="no"><name>A_ARG_TYPE_ConnectionStatus</name><dataType>string</dataType><allowedValueList><allowedValue>OK</allowedValue><allowedValue>ContentFormatMismatch</allowedValue><allowedValue>InsufficientBandwidth</allowedValue><allowedValue>UnreliableChannel</allowedValue><allowedValue>Unknown</allowedValue></allowedValueList></stateVariable>
+<stateVariable sendEvents="no"><name>A_ARG_TYPE_ConnectionManager</name><dataType>string</dataType></stateVariable>
+<stateVariable sendEvents="no"><name>A_ARG_TYPE_Direction</name><dataType>string</dataType><allowedValueList><allowedValue>Input</allowedValue><allowedValue>Output</allowedValue></allowedValueList></stateVariable>
+<stateVariable sendEvents="no"><name>A_ARG_TYPE_ProtocolInfo</name><dataType>string</dataType></stateVariable>
+<stateVariable sendEvents="no"><name>A_ARG_TYPE_ConnectionID</name><dataType>i4</dataType></stateVariable>
+<stateVariable sendEvents="no"><name>A_ARG_TYPE_AVTransportID</name><dataType>i4</dataType></stateVariable>
+<stateVariable sendEvents="no"><name>A_ARG_TYPE_RcsID</name><dataType>i4</dataType></stateVariable>
+</serviceStateTable>
+</scpd>`
+
+// Arg SOAP 应答参数，按声明顺序输出
+type Arg struct {
+	Name  string
+	Value string
+}
+
+type soapEnvelope struct {
+	Body struct {
+		Action struct {
+			XMLName xml.Name
+			Args    []struct {
+				XMLName xml.Name
+				Value   string `xml:",chardata"`
+			} `xml:",any"`
+		} `xml:",any"`
+	} `xml:"Body"`
+}
+
+// ParseSOAPAction 从 SOAPACTION 头（形如 "urn:...:ContentDirectory:1#Browse"）取动作名，从请求体取参数
+func ParseSOAPAction(header string, body io.Reader) (string, map[string]string, error) {
+	_, action, ok := strings.Cut(strings.Trim(header, `"`), "#")
+	if !ok || action == "" {
+		return "", nil, ErrInvalidSOAPRequest
+	}
+
+	var envelope soapEnvelope
+	if err := xml.NewDecoder(io.LimitReader(body, 1<<20)).Decode(&envelope); err != nil {
+		return "", nil, fmt.Errorf("%w: %v", ErrInvalidSOAPRequest, err)
+	}
+	if envelope.Body.Action.XMLName.Local != action {
+		return "", nil, ErrInvalidSOAPRequest
+	}
+	args := make(map[string]string, len(envelope.Body.Action.Args))
+	for _, arg := range envelope.Body.Action.Args {
+		args[arg.XMLName.Local] = arg.Value
+	}
+	return action, args, nil
+}
+
+func SOAPResponse(serviceType, action string, args ...Arg) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/"><s:Body>`)
+	buf.WriteString(`<u:` + action + `Response xmlns:u="` + serviceType + `">`)
+	for _, arg := range args {
+		buf.WriteString("<" + arg.Name + ">" + escape(arg.Value) + "</" + arg.Name + ">")
+	}
+	buf.WriteString(`</u:` + action + `Response></s:Body></s:Envelope>`)
+	return buf.Bytes()
+}
+
+func SOAPFault(code int, description string) []byte {
+	return []byte(xml.Header + `<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/"><s:Body>` +
+		`<s:Fault><faultcode>s:Client</faultcode><faultstring>UPnPError</faultstring><detail>` +
+		`<UPnPError xmlns="urn:schemas-upnp-org:control-1-0"><errorCode>` + strconv.Itoa(code) + `</errorCode>` +
+		`<errorDescription>` + escape(description) + `</errorDescription></UPnPError>` +
+		`</detail></s:Fault></s:Body></s:Envelope>`)
+}
+
+// DIDL 将 Browse 结果渲染为 DIDL-Lite；baseURL 取自客户端请求的主机，保证流地址对该客户端可达
+func DIDL(objects []scene_audio_route_models.DLNAObject, baseURL string) string {
+	var buf strings.Builder
+	buf.WriteString(`<DIDL-Lite xmlns="urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:upnp="urn:schemas-upnp-org:metadata-1-0/upnp/" xmlns:dlna="urn:schemas-dlna-org:metadata-1-0/">`)
+	for _, o := range objects {
+		tag := "item"
+		if o.Container {
+			tag = "container"
+		}
+		buf.WriteString("<" + tag + ` id="` + escape(o.ID) + `" parentID="` + escape(o.ParentID) + `" restricted="1"`)
+		if o.Container {
+			buf.WriteString(` childCount="` + strconv.Itoa(o.ChildCount) + `" searchable="0"`)
+		}
+		buf.WriteString(">")
+		element(&buf, "dc:title", o.Title)
+		element(&buf, "upnp:class", o.Class)
+		element(&buf, "upnp:artist", o.Artist)
+		element(&buf, "dc:creator", o.Artist)
+		if o.AlbumArtist != "" {
+			buf.WriteString(`<upnp:artist role="AlbumArtist">` + escape(o.AlbumArtist) + `</upnp:artist>`)
+		}
+		element(&buf, "upnp:album", o.Album)
+		element(&buf, "upnp:genre", o.Genre)
+		if o.Year > 0 {
+			element(&buf, "dc:date", fmt.Sprintf("%04d-01-01", o.Year))
+		}
+		if o.TrackNumber > 0 {
+			element(&buf, "upnp:originalTrackNumber", strconv.Itoa(o.TrackNumber))
+		}
+		if o.CoverID != "" {
+			buf.WriteString(`<upnp:albumArtURI dlna:profileID="JPEG_TN">` + escape(baseURL+CoverPath+o.CoverID) + `</upnp:albumArtURI>`)
+		}
+		if o.MediaFileID != "" {
+			mime := MimeType(o.Suffix)
+			buf.WriteString(`<res protocolInfo="http-get:*:` + mime + `:` + ContentFeatures(o.Suffix) + `"`)
+			if o.Size > 0 {
+				buf.WriteString(` size="` + strconv.Itoa(o.Size) + `"`)
+			}
+			if o.Duration > 0 {
+				buf.WriteString(` duration="` + formatDuration(o.Duration) + `"`)
+			}
+			if o.BitRate > 0 {
+				// DIDL 中 bitrate 单位为字节每秒
+				buf.WriteString(` bitrate="` + strconv.Itoa(o.BitRate*1000/8) + `"`)
+			}
+			if o.Channels > 0 {
+				buf.WriteString(` nrAudioChannels="` + strconv.Itoa(o.Channels) + `"`)
+			}
+			buf.WriteString(">" + escape(baseURL+StreamPath+o.MediaFileID) + "</res>")
+		}
+		buf.WriteString("</" + tag + ">")
+	}
+	buf.WriteString("</DIDL-Lite>")
+	return buf.String()
+}
+
+// MimeType 按文件后缀返回音频 MIME 类型，未知格式交由播放设备自行识别
+func MimeType(suffix string) string {
+	switch strings.ToLower(strings.TrimPrefix(suffix, ".")) {
+	case "mp3":
+		return "audio/mpeg"
+	case "flac":
+		return "audio/flac"
+	case "m4a", "m4b", "mp4", "alac":
+		return "audio/mp4"
+	case "aac":
+		return "audio/aac"
+	case "ogg", "oga":
+		return "audio/ogg"
+	case "opus":
+		return "audio/opus"
+	case "wav":
+		return "audio/wav"
+	case "aif", "aiff":
+		return "audio/aiff"
+	case "wma":
+		return "audio/x-ms-wma"
+	case "ape":
+		return "audio/x-ape"
+	case "dsf", "dff":
+		return "audio/x-dsd"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// ContentFeatures contentFeatures.dlna.org 头与 protocolInfo 第四段：支持按字节范围跳转，流式传输
+func ContentFeatures(suffix string) string {
+	features := "DLNA.ORG_OP=01;DLNA.ORG_CI=0;DLNA.ORG_FLAGS=01700000000000000000000000000000"
+	if strings.EqualFold(strings.TrimPrefix(suffix, "."), "mp3") {
+		return "DLNA.ORG_PN=MP3;" + features
+	}
+	return features
+}
+
+// SubscriptionID 事件订阅的应答标识；本服务不推送事件，只为兼容订阅后才开始浏览的设备
+func SubscriptionID() string {
+	sum := sha1.Sum([]byte(strconv.FormatInt(time.Now().UnixNano(), 10)))
+	return fmt.Sprintf("uuid:%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
+
+// formatDuration 输出 H:MM:SS.mmm
+func formatDuration(seconds float64) string {
+	d := time.Duration(seconds * float64(time.Second))
+	return fmt.Sprintf("%d:%02d:%02d.%03d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60, d.Milliseconds()%1000)
+}
+
+func element(buf *strings.Builder, name, value string) {
+	if value != "" {
+		buf.WriteString("<" + name + ">" + escape(value) + "</" + name + ">")
+	}
+}
+
+func escape(s string) string {
+	var buf strings.Builder
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
@@ -0,0 +1,168 @@
+package dlna_util
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ssdpAddress = "239.255.255.250:1900"
+	// ssdpMaxAge 通告有效期（秒），在有效期过半时重新通告
+	ssdpMaxAge         = 1800
+	ssdpNotifyInterval = ssdpMaxAge / 2 * time.Second
+	// ssdpMaxDelay M-SEARCH 应答的最大随机延迟，MX 更大时按此上限
+	ssdpMaxDelay = 3 * time.Second
+)
+
+var ssdpServer = runtime.GOOS + "/1.0 UPnP/1.0 NineSong/1.0"
+
+// SSDP 在局域网组播中通告媒体服务器并应答设备搜索
+type SSDP struct {
+	uuid     string
+	location string
+
+	conn   *net.UDPConn
+	group  *net.UDPAddr
+	done   chan struct{}
+	closed sync.Once
+}
+
+// NewSSDP location 为设备描述的完整地址
+func NewSSDP(uuid, location string) *SSDP {
+	return &SSDP{uuid: uuid, location: location, done: make(chan struct{})}
+}
+
+func (s *SSDP) Start() error {
+	group, err := net.ResolveUDPAddr("udp4", ssdpAddress)
+	if err != nil {
+		return err
+	}
+	conn, err := net.ListenMulticastUDP("udp4", nil, group)
+	if err != nil {
+		return fmt.Errorf("ssdp listen: %w", err)
+	}
+	s.conn, s.group = conn, group
+
+	go s.serve()
+	go s.advertise()
+	return nil
+}
+
+// Close 发送 byebye 后停止通告
+func (s *SSDP) Close() {
+	s.closed.Do(func() {
+		close(s.done)
+		s.notify("ssdp:byebye")
+		_ = s.conn.Close()
+	})
+}
+
+func (s *SSDP) targets() []string {
+	return []string{"upnp:rootdevice", "uuid:" + s.uuid, DeviceType, ContentDirectoryService, ConnectionManagerService}
+}
+
+func (s *SSDP) usn(target string) string {
+	if target == "uuid:"+s.uuid {
+		return target
+	}
+	return "uuid:" + s.uuid + "::" + target
+}
+
+func (s *SSDP) advertise() {
+	s.notify("ssdp:alive")
+	ticker := time.NewTicker(ssdpNotifyInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.notify("ssdp:alive")
+		}
+	}
+}
+
+func (s *SSDP) notify(nts string) {
+	for _, target := range s.targets() {
+		msg := "NOTIFY * HTTP/1.1\r\n" +
+			"HOST: " + ssdpAddress + "\r\n" +
+			"NT: " + target + "\r\n" +
+			"NTS: " + nts + "\r\n" +
+			"USN: " + s.usn(target) + "\r\n"
+		if nts == "ssdp:alive" {
+			msg += "CACHE-CONTROL: max-age=" + strconv.Itoa(ssdpMaxAge) + "\r\n" +
+				"LOCATION: " + s.location + "\r\n" +
+				"SERVER: " + ssdpServer + "\r\n"
+		}
+		if _, err := s.conn.WriteToUDP([]byte(msg+"\r\n"), s.group); err != nil {
+			log.Printf("SSDP 通告发送失败: %v", err)
+			return
+		}
+	}
+}
+
+func (s *SSDP) serve() {
+	buf := make([]byte, 2048)
+	for {
+		n, from, err := s.conn.ReadFromUDP(buf)
+		if err != nil {
+			select {
+			case <-s.done:
+			default:
+				log.Printf("SSDP 监听中断: %v", err)
+			}
+			return
+		}
+		req, err := http.ReadRequest(bufio.NewReader(bytes.NewReader(buf[:n])))
+		if err != nil || req.Method != "M-SEARCH" || req.Header.Get("MAN") != `"ssdp:discover"` {
+			continue
+		}
+		s.respond(req.Header.Get("ST"), req.Header.Get("MX"), from)
+	}
+}
+
+// respond 按 MX 随机延迟后单播应答，避免同一网段的设备同时回应
+func (s *SSDP) respond(st, mx string, to *net.UDPAddr) {
+	var matched []string
+	for _, target := range s.targets() {
+		if st == "ssdp:all" || strings.EqualFold(st, target) {
+			matched = append(matched, target)
+		}
+	}
+	if len(matched) == 0 {
+		return
+	}
+
+	delay := ssdpMaxDelay
+	if seconds, err := strconv.Atoi(mx); err == nil && seconds >= 0 && time.Duration(seconds)*time.Second < delay {
+		delay = time.Duration(seconds) * time.Second
+	}
+	go func() {
+		if delay > 0 {
+			time.Sleep(rand.N(delay))
+		}
+		for _, target := range matched {
+			msg := "HTTP/1.1 200 OK\r\n" +
+				"CACHE-CONTROL: max-age=" + strconv.Itoa(ssdpMaxAge) + "\r\n" +
+				"DATE: " + time.Now().UTC().Format(http.TimeFormat) + "\r\n" +
+				"EXT:\r\n" +
+				"LOCATION: " + s.location + "\r\n" +
+				"SERVER: " + ssdpServer + "\r\n" +
+				"ST: " + target + "\r\n" +
+				"USN: " + s.usn(target) + "\r\n\r\n"
+			if _, err := s.conn.WriteToUDP([]byte(msg), to); err != nil {
+				return
+			}
+		}
+	}()
+}
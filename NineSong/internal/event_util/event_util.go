@@ -0,0 +1,161 @@
+package event_util
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+)
+
+// 事件主题
+const (
+	// TopicScrobble 播放记录写入后发布，载荷为 scene_audio_route_models.ScrobbleEvent
+	TopicScrobble = "scrobble"
+	// TopicScan 扫描进行中定期发布，结束时再发布一次，载荷为 domain_file_entity.ScanStatus
+	TopicScan = "scan"
+	// TopicMediaAdded 扫描新入库单曲后发布，载荷为 domain_file_entity.MediaAddedEvent
+	TopicMediaAdded = "media_added"
+	// TopicAnnotation 收藏或评分变更后发布，载荷为 scene_audio_route_models.AnnotationEvent
+	TopicAnnotation = "annotation"
+	// TopicNowPlaying 客户端保存播放队列后发布，载荷为 scene_audio_route_models.NowPlayingEvent
+	TopicNowPlaying = "now_playing"
+	// TopicJukebox 点唱机播放状态变化后发布，载荷为 scene_audio_route_models.JukeboxEvent
+	TopicJukebox = "jukebox"
+)
+
+// subscriptionBuffer 每个订阅者的待投递事件上限，超出时丢弃新事件，避免慢消费者阻塞发布方
+const subscriptionBuffer = 64
+
+// Event 总线上传递的消息，Payload 为发布时序列化的 JSON
+type Event struct {
+	Topic   string          `json:"topic"`
+	Payload json.RawMessage `json:"payload"`
+	Time    time.Time       `json:"time"`
+}
+
+// Bus 发布订阅总线；多实例实现中任一实例发布的事件会投递到所有实例上的订阅者，包括发布方自身
+type Bus interface {
+	Publish(ctx context.Context, event Event) error
+	// Subscribe topics 为空时订阅全部主题
+	Subscribe(topics ...string) *Subscription
+	Close() error
+}
+
+var (
+	defaultMu  sync.RWMutex
+	defaultBus Bus = NewMemoryBus()
+)
+
+// Configure 在启动时设置共享总线实例
+func Configure(b Bus) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultBus = b
+}
+
+func Default() Bus {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultBus
+}
+
+// Publish 序列化载荷并发布到共享总线；事件是尽力投递的通知，失败只记录日志
+func Publish(ctx context.Context, topic string, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("事件 %s 序列化失败: %v", topic, err)
+		return
+	}
+	if err := Default().Publish(ctx, Event{Topic: topic, Payload: data, Time: time.Now().UTC()}); err != nil {
+		log.Printf("事件 %s 发布失败: %v", topic, err)
+	}
+}
+
+// Subscribe 订阅共享总线
+func Subscribe(topics ...string) *Subscription {
+	return Default().Subscribe(topics...)
+}
+
+// Subscription 订阅者从 C 读取事件，不再需要时调用 Close
+type Subscription struct {
+	C      <-chan Event
+	ch     chan Event
+	topics map[string]bool
+	hub    *hub
+	once   sync.Once
+}
+
+func (s *Subscription) Close() {
+	s.once.Do(func() { s.hub.remove(s) })
+}
+
+func (s *Subscription) matches(topic string) bool {
+	return len(s.topics) == 0 || s.topics[topic]
+}
+
+// hub 本实例内的订阅者集合，各总线实现收到事件后都经由它分发
+type hub struct {
+	mu   sync.RWMutex
+	subs map[*Subscription]struct{}
+}
+
+func newHub() *hub {
+	return &hub{subs: make(map[*Subscription]struct{})}
+}
+
+func (h *hub) subscribe(topics ...string) *Subscription {
+	ch := make(chan Event, subscriptionBuffer)
+	set := make(map[string]bool, len(topics))
+	for _, t := range topics {
+		set[t] = true
+	}
+	s := &Subscription{C: ch, ch: ch, topics: set, hub: h}
+
+	h.mu.Lock()
+	h.subs[s] = struct{}{}
+	h.mu.Unlock()
+	return s
+}
+
+func (h *hub) remove(s *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.subs[s]; ok {
+		delete(h.subs, s)
+		close(s.ch)
+	}
+}
+
+func (h *hub) dispatch(event Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for s := range h.subs {
+		if !s.matches(event.Topic) {
+			continue
+		}
+		select {
+		case s.ch <- event:
+		default:
+			log.Printf("事件订阅者积压，丢弃 %s 事件", event.Topic)
+		}
+	}
+}
+
+func (h *hub) closeAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for s := range h.subs {
+		delete(h.subs, s)
+		close(s.ch)
+	}
+}
+
+// reconnectDelay 远程总线断线后的重连间隔，按次数递增到 30 秒封顶
+func reconnectDelay(attempt int) time.Duration {
+	delay := time.Duration(attempt) * time.Second
+	if delay > 30*time.Second {
+		delay = 30 * time.Second
+	}
+	return delay
+}
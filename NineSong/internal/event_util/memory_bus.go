@@ -0,0 +1,26 @@
+package event_util
+
+import "context"
+
+// memoryBus 进程内总线，单实例部署的默认实现
+type memoryBus struct {
+	hub *hub
+}
+
+func NewMemoryBus() Bus {
+	return &memoryBus{hub: newHub()}
+}
+
+func (b *memoryBus) Publish(_ context.Context, event Event) error {
+	b.hub.dispatch(event)
+	return nil
+}
+
+func (b *memoryBus) Subscribe(topics ...string) *Subscription {
+	return b.hub.subscribe(topics...)
+}
+
+func (b *memoryBus) Close() error {
+	b.hub.closeAll()
+	return nil
+}
@@ -0,0 +1,86 @@
+package event_util
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cache_util"
+)
+
+const redisChannelPrefix = "ninesong:events:"
+
+// redisBus 通过 Redis PUBLISH/PSUBSCRIBE 在实例间转发事件；本实例发布的事件同样经 Redis 回到本地订阅者，
+// 保证各实例看到的顺序一致
+type redisBus struct {
+	client *cache_util.RedisClient
+	hub    *hub
+	cancel context.CancelFunc
+}
+
+func NewRedisBus(client *cache_util.RedisClient) Bus {
+	ctx, cancel := context.WithCancel(context.Background())
+	b := &redisBus{client: client, hub: newHub(), cancel: cancel}
+	go b.listen(ctx)
+	return b
+}
+
+func (b *redisBus) Publish(ctx context.Context, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = b.client.Do(ctx, "PUBLISH", redisChannelPrefix+event.Topic, string(data))
+	return err
+}
+
+func (b *redisBus) Subscribe(topics ...string) *Subscription {
+	return b.hub.subscribe(topics...)
+}
+
+func (b *redisBus) Close() error {
+	b.cancel()
+	b.hub.closeAll()
+	return nil
+}
+
+// listen 断线期间发布的事件会丢失，订阅者应把事件当作通知而非可靠队列
+func (b *redisBus) listen(ctx context.Context) {
+	for attempt := 0; ctx.Err() == nil; attempt++ {
+		sub, err := b.client.PSubscribe(ctx, redisChannelPrefix+"*")
+		if err != nil {
+			log.Printf("事件总线订阅 Redis 失败: %v", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(reconnectDelay(attempt + 1)):
+			}
+			continue
+		}
+		attempt = 0
+
+		stop := context.AfterFunc(ctx, func() { _ = sub.Close() })
+		for {
+			channel, payload, err := sub.Receive()
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Printf("事件总线 Redis 连接断开: %v", err)
+				}
+				break
+			}
+			var event Event
+			if err := json.Unmarshal([]byte(payload), &event); err != nil {
+				log.Printf("事件总线消息解析失败 %s: %v", channel, err)
+				continue
+			}
+			if event.Topic == "" {
+				event.Topic = strings.TrimPrefix(channel, redisChannelPrefix)
+			}
+			b.hub.dispatch(event)
+		}
+		stop()
+		_ = sub.Close()
+	}
+}
@@ -0,0 +1,289 @@
+package http_util
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cache_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/lock_util"
+)
+
+const (
+	// DefaultUserAgent MusicBrainz 等服务要求带联系方式的 User-Agent
+	DefaultUserAgent = "NineSong/1.0 ( https://github.com/hexiao5688/NineSong )"
+
+	defaultMaxBodySize = 4 << 20
+	retryBaseDelay     = 500 * time.Millisecond
+	retryMaxDelay      = 30 * time.Second
+)
+
+// ErrStatus 重试后仍返回非预期状态码，可用 errors.As 取出 *StatusError
+var ErrStatus = errors.New("unexpected status")
+
+type StatusError struct {
+	Provider   string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s返回状态码 %d", e.Provider, e.StatusCode)
+}
+
+func (e *StatusError) Unwrap() error { return ErrStatus }
+
+// Provider 单个外部服务的访问策略
+type Provider struct {
+	Name string
+	// Interval 两次请求的最小间隔，经 lock_util 共享节流器在所有实例间生效；为 0 时不限制
+	Interval time.Duration
+	// CacheTTL 成功与 404 响应的缓存时间，为 0 时不缓存
+	CacheTTL time.Duration
+	// MaxRetries 网络错误、429 与 5xx 的最大重试次数
+	MaxRetries  int
+	UserAgent   string
+	MaxBodySize int64
+	// PublicOnly 请求地址由用户提供时只允许连接公网地址，重定向同样检查
+	PublicOnly bool
+}
+
+// Request Body 以字节保存，便于重试时重放并参与缓存键计算
+type Request struct {
+	Method string
+	URL    string
+	Header http.Header
+	Body   []byte
+}
+
+type Response struct {
+	StatusCode int    `json:"status"`
+	Body       []byte `json:"body"`
+}
+
+// Client 外部元数据服务共用的 HTTP 客户端：节流、重试退避、响应缓存与调用统计
+type Client struct {
+	provider Provider
+	http     *http.Client
+}
+
+func NewClient(provider Provider, timeout time.Duration) *Client {
+	if provider.UserAgent == "" {
+		provider.UserAgent = DefaultUserAgent
+	}
+	if provider.MaxBodySize <= 0 {
+		provider.MaxBodySize = defaultMaxBodySize
+	}
+	register(provider.Name)
+	if provider.PublicOnly {
+		return &Client{provider: provider, http: NewPublicClient(timeout)}
+	}
+	return &Client{provider: provider, http: &http.Client{Timeout: timeout}}
+}
+
+// Do 返回任意状态码的响应；只有网络错误或重试耗尽时返回 error
+func (c *Client) Do(ctx context.Context, req Request) (*Response, error) {
+	if req.Method == "" {
+		req.Method = http.MethodGet
+	}
+	if c.provider.CacheTTL <= 0 {
+		return c.fetch(ctx, req)
+	}
+
+	key := c.cacheKey(req)
+	if raw, ok := cache_util.Default().Get(ctx, cache_util.NamespaceExternal, key); ok {
+		var cached Response
+		if json.Unmarshal(raw, &cached) == nil {
+			record(c.provider.Name, func(s *ProviderStats) { s.CacheHits++ })
+			return &cached, nil
+		}
+	}
+	res, err := c.fetch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if cacheable(res.StatusCode) {
+		if raw, err := json.Marshal(res); err == nil {
+			cache_util.Default().Set(ctx, cache_util.NamespaceExternal, key, raw, c.provider.CacheTTL)
+		}
+	}
+	return res, nil
+}
+
+// Get 非 2xx 响应返回 *StatusError
+func (c *Client) Get(ctx context.Context, endpoint string, header http.Header) ([]byte, error) {
+	res, err := c.Do(ctx, Request{Method: http.MethodGet, URL: endpoint, Header: header})
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, &StatusError{Provider: c.provider.Name, StatusCode: res.StatusCode}
+	}
+	return res.Body, nil
+}
+
+// GetJSON 与 Get 相同，成功时将正文解码到 out
+func (c *Client) GetJSON(ctx context.Context, endpoint string, header http.Header, out interface{}) error {
+	body, err := c.Get(ctx, endpoint, header)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("解析%s响应失败: %w", c.provider.Name, err)
+	}
+	return nil
+}
+
+// IsStatus 判断 err 是否为指定状态码的 StatusError
+func IsStatus(err error, statusCode int) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == statusCode
+}
+
+func (c *Client) fetch(ctx context.Context, req Request) (*Response, error) {
+	var lastErr error
+	for attempt := 0; ; attempt++ {
+		if c.provider.Interval > 0 {
+			if err := lock_util.Throttle(ctx, c.provider.Name, c.provider.Interval); err != nil {
+				return nil, err
+			}
+		}
+
+		started := time.Now()
+		res, retryAfter, err := c.send(ctx, req)
+		elapsed := time.Since(started)
+		record(c.provider.Name, func(s *ProviderStats) {
+			s.Requests++
+			s.totalLatency += elapsed
+			if res != nil && res.StatusCode == http.StatusTooManyRequests {
+				s.RateLimited++
+			}
+		})
+
+		switch {
+		case err != nil && ctx.Err() != nil:
+			c.fail(ctx.Err())
+			return nil, ctx.Err()
+		case errors.Is(err, ErrPrivateAddress):
+			// 地址被拒绝时重试没有意义
+			c.fail(err)
+			return nil, err
+		case err != nil:
+			lastErr = fmt.Errorf("%s请求失败: %w", c.provider.Name, err)
+		case retryable(res.StatusCode):
+			lastErr = &StatusError{Provider: c.provider.Name, StatusCode: res.StatusCode}
+		default:
+			return res, nil
+		}
+
+		if attempt >= c.provider.MaxRetries {
+			c.fail(lastErr)
+			if res != nil {
+				// 重试耗尽仍返回最后一次响应，由调用方按状态码处理
+				return res, nil
+			}
+			return nil, lastErr
+		}
+		record(c.provider.Name, func(s *ProviderStats) { s.Retries++ })
+		if err := sleep(ctx, backoff(attempt, retryAfter)); err != nil {
+			c.fail(err)
+			return nil, err
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, req Request) (*Response, time.Duration, error) {
+	var body io.Reader
+	if req.Body != nil {
+		body = bytes.NewReader(req.Body)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, req.URL, body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("构建请求失败: %w", err)
+	}
+	for key, values := range req.Header {
+		httpReq.Header[key] = values
+	}
+	httpReq.Header.Set("User-Agent", c.provider.UserAgent)
+
+	res, err := c.http.Do(httpReq)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(res.Body, c.provider.MaxBodySize))
+	if err != nil {
+		return nil, 0, fmt.Errorf("读取响应失败: %w", err)
+	}
+	return &Response{StatusCode: res.StatusCode, Body: data}, parseRetryAfter(res.Header.Get("Retry-After")), nil
+}
+
+func (c *Client) fail(err error) {
+	record(c.provider.Name, func(s *ProviderStats) {
+		s.Failures++
+		s.LastError = err.Error()
+		s.LastErrorAt = time.Now()
+	})
+}
+
+func (c *Client) cacheKey(req Request) string {
+	parts := []string{c.provider.Name, req.Method, req.URL}
+	if len(req.Body) > 0 {
+		sum := sha1.Sum(req.Body)
+		parts = append(parts, hex.EncodeToString(sum[:]))
+	}
+	return cache_util.Key(parts...)
+}
+
+func retryable(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
+
+// cacheable 404 同样缓存，避免反复查询确实不存在的条目
+func cacheable(statusCode int) bool {
+	return (statusCode >= 200 && statusCode <= 299) || statusCode == http.StatusNotFound
+}
+
+// backoff 服务端给出 Retry-After 时遵循，否则按指数退避并加入抖动
+func backoff(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return min(retryAfter, retryMaxDelay)
+	}
+	delay := retryBaseDelay << attempt
+	delay += time.Duration(rand.Int63n(int64(delay) / 2))
+	return min(delay, retryMaxDelay)
+}
+
+// parseRetryAfter 支持秒数与 HTTP 日期两种格式
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return time.Until(at)
+	}
+	return 0
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
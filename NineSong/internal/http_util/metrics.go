@@ -0,0 +1,59 @@
+package http_util
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// ProviderStats 进程启动以来单个外部服务的调用统计，多实例部署时各实例分别计数
+type ProviderStats struct {
+	Provider     string    `json:"provider"`
+	Requests     int64     `json:"requests"` // 实际发出的请求，含重试
+	CacheHits    int64     `json:"cache_hits"`
+	Retries      int64     `json:"retries"`
+	RateLimited  int64     `json:"rate_limited"` // 服务端返回 429 的次数
+	Failures     int64     `json:"failures"`     // 重试耗尽或被取消的调用
+	AvgLatencyMs int64     `json:"avg_latency_ms"`
+	LastError    string    `json:"last_error,omitempty"`
+	LastErrorAt  time.Time `json:"last_error_at,omitempty"`
+
+	totalLatency time.Duration
+}
+
+var (
+	statsMu sync.Mutex
+	stats   = make(map[string]*ProviderStats)
+)
+
+func register(provider string) {
+	record(provider, func(*ProviderStats) {})
+}
+
+func record(provider string, update func(s *ProviderStats)) {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	s, ok := stats[provider]
+	if !ok {
+		s = &ProviderStats{Provider: provider}
+		stats[provider] = s
+	}
+	update(s)
+}
+
+// Snapshot 按服务名排序返回各服务统计的副本
+func Snapshot() []ProviderStats {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+
+	result := make([]ProviderStats, 0, len(stats))
+	for _, s := range stats {
+		copied := *s
+		if copied.Requests > 0 {
+			copied.AvgLatencyMs = (copied.totalLatency / time.Duration(copied.Requests)).Milliseconds()
+		}
+		result = append(result, copied)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Provider < result[j].Provider })
+	return result
+}
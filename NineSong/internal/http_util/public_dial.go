@@ -0,0 +1,95 @@
+package http_util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+const maxPublicRedirects = 10
+
+// ErrPrivateAddress 目标为回环、内网、链路本地或未指定地址，由用户提供的地址不得访问服务端所在网络
+var ErrPrivateAddress = errors.New("private network address not allowed")
+
+// isPrivateIP IPv4 映射的 IPv6 地址按 IPv4 判断
+func isPrivateIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast()
+}
+
+// publicOnlyControl 在连接建立前检查实际拨号的地址，域名解析结果在检查后变化（DNS 重绑定）也无法绕过
+func publicOnlyControl(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrPrivateAddress, address)
+	}
+	if ip := net.ParseIP(host); ip == nil || isPrivateIP(ip) {
+		return fmt.Errorf("%w: %s", ErrPrivateAddress, host)
+	}
+	return nil
+}
+
+// PublicTransport 只能连接公网地址的 Transport，用于访问用户提供的地址（播客源、电台、远程附件）；
+// 不读取代理配置，经代理转发时无法检查真实的目标地址
+func PublicTransport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   publicOnlyControl,
+	}
+	return &http.Transport{
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+}
+
+// CheckPublicRedirect 作为 http.Client 的 CheckRedirect，每次重定向都重新确认目标为公网的 http(s) 地址
+func CheckPublicRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxPublicRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxPublicRedirects)
+	}
+	return CheckPublicURL(req.Context(), req.URL)
+}
+
+// CheckPublicURL 解析主机名并确认全部地址均为公网地址，便于在发起请求前给出明确错误；
+// 拨号时仍会逐个连接再次检查
+func CheckPublicURL(ctx context.Context, target *url.URL) error {
+	if target.Scheme != "http" && target.Scheme != "https" {
+		return fmt.Errorf("%w: unsupported scheme %q", ErrPrivateAddress, target.Scheme)
+	}
+	host := target.Hostname()
+	if ip := net.ParseIP(host); ip != nil {
+		if isPrivateIP(ip) {
+			return fmt.Errorf("%w: %s", ErrPrivateAddress, host)
+		}
+		return nil
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fmt.Errorf("resolve %s failed: %w", host, err)
+	}
+	for _, addr := range addrs {
+		if isPrivateIP(addr.IP) {
+			return fmt.Errorf("%w: %s", ErrPrivateAddress, host)
+		}
+	}
+	return nil
+}
+
+// NewPublicClient 只能访问公网地址的 http.Client，timeout 为 0 时不限制整体时长
+func NewPublicClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout:       timeout,
+		Transport:     PublicTransport(),
+		CheckRedirect: CheckPublicRedirect,
+	}
+}
@@ -0,0 +1,108 @@
+package image_util
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const thumbnailQuality = 85
+
+// ResizeToJPEG 将 src 等比缩放到最长边不超过 size 并以 JPEG 写入 dst；
+// 原图不大于 size 时仅转码。先写临时文件再重命名，并发生成同一缩略图时不会读到半成品
+func ResizeToJPEG(src, dst string, size int) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	img, _, err := image.Decode(in)
+	_ = in.Close()
+	if err != nil {
+		return fmt.Errorf("decode image failed: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".thumb-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := jpeg.Encode(tmp, Resize(img, size), &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("encode thumbnail failed: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dst)
+}
+
+// Resize 按区域平均等比缩小，最长边不超过 size；不做放大
+func Resize(src image.Image, size int) image.Image {
+	bounds := src.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	if size <= 0 || (srcW <= size && srcH <= size) {
+		return src
+	}
+
+	dstW, dstH := size, size
+	if srcW >= srcH {
+		dstH = max(1, srcH*size/srcW)
+	} else {
+		dstW = max(1, srcW*size/srcH)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		y0 := bounds.Min.Y + y*srcH/dstH
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*srcH/dstH)
+		for x := 0; x < dstW; x++ {
+			x0 := bounds.Min.X + x*srcW/dstW
+			x1 := max(x0+1, bounds.Min.X+(x+1)*srcW/dstW)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r += uint64(cr)
+					g += uint64(cg)
+					b += uint64(cb)
+					a += uint64(ca)
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(b / n >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+	return dst
+}
+
+// FileVersion 返回文件内容的短哈希，作为封面 URL 的版本参数；内容不变时版本不变
+func FileVersion(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha1.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil))[:12], nil
+}
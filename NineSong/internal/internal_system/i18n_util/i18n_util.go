@@ -0,0 +1,156 @@
+package i18n_util
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 目前只维护中英文两套文案，对应中文与英文客户端
+const (
+	LangZH = "zh"
+	LangEN = "en"
+
+	// DefaultLang 未带 Accept-Language 或均不支持时使用
+	DefaultLang = LangZH
+)
+
+// catalog 错误码到各语言文案；错误码保持稳定，客户端应按 code 判断而不是解析文案
+var catalog = map[string]map[string]string{
+	// 参数校验
+	"INVALID_PARAMS":     {LangZH: "请求参数无效", LangEN: "Invalid request parameters"},
+	"INVALID_PARAMETERS": {LangZH: "请求参数无效", LangEN: "Invalid request parameters"},
+	"PARAMS_ERROR":       {LangZH: "请求参数无效", LangEN: "Invalid request parameters"},
+	"INVALID_REQUEST":    {LangZH: "请求无效", LangEN: "Invalid request"},
+	"BINDING_ERROR":      {LangZH: "请求参数格式错误", LangEN: "Malformed request parameters"},
+	"MISSING_PARAMETER":  {LangZH: "缺少必填参数", LangEN: "Missing required parameter"},
+	"MISSING_PARAMS":     {LangZH: "缺少必填参数", LangEN: "Missing required parameters"},
+	"INVALID_ID":         {LangZH: "ID格式无效", LangEN: "Invalid ID format"},
+	"INVALID_TYPE":       {LangZH: "类型无效", LangEN: "Invalid type"},
+	"UNSUPPORTED_TYPE":   {LangZH: "不支持的类型", LangEN: "Unsupported type"},
+	"INVALID_IMAGE":      {LangZH: "图片无效或格式不受支持", LangEN: "Invalid or unsupported image"},
+	"INVALID_SYNC_TOKEN": {LangZH: "同步令牌无效或已过期，请重新全量同步", LangEN: "Sync token is invalid or expired, please run a full sync"},
+	"GENRE_CYCLE":        {LangZH: "流派层级不能形成循环", LangEN: "Genre hierarchy cannot contain cycles"},
+
+	// 资源不存在
+	"NOT_FOUND":           {LangZH: "资源不存在", LangEN: "Resource not found"},
+	"MEDIA_NOT_FOUND":     {LangZH: "媒体文件不存在", LangEN: "Media file not found"},
+	"FILE_NOT_FOUND":      {LangZH: "文件不存在", LangEN: "File not found"},
+	"LYRICS_NOT_FOUND":    {LangZH: "未找到歌词", LangEN: "Lyrics not found"},
+	"DIRECTORY_NOT_FOUND": {LangZH: "目录不存在", LangEN: "Directory not found"},
+	"NOT_A_DIRECTORY":     {LangZH: "路径不是目录", LangEN: "Path is not a directory"},
+
+	// 冲突
+	"CONFLICT":       {LangZH: "资源已被修改或存在冲突", LangEN: "Resource conflict"},
+	"NAME_CONFLICT":  {LangZH: "名称已存在", LangEN: "Name already exists"},
+	"ALIAS_CONFLICT": {LangZH: "别名已被占用", LangEN: "Alias is already in use"},
+
+	// 权限
+	"UNAUTHORIZED":     {LangZH: "未登录或登录已过期", LangEN: "Not authenticated"},
+	"FORBIDDEN":        {LangZH: "没有权限执行此操作", LangEN: "Permission denied"},
+	"HOST_NOT_ALLOWED": {LangZH: "目标地址不在允许范围内", LangEN: "Target host is not allowed"},
+
+	// 配额与大小限制
+	"QUOTA_EXCEEDED": {LangZH: "已超出配额上限", LangEN: "Quota exceeded"},
+	"FILE_TOO_LARGE": {LangZH: "文件过大", LangEN: "File is too large"},
+
+	// 外部服务与配置
+	"NOT_CONFIGURED":       {LangZH: "该功能未配置", LangEN: "Feature is not configured"},
+	"INBOX_NOT_CONFIGURED": {LangZH: "未配置上传收件箱", LangEN: "Upload inbox is not configured"},
+	"REMOTE_UNAVAILABLE":   {LangZH: "远程服务不可用", LangEN: "Remote service unavailable"},
+	"PLAYER_UNAVAILABLE":   {LangZH: "播放器不可用", LangEN: "Player unavailable"},
+	"DEVICE_UNREACHABLE":   {LangZH: "无法连接设备", LangEN: "Device unreachable"},
+	"STREAM_UNREACHABLE":   {LangZH: "无法连接串流地址", LangEN: "Stream URL is unreachable"},
+
+	// 服务端错误
+	"SERVER_ERROR":   {LangZH: "服务器内部错误", LangEN: "Internal server error"},
+	"INTERNAL_ERROR": {LangZH: "服务器内部错误", LangEN: "Internal server error"},
+	"DATABASE_ERROR": {LangZH: "数据库错误", LangEN: "Database error"},
+}
+
+// statusFallback 错误码不在目录中时按 HTTP 状态给出通用文案
+var statusFallback = map[int]map[string]string{
+	http.StatusBadRequest:            {LangZH: "请求无效", LangEN: "Invalid request"},
+	http.StatusUnauthorized:          {LangZH: "未登录或登录已过期", LangEN: "Not authenticated"},
+	http.StatusForbidden:             {LangZH: "没有权限执行此操作", LangEN: "Permission denied"},
+	http.StatusNotFound:              {LangZH: "资源不存在", LangEN: "Resource not found"},
+	http.StatusConflict:              {LangZH: "资源已被修改或存在冲突", LangEN: "Resource conflict"},
+	http.StatusRequestEntityTooLarge: {LangZH: "请求内容过大", LangEN: "Request is too large"},
+	http.StatusTooManyRequests:       {LangZH: "请求过于频繁，请稍后再试", LangEN: "Too many requests, please try again later"},
+	http.StatusServiceUnavailable:    {LangZH: "服务暂不可用", LangEN: "Service unavailable"},
+}
+
+// Message 返回错误码在指定语言下的文案，目录中没有该错误码时按状态码回退，均无匹配返回 false
+func Message(code string, statusCode int, lang string) (string, bool) {
+	if messages, ok := catalog[code]; ok {
+		return pick(messages, lang), true
+	}
+	if messages, ok := statusFallback[statusCode]; ok {
+		return pick(messages, lang), true
+	}
+	if statusCode >= http.StatusInternalServerError {
+		return pick(catalog["SERVER_ERROR"], lang), true
+	}
+	return "", false
+}
+
+func pick(messages map[string]string, lang string) string {
+	if msg, ok := messages[lang]; ok {
+		return msg
+	}
+	return messages[DefaultLang]
+}
+
+// Catalog 返回指定语言的完整错误码文案，供客户端离线缓存
+func Catalog(lang string) map[string]string {
+	out := make(map[string]string, len(catalog))
+	for code, messages := range catalog {
+		out[code] = pick(messages, lang)
+	}
+	return out
+}
+
+// Negotiate 按 Accept-Language 的 q 值选出支持的语言，如 "en-US,en;q=0.9,zh;q=0.8" 返回 en
+func Negotiate(acceptLanguage string) string {
+	type candidate struct {
+		lang string
+		q    float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" {
+			continue
+		}
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		candidates = append(candidates, candidate{lang: baseLanguage(tag), q: q})
+	}
+	// 稳定排序保留同权重语言的原始顺序
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+
+	for _, c := range candidates {
+		switch c.lang {
+		case LangZH, LangEN:
+			return c.lang
+		}
+	}
+	return DefaultLang
+}
+
+// baseLanguage 只取主语言子标签，zh-CN 与 zh-Hant-TW 均归为 zh
+func baseLanguage(tag string) string {
+	lang, _, _ := strings.Cut(tag, "-")
+	lang, _, _ = strings.Cut(lang, "_")
+	return strings.ToLower(lang)
+}
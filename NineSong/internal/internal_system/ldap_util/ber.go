@@ -0,0 +1,164 @@
+package ldap_util
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// BER 标签，仅包含简单绑定与查询用到的部分
+const (
+	tagInteger     = 0x02
+	tagOctetString = 0x04
+	tagEnumerated  = 0x0a
+	tagBoolean     = 0x01
+	tagSequence    = 0x30
+	tagSet         = 0x31
+
+	tagBindRequest      = 0x60
+	tagBindResponse     = 0x61
+	tagUnbindRequest    = 0x42
+	tagSearchRequest    = 0x63
+	tagSearchEntry      = 0x64
+	tagSearchDone       = 0x65
+	tagSearchReference  = 0x73
+	tagSimpleAuth       = 0x80
+	tagFilterAnd        = 0xa0
+	tagFilterOr         = 0xa1
+	tagFilterNot        = 0xa2
+	tagFilterEquality   = 0xa3
+	tagFilterPresent    = 0x87
+	maxBERElementLength = 16 << 20
+)
+
+var errMalformed = errors.New("ldap: malformed response")
+
+type berElement struct {
+	tag     byte
+	content []byte
+}
+
+func encode(tag byte, content []byte) []byte {
+	out := []byte{tag}
+	n := len(content)
+	switch {
+	case n < 0x80:
+		out = append(out, byte(n))
+	case n <= 0xff:
+		out = append(out, 0x81, byte(n))
+	case n <= 0xffff:
+		out = append(out, 0x82, byte(n>>8), byte(n))
+	default:
+		out = append(out, 0x84, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+	return append(out, content...)
+}
+
+func encodeSeq(tag byte, children ...[]byte) []byte {
+	var content []byte
+	for _, child := range children {
+		content = append(content, child...)
+	}
+	return encode(tag, content)
+}
+
+func encodeInt(tag byte, v int) []byte {
+	if v == 0 {
+		return encode(tag, []byte{0})
+	}
+	var buf []byte
+	for x := v; x > 0; x >>= 8 {
+		buf = append([]byte{byte(x)}, buf...)
+	}
+	// 最高位为 1 时补零，避免被解析为负数
+	if buf[0]&0x80 != 0 {
+		buf = append([]byte{0}, buf...)
+	}
+	return encode(tag, buf)
+}
+
+func encodeString(tag byte, s string) []byte {
+	return encode(tag, []byte(s))
+}
+
+func encodeBool(v bool) []byte {
+	if v {
+		return encode(tagBoolean, []byte{0xff})
+	}
+	return encode(tagBoolean, []byte{0})
+}
+
+// readElement 从连接读取一个完整的顶层元素
+func readElement(r *bufio.Reader) (berElement, error) {
+	tag, err := r.ReadByte()
+	if err != nil {
+		return berElement{}, err
+	}
+	first, err := r.ReadByte()
+	if err != nil {
+		return berElement{}, err
+	}
+	length := int(first)
+	if first&0x80 != 0 {
+		count := int(first & 0x7f)
+		if count == 0 || count > 4 {
+			return berElement{}, errMalformed
+		}
+		length = 0
+		for i := 0; i < count; i++ {
+			b, err := r.ReadByte()
+			if err != nil {
+				return berElement{}, err
+			}
+			length = length<<8 | int(b)
+		}
+	}
+	if length > maxBERElementLength {
+		return berElement{}, fmt.Errorf("ldap: element too large (%d bytes)", length)
+	}
+	content := make([]byte, length)
+	if _, err := io.ReadFull(r, content); err != nil {
+		return berElement{}, err
+	}
+	return berElement{tag: tag, content: content}, nil
+}
+
+// children 解析构造类型元素的子元素
+func (e berElement) children() ([]berElement, error) {
+	var out []berElement
+	data := e.content
+	for len(data) > 0 {
+		if len(data) < 2 {
+			return nil, errMalformed
+		}
+		tag, first := data[0], data[1]
+		data = data[2:]
+		length := int(first)
+		if first&0x80 != 0 {
+			count := int(first & 0x7f)
+			if count == 0 || count > 4 || len(data) < count {
+				return nil, errMalformed
+			}
+			length = 0
+			for _, b := range data[:count] {
+				length = length<<8 | int(b)
+			}
+			data = data[count:]
+		}
+		if length > len(data) {
+			return nil, errMalformed
+		}
+		out = append(out, berElement{tag: tag, content: data[:length]})
+		data = data[length:]
+	}
+	return out, nil
+}
+
+func (e berElement) int() int {
+	v := 0
+	for _, b := range e.content {
+		v = v<<8 | int(b)
+	}
+	return v
+}
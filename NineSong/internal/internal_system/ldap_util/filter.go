@@ -0,0 +1,118 @@
+package ldap_util
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// EscapeFilter 按 RFC 4515 转义填入过滤器的值
+func EscapeFilter(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch c {
+		case '*', '(', ')', '\\', 0:
+			fmt.Fprintf(&b, "\\%02x", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// compileFilter 支持 &、|、!、等值与存在性（attr=*）过滤器，不支持子串与范围匹配
+func compileFilter(filter string) ([]byte, error) {
+	encoded, rest, err := parseFilter(strings.TrimSpace(filter))
+	if err != nil {
+		return nil, err
+	}
+	if rest != "" {
+		return nil, fmt.Errorf("ldap: unexpected %q after filter", rest)
+	}
+	return encoded, nil
+}
+
+func parseFilter(s string) ([]byte, string, error) {
+	if !strings.HasPrefix(s, "(") {
+		return nil, "", fmt.Errorf("ldap: filter must start with '(' near %q", s)
+	}
+	s = s[1:]
+	if s == "" {
+		return nil, "", fmt.Errorf("ldap: unterminated filter")
+	}
+
+	switch s[0] {
+	case '&', '|':
+		tag := byte(tagFilterAnd)
+		if s[0] == '|' {
+			tag = tagFilterOr
+		}
+		s = s[1:]
+		var items [][]byte
+		for strings.HasPrefix(s, "(") {
+			item, rest, err := parseFilter(s)
+			if err != nil {
+				return nil, "", err
+			}
+			items = append(items, item)
+			s = rest
+		}
+		if !strings.HasPrefix(s, ")") || len(items) == 0 {
+			return nil, "", fmt.Errorf("ldap: malformed filter set")
+		}
+		return encodeSeq(tag, items...), s[1:], nil
+	case '!':
+		item, rest, err := parseFilter(s[1:])
+		if err != nil {
+			return nil, "", err
+		}
+		if !strings.HasPrefix(rest, ")") {
+			return nil, "", fmt.Errorf("ldap: malformed not filter")
+		}
+		return encodeSeq(tagFilterNot, item), rest[1:], nil
+	}
+
+	end := strings.IndexByte(s, ')')
+	if end < 0 {
+		return nil, "", fmt.Errorf("ldap: unterminated filter")
+	}
+	attr, value, ok := strings.Cut(s[:end], "=")
+	if !ok || attr == "" || strings.ContainsAny(attr, "~<>:") {
+		return nil, "", fmt.Errorf("ldap: unsupported filter item %q", s[:end])
+	}
+	if value == "*" {
+		return encodeString(tagFilterPresent, attr), s[end+1:], nil
+	}
+	if strings.Contains(value, "*") {
+		return nil, "", fmt.Errorf("ldap: substring filters are not supported")
+	}
+	raw, err := unescapeFilter(value)
+	if err != nil {
+		return nil, "", err
+	}
+	return encodeSeq(tagFilterEquality, encodeString(tagOctetString, attr), encodeString(tagOctetString, raw)), s[end+1:], nil
+}
+
+func unescapeFilter(value string) (string, error) {
+	if !strings.Contains(value, "\\") {
+		return value, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' {
+			b.WriteByte(value[i])
+			continue
+		}
+		if i+3 > len(value) {
+			return "", fmt.Errorf("ldap: bad escape in %q", value)
+		}
+		decoded, err := hex.DecodeString(value[i+1 : i+3])
+		if err != nil {
+			return "", fmt.Errorf("ldap: bad escape in %q", value)
+		}
+		b.Write(decoded)
+		i += 2
+	}
+	return b.String(), nil
+}
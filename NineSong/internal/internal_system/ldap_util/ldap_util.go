@@ -0,0 +1,288 @@
+package ldap_util
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCredentials 用户不存在、匹配到多个条目或密码错误
+var ErrInvalidCredentials = errors.New("ldap: invalid credentials")
+
+const (
+	resultSuccess            = 0
+	resultInvalidCredentials = 49
+	dialTimeout              = 5 * time.Second
+)
+
+// Config 连接与查找用户所需的配置
+type Config struct {
+	URL          string // ldap://host:389 或 ldaps://host:636
+	BindDN       string // 查找用户使用的服务账号，为空时匿名查找
+	BindPassword string
+	BaseDN       string
+	UserFilter   string // %s 替换为转义后的用户名，如 (uid=%s)
+	EmailAttr    string
+	NameAttr     string
+	GroupAttr    string // 如 memberOf
+}
+
+// Entry 查找到的用户条目
+type Entry struct {
+	DN         string
+	Attributes map[string][]string
+}
+
+func (e *Entry) First(attr string) string {
+	for name, values := range e.Attributes {
+		if strings.EqualFold(name, attr) && len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+func (e *Entry) All(attr string) []string {
+	for name, values := range e.Attributes {
+		if strings.EqualFold(name, attr) {
+			return values
+		}
+	}
+	return nil
+}
+
+// Authenticate 先以服务账号查找用户，再以用户 DN 和密码绑定校验；每次调用使用独立连接
+func Authenticate(ctx context.Context, cfg Config, username, password string) (*Entry, error) {
+	// 空密码的简单绑定会被服务端当作匿名绑定而成功
+	if username == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+	filter, err := compileFilter(fmt.Sprintf(cfg.UserFilter, EscapeFilter(username)))
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := dial(ctx, cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.close()
+
+	if cfg.BindDN != "" {
+		if err := conn.bind(cfg.BindDN, cfg.BindPassword); err != nil {
+			return nil, fmt.Errorf("ldap service bind failed: %w", err)
+		}
+	}
+	attrs := []string{cfg.EmailAttr, cfg.NameAttr, cfg.GroupAttr}
+	entries, err := conn.search(cfg.BaseDN, filter, attrs)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) != 1 {
+		return nil, ErrInvalidCredentials
+	}
+	if err := conn.bind(entries[0].DN, password); err != nil {
+		return nil, err
+	}
+	return &entries[0], nil
+}
+
+type conn struct {
+	nc     net.Conn
+	reader *bufio.Reader
+	nextID int
+}
+
+func dial(ctx context.Context, rawURL string) (*conn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ldap url: %w", err)
+	}
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	var nc net.Conn
+	switch u.Scheme {
+	case "ldap":
+		addr := u.Host
+		if u.Port() == "" {
+			addr = net.JoinHostPort(u.Hostname(), "389")
+		}
+		nc, err = dialer.DialContext(ctx, "tcp", addr)
+	case "ldaps":
+		addr := u.Host
+		if u.Port() == "" {
+			addr = net.JoinHostPort(u.Hostname(), "636")
+		}
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12}}
+		nc, err = tlsDialer.DialContext(ctx, "tcp", addr)
+	default:
+		return nil, fmt.Errorf("unsupported ldap scheme %q", u.Scheme)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("连接LDAP失败: %w", err)
+	}
+	deadline := time.Now().Add(dialTimeout * 2)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = nc.SetDeadline(deadline)
+	return &conn{nc: nc, reader: bufio.NewReader(nc)}, nil
+}
+
+func (c *conn) close() {
+	c.nextID++
+	_, _ = c.nc.Write(encodeSeq(tagSequence, encodeInt(tagInteger, c.nextID), encode(tagUnbindRequest, nil)))
+	_ = c.nc.Close()
+}
+
+func (c *conn) send(op []byte) (int, error) {
+	c.nextID++
+	_, err := c.nc.Write(encodeSeq(tagSequence, encodeInt(tagInteger, c.nextID), op))
+	return c.nextID, err
+}
+
+// receive 读取下一条响应并返回其中的协议操作
+func (c *conn) receive(id int) (berElement, error) {
+	for {
+		msg, err := readElement(c.reader)
+		if err != nil {
+			return berElement{}, err
+		}
+		parts, err := msg.children()
+		if err != nil || len(parts) < 2 {
+			return berElement{}, errMalformed
+		}
+		// 忽略通知类消息（messageID 为 0）
+		if parts[0].int() != id {
+			continue
+		}
+		return parts[1], nil
+	}
+}
+
+func (c *conn) bind(dn, password string) error {
+	id, err := c.send(encodeSeq(tagBindRequest,
+		encodeInt(tagInteger, 3),
+		encodeString(tagOctetString, dn),
+		encodeString(tagSimpleAuth, password),
+	))
+	if err != nil {
+		return err
+	}
+	op, err := c.receive(id)
+	if err != nil {
+		return err
+	}
+	if op.tag != tagBindResponse {
+		return errMalformed
+	}
+	return resultError(op)
+}
+
+func (c *conn) search(baseDN string, filter []byte, attrs []string) ([]Entry, error) {
+	var attrList [][]byte
+	for _, attr := range attrs {
+		if attr != "" {
+			attrList = append(attrList, encodeString(tagOctetString, attr))
+		}
+	}
+	id, err := c.send(encodeSeq(tagSearchRequest,
+		encodeString(tagOctetString, baseDN),
+		encodeInt(tagEnumerated, 2), // wholeSubtree
+		encodeInt(tagEnumerated, 0), // neverDerefAliases
+		encodeInt(tagInteger, 2),    // 只需知道是否唯一
+		encodeInt(tagInteger, int(dialTimeout/time.Second)),
+		encodeBool(false),
+		filter,
+		encodeSeq(tagSequence, attrList...),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for {
+		op, err := c.receive(id)
+		if err != nil {
+			return nil, err
+		}
+		switch op.tag {
+		case tagSearchEntry:
+			entry, err := parseEntry(op)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+		case tagSearchReference:
+		case tagSearchDone:
+			// sizeLimitExceeded(4) 说明匹配到多个条目，交由调用方按非唯一处理
+			if err := resultError(op); err != nil && !isResult(err, 4) {
+				return nil, err
+			}
+			return entries, nil
+		default:
+			return nil, errMalformed
+		}
+	}
+}
+
+func parseEntry(op berElement) (Entry, error) {
+	parts, err := op.children()
+	if err != nil || len(parts) < 2 {
+		return Entry{}, errMalformed
+	}
+	entry := Entry{DN: string(parts[0].content), Attributes: make(map[string][]string)}
+	attrs, err := parts[1].children()
+	if err != nil {
+		return Entry{}, errMalformed
+	}
+	for _, attr := range attrs {
+		fields, err := attr.children()
+		if err != nil || len(fields) < 2 {
+			return Entry{}, errMalformed
+		}
+		values, err := fields[1].children()
+		if err != nil {
+			return Entry{}, errMalformed
+		}
+		name := string(fields[0].content)
+		for _, v := range values {
+			entry.Attributes[name] = append(entry.Attributes[name], string(v.content))
+		}
+	}
+	return entry, nil
+}
+
+type resultCodeError struct {
+	code    int
+	message string
+}
+
+func (e resultCodeError) Error() string {
+	return fmt.Sprintf("ldap: result code %d: %s", e.code, e.message)
+}
+
+func isResult(err error, code int) bool {
+	var rc resultCodeError
+	return errors.As(err, &rc) && rc.code == code
+}
+
+func resultError(op berElement) error {
+	parts, err := op.children()
+	if err != nil || len(parts) < 3 {
+		return errMalformed
+	}
+	switch code := parts[0].int(); code {
+	case resultSuccess:
+		return nil
+	case resultInvalidCredentials:
+		return ErrInvalidCredentials
+	default:
+		return resultCodeError{code: code, message: string(parts[2].content)}
+	}
+}
@@ -0,0 +1,240 @@
+package oidc_util
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+)
+
+// Config 授权码流程所需的客户端配置
+type Config struct {
+	Issuer       string // 如 https://auth.example.com/application/o/ninesong/
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	GroupsClaim  string
+}
+
+// Claims ID Token 中用到的声明
+type Claims struct {
+	Subject string
+	Email   string
+	Name    string
+	Groups  []string
+}
+
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Client 首次使用时读取发现文档，签名公钥按 kid 缓存，遇到未知 kid 时重新拉取
+type Client struct {
+	cfg  Config
+	http *http.Client
+
+	mu   sync.Mutex
+	meta *discovery
+	keys map[string]interface{}
+}
+
+func NewClient(cfg Config, timeout time.Duration) *Client {
+	return &Client{cfg: cfg, http: &http.Client{Timeout: timeout}}
+}
+
+// NewState 生成 state、nonce 与 PKCE code_verifier
+func NewState() (state, nonce, verifier string) {
+	return randomString(), randomString(), randomString()
+}
+
+// AuthURL 返回跳转到身份提供方的授权地址
+func (c *Client) AuthURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	meta, err := c.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	challenge := sha256.Sum256([]byte(verifier))
+	q := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {c.cfg.ClientID},
+		"redirect_uri":          {c.cfg.RedirectURL},
+		"scope":                 {strings.Join(c.cfg.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+	sep := "?"
+	if strings.Contains(meta.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return meta.AuthorizationEndpoint + sep + q.Encode(), nil
+}
+
+// Exchange 用授权码换取并校验 ID Token
+func (c *Client) Exchange(ctx context.Context, code, nonce, verifier string) (*Claims, error) {
+	meta, err := c.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {c.cfg.RedirectURL},
+		"code_verifier": {verifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(c.cfg.ClientID), url.QueryEscape(c.cfg.ClientSecret))
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求OIDC令牌失败: %w", err)
+	}
+	defer res.Body.Close()
+	var token struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&token); err != nil {
+		return nil, fmt.Errorf("解析OIDC令牌响应失败: %w", err)
+	}
+	if res.StatusCode != http.StatusOK || token.IDToken == "" {
+		return nil, fmt.Errorf("oidc token endpoint returned %d: %s %s", res.StatusCode, token.Error, token.ErrorDescription)
+	}
+	return c.verify(ctx, meta, token.IDToken, nonce)
+}
+
+func (c *Client) verify(ctx context.Context, meta *discovery, rawToken, nonce string) (*Claims, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(rawToken, claims, func(token *jwt.Token) (interface{}, error) {
+		switch token.Method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA, *jwt.SigningMethodRSAPSS:
+		default:
+			return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+		}
+		kid, _ := token.Header["kid"].(string)
+		return c.key(ctx, meta, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid id token: %w", err)
+	}
+
+	if !claims.VerifyIssuer(meta.Issuer, true) {
+		return nil, errors.New("invalid id token: issuer mismatch")
+	}
+	if !claims.VerifyAudience(c.cfg.ClientID, true) {
+		return nil, errors.New("invalid id token: audience mismatch")
+	}
+	if got, _ := claims["nonce"].(string); got != nonce {
+		return nil, errors.New("invalid id token: nonce mismatch")
+	}
+
+	out := &Claims{}
+	out.Subject, _ = claims["sub"].(string)
+	out.Email, _ = claims["email"].(string)
+	for _, key := range []string{"name", "preferred_username", "nickname"} {
+		if name, _ := claims[key].(string); name != "" {
+			out.Name = name
+			break
+		}
+	}
+	switch groups := claims[c.cfg.GroupsClaim].(type) {
+	case []interface{}:
+		for _, g := range groups {
+			if s, ok := g.(string); ok {
+				out.Groups = append(out.Groups, s)
+			}
+		}
+	case string:
+		out.Groups = strings.Fields(strings.ReplaceAll(groups, ",", " "))
+	}
+	if out.Subject == "" {
+		return nil, errors.New("invalid id token: missing subject")
+	}
+	return out, nil
+}
+
+func (c *Client) discover(ctx context.Context) (*discovery, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.meta != nil {
+		return c.meta, nil
+	}
+
+	var meta discovery
+	if err := c.getJSON(ctx, strings.TrimSuffix(c.cfg.Issuer, "/")+"/.well-known/openid-configuration", &meta); err != nil {
+		return nil, fmt.Errorf("读取OIDC发现文档失败: %w", err)
+	}
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return nil, errors.New("oidc discovery document is incomplete")
+	}
+	c.meta = &meta
+	return c.meta, nil
+}
+
+func (c *Client) key(ctx context.Context, meta *discovery, kid string) (interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if key, ok := c.keys[kid]; ok {
+		return key, nil
+	}
+
+	var set jwks
+	if err := c.getJSON(ctx, meta.JWKSURI, &set); err != nil {
+		return nil, fmt.Errorf("读取OIDC签名公钥失败: %w", err)
+	}
+	c.keys = set.publicKeys()
+	if key, ok := c.keys[kid]; ok {
+		return key, nil
+	}
+	// 只有一把公钥且令牌未声明 kid 时直接使用
+	if kid == "" && len(c.keys) == 1 {
+		for _, key := range c.keys {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+func (c *Client) getJSON(ctx context.Context, rawURL string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	res, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %d", rawURL, res.StatusCode)
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+func randomString() string {
+	buf := make([]byte, 32)
+	_, _ = rand.Read(buf)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
@@ -0,0 +1,63 @@
+package status_util
+
+import (
+	"sync"
+	"time"
+)
+
+// recentErrorCapacity 只保留最近的服务端错误，供管理面板展示
+const recentErrorCapacity = 50
+
+var startedAt = time.Now()
+
+// StartedAt 进程启动时间
+func StartedAt() time.Time {
+	return startedAt
+}
+
+// Uptime 进程已运行时长
+func Uptime() time.Duration {
+	return time.Since(startedAt)
+}
+
+// RecentError 一次返回 5xx 的请求
+type RecentError struct {
+	Time    time.Time `json:"time"`
+	Method  string    `json:"method"`
+	Path    string    `json:"path"`
+	Status  int       `json:"status"`
+	Code    string    `json:"code"`
+	Message string    `json:"message"`
+}
+
+var (
+	errorsMu   sync.Mutex
+	errorsRing = make([]RecentError, 0, recentErrorCapacity)
+	errorsNext int
+)
+
+// RecordError 写入环形缓冲，超出容量时覆盖最早的记录
+func RecordError(e RecentError) {
+	errorsMu.Lock()
+	defer errorsMu.Unlock()
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	if len(errorsRing) < recentErrorCapacity {
+		errorsRing = append(errorsRing, e)
+		return
+	}
+	errorsRing[errorsNext] = e
+	errorsNext = (errorsNext + 1) % recentErrorCapacity
+}
+
+// RecentErrors 按时间倒序返回最近的错误
+func RecentErrors() []RecentError {
+	errorsMu.Lock()
+	defer errorsMu.Unlock()
+	result := make([]RecentError, 0, len(errorsRing))
+	for i := len(errorsRing) - 1; i >= 0; i-- {
+		result = append(result, errorsRing[(errorsNext+i)%len(errorsRing)])
+	}
+	return result
+}
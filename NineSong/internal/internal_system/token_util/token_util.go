@@ -1,6 +1,10 @@
 package token_util
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	domain_system_auth2 "github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_auth"
 	"time"
@@ -73,3 +77,18 @@ func ExtractIDFromToken(requestToken string, secret string) (string, error) {
 
 	return claims["id"].(string), nil
 }
+
+// GenerateAPIKey 生成带前缀的随机 API 密钥，32 字节随机数经 base64url 编码
+func GenerateAPIKey() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return domain_system_auth2.APIKeyPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// HashAPIKey 密钥本身已足够随机，无需加盐的慢哈希
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,252 @@
+package jukebox_util
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// commandTimeout 单条 IPC 命令等待 mpv 应答的最长时间
+	commandTimeout = 5 * time.Second
+	// startupTimeout 启动 mpv 后等待 IPC 套接字可连接的最长时间
+	startupTimeout = 5 * time.Second
+)
+
+var ErrPlayerUnavailable = errors.New("jukebox player unavailable")
+
+// Backend 服务器本机的音频输出，由点唱机业务层驱动
+type Backend interface {
+	Load(path string) error
+	SetPause(paused bool) error
+	Seek(seconds float64) error
+	SetVolume(volume int) error
+	Position() (float64, error)
+	Stop() error
+	// Ended 当前曲目自然播放结束时收到通知，手动切换或停止不会触发
+	Ended() <-chan struct{}
+	Close() error
+}
+
+type ipcResponse struct {
+	RequestID int64           `json:"request_id"`
+	Error     string          `json:"error"`
+	Data      json.RawMessage `json:"data"`
+	Event     string          `json:"event"`
+	Reason    string          `json:"reason"`
+}
+
+// MPV 通过 JSON IPC 控制常驻的 mpv 进程；进程意外退出后在下一条命令时重新启动。
+// IPC 使用 Unix 套接字，Windows 上的命名管道暂不支持
+type MPV struct {
+	path   string
+	device string
+	socket string
+
+	mu      sync.Mutex
+	cmd     *exec.Cmd
+	conn    net.Conn
+	nextID  int64
+	pending map[int64]chan ipcResponse
+	ended   chan struct{}
+}
+
+// NewMPV path 为空时从 PATH 中查找 mpv，device 为空时使用系统默认输出设备
+func NewMPV(path, device string) *MPV {
+	if path == "" {
+		path = "mpv"
+	}
+	return &MPV{
+		path:    path,
+		device:  device,
+		socket:  filepath.Join(os.TempDir(), "ninesong-jukebox-"+strconv.Itoa(os.Getpid())+".sock"),
+		pending: make(map[int64]chan ipcResponse),
+		ended:   make(chan struct{}, 1),
+	}
+}
+
+func (m *MPV) Load(path string) error {
+	_, err := m.command("loadfile", path, "replace")
+	return err
+}
+
+func (m *MPV) SetPause(paused bool) error {
+	_, err := m.command("set_property", "pause", paused)
+	return err
+}
+
+func (m *MPV) Seek(seconds float64) error {
+	_, err := m.command("seek", seconds, "absolute")
+	return err
+}
+
+func (m *MPV) SetVolume(volume int) error {
+	_, err := m.command("set_property", "volume", volume)
+	return err
+}
+
+func (m *MPV) Position() (float64, error) {
+	data, err := m.command("get_property", "time-pos")
+	if err != nil {
+		return 0, err
+	}
+	var pos float64
+	if err := json.Unmarshal(data, &pos); err != nil {
+		return 0, nil
+	}
+	return pos, nil
+}
+
+func (m *MPV) Stop() error {
+	_, err := m.command("stop")
+	return err
+}
+
+func (m *MPV) Ended() <-chan struct{} {
+	return m.ended
+}
+
+func (m *MPV) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.shutdownLocked()
+	return nil
+}
+
+func (m *MPV) command(args ...any) (json.RawMessage, error) {
+	m.mu.Lock()
+	if err := m.ensureStartedLocked(); err != nil {
+		m.mu.Unlock()
+		return nil, err
+	}
+	m.nextID++
+	id := m.nextID
+	reply := make(chan ipcResponse, 1)
+	m.pending[id] = reply
+
+	payload, _ := json.Marshal(map[string]any{"command": args, "request_id": id})
+	_, err := m.conn.Write(append(payload, '\n'))
+	m.mu.Unlock()
+	if err != nil {
+		m.dropPending(id)
+		return nil, fmt.Errorf("%w: %v", ErrPlayerUnavailable, err)
+	}
+
+	select {
+	case resp := <-reply:
+		if resp.Error != "" && resp.Error != "success" {
+			return nil, fmt.Errorf("mpv %v: %s", args[0], resp.Error)
+		}
+		return resp.Data, nil
+	case <-time.After(commandTimeout):
+		m.dropPending(id)
+		return nil, fmt.Errorf("%w: mpv %v timed out", ErrPlayerUnavailable, args[0])
+	}
+}
+
+func (m *MPV) dropPending(id int64) {
+	m.mu.Lock()
+	delete(m.pending, id)
+	m.mu.Unlock()
+}
+
+func (m *MPV) ensureStartedLocked() error {
+	if m.conn != nil {
+		return nil
+	}
+	_ = os.Remove(m.socket)
+
+	args := []string{"--idle=yes", "--no-video", "--no-terminal", "--input-ipc-server=" + m.socket}
+	if m.device != "" {
+		args = append(args, "--audio-device="+m.device)
+	}
+	cmd := exec.Command(m.path, args...)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("%w: %v", ErrPlayerUnavailable, err)
+	}
+
+	var conn net.Conn
+	deadline := time.Now().Add(startupTimeout)
+	for {
+		var err error
+		if conn, err = net.Dial("unix", m.socket); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			_ = cmd.Process.Kill()
+			_ = cmd.Wait()
+			return fmt.Errorf("%w: connect ipc: %v", ErrPlayerUnavailable, err)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	m.cmd = cmd
+	m.conn = conn
+	go m.readLoop(conn)
+	go func() {
+		if err := cmd.Wait(); err != nil {
+			log.Printf("点唱机播放进程退出: %v", err)
+		}
+	}()
+	return nil
+}
+
+// readLoop 分发命令应答与播放结束事件，连接断开时清理状态以便下次命令重新启动进程
+func (m *MPV) readLoop(conn net.Conn) {
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var resp ipcResponse
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+			continue
+		}
+		if resp.Event != "" {
+			if resp.Event == "end-file" && resp.Reason == "eof" {
+				select {
+				case m.ended <- struct{}{}:
+				default:
+				}
+			}
+			continue
+		}
+
+		m.mu.Lock()
+		reply, ok := m.pending[resp.RequestID]
+		delete(m.pending, resp.RequestID)
+		m.mu.Unlock()
+		if ok {
+			reply <- resp
+		}
+	}
+
+	m.mu.Lock()
+	if m.conn == conn {
+		m.shutdownLocked()
+	}
+	m.mu.Unlock()
+}
+
+func (m *MPV) shutdownLocked() {
+	if m.conn != nil {
+		_ = m.conn.Close()
+		m.conn = nil
+	}
+	if m.cmd != nil && m.cmd.Process != nil {
+		_ = m.cmd.Process.Kill()
+		m.cmd = nil
+	}
+	for id, reply := range m.pending {
+		reply <- ipcResponse{RequestID: id, Error: ErrPlayerUnavailable.Error()}
+		delete(m.pending, id)
+	}
+	_ = os.Remove(m.socket)
+}
@@ -0,0 +1,145 @@
+package lock_util
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+// ErrLocked 锁已被其他任务或实例持有
+var ErrLocked = errors.New("lock is held by another owner")
+
+// 常用锁键，多实例共享同一 MongoDB 与媒体库时用于互斥
+const (
+	KeyScanGlobal = "scan:global" // 全局扫描与数据修复，集群内同一时间只允许一个
+	KeyScanPath   = "scan:path:"  // 前缀，后接媒体库路径
+	KeyMigrations = "migrations"  // 启动时的索引与数据迁移
+
+	// heldTTL 持有期间的锁有效期，实例崩溃后最多这么久即可被其他实例接管
+	heldTTL = time.Minute
+)
+
+// Locker 带过期时间的互斥锁与按间隔放行的节流器；多实例实现需保证跨实例可见
+type Locker interface {
+	// TryLock 锁空闲时以 token 占用 ttl，已被占用时返回 ErrLocked
+	TryLock(ctx context.Context, key, token string, ttl time.Duration) error
+	// Refresh 仍由 token 持有时延长有效期，否则返回 ErrLocked
+	Refresh(ctx context.Context, key, token string, ttl time.Duration) error
+	// Unlock 只释放由 token 持有的锁
+	Unlock(ctx context.Context, key, token string) error
+	// Held 锁当前是否被任意持有者占用
+	Held(ctx context.Context, key string) (bool, error)
+	// Throttle 阻塞到距同一键上次放行至少 interval
+	Throttle(ctx context.Context, key string, interval time.Duration) error
+}
+
+var (
+	defaultMu     sync.RWMutex
+	defaultLocker Locker = NewMemoryLocker()
+)
+
+// Configure 在启动时设置共享锁实例
+func Configure(l Locker) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultLocker = l
+}
+
+func Default() Locker {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultLocker
+}
+
+// Acquire 尝试占用 keys 中的全部锁，任一被占用时释放已占用的并返回 ErrLocked；
+// 持有期间后台定期续期，返回的 release 可重复调用
+func Acquire(ctx context.Context, keys ...string) (release func(), err error) {
+	locker := Default()
+	token := newToken()
+
+	held := make([]string, 0, len(keys))
+	unlockAll := func() {
+		for _, key := range held {
+			if err := locker.Unlock(context.Background(), key, token); err != nil {
+				log.Printf("释放锁 %s 失败: %v", key, err)
+			}
+		}
+	}
+	for _, key := range keys {
+		if err := locker.TryLock(ctx, key, token, heldTTL); err != nil {
+			unlockAll()
+			return nil, err
+		}
+		held = append(held, key)
+	}
+
+	stop := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(heldTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+			for _, key := range held {
+				if err := locker.Refresh(context.Background(), key, token, heldTTL); err != nil {
+					log.Printf("锁 %s 续期失败: %v", key, err)
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(stop)
+			unlockAll()
+		})
+	}, nil
+}
+
+// Held 查询共享锁是否被占用
+func Held(ctx context.Context, key string) (bool, error) {
+	return Default().Held(ctx, key)
+}
+
+// Throttle 使用共享节流器，同一键在所有实例上按 interval 串行放行
+func Throttle(ctx context.Context, key string, interval time.Duration) error {
+	return Default().Throttle(ctx, key, interval)
+}
+
+// RunExclusive 集群内只有一个实例执行 fn，其他实例正在执行时直接跳过；用于各实例都会启动的后台定时任务
+func RunExclusive(ctx context.Context, key string, fn func(ctx context.Context) error) error {
+	release, err := Acquire(ctx, "job:"+key)
+	if errors.Is(err, ErrLocked) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer release()
+	return fn(ctx)
+}
+
+func newToken() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
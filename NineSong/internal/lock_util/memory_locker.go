@@ -0,0 +1,85 @@
+package lock_util
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryLocker 进程内实现，单实例部署的默认选择
+type memoryLocker struct {
+	mu    sync.Mutex
+	locks map[string]memoryLock
+	next  map[string]time.Time // 节流键下一次可放行的时间
+}
+
+type memoryLock struct {
+	token   string
+	expires time.Time
+}
+
+func NewMemoryLocker() Locker {
+	return &memoryLocker{
+		locks: make(map[string]memoryLock),
+		next:  make(map[string]time.Time),
+	}
+}
+
+func (l *memoryLocker) TryLock(_ context.Context, key, token string, ttl time.Duration) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if current, ok := l.locks[key]; ok && current.expires.After(now) {
+		return ErrLocked
+	}
+	l.locks[key] = memoryLock{token: token, expires: now.Add(ttl)}
+	return nil
+}
+
+func (l *memoryLocker) Refresh(_ context.Context, key, token string, ttl time.Duration) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	current, ok := l.locks[key]
+	if !ok || current.token != token {
+		return ErrLocked
+	}
+	l.locks[key] = memoryLock{token: token, expires: time.Now().Add(ttl)}
+	return nil
+}
+
+func (l *memoryLocker) Unlock(_ context.Context, key, token string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if current, ok := l.locks[key]; ok && current.token == token {
+		delete(l.locks, key)
+	}
+	return nil
+}
+
+func (l *memoryLocker) Held(_ context.Context, key string) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	current, ok := l.locks[key]
+	return ok && current.expires.After(time.Now()), nil
+}
+
+// Throttle 预约下一个可用时间点后在锁外等待，不阻塞其他键
+func (l *memoryLocker) Throttle(ctx context.Context, key string, interval time.Duration) error {
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next[key]
+	if at.Before(now) {
+		at = now
+	}
+	l.next[key] = at.Add(interval)
+	l.mu.Unlock()
+
+	if delay := time.Until(at); delay > 0 {
+		return sleep(ctx, delay)
+	}
+	return nil
+}
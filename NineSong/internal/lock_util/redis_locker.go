@@ -0,0 +1,87 @@
+package lock_util
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cache_util"
+)
+
+const (
+	redisKeyPrefix = "ninesong:lock:"
+
+	// 比较 token 后再操作，避免锁过期被他人占用后误删或误续期
+	redisUnlockScript  = `if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("DEL", KEYS[1]) else return 0 end`
+	redisRefreshScript = `if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("PEXPIRE", KEYS[1], ARGV[2]) else return 0 end`
+
+	// redisThrottlePoll 节流键剩余时间未知时的重试间隔
+	redisThrottlePoll = 50 * time.Millisecond
+)
+
+// redisLocker 基于 SET NX PX 的单节点 Redis 锁，多个实例共享同一 Redis 即可互斥
+type redisLocker struct {
+	client *cache_util.RedisClient
+}
+
+func NewRedisLocker(client *cache_util.RedisClient) Locker {
+	return &redisLocker{client: client}
+}
+
+func (l *redisLocker) TryLock(ctx context.Context, key, token string, ttl time.Duration) error {
+	_, err := l.client.Do(ctx, "SET", redisKeyPrefix+key, token, "NX", "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	if errors.Is(err, cache_util.ErrRedisNil) {
+		return ErrLocked
+	}
+	return err
+}
+
+func (l *redisLocker) Refresh(ctx context.Context, key, token string, ttl time.Duration) error {
+	reply, err := l.client.Do(ctx, "EVAL", redisRefreshScript, "1", redisKeyPrefix+key, token, strconv.FormatInt(ttl.Milliseconds(), 10))
+	if err != nil {
+		return err
+	}
+	if n, _ := reply.(int64); n == 0 {
+		return ErrLocked
+	}
+	return nil
+}
+
+func (l *redisLocker) Unlock(ctx context.Context, key, token string) error {
+	_, err := l.client.Do(ctx, "EVAL", redisUnlockScript, "1", redisKeyPrefix+key, token)
+	return err
+}
+
+func (l *redisLocker) Held(ctx context.Context, key string) (bool, error) {
+	reply, err := l.client.Do(ctx, "EXISTS", redisKeyPrefix+key)
+	if err != nil {
+		return false, err
+	}
+	n, _ := reply.(int64)
+	return n > 0, nil
+}
+
+// Throttle 抢占一个 interval 长度的节流键，被占用时按剩余时间等待后重试
+func (l *redisLocker) Throttle(ctx context.Context, key string, interval time.Duration) error {
+	redisKey := redisKeyPrefix + "throttle:" + key
+	for {
+		_, err := l.client.Do(ctx, "SET", redisKey, "1", "NX", "PX", strconv.FormatInt(interval.Milliseconds(), 10))
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, cache_util.ErrRedisNil) {
+			return err
+		}
+
+		delay := redisThrottlePoll
+		if reply, err := l.client.Do(ctx, "PTTL", redisKey); err == nil {
+			if ms, _ := reply.(int64); ms > 0 {
+				delay = time.Duration(ms) * time.Millisecond
+			}
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
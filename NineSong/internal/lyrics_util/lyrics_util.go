@@ -0,0 +1,170 @@
+package lyrics_util
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// timeTagPattern LRC 时间标签，如 [01:23.45]、[01:23:450]、[01:23]
+var timeTagPattern = regexp.MustCompile(`\[(\d{1,3}):(\d{1,2})(?:[.:](\d{1,3}))?\]`)
+
+// metaTagPattern [ar:...]、[offset:...] 等标识标签
+var metaTagPattern = regexp.MustCompile(`^\[[A-Za-z]+:[^\]]*\]$`)
+
+// Line 一行同步歌词
+type Line struct {
+	TimeMs int64
+	Text   string
+}
+
+// IsSynced 至少有一行以时间标签开头即视为同步歌词
+func IsSynced(text string) bool {
+	for _, line := range strings.Split(text, "\n") {
+		if loc := timeTagPattern.FindStringIndex(strings.TrimSpace(line)); loc != nil && loc[0] == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse 解析 LRC 文本，返回按时间排序的同步歌词及去除标签后的纯文本；
+// 一行带多个时间标签时展开为多行，[offset:] 以毫秒整体平移时间
+func Parse(text string) ([]Line, string) {
+	text = strings.ReplaceAll(strings.TrimPrefix(text, "\ufeff"), "\r\n", "\n")
+
+	var (
+		lines  []Line
+		plain  []string
+		offset int64
+	)
+	for _, raw := range strings.Split(text, "\n") {
+		raw = strings.TrimSpace(raw)
+		if strings.HasPrefix(strings.ToLower(raw), "[offset:") {
+			value := strings.TrimSuffix(raw[len("[offset:"):], "]")
+			offset, _ = strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+			continue
+		}
+		if metaTagPattern.MatchString(raw) && !timeTagPattern.MatchString(raw) {
+			continue
+		}
+
+		var times []int64
+		rest := raw
+		for {
+			loc := timeTagPattern.FindStringSubmatchIndex(rest)
+			if loc == nil || loc[0] != 0 {
+				break
+			}
+			times = append(times, tagMillis(rest, loc))
+			rest = rest[loc[1]:]
+		}
+		rest = strings.TrimSpace(rest)
+
+		for _, t := range times {
+			lines = append(lines, Line{TimeMs: t, Text: rest})
+		}
+		plain = append(plain, rest)
+	}
+
+	for i := range lines {
+		// offset 为正表示歌词提前
+		lines[i].TimeMs = max(0, lines[i].TimeMs-offset)
+	}
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i].TimeMs < lines[j].TimeMs })
+	return lines, strings.TrimSpace(strings.Join(plain, "\n"))
+}
+
+func tagMillis(s string, loc []int) int64 {
+	minutes, _ := strconv.ParseInt(s[loc[2]:loc[3]], 10, 64)
+	seconds, _ := strconv.ParseInt(s[loc[4]:loc[5]], 10, 64)
+	var fraction int64
+	if loc[6] >= 0 {
+		digits := s[loc[6]:loc[7]]
+		fraction, _ = strconv.ParseInt(digits, 10, 64)
+		// 两位为百分之一秒，一位为十分之一秒
+		for i := len(digits); i < 3; i++ {
+			fraction *= 10
+		}
+	}
+	return minutes*60_000 + seconds*1000 + fraction
+}
+
+// Match 歌词中命中检索词最多的一行，连同下一行作为片段
+type Match struct {
+	Snippet    string
+	Highlights [][2]int // Snippet 中命中部分的字符区间 [start, end)，按字符（rune）计
+	TimeMs     int64    // 同步歌词中命中行的时间，非同步歌词为 -1
+}
+
+// FindMatch 按不区分大小写的包含匹配挑选命中检索词种类最多的行，均未命中时返回 false
+func FindMatch(text string, terms []string) (Match, bool) {
+	lines, plain := Parse(text)
+	if len(lines) == 0 {
+		for _, line := range strings.Split(plain, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				lines = append(lines, Line{TimeMs: -1, Text: line})
+			}
+		}
+	}
+
+	lowered := make([]string, 0, len(terms))
+	for _, term := range terms {
+		if term = strings.ToLower(strings.TrimSpace(term)); term != "" {
+			lowered = append(lowered, term)
+		}
+	}
+
+	best, bestHits := -1, 0
+	for i, line := range lines {
+		text := strings.ToLower(line.Text)
+		hits := 0
+		for _, term := range lowered {
+			if strings.Contains(text, term) {
+				hits++
+			}
+		}
+		if hits > bestHits {
+			best, bestHits = i, hits
+		}
+	}
+	if best < 0 {
+		return Match{}, false
+	}
+
+	snippet := lines[best].Text
+	if best+1 < len(lines) && lines[best+1].Text != "" && lines[best+1].Text != snippet {
+		snippet += " / " + lines[best+1].Text
+	}
+	return Match{
+		Snippet:    snippet,
+		Highlights: highlightRanges(snippet, lowered),
+		TimeMs:     lines[best].TimeMs,
+	}, true
+}
+
+// highlightRanges 检索词在文本中的全部出现位置，重叠区间合并
+func highlightRanges(text string, terms []string) [][2]int {
+	runes := []rune(strings.ToLower(text))
+	var ranges [][2]int
+	for _, term := range terms {
+		termRunes := []rune(term)
+		for i := 0; i+len(termRunes) <= len(runes); i++ {
+			if string(runes[i:i+len(termRunes)]) == term {
+				ranges = append(ranges, [2]int{i, i + len(termRunes)})
+			}
+		}
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+
+	merged := make([][2]int, 0, len(ranges))
+	for _, r := range ranges {
+		if n := len(merged); n > 0 && r[0] <= merged[n-1][1] {
+			merged[n-1][1] = max(merged[n-1][1], r[1])
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
@@ -0,0 +1,177 @@
+package podcast_util
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html/charset"
+)
+
+const itunesNamespace = "http://www.itunes.com/dtds/podcast-1.0.dtd"
+
+// ErrInvalidFeed 内容不是 RSS 2.0 播客订阅源
+var ErrInvalidFeed = errors.New("podcast: invalid rss feed")
+
+// Feed 订阅源中客户端需要的频道信息，只包含带音频附件的条目
+type Feed struct {
+	Title       string
+	Description string
+	Author      string
+	ImageURL    string
+	SiteURL     string
+	Episodes    []Episode
+}
+
+type Episode struct {
+	GUID         string
+	Title        string
+	Description  string
+	PublishedAt  time.Time
+	Duration     time.Duration
+	EnclosureURL string
+	MimeType     string
+	Size         int64
+}
+
+type rssImage struct {
+	XMLName xml.Name
+	URL     string `xml:"url"`
+	Href    string `xml:"href,attr"`
+}
+
+type rssDocument struct {
+	XMLName xml.Name `xml:"rss"`
+	Channel struct {
+		Title       string     `xml:"title"`
+		Description string     `xml:"description"`
+		Link        string     `xml:"link"`
+		Author      string     `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd author"`
+		Images      []rssImage `xml:"image"`
+		Items       []struct {
+			Title       string `xml:"title"`
+			Description string `xml:"description"`
+			Summary     string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd summary"`
+			GUID        string `xml:"guid"`
+			PubDate     string `xml:"pubDate"`
+			Duration    string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd duration"`
+			Enclosure   struct {
+				URL    string `xml:"url,attr"`
+				Type   string `xml:"type,attr"`
+				Length string `xml:"length,attr"`
+			} `xml:"enclosure"`
+		} `xml:"item"`
+	} `xml:"channel"`
+}
+
+// pubDateLayouts RFC 822 及常见的不规范写法
+var pubDateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	"Mon, 02 Jan 2006 15:04 -0700",
+	time.RFC3339,
+}
+
+// Parse 解析 RSS 2.0 订阅源，maxEpisodes 大于 0 时只保留前 maxEpisodes 个条目（订阅源通常按时间倒序）
+func Parse(data []byte, maxEpisodes int) (*Feed, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.CharsetReader = charset.NewReaderLabel
+	decoder.Strict = false
+
+	var doc rssDocument
+	if err := decoder.Decode(&doc); err != nil && !errors.Is(err, io.EOF) {
+		return nil, ErrInvalidFeed
+	}
+	if doc.XMLName.Local != "rss" || strings.TrimSpace(doc.Channel.Title) == "" {
+		return nil, ErrInvalidFeed
+	}
+
+	channel := doc.Channel
+	feed := &Feed{
+		Title:       strings.TrimSpace(channel.Title),
+		Description: strings.TrimSpace(channel.Description),
+		Author:      strings.TrimSpace(channel.Author),
+		SiteURL:     strings.TrimSpace(channel.Link),
+	}
+	// itunes:image 通常分辨率更高，优先使用
+	for _, image := range channel.Images {
+		if image.XMLName.Space == itunesNamespace && image.Href != "" {
+			feed.ImageURL = strings.TrimSpace(image.Href)
+			break
+		}
+		if feed.ImageURL == "" && image.URL != "" {
+			feed.ImageURL = strings.TrimSpace(image.URL)
+		}
+	}
+
+	seen := make(map[string]bool, len(channel.Items))
+	for _, item := range channel.Items {
+		if maxEpisodes > 0 && len(feed.Episodes) >= maxEpisodes {
+			break
+		}
+		enclosure := strings.TrimSpace(item.Enclosure.URL)
+		if enclosure == "" {
+			continue
+		}
+		// 没有 guid 的条目以附件地址区分
+		guid := strings.TrimSpace(item.GUID)
+		if guid == "" {
+			guid = enclosure
+		}
+		if seen[guid] {
+			continue
+		}
+		seen[guid] = true
+
+		description := strings.TrimSpace(item.Description)
+		if description == "" {
+			description = strings.TrimSpace(item.Summary)
+		}
+		size, _ := strconv.ParseInt(strings.TrimSpace(item.Enclosure.Length), 10, 64)
+		feed.Episodes = append(feed.Episodes, Episode{
+			GUID:         guid,
+			Title:        strings.TrimSpace(item.Title),
+			Description:  description,
+			PublishedAt:  parsePubDate(item.PubDate),
+			Duration:     parseDuration(item.Duration),
+			EnclosureURL: enclosure,
+			MimeType:     strings.TrimSpace(item.Enclosure.Type),
+			Size:         size,
+		})
+	}
+	return feed, nil
+}
+
+func parsePubDate(raw string) time.Time {
+	raw = strings.TrimSpace(raw)
+	for _, layout := range pubDateLayouts {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t.UTC()
+		}
+	}
+	return time.Time{}
+}
+
+// parseDuration itunes:duration 可以是秒数、MM:SS 或 HH:MM:SS
+func parseDuration(raw string) time.Duration {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return 0
+	}
+	var seconds float64
+	for _, part := range strings.Split(raw, ":") {
+		value, err := strconv.ParseFloat(part, 64)
+		if err != nil || value < 0 {
+			return 0
+		}
+		seconds = seconds*60 + value
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -33,6 +33,9 @@ type Collection interface {
 	UpdateOne(context.Context, interface{}, interface{}, ...*options.UpdateOptions) (*mongo.UpdateResult, error)
 	UpdateMany(context.Context, interface{}, interface{}, ...*options.UpdateOptions) (*mongo.UpdateResult, error)
 	UpdateByID(ctx context.Context, id interface{}, update interface{}) (*mongo.UpdateResult, error)
+	BulkWrite(context.Context, []mongo.WriteModel, ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error)
+	CreateIndexes(context.Context, []mongo.IndexModel) ([]string, error)
+	ListIndexNames(context.Context) ([]string, error)
 }
 
 type SingleResult interface {
@@ -198,6 +201,32 @@ func (mc *mongoCollection) UpdateByID(ctx context.Context, id interface{}, updat
 	return mc.coll.UpdateByID(ctx, id, update)
 }
 
+func (mc *mongoCollection) BulkWrite(ctx context.Context, models []mongo.WriteModel, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error) {
+	return mc.coll.BulkWrite(ctx, models, opts...)
+}
+
+func (mc *mongoCollection) CreateIndexes(ctx context.Context, models []mongo.IndexModel) ([]string, error) {
+	return mc.coll.Indexes().CreateMany(ctx, models)
+}
+
+func (mc *mongoCollection) ListIndexNames(ctx context.Context) ([]string, error) {
+	cursor, err := mc.coll.Indexes().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var indexes []struct {
+		Name string `bson:"name"`
+	}
+	if err := cursor.All(ctx, &indexes); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(indexes))
+	for _, index := range indexes {
+		names = append(names, index.Name)
+	}
+	return names, nil
+}
+
 func (mc *mongoCollection) CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error) {
 	return mc.coll.CountDocuments(ctx, filter, opts...)
 }
@@ -0,0 +1,382 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
+)
+
+// ErrCircuitOpen 连续失败触发熔断，冷却期内直接失败不再访问数据库
+var ErrCircuitOpen = errors.New("mongo circuit breaker is open")
+
+// ResilienceOptions 重试与熔断参数
+type ResilienceOptions struct {
+	MaxRetries       int           // 瞬时错误的最大重试次数
+	BaseDelay        time.Duration // 首次重试延迟，之后按指数增长并加随机抖动
+	MaxDelay         time.Duration
+	FailureThreshold int           // 连续瞬时失败达到该次数后熔断
+	OpenTimeout      time.Duration // 熔断后的冷却时间，之后放行一次探测请求
+}
+
+func DefaultResilienceOptions() ResilienceOptions {
+	return ResilienceOptions{
+		MaxRetries:       3,
+		BaseDelay:        100 * time.Millisecond,
+		MaxDelay:         2 * time.Second,
+		FailureThreshold: 5,
+		OpenTimeout:      30 * time.Second,
+	}
+}
+
+// 主节点切换、关闭中等可重试的服务端错误码
+var transientErrorCodes = []int{
+	6,     // HostUnreachable
+	7,     // HostNotFound
+	89,    // NetworkTimeout
+	91,    // ShutdownInProgress
+	189,   // PrimarySteppedDown
+	9001,  // SocketException
+	10107, // NotWritablePrimary
+	11600, // InterruptedAtShutdown
+	11602, // InterruptedDueToReplStateChange
+	13435, // NotPrimaryNoSecondaryOk
+	13436, // NotPrimaryOrSecondary
+}
+
+// IsTransientError 网络中断、选主期间无可用节点等可以通过重试恢复的错误
+func IsTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+	// 选主超时会包装调用方的 context 错误，需先于 context 判断
+	var selectionErr topology.ServerSelectionError
+	if errors.As(err, &selectionErr) || mongo.IsNetworkError(err) {
+		return true
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) {
+		if serverErr.HasErrorLabel("RetryableWriteError") || serverErr.HasErrorLabel("TransientTransactionError") {
+			return true
+		}
+		for _, code := range transientErrorCodes {
+			if serverErr.HasErrorCode(code) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsUnavailable 数据库暂时不可用（熔断或瞬时错误），调用方可据此返回降级数据
+func IsUnavailable(err error) bool {
+	return errors.Is(err, ErrCircuitOpen) || IsTransientError(err)
+}
+
+const (
+	breakerClosed = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+type circuitBreaker struct {
+	mu        sync.Mutex
+	state     int
+	failures  int
+	openedAt  time.Time
+	threshold int
+	timeout   time.Duration
+}
+
+// allow 半开状态只放行一个探测请求，其余请求继续快速失败
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) < b.timeout {
+			return false
+		}
+		b.state = breakerHalfOpen
+		return true
+	case breakerHalfOpen:
+		return false
+	}
+	return true
+}
+
+func (b *circuitBreaker) record(err error) {
+	transient := IsTransientError(err)
+	// 调用方取消或慢查询超时不能说明数据库是否可用
+	if !transient && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !transient {
+		if b.state != breakerClosed {
+			log.Printf("MongoDB 已恢复，关闭熔断")
+		}
+		b.state = breakerClosed
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		if b.state != breakerOpen {
+			log.Printf("MongoDB 连续失败 %d 次，熔断 %s: %v", b.failures, b.timeout, err)
+		}
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// resilience 同一数据库的全部集合共享一个熔断器
+type resilience struct {
+	opts    ResilienceOptions
+	breaker *circuitBreaker
+}
+
+// run 执行一次数据库调用；retryable 为 false 的写操作只在请求确定未发出时重试，避免重复写入
+func (r *resilience) run(ctx context.Context, retryable bool, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		if !r.breaker.allow() {
+			return ErrCircuitOpen
+		}
+		err := fn()
+		r.breaker.record(err)
+		if err == nil || attempt >= r.opts.MaxRetries || !r.shouldRetry(err, retryable) {
+			return err
+		}
+
+		delay := r.opts.BaseDelay << attempt
+		if delay > r.opts.MaxDelay {
+			delay = r.opts.MaxDelay
+		}
+		delay = delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (r *resilience) shouldRetry(err error, retryable bool) bool {
+	if !IsTransientError(err) {
+		return false
+	}
+	if retryable {
+		return true
+	}
+	var selectionErr topology.ServerSelectionError
+	if errors.As(err, &selectionErr) {
+		return true
+	}
+	var serverErr mongo.ServerError
+	return errors.As(err, &serverErr) && serverErr.HasErrorLabel("RetryableWriteError")
+}
+
+type resilientDatabase struct {
+	Database
+	res *resilience
+}
+
+type resilientCollection struct {
+	coll Collection
+	res  *resilience
+}
+
+// NewResilientDatabase 为数据库调用增加瞬时错误重试与熔断，接口与原 Database 一致
+func NewResilientDatabase(db Database, opts ResilienceOptions) Database {
+	defaults := DefaultResilienceOptions()
+	if opts.MaxRetries < 0 {
+		opts.MaxRetries = 0
+	}
+	if opts.BaseDelay <= 0 {
+		opts.BaseDelay = defaults.BaseDelay
+	}
+	if opts.MaxDelay <= 0 {
+		opts.MaxDelay = defaults.MaxDelay
+	}
+	if opts.FailureThreshold <= 0 {
+		opts.FailureThreshold = defaults.FailureThreshold
+	}
+	if opts.OpenTimeout <= 0 {
+		opts.OpenTimeout = defaults.OpenTimeout
+	}
+	return &resilientDatabase{
+		Database: db,
+		res: &resilience{
+			opts:    opts,
+			breaker: &circuitBreaker{threshold: opts.FailureThreshold, timeout: opts.OpenTimeout},
+		},
+	}
+}
+
+func (rd *resilientDatabase) Collection(name string) Collection {
+	return &resilientCollection{coll: rd.Database.Collection(name), res: rd.res}
+}
+
+func (rd *resilientDatabase) ListCollectionNames(ctx context.Context, filter interface{}) ([]string, error) {
+	var names []string
+	err := rd.res.run(ctx, true, func() (err error) {
+		names, err = rd.Database.ListCollectionNames(ctx, filter)
+		return err
+	})
+	return names, err
+}
+
+// errSingleResult 熔断或重试失败时返回，Decode 时报告错误
+type errSingleResult struct {
+	err error
+}
+
+func (r errSingleResult) Decode(interface{}) error {
+	return r.err
+}
+
+func (rc *resilientCollection) FindOne(ctx context.Context, filter interface{}) SingleResult {
+	var result SingleResult
+	err := rc.res.run(ctx, true, func() error {
+		result = rc.coll.FindOne(ctx, filter)
+		// 只有原生结果能在 Decode 前取得错误，其余实现（如 mock）直接返回
+		if sr, ok := result.(*mongoSingleResult); ok {
+			if err := sr.sr.Err(); !errors.Is(err, mongo.ErrNoDocuments) {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errSingleResult{err: err}
+	}
+	return result
+}
+
+func (rc *resilientCollection) InsertOne(ctx context.Context, document interface{}) (interface{}, error) {
+	var id interface{}
+	err := rc.res.run(ctx, false, func() (err error) {
+		id, err = rc.coll.InsertOne(ctx, document)
+		return err
+	})
+	return id, err
+}
+
+func (rc *resilientCollection) InsertMany(ctx context.Context, documents []interface{}) ([]interface{}, error) {
+	var ids []interface{}
+	err := rc.res.run(ctx, false, func() (err error) {
+		ids, err = rc.coll.InsertMany(ctx, documents)
+		return err
+	})
+	return ids, err
+}
+
+func (rc *resilientCollection) DeleteOne(ctx context.Context, filter interface{}) (int64, error) {
+	var count int64
+	err := rc.res.run(ctx, false, func() (err error) {
+		count, err = rc.coll.DeleteOne(ctx, filter)
+		return err
+	})
+	return count, err
+}
+
+func (rc *resilientCollection) DeleteMany(ctx context.Context, filter interface{}) (int64, error) {
+	var count int64
+	err := rc.res.run(ctx, false, func() (err error) {
+		count, err = rc.coll.DeleteMany(ctx, filter)
+		return err
+	})
+	return count, err
+}
+
+func (rc *resilientCollection) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (Cursor, error) {
+	var cursor Cursor
+	err := rc.res.run(ctx, true, func() (err error) {
+		cursor, err = rc.coll.Find(ctx, filter, opts...)
+		return err
+	})
+	return cursor, err
+}
+
+func (rc *resilientCollection) CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error) {
+	var count int64
+	err := rc.res.run(ctx, true, func() (err error) {
+		count, err = rc.coll.CountDocuments(ctx, filter, opts...)
+		return err
+	})
+	return count, err
+}
+
+func (rc *resilientCollection) Aggregate(ctx context.Context, pipeline interface{}) (Cursor, error) {
+	var cursor Cursor
+	err := rc.res.run(ctx, true, func() (err error) {
+		cursor, err = rc.coll.Aggregate(ctx, pipeline)
+		return err
+	})
+	return cursor, err
+}
+
+func (rc *resilientCollection) UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	var result *mongo.UpdateResult
+	err := rc.res.run(ctx, false, func() (err error) {
+		result, err = rc.coll.UpdateOne(ctx, filter, update, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (rc *resilientCollection) UpdateMany(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	var result *mongo.UpdateResult
+	err := rc.res.run(ctx, false, func() (err error) {
+		result, err = rc.coll.UpdateMany(ctx, filter, update, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (rc *resilientCollection) UpdateByID(ctx context.Context, id interface{}, update interface{}) (*mongo.UpdateResult, error) {
+	var result *mongo.UpdateResult
+	err := rc.res.run(ctx, false, func() (err error) {
+		result, err = rc.coll.UpdateByID(ctx, id, update)
+		return err
+	})
+	return result, err
+}
+
+func (rc *resilientCollection) BulkWrite(ctx context.Context, models []mongo.WriteModel, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error) {
+	var result *mongo.BulkWriteResult
+	err := rc.res.run(ctx, false, func() (err error) {
+		result, err = rc.coll.BulkWrite(ctx, models, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (rc *resilientCollection) CreateIndexes(ctx context.Context, models []mongo.IndexModel) ([]string, error) {
+	var names []string
+	err := rc.res.run(ctx, true, func() (err error) {
+		names, err = rc.coll.CreateIndexes(ctx, models)
+		return err
+	})
+	return names, err
+}
+
+func (rc *resilientCollection) ListIndexNames(ctx context.Context) ([]string, error) {
+	var names []string
+	err := rc.res.run(ctx, true, func() (err error) {
+		names, err = rc.coll.ListIndexNames(ctx)
+		return err
+	})
+	return names, err
+}
@@ -0,0 +1,86 @@
+package repository_auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_auth"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type apiKeyRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+func NewAPIKeyRepository(db mongo.Database, collection string) domain_auth.APIKeyRepository {
+	return &apiKeyRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (ar *apiKeyRepository) Create(c context.Context, key *domain_auth.APIKey) error {
+	_, err := ar.database.Collection(ar.collection).InsertOne(c, key)
+	return err
+}
+
+func (ar *apiKeyRepository) FetchByUserID(c context.Context, userID string) ([]domain_auth.APIKey, error) {
+	idHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := ar.database.Collection(ar.collection).Find(c,
+		bson.M{"user_id": idHex},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]domain_auth.APIKey, 0)
+	err = cursor.All(c, &keys)
+	return keys, err
+}
+
+func (ar *apiKeyRepository) GetByHash(c context.Context, hash string) (domain_auth.APIKey, error) {
+	var key domain_auth.APIKey
+	err := ar.database.Collection(ar.collection).FindOne(c, bson.M{"key_hash": hash}).Decode(&key)
+	if errors.Is(err, driver.ErrNoDocuments) {
+		return key, domain_auth.ErrInvalidAPIKey
+	}
+	return key, err
+}
+
+// Delete 只删除属于该用户的密钥，其余情况返回 ErrInvalidAPIKey
+func (ar *apiKeyRepository) Delete(c context.Context, userID, id string) error {
+	userHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return err
+	}
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain_auth.ErrInvalidAPIKey
+	}
+
+	deleted, err := ar.database.Collection(ar.collection).DeleteOne(c, bson.M{"_id": idHex, "user_id": userHex})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return domain_auth.ErrInvalidAPIKey
+	}
+	return nil
+}
+
+func (ar *apiKeyRepository) TouchLastUsed(c context.Context, id primitive.ObjectID, at time.Time) error {
+	_, err := ar.database.Collection(ar.collection).UpdateOne(c,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"last_used_at": at}})
+	return err
+}
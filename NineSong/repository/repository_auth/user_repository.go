@@ -2,6 +2,10 @@ package repository_auth
 
 import (
 	"context"
+	"fmt"
+	"log"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_auth"
 
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
@@ -70,3 +74,46 @@ func (ur *userRepository) GetByID(c context.Context, id string) (domain_auth.Use
 	err = collection.FindOne(c, bson.M{"_id": idHex}).Decode(&user)
 	return user, err
 }
+
+func (ur *userRepository) GetByExternalID(c context.Context, source, externalID string) (domain_auth.User, error) {
+	collection := ur.database.Collection(ur.collection)
+	var user domain_auth.User
+	err := collection.FindOne(c, bson.M{"source": source, "external_id": externalID}).Decode(&user)
+	return user, err
+}
+
+// UpdateRoles 同步写入旧版 admin 标记，保证旧版本实例读取时权限一致
+func (ur *userRepository) UpdateRoles(c context.Context, id string, roles []string) error {
+	collection := ur.database.Collection(ur.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	admin := domain_auth.User{Roles: roles}.HasRole(domain_auth.RoleAdmin)
+
+	_, err = collection.UpdateOne(c, bson.M{"_id": idHex}, bson.M{"$set": bson.M{"roles": roles, "admin": admin}})
+	return err
+}
+
+// BackfillUserRoles 为只有 admin 标记的旧用户补写角色
+func BackfillUserRoles(ctx context.Context, db mongo.Database) error {
+	collection := db.Collection(domain.CollectionUser)
+	missing := bson.M{"$or": bson.A{bson.M{"roles": bson.M{"$exists": false}}, bson.M{"roles": nil}}}
+
+	admins, err := collection.UpdateMany(ctx,
+		bson.M{"$and": bson.A{missing, bson.M{"admin": true}}},
+		bson.M{"$set": bson.M{"roles": bson.A{domain_auth.RoleAdmin}}},
+	)
+	if err != nil {
+		return fmt.Errorf("backfill admin roles failed: %w", err)
+	}
+	users, err := collection.UpdateMany(ctx, missing, bson.M{"$set": bson.M{"roles": bson.A{domain_auth.RoleUser}}})
+	if err != nil {
+		return fmt.Errorf("backfill user roles failed: %w", err)
+	}
+	if n := admins.ModifiedCount + users.ModifiedCount; n > 0 {
+		log.Printf("已为 %d 位用户补写角色", n)
+	}
+	return nil
+}
@@ -380,3 +380,70 @@ func (r *folderRepo) UpdateStatus(ctx context.Context, id primitive.ObjectID, st
 	)
 	return err
 }
+
+func (r *folderRepo) UpdateAccess(ctx context.Context, id primitive.ObjectID, users, roles []string) error {
+	result, err := r.db.Collection(r.collection).UpdateByID(
+		ctx,
+		id,
+		bson.M{"$set": bson.M{"allowed_users": users, "allowed_roles": roles, "updated_at": time.Now()}},
+	)
+	if err != nil {
+		return fmt.Errorf("数据库更新失败: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain_file_entity.ErrLibraryNotFound
+	}
+	return nil
+}
+
+func (r *folderRepo) UpdateAudiobook(ctx context.Context, id primitive.ObjectID, audiobook bool) error {
+	result, err := r.db.Collection(r.collection).UpdateByID(
+		ctx,
+		id,
+		bson.M{"$set": bson.M{"audiobook": audiobook, "updated_at": time.Now()}},
+	)
+	if err != nil {
+		return fmt.Errorf("数据库更新失败: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain_file_entity.ErrLibraryNotFound
+	}
+	return nil
+}
+
+// BackfillMediaFolderIDs 为升级前入库的媒体文件按 library_path 补写所属媒体库ID，
+// 增量扫描跳过未变化文件，不会自行补写
+func BackfillMediaFolderIDs(ctx context.Context, db mongo.Database) error {
+	cursor, err := db.Collection(domain.CollectionFileEntityFolderInfo).Find(ctx,
+		bson.M{"folder_type": int(domain_file_entity.MusicLibrary)})
+	if err != nil {
+		return fmt.Errorf("获取媒体库失败: %w", err)
+	}
+	var folders []domain_file_entity.LibraryFolderMetadata
+	if err := cursor.All(ctx, &folders); err != nil {
+		return fmt.Errorf("解码媒体库失败: %w", err)
+	}
+
+	mediaColl := db.Collection(domain.CollectionFileEntityAudioSceneMediaFile)
+	for _, folder := range folders {
+		// 与扫描写入的 library_path 格式保持一致
+		libraryPath := strings.Replace(folder.FolderPath, "/", "\\", -1)
+		if !strings.HasSuffix(libraryPath, "\\") {
+			libraryPath += "\\"
+		}
+		result, err := mediaColl.UpdateMany(ctx,
+			bson.M{
+				"library_path": libraryPath,
+				"folder_id":    bson.M{"$in": bson.A{nil, ""}},
+			},
+			bson.M{"$set": bson.M{"folder_id": folder.ID.Hex()}},
+		)
+		if err != nil {
+			return fmt.Errorf("补写媒体库ID失败 %s: %w", folder.FolderPath, err)
+		}
+		if result.ModifiedCount > 0 {
+			log.Printf("媒体库 %s 已补写媒体库ID %d 条", folder.FolderPath, result.ModifiedCount)
+		}
+	}
+	return nil
+}
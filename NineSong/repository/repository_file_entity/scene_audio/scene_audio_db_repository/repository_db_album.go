@@ -6,6 +6,7 @@ import (
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_interface"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/deletion_util"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -84,8 +85,7 @@ func (r *albumRepository) UpdateByID(ctx context.Context, id primitive.ObjectID,
 }
 
 func (r *albumRepository) DeleteByID(ctx context.Context, id primitive.ObjectID) error {
-	coll := r.db.Collection(r.collection)
-	_, err := coll.DeleteOne(ctx, bson.M{"_id": id})
+	_, err := deletion_util.DeleteOne(ctx, r.db, r.collection, "album", bson.M{"_id": id})
 	if err != nil {
 		return fmt.Errorf("album delete by ID failed: %w", err)
 	}
@@ -93,8 +93,7 @@ func (r *albumRepository) DeleteByID(ctx context.Context, id primitive.ObjectID)
 }
 
 func (r *albumRepository) DeleteByName(ctx context.Context, name string) error {
-	coll := r.db.Collection(r.collection)
-	_, err := coll.DeleteOne(ctx, bson.M{"name": name})
+	_, err := deletion_util.DeleteOne(ctx, r.db, r.collection, "album", bson.M{"name": name})
 	if err != nil {
 		return fmt.Errorf("album delete by name failed: %w", err)
 	}
@@ -102,10 +101,8 @@ func (r *albumRepository) DeleteByName(ctx context.Context, name string) error {
 }
 
 func (r *albumRepository) DeleteAll(ctx context.Context) (int64, error) {
-	coll := r.db.Collection(r.collection)
-
 	// 删除集合中的所有文档
-	result, err := coll.DeleteMany(ctx, bson.M{})
+	result, err := deletion_util.DeleteMany(ctx, r.db, r.collection, "album", bson.M{})
 	if err != nil {
 		return 0, fmt.Errorf("删除所有艺术家失败: %w", err)
 	}
@@ -491,3 +488,63 @@ func (r *albumRepository) InspectAlbumMediaCountByAlbum(ctx context.Context, alb
 		return true, nil
 	}
 }
+
+func (r *albumRepository) RebuildStatistics(ctx context.Context, albumID primitive.ObjectID) (int, error) {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).Aggregate(ctx, []bson.D{
+		{{Key: "$match", Value: bson.M{"album_id": albumID.Hex()}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":        nil,
+			"song_count": bson.M{"$sum": 1},
+			"size":       bson.M{"$sum": "$size"},
+			"duration":   bson.M{"$sum": "$duration"},
+			// 年份为 0 时视为缺失，不参与最小值统计
+			"min_year": bson.M{"$min": bson.M{"$cond": bson.A{bson.M{"$gt": bson.A{"$year", 0}}, "$year", nil}}},
+			"max_year": bson.M{"$max": "$year"},
+		}}},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("album statistics query failed: %w", err)
+	}
+	var stats []struct {
+		SongCount int     `bson:"song_count"`
+		Size      int     `bson:"size"`
+		Duration  float64 `bson:"duration"`
+		MinYear   int     `bson:"min_year"`
+		MaxYear   int     `bson:"max_year"`
+	}
+	err = cursor.All(ctx, &stats)
+	_ = cursor.Close(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("decode album statistics failed: %w", err)
+	}
+	if len(stats) == 0 {
+		return 0, nil
+	}
+
+	_, err = r.db.Collection(r.collection).UpdateByID(ctx, albumID, bson.M{"$set": bson.M{
+		"song_count": stats[0].SongCount,
+		"size":       stats[0].Size,
+		"duration":   stats[0].Duration,
+		"min_year":   stats[0].MinYear,
+		"max_year":   stats[0].MaxYear,
+	}})
+	if err != nil {
+		return 0, fmt.Errorf("album statistics update failed: %w", err)
+	}
+	return stats[0].SongCount, nil
+}
+
+// BackfillAlbumVersionGroups 为升级前入库的专辑补写版本分组ID；未按发行版拆分时专辑ID即分组ID
+func BackfillAlbumVersionGroups(ctx context.Context, db mongo.Database) error {
+	result, err := db.Collection(domain.CollectionFileEntityAudioSceneAlbum).UpdateMany(ctx,
+		bson.M{"version_group_id": bson.M{"$in": bson.A{nil, ""}}},
+		bson.A{bson.M{"$set": bson.M{"version_group_id": bson.M{"$toString": "$_id"}}}},
+	)
+	if err != nil {
+		return fmt.Errorf("补写专辑版本分组失败: %w", err)
+	}
+	if result.ModifiedCount > 0 {
+		log.Printf("已补写专辑版本分组 %d 条", result.ModifiedCount)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package scene_audio_db_repository
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type albumAttachmentRepository struct {
+	db         mongo.Database
+	collection string
+}
+
+func NewAlbumAttachmentRepository(db mongo.Database, collection string) scene_audio_db_interface.AlbumAttachmentRepository {
+	return &albumAttachmentRepository{
+		db:         db,
+		collection: collection,
+	}
+}
+
+func (r *albumAttachmentRepository) SyncFolder(ctx context.Context, albumID, dir string, found []scene_audio_db_models.AlbumAttachmentMetadata) error {
+	coll := r.db.Collection(r.collection)
+	now := time.Now().UTC()
+
+	paths := make(bson.A, 0, len(found))
+	for _, a := range found {
+		paths = append(paths, a.Path)
+		_, err := coll.UpdateOne(ctx,
+			bson.M{"album_id": albumID, "path": a.Path},
+			bson.M{
+				"$set": bson.M{
+					"name":         a.Name,
+					"content_type": a.ContentType,
+					"size":         a.Size,
+					"source":       scene_audio_db_models.AttachmentSourceFolder,
+					"updated_at":   now,
+				},
+				"$setOnInsert": bson.M{"_id": primitive.NewObjectID(), "created_at": now},
+			},
+			options.Update().SetUpsert(true))
+		if err != nil {
+			return fmt.Errorf("attachment upsert failed: %w", err)
+		}
+	}
+
+	// 只清理直接位于 dir 下的文件，同一专辑分布在多个光盘目录时互不影响
+	dirPattern := "^" + regexp.QuoteMeta(filepath.Clean(dir)+string(filepath.Separator)) + `[^/\\]+$`
+	_, err := coll.DeleteMany(ctx, bson.M{
+		"album_id": albumID,
+		"source":   scene_audio_db_models.AttachmentSourceFolder,
+		"path":     bson.M{"$regex": dirPattern, "$nin": paths},
+	})
+	if err != nil {
+		return fmt.Errorf("delete stale attachments failed: %w", err)
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_interface"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/deletion_util"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -62,8 +63,7 @@ func (r *artistRepository) BulkUpsert(ctx context.Context, artists []*scene_audi
 }
 
 func (r *artistRepository) DeleteByID(ctx context.Context, id primitive.ObjectID) error {
-	coll := r.db.Collection(r.collection)
-	_, err := coll.DeleteOne(ctx, bson.M{"_id": id})
+	_, err := deletion_util.DeleteOne(ctx, r.db, r.collection, "artist", bson.M{"_id": id})
 	if err != nil {
 		return fmt.Errorf("artist delete by ID failed: %w", err)
 	}
@@ -71,8 +71,7 @@ func (r *artistRepository) DeleteByID(ctx context.Context, id primitive.ObjectID
 }
 
 func (r *artistRepository) DeleteByName(ctx context.Context, name string) error {
-	coll := r.db.Collection(r.collection)
-	_, err := coll.DeleteOne(ctx, bson.M{"name": name})
+	_, err := deletion_util.DeleteOne(ctx, r.db, r.collection, "artist", bson.M{"name": name})
 	if err != nil {
 		return fmt.Errorf("artist delete by name failed: %w", err)
 	}
@@ -80,10 +79,8 @@ func (r *artistRepository) DeleteByName(ctx context.Context, name string) error
 }
 
 func (r *artistRepository) DeleteAll(ctx context.Context) (int64, error) {
-	coll := r.db.Collection(r.collection)
-
 	// 删除集合中的所有文档
-	result, err := coll.DeleteMany(ctx, bson.M{})
+	result, err := deletion_util.DeleteMany(ctx, r.db, r.collection, "artist", bson.M{})
 	if err != nil {
 		return 0, fmt.Errorf("删除所有艺术家失败: %w", err)
 	}
@@ -240,9 +237,32 @@ func (r *artistRepository) GetByMbzID(ctx context.Context, mbzID string) (*scene
 	return &artist, nil
 }
 
-func (r *artistRepository) DeleteAllInvalid(ctx context.Context) (int64, error) {
+func (r *artistRepository) GetAliasMap(ctx context.Context) (map[string]string, error) {
 	coll := r.db.Collection(r.collection)
+	cursor, err := coll.Find(ctx,
+		bson.M{"aliases.0": bson.M{"$exists": true}},
+		options.Find().SetProjection(bson.M{"name": 1, "aliases": 1}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get artist aliases failed: %w", err)
+	}
+	defer cursor.Close(ctx)
 
+	var artists []scene_audio_db_models.ArtistMetadata
+	if err := cursor.All(ctx, &artists); err != nil {
+		return nil, fmt.Errorf("decode artist aliases failed: %w", err)
+	}
+
+	aliasMap := make(map[string]string)
+	for _, artist := range artists {
+		for _, alias := range artist.Aliases {
+			aliasMap[strings.ToLower(strings.TrimSpace(alias.Name))] = artist.Name
+		}
+	}
+	return aliasMap, nil
+}
+
+func (r *artistRepository) DeleteAllInvalid(ctx context.Context) (int64, error) {
 	filter := bson.M{
 		"$and": []bson.M{
 			{"album_count": bson.M{"$eq": 0, "$exists": true}},
@@ -254,7 +274,7 @@ func (r *artistRepository) DeleteAllInvalid(ctx context.Context) (int64, error)
 		},
 	}
 
-	result, err := coll.DeleteMany(ctx, filter)
+	result, err := deletion_util.DeleteMany(ctx, r.db, r.collection, "artist", filter)
 	if err != nil {
 		return 0, fmt.Errorf("删除无效艺术家失败: %w", err)
 	}
@@ -0,0 +1,112 @@
+package scene_audio_db_repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type availabilityRepository struct {
+	db mongo.Database
+}
+
+func NewAvailabilityRepository(db mongo.Database) scene_audio_db_interface.AvailabilityRepository {
+	return &availabilityRepository{db: db}
+}
+
+func (r *availabilityRepository) GetMediaSources(ctx context.Context, libraryPath string) ([]scene_audio_db_models.MediaSource, error) {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).Find(ctx,
+		bson.M{"library_path": libraryPath},
+		options.Find().SetProjection(bson.M{"_id": 1, "path": 1, "availability": 1}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("media source query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var sources []scene_audio_db_models.MediaSource
+	if err := cursor.All(ctx, &sources); err != nil {
+		return nil, fmt.Errorf("decode media sources failed: %w", err)
+	}
+	return sources, nil
+}
+
+func (r *availabilityRepository) SetMediaAvailability(ctx context.Context, ids []primitive.ObjectID, availability string) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result, err := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).UpdateMany(ctx,
+		bson.M{"_id": bson.M{"$in": ids}},
+		bson.M{"$set": bson.M{"availability": availability}},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("set media availability failed: %w", err)
+	}
+	return result.ModifiedCount, nil
+}
+
+func (r *availabilityRepository) RefreshAlbumAvailability(ctx context.Context) (int64, error) {
+	// 以等级取最大值：任一曲目在线即在线，其次缓存，全部缺失才算缺失；未检查过的曲目视为在线
+	rank := bson.D{{Key: "$switch", Value: bson.D{
+		{Key: "branches", Value: bson.A{
+			bson.D{{Key: "case", Value: bson.D{{Key: "$eq", Value: bson.A{"$availability", scene_audio_db_models.AvailabilityMissing}}}}, {Key: "then", Value: 1}},
+			bson.D{{Key: "case", Value: bson.D{{Key: "$eq", Value: bson.A{"$availability", scene_audio_db_models.AvailabilityCached}}}}, {Key: "then", Value: 2}},
+		}},
+		{Key: "default", Value: 3},
+	}}}
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).Aggregate(ctx, []bson.D{
+		{{Key: "$match", Value: bson.D{{Key: "album_id", Value: bson.D{{Key: "$nin", Value: bson.A{"", nil}}}}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$album_id"},
+			{Key: "rank", Value: bson.D{{Key: "$max", Value: rank}}},
+		}}},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("album availability aggregate failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		AlbumID string `bson:"_id"`
+		Rank    int    `bson:"rank"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return 0, fmt.Errorf("decode album availability failed: %w", err)
+	}
+
+	var models []driver.WriteModel
+	for _, row := range rows {
+		albumID, err := primitive.ObjectIDFromHex(row.AlbumID)
+		if err != nil {
+			continue
+		}
+		availability := scene_audio_db_models.AvailabilityOnline
+		switch row.Rank {
+		case 1:
+			availability = scene_audio_db_models.AvailabilityMissing
+		case 2:
+			availability = scene_audio_db_models.AvailabilityCached
+		}
+		models = append(models, driver.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": albumID, "availability": bson.M{"$ne": availability}}).
+			SetUpdate(bson.M{"$set": bson.M{"availability": availability}}))
+	}
+	if len(models) == 0 {
+		return 0, nil
+	}
+
+	result, err := r.db.Collection(domain.CollectionFileEntityAudioSceneAlbum).BulkWrite(ctx, models,
+		options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return 0, fmt.Errorf("update album availability failed: %w", err)
+	}
+	return result.ModifiedCount, nil
+}
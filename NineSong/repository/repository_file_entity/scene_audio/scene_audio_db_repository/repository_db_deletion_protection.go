@@ -0,0 +1,146 @@
+package scene_audio_db_repository
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type deletionProtectionRepository struct {
+	db mongo.Database
+}
+
+func NewDeletionProtectionRepository(db mongo.Database) scene_audio_db_interface.DeletionProtectionRepository {
+	return &deletionProtectionRepository{db: db}
+}
+
+func (r *deletionProtectionRepository) FindStarred(ctx context.Context, itemType string, ids []primitive.ObjectID) ([]primitive.ObjectID, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	hexIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		hexIDs = append(hexIDs, id.Hex())
+	}
+
+	var starred []primitive.ObjectID
+	for i := 0; i < len(hexIDs); i += maintenanceDeleteBatchSize {
+		end := min(i+maintenanceDeleteBatchSize, len(hexIDs))
+		cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneAnnotation).Find(ctx,
+			bson.M{"item_type": itemType, "item_id": bson.M{"$in": hexIDs[i:end]}, "starred": true},
+			options.Find().SetProjection(bson.M{"item_id": 1}),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("starred %s query failed: %w", itemType, err)
+		}
+		var docs []struct {
+			ItemID string `bson:"item_id"`
+		}
+		err = cursor.All(ctx, &docs)
+		_ = cursor.Close(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("decode starred %s failed: %w", itemType, err)
+		}
+		for _, doc := range docs {
+			if id, err := primitive.ObjectIDFromHex(doc.ItemID); err == nil {
+				starred = append(starred, id)
+			}
+		}
+	}
+	return starred, nil
+}
+
+func (r *deletionProtectionRepository) GetStarredPaths(ctx context.Context, itemType string) ([]string, error) {
+	collection, ok := maintenanceAnnotationCollections[itemType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported item type %s", itemType)
+	}
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneAnnotation).Find(ctx,
+		bson.M{"item_type": itemType, "starred": true},
+		options.Find().SetProjection(bson.M{"item_id": 1}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("starred %s query failed: %w", itemType, err)
+	}
+	var docs []struct {
+		ItemID string `bson:"item_id"`
+	}
+	err = cursor.All(ctx, &docs)
+	_ = cursor.Close(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("decode starred %s failed: %w", itemType, err)
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(docs))
+	for _, doc := range docs {
+		if id, err := primitive.ObjectIDFromHex(doc.ItemID); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return r.paths(ctx, collection, bson.M{"_id": bson.M{"$in": ids}})
+}
+
+func (r *deletionProtectionRepository) GetPathsOutside(ctx context.Context, itemType string, libraryPaths []string) ([]string, error) {
+	collection, ok := maintenanceAnnotationCollections[itemType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported item type %s", itemType)
+	}
+	filter := bson.M{}
+	if len(libraryPaths) > 0 {
+		filter["library_path"] = bson.M{"$nin": libraryPaths}
+	}
+	return r.paths(ctx, collection, filter)
+}
+
+func (r *deletionProtectionRepository) CountInvalid(ctx context.Context, itemType string, validPaths []string) (int64, error) {
+	collection, ok := maintenanceAnnotationCollections[itemType]
+	if !ok {
+		return 0, fmt.Errorf("unsupported item type %s", itemType)
+	}
+	// 与 DeleteAllInvalid 使用相同的路径比较方式
+	valid := make(map[string]struct{}, len(validPaths))
+	for _, path := range validPaths {
+		valid[filepath.Clean(path)] = struct{}{}
+	}
+	paths, err := r.paths(ctx, collection, bson.M{})
+	if err != nil {
+		return 0, err
+	}
+	var invalid int64
+	for _, path := range paths {
+		if _, ok := valid[filepath.Clean(path)]; !ok {
+			invalid++
+		}
+	}
+	return invalid, nil
+}
+
+func (r *deletionProtectionRepository) paths(ctx context.Context, collection string, filter bson.M) ([]string, error) {
+	cursor, err := r.db.Collection(collection).Find(ctx, filter, options.Find().SetProjection(bson.M{"path": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("query %s paths failed: %w", collection, err)
+	}
+	defer cursor.Close(ctx)
+
+	var paths []string
+	for cursor.Next(ctx) {
+		var doc struct {
+			Path string `bson:"path"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("decode %s path failed: %w", collection, err)
+		}
+		paths = append(paths, doc.Path)
+	}
+	return paths, nil
+}
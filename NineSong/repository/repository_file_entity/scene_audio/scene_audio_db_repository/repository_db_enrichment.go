@@ -0,0 +1,223 @@
+package scene_audio_db_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// enrichmentCollections 可匹配条目类型对应的集合
+var enrichmentCollections = map[string]string{
+	scene_audio_db_models.EnrichmentItemAlbum:  domain.CollectionFileEntityAudioSceneAlbum,
+	scene_audio_db_models.EnrichmentItemArtist: domain.CollectionFileEntityAudioSceneArtist,
+	scene_audio_db_models.EnrichmentItemMedia:  domain.CollectionFileEntityAudioSceneMediaFile,
+}
+
+type enrichmentRepository struct {
+	db mongo.Database
+}
+
+func NewEnrichmentRepository(db mongo.Database) scene_audio_db_interface.EnrichmentRepository {
+	return &enrichmentRepository{db: db}
+}
+
+// pendingFilter 未关联 MusicBrainz ID 且近期未检查过的条目
+func pendingFilter(field string, checkedBefore time.Time) bson.M {
+	return bson.M{
+		field: bson.M{"$in": bson.A{"", nil}},
+		"$or": bson.A{
+			bson.M{scene_audio_db_models.MBZCheckedField: bson.M{"$exists": false}},
+			bson.M{scene_audio_db_models.MBZCheckedField: bson.M{"$lt": checkedBefore}},
+		},
+	}
+}
+
+func (r *enrichmentRepository) GetPendingAlbums(ctx context.Context, checkedBefore time.Time, limit int) ([]scene_audio_db_models.EnrichmentAlbum, error) {
+	var albums []scene_audio_db_models.EnrichmentAlbum
+	if err := r.findPending(ctx, domain.CollectionFileEntityAudioSceneAlbum, pendingFilter("mbz_album_id", checkedBefore), limit, &albums); err != nil {
+		return nil, err
+	}
+	return albums, nil
+}
+
+func (r *enrichmentRepository) GetPendingArtists(ctx context.Context, checkedBefore time.Time, limit int) ([]scene_audio_db_models.EnrichmentArtist, error) {
+	var artists []scene_audio_db_models.EnrichmentArtist
+	if err := r.findPending(ctx, domain.CollectionFileEntityAudioSceneArtist, pendingFilter("mbz_artist_id", checkedBefore), limit, &artists); err != nil {
+		return nil, err
+	}
+	return artists, nil
+}
+
+func (r *enrichmentRepository) findPending(ctx context.Context, collection string, filter bson.M, limit int, out interface{}) error {
+	cursor, err := r.db.Collection(collection).Find(ctx, filter,
+		options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(int64(limit)))
+	if err != nil {
+		return fmt.Errorf("query pending %s failed: %w", collection, err)
+	}
+	defer cursor.Close(ctx)
+	if err := cursor.All(ctx, out); err != nil {
+		return fmt.Errorf("decode pending %s failed: %w", collection, err)
+	}
+	return nil
+}
+
+func (r *enrichmentRepository) GetItemType(ctx context.Context, id primitive.ObjectID) (string, error) {
+	for _, itemType := range []string{
+		scene_audio_db_models.EnrichmentItemAlbum,
+		scene_audio_db_models.EnrichmentItemArtist,
+		scene_audio_db_models.EnrichmentItemMedia,
+	} {
+		count, err := r.db.Collection(enrichmentCollections[itemType]).CountDocuments(ctx, bson.M{"_id": id})
+		if err != nil {
+			return "", fmt.Errorf("%s query failed: %w", itemType, err)
+		}
+		if count > 0 {
+			return itemType, nil
+		}
+	}
+	return "", scene_audio_db_models.ErrEnrichmentItemNotFound
+}
+
+func (r *enrichmentRepository) GetAlbum(ctx context.Context, id primitive.ObjectID) (*scene_audio_db_models.EnrichmentAlbum, error) {
+	var album scene_audio_db_models.EnrichmentAlbum
+	if err := r.findOne(ctx, domain.CollectionFileEntityAudioSceneAlbum, id, &album); err != nil {
+		return nil, err
+	}
+	return &album, nil
+}
+
+func (r *enrichmentRepository) GetArtist(ctx context.Context, id primitive.ObjectID) (*scene_audio_db_models.EnrichmentArtist, error) {
+	var artist scene_audio_db_models.EnrichmentArtist
+	if err := r.findOne(ctx, domain.CollectionFileEntityAudioSceneArtist, id, &artist); err != nil {
+		return nil, err
+	}
+	return &artist, nil
+}
+
+func (r *enrichmentRepository) GetMediaAlbumID(ctx context.Context, id primitive.ObjectID) (primitive.ObjectID, error) {
+	var media struct {
+		AlbumID string `bson:"album_id"`
+	}
+	if err := r.findOne(ctx, domain.CollectionFileEntityAudioSceneMediaFile, id, &media); err != nil {
+		return primitive.NilObjectID, err
+	}
+	albumID, err := primitive.ObjectIDFromHex(media.AlbumID)
+	if err != nil {
+		return primitive.NilObjectID, scene_audio_db_models.ErrEnrichmentItemNotFound
+	}
+	return albumID, nil
+}
+
+func (r *enrichmentRepository) findOne(ctx context.Context, collection string, id primitive.ObjectID, out interface{}) error {
+	err := r.db.Collection(collection).FindOne(ctx, bson.M{"_id": id}).Decode(out)
+	if err != nil {
+		if errors.Is(err, driver.ErrNoDocuments) {
+			return scene_audio_db_models.ErrEnrichmentItemNotFound
+		}
+		return fmt.Errorf("query %s failed: %w", collection, err)
+	}
+	return nil
+}
+
+func (r *enrichmentRepository) GetAlbumTracks(ctx context.Context, albumID primitive.ObjectID) ([]scene_audio_db_models.EnrichmentTrack, error) {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).Find(ctx,
+		bson.M{"album_id": albumID.Hex()},
+		options.Find().SetProjection(bson.M{"_id": 1, "title": 1, "disc_number": 1, "track_number": 1}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("album tracks query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var tracks []scene_audio_db_models.EnrichmentTrack
+	if err := cursor.All(ctx, &tracks); err != nil {
+		return nil, fmt.Errorf("decode album tracks failed: %w", err)
+	}
+	return tracks, nil
+}
+
+func (r *enrichmentRepository) LinkAlbum(
+	ctx context.Context,
+	id primitive.ObjectID,
+	releaseID, artistID string,
+	edition scene_audio_db_models.EnrichmentAlbumEdition,
+) error {
+	set := bson.M{
+		"mbz_album_id":                        releaseID,
+		scene_audio_db_models.MBZCheckedField: time.Now().UTC(),
+	}
+	if artistID != "" {
+		set["mbz_album_artist_id"] = artistID
+	}
+	for field, value := range map[string]string{
+		"release_country":   edition.ReleaseCountry,
+		"mbz_album_comment": edition.MBZAlbumComment,
+		"catalog_num":       edition.CatalogNum,
+	} {
+		if value != "" {
+			set[field] = value
+		}
+	}
+	if _, err := r.db.Collection(domain.CollectionFileEntityAudioSceneAlbum).UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": set}); err != nil {
+		return fmt.Errorf("link album failed: %w", err)
+	}
+	return nil
+}
+
+func (r *enrichmentRepository) LinkArtist(ctx context.Context, id primitive.ObjectID, artistID string) error {
+	_, err := r.db.Collection(domain.CollectionFileEntityAudioSceneArtist).UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{
+		"mbz_artist_id":                       artistID,
+		scene_audio_db_models.MBZCheckedField: time.Now().UTC(),
+	}})
+	if err != nil {
+		return fmt.Errorf("link artist failed: %w", err)
+	}
+	return nil
+}
+
+func (r *enrichmentRepository) LinkTracks(ctx context.Context, releaseID string, matches []scene_audio_db_models.EnrichmentTrackMatch) (int64, error) {
+	if len(matches) == 0 {
+		return 0, nil
+	}
+	now := time.Now().UTC()
+	models := make([]driver.WriteModel, 0, len(matches))
+	for _, m := range matches {
+		models = append(models, driver.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": m.ID}).
+			SetUpdate(bson.M{"$set": bson.M{
+				"mbz_track_id":                        m.RecordingID,
+				"mbz_release_track_id":                m.ReleaseTrackID,
+				"mbz_album_id":                        releaseID,
+				scene_audio_db_models.MBZCheckedField: now,
+			}}))
+	}
+	res, err := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).
+		BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return 0, fmt.Errorf("link tracks failed: %w", err)
+	}
+	return res.ModifiedCount, nil
+}
+
+func (r *enrichmentRepository) MarkChecked(ctx context.Context, itemType string, id primitive.ObjectID) error {
+	collection, ok := enrichmentCollections[itemType]
+	if !ok {
+		return fmt.Errorf("unsupported item type: %s", itemType)
+	}
+	_, err := r.db.Collection(collection).UpdateOne(ctx, bson.M{"_id": id},
+		bson.M{"$set": bson.M{scene_audio_db_models.MBZCheckedField: time.Now().UTC()}})
+	if err != nil {
+		return fmt.Errorf("mark %s checked failed: %w", itemType, err)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package scene_audio_db_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type fingerprintRepository struct {
+	db mongo.Database
+}
+
+func NewFingerprintRepository(db mongo.Database) scene_audio_db_interface.FingerprintRepository {
+	return &fingerprintRepository{db: db}
+}
+
+func (r *fingerprintRepository) IsFingerprinted(ctx context.Context, mediaID primitive.ObjectID) (bool, error) {
+	count, err := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).CountDocuments(ctx, bson.M{
+		"_id":             mediaID,
+		"acoustid_status": bson.M{"$exists": true},
+	})
+	if err != nil {
+		return false, fmt.Errorf("fingerprint query failed: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (r *fingerprintRepository) SaveResult(ctx context.Context, mediaID primitive.ObjectID, result *scene_audio_db_models.FingerprintResult) error {
+	coll := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile)
+	if _, err := coll.UpdateOne(ctx, bson.M{"_id": mediaID}, bson.M{"$set": result}); err != nil {
+		return fmt.Errorf("save fingerprint failed: %w", err)
+	}
+	if result.Status != scene_audio_db_models.FingerprintStatusMatched || result.RecordingID == "" {
+		return nil
+	}
+	// 标签中已有的录音ID优先
+	if _, err := coll.UpdateOne(ctx,
+		bson.M{"_id": mediaID, "mbz_track_id": bson.M{"$in": bson.A{"", nil}}},
+		bson.M{"$set": bson.M{"mbz_track_id": result.RecordingID}},
+	); err != nil {
+		return fmt.Errorf("link recording failed: %w", err)
+	}
+	return nil
+}
+
+func (r *fingerprintRepository) GetReviewItems(ctx context.Context, skip, limit int64) ([]scene_audio_db_models.FingerprintReviewItem, int64, error) {
+	coll := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile)
+	filter := bson.M{"acoustid_status": scene_audio_db_models.FingerprintStatusReview}
+
+	total, err := coll.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("count fingerprint reviews failed: %w", err)
+	}
+	cursor, err := coll.Find(ctx, filter, options.Find().
+		SetSort(bson.D{{Key: "acoustid_score", Value: -1}, {Key: "_id", Value: 1}}).
+		SetSkip(skip).
+		SetLimit(limit).
+		SetProjection(bson.M{"acoustid_fingerprint": 0}))
+	if err != nil {
+		return nil, 0, fmt.Errorf("query fingerprint reviews failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	items := make([]scene_audio_db_models.FingerprintReviewItem, 0)
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, 0, fmt.Errorf("decode fingerprint reviews failed: %w", err)
+	}
+	return items, total, nil
+}
+
+func (r *fingerprintRepository) ResolveReview(ctx context.Context, mediaID primitive.ObjectID, accept bool) error {
+	coll := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile)
+	filter := bson.M{"_id": mediaID, "acoustid_status": scene_audio_db_models.FingerprintStatusReview}
+
+	var doc struct {
+		RecordingID string `bson:"acoustid_recording_id"`
+	}
+	if err := coll.FindOne(ctx, filter).Decode(&doc); err != nil {
+		if errors.Is(err, driver.ErrNoDocuments) {
+			return scene_audio_db_models.ErrFingerprintReviewNotFound
+		}
+		return fmt.Errorf("fingerprint review query failed: %w", err)
+	}
+
+	set := bson.M{
+		"acoustid_status":     scene_audio_db_models.FingerprintStatusRejected,
+		"acoustid_checked_at": time.Now().UTC(),
+	}
+	if accept {
+		set["acoustid_status"] = scene_audio_db_models.FingerprintStatusMatched
+		set["mbz_track_id"] = doc.RecordingID
+	}
+	result, err := coll.UpdateOne(ctx, filter, bson.M{"$set": set})
+	if err != nil {
+		return fmt.Errorf("resolve fingerprint review failed: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return scene_audio_db_models.ErrFingerprintReviewNotFound
+	}
+	return nil
+}
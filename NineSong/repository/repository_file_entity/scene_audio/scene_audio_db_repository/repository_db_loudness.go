@@ -0,0 +1,137 @@
+package scene_audio_db_repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type loudnessRepository struct {
+	db mongo.Database
+}
+
+func NewLoudnessRepository(db mongo.Database) scene_audio_db_interface.LoudnessRepository {
+	return &loudnessRepository{db: db}
+}
+
+// loudnessPendingFilter 未分析过且缺少曲目或专辑增益标签的曲目（字段不存在时与 nil 匹配，可使用索引）
+func loudnessPendingFilter() bson.M {
+	return bson.M{
+		scene_audio_db_models.LoudnessAnalyzedField: nil,
+		"$or": bson.A{
+			bson.M{"rg_track_gain": bson.M{"$in": bson.A{0, nil}}},
+			bson.M{"rg_album_gain": bson.M{"$in": bson.A{0, nil}}},
+		},
+	}
+}
+
+func (r *loudnessRepository) GetPendingAlbumIDs(ctx context.Context, limit int) ([]string, error) {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).Aggregate(ctx, []bson.D{
+		{{Key: "$match", Value: loudnessPendingFilter()}},
+		{{Key: "$group", Value: bson.M{"_id": bson.M{"$ifNull": bson.A{"$album_id", ""}}}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+		{{Key: "$limit", Value: limit}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query pending loudness albums failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		ID string `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("decode pending loudness albums failed: %w", err)
+	}
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	return ids, nil
+}
+
+func (r *loudnessRepository) GetAlbumTracks(ctx context.Context, albumID string, limit int) ([]scene_audio_db_models.LoudnessTrack, error) {
+	filter := bson.M{"album_id": albumID}
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	if albumID == "" {
+		filter = loudnessPendingFilter()
+		filter["album_id"] = bson.M{"$in": bson.A{"", nil}}
+		opts.SetLimit(int64(limit))
+	}
+
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("query album tracks failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var tracks []scene_audio_db_models.LoudnessTrack
+	if err := cursor.All(ctx, &tracks); err != nil {
+		return nil, fmt.Errorf("decode album tracks failed: %w", err)
+	}
+	return tracks, nil
+}
+
+func (r *loudnessRepository) SaveTrackLoudness(ctx context.Context, id primitive.ObjectID, loudness, truePeak float64, writeGain bool) error {
+	set := bson.M{
+		scene_audio_db_models.LoudnessIntegratedField: loudness,
+		scene_audio_db_models.LoudnessTruePeakField:   truePeak,
+		scene_audio_db_models.LoudnessAnalyzedField:   time.Now().UTC(),
+	}
+	if writeGain {
+		set["rg_track_gain"] = scene_audio_db_models.ReplayGainFromLoudness(loudness)
+		set["rg_track_peak"] = truePeak
+	}
+	_, err := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": set, "$unset": bson.M{scene_audio_db_models.LoudnessErrorField: ""}},
+	)
+	if err != nil {
+		return fmt.Errorf("save track loudness failed: %w", err)
+	}
+	return nil
+}
+
+func (r *loudnessRepository) MarkFailed(ctx context.Context, id primitive.ObjectID, reason string) error {
+	_, err := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{
+			scene_audio_db_models.LoudnessAnalyzedField: time.Now().UTC(),
+			scene_audio_db_models.LoudnessErrorField:    reason,
+		}},
+	)
+	if err != nil {
+		return fmt.Errorf("mark loudness failed: %w", err)
+	}
+	return nil
+}
+
+func (r *loudnessRepository) SaveAlbumGain(ctx context.Context, albumID string, gain, peak float64) (int64, error) {
+	// 由标签提供专辑增益的曲目不覆盖，之前计算过的随专辑曲目变化重新计算
+	result, err := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).UpdateMany(ctx,
+		bson.M{
+			"album_id": albumID,
+			"$or": bson.A{
+				bson.M{"rg_album_gain": bson.M{"$in": bson.A{0, nil}}},
+				bson.M{scene_audio_db_models.LoudnessAlbumComputedField: true},
+			},
+		},
+		bson.M{"$set": bson.M{
+			"rg_album_gain": gain,
+			"rg_album_peak": peak,
+			scene_audio_db_models.LoudnessAlbumComputedField: true,
+		}},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("save album gain failed: %w", err)
+	}
+	return result.ModifiedCount, nil
+}
@@ -0,0 +1,276 @@
+package scene_audio_db_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/deletion_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const maintenanceDeleteBatchSize = 1000
+
+// maintenanceAnnotationCollections 注解 item_type 对应的条目集合，其他类型的注解不检查
+var maintenanceAnnotationCollections = map[string]string{
+	"media":     domain.CollectionFileEntityAudioSceneMediaFile,
+	"media_cue": domain.CollectionFileEntityAudioSceneMediaFileCue,
+	"album":     domain.CollectionFileEntityAudioSceneAlbum,
+	"artist":    domain.CollectionFileEntityAudioSceneArtist,
+}
+
+// idReference 引用字段，unwind 非空时先展开数组字段
+type idReference struct {
+	collection string
+	unwind     string
+	field      string
+}
+
+type maintenanceRepository struct {
+	db mongo.Database
+}
+
+func NewMaintenanceRepository(db mongo.Database) scene_audio_db_interface.MaintenanceRepository {
+	return &maintenanceRepository{db: db}
+}
+
+func (r *maintenanceRepository) GetMediaSources(ctx context.Context, libraryPath string) ([]scene_audio_db_models.MediaSource, error) {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).Find(ctx,
+		bson.M{"library_path": libraryPath},
+		options.Find().SetProjection(bson.M{"_id": 1, "path": 1}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("media source query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var sources []scene_audio_db_models.MediaSource
+	if err := cursor.All(ctx, &sources); err != nil {
+		return nil, fmt.Errorf("decode media sources failed: %w", err)
+	}
+	return sources, nil
+}
+
+func (r *maintenanceRepository) FindEmptyAlbums(ctx context.Context) ([]primitive.ObjectID, error) {
+	return r.findUnreferenced(ctx, domain.CollectionFileEntityAudioSceneAlbum,
+		idReference{collection: domain.CollectionFileEntityAudioSceneMediaFile, field: "album_id"},
+	)
+}
+
+// FindEmptyArtists 曲目、专辑艺术家与 CUE 演出者均未引用的艺术家
+func (r *maintenanceRepository) FindEmptyArtists(ctx context.Context) ([]primitive.ObjectID, error) {
+	return r.findUnreferenced(ctx, domain.CollectionFileEntityAudioSceneArtist,
+		idReference{collection: domain.CollectionFileEntityAudioSceneMediaFile, field: "artist_id"},
+		idReference{collection: domain.CollectionFileEntityAudioSceneMediaFile, field: "album_artist_id"},
+		idReference{collection: domain.CollectionFileEntityAudioSceneMediaFile, unwind: "all_artist_ids", field: "all_artist_ids.artist_id"},
+		idReference{collection: domain.CollectionFileEntityAudioSceneMediaFile, unwind: "all_album_artist_ids", field: "all_album_artist_ids.artist_id"},
+		idReference{collection: domain.CollectionFileEntityAudioSceneMediaFileCue, field: "performer_id"},
+		idReference{collection: domain.CollectionFileEntityAudioSceneMediaFileCue, unwind: "all_artist_ids", field: "all_artist_ids.artist_id"},
+		idReference{collection: domain.CollectionFileEntityAudioSceneMediaFileCue, unwind: "cue_tracks", field: "cue_tracks.track_performer_id"},
+	)
+}
+
+func (r *maintenanceRepository) FindDanglingAnnotations(ctx context.Context) ([]primitive.ObjectID, error) {
+	var dangling []primitive.ObjectID
+	for itemType, collection := range maintenanceAnnotationCollections {
+		existing, err := r.existingIDs(ctx, collection)
+		if err != nil {
+			return nil, err
+		}
+
+		cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneAnnotation).Find(ctx,
+			bson.M{"item_type": itemType},
+			options.Find().SetProjection(bson.M{"_id": 1, "item_id": 1}),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("annotation query failed: %w", err)
+		}
+		for cursor.Next(ctx) {
+			var doc struct {
+				ID     primitive.ObjectID `bson:"_id"`
+				ItemID string             `bson:"item_id"`
+			}
+			if err := cursor.Decode(&doc); err != nil {
+				_ = cursor.Close(ctx)
+				return nil, fmt.Errorf("decode annotation failed: %w", err)
+			}
+			if !existing[doc.ItemID] {
+				dangling = append(dangling, doc.ID)
+			}
+		}
+		_ = cursor.Close(ctx)
+	}
+	return dangling, nil
+}
+
+func (r *maintenanceRepository) DeleteMediaFiles(ctx context.Context, ids []primitive.ObjectID) (int64, error) {
+	return deletion_util.DeleteByIDs(ctx, r.db, domain.CollectionFileEntityAudioSceneMediaFile, "media", ids)
+}
+
+func (r *maintenanceRepository) DeleteAlbums(ctx context.Context, ids []primitive.ObjectID) (int64, error) {
+	return deletion_util.DeleteByIDs(ctx, r.db, domain.CollectionFileEntityAudioSceneAlbum, "album", ids)
+}
+
+func (r *maintenanceRepository) DeleteArtists(ctx context.Context, ids []primitive.ObjectID) (int64, error) {
+	return deletion_util.DeleteByIDs(ctx, r.db, domain.CollectionFileEntityAudioSceneArtist, "artist", ids)
+}
+
+// DeleteAnnotations 注解不属于变更流的条目类型，直接删除
+func (r *maintenanceRepository) DeleteAnnotations(ctx context.Context, ids []primitive.ObjectID) (int64, error) {
+	coll := r.db.Collection(domain.CollectionFileEntityAudioSceneAnnotation)
+	var total int64
+	for i := 0; i < len(ids); i += maintenanceDeleteBatchSize {
+		end := i + maintenanceDeleteBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		deleted, err := coll.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids[i:end]}})
+		if err != nil {
+			return total, fmt.Errorf("delete annotations failed: %w", err)
+		}
+		total += deleted
+	}
+	return total, nil
+}
+
+func (r *maintenanceRepository) SaveReport(ctx context.Context, report *scene_audio_db_models.MaintenanceReport) error {
+	if _, err := r.db.Collection(domain.CollectionFileEntityAudioSceneMaintenanceReport).InsertOne(ctx, report); err != nil {
+		return fmt.Errorf("save maintenance report failed: %w", err)
+	}
+	return nil
+}
+
+// GetReport TTL 索引清理存在延迟，已过期但尚未清理的报告同样视为不存在
+func (r *maintenanceRepository) GetReport(ctx context.Context, id primitive.ObjectID) (*scene_audio_db_models.MaintenanceReport, error) {
+	var report scene_audio_db_models.MaintenanceReport
+	err := r.db.Collection(domain.CollectionFileEntityAudioSceneMaintenanceReport).FindOne(ctx, bson.M{
+		"_id":        id,
+		"expires_at": bson.M{"$gt": time.Now()},
+	}).Decode(&report)
+	if err != nil {
+		if errors.Is(err, driver.ErrNoDocuments) {
+			return nil, fmt.Errorf("maintenance report %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("maintenance report query failed: %w", err)
+	}
+	return &report, nil
+}
+
+func (r *maintenanceRepository) ListReports(ctx context.Context, limit int) ([]scene_audio_db_models.MaintenanceReport, error) {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneMaintenanceReport).Find(ctx,
+		bson.M{"expires_at": bson.M{"$gt": time.Now()}},
+		options.Find().
+			SetProjection(bson.M{"changes": 0}).
+			SetSort(bson.D{{Key: "started_at", Value: -1}}).
+			SetLimit(int64(limit)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("maintenance report query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	reports := make([]scene_audio_db_models.MaintenanceReport, 0)
+	if err := cursor.All(ctx, &reports); err != nil {
+		return nil, fmt.Errorf("decode maintenance reports failed: %w", err)
+	}
+	return reports, nil
+}
+
+func (r *maintenanceRepository) MarkApplied(ctx context.Context, id primitive.ObjectID, appliedAt time.Time) (bool, error) {
+	result, err := r.db.Collection(domain.CollectionFileEntityAudioSceneMaintenanceReport).UpdateOne(ctx,
+		bson.M{"_id": id, "applied_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"applied_at": appliedAt}},
+	)
+	if err != nil {
+		return false, fmt.Errorf("update maintenance report failed: %w", err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// findUnreferenced 返回 collection 中未被任何引用字段指向的条目ID
+func (r *maintenanceRepository) findUnreferenced(ctx context.Context, collection string, refs ...idReference) ([]primitive.ObjectID, error) {
+	referenced := make(map[string]bool)
+	for _, ref := range refs {
+		if err := r.collectReferences(ctx, ref, referenced); err != nil {
+			return nil, err
+		}
+	}
+
+	cursor, err := r.db.Collection(collection).Find(ctx, bson.M{},
+		options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("query %s failed: %w", collection, err)
+	}
+	defer cursor.Close(ctx)
+
+	var ids []primitive.ObjectID
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("decode %s failed: %w", collection, err)
+		}
+		if !referenced[doc.ID.Hex()] {
+			ids = append(ids, doc.ID)
+		}
+	}
+	return ids, nil
+}
+
+func (r *maintenanceRepository) collectReferences(ctx context.Context, ref idReference, referenced map[string]bool) error {
+	var pipeline []bson.D
+	if ref.unwind != "" {
+		pipeline = append(pipeline, bson.D{{Key: "$unwind", Value: "$" + ref.unwind}})
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$match", Value: bson.D{{Key: ref.field, Value: bson.D{{Key: "$nin", Value: bson.A{"", nil}}}}}}},
+		bson.D{{Key: "$group", Value: bson.D{{Key: "_id", Value: "$" + ref.field}}}},
+	)
+
+	cursor, err := r.db.Collection(ref.collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return fmt.Errorf("collect %s.%s failed: %w", ref.collection, ref.field, err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		ID string `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return fmt.Errorf("decode %s.%s failed: %w", ref.collection, ref.field, err)
+	}
+	for _, row := range rows {
+		referenced[row.ID] = true
+	}
+	return nil
+}
+
+func (r *maintenanceRepository) existingIDs(ctx context.Context, collection string) (map[string]bool, error) {
+	cursor, err := r.db.Collection(collection).Find(ctx, bson.M{},
+		options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("query %s failed: %w", collection, err)
+	}
+	defer cursor.Close(ctx)
+
+	existing := make(map[string]bool)
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("decode %s failed: %w", collection, err)
+		}
+		existing[doc.ID.Hex()] = true
+	}
+	return existing, nil
+}
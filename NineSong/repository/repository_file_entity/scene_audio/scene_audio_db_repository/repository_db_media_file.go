@@ -7,6 +7,7 @@ import (
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_interface"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/deletion_util"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -40,9 +41,13 @@ func (r *mediaFileRepository) Upsert(ctx context.Context, file *scene_audio_db_m
 	}
 
 	update := file.ToUpdateDoc()
-	update["$setOnInsert"] = bson.M{
+	setOnInsert := bson.M{
 		"created_at": now,
 	}
+	if file.ReviewStatus != "" {
+		setOnInsert["review_status"] = file.ReviewStatus
+	}
+	update["$setOnInsert"] = setOnInsert
 
 	opts := options.Update().SetUpsert(true)
 	result, err := coll.UpdateOne(ctx, filter, update, opts)
@@ -99,8 +104,7 @@ func (r *mediaFileRepository) BulkUpsert(ctx context.Context, files []*scene_aud
 }
 
 func (r *mediaFileRepository) DeleteByID(ctx context.Context, id primitive.ObjectID) error {
-	coll := r.db.Collection(r.collection)
-	_, err := coll.DeleteOne(ctx, bson.M{"_id": id})
+	_, err := deletion_util.DeleteOne(ctx, r.db, r.collection, "media", bson.M{"_id": id})
 	if err != nil {
 		return fmt.Errorf("delete media file failed: %w", err)
 	}
@@ -108,8 +112,7 @@ func (r *mediaFileRepository) DeleteByID(ctx context.Context, id primitive.Objec
 }
 
 func (r *mediaFileRepository) DeleteByPath(ctx context.Context, path string) error {
-	coll := r.db.Collection(r.collection)
-	_, err := coll.DeleteOne(ctx, bson.M{"path": path})
+	_, err := deletion_util.DeleteOne(ctx, r.db, r.collection, "media", bson.M{"path": path})
 	if err != nil {
 		return fmt.Errorf("delete by path failed: %w", err)
 	}
@@ -132,7 +135,7 @@ func (r *mediaFileRepository) DeleteAllInvalid(
 	// 场景1：全量删除（无folderPath过滤）
 	if len(filePaths) == 0 {
 		// 直接删除所有文档
-		delResult, err := coll.DeleteMany(ctx, bson.M{})
+		delResult, err := deletion_util.DeleteMany(ctx, r.db, r.collection, "media", bson.M{})
 		if err != nil {
 			return 0, deletedArtists, fmt.Errorf("全量删除失败: %w", err)
 		}
@@ -216,7 +219,7 @@ func (r *mediaFileRepository) DeleteAllInvalid(
 		}
 		batch := toDelete[i:end]
 
-		delResult, err := coll.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": batch}})
+		delResult, err := deletion_util.DeleteByIDs(ctx, r.db, r.collection, "media", batch)
 		if err != nil {
 			return totalDeleted, deletedArtists, fmt.Errorf("批量删除失败: %w", err)
 		}
@@ -235,8 +238,6 @@ func (r *mediaFileRepository) DeleteAllInvalid(
 }
 
 func (r *mediaFileRepository) DeleteByFolder(ctx context.Context, folderPath string) (int64, error) {
-	coll := r.db.Collection(r.collection)
-
 	// 标准化路径格式（确保以反斜杠结尾）
 	normalizedFolderPath := strings.Replace(folderPath, "/", "\\", -1)
 	if !strings.HasSuffix(normalizedFolderPath, "\\") {
@@ -253,7 +254,7 @@ func (r *mediaFileRepository) DeleteByFolder(ctx context.Context, folderPath str
 	}
 
 	// 执行删除操作
-	result, err := coll.DeleteMany(ctx, filter)
+	result, err := deletion_util.DeleteMany(ctx, r.db, r.collection, "media", filter)
 	if err != nil {
 		return 0, fmt.Errorf("删除文件夹内容失败: %w", err)
 	}
@@ -289,6 +290,50 @@ func (r *mediaFileRepository) GetByPath(ctx context.Context, path string) (*scen
 	return &file, nil
 }
 
+func (r *mediaFileRepository) GetByReviewStatus(
+	ctx context.Context,
+	status string,
+	skip, limit int64,
+) ([]*scene_audio_db_models.MediaFileMetadata, int64, error) {
+	coll := r.db.Collection(r.collection)
+	filter := bson.M{"review_status": status}
+
+	total, err := coll.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("count by review status failed: %w", err)
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetSkip(skip).
+		SetLimit(limit)
+	cursor, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("find by review status failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var files []*scene_audio_db_models.MediaFileMetadata
+	if err := cursor.All(ctx, &files); err != nil {
+		return nil, 0, fmt.Errorf("decode review files failed: %w", err)
+	}
+	return files, total, nil
+}
+
+func (r *mediaFileRepository) SetReviewStatus(ctx context.Context, ids []primitive.ObjectID, status string) (int64, error) {
+	coll := r.db.Collection(r.collection)
+
+	update := bson.M{"$unset": bson.M{"review_status": ""}}
+	if status != "" {
+		update = bson.M{"$set": bson.M{"review_status": status}}
+	}
+	result, err := coll.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": ids}}, update)
+	if err != nil {
+		return 0, fmt.Errorf("set review status failed: %w", err)
+	}
+	return result.MatchedCount, nil
+}
+
 func (r *mediaFileRepository) GetByFolder(ctx context.Context, folderPath string) ([]string, error) {
 	coll := r.db.Collection(r.collection)
 
@@ -506,10 +551,7 @@ func (r *mediaFileRepository) inspectMedia(
 				}
 
 				batch := toDelete[i:end]
-				delResult, err := coll.DeleteMany(
-					ctx,
-					bson.M{"_id": bson.M{"$in": batch}},
-				)
+				delResult, err := deletion_util.DeleteByIDs(ctx, r.db, r.collection, "media", batch)
 				if err != nil {
 					log.Printf("部分删除失败: %v", err)
 				} else {
@@ -529,3 +529,27 @@ func (r *mediaFileCueRepository) InspectGuestMediaCueCountByArtist(
 	}
 	return r.inspectMediaCue(ctx, filter, filePaths, false)
 }
+
+// NormalizeCueTrackDurations 升级前入库的 CUE 音轨按秒写入虚拟曲目时长，按音轨时长重新换算为纳秒，
+// 与 taglib 曲目的存储单位一致；结果只取决于音轨时长，可重复执行
+func NormalizeCueTrackDurations(ctx context.Context, db mongo.Database) error {
+	result, err := db.Collection(domain.CollectionFileEntityAudioSceneMediaFileCue).UpdateMany(ctx,
+		bson.M{"cue_tracks.0": bson.M{"$exists": true}},
+		bson.A{bson.M{"$set": bson.M{"cue_tracks": bson.M{"$map": bson.M{
+			"input": "$cue_tracks",
+			"as":    "t",
+			"in": bson.M{"$mergeObjects": bson.A{"$$t", bson.M{
+				"cue_track_extended": bson.M{"$mergeObjects": bson.A{"$$t.cue_track_extended", bson.M{
+					"duration": bson.M{"$multiply": bson.A{bson.M{"$ifNull": bson.A{"$$t.track_duration_seconds", 0}}, float64(time.Second)}},
+				}}},
+			}}},
+		}}}}},
+	)
+	if err != nil {
+		return fmt.Errorf("换算 CUE 音轨时长失败: %w", err)
+	}
+	if result.ModifiedCount > 0 {
+		log.Printf("已换算 CUE 音轨时长 %d 条", result.ModifiedCount)
+	}
+	return nil
+}
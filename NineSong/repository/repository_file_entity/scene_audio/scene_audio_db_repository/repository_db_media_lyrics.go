@@ -0,0 +1,72 @@
+package scene_audio_db_repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mediaLyricsRepository struct {
+	db         mongo.Database
+	collection string
+}
+
+func NewMediaLyricsRepository(db mongo.Database, collection string) scene_audio_db_interface.MediaLyricsRepository {
+	return &mediaLyricsRepository{
+		db:         db,
+		collection: collection,
+	}
+}
+
+// Upsert 按 media_id 覆盖歌词；手动固定的记录不匹配过滤条件，
+// 插入时与 media_id 唯一索引冲突，视为无需写入
+func (r *mediaLyricsRepository) Upsert(ctx context.Context, lyrics *scene_audio_db_models.MediaLyricsMetadata) error {
+	coll := r.db.Collection(r.collection)
+	filter := bson.M{
+		"media_id":    lyrics.MediaID,
+		"lyrics_type": bson.M{"$ne": scene_audio_db_models.LyricsTypeManual},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"lyrics_hash": lyrics.Hash,
+			"lyrics_type": lyrics.Type,
+			"lyrics_path": lyrics.Path,
+			"lyrics":      lyrics.Lyrics,
+			"synced":      lyrics.Synced,
+			"updated_at":  time.Now().UTC(),
+		},
+		"$unset":       bson.M{"lyrics_provider": ""},
+		"$setOnInsert": bson.M{"_id": primitive.NewObjectID()},
+	}
+
+	_, err := coll.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		if driver.IsDuplicateKeyError(err) {
+			return nil
+		}
+		return fmt.Errorf("lyrics upsert failed: %w", err)
+	}
+	return nil
+}
+
+func (r *mediaLyricsRepository) DeleteLocalByMediaID(ctx context.Context, mediaID string) error {
+	filter := bson.M{
+		"media_id": mediaID,
+		"lyrics_type": bson.M{"$in": bson.A{
+			scene_audio_db_models.LyricsTypeEmbedded,
+			scene_audio_db_models.LyricsTypeSidecar,
+		}},
+	}
+	if _, err := r.db.Collection(r.collection).DeleteMany(ctx, filter); err != nil {
+		return fmt.Errorf("delete lyrics failed: %w", err)
+	}
+	return nil
+}
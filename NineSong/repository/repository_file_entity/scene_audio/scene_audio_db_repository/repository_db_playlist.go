@@ -6,6 +6,7 @@ import (
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_interface"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/deletion_util"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -102,8 +103,7 @@ func (r *playlistRepository) BulkUpsert(ctx context.Context, files []*scene_audi
 }
 
 func (r *playlistRepository) DeleteByID(ctx context.Context, id primitive.ObjectID) error {
-	coll := r.db.Collection(r.collection)
-	_, err := coll.DeleteOne(ctx, bson.M{"_id": id})
+	_, err := deletion_util.DeleteOne(ctx, r.db, r.collection, "playlist", bson.M{"_id": id})
 	if err != nil {
 		return fmt.Errorf("delete media file failed: %w", err)
 	}
@@ -111,8 +111,7 @@ func (r *playlistRepository) DeleteByID(ctx context.Context, id primitive.Object
 }
 
 func (r *playlistRepository) DeleteByPath(ctx context.Context, path string) error {
-	coll := r.db.Collection(r.collection)
-	_, err := coll.DeleteOne(ctx, bson.M{"path": path})
+	_, err := deletion_util.DeleteOne(ctx, r.db, r.collection, "playlist", bson.M{"path": path})
 	if err != nil {
 		return fmt.Errorf("delete by path failed: %w", err)
 	}
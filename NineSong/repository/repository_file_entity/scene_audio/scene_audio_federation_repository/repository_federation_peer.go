@@ -0,0 +1,113 @@
+package scene_audio_federation_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_federation/scene_audio_federation_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_federation/scene_audio_federation_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type federationPeerRepository struct {
+	db mongo.Database
+}
+
+func NewFederationPeerRepository(db mongo.Database) scene_audio_federation_interface.FederationPeerRepository {
+	return &federationPeerRepository{db: db}
+}
+
+func (r *federationPeerRepository) Insert(ctx context.Context, peer *scene_audio_federation_models.FederationPeer) error {
+	if peer.ID.IsZero() {
+		peer.ID = primitive.NewObjectID()
+	}
+	now := time.Now().UTC()
+	peer.CreatedAt = now
+	peer.UpdatedAt = now
+	if _, err := r.db.Collection(domain.CollectionFileEntityAudioSceneFederationPeer).InsertOne(ctx, peer); err != nil {
+		return fmt.Errorf("insert federation peer failed: %w", err)
+	}
+	return nil
+}
+
+func (r *federationPeerRepository) Update(ctx context.Context, peer *scene_audio_federation_models.FederationPeer) error {
+	peer.UpdatedAt = time.Now().UTC()
+	if _, err := r.db.Collection(domain.CollectionFileEntityAudioSceneFederationPeer).UpdateOne(ctx,
+		bson.M{"_id": peer.ID},
+		bson.M{"$set": bson.M{
+			"name":               peer.Name,
+			"base_url":           peer.BaseURL,
+			"status":             peer.Status,
+			"outbound_token":     peer.OutboundToken,
+			"inbound_token_hash": peer.InboundTokenHash,
+			"allow_stream":       peer.AllowStream,
+			"stream_kbps":        peer.StreamKbps,
+			"updated_at":         peer.UpdatedAt,
+		}},
+	); err != nil {
+		return fmt.Errorf("update federation peer failed: %w", err)
+	}
+	return nil
+}
+
+func (r *federationPeerRepository) Delete(ctx context.Context, id string) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, scene_audio_federation_models.ErrPeerNotFound
+	}
+	deleted, err := r.db.Collection(domain.CollectionFileEntityAudioSceneFederationPeer).DeleteOne(ctx, bson.M{"_id": objID})
+	if err != nil {
+		return false, fmt.Errorf("delete federation peer failed: %w", err)
+	}
+	return deleted > 0, nil
+}
+
+func (r *federationPeerRepository) GetByID(ctx context.Context, id string) (*scene_audio_federation_models.FederationPeer, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, scene_audio_federation_models.ErrPeerNotFound
+	}
+	return r.findOne(ctx, bson.M{"_id": objID})
+}
+
+func (r *federationPeerRepository) GetByInboundTokenHash(ctx context.Context, hash string) (*scene_audio_federation_models.FederationPeer, error) {
+	if hash == "" {
+		return nil, scene_audio_federation_models.ErrPeerNotFound
+	}
+	return r.findOne(ctx, bson.M{"inbound_token_hash": hash})
+}
+
+func (r *federationPeerRepository) GetAll(ctx context.Context) ([]scene_audio_federation_models.FederationPeer, error) {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneFederationPeer).Find(ctx,
+		bson.M{},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("federation peer query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	peers := []scene_audio_federation_models.FederationPeer{}
+	if err := cursor.All(ctx, &peers); err != nil {
+		return nil, fmt.Errorf("decode federation peers failed: %w", err)
+	}
+	return peers, nil
+}
+
+func (r *federationPeerRepository) findOne(ctx context.Context, filter bson.M) (*scene_audio_federation_models.FederationPeer, error) {
+	var peer scene_audio_federation_models.FederationPeer
+	if err := r.db.Collection(domain.CollectionFileEntityAudioSceneFederationPeer).FindOne(ctx, filter).Decode(&peer); err != nil {
+		if errors.Is(err, driver.ErrNoDocuments) {
+			return nil, scene_audio_federation_models.ErrPeerNotFound
+		}
+		return nil, fmt.Errorf("federation peer query failed: %w", err)
+	}
+	return &peer, nil
+}
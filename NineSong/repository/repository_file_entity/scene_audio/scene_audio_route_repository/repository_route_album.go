@@ -2,14 +2,16 @@ package scene_audio_route_repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cache_util"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"strconv"
-	"strings"
 	"time"
 )
 
@@ -28,53 +30,55 @@ func NewAlbumRepository(db mongo.Database, collection string) scene_audio_route_
 func (r *albumRepository) GetAlbumItems(
 	ctx context.Context,
 	start, end, sort, order, search, starred, artistId string,
-	minYear, maxYear string,
+	minYear, maxYear, decade, folderId, available, custom string,
+) ([]scene_audio_route_models.AlbumMetadata, error) {
+	if starred != "" || isAnnotationField(sortFieldFor(scene_audio_route_models.SortEntityAlbums, sort)) {
+		return r.queryAlbumItems(ctx, start, end, sort, order, search, starred, artistId, minYear, maxYear, decade, folderId, available, custom)
+	}
+
+	key := cache_util.Key("album", start, end, sort, order, search, artistId, minYear, maxYear, decade, folderId, available, custom)
+	albums, err := sharedCatalogPage(ctx, key, func(ctx context.Context) ([]scene_audio_route_models.AlbumMetadata, error) {
+		return r.queryAlbumItems(ctx, start, end, sort, order, search, starred, artistId, minYear, maxYear, decade, folderId, available, custom)
+	})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]primitive.ObjectID, 0, len(albums))
+	for _, album := range albums {
+		ids = append(ids, album.ID)
+	}
+	annotations, err := userAnnotationsFor(ctx, r.db, scene_audio_route_models.AnnotationItemAlbum, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range albums {
+		if a, ok := annotations[albums[i].ID]; ok {
+			albums[i].PlayCount, albums[i].PlayCompleteCount = a.PlayCount, a.PlayCompleteCount
+			albums[i].PlayDate, albums[i].FirstPlayDate = a.PlayDate, a.FirstPlayDate
+			albums[i].Rating, albums[i].RatedAt = a.Rating, a.RatedAt
+			albums[i].Starred, albums[i].StarredAt = a.Starred, a.StarredAt
+		}
+	}
+	return albums, nil
+}
+
+func (r *albumRepository) queryAlbumItems(
+	ctx context.Context,
+	start, end, sort, order, search, starred, artistId string,
+	minYear, maxYear, decade, folderId, available, custom string,
 ) ([]scene_audio_route_models.AlbumMetadata, error) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 	coll := r.db.Collection(r.collection)
 
-	// 构建完整聚合管道
-	pipeline := []bson.D{
-		{
-			{Key: "$lookup", Value: bson.D{
-				{Key: "from", Value: domain.CollectionFileEntityAudioSceneAnnotation},
-				{Key: "let", Value: bson.D{{Key: "albumId", Value: "$_id"}}},
-				{Key: "pipeline", Value: []bson.D{
-					{
-						{Key: "$match", Value: bson.D{
-							{Key: "$expr", Value: bson.D{
-								{Key: "$and", Value: bson.A{
-									bson.D{{Key: "$eq", Value: bson.A{"$item_id", "$$albumId"}}},
-									bson.D{{Key: "$eq", Value: bson.A{"$item_type", "album"}}},
-								}},
-							}},
-						}},
-					},
-				}},
-				{Key: "as", Value: "annotations"},
-			}},
-		},
-		{
-			{Key: "$unwind", Value: bson.D{
-				{Key: "path", Value: "$annotations"},
-				{Key: "preserveNullAndEmptyArrays", Value: true},
-			}},
-		},
-		{
-			{Key: "$addFields", Value: bson.D{
-				{Key: "play_count", Value: "$annotations.play_count"},
-				{Key: "play_date", Value: "$annotations.play_date"},
-				{Key: "rating", Value: "$annotations.rating"},
-				{Key: "starred", Value: "$annotations.starred"},
-				{Key: "starred_at", Value: "$annotations.starred_at"},
-			}},
-		},
-	}
+	// 构建完整聚合管道，全文检索条件必须位于首位
+	leadStages, searchCond := splitSearchStage(albumSearch(ctx, r.db, search))
+	pipeline := append(leadStages, availabilityStages(available)...)
+	pipeline = append(pipeline, annotationFallbackStages(ctx, scene_audio_route_models.AnnotationItemAlbum, "")...)
 
 	// 核心逻辑：播放相关排序时过滤无效数据
-	validatedSort := validateAlbumSortField(sort)
-	if validatedSort == "play_count" || validatedSort == "play_date" {
+	validatedSort := sortFieldFor(scene_audio_route_models.SortEntityAlbums, sort)
+	if validatedSort == "play_count" || validatedSort == "play_date" || validatedSort == "first_play_date" {
 		pipeline = append(pipeline, bson.D{
 			{Key: "$match", Value: bson.D{
 				{Key: "$and", Value: bson.A{
@@ -86,7 +90,15 @@ func (r *albumRepository) GetAlbumItems(
 	}
 
 	// 其他过滤条件
-	if match := buildAlbumMatch(search, starred, artistId, minYear, maxYear); len(match) > 0 {
+	folderCond, err := folderAlbumCondition(ctx, r.db, folderId)
+	if err != nil {
+		return nil, err
+	}
+	customCond, err := customTagCondition(scene_audio_route_models.SortEntityAlbums, custom)
+	if err != nil {
+		return nil, err
+	}
+	if match := append(append(buildAlbumMatch(searchCond, starred, artistId, minYear, maxYear, decade), folderCond...), customCond...); len(match) > 0 {
 		pipeline = append(pipeline, bson.D{{Key: "$match", Value: match}})
 	}
 
@@ -116,55 +128,60 @@ func (r *albumRepository) GetAlbumItems(
 
 func (r *albumRepository) GetAlbumFilterItemsCount(
 	ctx context.Context,
-	search, starred, artistId, minYear, maxYear string,
+	search, starred, artistId, minYear, maxYear, decade, folderId, available, custom string,
+	genres string,
 ) (*scene_audio_route_models.AlbumFilterCounts, error) {
 	coll := r.db.Collection(r.collection)
+	leadStages, searchCond := splitSearchStage(albumSearch(ctx, r.db, search))
+	folderCond, err := folderAlbumCondition(ctx, r.db, folderId)
+	if err != nil {
+		return nil, err
+	}
+	customCond, err := customTagCondition(scene_audio_route_models.SortEntityAlbums, custom)
+	if err != nil {
+		return nil, err
+	}
 
-	pipeline := []bson.D{
-		{
-			{Key: "$lookup", Value: bson.D{
-				{Key: "from", Value: domain.CollectionFileEntityAudioSceneAnnotation},
-				{Key: "let", Value: bson.D{{Key: "albumId", Value: "$_id"}}},
-				{Key: "pipeline", Value: []bson.D{
-					{
-						{Key: "$match", Value: bson.D{
-							{Key: "$expr", Value: bson.D{
-								{Key: "$and", Value: bson.A{
-									bson.D{{Key: "$eq", Value: bson.A{"$item_id", "$$albumId"}}},
-									bson.D{{Key: "$eq", Value: bson.A{"$item_type", "album"}}},
-								}},
-							}},
-						}},
-					},
+	facets := bson.D{
+		{Key: "total", Value: []bson.D{
+			{{Key: "$count", Value: "count"}},
+		}},
+		{Key: "starred", Value: []bson.D{
+			{{Key: "$match", Value: bson.D{
+				{Key: "starred", Value: true},
+			}}},
+			{{Key: "$count", Value: "count"}},
+		}},
+		{Key: "recent_play", Value: []bson.D{
+			{{Key: "$match", Value: bson.D{
+				{Key: "play_count", Value: bson.D{
+					{Key: "$gt", Value: 0},
 				}},
-				{Key: "as", Value: "annotations"},
-			}},
-		},
+			}}},
+			{{Key: "$count", Value: "count"}},
+		}},
+	}
+	// 流派细分与总数在同一 $facet 中计算，按专辑数降序取前 N 个
+	genreLimit, _ := strconv.Atoi(genres)
+	if genreLimit > 0 {
+		facets = append(facets, bson.E{Key: "genres", Value: []bson.D{
+			{{Key: "$match", Value: bson.D{{Key: "genre", Value: bson.D{{Key: "$nin", Value: bson.A{"", nil}}}}}}},
+			{{Key: "$group", Value: bson.D{{Key: "_id", Value: "$genre"}, {Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}}}}},
+			{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+			{{Key: "$limit", Value: genreLimit}},
+		}})
+	}
+
+	pipeline := append(leadStages, availabilityStages(available)...)
+	pipeline = append(pipeline, annotationFallbackStages(ctx, scene_audio_route_models.AnnotationItemAlbum, "")...)
+	pipeline = append(pipeline, []bson.D{
 		{
-			{Key: "$match", Value: buildAlbumBaseMatch(search, starred, artistId, minYear, maxYear)},
+			{Key: "$match", Value: append(append(buildAlbumBaseMatch(searchCond, starred, artistId, minYear, maxYear, decade), folderCond...), customCond...)},
 		},
 		{
-			{Key: "$facet", Value: bson.D{
-				{Key: "total", Value: []bson.D{
-					{{Key: "$count", Value: "count"}},
-				}},
-				{Key: "starred", Value: []bson.D{
-					{{Key: "$match", Value: bson.D{
-						{Key: "annotations.starred", Value: true},
-					}}},
-					{{Key: "$count", Value: "count"}},
-				}},
-				{Key: "recent_play", Value: []bson.D{
-					{{Key: "$match", Value: bson.D{
-						{Key: "annotations.play_count", Value: bson.D{
-							{Key: "$gt", Value: 0},
-						}},
-					}}},
-					{{Key: "$count", Value: "count"}},
-				}},
-			}},
+			{Key: "$facet", Value: facets},
 		},
-	}
+	}...)
 
 	cursor, err := coll.Aggregate(ctx, pipeline)
 	if err != nil {
@@ -177,9 +194,10 @@ func (r *albumRepository) GetAlbumFilterItemsCount(
 	}()
 
 	var result []struct {
-		Total      []map[string]int `bson:"total"`
-		Starred    []map[string]int `bson:"starred"`
-		RecentPlay []map[string]int `bson:"recent_play"`
+		Total      []map[string]int                           `bson:"total"`
+		Starred    []map[string]int                           `bson:"starred"`
+		RecentPlay []map[string]int                           `bson:"recent_play"`
+		Genres     []scene_audio_route_models.AlbumGenreCount `bson:"genres"`
 	}
 
 	if err := cursor.All(ctx, &result); err != nil {
@@ -187,28 +205,123 @@ func (r *albumRepository) GetAlbumFilterItemsCount(
 	}
 
 	counts := &scene_audio_route_models.AlbumFilterCounts{}
+	if genreLimit > 0 {
+		counts.Genres = make([]scene_audio_route_models.AlbumGenreCount, 0)
+	}
 	if len(result) > 0 {
 		counts.Total = extractCount(result[0].Total)
 		counts.Starred = extractCount(result[0].Starred)
 		counts.RecentPlay = extractCount(result[0].RecentPlay)
+		if result[0].Genres != nil {
+			counts.Genres = result[0].Genres
+		}
+	}
+
+	return counts, nil
+}
+
+// GetAlbumYears 年份分组与年代分桶在同一 $facet 中计算；$bucket 只输出有专辑的年代
+func (r *albumRepository) GetAlbumYears(
+	ctx context.Context,
+	search, starred, artistId, folderId, available, custom string,
+) (*scene_audio_route_models.AlbumYearCounts, error) {
+	leadStages, searchCond := splitSearchStage(albumSearch(ctx, r.db, search))
+	folderCond, err := folderAlbumCondition(ctx, r.db, folderId)
+	if err != nil {
+		return nil, err
+	}
+	customCond, err := customTagCondition(scene_audio_route_models.SortEntityAlbums, custom)
+	if err != nil {
+		return nil, err
+	}
+
+	// 年代边界覆盖到当前年代之后一个年代，超出边界的年份归入 default 桶并在结果中忽略
+	lastDecade := time.Now().Year()/10*10 + 10
+	boundaries := bson.A{}
+	for decade := scene_audio_route_models.AlbumDecadeFirst; decade <= lastDecade+10; decade += 10 {
+		boundaries = append(boundaries, decade)
+	}
+	hasYear := bson.D{{Key: "original_year", Value: bson.D{{Key: "$gt", Value: 0}}}}
+
+	pipeline := append(leadStages, availabilityStages(available)...)
+	pipeline = append(pipeline, annotationFallbackStages(ctx, scene_audio_route_models.AnnotationItemAlbum, "")...)
+	pipeline = append(pipeline,
+		bson.D{{Key: "$match", Value: append(append(buildAlbumBaseMatch(searchCond, starred, artistId, "", "", ""), folderCond...), customCond...)}},
+		bson.D{{Key: "$facet", Value: bson.D{
+			{Key: "years", Value: []bson.D{
+				{{Key: "$match", Value: hasYear}},
+				{{Key: "$group", Value: bson.D{{Key: "_id", Value: "$original_year"}, {Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}}}}},
+				{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+			}},
+			{Key: "decades", Value: []bson.D{
+				{{Key: "$match", Value: hasYear}},
+				{{Key: "$bucket", Value: bson.D{
+					{Key: "groupBy", Value: "$original_year"},
+					{Key: "boundaries", Value: boundaries},
+					{Key: "default", Value: -1},
+					{Key: "output", Value: bson.D{{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}}}},
+				}}},
+				{{Key: "$match", Value: bson.D{{Key: "_id", Value: bson.D{{Key: "$gte", Value: 0}}}}}},
+			}},
+			{Key: "unknown", Value: []bson.D{
+				{{Key: "$match", Value: bson.D{{Key: "original_year", Value: bson.D{{Key: "$not", Value: bson.D{{Key: "$gt", Value: 0}}}}}}}},
+				{{Key: "$count", Value: "count"}},
+			}},
+		}}},
+	)
+
+	cursor, err := r.db.Collection(r.collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("album years query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var result []struct {
+		Years   []scene_audio_route_models.AlbumYearCount   `bson:"years"`
+		Decades []scene_audio_route_models.AlbumDecadeCount `bson:"decades"`
+		Unknown []map[string]int                            `bson:"unknown"`
+	}
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, fmt.Errorf("decode album years error: %w", err)
 	}
 
+	counts := &scene_audio_route_models.AlbumYearCounts{
+		Years:   make([]scene_audio_route_models.AlbumYearCount, 0),
+		Decades: make([]scene_audio_route_models.AlbumDecadeCount, 0),
+	}
+	if len(result) > 0 {
+		if result[0].Years != nil {
+			counts.Years = result[0].Years
+		}
+		if result[0].Decades != nil {
+			counts.Decades = result[0].Decades
+		}
+		counts.Unknown = extractCount(result[0].Unknown)
+	}
 	return counts, nil
 }
 
-// 优化过滤条件构建
-func buildAlbumMatch(search, starred, artistId, minYear, maxYear string) bson.D {
+// albumSearch 按专辑接口的搜索策略构建关键字条件
+func albumSearch(ctx context.Context, db mongo.Database, search string) (searchStrategy, bson.D) {
+	strategy := searchStrategyFor(scene_audio_route_models.SearchEndpointAlbums)
+	if search == "" {
+		return strategy, nil
+	}
+	fields := []string{"name", "artist", "album_artist"}
+	if fieldStrategy, cond, ok := fieldSearch(search, fields); ok {
+		return fieldStrategy, cond
+	}
+	aliasArtistIDs := findAliasArtistIDs(ctx, db, strategy, search)
+	return strategy, strategy.condition(search, fields, aliasArtistBranches(aliasArtistIDs)...)
+}
+
+// 优化过滤条件构建；searchCond 为 albumSearch 构建的关键字条件
+func buildAlbumMatch(searchCond bson.D, starred, artistId, minYear, maxYear, decade string) bson.D {
 	filter := bson.D{}
 
 	// 优化艺术家过滤条件
 	if artistId != "" {
-		filter = append(filter, bson.E{
-			Key: "$or",
-			Value: bson.A{
-				bson.D{{Key: "artist_id", Value: artistId}},
-				bson.D{{Key: "all_artist_ids.artist_id", Value: artistId}},
-			},
-		})
+		filter = append(filter, artistIDsFilter(artistId))
 	}
 
 	// 增强年份过滤逻辑
@@ -238,18 +351,18 @@ func buildAlbumMatch(search, starred, artistId, minYear, maxYear string) bson.D
 		}
 	}
 
-	// 搜索条件
-	if search != "" {
-		filter = append(filter, bson.E{
-			Key: "$or",
-			Value: []bson.D{
-				{{Key: "name", Value: bson.D{{Key: "$regex", Value: search}, {Key: "$options", Value: "i"}}}},
-				{{Key: "artist", Value: bson.D{{Key: "$regex", Value: search}, {Key: "$options", Value: "i"}}}},
-				{{Key: "album_artist", Value: bson.D{{Key: "$regex", Value: search}, {Key: "$options", Value: "i"}}}},
-			},
-		})
+	// 年代按原始发行年份过滤，再版专辑归入原版所在年代
+	if decade != "" {
+		if start, err := strconv.Atoi(decade); err == nil {
+			filter = append(filter, bson.E{
+				Key: "original_year", Value: bson.D{{Key: "$gte", Value: start}, {Key: "$lte", Value: start + 9}},
+			})
+		}
 	}
 
+	// 搜索条件
+	filter = append(filter, searchCond...)
+
 	// Starred过滤
 	if starred != "" {
 		if isStarred, err := strconv.ParseBool(starred); err == nil {
@@ -260,58 +373,8 @@ func buildAlbumMatch(search, starred, artistId, minYear, maxYear string) bson.D
 	return filter
 }
 
-func buildAlbumBaseMatch(search, starred, artistId, minYear, maxYear string) bson.D {
-	return buildAlbumMatch(search, starred, artistId, minYear, maxYear)
-}
-
-func validateAlbumSortField(sort string) string {
-	sortMappings := map[string]string{
-		"name":         "order_album_name",
-		"artist":       "artist",
-		"album_artist": "album_artist",
-		"min_year":     "min_year",
-		"max_year":     "max_year",
-		"rating":       "rating",
-		"starred_at":   "starred_at",
-		"genre":        "genre",
-		"song_count":   "song_count",
-		"duration":     "duration",
-		"size":         "size",
-		"play_count":   "play_count",
-		"play_date":    "play_date",
-		"created_at":   "created_at",
-		"updated_at":   "updated_at",
-	}
-
-	lowerSort := strings.ToLower(sort)
-
-	if mappedField, exists := sortMappings[lowerSort]; exists {
-		return mappedField
-	}
-
-	validSortFields := map[string]bool{
-		"order_album_name": true,
-		"artist":           true,
-		"album_artist":     true,
-		"min_year":         true,
-		"max_year":         true,
-		"rating":           true,
-		"starred_at":       true,
-		"genre":            true,
-		"song_count":       true,
-		"duration":         true,
-		"size":             true,
-		"play_count":       true,
-		"play_date":        true,
-		"created_at":       true,
-		"updated_at":       true,
-	}
-
-	if validSortFields[lowerSort] {
-		return lowerSort
-	}
-
-	return "_id"
+func buildAlbumBaseMatch(searchCond bson.D, starred, artistId, minYear, maxYear, decade string) bson.D {
+	return buildAlbumMatch(searchCond, starred, artistId, minYear, maxYear, decade)
 }
 
 // 修复排序稳定性：添加唯一字段作为次要排序条件
@@ -351,3 +414,144 @@ func buildAlbumPaginationStage(start, end string) []bson.D {
 
 	return stages
 }
+
+func (r *albumRepository) GetAlbumShelves(
+	ctx context.Context,
+	limits scene_audio_route_models.AlbumShelfLimits,
+) (*scene_audio_route_models.AlbumShelves, error) {
+	sortLimit := func(limit int, sort bson.D, match bson.D) bson.A {
+		stages := bson.A{}
+		if len(match) > 0 {
+			stages = append(stages, bson.D{{Key: "$match", Value: match}})
+		}
+		return append(stages,
+			bson.D{{Key: "$sort", Value: sort}},
+			bson.D{{Key: "$limit", Value: limit}},
+		)
+	}
+
+	shelves := []struct {
+		name   string
+		limit  int
+		stages bson.A
+	}{
+		{"recently_added", limits.RecentlyAdded, sortLimit(limits.RecentlyAdded,
+			bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: 1}}, nil)},
+		{"recently_played", limits.RecentlyPlayed, sortLimit(limits.RecentlyPlayed,
+			bson.D{{Key: "play_date", Value: -1}, {Key: "_id", Value: 1}},
+			bson.D{{Key: "play_count", Value: bson.D{{Key: "$gt", Value: 0}}}})},
+		{"most_played", limits.MostPlayed, sortLimit(limits.MostPlayed,
+			bson.D{{Key: "play_count", Value: -1}, {Key: "play_date", Value: -1}, {Key: "_id", Value: 1}},
+			bson.D{{Key: "play_count", Value: bson.D{{Key: "$gt", Value: 0}}}})},
+		{"random", limits.Random, bson.A{bson.D{{Key: "$sample", Value: bson.D{{Key: "size", Value: limits.Random}}}}}},
+		// 先按专辑自身评分，再按曲目平均分
+		{"top_rated", limits.TopRated, sortLimit(limits.TopRated,
+			bson.D{{Key: "rating", Value: -1}, {Key: "average_rating", Value: -1}, {Key: "_id", Value: 1}},
+			bson.D{{Key: "$or", Value: bson.A{
+				bson.D{{Key: "rating", Value: bson.D{{Key: "$gt", Value: 0}}}},
+				bson.D{{Key: "average_rating", Value: bson.D{{Key: "$gt", Value: 0}}}},
+			}}})},
+	}
+
+	facet := bson.D{}
+	for _, shelf := range shelves {
+		if shelf.limit > 0 {
+			facet = append(facet, bson.E{Key: shelf.name, Value: shelf.stages})
+		}
+	}
+	result := &scene_audio_route_models.AlbumShelves{}
+	if len(facet) == 0 {
+		return result, nil
+	}
+
+	scopeCond, err := visibleAlbumCondition(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	var pipeline []bson.D
+	if len(scopeCond) > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: scopeCond}})
+	}
+	pipeline = append(pipeline, annotationFallbackStages(ctx, scene_audio_route_models.AnnotationItemAlbum, "")...)
+	pipeline = append(pipeline, bson.D{{Key: "$facet", Value: facet}})
+	cursor, err := r.db.Collection(r.collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("shelves query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(result); err != nil {
+			return nil, fmt.Errorf("decode shelves failed: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// GetAlbumTracks 返回专辑及其曲目，曲目按光盘分组
+func (r *albumRepository) GetAlbumTracks(ctx context.Context, albumId string) (*scene_audio_route_models.AlbumTracks, error) {
+	objID, err := primitive.ObjectIDFromHex(albumId)
+	if err != nil {
+		return nil, errors.New("invalid album id format")
+	}
+
+	cursor, err := r.db.Collection(r.collection).Aggregate(ctx, append(
+		[]bson.D{{{Key: "$match", Value: bson.D{{Key: "_id", Value: objID}}}}},
+		annotationFallbackStages(ctx, scene_audio_route_models.AnnotationItemAlbum, "")...,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("album query failed: %w", err)
+	}
+	var albums []scene_audio_route_models.AlbumMetadata
+	err = cursor.All(ctx, &albums)
+	_ = cursor.Close(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("decode album failed: %w", err)
+	}
+	if len(albums) == 0 {
+		return nil, fmt.Errorf("album %w", domain.ErrNotFound)
+	}
+
+	pipeline := annotationFallbackStages(ctx, scene_audio_route_models.AnnotationItemMedia, "")
+	pipeline = append(pipeline,
+		bson.D{{Key: "$match", Value: buildMatchStage(ctx, nil, "", albumId, "", "", "")}},
+		buildSortStage(albumTrackOrderField, "asc"),
+	)
+	cursor, err = r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("album tracks query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+	var tracks []scene_audio_route_models.MediaFileMetadata
+	if err := cursor.All(ctx, &tracks); err != nil {
+		return nil, fmt.Errorf("decode album tracks failed: %w", err)
+	}
+	if len(tracks) == 0 && domain.FolderScopeFromContext(ctx) != nil {
+		return nil, fmt.Errorf("album %w", domain.ErrNotFound)
+	}
+
+	return &scene_audio_route_models.AlbumTracks{
+		Album: albums[0],
+		Discs: groupTracksByDisc(tracks),
+	}, nil
+}
+
+// groupTracksByDisc 将已按光盘号与音轨号排序的曲目按光盘分组，未标注光盘号的曲目归入第 1 张
+func groupTracksByDisc(tracks []scene_audio_route_models.MediaFileMetadata) []scene_audio_route_models.AlbumDisc {
+	discs := make([]scene_audio_route_models.AlbumDisc, 0)
+	for _, track := range tracks {
+		discNumber := max(track.DiscNumber, 1)
+		last := len(discs) - 1
+		if last < 0 || discs[last].DiscNumber != discNumber {
+			discs = append(discs, scene_audio_route_models.AlbumDisc{DiscNumber: discNumber})
+			last++
+		}
+		disc := &discs[last]
+		if disc.Subtitle == "" {
+			disc.Subtitle = track.DiscSubtitle
+		}
+		disc.Duration += track.Duration
+		disc.Tracks = append(disc.Tracks, track)
+	}
+	return discs
+}
@@ -0,0 +1,87 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type albumAttachmentRepository struct {
+	db mongo.Database
+}
+
+func NewAlbumAttachmentRepository(db mongo.Database) scene_audio_route_interface.AlbumAttachmentRepository {
+	return &albumAttachmentRepository{db: db}
+}
+
+func (r *albumAttachmentRepository) AlbumExists(ctx context.Context, albumId string) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(albumId)
+	if err != nil {
+		return false, errors.New("invalid album id format")
+	}
+	count, err := r.db.Collection(domain.CollectionFileEntityAudioSceneAlbum).CountDocuments(ctx, bson.M{"_id": objID})
+	if err != nil {
+		return false, fmt.Errorf("album query failed: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (r *albumAttachmentRepository) GetByAlbum(ctx context.Context, albumId string) ([]scene_audio_db_models.AlbumAttachmentMetadata, error) {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneAlbumAttachment).Find(ctx,
+		bson.M{"album_id": albumId},
+		options.Find().SetSort(bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("attachments query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	attachments := make([]scene_audio_db_models.AlbumAttachmentMetadata, 0)
+	if err := cursor.All(ctx, &attachments); err != nil {
+		return nil, fmt.Errorf("decode attachments failed: %w", err)
+	}
+	return attachments, nil
+}
+
+func (r *albumAttachmentRepository) Get(ctx context.Context, albumId, attachmentId string) (*scene_audio_db_models.AlbumAttachmentMetadata, error) {
+	objID, err := primitive.ObjectIDFromHex(attachmentId)
+	if err != nil {
+		return nil, errors.New("invalid attachment id format")
+	}
+	var attachment scene_audio_db_models.AlbumAttachmentMetadata
+	err = r.db.Collection(domain.CollectionFileEntityAudioSceneAlbumAttachment).
+		FindOne(ctx, bson.M{"_id": objID, "album_id": albumId}).Decode(&attachment)
+	if err != nil {
+		if errors.Is(err, driver.ErrNoDocuments) {
+			return nil, fmt.Errorf("attachment %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("attachment query failed: %w", err)
+	}
+	return &attachment, nil
+}
+
+func (r *albumAttachmentRepository) Insert(ctx context.Context, attachment *scene_audio_db_models.AlbumAttachmentMetadata) error {
+	if _, err := r.db.Collection(domain.CollectionFileEntityAudioSceneAlbumAttachment).InsertOne(ctx, attachment); err != nil {
+		return fmt.Errorf("insert attachment failed: %w", err)
+	}
+	return nil
+}
+
+func (r *albumAttachmentRepository) Delete(ctx context.Context, attachmentId string) error {
+	objID, err := primitive.ObjectIDFromHex(attachmentId)
+	if err != nil {
+		return errors.New("invalid attachment id format")
+	}
+	if _, err := r.db.Collection(domain.CollectionFileEntityAudioSceneAlbumAttachment).DeleteOne(ctx, bson.M{"_id": objID}); err != nil {
+		return fmt.Errorf("delete attachment failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,251 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_musicbrainz/scene_audio_musicbrainz_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_musicbrainz/scene_audio_musicbrainz_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 发行版曲目信息基本不变，缓存较长时间以减少对 MusicBrainz 的请求
+const musicBrainzReleaseCacheTTL = 30 * 24 * time.Hour
+
+type albumCompletenessRepository struct {
+	db          mongo.Database
+	collection  string
+	musicBrainz scene_audio_musicbrainz_interface.MusicBrainzClient
+}
+
+func NewAlbumCompletenessRepository(
+	db mongo.Database,
+	collection string,
+	musicBrainz scene_audio_musicbrainz_interface.MusicBrainzClient,
+) scene_audio_route_interface.AlbumCompletenessRepository {
+	return &albumCompletenessRepository{
+		db:          db,
+		collection:  collection,
+		musicBrainz: musicBrainz,
+	}
+}
+
+type completenessAlbum struct {
+	ID         primitive.ObjectID `bson:"_id"`
+	Name       string             `bson:"name"`
+	Artist     string             `bson:"artist"`
+	MBZAlbumID string             `bson:"mbz_album_id"`
+}
+
+type completenessTrack struct {
+	Title             string `bson:"title"`
+	TrackNumber       int    `bson:"track_number"`
+	DiscNumber        int    `bson:"disc_number"`
+	MBZTrackID        string `bson:"mbz_track_id"`
+	MBZReleaseTrackID string `bson:"mbz_release_track_id"`
+}
+
+func (r *albumCompletenessRepository) GetIncompleteAlbums(
+	ctx context.Context,
+	start, end string,
+) ([]scene_audio_route_models.AlbumCompletenessMetadata, error) {
+	coll := r.db.Collection(r.collection)
+
+	findOpts := options.Find().SetSort(bson.D{
+		{Key: "order_album_name", Value: 1},
+		{Key: "_id", Value: 1},
+	})
+	startInt, err1 := strconv.Atoi(start)
+	endInt, err2 := strconv.Atoi(end)
+	if err1 == nil && err2 == nil && startInt >= 0 && endInt > startInt {
+		findOpts.SetSkip(int64(startInt)).SetLimit(int64(endInt - startInt))
+	}
+
+	filter := bson.D{{Key: "mbz_album_id", Value: bson.D{{Key: "$nin", Value: bson.A{"", nil}}}}}
+	visibleCond, err := visibleAlbumCondition(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := coll.Find(ctx, append(filter, visibleCond...), findOpts)
+	if err != nil {
+		return nil, fmt.Errorf("album query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var albums []completenessAlbum
+	if err := cursor.All(ctx, &albums); err != nil {
+		return nil, fmt.Errorf("decode error: %w", err)
+	}
+
+	results := make([]scene_audio_route_models.AlbumCompletenessMetadata, 0)
+	for _, album := range albums {
+		result, err := r.compare(ctx, album)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			// 单个专辑查询失败不影响其它专辑
+			log.Printf("专辑[%s]曲目比对失败: %v", album.ID.Hex(), err)
+			continue
+		}
+		if len(result.MissingTracks) > 0 {
+			results = append(results, *result)
+		}
+	}
+	return results, nil
+}
+
+func (r *albumCompletenessRepository) GetAlbumMissingTracks(
+	ctx context.Context,
+	albumId string,
+) (*scene_audio_route_models.AlbumCompletenessMetadata, error) {
+	objID, err := primitive.ObjectIDFromHex(albumId)
+	if err != nil {
+		return nil, errors.New("invalid album id format")
+	}
+	visible, err := itemVisible(ctx, r.db, scene_audio_route_models.AnnotationItemAlbum, objID)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, fmt.Errorf("album %w", domain.ErrNotFound)
+	}
+
+	var album completenessAlbum
+	if err := r.db.Collection(r.collection).FindOne(ctx, bson.M{"_id": objID}).Decode(&album); err != nil {
+		if errors.Is(err, driver.ErrNoDocuments) {
+			return nil, fmt.Errorf("album %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("album query failed: %w", err)
+	}
+	if album.MBZAlbumID == "" {
+		return nil, errors.New("album has no musicbrainz release id")
+	}
+
+	return r.compare(ctx, album)
+}
+
+func (r *albumCompletenessRepository) compare(
+	ctx context.Context,
+	album completenessAlbum,
+) (*scene_audio_route_models.AlbumCompletenessMetadata, error) {
+	release, err := r.getRelease(ctx, album.MBZAlbumID)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).Find(
+		ctx,
+		// 本地曲目只统计调用方可见的部分
+		bson.D{{Key: "album_id", Value: album.ID.Hex()}, visibleFilter(ctx)},
+		options.Find().SetProjection(bson.M{
+			"title": 1, "track_number": 1, "disc_number": 1,
+			"mbz_track_id": 1, "mbz_release_track_id": 1,
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("media query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var tracks []completenessTrack
+	if err := cursor.All(ctx, &tracks); err != nil {
+		return nil, fmt.Errorf("decode error: %w", err)
+	}
+
+	return &scene_audio_route_models.AlbumCompletenessMetadata{
+		AlbumID:           album.ID,
+		Name:              album.Name,
+		Artist:            album.Artist,
+		MBZAlbumID:        album.MBZAlbumID,
+		LocalTrackCount:   len(tracks),
+		ReleaseTrackCount: release.TrackCount,
+		MissingTracks:     findMissingTracks(release.Tracks, tracks),
+	}, nil
+}
+
+// findMissingTracks 依次按发行版曲目ID、录音ID、碟号+曲号、标题匹配本地曲目
+func findMissingTracks(
+	releaseTracks []scene_audio_musicbrainz_models.MusicBrainzTrack,
+	localTracks []completenessTrack,
+) []scene_audio_musicbrainz_models.MusicBrainzTrack {
+	releaseTrackIDs := make(map[string]bool)
+	recordingIDs := make(map[string]bool)
+	positions := make(map[string]bool)
+	titles := make(map[string]bool)
+	for _, t := range localTracks {
+		if t.MBZReleaseTrackID != "" {
+			releaseTrackIDs[t.MBZReleaseTrackID] = true
+		}
+		if t.MBZTrackID != "" {
+			recordingIDs[t.MBZTrackID] = true
+		}
+		if t.TrackNumber > 0 {
+			disc := t.DiscNumber
+			if disc <= 0 {
+				disc = 1
+			}
+			positions[fmt.Sprintf("%d-%d", disc, t.TrackNumber)] = true
+		}
+		titles[strings.ToLower(strings.TrimSpace(t.Title))] = true
+	}
+
+	missing := make([]scene_audio_musicbrainz_models.MusicBrainzTrack, 0)
+	for _, t := range releaseTracks {
+		switch {
+		case releaseTrackIDs[t.ID]:
+		case recordingIDs[t.RecordingID]:
+		case positions[fmt.Sprintf("%d-%d", t.DiscNumber, t.TrackNumber)]:
+		case titles[strings.ToLower(strings.TrimSpace(t.Title))]:
+		default:
+			missing = append(missing, t)
+		}
+	}
+	return missing
+}
+
+// getRelease 优先读取本地缓存，过期或不存在时请求 MusicBrainz 并写回
+func (r *albumCompletenessRepository) getRelease(
+	ctx context.Context,
+	releaseID string,
+) (*scene_audio_musicbrainz_models.MusicBrainzRelease, error) {
+	coll := r.db.Collection(domain.CollectionFileEntityAudioSceneMusicBrainzRelease)
+
+	var cached scene_audio_musicbrainz_models.MusicBrainzRelease
+	err := coll.FindOne(ctx, bson.M{"mbz_album_id": releaseID}).Decode(&cached)
+	if err == nil && time.Since(cached.FetchedAt) < musicBrainzReleaseCacheTTL {
+		return &cached, nil
+	}
+	if err != nil && !errors.Is(err, driver.ErrNoDocuments) {
+		return nil, fmt.Errorf("release cache query failed: %w", err)
+	}
+
+	release, err := r.musicBrainz.GetRelease(ctx, releaseID)
+	if err != nil {
+		return nil, err
+	}
+	release.ID = releaseID
+
+	update := bson.M{"$set": bson.M{
+		"title":       release.Title,
+		"track_count": release.TrackCount,
+		"tracks":      release.Tracks,
+		"fetched_at":  release.FetchedAt,
+	}}
+	if _, err := coll.UpdateOne(ctx, bson.M{"mbz_album_id": releaseID}, update, options.Update().SetUpsert(true)); err != nil {
+		log.Printf("缓存发行版[%s]失败: %v", releaseID, err)
+	}
+	return release, nil
+}
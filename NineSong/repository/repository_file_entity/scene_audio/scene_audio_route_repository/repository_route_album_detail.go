@@ -0,0 +1,223 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// albumDetailDoc 专辑详情聚合的结果，专辑字段内联，其余为 $lookup 结果
+type albumDetailDoc struct {
+	scene_audio_route_models.AlbumMetadata `bson:",inline"`
+
+	Tracks      []scene_audio_route_models.MediaFileMetadata    `bson:"detail_tracks"`
+	Artists     []scene_audio_route_models.ArtistMetadata       `bson:"detail_artists"`
+	Similar     []scene_audio_route_models.AlbumMetadata        `bson:"detail_similar"`
+	Versions    []scene_audio_route_models.AlbumMetadata        `bson:"detail_versions"`
+	Attachments []scene_audio_db_models.AlbumAttachmentMetadata `bson:"detail_attachments"`
+	UserHistory []struct {
+		Count        int       `bson:"count"`
+		LastPlayedAt time.Time `bson:"last_played_at"`
+	} `bson:"detail_user_history"`
+}
+
+// GetAlbumDetail 一次聚合取回专辑页所需数据：曲目（按光盘与音轨号）、参与艺术家、当前用户的播放统计、相似专辑与其他发行版。
+// 相似专辑优先同一专辑艺术家，其次同流派，再按播放次数，同一版本分组的专辑只出现在 versions 中
+func (r *albumRepository) GetAlbumDetail(ctx context.Context, albumId, userId string) (*scene_audio_route_models.AlbumDetail, error) {
+	objID, err := primitive.ObjectIDFromHex(albumId)
+	if err != nil {
+		return nil, errors.New("invalid album id format")
+	}
+
+	trackPipeline := bson.A{
+		bson.D{{Key: "$match", Value: bson.D{{Key: "$expr", Value: bson.D{{Key: "$eq", Value: bson.A{"$album_id", "$$album_id"}}}}}}},
+		bson.D{{Key: "$match", Value: bson.D{visibleFilter(ctx)}}},
+	}
+	for _, stage := range annotationFallbackStages(ctx, scene_audio_route_models.AnnotationItemMedia, "") {
+		trackPipeline = append(trackPipeline, stage)
+	}
+	trackPipeline = append(trackPipeline, buildSortStage(albumTrackOrderField, "asc"))
+
+	// 专辑与各曲目中出现的艺术家ID（十六进制）转换为 ObjectID
+	artistIDs := bson.D{{Key: "$setUnion", Value: bson.A{
+		bson.A{"$artist_id", "$album_artist_id"},
+		bson.D{{Key: "$ifNull", Value: bson.A{"$all_artist_ids.artist_id", bson.A{}}}},
+		bson.D{{Key: "$ifNull", Value: bson.A{"$all_album_artist_ids.artist_id", bson.A{}}}},
+		bson.D{{Key: "$ifNull", Value: bson.A{"$detail_tracks.artist_id", bson.A{}}}},
+		bson.D{{Key: "$reduce", Value: bson.D{
+			{Key: "input", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$detail_tracks.all_artist_ids.artist_id", bson.A{}}}}},
+			{Key: "initialValue", Value: bson.A{}},
+			{Key: "in", Value: bson.D{{Key: "$concatArrays", Value: bson.A{"$$value", "$$this"}}}},
+		}}},
+	}}}
+	artistOIDs := bson.D{{Key: "$map", Value: bson.D{
+		{Key: "input", Value: artistIDs},
+		{Key: "in", Value: bson.D{{Key: "$convert", Value: bson.D{
+			{Key: "input", Value: "$$this"}, {Key: "to", Value: "objectId"}, {Key: "onError", Value: nil}, {Key: "onNull", Value: nil},
+		}}}},
+	}}}
+
+	pipeline := []bson.D{
+		{{Key: "$match", Value: bson.D{{Key: "_id", Value: objID}}}},
+	}
+	pipeline = append(pipeline, annotationFallbackStages(ctx, scene_audio_route_models.AnnotationItemAlbum, "")...)
+	pipeline = append(pipeline,
+		bson.D{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: domain.CollectionFileEntityAudioSceneMediaFile},
+			{Key: "let", Value: bson.D{{Key: "album_id", Value: albumId}}},
+			{Key: "pipeline", Value: trackPipeline},
+			{Key: "as", Value: "detail_tracks"},
+		}}},
+		bson.D{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: domain.CollectionFileEntityAudioSceneArtist},
+			{Key: "let", Value: bson.D{{Key: "ids", Value: artistOIDs}}},
+			{Key: "pipeline", Value: bson.A{
+				bson.D{{Key: "$match", Value: bson.D{{Key: "$expr", Value: bson.D{{Key: "$in", Value: bson.A{"$_id", "$$ids"}}}}}}},
+				bson.D{{Key: "$sort", Value: bson.D{{Key: "name", Value: 1}}}},
+			}},
+			{Key: "as", Value: "detail_artists"},
+		}}},
+		bson.D{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: domain.CollectionFileEntityAudioScenePlayHistory},
+			{Key: "pipeline", Value: bson.A{
+				bson.D{{Key: "$match", Value: bson.D{{Key: "user_id", Value: userId}, {Key: "album_id", Value: albumId}}}},
+				bson.D{{Key: "$group", Value: bson.D{
+					{Key: "_id", Value: nil},
+					{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+					{Key: "last_played_at", Value: bson.D{{Key: "$max", Value: "$played_at"}}},
+				}}},
+			}},
+			{Key: "as", Value: "detail_user_history"},
+		}}},
+		bson.D{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: r.collection},
+			{Key: "let", Value: bson.D{
+				{Key: "id", Value: "$_id"},
+				{Key: "genre", Value: "$genre"},
+				{Key: "album_artist_id", Value: "$album_artist_id"},
+				{Key: "version_group_id", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$version_group_id", ""}}}},
+			}},
+			{Key: "pipeline", Value: bson.A{
+				bson.D{{Key: "$match", Value: bson.D{{Key: "$expr", Value: bson.D{{Key: "$and", Value: bson.A{
+					bson.D{{Key: "$ne", Value: bson.A{"$_id", "$$id"}}},
+					bson.D{{Key: "$or", Value: bson.A{
+						bson.D{{Key: "$eq", Value: bson.A{"$$version_group_id", ""}}},
+						bson.D{{Key: "$ne", Value: bson.A{"$version_group_id", "$$version_group_id"}}},
+					}}},
+					bson.D{{Key: "$or", Value: bson.A{
+						bson.D{{Key: "$and", Value: bson.A{
+							bson.D{{Key: "$ne", Value: bson.A{"$$album_artist_id", ""}}},
+							bson.D{{Key: "$eq", Value: bson.A{"$album_artist_id", "$$album_artist_id"}}},
+						}}},
+						bson.D{{Key: "$and", Value: bson.A{
+							bson.D{{Key: "$ne", Value: bson.A{"$$genre", ""}}},
+							bson.D{{Key: "$eq", Value: bson.A{"$genre", "$$genre"}}},
+						}}},
+					}}},
+				}}}}}}},
+				bson.D{{Key: "$addFields", Value: bson.D{{Key: "similar_score", Value: bson.D{{Key: "$cond", Value: bson.A{
+					bson.D{{Key: "$eq", Value: bson.A{"$album_artist_id", "$$album_artist_id"}}}, 2, 1,
+				}}}}}}},
+				bson.D{{Key: "$sort", Value: bson.D{
+					{Key: "similar_score", Value: -1},
+					{Key: "play_count", Value: -1},
+					{Key: "_id", Value: 1},
+				}}},
+				bson.D{{Key: "$limit", Value: scene_audio_route_models.AlbumDetailSimilarLimit}},
+				bson.D{{Key: "$unset", Value: "similar_score"}},
+			}},
+			{Key: "as", Value: "detail_similar"},
+		}}},
+		bson.D{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: r.collection},
+			{Key: "let", Value: bson.D{
+				{Key: "id", Value: "$_id"},
+				{Key: "version_group_id", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$version_group_id", ""}}}},
+			}},
+			{Key: "pipeline", Value: bson.A{
+				bson.D{{Key: "$match", Value: bson.D{{Key: "$expr", Value: bson.D{{Key: "$and", Value: bson.A{
+					bson.D{{Key: "$ne", Value: bson.A{"$$version_group_id", ""}}},
+					bson.D{{Key: "$eq", Value: bson.A{"$version_group_id", "$$version_group_id"}}},
+					bson.D{{Key: "$ne", Value: bson.A{"$_id", "$$id"}}},
+				}}}}}}},
+				bson.D{{Key: "$sort", Value: bson.D{
+					{Key: "release_country", Value: 1},
+					{Key: "min_year", Value: 1},
+					{Key: "_id", Value: 1},
+				}}},
+			}},
+			{Key: "as", Value: "detail_versions"},
+		}}},
+		bson.D{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: domain.CollectionFileEntityAudioSceneAlbumAttachment},
+			{Key: "pipeline", Value: bson.A{
+				bson.D{{Key: "$match", Value: bson.D{{Key: "album_id", Value: albumId}}}},
+				bson.D{{Key: "$sort", Value: bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}}},
+			}},
+			{Key: "as", Value: "detail_attachments"},
+		}}},
+	)
+
+	cursor, err := r.db.Collection(r.collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("album detail query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []albumDetailDoc
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("decode album detail failed: %w", err)
+	}
+	// 受媒体库权限限制时，没有可见曲目的专辑视为不存在
+	if len(docs) == 0 || (len(docs[0].Tracks) == 0 && domain.FolderScopeFromContext(ctx) != nil) {
+		return nil, fmt.Errorf("album %w", domain.ErrNotFound)
+	}
+	doc := docs[0]
+
+	detail := &scene_audio_route_models.AlbumDetail{
+		Album:         doc.AlbumMetadata,
+		Discs:         groupTracksByDisc(doc.Tracks),
+		Artists:       doc.Artists,
+		TrackCount:    len(doc.Tracks),
+		SimilarAlbums: doc.Similar,
+		Versions:      doc.Versions,
+		Attachments:   doc.Attachments,
+		Annotation: scene_audio_route_models.AlbumUserAnnotation{
+			Starred:   doc.Starred,
+			StarredAt: doc.StarredAt,
+			Rating:    doc.Rating,
+			RatedAt:   doc.RatedAt,
+			PlayCount: doc.PlayCount,
+			PlayDate:  doc.PlayDate,
+		},
+	}
+	for _, track := range doc.Tracks {
+		detail.Duration += track.Duration
+		detail.Size += track.Size
+	}
+	if len(doc.UserHistory) > 0 {
+		detail.Annotation.UserPlayCount = doc.UserHistory[0].Count
+		lastPlayed := doc.UserHistory[0].LastPlayedAt
+		detail.Annotation.UserLastPlayed = &lastPlayed
+	}
+	if detail.Artists == nil {
+		detail.Artists = make([]scene_audio_route_models.ArtistMetadata, 0)
+	}
+	if detail.Attachments == nil {
+		detail.Attachments = make([]scene_audio_db_models.AlbumAttachmentMetadata, 0)
+	}
+	if detail.SimilarAlbums == nil {
+		detail.SimilarAlbums = make([]scene_audio_route_models.AlbumMetadata, 0)
+	}
+	if detail.Versions == nil {
+		detail.Versions = make([]scene_audio_route_models.AlbumMetadata, 0)
+	}
+	return detail, nil
+}
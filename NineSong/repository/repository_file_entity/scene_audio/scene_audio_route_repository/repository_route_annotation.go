@@ -4,15 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"go.mongodb.org/mongo-driver/mongo/options"
+	"log"
 	"time"
 
+	"go.mongodb.org/mongo-driver/mongo/options"
+
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
 )
 
 type annotationRepository struct {
@@ -23,35 +26,53 @@ func NewAnnotationRepository(db mongo.Database) scene_audio_route_interface.Anno
 	return &annotationRepository{db: db}
 }
 
-func (r *annotationRepository) createFilter(itemId, itemType string) (bson.M, error) {
+// createFilter 注解按 (user_id, item_id, item_type) 区分，用户取自请求 context；upsert 时这三个字段随过滤条件写入。
+// 调用方受媒体库权限限制时，不可见的条目按不存在处理
+func (r *annotationRepository) createFilter(ctx context.Context, itemId, itemType string) (bson.M, error) {
 	objID, err := primitive.ObjectIDFromHex(itemId)
 	if err != nil {
 		return nil, errors.New("invalid item_id format")
 	}
+	visible, err := itemVisible(ctx, r.db, scene_audio_route_models.AnnotationItemType(itemType), objID)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, scene_audio_route_models.ErrAnnotationItemNotFound
+	}
 
 	return bson.M{
+		"user_id":   domain.UserIDFromContext(ctx),
 		"item_id":   objID,
 		"item_type": itemType,
 	}, nil
 }
 
+// syncItem 将注解变化同步到条目文档的冗余字段
+func (r *annotationRepository) syncItem(ctx context.Context, itemId, itemType string) {
+	if objID, err := primitive.ObjectIDFromHex(itemId); err == nil {
+		syncItemAnnotationsQuietly(ctx, r.db, scene_audio_route_models.AnnotationItemType(itemType), objID)
+	}
+}
+
 func (r *annotationRepository) UpdateStarred(
 	ctx context.Context,
 	itemId, itemType string,
 ) (bool, error) {
-	filter, err := r.createFilter(itemId, itemType)
+	filter, err := r.createFilter(ctx, itemId, itemType)
 	if err != nil {
 		return false, err
 	}
 
+	now := time.Now().UTC()
 	update := bson.M{
 		"$set": bson.M{
 			"starred":    true,
-			"starred_at": time.Now().UTC(),
-			"updated_at": time.Now().UTC(),
+			"starred_at": now,
+			"updated_at": now,
 		},
 		"$setOnInsert": bson.M{
-			"created_at": time.Now().UTC(),
+			"created_at": now,
 			"play_count": 0,
 			"rating":     0,
 		},
@@ -74,6 +95,7 @@ func (r *annotationRepository) UpdateStarred(
 		return false, fmt.Errorf("fetch document failed: %w", err)
 	}
 
+	r.syncItem(ctx, itemId, itemType)
 	return true, nil
 }
 
@@ -81,7 +103,7 @@ func (r *annotationRepository) UpdateUnStarred(
 	ctx context.Context,
 	itemId, itemType string,
 ) (bool, error) {
-	filter, err := r.createFilter(itemId, itemType)
+	filter, err := r.createFilter(ctx, itemId, itemType)
 	if err != nil {
 		return false, err
 	}
@@ -110,23 +132,190 @@ func (r *annotationRepository) UpdateUnStarred(
 		return false, fmt.Errorf("fetch document failed: %w", err)
 	}
 
+	r.syncItem(ctx, itemId, itemType)
 	return true, nil
 }
 
+func (r *annotationRepository) SetStarred(
+	ctx context.Context,
+	itemId, itemType string,
+	starred bool,
+) (*scene_audio_route_models.AnnotationMetadata, error) {
+	return r.SetAnnotation(ctx, itemId, scene_audio_route_models.AnnotationItemType(itemType),
+		scene_audio_route_models.AnnotationUpdate{Starred: &starred})
+}
+
+func (r *annotationRepository) GetAnnotation(
+	ctx context.Context,
+	itemId string,
+	itemType scene_audio_route_models.AnnotationItemType,
+) (*scene_audio_route_models.AnnotationMetadata, error) {
+	filter, err := r.createFilter(ctx, itemId, string(itemType))
+	if err != nil {
+		return nil, err
+	}
+
+	var doc scene_audio_route_models.AnnotationMetadata
+	err = r.db.Collection(domain.CollectionFileEntityAudioSceneAnnotation).FindOne(ctx, filter).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, driver.ErrNoDocuments) {
+			return nil, fmt.Errorf("annotation %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("fetch document failed: %w", err)
+	}
+	return &doc, nil
+}
+
+func (r *annotationRepository) SetAnnotation(
+	ctx context.Context,
+	itemId string,
+	itemType scene_audio_route_models.AnnotationItemType,
+	update scene_audio_route_models.AnnotationUpdate,
+) (*scene_audio_route_models.AnnotationMetadata, error) {
+	filter, err := r.createFilter(ctx, itemId, string(itemType))
+	if err != nil {
+		return nil, err
+	}
+
+	// 条目不存在时不创建注解，避免留下无主文档
+	collection, ok := itemType.Collection()
+	if !ok {
+		return nil, fmt.Errorf("unsupported item type: %s", itemType)
+	}
+	count, err := r.db.Collection(collection).CountDocuments(ctx, bson.M{"_id": filter["item_id"]})
+	if err != nil {
+		return nil, fmt.Errorf("item query failed: %w", err)
+	}
+	if count == 0 {
+		return nil, scene_audio_route_models.ErrAnnotationItemNotFound
+	}
+
+	now := time.Now().UTC()
+	set := bson.M{"updated_at": now}
+	setOnInsert := bson.M{"created_at": now}
+	inc := bson.M{}
+	if update.Starred != nil {
+		starredAt := now
+		if !*update.Starred {
+			starredAt = time.Time{}
+		}
+		set["starred"] = *update.Starred
+		set["starred_at"] = starredAt
+	} else {
+		setOnInsert["starred"] = false
+	}
+	if update.Rating != nil {
+		ratedAt := now
+		if *update.Rating == 0 {
+			ratedAt = time.Time{}
+		}
+		set["rating"] = *update.Rating
+		set["rated_at"] = ratedAt
+	} else {
+		setOnInsert["rating"] = 0
+	}
+	if update.Played {
+		inc["play_count"] = 1
+		set["play_date"] = now
+		if update.Complete {
+			inc["play_complete_count"] = 1
+		}
+	} else {
+		setOnInsert["play_count"] = 0
+	}
+
+	doc := bson.M{"$set": set, "$setOnInsert": setOnInsert}
+	if len(inc) > 0 {
+		doc["$inc"] = inc
+		doc["$min"] = bson.M{"first_play_date": now}
+	}
+
+	coll := r.db.Collection(domain.CollectionFileEntityAudioSceneAnnotation)
+	if _, err := coll.UpdateOne(ctx, filter, doc, options.Update().SetUpsert(true)); err != nil {
+		return nil, fmt.Errorf("update operation failed: %w", err)
+	}
+
+	var annotation scene_audio_route_models.AnnotationMetadata
+	if err := coll.FindOne(ctx, filter).Decode(&annotation); err != nil {
+		return nil, fmt.Errorf("fetch document failed: %w", err)
+	}
+
+	r.syncItem(ctx, itemId, string(itemType))
+	if itemType == scene_audio_route_models.AnnotationItemMedia && update.Rating != nil {
+		if objID, err := primitive.ObjectIDFromHex(itemId); err == nil {
+			refreshAverageRatingsQuietly(ctx, r.db, objID)
+		}
+	}
+	return &annotation, nil
+}
+
 func (r *annotationRepository) UpdateRating(
 	ctx context.Context,
 	itemId, itemType string,
 	rating int,
 ) (bool, error) {
-	filter, err := r.createFilter(itemId, itemType)
-	if err != nil {
+	if _, err := r.writeRating(ctx, itemId, itemType, rating); err != nil {
 		return false, err
 	}
+	if scene_audio_route_models.AnnotationItemType(itemType) == scene_audio_route_models.AnnotationItemMedia {
+		if objID, err := primitive.ObjectIDFromHex(itemId); err == nil {
+			refreshAverageRatingsQuietly(ctx, r.db, objID)
+		}
+	}
+	return true, nil
+}
+
+func (r *annotationRepository) RateMediaFile(
+	ctx context.Context,
+	mediaFileId string,
+	rating int,
+) (*scene_audio_route_models.MediaFileRating, error) {
+	objID, err := primitive.ObjectIDFromHex(mediaFileId)
+	if err != nil {
+		return nil, errors.New("invalid item_id format")
+	}
+	count, err := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).CountDocuments(ctx, bson.D{{Key: "_id", Value: objID}, visibleFilter(ctx)})
+	if err != nil {
+		return nil, fmt.Errorf("media query failed: %w", err)
+	}
+	if count == 0 {
+		return nil, scene_audio_route_models.ErrAnnotationItemNotFound
+	}
+
+	annotation, err := r.writeRating(ctx, mediaFileId, string(scene_audio_route_models.AnnotationItemMedia), rating)
+	if err != nil {
+		return nil, err
+	}
+	result, err := refreshAverageRatings(ctx, r.db, objID)
+	if err != nil {
+		return nil, err
+	}
+	result.Annotation = annotation
+	return result, nil
+}
+
+func (r *annotationRepository) writeRating(
+	ctx context.Context,
+	itemId, itemType string,
+	rating int,
+) (*scene_audio_route_models.AnnotationMetadata, error) {
+	filter, err := r.createFilter(ctx, itemId, itemType)
+	if err != nil {
+		return nil, err
+	}
+
+	// 评分时间以服务端时钟为准，清除评分时同时清除评分时间
+	now := time.Now().UTC()
+	ratedAt := now
+	if rating == 0 {
+		ratedAt = time.Time{}
+	}
 
 	update := bson.M{
 		"$set": bson.M{
 			"rating":     rating,
-			"updated_at": time.Now().UTC(),
+			"rated_at":   ratedAt,
+			"updated_at": now,
 		},
 		"$setOnInsert": bson.M{
 			"created_at": time.Now().UTC(),
@@ -140,7 +329,7 @@ func (r *annotationRepository) UpdateRating(
 
 	res, err := coll.UpdateOne(ctx, filter, update, opts)
 	if err != nil {
-		return false, fmt.Errorf("update operation failed: %w", err)
+		return nil, fmt.Errorf("update operation failed: %w", err)
 	}
 
 	var doc scene_audio_route_models.AnnotationMetadata
@@ -149,27 +338,30 @@ func (r *annotationRepository) UpdateRating(
 	}
 
 	if err := coll.FindOne(ctx, filter).Decode(&doc); err != nil {
-		return false, fmt.Errorf("fetch document failed: %w", err)
+		return nil, fmt.Errorf("fetch document failed: %w", err)
 	}
 
-	return true, nil
+	r.syncItem(ctx, itemId, itemType)
+	return &doc, nil
 }
 
 func (r *annotationRepository) UpdateScrobble(
 	ctx context.Context,
 	itemId, itemType string,
 ) (bool, error) {
-	filter, err := r.createFilter(itemId, itemType)
+	filter, err := r.createFilter(ctx, itemId, itemType)
 	if err != nil {
 		return false, err
 	}
 
+	now := time.Now().UTC()
 	update := bson.M{
 		"$inc": bson.M{"play_count": 1},
 		"$set": bson.M{
-			"play_date":  time.Now().UTC(),
-			"updated_at": time.Now().UTC(),
+			"play_date":  now,
+			"updated_at": now,
 		},
+		"$min": bson.M{"first_play_date": now},
 		"$setOnInsert": bson.M{
 			"created_at": time.Now().UTC(),
 			"starred":    false,
@@ -194,6 +386,7 @@ func (r *annotationRepository) UpdateScrobble(
 		return false, fmt.Errorf("fetch document failed: %w", err)
 	}
 
+	r.syncItem(ctx, itemId, itemType)
 	return true, nil
 }
 
@@ -201,7 +394,7 @@ func (r *annotationRepository) UpdateCompleteScrobble(
 	ctx context.Context,
 	itemId, itemType string,
 ) (bool, error) {
-	filter, err := r.createFilter(itemId, itemType)
+	filter, err := r.createFilter(ctx, itemId, itemType)
 	if err != nil {
 		return false, err
 	}
@@ -232,6 +425,7 @@ func (r *annotationRepository) UpdateCompleteScrobble(
 		return false, fmt.Errorf("fetch document failed: %w", err)
 	}
 
+	r.syncItem(ctx, itemId, itemType)
 	return true, nil
 }
 
@@ -240,7 +434,7 @@ func (r *annotationRepository) UpdateTagSource(
 	itemId, itemType string,
 	tags []scene_audio_route_models.TagSource,
 ) (bool, error) {
-	filter, err := r.createFilter(itemId, itemType)
+	filter, err := r.createFilter(ctx, itemId, itemType)
 	if err != nil {
 		return false, err
 	}
@@ -275,7 +469,7 @@ func (r *annotationRepository) UpdateWeightedTag(
 	itemId, itemType string,
 	tags []scene_audio_route_models.WeightedTag,
 ) (bool, error) {
-	filter, err := r.createFilter(itemId, itemType)
+	filter, err := r.createFilter(ctx, itemId, itemType)
 	if err != nil {
 		return false, err
 	}
@@ -304,3 +498,38 @@ func (r *annotationRepository) UpdateWeightedTag(
 
 	return true, nil
 }
+
+// AssignLegacyAnnotations 升级前写入的注解没有用户，归属最早创建的管理员（没有管理员时为最早的用户），
+// 单用户部署升级后收藏、评分与播放统计保持不变
+func AssignLegacyAnnotations(ctx context.Context, db mongo.Database) error {
+	cursor, err := db.Collection(domain.CollectionUser).Find(ctx, bson.M{}, options.Find().
+		SetProjection(bson.M{"_id": 1}).
+		SetSort(bson.D{{Key: "admin", Value: -1}, {Key: "_id", Value: 1}}).
+		SetLimit(1))
+	if err != nil {
+		return fmt.Errorf("owner query failed: %w", err)
+	}
+	var rows []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return fmt.Errorf("decode owner failed: %w", err)
+	}
+	_ = cursor.Close(ctx)
+	if len(rows) == 0 {
+		return nil
+	}
+	ownerID := rows[0].ID.Hex()
+
+	res, err := db.Collection(domain.CollectionFileEntityAudioSceneAnnotation).UpdateMany(ctx,
+		bson.M{"user_id": bson.M{"$in": bson.A{nil, ""}}},
+		bson.M{"$set": bson.M{"user_id": ownerID}},
+	)
+	if err != nil {
+		return fmt.Errorf("assign legacy annotations failed: %w", err)
+	}
+	if res.ModifiedCount > 0 {
+		log.Printf("已将 %d 条无用户注解归属用户 %s", res.ModifiedCount, ownerID)
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AnnotationSyncedField 条目文档上冗余注解字段的同步时间，缺失表示尚未同步，查询时回退为关联注解集合
+const AnnotationSyncedField = "annotation_synced_at"
+
+// userAnnotationsField 条目文档上按用户 ID 存放的冗余注解，各用户的播放、收藏与评分互不混合
+const userAnnotationsField = "user_annotations"
+
+const annotationRepairBatchSize = 500
+
+// denormalizedAnnotationFields 冗余到条目文档上的注解字段及同一用户多条注解时的合并方式
+var denormalizedAnnotationFields = []struct {
+	field      string
+	accumulate string
+}{
+	{"play_count", "$sum"},
+	{"play_complete_count", "$sum"},
+	{"play_date", "$max"},
+	{"first_play_date", "$min"},
+	{"rating", "$max"},
+	{"rated_at", "$max"},
+	{"starred", "$max"},
+	{"starred_at", "$max"},
+}
+
+// syncItemAnnotations 按注解集合重新计算条目上各用户的冗余字段，重复执行结果一致；
+// 同时移除旧版本写在条目顶层的全体用户汇总字段
+func syncItemAnnotations(ctx context.Context, db mongo.Database, itemType scene_audio_route_models.AnnotationItemType, itemIDs ...primitive.ObjectID) error {
+	collection, ok := itemType.Collection()
+	if !ok || len(itemIDs) == 0 {
+		return nil
+	}
+
+	group := bson.D{{Key: "_id", Value: bson.D{{Key: "item_id", Value: "$item_id"}, {Key: "user_id", Value: "$user_id"}}}}
+	for _, f := range denormalizedAnnotationFields {
+		group = append(group, bson.E{Key: f.field, Value: bson.D{{Key: f.accumulate, Value: "$" + f.field}}})
+	}
+	cursor, err := db.Collection(domain.CollectionFileEntityAudioSceneAnnotation).Aggregate(ctx, []bson.D{
+		{{Key: "$match", Value: bson.D{
+			{Key: "item_id", Value: bson.D{{Key: "$in", Value: itemIDs}}},
+			{Key: "item_type", Value: itemType},
+			// 未归属用户的旧注解不会被任何用户读到，不冗余
+			{Key: "user_id", Value: bson.D{{Key: "$nin", Value: bson.A{"", nil}}}},
+		}}},
+		{{Key: "$group", Value: group}},
+	})
+	if err != nil {
+		return fmt.Errorf("annotation aggregate failed: %w", err)
+	}
+	var rows []struct {
+		Key struct {
+			ItemID primitive.ObjectID `bson:"item_id"`
+			UserID string             `bson:"user_id"`
+		} `bson:"_id"`
+		Fields bson.M `bson:",inline"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return fmt.Errorf("decode annotation aggregate failed: %w", err)
+	}
+	_ = cursor.Close(ctx)
+
+	perUser := make(map[primitive.ObjectID]bson.M, len(itemIDs))
+	for _, row := range rows {
+		if perUser[row.Key.ItemID] == nil {
+			perUser[row.Key.ItemID] = bson.M{}
+		}
+		perUser[row.Key.ItemID][row.Key.UserID] = row.Fields
+	}
+
+	legacy := bson.M{}
+	for _, f := range denormalizedAnnotationFields {
+		legacy[f.field] = ""
+	}
+	now := time.Now().UTC()
+	models := make([]driver.WriteModel, 0, len(itemIDs))
+	for _, id := range itemIDs {
+		users := perUser[id]
+		if users == nil {
+			users = bson.M{}
+		}
+		update := bson.M{
+			"$set":   bson.M{userAnnotationsField: users, AnnotationSyncedField: now},
+			"$unset": legacy,
+		}
+		models = append(models, driver.NewUpdateOneModel().SetFilter(bson.M{"_id": id}).SetUpdate(update))
+	}
+	if _, err := db.Collection(collection).BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("annotation denormalization failed: %w", err)
+	}
+	return nil
+}
+
+// syncItemAnnotationsQuietly 注解本身已写入成功，同步失败只记录日志，未同步的条目由查询回退与启动修复兜底
+func syncItemAnnotationsQuietly(ctx context.Context, db mongo.Database, itemType scene_audio_route_models.AnnotationItemType, itemIDs ...primitive.ObjectID) {
+	if err := syncItemAnnotations(ctx, db, itemType, itemIDs...); err != nil {
+		log.Printf("同步条目注解字段失败 %s %v: %v", itemType, itemIDs, err)
+		// 清除同步标记，使查询回退到关联注解集合
+		collection, ok := itemType.Collection()
+		if !ok {
+			return
+		}
+		_, _ = db.Collection(collection).UpdateMany(ctx,
+			bson.M{"_id": bson.M{"$in": itemIDs}},
+			bson.M{"$unset": bson.M{AnnotationSyncedField: ""}},
+		)
+	}
+}
+
+// RepairAnnotationDenormalization 为尚未同步的条目补写冗余注解字段，用于首次升级与新扫描入库的条目；
+// 旧版本按全体用户汇总同步过的条目没有按用户字段，同样重新同步
+func RepairAnnotationDenormalization(ctx context.Context, db mongo.Database) error {
+	for itemType, collection := range scene_audio_route_models.AnnotationItemTypes() {
+		repaired := 0
+		for {
+			cursor, err := db.Collection(collection).Find(ctx,
+				bson.M{"$or": bson.A{
+					bson.M{AnnotationSyncedField: bson.M{"$exists": false}},
+					bson.M{userAnnotationsField: bson.M{"$exists": false}},
+				}},
+				options.Find().SetProjection(bson.M{"_id": 1}).SetLimit(annotationRepairBatchSize),
+			)
+			if err != nil {
+				return fmt.Errorf("query unsynced %s failed: %w", itemType, err)
+			}
+			var docs []struct {
+				ID primitive.ObjectID `bson:"_id"`
+			}
+			if err := cursor.All(ctx, &docs); err != nil {
+				return fmt.Errorf("decode unsynced %s failed: %w", itemType, err)
+			}
+			_ = cursor.Close(ctx)
+			if len(docs) == 0 {
+				break
+			}
+
+			ids := make([]primitive.ObjectID, 0, len(docs))
+			for _, doc := range docs {
+				ids = append(ids, doc.ID)
+			}
+			if err := syncItemAnnotations(ctx, db, itemType, ids...); err != nil {
+				return err
+			}
+			repaired += len(ids)
+			if len(docs) < annotationRepairBatchSize {
+				break
+			}
+		}
+		if repaired > 0 {
+			log.Printf("已补写 %s 冗余注解字段 %d 条", itemType, repaired)
+		}
+	}
+	return nil
+}
+
+// annotationFallbackStages 读取当前用户在条目上的冗余注解字段，未同步的条目才按 ID 关联该用户的注解，
+// 已同步条目的关联键为空，只命中索引的空结果；请求不带用户时不附带任何注解字段。
+// prefix 为条目在文档中的路径前缀（如 "media_file."）
+func annotationFallbackStages(ctx context.Context, itemType scene_audio_route_models.AnnotationItemType, prefix string) []bson.D {
+	hidden := bson.A{prefix + userAnnotationsField}
+	userID := domain.UserIDFromContext(ctx)
+	if userID == "" {
+		for _, f := range denormalizedAnnotationFields {
+			hidden = append(hidden, prefix+f.field)
+		}
+		return []bson.D{{{Key: "$unset", Value: hidden}}}
+	}
+
+	// 该用户没有注解时字段解析为缺失，与未播放、未收藏、未评分的表现一致
+	fields := make(bson.D, 0, len(denormalizedAnnotationFields))
+	for _, f := range denormalizedAnnotationFields {
+		fields = append(fields, bson.E{Key: prefix + f.field, Value: bson.D{{Key: "$cond", Value: bson.A{
+			bson.D{{Key: "$gt", Value: bson.A{bson.D{{Key: "$size", Value: "$annotations"}}, 0}}},
+			bson.D{{Key: "$first", Value: "$annotations." + f.field}},
+			"$" + prefix + userAnnotationsField + "." + userID + "." + f.field,
+		}}}})
+	}
+
+	return []bson.D{
+		{{Key: "$addFields", Value: bson.D{{Key: "annotation_lookup_id", Value: bson.D{{Key: "$cond", Value: bson.A{
+			bson.D{{Key: "$gt", Value: bson.A{"$" + prefix + AnnotationSyncedField, nil}}},
+			"$$REMOVE",
+			"$" + prefix + "_id",
+		}}}}}}},
+		{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: domain.CollectionFileEntityAudioSceneAnnotation},
+			{Key: "localField", Value: "annotation_lookup_id"},
+			{Key: "foreignField", Value: "item_id"},
+			{Key: "pipeline", Value: []bson.D{
+				{{Key: "$match", Value: bson.D{{Key: "user_id", Value: userID}, {Key: "item_type", Value: itemType}}}},
+				{{Key: "$limit", Value: 1}},
+			}},
+			{Key: "as", Value: "annotations"},
+		}}},
+		{{Key: "$addFields", Value: fields}},
+		{{Key: "$unset", Value: append(hidden, "annotations", "annotation_lookup_id")}},
+	}
+}
@@ -0,0 +1,138 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ratingSource 重新计算平均分所需的曲目字段
+type ratingSource struct {
+	AlbumID      string `bson:"album_id"`
+	ArtistID     string `bson:"artist_id"`
+	AllArtistIDs []struct {
+		ArtistID string `bson:"artist_id"`
+	} `bson:"all_artist_ids"`
+}
+
+// refreshAverageRatings 按曲目评分重新计算其专辑与全部参与艺术家的平均分；平均分为全库共享数据，
+// 仅起点曲目须对调用方可见
+func refreshAverageRatings(ctx context.Context, db mongo.Database, mediaID primitive.ObjectID) (*scene_audio_route_models.MediaFileRating, error) {
+	var source ratingSource
+	err := db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).FindOne(ctx, bson.D{{Key: "_id", Value: mediaID}, visibleFilter(ctx)}).Decode(&source)
+	if errors.Is(err, driver.ErrNoDocuments) {
+		return nil, scene_audio_route_models.ErrAnnotationItemNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("media query failed: %w", err)
+	}
+
+	result := &scene_audio_route_models.MediaFileRating{AlbumID: source.AlbumID, ArtistID: source.ArtistID}
+	if albumID, err := primitive.ObjectIDFromHex(source.AlbumID); err == nil {
+		avg, err := saveAverageRating(ctx, db, domain.CollectionFileEntityAudioSceneAlbum, albumID,
+			bson.M{"album_id": source.AlbumID})
+		if err != nil {
+			return nil, err
+		}
+		result.AlbumAverageRating = avg
+	}
+
+	artistIDs := []string{source.ArtistID}
+	for _, pair := range source.AllArtistIDs {
+		artistIDs = append(artistIDs, pair.ArtistID)
+	}
+	seen := make(map[string]bool, len(artistIDs))
+	for _, id := range artistIDs {
+		artistID, err := primitive.ObjectIDFromHex(id)
+		if err != nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		avg, err := saveAverageRating(ctx, db, domain.CollectionFileEntityAudioSceneArtist, artistID,
+			bson.M{"$or": bson.A{bson.M{"artist_id": id}, bson.M{"all_artist_ids.artist_id": id}}})
+		if err != nil {
+			return nil, err
+		}
+		if id == source.ArtistID {
+			result.ArtistAverageRating = avg
+		}
+	}
+	return result, nil
+}
+
+// refreshAverageRatingsQuietly 评分已写入时使用，平均分计算失败只记录日志
+func refreshAverageRatingsQuietly(ctx context.Context, db mongo.Database, mediaID primitive.ObjectID) {
+	if _, err := refreshAverageRatings(ctx, db, mediaID); err != nil {
+		log.Printf("重新计算平均评分失败 %s: %v", mediaID.Hex(), err)
+	}
+}
+
+// saveAverageRating 以注解集合为准计算曲目平均分并写回条目，未评分（0分）的曲目不参与计算
+func saveAverageRating(
+	ctx context.Context,
+	db mongo.Database,
+	collection string,
+	itemID primitive.ObjectID,
+	trackFilter bson.M,
+) (float64, error) {
+	cursor, err := db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).Find(ctx, trackFilter,
+		options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return 0, fmt.Errorf("track query failed: %w", err)
+	}
+	var tracks []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &tracks); err != nil {
+		return 0, fmt.Errorf("decode tracks failed: %w", err)
+	}
+	trackIDs := make(bson.A, 0, len(tracks))
+	for _, track := range tracks {
+		trackIDs = append(trackIDs, track.ID)
+	}
+
+	var average float64
+	if len(trackIDs) > 0 {
+		cursor, err := db.Collection(domain.CollectionFileEntityAudioSceneAnnotation).Aggregate(ctx, []bson.D{
+			{{Key: "$match", Value: bson.D{
+				{Key: "item_type", Value: scene_audio_route_models.AnnotationItemMedia},
+				{Key: "item_id", Value: bson.D{{Key: "$in", Value: trackIDs}}},
+				{Key: "rating", Value: bson.D{{Key: "$gt", Value: 0}}},
+			}}},
+			{{Key: "$group", Value: bson.D{
+				{Key: "_id", Value: nil},
+				{Key: "average", Value: bson.D{{Key: "$avg", Value: "$rating"}}},
+			}}},
+		})
+		if err != nil {
+			return 0, fmt.Errorf("average rating aggregate failed: %w", err)
+		}
+		var rows []struct {
+			Average float64 `bson:"average"`
+		}
+		if err := cursor.All(ctx, &rows); err != nil {
+			return 0, fmt.Errorf("decode average rating failed: %w", err)
+		}
+		if len(rows) > 0 {
+			average = math.Round(rows[0].Average*100) / 100
+		}
+	}
+
+	if _, err := db.Collection(collection).UpdateOne(ctx,
+		bson.M{"_id": itemID},
+		bson.M{"$set": bson.M{"average_rating": average}},
+	); err != nil {
+		return 0, fmt.Errorf("save average rating failed: %w", err)
+	}
+	return average, nil
+}
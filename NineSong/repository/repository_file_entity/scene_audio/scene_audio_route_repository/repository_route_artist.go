@@ -2,16 +2,18 @@ package scene_audio_route_repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cache_util"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type artistRepository struct {
@@ -29,59 +31,60 @@ func NewArtistRepository(db mongo.Database, collection string) scene_audio_route
 func (r *artistRepository) GetArtistItems(
 	ctx context.Context,
 	start, end, sort, order, search, starred string,
+) ([]scene_audio_route_models.ArtistMetadata, error) {
+	if starred != "" || isAnnotationField(sortFieldFor(scene_audio_route_models.SortEntityArtists, sort)) {
+		return r.queryArtistItems(ctx, start, end, sort, order, search, starred)
+	}
+
+	key := cache_util.Key("artist", start, end, sort, order, search)
+	artists, err := sharedCatalogPage(ctx, key, func(ctx context.Context) ([]scene_audio_route_models.ArtistMetadata, error) {
+		return r.queryArtistItems(ctx, start, end, sort, order, search, starred)
+	})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]primitive.ObjectID, 0, len(artists))
+	for _, artist := range artists {
+		ids = append(ids, artist.ID)
+	}
+	annotations, err := userAnnotationsFor(ctx, r.db, scene_audio_route_models.AnnotationItemArtist, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range artists {
+		if a, ok := annotations[artists[i].ID]; ok {
+			artists[i].PlayCount, artists[i].PlayCompleteCount = a.PlayCount, a.PlayCompleteCount
+			artists[i].PlayDate = a.PlayDate
+			artists[i].Rating, artists[i].RatedAt = a.Rating, a.RatedAt
+			artists[i].Starred, artists[i].StarredAt = a.Starred, a.StarredAt
+		}
+	}
+	return artists, nil
+}
+
+func (r *artistRepository) queryArtistItems(
+	ctx context.Context,
+	start, end, sort, order, search, starred string,
 ) ([]scene_audio_route_models.ArtistMetadata, error) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 	coll := r.db.Collection(r.collection)
-
-	pipeline := []bson.D{
-		// 使用$lookup但不立即$unwind
-		{
-			{Key: "$lookup", Value: bson.D{
-				{Key: "from", Value: domain.CollectionFileEntityAudioSceneAnnotation},
-				{Key: "let", Value: bson.D{{Key: "artistId", Value: "$_id"}}},
-				{Key: "pipeline", Value: []bson.D{
-					{
-						{Key: "$match", Value: bson.D{
-							{Key: "$expr", Value: bson.D{
-								{Key: "$and", Value: bson.A{
-									bson.D{{Key: "$eq", Value: bson.A{"$item_id", "$$artistId"}}},
-									bson.D{{Key: "$eq", Value: bson.A{"$item_type", "artist"}}},
-								}},
-							}},
-						}},
-					},
-					// 新增：只取第一个注解（避免重复）
-					{{Key: "$limit", Value: 1}},
-				}},
-				{Key: "as", Value: "annotations"},
-			}},
-		},
-		// 修改$unwind阶段
-		{
-			{Key: "$unwind", Value: bson.D{
-				{Key: "path", Value: "$annotations"},
-				{Key: "preserveNullAndEmptyArrays", Value: true}, // 保留无注解的艺术家
-			}},
-		},
-		{
-			{Key: "$addFields", Value: bson.D{
-				{Key: "play_count", Value: "$annotations.play_count"},
-				{Key: "play_date", Value: "$annotations.play_date"},
-				{Key: "rating", Value: "$annotations.rating"},
-				{Key: "starred", Value: "$annotations.starred"},
-				{Key: "starred_at", Value: "$annotations.starred_at"},
-			}},
-		},
+	scopeCond, err := visibleArtistCondition(ctx, r.db)
+	if err != nil {
+		return nil, err
 	}
 
+	// 全文检索条件必须位于首位
+	leadStages, searchCond := splitSearchStage(artistSearch(search))
+	pipeline := append(leadStages, annotationFallbackStages(ctx, scene_audio_route_models.AnnotationItemArtist, "")...)
+
 	// 添加过滤条件
-	if match := buildArtistMatch(search, starred); len(match) > 0 {
+	if match := append(buildArtistMatch(searchCond, starred), scopeCond...); len(match) > 0 {
 		pipeline = append(pipeline, bson.D{{Key: "$match", Value: match}})
 	}
 
 	// 处理特殊排序
-	validatedSort := validateArtistSortField(sort)
+	validatedSort := sortFieldFor(scene_audio_route_models.SortEntityArtists, sort)
 	if validatedSort == "play_date" {
 		pipeline = append(pipeline, bson.D{
 			{Key: "$match", Value: bson.D{
@@ -123,29 +126,20 @@ func (r *artistRepository) GetArtistFilterItemsCount(
 	search, starred string,
 ) (*scene_audio_route_models.ArtistFilterCounts, error) {
 	coll := r.db.Collection(r.collection)
+	scopeCond, err := visibleArtistCondition(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	albumScopeCond, err := visibleAlbumCondition(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
 
-	pipeline := []bson.D{
-		{
-			{Key: "$lookup", Value: bson.D{
-				{Key: "from", Value: domain.CollectionFileEntityAudioSceneAnnotation},
-				{Key: "let", Value: bson.D{{Key: "artistId", Value: "$_id"}}},
-				{Key: "pipeline", Value: []bson.D{
-					{
-						{Key: "$match", Value: bson.D{
-							{Key: "$expr", Value: bson.D{
-								{Key: "$and", Value: bson.A{
-									bson.D{{Key: "$eq", Value: bson.A{"$item_id", "$$artistId"}}},
-									bson.D{{Key: "$eq", Value: bson.A{"$item_type", "artist"}}},
-								}},
-							}},
-						}},
-					},
-				}},
-				{Key: "as", Value: "annotations"},
-			}},
-		},
+	leadStages, searchCond := splitSearchStage(artistSearch(search))
+	pipeline := append(leadStages, annotationFallbackStages(ctx, scene_audio_route_models.AnnotationItemArtist, "")...)
+	pipeline = append(pipeline, []bson.D{
 		{
-			{Key: "$match", Value: buildArtistBaseMatch(search, starred)},
+			{Key: "$match", Value: append(buildArtistBaseMatch(searchCond, starred), scopeCond...)},
 		},
 		{
 			{Key: "$facet", Value: bson.D{
@@ -154,21 +148,23 @@ func (r *artistRepository) GetArtistFilterItemsCount(
 				}},
 				{Key: "starred", Value: []bson.D{
 					{{Key: "$match", Value: bson.D{
-						{Key: "annotations.starred", Value: true},
+						{Key: "starred", Value: true},
 					}}},
 					{{Key: "$count", Value: "count"}},
 				}},
 				{Key: "recent_play", Value: []bson.D{
 					{{Key: "$match", Value: bson.D{
-						{Key: "annotations.play_count", Value: bson.D{
+						{Key: "play_count", Value: bson.D{
 							{Key: "$gt", Value: 0},
 						}},
 					}}},
 					{{Key: "$count", Value: "count"}},
 				}},
+				{Key: "albums", Value: artistOwnedCountFacet(domain.CollectionFileEntityAudioSceneAlbum, albumScopeCond...)},
+				{Key: "songs", Value: artistOwnedCountFacet(domain.CollectionFileEntityAudioSceneMediaFile, visibleFilter(ctx))},
 			}},
 		},
-	}
+	}...)
 
 	cursor, err := coll.Aggregate(ctx, pipeline)
 	if err != nil {
@@ -185,6 +181,8 @@ func (r *artistRepository) GetArtistFilterItemsCount(
 		Total      []map[string]int `bson:"total"`
 		Starred    []map[string]int `bson:"starred"`
 		RecentPlay []map[string]int `bson:"recent_play"`
+		Albums     []map[string]int `bson:"albums"`
+		Songs      []map[string]int `bson:"songs"`
 	}
 
 	if err := cursor.All(ctx, &result); err != nil {
@@ -196,24 +194,52 @@ func (r *artistRepository) GetArtistFilterItemsCount(
 		counts.Total = extractCount(result[0].Total)
 		counts.Starred = extractCount(result[0].Starred)
 		counts.RecentPlay = extractCount(result[0].RecentPlay)
+		counts.Albums = extractCount(result[0].Albums)
+		counts.Songs = extractCount(result[0].Songs)
 	}
 
 	return counts, nil
 }
 
-// Helper functions
-func buildArtistMatch(search, starred string) bson.D {
-	filter := bson.D{}
+// artistOwnedCountFacet 统计过滤后艺术家名下（按主艺术家 artist_id）的条目总数，每个条目只计入一位艺术家
+func artistOwnedCountFacet(collection string, extra ...bson.E) []bson.D {
+	match := bson.D{{Key: "$expr", Value: bson.D{{Key: "$eq", Value: bson.A{"$artist_id", "$$artist_id"}}}}}
+	match = append(match, extra...)
+	return []bson.D{
+		{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: collection},
+			{Key: "let", Value: bson.D{{Key: "artist_id", Value: bson.D{{Key: "$toString", Value: "$_id"}}}}},
+			{Key: "pipeline", Value: []bson.D{
+				{{Key: "$match", Value: match}},
+				{{Key: "$count", Value: "count"}},
+			}},
+			{Key: "as", Value: "owned"},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: nil},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: bson.D{{Key: "$sum", Value: "$owned.count"}}}}},
+		}}},
+		{{Key: "$project", Value: bson.D{{Key: "_id", Value: 0}, {Key: "count", Value: 1}}}},
+	}
+}
 
-	if search != "" {
-		filter = append(filter, bson.E{
-			Key: "name",
-			Value: bson.D{
-				{Key: "$regex", Value: search},
-				{Key: "$options", Value: "i"},
-			},
-		})
+// Helper functions
+// artistSearch 名称或别名任一匹配，匹配方式取艺术家接口的搜索策略
+func artistSearch(search string) (searchStrategy, bson.D) {
+	strategy := searchStrategyFor(scene_audio_route_models.SearchEndpointArtists)
+	if search == "" {
+		return strategy, nil
 	}
+	fields := []string{"name", "aliases.name"}
+	if fieldStrategy, cond, ok := fieldSearch(search, fields); ok {
+		return fieldStrategy, cond
+	}
+	return strategy, strategy.condition(search, fields)
+}
+
+func buildArtistMatch(searchCond bson.D, starred string) bson.D {
+	filter := bson.D{}
+	filter = append(filter, searchCond...)
 
 	if starred != "" {
 		if isStarred, err := strconv.ParseBool(starred); err == nil {
@@ -224,47 +250,8 @@ func buildArtistMatch(search, starred string) bson.D {
 	return filter
 }
 
-func buildArtistBaseMatch(search, starred string) bson.D {
-	return buildArtistMatch(search, starred)
-}
-
-func validateArtistSortField(sort string) string {
-	sortMappings := map[string]string{
-		"name":        "order_artist_name",
-		"album_count": "album_count",
-		"song_count":  "song_count",
-		"play_count":  "play_count",
-		"play_date":   "play_date",
-		"rating":      "rating",
-		"starred_at":  "starred_at",
-		"size":        "size",
-		"created_at":  "created_at",
-		"updated_at":  "updated_at",
-	}
-
-	lowerSort := strings.ToLower(sort)
-	if mappedField, exists := sortMappings[lowerSort]; exists {
-		return mappedField
-	}
-
-	validSortFields := map[string]bool{
-		"order_artist_name": true,
-		"album_count":       true,
-		"song_count":        true,
-		"play_count":        true,
-		"play_date":         true,
-		"rating":            true,
-		"starred_at":        true,
-		"size":              true,
-		"created_at":        true,
-		"updated_at":        true,
-	}
-
-	if validSortFields[lowerSort] {
-		return lowerSort
-	}
-
-	return "_id"
+func buildArtistBaseMatch(searchCond bson.D, starred string) bson.D {
+	return buildArtistMatch(searchCond, starred)
 }
 
 // 修复排序稳定性
@@ -302,3 +289,38 @@ func buildArtistPaginationStage(start, end string) []bson.D {
 	}
 	return stages
 }
+
+// GetArtist 按ID返回单个艺术家，附带当前用户的注解；受媒体库权限限制时不可见的艺术家视为不存在
+func (r *artistRepository) GetArtist(ctx context.Context, artistId string) (*scene_audio_route_models.ArtistMetadata, error) {
+	objID, err := primitive.ObjectIDFromHex(artistId)
+	if err != nil {
+		return nil, errors.New("invalid artist id format")
+	}
+	scopeCond, err := visibleArtistCondition(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline := []bson.D{
+		{{Key: "$match", Value: bson.D{{Key: "_id", Value: objID}}}},
+	}
+	if len(scopeCond) > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: scopeCond}})
+	}
+	pipeline = append(pipeline, annotationFallbackStages(ctx, scene_audio_route_models.AnnotationItemArtist, "")...)
+
+	cursor, err := r.db.Collection(r.collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("artist query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var artists []scene_audio_route_models.ArtistMetadata
+	if err := cursor.All(ctx, &artists); err != nil {
+		return nil, fmt.Errorf("decode artist failed: %w", err)
+	}
+	if len(artists) == 0 {
+		return nil, fmt.Errorf("artist %w", domain.ErrNotFound)
+	}
+	return &artists[0], nil
+}
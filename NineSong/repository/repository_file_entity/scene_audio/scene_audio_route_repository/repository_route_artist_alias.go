@@ -0,0 +1,479 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_musicbrainz/scene_audio_musicbrainz_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/deletion_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type artistAliasRepository struct {
+	db          mongo.Database
+	collection  string
+	musicBrainz scene_audio_musicbrainz_interface.MusicBrainzClient
+}
+
+func NewArtistAliasRepository(
+	db mongo.Database,
+	collection string,
+	musicBrainz scene_audio_musicbrainz_interface.MusicBrainzClient,
+) scene_audio_route_interface.ArtistAliasRepository {
+	return &artistAliasRepository{
+		db:          db,
+		collection:  collection,
+		musicBrainz: musicBrainz,
+	}
+}
+
+type aliasArtist struct {
+	ID              primitive.ObjectID                     `bson:"_id"`
+	Name            string                                 `bson:"name"`
+	MBZArtistID     string                                 `bson:"mbz_artist_id"`
+	Aliases         []scene_audio_route_models.ArtistAlias `bson:"aliases"`
+	AlbumCount      int                                    `bson:"album_count"`
+	GuestAlbumCount int                                    `bson:"guest_album_count"`
+	SongCount       int                                    `bson:"song_count"`
+	GuestSongCount  int                                    `bson:"guest_song_count"`
+	CueCount        int                                    `bson:"cue_count"`
+	GuestCueCount   int                                    `bson:"guest_cue_count"`
+	Size            int                                    `bson:"size"`
+}
+
+// 艺术家ID及名称在各集合中的引用字段
+var artistScalarRefs = []struct {
+	collection string
+	idField    string
+	nameField  string
+}{
+	{domain.CollectionFileEntityAudioSceneMediaFile, "artist_id", "artist"},
+	{domain.CollectionFileEntityAudioSceneMediaFile, "album_artist_id", "album_artist"},
+	{domain.CollectionFileEntityAudioSceneAlbum, "artist_id", "artist"},
+	{domain.CollectionFileEntityAudioSceneAlbum, "album_artist_id", "album_artist"},
+	{domain.CollectionFileEntityAudioSceneMediaFileCue, "performer_id", "performer"},
+}
+
+var artistArrayRefs = []struct {
+	collection string
+	field      string
+	idKey      string
+	nameKey    string
+}{
+	{domain.CollectionFileEntityAudioSceneMediaFile, "all_artist_ids", "artist_id", "artist_name"},
+	{domain.CollectionFileEntityAudioSceneMediaFile, "all_album_artist_ids", "artist_id", "artist_name"},
+	{domain.CollectionFileEntityAudioSceneAlbum, "all_artist_ids", "artist_id", "artist_name"},
+	{domain.CollectionFileEntityAudioSceneAlbum, "all_album_artist_ids", "artist_id", "artist_name"},
+	{domain.CollectionFileEntityAudioSceneMediaFileCue, "all_artist_ids", "artist_id", "artist_name"},
+	{domain.CollectionFileEntityAudioSceneMediaFileCue, "cue_tracks", "track_performer_id", "track_performer"},
+}
+
+func (r *artistAliasRepository) GetArtistAliases(
+	ctx context.Context,
+	artistId string,
+) ([]scene_audio_route_models.ArtistAlias, error) {
+	artist, err := r.getArtist(ctx, artistId)
+	if err != nil {
+		return nil, err
+	}
+	return nonNilAliases(artist.Aliases), nil
+}
+
+func (r *artistAliasRepository) AddArtistAlias(
+	ctx context.Context,
+	artistId string,
+	alias scene_audio_route_models.ArtistAlias,
+) ([]scene_audio_route_models.ArtistAlias, error) {
+	artist, err := r.getArtist(ctx, artistId)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(artist.Name, alias.Name) {
+		return nil, errors.New("alias is the same as artist name")
+	}
+	if err := r.checkAliasConflict(ctx, artist.ID, alias.Name); err != nil {
+		return nil, err
+	}
+
+	// 同名别名（忽略大小写）只保留一条，以最新来源为准
+	aliases := make([]scene_audio_route_models.ArtistAlias, 0, len(artist.Aliases)+1)
+	for _, a := range artist.Aliases {
+		if !strings.EqualFold(a.Name, alias.Name) {
+			aliases = append(aliases, a)
+		}
+	}
+	aliases = append(aliases, alias)
+
+	return r.saveAliases(ctx, artist.ID, aliases)
+}
+
+func (r *artistAliasRepository) RemoveArtistAlias(
+	ctx context.Context,
+	artistId string,
+	name string,
+) ([]scene_audio_route_models.ArtistAlias, error) {
+	artist, err := r.getArtist(ctx, artistId)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases := make([]scene_audio_route_models.ArtistAlias, 0, len(artist.Aliases))
+	for _, a := range artist.Aliases {
+		if !strings.EqualFold(a.Name, name) {
+			aliases = append(aliases, a)
+		}
+	}
+	if len(aliases) == len(artist.Aliases) {
+		return nil, fmt.Errorf("alias %w", domain.ErrNotFound)
+	}
+
+	return r.saveAliases(ctx, artist.ID, aliases)
+}
+
+func (r *artistAliasRepository) SyncMusicBrainzAliases(
+	ctx context.Context,
+	artistId string,
+) ([]scene_audio_route_models.ArtistAlias, error) {
+	artist, err := r.getArtist(ctx, artistId)
+	if err != nil {
+		return nil, err
+	}
+	if artist.MBZArtistID == "" {
+		return nil, errors.New("artist has no musicbrainz id")
+	}
+
+	names, err := r.musicBrainz.GetArtistAliases(ctx, artist.MBZArtistID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch musicbrainz aliases failed: %w", err)
+	}
+
+	aliases := nonNilAliases(artist.Aliases)
+	for _, name := range names {
+		if strings.EqualFold(name, artist.Name) || containsAlias(aliases, name) {
+			continue
+		}
+		if err := r.checkAliasConflict(ctx, artist.ID, name); err != nil {
+			log.Printf("跳过与其它艺术家冲突的别名[%s]: %v", name, err)
+			continue
+		}
+		aliases = append(aliases, scene_audio_route_models.ArtistAlias{
+			Name:   name,
+			Source: scene_audio_route_models.ArtistAliasSourceMusicBrainz,
+		})
+	}
+
+	return r.saveAliases(ctx, artist.ID, aliases)
+}
+
+func (r *artistAliasRepository) MergeArtists(
+	ctx context.Context,
+	sourceId string,
+	targetId string,
+) (*scene_audio_route_models.ArtistMergeResult, error) {
+	source, err := r.getArtist(ctx, sourceId)
+	if err != nil {
+		return nil, err
+	}
+	target, err := r.getArtist(ctx, targetId)
+	if err != nil {
+		return nil, err
+	}
+
+	srcID, tgtID := source.ID.Hex(), target.ID.Hex()
+	result := &scene_audio_route_models.ArtistMergeResult{
+		SourceID: srcID,
+		TargetID: tgtID,
+	}
+
+	// 先统计受影响文档数，同一文档可能命中多个引用字段
+	counts, err := r.countReferences(ctx, srcID)
+	if err != nil {
+		return nil, err
+	}
+	result.MediaFilesUpdated = counts[domain.CollectionFileEntityAudioSceneMediaFile]
+	result.AlbumsUpdated = counts[domain.CollectionFileEntityAudioSceneAlbum]
+	result.CueFilesUpdated = counts[domain.CollectionFileEntityAudioSceneMediaFileCue]
+
+	for _, ref := range artistScalarRefs {
+		coll := r.db.Collection(ref.collection)
+		if _, err := coll.UpdateMany(ctx,
+			bson.M{ref.idField: srcID},
+			bson.M{"$set": bson.M{ref.idField: tgtID}},
+		); err != nil {
+			return nil, fmt.Errorf("reassign %s.%s failed: %w", ref.collection, ref.idField, err)
+		}
+		if _, err := coll.UpdateMany(ctx,
+			bson.M{ref.idField: tgtID, ref.nameField: source.Name},
+			bson.M{"$set": bson.M{ref.nameField: target.Name}},
+		); err != nil {
+			return nil, fmt.Errorf("rename %s.%s failed: %w", ref.collection, ref.nameField, err)
+		}
+	}
+
+	for _, ref := range artistArrayRefs {
+		opts := options.Update().SetArrayFilters(options.ArrayFilters{
+			Filters: []interface{}{bson.M{"a." + ref.idKey: srcID}},
+		})
+		if _, err := r.db.Collection(ref.collection).UpdateMany(ctx,
+			bson.M{ref.field + "." + ref.idKey: srcID},
+			bson.M{"$set": bson.M{
+				ref.field + ".$[a]." + ref.idKey:   tgtID,
+				ref.field + ".$[a]." + ref.nameKey: target.Name,
+			}},
+			opts,
+		); err != nil {
+			return nil, fmt.Errorf("reassign %s.%s failed: %w", ref.collection, ref.field, err)
+		}
+	}
+
+	merged, err := r.mergeAnnotations(ctx, source.ID, target.ID)
+	if err != nil {
+		return nil, err
+	}
+	result.AnnotationMerged = merged
+
+	// 源艺术家名称及其别名并入目标别名
+	aliases := nonNilAliases(target.Aliases)
+	candidates := append([]scene_audio_route_models.ArtistAlias{{Name: source.Name, Source: scene_audio_route_models.ArtistAliasSourceMerge}}, source.Aliases...)
+	for _, a := range candidates {
+		if a.Name == "" || strings.EqualFold(a.Name, target.Name) || containsAlias(aliases, a.Name) {
+			continue
+		}
+		aliases = append(aliases, a)
+	}
+
+	_, err = r.db.Collection(r.collection).UpdateOne(ctx,
+		bson.M{"_id": target.ID},
+		bson.M{
+			"$set": bson.M{"aliases": aliases},
+			"$inc": bson.M{
+				"album_count":       source.AlbumCount,
+				"guest_album_count": source.GuestAlbumCount,
+				"song_count":        source.SongCount,
+				"guest_song_count":  source.GuestSongCount,
+				"cue_count":         source.CueCount,
+				"guest_cue_count":   source.GuestCueCount,
+				"size":              source.Size,
+			},
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("update target artist failed: %w", err)
+	}
+	result.Aliases = aliases
+
+	if _, err := deletion_util.DeleteByIDs(ctx, r.db, r.collection, "artist", []primitive.ObjectID{source.ID}); err != nil {
+		return nil, fmt.Errorf("delete source artist failed: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *artistAliasRepository) countReferences(ctx context.Context, artistId string) (map[string]int64, error) {
+	conditions := make(map[string]bson.A)
+	for _, ref := range artistScalarRefs {
+		conditions[ref.collection] = append(conditions[ref.collection], bson.M{ref.idField: artistId})
+	}
+	for _, ref := range artistArrayRefs {
+		conditions[ref.collection] = append(conditions[ref.collection], bson.M{ref.field + "." + ref.idKey: artistId})
+	}
+
+	counts := make(map[string]int64, len(conditions))
+	for collection, or := range conditions {
+		count, err := r.db.Collection(collection).CountDocuments(ctx, bson.M{"$or": or})
+		if err != nil {
+			return nil, fmt.Errorf("count %s references failed: %w", collection, err)
+		}
+		counts[collection] = count
+	}
+	return counts, nil
+}
+
+// mergeAnnotations 目标无注释时直接改指向，否则累加播放统计并保留较高评分与收藏状态
+func (r *artistAliasRepository) mergeAnnotations(ctx context.Context, sourceID, targetID primitive.ObjectID) (bool, error) {
+	coll := r.db.Collection(domain.CollectionFileEntityAudioSceneAnnotation)
+
+	cursor, err := coll.Find(ctx, bson.M{"item_id": sourceID, "item_type": scene_audio_route_models.AnnotationItemArtist})
+	if err != nil {
+		return false, fmt.Errorf("annotation query failed: %w", err)
+	}
+	var sources []scene_audio_route_models.AnnotationMetadata
+	if err := cursor.All(ctx, &sources); err != nil {
+		return false, fmt.Errorf("decode annotation failed: %w", err)
+	}
+	_ = cursor.Close(ctx)
+
+	for _, src := range sources {
+		targetFilter := bson.M{"item_id": targetID, "item_type": scene_audio_route_models.AnnotationItemArtist, "user_id": src.UserID}
+
+		var tgt scene_audio_route_models.AnnotationMetadata
+		err := coll.FindOne(ctx, targetFilter).Decode(&tgt)
+		if errors.Is(err, driver.ErrNoDocuments) {
+			if _, err := coll.UpdateOne(ctx, bson.M{"_id": src.ID}, bson.M{"$set": bson.M{"item_id": targetID}}); err != nil {
+				return false, fmt.Errorf("reassign annotation failed: %w", err)
+			}
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("annotation query failed: %w", err)
+		}
+
+		set := bson.M{"starred": tgt.Starred || src.Starred}
+		if !tgt.Starred && src.Starred {
+			set["starred_at"] = src.StarredAt
+		}
+		if src.Rating > tgt.Rating {
+			set["rating"] = src.Rating
+			set["rated_at"] = src.RatedAt
+		}
+		if src.PlayDate.After(tgt.PlayDate) {
+			set["play_date"] = src.PlayDate
+		}
+		if !src.FirstPlayDate.IsZero() && (tgt.FirstPlayDate.IsZero() || src.FirstPlayDate.Before(tgt.FirstPlayDate)) {
+			set["first_play_date"] = src.FirstPlayDate
+		}
+
+		if _, err := coll.UpdateOne(ctx, bson.M{"_id": tgt.ID}, bson.M{
+			"$set": set,
+			"$inc": bson.M{
+				"play_count":          src.PlayCount,
+				"play_complete_count": src.PlayCompleteCount,
+			},
+		}); err != nil {
+			return false, fmt.Errorf("merge annotation failed: %w", err)
+		}
+		if _, err := coll.DeleteOne(ctx, bson.M{"_id": src.ID}); err != nil {
+			return false, fmt.Errorf("delete merged annotation failed: %w", err)
+		}
+	}
+
+	if len(sources) > 0 {
+		syncItemAnnotationsQuietly(ctx, r.db, scene_audio_route_models.AnnotationItemArtist, sourceID, targetID)
+	}
+	return len(sources) > 0, nil
+}
+
+func (r *artistAliasRepository) getArtist(ctx context.Context, artistId string) (*aliasArtist, error) {
+	objID, err := primitive.ObjectIDFromHex(artistId)
+	if err != nil {
+		return nil, errors.New("invalid artist id format")
+	}
+	visible, err := itemVisible(ctx, r.db, scene_audio_route_models.AnnotationItemArtist, objID)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, fmt.Errorf("artist %w", domain.ErrNotFound)
+	}
+
+	var artist aliasArtist
+	if err := r.db.Collection(r.collection).FindOne(ctx, bson.M{"_id": objID}).Decode(&artist); err != nil {
+		if errors.Is(err, driver.ErrNoDocuments) {
+			return nil, fmt.Errorf("artist %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("artist query failed: %w", err)
+	}
+	return &artist, nil
+}
+
+// checkAliasConflict 别名不能是其它艺术家的名称或别名，否则归一结果不确定
+func (r *artistAliasRepository) checkAliasConflict(ctx context.Context, artistID primitive.ObjectID, name string) error {
+	pattern := primitive.Regex{Pattern: "^" + regexp.QuoteMeta(name) + "$", Options: "i"}
+	count, err := r.db.Collection(r.collection).CountDocuments(ctx, bson.M{
+		"_id": bson.M{"$ne": artistID},
+		"$or": bson.A{
+			bson.M{"name": pattern},
+			bson.M{"aliases.name": pattern},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("alias conflict check failed: %w", err)
+	}
+	if count > 0 {
+		return scene_audio_route_models.ErrArtistAliasConflict
+	}
+	return nil
+}
+
+func (r *artistAliasRepository) saveAliases(
+	ctx context.Context,
+	artistID primitive.ObjectID,
+	aliases []scene_audio_route_models.ArtistAlias,
+) ([]scene_audio_route_models.ArtistAlias, error) {
+	if _, err := r.db.Collection(r.collection).UpdateOne(ctx,
+		bson.M{"_id": artistID},
+		bson.M{"$set": bson.M{"aliases": aliases}},
+	); err != nil {
+		return nil, fmt.Errorf("update aliases failed: %w", err)
+	}
+	return aliases, nil
+}
+
+// findAliasArtistIDs 返回别名匹配搜索词的艺术家ID，用于专辑与曲目搜索；匹配方式与调用方的搜索策略一致
+func findAliasArtistIDs(ctx context.Context, db mongo.Database, strategy searchStrategy, search string) []string {
+	if search == "" {
+		return nil
+	}
+
+	cursor, err := db.Collection(domain.CollectionFileEntityAudioSceneArtist).Find(ctx,
+		strategy.condition(search, []string{"aliases.name"}),
+		options.Find().SetProjection(bson.M{"_id": 1}).SetLimit(100),
+	)
+	if err != nil {
+		log.Printf("艺术家别名查询失败: %v", err)
+		return nil
+	}
+	defer cursor.Close(ctx)
+
+	var artists []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &artists); err != nil {
+		log.Printf("艺术家别名解码失败: %v", err)
+		return nil
+	}
+
+	ids := make([]string, 0, len(artists))
+	for _, a := range artists {
+		ids = append(ids, a.ID.Hex())
+	}
+	return ids
+}
+
+// aliasArtistBranches 别名命中的艺术家作为搜索条件的附加分支
+func aliasArtistBranches(ids []string) []bson.D {
+	if len(ids) == 0 {
+		return nil
+	}
+	return []bson.D{
+		{{Key: "artist_id", Value: bson.D{{Key: "$in", Value: ids}}}},
+		{{Key: "all_artist_ids.artist_id", Value: bson.D{{Key: "$in", Value: ids}}}},
+	}
+}
+
+func containsAlias(aliases []scene_audio_route_models.ArtistAlias, name string) bool {
+	for _, a := range aliases {
+		if strings.EqualFold(a.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func nonNilAliases(aliases []scene_audio_route_models.ArtistAlias) []scene_audio_route_models.ArtistAlias {
+	if aliases == nil {
+		return []scene_audio_route_models.ArtistAlias{}
+	}
+	return aliases
+}
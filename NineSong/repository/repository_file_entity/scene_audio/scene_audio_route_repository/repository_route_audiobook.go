@@ -0,0 +1,324 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type audiobookRepository struct {
+	db mongo.Database
+}
+
+func NewAudiobookRepository(db mongo.Database) scene_audio_route_interface.AudiobookRepository {
+	return &audiobookRepository{db: db}
+}
+
+func (r *audiobookRepository) GetAudiobooks(
+	ctx context.Context,
+	userId, sort string,
+	start, count int,
+) ([]scene_audio_route_models.Audiobook, int, error) {
+	match, err := r.bookTrackMatch(ctx)
+	if err != nil || match == nil {
+		return []scene_audio_route_models.Audiobook{}, 0, err
+	}
+
+	if sort == scene_audio_route_models.AudiobookSortRecent {
+		return r.recentAudiobooks(ctx, userId, match, start, count)
+	}
+
+	page := []bson.D{{{Key: "$skip", Value: start}}}
+	if count > 0 {
+		page = append(page, bson.D{{Key: "$limit", Value: count}})
+	}
+	pipeline := append(bookGroupPipeline(match),
+		bson.D{{Key: "$sort", Value: bson.D{
+			{Key: "order_author", Value: 1},
+			{Key: "year", Value: 1},
+			{Key: "order_title", Value: 1},
+			{Key: "_id", Value: 1},
+		}}},
+		bson.D{{Key: "$facet", Value: bson.D{
+			{Key: "total", Value: []bson.D{{{Key: "$count", Value: "count"}}}},
+			{Key: "books", Value: page},
+		}}},
+	)
+
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, fmt.Errorf("audiobooks query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var result []struct {
+		Total []struct {
+			Count int `bson:"count"`
+		} `bson:"total"`
+		Books []scene_audio_route_models.Audiobook `bson:"books"`
+	}
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, 0, fmt.Errorf("decode error: %w", err)
+	}
+	if len(result) == 0 || len(result[0].Total) == 0 {
+		return []scene_audio_route_models.Audiobook{}, 0, nil
+	}
+
+	books := result[0].Books
+	if err := r.attachProgress(ctx, userId, books); err != nil {
+		return nil, 0, err
+	}
+	return books, result[0].Total[0].Count, nil
+}
+
+// recentAudiobooks 按进度更新时间倒序，进度所属的书已移出有声书库或不可访问时跳过
+func (r *audiobookRepository) recentAudiobooks(
+	ctx context.Context,
+	userId string,
+	match bson.D,
+	start, count int,
+) ([]scene_audio_route_models.Audiobook, int, error) {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneAudiobookProgress).Find(ctx,
+		bson.M{"user_id": userId},
+		options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}}),
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("find operation failed: %w", err)
+	}
+	var progresses []scene_audio_route_models.AudiobookProgress
+	if err := cursor.All(ctx, &progresses); err != nil {
+		return nil, 0, fmt.Errorf("decode error: %w", err)
+	}
+	if len(progresses) == 0 {
+		return []scene_audio_route_models.Audiobook{}, 0, nil
+	}
+
+	bookIDs := make(bson.A, 0, len(progresses))
+	for _, progress := range progresses {
+		bookIDs = append(bookIDs, progress.BookID)
+	}
+	match = append(match, bson.E{Key: "album_id", Value: bson.D{{Key: "$in", Value: bookIDs}}})
+	grouped, err := r.groupBooks(ctx, match)
+	if err != nil {
+		return nil, 0, err
+	}
+	byID := make(map[string]scene_audio_route_models.Audiobook, len(grouped))
+	for _, book := range grouped {
+		byID[book.ID] = book
+	}
+
+	books := make([]scene_audio_route_models.Audiobook, 0, len(grouped))
+	for i := range progresses {
+		book, ok := byID[progresses[i].BookID]
+		if !ok {
+			continue
+		}
+		book.Progress = &progresses[i]
+		books = append(books, book)
+	}
+
+	total := len(books)
+	if start >= total {
+		return []scene_audio_route_models.Audiobook{}, total, nil
+	}
+	end := total
+	if count > 0 && start+count < total {
+		end = start + count
+	}
+	return books[start:end], total, nil
+}
+
+func (r *audiobookRepository) GetAudiobook(ctx context.Context, userId, id string) (*scene_audio_route_models.Audiobook, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return nil, scene_audio_route_models.ErrInvalidAudiobook
+	}
+	match, err := r.bookTrackMatch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if match == nil {
+		return nil, fmt.Errorf("audiobook %w", domain.ErrNotFound)
+	}
+	match = append(match, bson.E{Key: "album_id", Value: id})
+
+	books, err := r.groupBooks(ctx, match)
+	if err != nil {
+		return nil, err
+	}
+	if len(books) == 0 {
+		return nil, fmt.Errorf("audiobook %w", domain.ErrNotFound)
+	}
+	book := books[0]
+
+	// 文件名中的数字按数值比较，"2.mp3" 排在 "10.mp3" 之前
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).Find(ctx, match,
+		options.Find().
+			SetSort(bson.D{{Key: "disc_number", Value: 1}, {Key: "track_number", Value: 1}, {Key: "path", Value: 1}}).
+			SetCollation(&options.Collation{Locale: "en", NumericOrdering: true}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("audiobook tracks query failed: %w", err)
+	}
+	book.Tracks = make([]scene_audio_route_models.MediaFileMetadata, 0, book.TrackCount)
+	if err := cursor.All(ctx, &book.Tracks); err != nil {
+		return nil, fmt.Errorf("decode error: %w", err)
+	}
+
+	var progress scene_audio_route_models.AudiobookProgress
+	err = r.db.Collection(domain.CollectionFileEntityAudioSceneAudiobookProgress).
+		FindOne(ctx, bson.M{"user_id": userId, "book_id": id}).Decode(&progress)
+	switch {
+	case err == nil:
+		book.Progress = &progress
+	case !errors.Is(err, driver.ErrNoDocuments):
+		return nil, fmt.Errorf("find one error: %w", err)
+	}
+	return &book, nil
+}
+
+func (r *audiobookRepository) SaveProgress(
+	ctx context.Context,
+	progress scene_audio_route_models.AudiobookProgress,
+) (*scene_audio_route_models.AudiobookProgress, error) {
+	progress.UpdatedAt = time.Now().UTC()
+	coll := r.db.Collection(domain.CollectionFileEntityAudioSceneAudiobookProgress)
+	_, err := coll.UpdateOne(ctx,
+		bson.M{"user_id": progress.UserID, "book_id": progress.BookID},
+		bson.M{
+			"$set": bson.M{
+				"media_file_id": progress.MediaFileID,
+				"position":      progress.Position,
+				"finished":      progress.Finished,
+				"updated_at":    progress.UpdatedAt,
+			},
+			"$setOnInsert": bson.M{"_id": primitive.NewObjectID()},
+		},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("upsert failed: %w", err)
+	}
+
+	var saved scene_audio_route_models.AudiobookProgress
+	if err := coll.FindOne(ctx, bson.M{"user_id": progress.UserID, "book_id": progress.BookID}).Decode(&saved); err != nil {
+		return nil, fmt.Errorf("find one error: %w", err)
+	}
+	return &saved, nil
+}
+
+func (r *audiobookRepository) DeleteProgress(ctx context.Context, userId, bookId string) (bool, error) {
+	deleted, err := r.db.Collection(domain.CollectionFileEntityAudioSceneAudiobookProgress).
+		DeleteOne(ctx, bson.M{"user_id": userId, "book_id": bookId})
+	if err != nil {
+		return false, fmt.Errorf("delete failed: %w", err)
+	}
+	return deleted > 0, nil
+}
+
+// bookTrackMatch 有声书库中调用方可见的曲目，没有可访问的有声书库时返回 nil
+func (r *audiobookRepository) bookTrackMatch(ctx context.Context) (bson.D, error) {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityFolderInfo).Find(ctx, bson.M{
+		"folder_type": int(domain_file_entity.MusicLibrary),
+		"audiobook":   true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("audiobook libraries query failed: %w", err)
+	}
+	var folders []domain_file_entity.LibraryFolderMetadata
+	if err := cursor.All(ctx, &folders); err != nil {
+		return nil, fmt.Errorf("decode error: %w", err)
+	}
+
+	scope := domain.FolderScopeFromContext(ctx)
+	ids := make(bson.A, 0, len(folders))
+	for _, folder := range folders {
+		if scope.Allows(folder.ID.Hex()) {
+			ids = append(ids, folder.ID.Hex())
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return bson.D{
+		{Key: "folder_id", Value: bson.D{{Key: "$in", Value: ids}}},
+		{Key: "album_id", Value: bson.D{{Key: "$nin", Value: bson.A{"", nil}}}},
+		visibleFilter(ctx),
+	}, nil
+}
+
+func (r *audiobookRepository) groupBooks(ctx context.Context, match bson.D) ([]scene_audio_route_models.Audiobook, error) {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).Aggregate(ctx, bookGroupPipeline(match))
+	if err != nil {
+		return nil, fmt.Errorf("audiobooks query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	books := make([]scene_audio_route_models.Audiobook, 0)
+	if err := cursor.All(ctx, &books); err != nil {
+		return nil, fmt.Errorf("decode error: %w", err)
+	}
+	return books, nil
+}
+
+// bookGroupPipeline 按专辑汇总曲目为书，order_* 字段仅用于排序
+func bookGroupPipeline(match bson.D) []bson.D {
+	return []bson.D{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$album_id"},
+			{Key: "title", Value: bson.D{{Key: "$first", Value: "$album"}}},
+			{Key: "author", Value: bson.D{{Key: "$first", Value: "$album_artist"}}},
+			{Key: "author_id", Value: bson.D{{Key: "$first", Value: "$album_artist_id"}}},
+			{Key: "order_title", Value: bson.D{{Key: "$first", Value: "$order_album_name"}}},
+			{Key: "order_author", Value: bson.D{{Key: "$first", Value: "$order_album_artist_name"}}},
+			{Key: "year", Value: bson.D{{Key: "$max", Value: "$year"}}},
+			{Key: "has_cover_art", Value: bson.D{{Key: "$max", Value: "$has_cover_art"}}},
+			{Key: "track_count", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "chapter_count", Value: bson.D{{Key: "$sum", Value: bson.D{{Key: "$max", Value: bson.A{
+				1, bson.D{{Key: "$size", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$chapters", bson.A{}}}}}},
+			}}}}}},
+			{Key: "duration", Value: bson.D{{Key: "$sum", Value: "$duration"}}},
+		}}},
+		// 曲目时长按纳秒存储，全书时长与收听进度一样按秒返回
+		{{Key: "$set", Value: bson.D{{Key: "duration", Value: bson.D{{Key: "$divide", Value: bson.A{"$duration", float64(time.Second)}}}}}}},
+	}
+}
+
+func (r *audiobookRepository) attachProgress(ctx context.Context, userId string, books []scene_audio_route_models.Audiobook) error {
+	if len(books) == 0 {
+		return nil
+	}
+	ids := make(bson.A, 0, len(books))
+	for _, book := range books {
+		ids = append(ids, book.ID)
+	}
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneAudiobookProgress).Find(ctx,
+		bson.M{"user_id": userId, "book_id": bson.M{"$in": ids}})
+	if err != nil {
+		return fmt.Errorf("find operation failed: %w", err)
+	}
+	var progresses []scene_audio_route_models.AudiobookProgress
+	if err := cursor.All(ctx, &progresses); err != nil {
+		return fmt.Errorf("decode error: %w", err)
+	}
+
+	byBook := make(map[string]*scene_audio_route_models.AudiobookProgress, len(progresses))
+	for i := range progresses {
+		byBook[progresses[i].BookID] = &progresses[i]
+	}
+	for i := range books {
+		books[i].Progress = byBook[books[i].ID]
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type bookmarkRepository struct {
+	db mongo.Database
+}
+
+func NewBookmarkRepository(db mongo.Database) scene_audio_route_interface.BookmarkRepository {
+	return &bookmarkRepository{db: db}
+}
+
+func (r *bookmarkRepository) GetBookmarks(
+	ctx context.Context,
+	userId, itemType string,
+	start, count int,
+) ([]scene_audio_route_models.Bookmark, int, error) {
+	match := bson.D{{Key: "user_id", Value: userId}}
+	if itemType != "" {
+		match = append(match, bson.E{Key: "item_type", Value: itemType})
+	}
+
+	page := []bson.D{{{Key: "$skip", Value: start}}}
+	if count > 0 {
+		page = append(page, bson.D{{Key: "$limit", Value: count}})
+	}
+	pipeline := append(r.itemPipeline(ctx, userId, match),
+		bson.D{{Key: "$facet", Value: bson.D{
+			{Key: "total", Value: []bson.D{{{Key: "$count", Value: "count"}}}},
+			{Key: "bookmarks", Value: page},
+		}}},
+	)
+
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneBookmark).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, fmt.Errorf("bookmarks query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var result []struct {
+		Total []struct {
+			Count int `bson:"count"`
+		} `bson:"total"`
+		Bookmarks []scene_audio_route_models.Bookmark `bson:"bookmarks"`
+	}
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, 0, fmt.Errorf("decode error: %w", err)
+	}
+	if len(result) == 0 || len(result[0].Total) == 0 {
+		return []scene_audio_route_models.Bookmark{}, 0, nil
+	}
+	return result[0].Bookmarks, result[0].Total[0].Count, nil
+}
+
+func (r *bookmarkRepository) GetBookmark(ctx context.Context, userId, itemId string) (*scene_audio_route_models.Bookmark, error) {
+	pipeline := r.itemPipeline(ctx, userId, bson.D{{Key: "user_id", Value: userId}, {Key: "item_id", Value: itemId}})
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneBookmark).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("bookmark query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var bookmarks []scene_audio_route_models.Bookmark
+	if err := cursor.All(ctx, &bookmarks); err != nil {
+		return nil, fmt.Errorf("decode error: %w", err)
+	}
+	if len(bookmarks) == 0 {
+		return nil, fmt.Errorf("bookmark %w", domain.ErrNotFound)
+	}
+	return &bookmarks[0], nil
+}
+
+// itemPipeline 按最近更新排序并关联曲目或播客单集的标题与时长，条目已删除或不可见的书签被过滤
+func (r *bookmarkRepository) itemPipeline(ctx context.Context, userId string, match bson.D) []bson.D {
+	itemOID := bson.D{{Key: "$convert", Value: bson.D{
+		{Key: "input", Value: "$item_id"}, {Key: "to", Value: "objectId"}, {Key: "onError", Value: nil}, {Key: "onNull", Value: nil},
+	}}}
+	itemProject := bson.D{{Key: "$project", Value: bson.D{{Key: "title", Value: 1}, {Key: "duration", Value: 1}}}}
+
+	return []bson.D{
+		{{Key: "$match", Value: match}},
+		{{Key: "$sort", Value: bson.D{{Key: "updated_at", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: domain.CollectionFileEntityAudioSceneMediaFile},
+			{Key: "let", Value: bson.D{{Key: "oid", Value: itemOID}, {Key: "item_type", Value: "$item_type"}}},
+			{Key: "pipeline", Value: bson.A{
+				bson.D{{Key: "$match", Value: bson.D{{Key: "$expr", Value: bson.D{{Key: "$and", Value: bson.A{
+					bson.D{{Key: "$eq", Value: bson.A{"$$item_type", scene_audio_route_models.BookmarkItemMedia}}},
+					bson.D{{Key: "$eq", Value: bson.A{"$_id", "$$oid"}}},
+				}}}}}}},
+				bson.D{{Key: "$match", Value: bson.D{visibleFilter(ctx)}}},
+				itemProject,
+			}},
+			{Key: "as", Value: "bookmark_media"},
+		}}},
+		{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: domain.CollectionFileEntityAudioScenePodcastEpisode},
+			{Key: "let", Value: bson.D{{Key: "oid", Value: itemOID}, {Key: "item_type", Value: "$item_type"}}},
+			{Key: "pipeline", Value: bson.A{
+				bson.D{{Key: "$match", Value: bson.D{
+					{Key: "$expr", Value: bson.D{{Key: "$and", Value: bson.A{
+						bson.D{{Key: "$eq", Value: bson.A{"$$item_type", scene_audio_route_models.BookmarkItemPodcastEpisode}}},
+						bson.D{{Key: "$eq", Value: bson.A{"$_id", "$$oid"}}},
+					}}}},
+					{Key: "user_id", Value: userId},
+				}}},
+				itemProject,
+			}},
+			{Key: "as", Value: "bookmark_episode"},
+		}}},
+		{{Key: "$addFields", Value: bson.D{{Key: "bookmark_item", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{
+			bson.D{{Key: "$concatArrays", Value: bson.A{"$bookmark_media", "$bookmark_episode"}}}, 0,
+		}}}}}}},
+		{{Key: "$match", Value: bson.D{{Key: "bookmark_item", Value: bson.D{{Key: "$exists", Value: true}}}}}},
+		{{Key: "$addFields", Value: bson.D{
+			{Key: "title", Value: "$bookmark_item.title"},
+			{Key: "duration", Value: "$bookmark_item.duration"},
+		}}},
+		{{Key: "$unset", Value: bson.A{"bookmark_media", "bookmark_episode", "bookmark_item"}}},
+	}
+}
+
+func (r *bookmarkRepository) GetItemDuration(ctx context.Context, userId, itemType, itemId string) (float64, error) {
+	oid, err := primitive.ObjectIDFromHex(itemId)
+	if err != nil {
+		return 0, fmt.Errorf("bookmark item %w", domain.ErrNotFound)
+	}
+
+	var collection string
+	var filter bson.D
+	switch itemType {
+	case scene_audio_route_models.BookmarkItemMedia:
+		collection = domain.CollectionFileEntityAudioSceneMediaFile
+		filter = bson.D{{Key: "_id", Value: oid}, visibleFilter(ctx)}
+	case scene_audio_route_models.BookmarkItemPodcastEpisode:
+		collection = domain.CollectionFileEntityAudioScenePodcastEpisode
+		filter = bson.D{{Key: "_id", Value: oid}, {Key: "user_id", Value: userId}}
+	default:
+		return 0, scene_audio_route_models.ErrInvalidBookmark
+	}
+
+	var item struct {
+		Duration float64 `bson:"duration"`
+	}
+	if err := r.db.Collection(collection).FindOne(ctx, filter).Decode(&item); err != nil {
+		if errors.Is(err, driver.ErrNoDocuments) {
+			return 0, fmt.Errorf("bookmark item %w", domain.ErrNotFound)
+		}
+		return 0, fmt.Errorf("bookmark item query failed: %w", err)
+	}
+	// 曲目时长按 taglib 原样以纳秒存储，播客单集为秒
+	if itemType == scene_audio_route_models.BookmarkItemMedia {
+		return time.Duration(item.Duration).Seconds(), nil
+	}
+	return item.Duration, nil
+}
+
+func (r *bookmarkRepository) SaveBookmark(
+	ctx context.Context,
+	bookmark scene_audio_route_models.Bookmark,
+) (*scene_audio_route_models.Bookmark, error) {
+	now := time.Now().UTC()
+	coll := r.db.Collection(domain.CollectionFileEntityAudioSceneBookmark)
+	_, err := coll.UpdateOne(ctx,
+		bson.M{"user_id": bookmark.UserID, "item_id": bookmark.ItemID},
+		bson.M{
+			"$set": bson.M{
+				"item_type":  bookmark.ItemType,
+				"position":   bookmark.Position,
+				"comment":    bookmark.Comment,
+				"updated_at": now,
+			},
+			"$setOnInsert": bson.M{"_id": primitive.NewObjectID(), "created_at": now},
+		},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("upsert failed: %w", err)
+	}
+	return r.GetBookmark(ctx, bookmark.UserID, bookmark.ItemID)
+}
+
+func (r *bookmarkRepository) DeleteBookmark(ctx context.Context, userId, itemId string) (bool, error) {
+	deleted, err := r.db.Collection(domain.CollectionFileEntityAudioSceneBookmark).
+		DeleteOne(ctx, bson.M{"user_id": userId, "item_id": itemId})
+	if err != nil {
+		return false, fmt.Errorf("delete failed: %w", err)
+	}
+	return deleted > 0, nil
+}
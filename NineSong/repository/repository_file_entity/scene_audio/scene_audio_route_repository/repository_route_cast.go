@@ -0,0 +1,40 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+)
+
+type castRepository struct {
+	db mongo.Database
+}
+
+func NewCastRepository(db mongo.Database) scene_audio_route_interface.CastRepository {
+	return &castRepository{db: db}
+}
+
+func (r *castRepository) GetMediaFile(ctx context.Context, mediaFileId string) (*scene_audio_route_models.MediaFileMetadata, error) {
+	objID, err := primitive.ObjectIDFromHex(mediaFileId)
+	if err != nil {
+		return nil, errors.New("invalid media file id format")
+	}
+
+	var file scene_audio_route_models.MediaFileMetadata
+	err = r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).FindOne(ctx, bson.D{{Key: "_id", Value: objID}, visibleFilter(ctx)}).Decode(&file)
+	if err != nil {
+		if errors.Is(err, driver.ErrNoDocuments) {
+			return nil, fmt.Errorf("media file %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("media query failed: %w", err)
+	}
+	return &file, nil
+}
@@ -0,0 +1,77 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cache_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// userAnnotation 当前用户对单个条目的注解，用于叠加到共享的目录列表上
+type userAnnotation struct {
+	ItemID            primitive.ObjectID `bson:"item_id"`
+	PlayCount         int                `bson:"play_count"`
+	PlayCompleteCount int                `bson:"play_complete_count"`
+	PlayDate          time.Time          `bson:"play_date"`
+	FirstPlayDate     time.Time          `bson:"first_play_date"`
+	Rating            int                `bson:"rating"`
+	RatedAt           time.Time          `bson:"rated_at"`
+	Starred           bool               `bson:"starred"`
+	StarredAt         time.Time          `bson:"starred_at"`
+}
+
+// isAnnotationField 排序或过滤字段来自用户注解时，列表结果因用户而异，不能共享
+func isAnnotationField(field string) bool {
+	for _, f := range denormalizedAnnotationFields {
+		if f.field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// sharedCatalogPage 不依赖用户注解的列表按媒体库范围在用户之间共享：以不带用户的请求加载，
+// 结果不含任何注解字段，调用方再叠加当前用户的注解。启动预热写入的也是这份缓存
+func sharedCatalogPage[T any](ctx context.Context, key string, load func(ctx context.Context) ([]T, error)) ([]T, error) {
+	catalogCtx := domain.WithUserID(ctx, "")
+	return cache_util.GetOrLoad(ctx, cache_util.NamespaceLists,
+		cache_util.Key("catalog", domain.FolderScopeFromContext(ctx).Key(), key), cache_util.DefaultTTL(),
+		func() ([]T, error) {
+			return load(catalogCtx)
+		})
+}
+
+// userAnnotationsFor 读取当前用户对一批条目的注解，请求不带用户时返回空；查询命中 idx_user_item 索引
+func userAnnotationsFor(ctx context.Context, db mongo.Database, itemType scene_audio_route_models.AnnotationItemType, itemIDs []primitive.ObjectID) (map[primitive.ObjectID]userAnnotation, error) {
+	userID := domain.UserIDFromContext(ctx)
+	if userID == "" || len(itemIDs) == 0 {
+		return nil, nil
+	}
+	cursor, err := db.Collection(domain.CollectionFileEntityAudioSceneAnnotation).Find(ctx, bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "item_type", Value: itemType},
+		{Key: "item_id", Value: bson.D{{Key: "$in", Value: itemIDs}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query user annotations failed: %w", err)
+	}
+	var rows []userAnnotation
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("decode user annotations failed: %w", err)
+	}
+	_ = cursor.Close(ctx)
+
+	annotations := make(map[primitive.ObjectID]userAnnotation, len(rows))
+	for _, row := range rows {
+		if _, ok := annotations[row.ItemID]; !ok {
+			annotations[row.ItemID] = row
+		}
+	}
+	return annotations, nil
+}
@@ -0,0 +1,154 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type changesRepository struct {
+	db mongo.Database
+}
+
+// changeFeedCollections 变更流实体类型对应的集合
+var changeFeedCollections = map[string]string{
+	"media":    domain.CollectionFileEntityAudioSceneMediaFile,
+	"album":    domain.CollectionFileEntityAudioSceneAlbum,
+	"artist":   domain.CollectionFileEntityAudioSceneArtist,
+	"playlist": domain.CollectionFileEntityAudioScenePlaylist,
+}
+
+func NewChangesRepository(db mongo.Database) scene_audio_route_interface.ChangesRepository {
+	return &changesRepository{db: db}
+}
+
+func (r *changesRepository) GetChangedItems(
+	ctx context.Context,
+	itemType string,
+	since, until time.Time,
+	after *scene_audio_route_models.ChangeCursor,
+	limit int,
+) ([]scene_audio_route_models.ChangedItem, error) {
+	collection, ok := changeFeedCollections[itemType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported item type: %s", itemType)
+	}
+	visible, err := r.visibleCondition(ctx, itemType)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := append(changeRangeFilter("updated_at", since, until, after), visible...)
+	cursor, err := r.db.Collection(collection).Find(ctx, filter,
+		options.Find().
+			SetProjection(bson.M{"_id": 1, "created_at": 1, "updated_at": 1}).
+			SetSort(bson.D{{Key: "updated_at", Value: 1}, {Key: "_id", Value: 1}}).
+			SetLimit(int64(limit)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("changed %s query failed: %w", itemType, err)
+	}
+	defer cursor.Close(ctx)
+
+	items := make([]scene_audio_route_models.ChangedItem, 0)
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, fmt.Errorf("changed %s decode failed: %w", itemType, err)
+	}
+	return items, nil
+}
+
+// visibleCondition 单曲排除待审核、已驳回及不可访问媒体库中的曲目，专辑与艺术家按可访问媒体库过滤，播放列表只包含自己的
+func (r *changesRepository) visibleCondition(ctx context.Context, itemType string) (bson.D, error) {
+	switch itemType {
+	case "media":
+		return bson.D{visibleFilter(ctx)}, nil
+	case "album":
+		return visibleAlbumCondition(ctx, r.db)
+	case "artist":
+		return visibleArtistCondition(ctx, r.db)
+	case "playlist":
+		return bson.D{playlistOwnerFilter(ctx)}, nil
+	}
+	return nil, nil
+}
+
+func (r *changesRepository) GetDeletedItems(
+	ctx context.Context,
+	since, until time.Time,
+	after *scene_audio_route_models.ChangeCursor,
+	limit int,
+) ([]scene_audio_route_models.DeletionRecord, error) {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneDeletionLog).Find(ctx,
+		changeRangeFilter("deleted_at", since, until, after),
+		options.Find().
+			SetSort(bson.D{{Key: "deleted_at", Value: 1}, {Key: "_id", Value: 1}}).
+			SetLimit(int64(limit)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("deletion log query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	records := make([]scene_audio_route_models.DeletionRecord, 0)
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, fmt.Errorf("deletion log decode failed: %w", err)
+	}
+	return records, nil
+}
+
+func (r *changesRepository) GetExistingItems(ctx context.Context, itemType string, ids []string) (map[string]bool, error) {
+	collection, ok := changeFeedCollections[itemType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported item type: %s", itemType)
+	}
+	oids := make(bson.A, 0, len(ids))
+	for _, id := range ids {
+		if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+			oids = append(oids, oid)
+		}
+	}
+	existing := make(map[string]bool)
+	if len(oids) == 0 {
+		return existing, nil
+	}
+
+	cursor, err := r.db.Collection(collection).Find(ctx,
+		bson.M{"_id": bson.M{"$in": oids}},
+		options.Find().SetProjection(bson.M{"_id": 1}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("existing %s query failed: %w", itemType, err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("existing %s decode failed: %w", itemType, err)
+	}
+	for _, doc := range docs {
+		existing[doc.ID.Hex()] = true
+	}
+	return existing, nil
+}
+
+// changeRangeFilter 时间字段位于 [since, until)，after 不为空时只取 (时间, _id) 排在其后的文档
+func changeRangeFilter(field string, since, until time.Time, after *scene_audio_route_models.ChangeCursor) bson.D {
+	filter := bson.D{{Key: field, Value: bson.D{{Key: "$gte", Value: since}, {Key: "$lt", Value: until}}}}
+	if after != nil {
+		filter = append(filter, bson.E{Key: "$or", Value: bson.A{
+			bson.D{{Key: field, Value: bson.D{{Key: "$gt", Value: after.Time}}}},
+			bson.D{{Key: field, Value: after.Time}, {Key: "_id", Value: bson.D{{Key: "$gt", Value: after.ID}}}},
+		}})
+	}
+	return filter
+}
@@ -0,0 +1,107 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type chartRepository struct {
+	db mongo.Database
+}
+
+func NewChartRepository(db mongo.Database) scene_audio_route_interface.ChartRepository {
+	return &chartRepository{db: db}
+}
+
+func (r *chartRepository) TopMediaFiles(ctx context.Context, since time.Time, limit int) ([]scene_audio_route_models.ChartMediaFile, error) {
+	items := make([]scene_audio_route_models.ChartMediaFile, 0)
+	err := r.top(ctx, since, limit, "$media_file_id", false,
+		domain.CollectionFileEntityAudioSceneMediaFile, bson.D{visibleFilter(ctx)}, &items)
+	return items, err
+}
+
+func (r *chartRepository) TopAlbums(ctx context.Context, since time.Time, limit int) ([]scene_audio_route_models.ChartAlbum, error) {
+	items := make([]scene_audio_route_models.ChartAlbum, 0)
+	scopeCond, err := visibleAlbumCondition(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	err = r.top(ctx, since, limit, "$album_id", true,
+		domain.CollectionFileEntityAudioSceneAlbum, append(bson.D{}, scopeCond...), &items)
+	return items, err
+}
+
+func (r *chartRepository) TopArtists(ctx context.Context, since time.Time, limit int) ([]scene_audio_route_models.ChartArtist, error) {
+	items := make([]scene_audio_route_models.ChartArtist, 0)
+	scopeCond, err := visibleArtistCondition(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	err = r.top(ctx, since, limit, "$artist_id", true,
+		domain.CollectionFileEntityAudioSceneArtist, append(bson.D{}, scopeCond...), &items)
+	return items, err
+}
+
+// top 按 field 汇总播放记录并关联条目；hexID 表示播放记录中该字段为十六进制字符串，需转换后关联
+func (r *chartRepository) top(
+	ctx context.Context,
+	since time.Time,
+	limit int,
+	field string,
+	hexID bool,
+	collection string,
+	itemFilter bson.D,
+	out interface{},
+) error {
+	match := bson.D{{Key: field[1:], Value: bson.M{"$nin": bson.A{"", nil}}}}
+	if !since.IsZero() {
+		match = append(match, bson.E{Key: "played_at", Value: bson.M{"$gte": since}})
+	}
+
+	itemID := interface{}("$_id")
+	if hexID {
+		itemID = bson.M{"$convert": bson.M{"input": "$_id", "to": "objectId", "onError": nil, "onNull": nil}}
+	}
+	lookupPipeline := bson.A{
+		bson.M{"$match": bson.M{"$expr": bson.M{"$eq": bson.A{"$_id", "$$item_id"}}}},
+	}
+	if len(itemFilter) > 0 {
+		lookupPipeline = append(lookupPipeline, bson.M{"$match": itemFilter})
+	}
+
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioScenePlayHistory).Aggregate(ctx, []bson.D{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id":       field,
+			"plays":     bson.M{"$sum": 1},
+			"listeners": bson.M{"$addToSet": "$user_id"},
+		}}},
+		{{Key: "$addFields", Value: bson.M{"listeners": bson.M{"$size": "$listeners"}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "plays", Value: -1}, {Key: "listeners", Value: -1}, {Key: "_id", Value: 1}}}},
+		// 多取一些候选，补足已删除或被隐藏的条目
+		{{Key: "$limit", Value: limit * 2}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":     collection,
+			"let":      bson.M{"item_id": itemID},
+			"pipeline": lookupPipeline,
+			"as":       "item",
+		}}},
+		{{Key: "$unwind", Value: "$item"}},
+		{{Key: "$limit", Value: limit}},
+	})
+	if err != nil {
+		return fmt.Errorf("chart %s query failed: %w", collection, err)
+	}
+	defer cursor.Close(ctx)
+	if err := cursor.All(ctx, out); err != nil {
+		return fmt.Errorf("decode chart %s failed: %w", collection, err)
+	}
+	return nil
+}
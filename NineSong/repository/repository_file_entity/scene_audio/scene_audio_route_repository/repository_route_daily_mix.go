@@ -0,0 +1,314 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// dailyMixNewCandidates 新入库曲目先按入库时间取候选，再排除用户播放过的
+const dailyMixNewCandidates = 500
+
+// systemPlaylistFilter 系统生成的播放列表
+var systemPlaylistFilter = bson.E{Key: "system", Value: bson.M{"$nin": bson.A{"", nil}}}
+
+type dailyMixRepository struct {
+	db mongo.Database
+}
+
+func NewDailyMixRepository(db mongo.Database) scene_audio_route_interface.DailyMixRepository {
+	return &dailyMixRepository{db: db}
+}
+
+func (r *dailyMixRepository) ActiveUsers(ctx context.Context, since time.Time) ([]string, error) {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioScenePlayHistory).Aggregate(ctx, []bson.D{
+		{{Key: "$match", Value: bson.M{
+			"played_at": bson.M{"$gte": since},
+			"user_id":   bson.M{"$nin": bson.A{"", nil}},
+		}}},
+		{{Key: "$group", Value: bson.M{"_id": "$user_id"}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("active users query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		UserID string `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("decode active users failed: %w", err)
+	}
+	users := make([]string, 0, len(rows))
+	for _, row := range rows {
+		users = append(users, row.UserID)
+	}
+	return users, nil
+}
+
+func (r *dailyMixRepository) TopGenres(ctx context.Context, userId string, since time.Time, limit int) ([]string, error) {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioScenePlayHistory).Aggregate(ctx, []bson.D{
+		{{Key: "$match", Value: bson.M{"user_id": userId, "played_at": bson.M{"$gte": since}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$media_file_id", "plays": bson.M{"$sum": 1}}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         domain.CollectionFileEntityAudioSceneMediaFile,
+			"localField":   "_id",
+			"foreignField": "_id",
+			"pipeline":     bson.A{bson.M{"$project": bson.M{"genre": 1}}},
+			"as":           "media_file",
+		}}},
+		{{Key: "$unwind", Value: "$media_file"}},
+		{{Key: "$match", Value: bson.M{"media_file.genre": bson.M{"$nin": bson.A{"", nil}}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$media_file.genre", "plays": bson.M{"$sum": "$plays"}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "plays", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("top genres query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		Genre string `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("decode top genres failed: %w", err)
+	}
+	genres := make([]string, 0, len(rows))
+	for _, row := range rows {
+		genres = append(genres, row.Genre)
+	}
+	return genres, nil
+}
+
+func (r *dailyMixRepository) GenreMediaFiles(ctx context.Context, genre string, limit int) ([]primitive.ObjectID, error) {
+	return r.mediaFileIDs(ctx, []bson.D{
+		{{Key: "$match", Value: bson.D{{Key: "genre", Value: genre}, visibleFilter(ctx)}}},
+		{{Key: "$sample", Value: bson.M{"size": limit}}},
+		{{Key: "$project", Value: bson.M{"_id": 1}}},
+	})
+}
+
+func (r *dailyMixRepository) NewMediaFiles(ctx context.Context, userId string, since time.Time, limit int) ([]primitive.ObjectID, error) {
+	return r.mediaFileIDs(ctx, []bson.D{
+		{{Key: "$match", Value: bson.D{{Key: "created_at", Value: bson.M{"$gte": since}}, visibleFilter(ctx)}}},
+		{{Key: "$sort", Value: bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}}},
+		{{Key: "$limit", Value: dailyMixNewCandidates}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": domain.CollectionFileEntityAudioScenePlayHistory,
+			"let":  bson.M{"media_file_id": "$_id"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{
+					"user_id": userId,
+					"$expr":   bson.M{"$eq": bson.A{"$media_file_id", "$$media_file_id"}},
+				}},
+				bson.M{"$limit": 1},
+			},
+			"as": "user_history",
+		}}},
+		{{Key: "$match", Value: bson.M{"user_history": bson.M{"$size": 0}}}},
+		{{Key: "$limit", Value: limit}},
+		{{Key: "$project", Value: bson.M{"_id": 1}}},
+	})
+}
+
+func (r *dailyMixRepository) mediaFileIDs(ctx context.Context, pipeline []bson.D) ([]primitive.ObjectID, error) {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("mix tracks query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("decode mix tracks failed: %w", err)
+	}
+	ids := make([]primitive.ObjectID, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	return ids, nil
+}
+
+func (r *dailyMixRepository) ReplaceMixes(ctx context.Context, userId string, mixes []scene_audio_route_models.DailyMixDraft) error {
+	playlistColl := r.db.Collection(domain.CollectionFileEntityAudioScenePlaylist)
+	trackColl := r.db.Collection(domain.CollectionFileEntityAudioScenePlaylistTrack)
+
+	old, err := r.systemPlaylists(ctx, userId, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return err
+	}
+	if len(old) > 0 {
+		oldIDs := make([]primitive.ObjectID, 0, len(old))
+		for _, p := range old {
+			oldIDs = append(oldIDs, p.ID)
+		}
+		if _, err := trackColl.DeleteMany(ctx, bson.M{"playlist_id": bson.M{"$in": oldIDs}}); err != nil {
+			return fmt.Errorf("delete mix tracks failed: %w", err)
+		}
+		if _, err := playlistColl.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": oldIDs}}); err != nil {
+			return fmt.Errorf("delete mixes failed: %w", err)
+		}
+	}
+
+	now := time.Now().UTC()
+	for _, mix := range mixes {
+		if len(mix.MediaFileIDs) == 0 {
+			continue
+		}
+		duration, err := r.totalDuration(ctx, mix.MediaFileIDs)
+		if err != nil {
+			return err
+		}
+		playlist := scene_audio_db_models.PlaylistMetadata{
+			ID:        primitive.NewObjectID(),
+			Name:      mix.Name,
+			Comment:   mix.Comment,
+			Duration:  duration,
+			SongCount: float64(len(mix.MediaFileIDs)),
+			CreatedAt: now,
+			UpdatedAt: now,
+			OwnerID:   userId,
+			System:    mix.Type,
+		}
+		if _, err := playlistColl.InsertOne(ctx, playlist); err != nil {
+			return fmt.Errorf("insert mix failed: %w", err)
+		}
+		tracks := make([]interface{}, 0, len(mix.MediaFileIDs))
+		for i, id := range mix.MediaFileIDs {
+			tracks = append(tracks, scene_audio_db_models.PlaylistTrackMetadata{
+				ID:          primitive.NewObjectID(),
+				PlaylistID:  playlist.ID,
+				MediaFileID: id,
+				Index:       i + 1,
+			})
+		}
+		if _, err := trackColl.InsertMany(ctx, tracks); err != nil {
+			return fmt.Errorf("insert mix tracks failed: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *dailyMixRepository) totalDuration(ctx context.Context, ids []primitive.ObjectID) (float64, error) {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).Aggregate(ctx, []bson.D{
+		{{Key: "$match", Value: bson.M{"_id": bson.M{"$in": ids}}}},
+		{{Key: "$group", Value: bson.M{"_id": nil, "duration": bson.M{"$sum": "$duration"}}}},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("mix duration query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		Duration float64 `bson:"duration"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return 0, fmt.Errorf("decode mix duration failed: %w", err)
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	return rows[0].Duration, nil
+}
+
+func (r *dailyMixRepository) GetMixes(ctx context.Context, userId string) ([]scene_audio_route_models.DailyMix, error) {
+	playlists, err := r.systemPlaylists(ctx, userId, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	mixes := make([]scene_audio_route_models.DailyMix, 0, len(playlists))
+	for _, p := range playlists {
+		mediaFiles, err := r.mixMediaFiles(ctx, p.ID)
+		if err != nil {
+			return nil, err
+		}
+		mixes = append(mixes, scene_audio_route_models.DailyMix{
+			ID:          p.ID,
+			Type:        p.System,
+			Name:        p.Name,
+			Comment:     p.Comment,
+			SongCount:   len(mediaFiles),
+			Duration:    p.Duration,
+			GeneratedAt: p.CreatedAt,
+			MediaFiles:  mediaFiles,
+		})
+	}
+	return mixes, nil
+}
+
+// mixMediaFiles 按歌单顺序返回曲目，生成后被删除或隐藏的曲目跳过
+func (r *dailyMixRepository) mixMediaFiles(ctx context.Context, playlistID primitive.ObjectID) ([]scene_audio_route_models.MediaFileMetadata, error) {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioScenePlaylistTrack).Aggregate(ctx, []bson.D{
+		{{Key: "$match", Value: bson.M{"playlist_id": playlistID}}},
+		{{Key: "$sort", Value: bson.M{"index": 1}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         domain.CollectionFileEntityAudioSceneMediaFile,
+			"localField":   "media_file_id",
+			"foreignField": "_id",
+			"pipeline":     bson.A{bson.M{"$match": bson.D{visibleFilter(ctx)}}},
+			"as":           "media_file",
+		}}},
+		{{Key: "$unwind", Value: "$media_file"}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$media_file"}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("mix media files query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	mediaFiles := make([]scene_audio_route_models.MediaFileMetadata, 0)
+	if err := cursor.All(ctx, &mediaFiles); err != nil {
+		return nil, fmt.Errorf("decode mix media files failed: %w", err)
+	}
+	return mediaFiles, nil
+}
+
+func (r *dailyMixRepository) systemPlaylists(ctx context.Context, userId string, opts *options.FindOptions) ([]scene_audio_db_models.PlaylistMetadata, error) {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioScenePlaylist).Find(ctx,
+		bson.D{{Key: "owner_id", Value: userId}, systemPlaylistFilter}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("system playlists query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	playlists := make([]scene_audio_db_models.PlaylistMetadata, 0)
+	if err := cursor.All(ctx, &playlists); err != nil {
+		return nil, fmt.Errorf("decode system playlists failed: %w", err)
+	}
+	return playlists, nil
+}
+
+func (r *dailyMixRepository) LastGeneratedAt(ctx context.Context) (time.Time, error) {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioScenePlaylist).Find(ctx,
+		bson.D{systemPlaylistFilter},
+		options.Find().SetProjection(bson.M{"created_at": 1}).SetSort(bson.M{"created_at": -1}).SetLimit(1))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("last mix query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		CreatedAt time.Time `bson:"created_at"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return time.Time{}, fmt.Errorf("decode last mix failed: %w", err)
+	}
+	if len(rows) == 0 {
+		return time.Time{}, nil
+	}
+	return rows[0].CreatedAt, nil
+}
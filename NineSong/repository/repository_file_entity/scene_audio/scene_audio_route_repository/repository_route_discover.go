@@ -0,0 +1,118 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type discoverRepository struct {
+	db mongo.Database
+}
+
+func NewDiscoverRepository(db mongo.Database) scene_audio_route_interface.DiscoverRepository {
+	return &discoverRepository{db: db}
+}
+
+func (r *discoverRepository) GetForgottenMediaFiles(ctx context.Context, userId string, since time.Time, minPlays, limit int) ([]scene_audio_route_models.ForgottenMediaFile, error) {
+	candidates := bson.D{
+		{Key: "$or", Value: bson.A{
+			bson.M{"starred": true},
+			bson.M{"play_count": bson.M{"$gte": minPlays}},
+		}},
+		visibleFilter(ctx),
+	}
+	mediaFiles := make([]scene_audio_route_models.ForgottenMediaFile, 0)
+	err := r.forgotten(ctx, domain.CollectionFileEntityAudioSceneMediaFile, candidates,
+		"media_file_id", "$_id", userId, since, minPlays, limit, &mediaFiles)
+	return mediaFiles, err
+}
+
+func (r *discoverRepository) GetForgottenAlbums(ctx context.Context, userId string, since time.Time, minPlays, limit int) ([]scene_audio_route_models.ForgottenAlbum, error) {
+	scopeCond, err := visibleAlbumCondition(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	candidates := append(bson.D{
+		{Key: "$or", Value: bson.A{
+			bson.M{"starred": true},
+			bson.M{"play_count": bson.M{"$gte": minPlays}},
+		}},
+	}, scopeCond...)
+	albums := make([]scene_audio_route_models.ForgottenAlbum, 0)
+	// 播放记录中的 album_id 为十六进制字符串
+	err = r.forgotten(ctx, domain.CollectionFileEntityAudioSceneAlbum, candidates,
+		"album_id", bson.M{"$toString": "$_id"}, userId, since, minPlays, limit, &albums)
+	return albums, err
+}
+
+// forgotten 先按全局注解（收藏、总播放次数）筛出候选，再关联该用户的播放记录：
+// 保留已收藏或用户播放不少于 minPlays 次、且最后一次播放早于 since 的条目，按用户播放次数降序
+func (r *discoverRepository) forgotten(
+	ctx context.Context,
+	collection string,
+	candidates bson.D,
+	historyField string,
+	itemID interface{},
+	userId string,
+	since time.Time,
+	minPlays, limit int,
+	out interface{},
+) error {
+	pipeline := []bson.D{
+		{{Key: "$match", Value: candidates}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": domain.CollectionFileEntityAudioScenePlayHistory,
+			"let":  bson.M{"item_id": itemID},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{
+					"user_id": userId,
+					"$expr":   bson.M{"$eq": bson.A{"$" + historyField, "$$item_id"}},
+				}},
+				bson.M{"$group": bson.M{
+					"_id":            nil,
+					"count":          bson.M{"$sum": 1},
+					"last_played_at": bson.M{"$max": "$played_at"},
+				}},
+			},
+			"as": "user_history",
+		}}},
+		{{Key: "$addFields", Value: bson.M{
+			"user_play_count": bson.M{"$ifNull": bson.A{bson.M{"$arrayElemAt": bson.A{"$user_history.count", 0}}, 0}},
+			"last_played_at":  bson.M{"$arrayElemAt": bson.A{"$user_history.last_played_at", 0}},
+		}}},
+		{{Key: "$match", Value: bson.M{"$and": bson.A{
+			bson.M{"$or": bson.A{
+				bson.M{"starred": true},
+				bson.M{"user_play_count": bson.M{"$gte": minPlays}},
+			}},
+			bson.M{"$or": bson.A{
+				bson.M{"last_played_at": nil},
+				bson.M{"last_played_at": bson.M{"$lt": since}},
+			}},
+		}}}},
+		{{Key: "$sort", Value: bson.D{
+			{Key: "user_play_count", Value: -1},
+			{Key: "last_played_at", Value: 1},
+			{Key: "_id", Value: 1},
+		}}},
+		{{Key: "$limit", Value: limit}},
+		{{Key: "$project", Value: bson.M{"user_history": 0}}},
+	}
+
+	cursor, err := r.db.Collection(collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return fmt.Errorf("forgotten %s query failed: %w", collection, err)
+	}
+	defer cursor.Close(ctx)
+	if err := cursor.All(ctx, out); err != nil {
+		return fmt.Errorf("decode forgotten %s failed: %w", collection, err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type dlnaRepository struct {
+	db mongo.Database
+}
+
+func NewDLNARepository(db mongo.Database) scene_audio_route_interface.DLNARepository {
+	return &dlnaRepository{db: db}
+}
+
+func (r *dlnaRepository) GetMediaFile(ctx context.Context, mediaFileId string) (*scene_audio_route_models.MediaFileMetadata, error) {
+	objID, err := primitive.ObjectIDFromHex(mediaFileId)
+	if err != nil {
+		return nil, errors.New("invalid media file id format")
+	}
+
+	var file scene_audio_route_models.MediaFileMetadata
+	err = r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).FindOne(ctx, bson.M{"_id": objID}).Decode(&file)
+	if err != nil {
+		if errors.Is(err, driver.ErrNoDocuments) {
+			return nil, fmt.Errorf("media file %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("media query failed: %w", err)
+	}
+	return &file, nil
+}
+
+func (r *dlnaRepository) GetSystemUpdateID(ctx context.Context) (uint32, error) {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).Find(ctx, bson.M{},
+		options.Find().
+			SetSort(bson.D{{Key: "updated_at", Value: -1}}).
+			SetProjection(bson.M{"updated_at": 1}).
+			SetLimit(1))
+	if err != nil {
+		return 0, fmt.Errorf("media query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var files []scene_audio_route_models.MediaFileMetadata
+	if err := cursor.All(ctx, &files); err != nil {
+		return 0, fmt.Errorf("decode error: %w", err)
+	}
+	if len(files) == 0 {
+		return 0, nil
+	}
+	return uint32(files[0].UpdatedAt.Unix()), nil
+}
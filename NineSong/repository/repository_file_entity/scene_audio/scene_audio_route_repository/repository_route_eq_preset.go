@@ -0,0 +1,96 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type eqPresetRepository struct {
+	db         mongo.Database
+	collection string
+}
+
+func NewEqPresetRepository(db mongo.Database, collection string) scene_audio_route_interface.EqPresetRepository {
+	return &eqPresetRepository{
+		db:         db,
+		collection: collection,
+	}
+}
+
+// 获取用户的所有均衡器预设
+func (r *eqPresetRepository) GetEqPresets(ctx context.Context, userId string) ([]scene_audio_route_models.EqPresetMetadata, error) {
+	coll := r.db.Collection(r.collection)
+	cursor, err := coll.Find(ctx, bson.M{"user_id": userId}, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("find operation failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var presets []scene_audio_route_models.EqPresetMetadata
+	if err := cursor.All(ctx, &presets); err != nil {
+		return nil, fmt.Errorf("decode error: %w", err)
+	}
+	return presets, nil
+}
+
+// 按名称获取单个预设
+func (r *eqPresetRepository) GetEqPreset(ctx context.Context, userId string, name string) (*scene_audio_route_models.EqPresetMetadata, error) {
+	coll := r.db.Collection(r.collection)
+	var preset scene_audio_route_models.EqPresetMetadata
+	err := coll.FindOne(ctx, bson.M{"user_id": userId, "name": name}).Decode(&preset)
+	if err != nil {
+		if errors.Is(err, driver.ErrNoDocuments) {
+			return nil, fmt.Errorf("eq preset %q %w", name, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("find one error: %w", err)
+	}
+	return &preset, nil
+}
+
+// 创建或覆盖预设（同一用户下名称唯一）
+func (r *eqPresetRepository) SaveEqPreset(ctx context.Context, preset scene_audio_route_models.EqPresetMetadata) (*scene_audio_route_models.EqPresetMetadata, error) {
+	coll := r.db.Collection(r.collection)
+	now := time.Now().UTC()
+
+	filter := bson.M{"user_id": preset.UserID, "name": preset.Name}
+	update := bson.M{
+		"$set": bson.M{
+			"bands":      preset.Bands,
+			"pre_amp":    preset.PreAmp,
+			"updated_at": now,
+		},
+		"$setOnInsert": bson.M{
+			"_id":        primitive.NewObjectID(),
+			"user_id":    preset.UserID,
+			"name":       preset.Name,
+			"created_at": now,
+		},
+	}
+
+	if _, err := coll.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true)); err != nil {
+		return nil, fmt.Errorf("upsert failed: %w", err)
+	}
+
+	return r.GetEqPreset(ctx, preset.UserID, preset.Name)
+}
+
+// 删除预设
+func (r *eqPresetRepository) DeleteEqPreset(ctx context.Context, userId string, name string) (bool, error) {
+	coll := r.db.Collection(r.collection)
+	result, err := coll.DeleteOne(ctx, bson.M{"user_id": userId, "name": name})
+	if err != nil {
+		return false, fmt.Errorf("delete failed: %w", err)
+	}
+	return result > 0, nil
+}
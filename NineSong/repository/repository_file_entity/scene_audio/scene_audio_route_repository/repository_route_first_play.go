@@ -0,0 +1,152 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// firstPlayHistoryFields 播放历史中引用各条目类型的字段及其是否为 ObjectID
+var firstPlayHistoryFields = map[scene_audio_route_models.AnnotationItemType]struct {
+	field    string
+	objectID bool
+}{
+	scene_audio_route_models.AnnotationItemMedia:  {"media_file_id", true},
+	scene_audio_route_models.AnnotationItemAlbum:  {"album_id", false},
+	scene_audio_route_models.AnnotationItemArtist: {"artist_id", false},
+}
+
+type firstPlayAnnotation struct {
+	ID       primitive.ObjectID                          `bson:"_id"`
+	ItemID   primitive.ObjectID                          `bson:"item_id"`
+	ItemType scene_audio_route_models.AnnotationItemType `bson:"item_type"`
+	PlayDate time.Time                                   `bson:"play_date"`
+}
+
+// BackfillFirstPlayDates 为升级前已有播放的注解补写首次播放时间：优先取播放历史中的最早记录，
+// 无历史时取最近播放时间；并清除对应条目的同步标记，由 RepairAnnotationDenormalization 重新冗余
+func BackfillFirstPlayDates(ctx context.Context, db mongo.Database) error {
+	coll := db.Collection(domain.CollectionFileEntityAudioSceneAnnotation)
+	filled := 0
+	for {
+		cursor, err := coll.Find(ctx,
+			bson.M{"play_count": bson.M{"$gt": 0}, "first_play_date": bson.M{"$exists": false}},
+			options.Find().SetProjection(bson.M{"_id": 1, "item_id": 1, "item_type": 1, "play_date": 1}).
+				SetLimit(annotationRepairBatchSize),
+		)
+		if err != nil {
+			return fmt.Errorf("query annotations without first play date failed: %w", err)
+		}
+		var docs []firstPlayAnnotation
+		if err := cursor.All(ctx, &docs); err != nil {
+			return fmt.Errorf("decode annotations failed: %w", err)
+		}
+		_ = cursor.Close(ctx)
+		if len(docs) == 0 {
+			break
+		}
+
+		byType := make(map[scene_audio_route_models.AnnotationItemType][]primitive.ObjectID)
+		for _, doc := range docs {
+			byType[doc.ItemType] = append(byType[doc.ItemType], doc.ItemID)
+		}
+		earliest := make(map[scene_audio_route_models.AnnotationItemType]map[primitive.ObjectID]time.Time, len(byType))
+		for itemType, ids := range byType {
+			if earliest[itemType], err = earliestPlays(ctx, db, itemType, ids); err != nil {
+				return err
+			}
+		}
+
+		models := make([]driver.WriteModel, 0, len(docs))
+		for _, doc := range docs {
+			first := doc.PlayDate
+			if t, ok := earliest[doc.ItemType][doc.ItemID]; ok && (first.IsZero() || t.Before(first)) {
+				first = t
+			}
+			models = append(models, driver.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": doc.ID}).
+				SetUpdate(bson.M{"$set": bson.M{"first_play_date": first}}))
+		}
+		if _, err := coll.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+			return fmt.Errorf("backfill first play date failed: %w", err)
+		}
+
+		for itemType, ids := range byType {
+			if collection, ok := itemType.Collection(); ok {
+				if _, err := db.Collection(collection).UpdateMany(ctx,
+					bson.M{"_id": bson.M{"$in": ids}},
+					bson.M{"$unset": bson.M{AnnotationSyncedField: ""}},
+				); err != nil {
+					return fmt.Errorf("reset %s annotation sync failed: %w", itemType, err)
+				}
+			}
+		}
+		filled += len(docs)
+	}
+	if filled > 0 {
+		log.Printf("已补写首次播放时间 %d 条", filled)
+	}
+	return nil
+}
+
+// earliestPlays 按播放历史计算条目的最早播放时间，不在历史中引用的条目类型返回空结果
+func earliestPlays(
+	ctx context.Context,
+	db mongo.Database,
+	itemType scene_audio_route_models.AnnotationItemType,
+	ids []primitive.ObjectID,
+) (map[primitive.ObjectID]time.Time, error) {
+	ref, ok := firstPlayHistoryFields[itemType]
+	if !ok {
+		return nil, nil
+	}
+	keys := make(bson.A, 0, len(ids))
+	for _, id := range ids {
+		if ref.objectID {
+			keys = append(keys, id)
+		} else {
+			keys = append(keys, id.Hex())
+		}
+	}
+
+	cursor, err := db.Collection(domain.CollectionFileEntityAudioScenePlayHistory).Aggregate(ctx, []bson.D{
+		{{Key: "$match", Value: bson.D{{Key: ref.field, Value: bson.D{{Key: "$in", Value: keys}}}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$" + ref.field},
+			{Key: "first", Value: bson.D{{Key: "$min", Value: "$played_at"}}},
+		}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("play history aggregate failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		ID    interface{} `bson:"_id"`
+		First time.Time   `bson:"first"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("decode play history aggregate failed: %w", err)
+	}
+	result := make(map[primitive.ObjectID]time.Time, len(rows))
+	for _, row := range rows {
+		switch id := row.ID.(type) {
+		case primitive.ObjectID:
+			result[id] = row.First
+		case string:
+			if objID, err := primitive.ObjectIDFromHex(id); err == nil {
+				result[objID] = row.First
+			}
+		}
+	}
+	return result, nil
+}
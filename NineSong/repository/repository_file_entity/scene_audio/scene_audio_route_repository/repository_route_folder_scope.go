@@ -0,0 +1,196 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// visibleFilter 可见曲目：排除待审核与已驳回的歌曲，调用方受媒体库权限限制时只保留可访问媒体库中的曲目；
+// 受限时用 $nor 合并两个条件，保证仍是单个键，可与调用方其它条件并列
+func visibleFilter(ctx context.Context) bson.E {
+	scope := domain.FolderScopeFromContext(ctx)
+	if scope == nil {
+		return reviewVisibleFilter()
+	}
+	return bson.E{Key: "$nor", Value: bson.A{
+		bson.D{{Key: "review_status", Value: bson.D{{Key: "$in", Value: bson.A{
+			scene_audio_db_models.ReviewStatusPending,
+			scene_audio_db_models.ReviewStatusRejected,
+		}}}}},
+		bson.D{{Key: "folder_id", Value: bson.D{{Key: "$nin", Value: scope.IDs}}}},
+	}}
+}
+
+// folderScopeMatch 合并请求指定的媒体库与调用方可访问的媒体库，均不限制时返回 nil
+func folderScopeMatch(ctx context.Context, folderId string) bson.D {
+	scope := domain.FolderScopeFromContext(ctx)
+	switch {
+	case scope == nil && folderId == "":
+		return nil
+	case folderId != "" && scope.Allows(folderId):
+		return bson.D{{Key: "folder_id", Value: folderId}}
+	case folderId != "":
+		return bson.D{{Key: "folder_id", Value: bson.D{{Key: "$in", Value: bson.A{}}}}}
+	default:
+		return bson.D{{Key: "folder_id", Value: bson.D{{Key: "$in", Value: scope.IDs}}}}
+	}
+}
+
+// folderAlbumCondition 专辑本身不记录媒体库，按指定或可访问媒体库下的曲目归属的专辑过滤
+func folderAlbumCondition(ctx context.Context, db mongo.Database, folderId string) (bson.D, error) {
+	match := folderScopeMatch(ctx, folderId)
+	if match == nil {
+		return nil, nil
+	}
+	ids, err := folderEntityIDs(ctx, db, match, bson.A{"$album_id"})
+	if err != nil {
+		return nil, fmt.Errorf("folder albums query failed: %w", err)
+	}
+	return bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}}}, nil
+}
+
+// visibleAlbumCondition 调用方受限时只保留可访问媒体库中有曲目的专辑
+func visibleAlbumCondition(ctx context.Context, db mongo.Database) (bson.D, error) {
+	return folderAlbumCondition(ctx, db, "")
+}
+
+// visibleArtistCondition 调用方受限时只保留在可访问媒体库中担任主艺术家、专辑艺术家或参与艺术家的艺术家
+func visibleArtistCondition(ctx context.Context, db mongo.Database) (bson.D, error) {
+	ids, restricted, err := visibleArtistIDs(ctx, db)
+	if err != nil || !restricted {
+		return nil, err
+	}
+	return bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}}}, nil
+}
+
+// visibleArtistIDs 调用方受限时返回其可见的艺术家ID，restricted 为 false 表示不受限
+func visibleArtistIDs(ctx context.Context, db mongo.Database) (ids []primitive.ObjectID, restricted bool, err error) {
+	match := folderScopeMatch(ctx, "")
+	if match == nil {
+		return nil, false, nil
+	}
+	ids, err = folderEntityIDs(ctx, db, match, bson.A{
+		bson.A{"$artist_id", "$album_artist_id"},
+		bson.D{{Key: "$ifNull", Value: bson.A{"$all_artist_ids.artist_id", bson.A{}}}},
+	})
+	if err != nil {
+		return nil, true, fmt.Errorf("folder artists query failed: %w", err)
+	}
+	return ids, true, nil
+}
+
+// visibleCueFilter CUE 曲目只记录媒体库路径，按可访问媒体库的 library_path 过滤
+func visibleCueFilter(ctx context.Context) bson.D {
+	scope := domain.FolderScopeFromContext(ctx)
+	if scope == nil {
+		return nil
+	}
+	paths := make(bson.A, 0, len(scope.Paths))
+	for _, path := range scope.Paths {
+		// 与扫描写入的 library_path 格式保持一致
+		paths = append(paths, strings.Replace(path, "/", "\\", -1))
+	}
+	return bson.D{{Key: "library_path", Value: bson.D{{Key: "$in", Value: paths}}}}
+}
+
+// itemVisible 调用方受限时检查单个条目是否可见：曲目须位于可访问媒体库，专辑与艺术家须在其中有曲目；
+// 不受限或其它条目类型直接视为可见，不查询数据库
+func itemVisible(ctx context.Context, db mongo.Database, itemType scene_audio_route_models.AnnotationItemType, itemID primitive.ObjectID) (bool, error) {
+	if domain.FolderScopeFromContext(ctx) == nil {
+		return true, nil
+	}
+
+	collection := domain.CollectionFileEntityAudioSceneMediaFile
+	var match bson.D
+	switch itemType {
+	case scene_audio_route_models.AnnotationItemMedia:
+		match = bson.D{{Key: "_id", Value: itemID}, visibleFilter(ctx)}
+	case scene_audio_route_models.AnnotationItemMediaCue:
+		collection = domain.CollectionFileEntityAudioSceneMediaFileCue
+		match = append(bson.D{{Key: "_id", Value: itemID}}, visibleCueFilter(ctx)...)
+	case scene_audio_route_models.AnnotationItemAlbum:
+		match = append(bson.D{{Key: "album_id", Value: itemID.Hex()}}, folderScopeMatch(ctx, "")...)
+	case scene_audio_route_models.AnnotationItemArtist:
+		match = append(bson.D{{Key: "$or", Value: bson.A{
+			bson.D{{Key: "artist_id", Value: itemID.Hex()}},
+			bson.D{{Key: "album_artist_id", Value: itemID.Hex()}},
+			bson.D{{Key: "all_artist_ids.artist_id", Value: itemID.Hex()}},
+		}}}, folderScopeMatch(ctx, "")...)
+	default:
+		return true, nil
+	}
+
+	count, err := db.Collection(collection).CountDocuments(ctx, match, options.Count().SetLimit(1))
+	if err != nil {
+		return false, fmt.Errorf("item visibility query failed: %w", err)
+	}
+	return count > 0, nil
+}
+
+// visibleMediaStages 调用方受限时按 localField 关联曲目并丢弃引用不可见曲目的文档，关联到的曲目写入 as 字段；
+// 不受限时返回 nil，调用方保持原有的关联方式
+func visibleMediaStages(ctx context.Context, localField, as string) []bson.D {
+	if domain.FolderScopeFromContext(ctx) == nil {
+		return nil
+	}
+	return []bson.D{
+		{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: domain.CollectionFileEntityAudioSceneMediaFile},
+			{Key: "localField", Value: localField},
+			{Key: "foreignField", Value: "_id"},
+			{Key: "pipeline", Value: []bson.D{{{Key: "$match", Value: bson.D{visibleFilter(ctx)}}}}},
+			{Key: "as", Value: as},
+		}}},
+		{{Key: "$unwind", Value: "$" + as}},
+	}
+}
+
+// folderEntityIDs 汇总匹配曲目引用的实体ID，idExprs 中每项为单个字段或 ID 数组表达式
+func folderEntityIDs(ctx context.Context, db mongo.Database, match bson.D, idExprs bson.A) ([]primitive.ObjectID, error) {
+	cursor, err := db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).Aggregate(ctx, []bson.D{
+		{{Key: "$match", Value: match}},
+		{{Key: "$project", Value: bson.D{{Key: "ids", Value: bson.D{{Key: "$setUnion", Value: wrapIDExprs(idExprs)}}}}}},
+		{{Key: "$unwind", Value: "$ids"}},
+		{{Key: "$group", Value: bson.D{{Key: "_id", Value: "$ids"}}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []struct {
+		ID string `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	ids := make([]primitive.ObjectID, 0, len(docs))
+	for _, doc := range docs {
+		if id, err := primitive.ObjectIDFromHex(doc.ID); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// wrapIDExprs $setUnion 只接受数组，单个字段包装为单元素数组
+func wrapIDExprs(idExprs bson.A) bson.A {
+	wrapped := make(bson.A, 0, len(idExprs))
+	for _, expr := range idExprs {
+		if field, ok := expr.(string); ok {
+			wrapped = append(wrapped, bson.A{field})
+			continue
+		}
+		wrapped = append(wrapped, expr)
+	}
+	return wrapped
+}
@@ -0,0 +1,281 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type genreRepository struct {
+	db         mongo.Database
+	collection string
+}
+
+func NewGenreRepository(db mongo.Database, collection string) scene_audio_route_interface.GenreRepository {
+	return &genreRepository{
+		db:         db,
+		collection: collection,
+	}
+}
+
+func (r *genreRepository) GetGenreTree(ctx context.Context, locale string) ([]scene_audio_route_models.GenreNode, error) {
+	genres, err := loadGenres(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	songCounts, err := r.countByGenre(ctx, domain.CollectionFileEntityAudioSceneMediaFile, bson.D{visibleFilter(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	albumScopeCond, err := visibleAlbumCondition(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	albumCounts, err := r.countByGenre(ctx, domain.CollectionFileEntityAudioSceneAlbum, albumScopeCond)
+	if err != nil {
+		return nil, err
+	}
+
+	// 节点以小写名称为键，媒体库中出现但未登记的流派补为顶层节点
+	nodes := make(map[string]*scene_audio_route_models.GenreNode)
+	parents := make(map[string]string)
+	for _, g := range genres {
+		key := strings.ToLower(g.Name)
+		nodes[key] = &scene_audio_route_models.GenreNode{
+			Name:        g.Name,
+			DisplayName: localizedGenreName(g, locale),
+			Parent:      g.Parent,
+		}
+		parents[key] = strings.ToLower(g.Parent)
+	}
+	for _, counts := range []map[string]genreCount{songCounts, albumCounts} {
+		for key, c := range counts {
+			if _, ok := nodes[key]; !ok {
+				nodes[key] = &scene_audio_route_models.GenreNode{Name: c.name, DisplayName: c.name}
+			}
+		}
+	}
+	for key, node := range nodes {
+		node.SongCount = songCounts[key].count
+		node.AlbumCount = albumCounts[key].count
+	}
+
+	children := make(map[string][]string)
+	var roots []string
+	for key := range nodes {
+		parent := parents[key]
+		if _, ok := nodes[parent]; parent == "" || !ok {
+			roots = append(roots, key)
+			continue
+		}
+		children[parent] = append(children[parent], key)
+	}
+
+	var build func(key string) scene_audio_route_models.GenreNode
+	build = func(key string) scene_audio_route_models.GenreNode {
+		node := *nodes[key]
+		node.TotalSongCount = node.SongCount
+		node.TotalAlbumCount = node.AlbumCount
+		node.Children = make([]scene_audio_route_models.GenreNode, 0, len(children[key]))
+		for _, child := range children[key] {
+			c := build(child)
+			node.TotalSongCount += c.TotalSongCount
+			node.TotalAlbumCount += c.TotalAlbumCount
+			node.Children = append(node.Children, c)
+		}
+		sortGenreNodes(node.Children)
+		return node
+	}
+
+	tree := make([]scene_audio_route_models.GenreNode, 0, len(roots))
+	for _, key := range roots {
+		tree = append(tree, build(key))
+	}
+	sortGenreNodes(tree)
+	return tree, nil
+}
+
+func (r *genreRepository) SaveGenre(
+	ctx context.Context,
+	genre scene_audio_route_models.GenreMetadata,
+) (*scene_audio_route_models.GenreMetadata, error) {
+	genres, err := loadGenres(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	// 沿父链向上查找，若回到自身则形成环
+	parents := make(map[string]string, len(genres))
+	for _, g := range genres {
+		parents[strings.ToLower(g.Name)] = strings.ToLower(g.Parent)
+	}
+	self := strings.ToLower(genre.Name)
+	for p, depth := strings.ToLower(genre.Parent), 0; p != ""; p, depth = parents[p], depth+1 {
+		if p == self || depth > len(parents) {
+			return nil, scene_audio_route_models.ErrGenreCycle
+		}
+	}
+
+	coll := r.db.Collection(r.collection)
+	now := time.Now().UTC()
+	filter := bson.M{"name": genreNamePattern(genre.Name)}
+	update := bson.M{
+		"$set": bson.M{
+			"name":            genre.Name,
+			"parent":          genre.Parent,
+			"localized_names": genre.LocalizedNames,
+			"updated_at":      now,
+		},
+		"$setOnInsert": bson.M{
+			"_id":        primitive.NewObjectID(),
+			"created_at": now,
+		},
+	}
+	if _, err := coll.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true)); err != nil {
+		return nil, fmt.Errorf("upsert failed: %w", err)
+	}
+
+	var saved scene_audio_route_models.GenreMetadata
+	if err := coll.FindOne(ctx, filter).Decode(&saved); err != nil {
+		return nil, fmt.Errorf("fetch saved genre failed: %w", err)
+	}
+	return &saved, nil
+}
+
+func (r *genreRepository) DeleteGenre(ctx context.Context, name string) (bool, error) {
+	coll := r.db.Collection(r.collection)
+
+	var genre scene_audio_route_models.GenreMetadata
+	if err := coll.FindOne(ctx, bson.M{"name": genreNamePattern(name)}).Decode(&genre); err != nil {
+		if errors.Is(err, driver.ErrNoDocuments) {
+			return false, nil
+		}
+		return false, fmt.Errorf("genre query failed: %w", err)
+	}
+
+	if _, err := coll.UpdateMany(ctx,
+		bson.M{"parent": genreNamePattern(genre.Name)},
+		bson.M{"$set": bson.M{"parent": genre.Parent, "updated_at": time.Now().UTC()}},
+	); err != nil {
+		return false, fmt.Errorf("reparent children failed: %w", err)
+	}
+
+	deleted, err := coll.DeleteOne(ctx, bson.M{"_id": genre.ID})
+	if err != nil {
+		return false, fmt.Errorf("delete failed: %w", err)
+	}
+	return deleted > 0, nil
+}
+
+type genreCount struct {
+	name  string
+	count int
+}
+
+// countByGenre 按小写流派名汇总，保留首次出现的原始写法用于展示；visible 为调用方可见条目的条件
+func (r *genreRepository) countByGenre(ctx context.Context, collection string, visible bson.D) (map[string]genreCount, error) {
+	match := append(bson.D{{Key: "genre", Value: bson.D{{Key: "$nin", Value: bson.A{"", nil}}}}}, visible...)
+	cursor, err := r.db.Collection(collection).Aggregate(ctx, []bson.D{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "$toLower", Value: "$genre"}}},
+			{Key: "name", Value: bson.D{{Key: "$first", Value: "$genre"}}},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("genre count failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		Key   string `bson:"_id"`
+		Name  string `bson:"name"`
+		Count int    `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("decode genre count failed: %w", err)
+	}
+
+	counts := make(map[string]genreCount, len(rows))
+	for _, row := range rows {
+		counts[row.Key] = genreCount{name: row.Name, count: row.Count}
+	}
+	return counts, nil
+}
+
+func loadGenres(ctx context.Context, db mongo.Database) ([]scene_audio_route_models.GenreMetadata, error) {
+	cursor, err := db.Collection(domain.CollectionFileEntityAudioSceneGenre).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("genre query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var genres []scene_audio_route_models.GenreMetadata
+	if err := cursor.All(ctx, &genres); err != nil {
+		return nil, fmt.Errorf("decode genres failed: %w", err)
+	}
+	return genres, nil
+}
+
+// genreSubtree 返回流派自身及全部后代名称
+func genreSubtree(genres []scene_audio_route_models.GenreMetadata, root string) []string {
+	children := make(map[string][]string)
+	for _, g := range genres {
+		if g.Parent != "" {
+			parent := strings.ToLower(g.Parent)
+			children[parent] = append(children[parent], g.Name)
+		}
+	}
+
+	names := []string{root}
+	visited := map[string]bool{strings.ToLower(root): true}
+	for i := 0; i < len(names); i++ {
+		for _, child := range children[strings.ToLower(names[i])] {
+			if !visited[strings.ToLower(child)] {
+				visited[strings.ToLower(child)] = true
+				names = append(names, child)
+			}
+		}
+	}
+	return names
+}
+
+// localizedGenreName 依次尝试完整locale、语言前缀，最后回退到原名
+func localizedGenreName(genre scene_audio_route_models.GenreMetadata, locale string) string {
+	if locale == "" || len(genre.LocalizedNames) == 0 {
+		return genre.Name
+	}
+	if name, ok := genre.LocalizedNames[locale]; ok && name != "" {
+		return name
+	}
+	if lang, _, found := strings.Cut(locale, "-"); found {
+		if name, ok := genre.LocalizedNames[lang]; ok && name != "" {
+			return name
+		}
+	}
+	return genre.Name
+}
+
+func genreNamePattern(name string) primitive.Regex {
+	return primitive.Regex{Pattern: "^" + regexp.QuoteMeta(name) + "$", Options: "i"}
+}
+
+func sortGenreNodes(nodes []scene_audio_route_models.GenreNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return strings.ToLower(nodes[i].Name) < strings.ToLower(nodes[j].Name)
+	})
+}
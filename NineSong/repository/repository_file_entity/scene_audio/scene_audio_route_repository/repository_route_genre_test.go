@@ -0,0 +1,71 @@
+package scene_audio_route_repository
+
+import (
+	"testing"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenreSubtree(t *testing.T) {
+	genres := []scene_audio_route_models.GenreMetadata{
+		{Name: "Rock"},
+		{Name: "Punk", Parent: "Rock"},
+		{Name: "Hardcore", Parent: "punk"},
+		{Name: "Metal", Parent: "ROCK"},
+		{Name: "Jazz"},
+		// 环形数据不应导致死循环
+		{Name: "Loop A", Parent: "Loop B"},
+		{Name: "Loop B", Parent: "Loop A"},
+	}
+
+	tests := []struct {
+		name string
+		root string
+		want []string
+	}{
+		{name: "whole tree with case insensitive parents", root: "Rock", want: []string{"Rock", "Punk", "Metal", "Hardcore"}},
+		{name: "inner node", root: "punk", want: []string{"punk", "Hardcore"}},
+		{name: "leaf", root: "Jazz", want: []string{"Jazz"}},
+		{name: "unregistered genre", root: "Ambient", want: []string{"Ambient"}},
+		{name: "cycle", root: "Loop A", want: []string{"Loop A", "Loop B"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, genreSubtree(genres, tt.root))
+		})
+	}
+}
+
+func TestLocalizedGenreName(t *testing.T) {
+	genre := scene_audio_route_models.GenreMetadata{
+		Name: "Rock",
+		LocalizedNames: map[string]string{
+			"zh":    "摇滚",
+			"zh-TW": "搖滾",
+			"ja":    "",
+		},
+	}
+
+	tests := []struct {
+		name   string
+		genre  scene_audio_route_models.GenreMetadata
+		locale string
+		want   string
+	}{
+		{name: "exact locale", genre: genre, locale: "zh-TW", want: "搖滾"},
+		{name: "language fallback", genre: genre, locale: "zh-CN", want: "摇滚"},
+		{name: "plain language", genre: genre, locale: "zh", want: "摇滚"},
+		{name: "empty translation", genre: genre, locale: "ja-JP", want: "Rock"},
+		{name: "missing locale", genre: genre, locale: "de", want: "Rock"},
+		{name: "no locale", genre: genre, locale: "", want: "Rock"},
+		{name: "no translations", genre: scene_audio_route_models.GenreMetadata{Name: "Jazz"}, locale: "zh", want: "Jazz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, localizedGenreName(tt.genre, tt.locale))
+		})
+	}
+}
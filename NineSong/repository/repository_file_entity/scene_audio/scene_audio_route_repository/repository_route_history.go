@@ -0,0 +1,108 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type historyRepository struct {
+	db mongo.Database
+}
+
+func NewHistoryRepository(db mongo.Database) scene_audio_route_interface.HistoryRepository {
+	return &historyRepository{db: db}
+}
+
+func (r *historyRepository) GetHistory(
+	ctx context.Context,
+	filter scene_audio_route_models.PlayHistoryFilter,
+) ([]scene_audio_route_models.PlayHistoryItem, int64, error) {
+	match := bson.D{{Key: "user_id", Value: filter.UserID}}
+	if filter.MediaFileID != "" {
+		mediaFileID, err := primitive.ObjectIDFromHex(filter.MediaFileID)
+		if err != nil {
+			return nil, 0, errors.New("invalid media file id format")
+		}
+		match = append(match, bson.E{Key: "media_file_id", Value: mediaFileID})
+	}
+	playedAt := bson.D{}
+	if !filter.From.IsZero() {
+		playedAt = append(playedAt, bson.E{Key: "$gte", Value: filter.From})
+	}
+	if !filter.To.IsZero() {
+		playedAt = append(playedAt, bson.E{Key: "$lt", Value: filter.To})
+	}
+	if len(playedAt) > 0 {
+		match = append(match, bson.E{Key: "played_at", Value: playedAt})
+	}
+
+	coll := r.db.Collection(domain.CollectionFileEntityAudioScenePlayHistory)
+	// 调用方受限时只统计并返回可访问媒体库中曲目的播放记录，需在分页前关联曲目
+	visible := visibleMediaStages(ctx, "media_file_id", "media_file")
+	total, err := r.count(ctx, coll, match, visible)
+	if err != nil {
+		return nil, 0, fmt.Errorf("count play history failed: %w", err)
+	}
+
+	pipeline := append([]bson.D{{{Key: "$match", Value: match}}}, visible...)
+	pipeline = append(pipeline, bson.D{{Key: "$sort", Value: bson.D{{Key: "played_at", Value: -1}, {Key: "_id", Value: -1}}}})
+	if filter.Skip > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$skip", Value: filter.Skip}})
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$limit", Value: filter.Limit}})
+	if visible == nil {
+		pipeline = append(pipeline,
+			bson.D{{Key: "$lookup", Value: bson.M{
+				"from":         domain.CollectionFileEntityAudioSceneMediaFile,
+				"localField":   "media_file_id",
+				"foreignField": "_id",
+				"as":           "media_file",
+			}}},
+			bson.D{{Key: "$unwind", Value: bson.M{"path": "$media_file", "preserveNullAndEmptyArrays": true}}},
+		)
+	}
+
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, fmt.Errorf("play history query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	items := make([]scene_audio_route_models.PlayHistoryItem, 0)
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, 0, fmt.Errorf("decode play history failed: %w", err)
+	}
+	return items, total, nil
+}
+
+// count 不需要关联曲目时直接按索引计数
+func (r *historyRepository) count(ctx context.Context, coll mongo.Collection, match bson.D, visible []bson.D) (int64, error) {
+	if visible == nil {
+		return coll.CountDocuments(ctx, match)
+	}
+	pipeline := append([]bson.D{{{Key: "$match", Value: match}}}, visible...)
+	cursor, err := coll.Aggregate(ctx, append(pipeline, bson.D{{Key: "$count", Value: "total"}}))
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		Total int64 `bson:"total"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	return rows[0].Total, nil
+}
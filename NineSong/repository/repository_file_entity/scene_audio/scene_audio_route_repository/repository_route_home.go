@@ -32,8 +32,14 @@ func (r *homeRepository) GetRandomArtistList(
 		limit = 50
 	}
 
+	scopeCond, err := visibleArtistCondition(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
 	// 构建随机查询
 	pipeline := []bson.M{
+		{"$match": append(bson.D{reviewVisibleFilter()}, scopeCond...)},
 		{"$sample": bson.M{"size": limit + skip}},
 		{"$skip": skip},
 		{"$limit": limit},
@@ -65,7 +71,13 @@ func (r *homeRepository) GetRandomAlbumList(
 		limit = 50
 	}
 
+	scopeCond, err := visibleAlbumCondition(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
 	pipeline := []bson.M{
+		{"$match": append(bson.D{}, scopeCond...)},
 		{"$sample": bson.M{"size": limit + skip}},
 		{"$skip": skip},
 		{"$limit": limit},
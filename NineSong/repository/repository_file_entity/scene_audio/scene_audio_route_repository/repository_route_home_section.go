@@ -0,0 +1,270 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// homeMixWindow 每日歌单只参考近期的播放历史
+	homeMixWindow = 90 * 24 * time.Hour
+	// homeMixCandidates 每位种子艺术家最多取多少首候选曲目再抽样
+	homeMixCandidates = 500
+)
+
+type homeSectionRepository struct {
+	db mongo.Database
+}
+
+func NewHomeSectionRepository(db mongo.Database) scene_audio_route_interface.HomeSectionRepository {
+	return &homeSectionRepository{db: db}
+}
+
+func (r *homeSectionRepository) GetRecentlyAddedAlbums(ctx context.Context, limit int) ([]scene_audio_route_models.AlbumMetadata, error) {
+	scopeCond, err := visibleAlbumCondition(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneAlbum).Find(ctx, append(bson.D{}, scopeCond...),
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).SetLimit(int64(limit)))
+	if err != nil {
+		return nil, fmt.Errorf("recently added query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	albums := make([]scene_audio_route_models.AlbumMetadata, 0)
+	if err := cursor.All(ctx, &albums); err != nil {
+		return nil, fmt.Errorf("decode albums failed: %w", err)
+	}
+	return albums, nil
+}
+
+func (r *homeSectionRepository) GetContinueListeningAlbums(ctx context.Context, userId string, limit int) ([]scene_audio_route_models.AlbumMetadata, error) {
+	ids, err := r.groupPlayHistory(ctx, bson.M{"user_id": userId, "album_id": bson.M{"$nin": bson.A{"", nil}}},
+		"$album_id", bson.D{{Key: "last_played_at", Value: -1}}, limit)
+	if err != nil {
+		return nil, err
+	}
+	scopeCond, err := visibleAlbumCondition(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	albums := make([]scene_audio_route_models.AlbumMetadata, 0, len(ids))
+	if err := r.findInOrder(ctx, domain.CollectionFileEntityAudioSceneAlbum, append(bson.D{}, scopeCond...), ids, &albums); err != nil {
+		return nil, err
+	}
+	return albums, nil
+}
+
+// GetFavoriteMediaFiles 该用户收藏的曲目，按收藏时间倒序
+func (r *homeSectionRepository) GetFavoriteMediaFiles(ctx context.Context, userId string, limit int) ([]scene_audio_route_models.MediaFileMetadata, error) {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneAnnotation).Find(ctx,
+		bson.M{"user_id": userId, "item_type": scene_audio_route_models.AnnotationItemMedia, "starred": true},
+		options.Find().
+			SetProjection(bson.M{"item_id": 1}).
+			SetSort(bson.D{{Key: "starred_at", Value: -1}, {Key: "_id", Value: -1}}).
+			SetLimit(int64(limit)))
+	if err != nil {
+		return nil, fmt.Errorf("favorites query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		ItemID primitive.ObjectID `bson:"item_id"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("decode favorites failed: %w", err)
+	}
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ItemID.Hex())
+	}
+
+	mediaFiles := make([]scene_audio_route_models.MediaFileMetadata, 0, len(ids))
+	if err := r.findInOrder(ctx, domain.CollectionFileEntityAudioSceneMediaFile, bson.D{visibleFilter(ctx)}, ids, &mediaFiles); err != nil {
+		return nil, err
+	}
+	return mediaFiles, nil
+}
+
+func (r *homeSectionRepository) GetOnThisDayMediaFiles(ctx context.Context, userId string, day time.Time, limit int) ([]scene_audio_route_models.MediaFileMetadata, error) {
+	day = day.UTC()
+	match := bson.M{
+		"user_id":   userId,
+		"played_at": bson.M{"$lt": time.Date(day.Year(), 1, 1, 0, 0, 0, 0, time.UTC)},
+		"$expr": bson.M{"$and": bson.A{
+			bson.M{"$eq": bson.A{bson.M{"$month": "$played_at"}, int(day.Month())}},
+			bson.M{"$eq": bson.A{bson.M{"$dayOfMonth": "$played_at"}, day.Day()}},
+		}},
+	}
+	ids, err := r.groupPlayHistory(ctx, match, "$media_file_id",
+		bson.D{{Key: "count", Value: -1}, {Key: "last_played_at", Value: -1}}, limit)
+	if err != nil {
+		return nil, err
+	}
+	mediaFiles := make([]scene_audio_route_models.MediaFileMetadata, 0, len(ids))
+	if err := r.findInOrder(ctx, domain.CollectionFileEntityAudioSceneMediaFile, bson.D{visibleFilter(ctx)}, ids, &mediaFiles); err != nil {
+		return nil, err
+	}
+	return mediaFiles, nil
+}
+
+func (r *homeSectionRepository) GetDailyMixes(ctx context.Context, userId string, day time.Time, limit int) ([]scene_audio_route_models.HomeMix, error) {
+	artistIDs, err := r.groupPlayHistory(ctx,
+		bson.M{
+			"user_id":   userId,
+			"artist_id": bson.M{"$nin": bson.A{"", nil}},
+			"played_at": bson.M{"$gte": day.Add(-homeMixWindow)},
+		},
+		"$artist_id", bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var artists []struct {
+		ID   primitive.ObjectID `bson:"_id"`
+		Name string             `bson:"name"`
+	}
+	scopeCond, err := visibleArtistCondition(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.findInOrder(ctx, domain.CollectionFileEntityAudioSceneArtist, append(bson.D{}, scopeCond...), artistIDs, &artists); err != nil {
+		return nil, err
+	}
+
+	mixes := make([]scene_audio_route_models.HomeMix, 0, len(artists))
+	for _, artist := range artists {
+		mediaFiles, err := r.mixTracks(ctx, userId, day, artist.ID.Hex())
+		if err != nil {
+			return nil, err
+		}
+		if len(mediaFiles) == 0 {
+			continue
+		}
+		mixes = append(mixes, scene_audio_route_models.HomeMix{
+			ArtistID:   artist.ID.Hex(),
+			ArtistName: artist.Name,
+			MediaFiles: mediaFiles,
+		})
+	}
+	return mixes, nil
+}
+
+// mixTracks 从艺术家参与的曲目中按用户、日期与艺术家确定的种子抽样，同一天重复请求结果一致
+func (r *homeSectionRepository) mixTracks(ctx context.Context, userId string, day time.Time, artistID string) ([]scene_audio_route_models.MediaFileMetadata, error) {
+	coll := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile)
+	filter := bson.D{
+		{Key: "$or", Value: bson.A{
+			bson.M{"artist_id": artistID},
+			bson.M{"all_artist_ids.artist_id": artistID},
+		}},
+		visibleFilter(ctx),
+	}
+	cursor, err := coll.Find(ctx, filter, options.Find().
+		SetProjection(bson.M{"_id": 1}).
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(homeMixCandidates))
+	if err != nil {
+		return nil, fmt.Errorf("mix candidates query failed: %w", err)
+	}
+	var candidates []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	err = cursor.All(ctx, &candidates)
+	_ = cursor.Close(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("decode mix candidates failed: %w", err)
+	}
+
+	seed := fnv.New64a()
+	_, _ = seed.Write([]byte(userId + "|" + day.UTC().Format(time.DateOnly) + "|" + artistID))
+	rng := rand.New(rand.NewSource(int64(seed.Sum64())))
+	rng.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+	if len(candidates) > scene_audio_route_models.HomeDailyMixTracks {
+		candidates = candidates[:scene_audio_route_models.HomeDailyMixTracks]
+	}
+
+	ids := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		ids = append(ids, c.ID.Hex())
+	}
+	mediaFiles := make([]scene_audio_route_models.MediaFileMetadata, 0, len(ids))
+	if err := r.findInOrder(ctx, domain.CollectionFileEntityAudioSceneMediaFile, bson.M{}, ids, &mediaFiles); err != nil {
+		return nil, err
+	}
+	return mediaFiles, nil
+}
+
+// groupPlayHistory 按 field 汇总播放记录（count 与 last_played_at），按 sort 排序后返回前 limit 个条目ID（十六进制）
+func (r *homeSectionRepository) groupPlayHistory(ctx context.Context, match bson.M, field string, sort bson.D, limit int) ([]string, error) {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioScenePlayHistory).Aggregate(ctx, []bson.D{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: field},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "last_played_at", Value: bson.D{{Key: "$max", Value: "$played_at"}}},
+		}}},
+		{{Key: "$sort", Value: sort}},
+		{{Key: "$limit", Value: limit}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("play history aggregate failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []bson.M
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("decode play history failed: %w", err)
+	}
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		switch id := row["_id"].(type) {
+		case primitive.ObjectID:
+			ids = append(ids, id.Hex())
+		case string:
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// findInOrder 按 ids 的顺序查询条目，已删除或不满足 filter 的条目跳过
+func (r *homeSectionRepository) findInOrder(ctx context.Context, collection string, filter interface{}, ids []string, out interface{}) error {
+	oids := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+			oids = append(oids, oid)
+		}
+	}
+	if len(oids) == 0 {
+		return nil
+	}
+
+	cursor, err := r.db.Collection(collection).Aggregate(ctx, []bson.D{
+		{{Key: "$match", Value: bson.M{"_id": bson.M{"$in": oids}}}},
+		{{Key: "$match", Value: filter}},
+		{{Key: "$addFields", Value: bson.M{"_order": bson.M{"$indexOfArray": bson.A{oids, "$_id"}}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_order", Value: 1}}}},
+		{{Key: "$project", Value: bson.M{"_order": 0}}},
+	})
+	if err != nil {
+		return fmt.Errorf("query %s failed: %w", collection, err)
+	}
+	defer cursor.Close(ctx)
+	if err := cursor.All(ctx, out); err != nil {
+		return fmt.Errorf("decode %s failed: %w", collection, err)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type internetRadioRepository struct {
+	db mongo.Database
+}
+
+func NewInternetRadioRepository(db mongo.Database) scene_audio_route_interface.InternetRadioRepository {
+	return &internetRadioRepository{db: db}
+}
+
+func (r *internetRadioRepository) GetStations(ctx context.Context, userId string) ([]scene_audio_route_models.InternetRadioStation, error) {
+	coll := r.db.Collection(domain.CollectionFileEntityAudioSceneInternetRadio)
+	cursor, err := coll.Find(ctx, bson.M{"user_id": userId}, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("find operation failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	stations := make([]scene_audio_route_models.InternetRadioStation, 0)
+	if err := cursor.All(ctx, &stations); err != nil {
+		return nil, fmt.Errorf("decode error: %w", err)
+	}
+	return stations, nil
+}
+
+func (r *internetRadioRepository) GetStation(ctx context.Context, userId, id string) (*scene_audio_route_models.InternetRadioStation, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, scene_audio_route_models.ErrInvalidRadioStation
+	}
+
+	var station scene_audio_route_models.InternetRadioStation
+	err = r.db.Collection(domain.CollectionFileEntityAudioSceneInternetRadio).
+		FindOne(ctx, bson.M{"_id": objID, "user_id": userId}).Decode(&station)
+	if err != nil {
+		if errors.Is(err, driver.ErrNoDocuments) {
+			return nil, fmt.Errorf("internet radio station %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("find one error: %w", err)
+	}
+	return &station, nil
+}
+
+func (r *internetRadioRepository) CountStations(ctx context.Context, userId string) (int64, error) {
+	return r.db.Collection(domain.CollectionFileEntityAudioSceneInternetRadio).CountDocuments(ctx, bson.M{"user_id": userId})
+}
+
+func (r *internetRadioRepository) CreateStation(
+	ctx context.Context,
+	station scene_audio_route_models.InternetRadioStation,
+) (*scene_audio_route_models.InternetRadioStation, error) {
+	now := time.Now().UTC()
+	station.ID = primitive.NewObjectID()
+	station.CreatedAt, station.UpdatedAt = now, now
+
+	if _, err := r.db.Collection(domain.CollectionFileEntityAudioSceneInternetRadio).InsertOne(ctx, station); err != nil {
+		if driver.IsDuplicateKeyError(err) {
+			return nil, scene_audio_route_models.ErrRadioStationExists
+		}
+		return nil, fmt.Errorf("insert failed: %w", err)
+	}
+	return &station, nil
+}
+
+func (r *internetRadioRepository) UpdateStation(
+	ctx context.Context,
+	station scene_audio_route_models.InternetRadioStation,
+) (*scene_audio_route_models.InternetRadioStation, error) {
+	matched, err := r.db.Collection(domain.CollectionFileEntityAudioSceneInternetRadio).UpdateOne(ctx,
+		bson.M{"_id": station.ID, "user_id": station.UserID},
+		bson.M{"$set": bson.M{
+			"name":          station.Name,
+			"stream_url":    station.StreamURL,
+			"home_page_url": station.HomePageURL,
+			"updated_at":    time.Now().UTC(),
+		}},
+	)
+	if err != nil {
+		if driver.IsDuplicateKeyError(err) {
+			return nil, scene_audio_route_models.ErrRadioStationExists
+		}
+		return nil, fmt.Errorf("update failed: %w", err)
+	}
+	if matched.MatchedCount == 0 {
+		return nil, fmt.Errorf("internet radio station %w", domain.ErrNotFound)
+	}
+	return r.GetStation(ctx, station.UserID, station.ID.Hex())
+}
+
+func (r *internetRadioRepository) DeleteStation(ctx context.Context, userId, id string) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, scene_audio_route_models.ErrInvalidRadioStation
+	}
+	deleted, err := r.db.Collection(domain.CollectionFileEntityAudioSceneInternetRadio).
+		DeleteOne(ctx, bson.M{"_id": objID, "user_id": userId})
+	if err != nil {
+		return false, fmt.Errorf("delete failed: %w", err)
+	}
+	return deleted > 0, nil
+}
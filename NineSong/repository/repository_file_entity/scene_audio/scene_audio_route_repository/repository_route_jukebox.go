@@ -0,0 +1,51 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type jukeboxRepository struct {
+	db mongo.Database
+}
+
+func NewJukeboxRepository(db mongo.Database) scene_audio_route_interface.JukeboxRepository {
+	return &jukeboxRepository{db: db}
+}
+
+func (r *jukeboxRepository) GetMediaFiles(ctx context.Context, ids []primitive.ObjectID) ([]scene_audio_route_models.MediaFileMetadata, error) {
+	if len(ids) == 0 {
+		return []scene_audio_route_models.MediaFileMetadata{}, nil
+	}
+
+	coll := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile)
+	cursor, err := coll.Find(ctx, bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}}, visibleFilter(ctx)})
+	if err != nil {
+		return nil, fmt.Errorf("media query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var files []scene_audio_route_models.MediaFileMetadata
+	if err := cursor.All(ctx, &files); err != nil {
+		return nil, fmt.Errorf("decode error: %w", err)
+	}
+
+	byID := make(map[primitive.ObjectID]scene_audio_route_models.MediaFileMetadata, len(files))
+	for _, f := range files {
+		byID[f.ID] = f
+	}
+	items := make([]scene_audio_route_models.MediaFileMetadata, 0, len(ids))
+	for _, id := range ids {
+		if f, ok := byID[id]; ok {
+			items = append(items, f)
+		}
+	}
+	return items, nil
+}
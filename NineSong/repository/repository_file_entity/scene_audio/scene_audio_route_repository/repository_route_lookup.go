@@ -0,0 +1,67 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type lookupRepository struct {
+	db mongo.Database
+}
+
+func NewLookupRepository(db mongo.Database) scene_audio_route_interface.LookupRepository {
+	return &lookupRepository{db: db}
+}
+
+func (r *lookupRepository) GetMediaFilesByISRC(ctx context.Context, isrc string) ([]scene_audio_route_models.MediaFileMetadata, error) {
+	pipeline := []bson.D{
+		{{Key: "$match", Value: bson.D{{Key: "isrc", Value: isrc}, visibleFilter(ctx)}}},
+		{{Key: "$sort", Value: bson.D{{Key: "order_album_name", Value: 1}, {Key: "_id", Value: 1}}}},
+	}
+	pipeline = append(pipeline, annotationFallbackStages(ctx, scene_audio_route_models.AnnotationItemMedia, "")...)
+
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("isrc lookup failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	results := make([]scene_audio_route_models.MediaFileMetadata, 0)
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("decode isrc lookup failed: %w", err)
+	}
+	return results, nil
+}
+
+func (r *lookupRepository) GetAlbumsByBarcode(ctx context.Context, barcode string) ([]scene_audio_route_models.AlbumMetadata, error) {
+	scopeCond, err := visibleAlbumCondition(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	pipeline := []bson.D{
+		{{Key: "$match", Value: bson.D{{Key: "barcode", Value: barcode}}}},
+	}
+	if len(scopeCond) > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: scopeCond}})
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}})
+	pipeline = append(pipeline, annotationFallbackStages(ctx, scene_audio_route_models.AnnotationItemAlbum, "")...)
+
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneAlbum).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("barcode lookup failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	results := make([]scene_audio_route_models.AlbumMetadata, 0)
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("decode barcode lookup failed: %w", err)
+	}
+	return results, nil
+}
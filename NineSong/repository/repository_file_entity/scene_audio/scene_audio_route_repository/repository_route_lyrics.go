@@ -0,0 +1,215 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_lyrics/scene_audio_lyrics_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_lyrics/scene_audio_lyrics_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/lyrics_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 在线歌词源均未找到时记录空结果，期间不再重复请求
+const onlineLyricsMissTTL = 7 * 24 * time.Hour
+
+type lyricsRepository struct {
+	db         mongo.Database
+	collection string
+	providers  []scene_audio_lyrics_interface.LyricsProvider
+}
+
+// NewLyricsRepository providers 为空时不从在线歌词源获取
+func NewLyricsRepository(
+	db mongo.Database,
+	collection string,
+	providers []scene_audio_lyrics_interface.LyricsProvider,
+) scene_audio_route_interface.LyricsRepository {
+	return &lyricsRepository{
+		db:         db,
+		collection: collection,
+		providers:  providers,
+	}
+}
+
+type lyricsMedia struct {
+	Title    string  `bson:"title"`
+	Artist   string  `bson:"artist"`
+	Album    string  `bson:"album"`
+	Duration float64 `bson:"duration"`
+	Lyrics   string  `bson:"lyrics"`
+}
+
+// lyricsQuery 曲目时长按纳秒存储，歌词源按秒匹配
+func (m *lyricsMedia) lyricsQuery() scene_audio_lyrics_models.LyricsQuery {
+	return scene_audio_lyrics_models.LyricsQuery{
+		Title:    m.Title,
+		Artist:   m.Artist,
+		Album:    m.Album,
+		Duration: time.Duration(m.Duration).Seconds(),
+	}
+}
+
+// GetLyrics 优先读取歌词记录（手动固定、扫描写入或在线缓存），尚未重新扫描的曲目回退到曲目文档中的内嵌歌词，
+// 本地均无歌词时依次请求在线歌词源；调用方不可见的曲目按不存在处理
+func (r *lyricsRepository) GetLyrics(ctx context.Context, mediaFileId string) (*scene_audio_route_models.MediaLyrics, error) {
+	media, err := r.getMedia(ctx, mediaFileId)
+	if err != nil {
+		return nil, err
+	}
+
+	var record scene_audio_db_models.MediaLyricsMetadata
+	err = r.db.Collection(r.collection).FindOne(ctx, bson.M{"media_id": mediaFileId}).Decode(&record)
+	switch {
+	case err == nil:
+		if strings.TrimSpace(record.Lyrics) != "" {
+			return buildMediaLyrics(mediaFileId, record.Type, record.Provider, record.Lyrics), nil
+		}
+	case errors.Is(err, driver.ErrNoDocuments):
+		record = scene_audio_db_models.MediaLyricsMetadata{}
+	default:
+		return nil, fmt.Errorf("lyrics query failed: %w", err)
+	}
+
+	if strings.TrimSpace(media.Lyrics) != "" {
+		return buildMediaLyrics(mediaFileId, scene_audio_db_models.LyricsTypeEmbedded, "", media.Lyrics), nil
+	}
+
+	if len(r.providers) == 0 ||
+		(record.Type == scene_audio_db_models.LyricsTypeOnline && time.Since(record.UpdatedAt) < onlineLyricsMissTTL) {
+		return nil, domain.ErrNotFound
+	}
+	return r.fetchOnline(ctx, mediaFileId, media)
+}
+
+// fetchOnline 依次尝试在线歌词源并缓存结果；仅在所有歌词源都明确未找到时记录空结果，请求失败不缓存
+func (r *lyricsRepository) fetchOnline(ctx context.Context, mediaFileId string, media *lyricsMedia) (*scene_audio_route_models.MediaLyrics, error) {
+	query := media.lyricsQuery()
+	if strings.TrimSpace(query.Title) == "" {
+		return nil, domain.ErrNotFound
+	}
+
+	failed := false
+	for _, provider := range r.providers {
+		text, err := provider.FetchLyrics(ctx, query)
+		if err != nil {
+			if !errors.Is(err, scene_audio_lyrics_models.ErrNotFound) {
+				log.Printf("在线歌词获取失败 %s %s: %v", provider.Name(), mediaFileId, err)
+				failed = true
+			}
+			continue
+		}
+		if err := r.save(ctx, mediaFileId, scene_audio_db_models.LyricsTypeOnline, provider.Name(), text, false); err != nil {
+			log.Printf("在线歌词缓存失败 %s: %v", mediaFileId, err)
+		}
+		return buildMediaLyrics(mediaFileId, scene_audio_db_models.LyricsTypeOnline, provider.Name(), text), nil
+	}
+
+	if !failed {
+		if err := r.save(ctx, mediaFileId, scene_audio_db_models.LyricsTypeOnline, "", "", false); err != nil {
+			log.Printf("在线歌词缓存失败 %s: %v", mediaFileId, err)
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *lyricsRepository) PinLyrics(ctx context.Context, mediaFileId, lyrics string) (*scene_audio_route_models.MediaLyrics, error) {
+	if _, err := r.getMedia(ctx, mediaFileId); err != nil {
+		return nil, err
+	}
+	lyrics = strings.TrimSpace(lyrics)
+	if err := r.save(ctx, mediaFileId, scene_audio_db_models.LyricsTypeManual, "", lyrics, true); err != nil {
+		return nil, err
+	}
+	return buildMediaLyrics(mediaFileId, scene_audio_db_models.LyricsTypeManual, "", lyrics), nil
+}
+
+// UnpinLyrics 删除手动固定的歌词；外挂歌词在下次完整扫描时恢复，内嵌歌词仍可从曲目文档回退读取
+func (r *lyricsRepository) UnpinLyrics(ctx context.Context, mediaFileId string) error {
+	if _, err := r.getMedia(ctx, mediaFileId); err != nil {
+		return err
+	}
+	deleted, err := r.db.Collection(r.collection).DeleteMany(ctx, bson.M{
+		"media_id":    mediaFileId,
+		"lyrics_type": scene_audio_db_models.LyricsTypeManual,
+	})
+	if err != nil {
+		return fmt.Errorf("delete lyrics failed: %w", err)
+	}
+	if deleted == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// save 按 media_id 写入歌词记录；overwriteManual 为 false 时不覆盖手动固定的歌词
+func (r *lyricsRepository) save(ctx context.Context, mediaFileId, lyricsType, provider, text string, overwriteManual bool) error {
+	filter := bson.M{"media_id": mediaFileId}
+	if !overwriteManual {
+		filter["lyrics_type"] = bson.M{"$ne": scene_audio_db_models.LyricsTypeManual}
+	}
+	sum := md5.Sum([]byte(text))
+	update := bson.M{
+		"$set": bson.M{
+			"lyrics_hash":     hex.EncodeToString(sum[:]),
+			"lyrics_type":     lyricsType,
+			"lyrics_path":     "",
+			"lyrics_provider": provider,
+			"lyrics":          text,
+			"synced":          lyrics_util.IsSynced(text),
+			"updated_at":      time.Now().UTC(),
+		},
+		"$setOnInsert": bson.M{"_id": primitive.NewObjectID()},
+	}
+	_, err := r.db.Collection(r.collection).UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil && !driver.IsDuplicateKeyError(err) {
+		return fmt.Errorf("lyrics upsert failed: %w", err)
+	}
+	return nil
+}
+
+func (r *lyricsRepository) getMedia(ctx context.Context, mediaFileId string) (*lyricsMedia, error) {
+	objID, err := primitive.ObjectIDFromHex(mediaFileId)
+	if err != nil {
+		return nil, errors.New("invalid media file id format")
+	}
+	var media lyricsMedia
+	err = r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).FindOne(ctx, bson.D{{Key: "_id", Value: objID}, visibleFilter(ctx)}).Decode(&media)
+	if err != nil {
+		if errors.Is(err, driver.ErrNoDocuments) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("media query failed: %w", err)
+	}
+	return &media, nil
+}
+
+func buildMediaLyrics(mediaFileId, source, provider, text string) *scene_audio_route_models.MediaLyrics {
+	lines, plain := lyrics_util.Parse(text)
+	result := &scene_audio_route_models.MediaLyrics{
+		MediaFileID: mediaFileId,
+		Source:      source,
+		Provider:    provider,
+		Synced:      len(lines) > 0,
+		Plain:       plain,
+		Lines:       make([]scene_audio_route_models.LyricsLine, 0, len(lines)),
+	}
+	for _, line := range lines {
+		result.Lines = append(result.Lines, scene_audio_route_models.LyricsLine{Time: line.TimeMs, Text: line.Text})
+	}
+	return result
+}
@@ -0,0 +1,28 @@
+package scene_audio_route_repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLyricsQueryDurationSeconds(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration float64
+		want     float64
+	}{
+		{name: "stored nanoseconds", duration: float64(3*time.Minute + 25*time.Second + 500*time.Millisecond), want: 205.5},
+		{name: "unknown duration", duration: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			media := &lyricsMedia{Title: "Song", Artist: "Artist", Duration: tt.duration}
+			query := media.lyricsQuery()
+			assert.Equal(t, tt.want, query.Duration)
+			assert.Equal(t, "Song", query.Title)
+		})
+	}
+}
@@ -3,13 +3,12 @@ package scene_audio_route_repository
 import (
 	"context"
 	"fmt"
-	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
 	"go.mongodb.org/mongo-driver/bson"
 	"strconv"
-	"strings"
 	"time"
 )
 
@@ -27,58 +26,29 @@ func NewMediaFileRepository(db mongo.Database, collection string) scene_audio_ro
 
 func (r *mediaFileRepository) GetMediaFileItems(
 	ctx context.Context,
-	start, end, sort, order, search, starred, albumId, artistId, year string,
+	start, end, sort, order, search, starred, albumId, artistId, year, folderId, available, custom string,
 ) ([]scene_audio_route_models.MediaFileMetadata, error) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 	coll := r.db.Collection(r.collection)
-
-	// 构建聚合管道（完全使用bson.D结构）
-	pipeline := []bson.D{
-		{
-			{Key: "$lookup", Value: bson.D{
-				{Key: "from", Value: domain.CollectionFileEntityAudioSceneAnnotation},
-				{Key: "let", Value: bson.D{{Key: "mediaId", Value: "$_id"}}},
-				{Key: "pipeline", Value: []bson.D{
-					{
-						{Key: "$match", Value: bson.D{
-							{Key: "$expr", Value: bson.D{
-								{Key: "$and", Value: bson.A{
-									bson.D{{Key: "$eq", Value: bson.A{"$item_id", "$$mediaId"}}},
-									bson.D{{Key: "$eq", Value: bson.A{"$item_type", "media"}}},
-								}},
-							}},
-						}},
-					},
-				}},
-				{Key: "as", Value: "annotations"},
-			}},
-		},
-		{
-			{Key: "$unwind", Value: bson.D{
-				{Key: "path", Value: "$annotations"},
-				{Key: "preserveNullAndEmptyArrays", Value: true},
-			}},
-		},
-		{
-			{Key: "$addFields", Value: bson.D{
-				{Key: "play_count", Value: "$annotations.play_count"},
-				{Key: "play_date", Value: "$annotations.play_date"},
-				{Key: "rating", Value: "$annotations.rating"},
-				{Key: "starred", Value: "$annotations.starred"},
-				{Key: "starred_at", Value: "$annotations.starred_at"},
-			}},
-		},
+	customCond, err := customTagCondition(scene_audio_route_models.SortEntityMediaFiles, custom)
+	if err != nil {
+		return nil, err
 	}
 
+	// 构建聚合管道（完全使用bson.D结构），全文检索条件必须位于首位
+	leadStages, searchCond := splitSearchStage(mediaFileSearch(ctx, r.db, search))
+	pipeline := append(leadStages, availabilityStages(available)...)
+	pipeline = append(pipeline, annotationFallbackStages(ctx, scene_audio_route_models.AnnotationItemMedia, "")...)
+
 	// 添加基础过滤条件
-	if match := buildMatchStage(search, starred, albumId, artistId, year); len(match) > 0 {
+	if match := append(buildMatchStage(ctx, searchCond, starred, albumId, artistId, year, folderId), customCond...); len(match) > 0 {
 		pipeline = append(pipeline, bson.D{{Key: "$match", Value: match}})
 	}
 
 	// 处理play_date排序的特殊过滤
 	validatedSort := validateSortField(sort, albumId)
-	if validatedSort == "play_date" {
+	if validatedSort == "play_date" || validatedSort == "first_play_date" {
 		pipeline = append(pipeline, bson.D{
 			{Key: "$match", Value: bson.D{
 				{Key: "play_count", Value: bson.D{{Key: "$gt", Value: 0}}},
@@ -116,32 +86,20 @@ func (r *mediaFileRepository) GetMediaFileItems(
 
 func (r *mediaFileRepository) GetMediaFileFilterItemsCount(
 	ctx context.Context,
-	search, starred, albumId, artistId, year string,
+	search, starred, albumId, artistId, year, folderId, available, custom string,
 ) (*scene_audio_route_models.MediaFileFilterCounts, error) {
 	coll := r.db.Collection(r.collection)
+	customCond, err := customTagCondition(scene_audio_route_models.SortEntityMediaFiles, custom)
+	if err != nil {
+		return nil, err
+	}
+	leadStages, searchCond := splitSearchStage(mediaFileSearch(ctx, r.db, search))
 
-	pipeline := []bson.D{
+	pipeline := append(leadStages, availabilityStages(available)...)
+	pipeline = append(pipeline, annotationFallbackStages(ctx, scene_audio_route_models.AnnotationItemMedia, "")...)
+	pipeline = append(pipeline, []bson.D{
 		{
-			{Key: "$lookup", Value: bson.D{
-				{Key: "from", Value: domain.CollectionFileEntityAudioSceneAnnotation},
-				{Key: "let", Value: bson.D{{Key: "mediaId", Value: "$_id"}}},
-				{Key: "pipeline", Value: []bson.D{
-					{
-						{Key: "$match", Value: bson.D{
-							{Key: "$expr", Value: bson.D{
-								{Key: "$and", Value: bson.A{
-									bson.D{{Key: "$eq", Value: bson.A{"$item_id", "$$mediaId"}}},
-									bson.D{{Key: "$eq", Value: bson.A{"$item_type", "media"}}},
-								}},
-							}},
-						}},
-					},
-				}},
-				{Key: "as", Value: "annotations"},
-			}},
-		},
-		{
-			{Key: "$match", Value: buildBaseMatch(search, albumId, artistId, year)},
+			{Key: "$match", Value: append(buildBaseMatch(ctx, searchCond, albumId, artistId, year, folderId), customCond...)},
 		},
 		{
 			{Key: "$facet", Value: bson.D{
@@ -150,13 +108,13 @@ func (r *mediaFileRepository) GetMediaFileFilterItemsCount(
 				}},
 				{Key: "starred", Value: []bson.D{
 					{{Key: "$match", Value: bson.D{
-						{Key: "annotations.starred", Value: true},
+						{Key: "starred", Value: true},
 					}}},
 					{{Key: "$count", Value: "count"}},
 				}},
 				{Key: "recent_play", Value: []bson.D{
 					{{Key: "$match", Value: bson.D{
-						{Key: "annotations.play_count", Value: bson.D{
+						{Key: "play_count", Value: bson.D{
 							{Key: "$gt", Value: 0},
 						}},
 					}}},
@@ -164,7 +122,7 @@ func (r *mediaFileRepository) GetMediaFileFilterItemsCount(
 				}},
 			}},
 		},
-	}
+	}...)
 
 	cursor, err := coll.Aggregate(ctx, pipeline)
 	if err != nil {
@@ -195,35 +153,18 @@ func (r *mediaFileRepository) GetMediaFileFilterItemsCount(
 	return counts, nil
 }
 
-// 排序字段映射
-func validateSortField(sort, albumId string) string {
-	sortMappings := map[string]string{
-		"title":        "order_title",
-		"album":        "order_album_name",
-		"artist":       "order_artist_name",
-		"album_artist": "order_album_artist_name",
-		"year":         "year",
-		"rating":       "rating",
-		"starred_at":   "starred_at",
-		"genre":        "genre",
-		"play_count":   "play_count",
-		"play_date":    "play_date",
-		"duration":     "duration",
-		"bit_rate":     "bit_rate",
-		"size":         "size",
-		"created_at":   "created_at",
-		"updated_at":   "updated_at",
-	}
+// albumTrackOrderField 专辑内曲目顺序：光盘号、音轨号，再按文件名兜底
+const albumTrackOrderField = "disc_number"
 
-	if mapped, ok := sortMappings[strings.ToLower(sort)]; ok {
-		return mapped
+// validateSortField 单专辑内默认按光盘号与音轨号排序
+func validateSortField(sort, albumId string) string {
+	if field, ok := lookupSortField(scene_audio_route_models.SortEntityMediaFiles, sort); ok {
+		return field
 	}
-
 	if len(albumId) > 0 {
-		return "file_name"
-	} else {
-		return "_id"
+		return albumTrackOrderField
 	}
+	return sortFieldFor(scene_audio_route_models.SortEntityMediaFiles, "")
 }
 
 // 排序稳定性：添加唯一字段作为次要排序条件
@@ -232,6 +173,16 @@ func buildSortStage(sort, order string) bson.D {
 	if order == "desc" {
 		sortOrder = -1
 	}
+	if sort == albumTrackOrderField {
+		return bson.D{
+			{Key: "$sort", Value: bson.D{
+				{Key: "disc_number", Value: sortOrder},
+				{Key: "track_number", Value: sortOrder},
+				{Key: "file_name", Value: sortOrder},
+				{Key: "_id", Value: 1},
+			}},
+		}
+	}
 	return bson.D{
 		{Key: "$sort", Value: bson.D{
 			{Key: sort, Value: sortOrder},
@@ -272,20 +223,25 @@ func extractCount(data []map[string]int) int {
 	return 0
 }
 
-func buildMatchStage(search, starred, albumId, artistId, year string) bson.D {
-	filter := bson.D{}
+// mediaFileSearch 按单曲接口的搜索策略构建关键字条件
+func mediaFileSearch(ctx context.Context, db mongo.Database, search string) (searchStrategy, bson.D) {
+	strategy := searchStrategyFor(scene_audio_route_models.SearchEndpointMediaFiles)
+	if search == "" {
+		return strategy, nil
+	}
+	fields := []string{"title", "artist", "album"}
+	if fieldStrategy, cond, ok := fieldSearch(search, fields); ok {
+		return fieldStrategy, cond
+	}
+	aliasArtistIDs := findAliasArtistIDs(ctx, db, strategy, search)
+	return strategy, strategy.condition(search, fields, aliasArtistBranches(aliasArtistIDs)...)
+}
+
+func buildMatchStage(ctx context.Context, searchCond bson.D, starred, albumId, artistId, year, folderId string) bson.D {
+	filter := bson.D{visibleFilter(ctx)}
 
 	if artistId != "" {
-		artistFilter := bson.D{
-			{Key: "$or", Value: bson.A{
-				bson.D{{Key: "artist_id", Value: artistId}},
-				bson.D{{
-					Key:   "all_artist_ids.artist_id",
-					Value: artistId,
-				}},
-			}},
-		}
-		filter = append(filter, bson.E{Key: "$and", Value: bson.A{artistFilter}})
+		filter = append(filter, artistIDsFilter(artistId))
 	}
 	if albumId != "" {
 		filter = append(filter, bson.E{Key: "album_id", Value: albumId})
@@ -295,13 +251,10 @@ func buildMatchStage(search, starred, albumId, artistId, year string) bson.D {
 			filter = append(filter, bson.E{Key: "year", Value: yearInt})
 		}
 	}
-	if search != "" {
-		filter = append(filter, bson.E{Key: "$or", Value: []bson.D{
-			{{Key: "title", Value: bson.D{{Key: "$regex", Value: search}, {Key: "$options", Value: "i"}}}},
-			{{Key: "artist", Value: bson.D{{Key: "$regex", Value: search}, {Key: "$options", Value: "i"}}}},
-			{{Key: "album", Value: bson.D{{Key: "$regex", Value: search}, {Key: "$options", Value: "i"}}}},
-		}})
+	if folderId != "" {
+		filter = append(filter, folderScopeMatch(ctx, folderId)...)
 	}
+	filter = append(filter, searchCond...)
 	if starred != "" {
 		if isStarred, err := strconv.ParseBool(starred); err == nil {
 			filter = append(filter, bson.E{Key: "starred", Value: isStarred})
@@ -311,6 +264,48 @@ func buildMatchStage(search, starred, albumId, artistId, year string) bson.D {
 	return filter
 }
 
-func buildBaseMatch(search, albumId, artistId, year string) bson.D {
-	return buildMatchStage(search, "", albumId, artistId, year)
+// artistIDsFilter artistId 可为逗号分隔的多个艺术家ID，匹配主艺术家或任一参与艺术家；
+// 包在 $and 中，避免与关键字搜索的 $or 冲突
+func artistIDsFilter(artistId string) bson.E {
+	ids := scene_audio_route_models.ParseArtistIDs(artistId)
+	var value interface{} = artistId
+	if len(ids) == 1 {
+		value = ids[0]
+	} else if len(ids) > 1 {
+		in := make(bson.A, 0, len(ids))
+		for _, id := range ids {
+			in = append(in, id)
+		}
+		value = bson.D{{Key: "$in", Value: in}}
+	}
+	return bson.E{Key: "$and", Value: bson.A{
+		bson.D{{Key: "$or", Value: bson.A{
+			bson.D{{Key: "artist_id", Value: value}},
+			bson.D{{Key: "all_artist_ids.artist_id", Value: value}},
+		}}},
+	}}
+}
+
+// availabilityStages available=true 时隐藏源文件不可访问的条目，尚未检查过的条目视为可用
+func availabilityStages(available string) []bson.D {
+	if available != "true" {
+		return []bson.D{}
+	}
+	return []bson.D{
+		{{Key: "$match", Value: bson.D{
+			{Key: "availability", Value: bson.D{{Key: "$ne", Value: scene_audio_db_models.AvailabilityMissing}}},
+		}}},
+	}
+}
+
+// reviewVisibleFilter 排除待审核与已驳回的歌曲
+func reviewVisibleFilter() bson.E {
+	return bson.E{Key: "review_status", Value: bson.D{{Key: "$nin", Value: bson.A{
+		scene_audio_db_models.ReviewStatusPending,
+		scene_audio_db_models.ReviewStatusRejected,
+	}}}}
+}
+
+func buildBaseMatch(ctx context.Context, searchCond bson.D, albumId, artistId, year, folderId string) bson.D {
+	return buildMatchStage(ctx, searchCond, "", albumId, artistId, year, folderId)
 }
@@ -4,10 +4,8 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"strings"
 	"time"
 
-	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
@@ -35,50 +33,15 @@ func (r *mediaFileCueRepository) GetMediaFileCueItems(
 	coll := r.db.Collection(r.collection)
 
 	// 构建聚合管道
-	pipeline := []bson.D{
-		{
-			{Key: "$lookup", Value: bson.D{
-				{Key: "from", Value: domain.CollectionFileEntityAudioSceneAnnotation},
-				{Key: "let", Value: bson.D{{Key: "mediaId", Value: "$_id"}}},
-				{Key: "pipeline", Value: []bson.D{
-					{
-						{Key: "$match", Value: bson.D{
-							{Key: "$expr", Value: bson.D{
-								{Key: "$and", Value: bson.A{
-									bson.D{{Key: "$eq", Value: bson.A{"$item_id", "$$mediaId"}}},
-									bson.D{{Key: "$eq", Value: bson.A{"$item_type", "media_cue"}}},
-								}},
-							}},
-						}},
-					},
-				}},
-				{Key: "as", Value: "annotations"},
-			}},
-		},
-		{
-			{Key: "$unwind", Value: bson.D{
-				{Key: "path", Value: "$annotations"},
-				{Key: "preserveNullAndEmptyArrays", Value: true},
-			}},
-		},
-		{
-			{Key: "$addFields", Value: bson.D{
-				{Key: "play_count", Value: "$annotations.play_count"},
-				{Key: "play_date", Value: "$annotations.play_date"},
-				{Key: "rating", Value: "$annotations.rating"},
-				{Key: "starred", Value: "$annotations.starred"},
-				{Key: "starred_at", Value: "$annotations.starred_at"},
-			}},
-		},
-	}
+	pipeline := annotationFallbackStages(ctx, scene_audio_route_models.AnnotationItemMediaCue, "")
 
 	// 添加过滤条件
-	if match := r.buildMatchStage(search, starred, albumId, artistId, year); len(match) > 0 {
+	if match := append(r.buildMatchStage(search, starred, albumId, artistId, year), visibleCueFilter(ctx)...); len(match) > 0 {
 		pipeline = append(pipeline, bson.D{{Key: "$match", Value: match}})
 	}
 
 	// 处理排序字段
-	validatedSort := r.validateSortField(sort)
+	validatedSort := sortFieldFor(scene_audio_route_models.SortEntityMediaFileCues, sort)
 	if validatedSort == "play_date" {
 		pipeline = append(pipeline, bson.D{
 			{Key: "$match", Value: bson.D{
@@ -117,40 +80,9 @@ func (r *mediaFileCueRepository) GetMediaFileCueFilterItemsCount(
 ) (*scene_audio_route_models.MediaFileCueFilterCounts, error) {
 	coll := r.db.Collection(r.collection)
 
-	pipeline := []bson.D{
+	pipeline := append(annotationFallbackStages(ctx, scene_audio_route_models.AnnotationItemMediaCue, ""), []bson.D{
 		{
-			{Key: "$lookup", Value: bson.D{
-				{Key: "from", Value: domain.CollectionFileEntityAudioSceneAnnotation},
-				{Key: "let", Value: bson.D{{Key: "mediaId", Value: "$_id"}}},
-				{Key: "pipeline", Value: []bson.D{
-					{
-						{Key: "$match", Value: bson.D{
-							{Key: "$expr", Value: bson.D{
-								{Key: "$and", Value: bson.A{
-									bson.D{{Key: "$eq", Value: bson.A{"$item_id", "$$mediaId"}}},
-									bson.D{{Key: "$eq", Value: bson.A{"$item_type", "media_cue"}}}, // 修改为 media_cue 类型
-								}},
-							}},
-						}},
-					},
-				}},
-				{Key: "as", Value: "annotations"},
-			}},
-		},
-		{
-			{Key: "$unwind", Value: bson.D{
-				{Key: "path", Value: "$annotations"},
-				{Key: "preserveNullAndEmptyArrays", Value: true},
-			}},
-		},
-		{
-			{Key: "$addFields", Value: bson.D{
-				{Key: "play_count", Value: "$annotations.play_count"},
-				{Key: "starred", Value: "$annotations.starred"},
-			}},
-		},
-		{
-			{Key: "$match", Value: r.buildBaseMatch(search, albumId, artistId, year)},
+			{Key: "$match", Value: append(r.buildBaseMatch(search, albumId, artistId, year), visibleCueFilter(ctx)...)},
 		},
 		{
 			{Key: "$facet", Value: bson.D{
@@ -173,7 +105,7 @@ func (r *mediaFileCueRepository) GetMediaFileCueFilterItemsCount(
 				}},
 			}},
 		},
-	}
+	}...)
 
 	cursor, err := coll.Aggregate(ctx, pipeline)
 	if err != nil {
@@ -208,28 +140,6 @@ func (r *mediaFileCueRepository) extractCount(data []map[string]int) int {
 	return 0
 }
 
-func (r *mediaFileCueRepository) validateSortField(sort string) string {
-	sortMappings := map[string]string{
-		"title":           "title",
-		"performer":       "performer",
-		"year":            "rem.date", // 使用REM中的日期字段
-		"rating":          "rating",
-		"starred_at":      "starred_at",
-		"genre":           "rem.genre",
-		"play_count":      "play_count",
-		"play_date":       "play_date",
-		"size":            "size",
-		"created_at":      "created_at",
-		"updated_at":      "updated_at",
-		"cue_track_count": "cue_track_count",
-	}
-
-	if mapped, ok := sortMappings[strings.ToLower(sort)]; ok {
-		return mapped
-	}
-	return "_id"
-}
-
 func (r *mediaFileCueRepository) buildMatchStage(search, starred, albumId, artistId, year string) bson.D {
 	filter := bson.D{}
 
@@ -0,0 +1,409 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const milestoneDayLayout = "2006-01-02"
+
+type milestoneRepository struct {
+	db         mongo.Database
+	collection string
+}
+
+func NewMilestoneRepository(db mongo.Database, collection string) scene_audio_route_interface.MilestoneRepository {
+	return &milestoneRepository{
+		db:         db,
+		collection: collection,
+	}
+}
+
+func milestoneKey(milestoneType, itemID string, threshold int) string {
+	return fmt.Sprintf("%s:%s:%d", milestoneType, itemID, threshold)
+}
+
+// GetMilestones 调用方受限时隐藏不可见艺术家的里程碑，累计时长类里程碑不关联条目，始终保留
+func (r *milestoneRepository) GetMilestones(ctx context.Context, userId, milestoneType string) ([]scene_audio_route_models.MilestoneMetadata, error) {
+	filter := bson.M{"user_id": userId}
+	if milestoneType != "" {
+		filter["type"] = milestoneType
+	}
+	artistIDs, restricted, err := visibleArtistIDs(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	if restricted {
+		itemIDs := bson.A{""}
+		for _, id := range artistIDs {
+			itemIDs = append(itemIDs, id.Hex())
+		}
+		filter["item_id"] = bson.M{"$in": itemIDs}
+	}
+	cursor, err := r.db.Collection(r.collection).Find(ctx, filter,
+		options.Find().SetSort(bson.D{{Key: "achieved_at", Value: -1}, {Key: "_id", Value: -1}}))
+	if err != nil {
+		return nil, fmt.Errorf("query milestones failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	milestones := make([]scene_audio_route_models.MilestoneMetadata, 0)
+	if err := cursor.All(ctx, &milestones); err != nil {
+		return nil, fmt.Errorf("decode milestones failed: %w", err)
+	}
+	return milestones, nil
+}
+
+func (r *milestoneRepository) RefreshMilestones(ctx context.Context, since time.Time) ([]scene_audio_route_models.MilestoneMetadata, error) {
+	users, err := r.activeUsers(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	var achieved []scene_audio_route_models.MilestoneMetadata
+	for _, userID := range users {
+		if ctx.Err() != nil {
+			return achieved, ctx.Err()
+		}
+		existing, err := r.existingKeys(ctx, userID)
+		if err != nil {
+			return achieved, err
+		}
+
+		var candidates []scene_audio_route_models.MilestoneMetadata
+		for _, compute := range []func(context.Context, string, map[string]bool) ([]scene_audio_route_models.MilestoneMetadata, error){
+			r.artistPlayMilestones,
+			r.artistStreakMilestones,
+			r.listeningHourMilestones,
+		} {
+			found, err := compute(ctx, userID, existing)
+			if err != nil {
+				return achieved, err
+			}
+			candidates = append(candidates, found...)
+		}
+
+		inserted, err := r.insert(ctx, userID, candidates)
+		if err != nil {
+			return achieved, err
+		}
+		achieved = append(achieved, inserted...)
+	}
+	return achieved, nil
+}
+
+// activeUsers since 之后写入过播放记录的用户，since 为零值时返回全部用户
+func (r *milestoneRepository) activeUsers(ctx context.Context, since time.Time) ([]string, error) {
+	match := bson.M{"user_id": bson.M{"$nin": bson.A{"", nil}}}
+	if !since.IsZero() {
+		match["created_at"] = bson.M{"$gte": since}
+	}
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioScenePlayHistory).Aggregate(ctx, []bson.D{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.D{{Key: "_id", Value: "$user_id"}}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query active users failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		ID string `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("decode active users failed: %w", err)
+	}
+	users := make([]string, 0, len(rows))
+	for _, row := range rows {
+		users = append(users, row.ID)
+	}
+	return users, nil
+}
+
+func (r *milestoneRepository) existingKeys(ctx context.Context, userID string) (map[string]bool, error) {
+	cursor, err := r.db.Collection(r.collection).Find(ctx, bson.M{"user_id": userID},
+		options.Find().SetProjection(bson.M{"type": 1, "item_id": 1, "threshold": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("query milestones failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []scene_audio_route_models.MilestoneMetadata
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("decode milestones failed: %w", err)
+	}
+	existing := make(map[string]bool, len(docs))
+	for _, doc := range docs {
+		existing[milestoneKey(doc.Type, doc.ItemID, doc.Threshold)] = true
+	}
+	return existing, nil
+}
+
+// artistPlayMilestones 播放次数达到门槛的艺术家，达成时间取第 N 次播放的时间
+func (r *milestoneRepository) artistPlayMilestones(ctx context.Context, userID string, existing map[string]bool) ([]scene_audio_route_models.MilestoneMetadata, error) {
+	thresholds := scene_audio_route_models.MilestoneThresholds[scene_audio_route_models.MilestoneArtistPlays]
+	coll := r.db.Collection(domain.CollectionFileEntityAudioScenePlayHistory)
+	cursor, err := coll.Aggregate(ctx, []bson.D{
+		{{Key: "$match", Value: bson.M{"user_id": userID, "artist_id": bson.M{"$nin": bson.A{"", nil}}}}},
+		{{Key: "$group", Value: bson.D{{Key: "_id", Value: "$artist_id"}, {Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}}}}},
+		{{Key: "$match", Value: bson.M{"count": bson.M{"$gte": thresholds[0]}}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("aggregate artist plays failed: %w", err)
+	}
+	var rows []struct {
+		ArtistID string `bson:"_id"`
+		Count    int    `bson:"count"`
+	}
+	err = cursor.All(ctx, &rows)
+	_ = cursor.Close(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("decode artist plays failed: %w", err)
+	}
+
+	var found []scene_audio_route_models.MilestoneMetadata
+	for _, row := range rows {
+		for _, threshold := range thresholds {
+			if row.Count < threshold || existing[milestoneKey(scene_audio_route_models.MilestoneArtistPlays, row.ArtistID, threshold)] {
+				continue
+			}
+			playedAt, err := r.nthPlay(ctx, userID, row.ArtistID, threshold)
+			if err != nil {
+				return nil, err
+			}
+			found = append(found, scene_audio_route_models.MilestoneMetadata{
+				Type:       scene_audio_route_models.MilestoneArtistPlays,
+				ItemID:     row.ArtistID,
+				Threshold:  threshold,
+				AchievedAt: playedAt,
+			})
+		}
+	}
+	return found, nil
+}
+
+// nthPlay 用户对该艺术家第 n 次播放的时间
+func (r *milestoneRepository) nthPlay(ctx context.Context, userID, artistID string, n int) (time.Time, error) {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioScenePlayHistory).Find(ctx,
+		bson.M{"user_id": userID, "artist_id": artistID},
+		options.Find().
+			SetSort(bson.D{{Key: "played_at", Value: 1}, {Key: "_id", Value: 1}}).
+			SetSkip(int64(n-1)).
+			SetLimit(1).
+			SetProjection(bson.M{"played_at": 1}),
+	)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("query artist play %d failed: %w", n, err)
+	}
+	defer cursor.Close(ctx)
+
+	var plays []struct {
+		PlayedAt time.Time `bson:"played_at"`
+	}
+	if err := cursor.All(ctx, &plays); err != nil {
+		return time.Time{}, fmt.Errorf("decode artist play %d failed: %w", n, err)
+	}
+	if len(plays) == 0 {
+		return time.Time{}, fmt.Errorf("artist play %d not found", n)
+	}
+	return plays[0].PlayedAt, nil
+}
+
+// artistStreakMilestones 按 UTC 日期统计连续收听同一艺术家的天数，达成时间取达到门槛当天的首次播放
+func (r *milestoneRepository) artistStreakMilestones(ctx context.Context, userID string, existing map[string]bool) ([]scene_audio_route_models.MilestoneMetadata, error) {
+	thresholds := scene_audio_route_models.MilestoneThresholds[scene_audio_route_models.MilestoneArtistStreak]
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioScenePlayHistory).Aggregate(ctx, []bson.D{
+		{{Key: "$match", Value: bson.M{"user_id": userID, "artist_id": bson.M{"$nin": bson.A{"", nil}}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{
+				{Key: "artist_id", Value: "$artist_id"},
+				{Key: "day", Value: bson.D{{Key: "$dateToString", Value: bson.D{
+					{Key: "format", Value: "%Y-%m-%d"},
+					{Key: "date", Value: "$played_at"},
+				}}}},
+			}},
+			{Key: "first_played_at", Value: bson.D{{Key: "$min", Value: "$played_at"}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id.artist_id", Value: 1}, {Key: "_id.day", Value: 1}}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("aggregate artist days failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var (
+		found      []scene_audio_route_models.MilestoneMetadata
+		lastArtist string
+		lastDay    time.Time
+		streak     int
+	)
+	for cursor.Next(ctx) {
+		var row struct {
+			ID struct {
+				ArtistID string `bson:"artist_id"`
+				Day      string `bson:"day"`
+			} `bson:"_id"`
+			FirstPlayedAt time.Time `bson:"first_played_at"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			return nil, fmt.Errorf("decode artist days failed: %w", err)
+		}
+		day, err := time.Parse(milestoneDayLayout, row.ID.Day)
+		if err != nil {
+			continue
+		}
+		if row.ID.ArtistID == lastArtist && day.Sub(lastDay) == 24*time.Hour {
+			streak++
+		} else {
+			streak = 1
+		}
+		lastArtist, lastDay = row.ID.ArtistID, day
+
+		for _, threshold := range thresholds {
+			key := milestoneKey(scene_audio_route_models.MilestoneArtistStreak, row.ID.ArtistID, threshold)
+			if streak != threshold || existing[key] {
+				continue
+			}
+			existing[key] = true // 同一门槛只取最早的一次连续收听
+			found = append(found, scene_audio_route_models.MilestoneMetadata{
+				Type:       scene_audio_route_models.MilestoneArtistStreak,
+				ItemID:     row.ID.ArtistID,
+				Threshold:  threshold,
+				AchievedAt: row.FirstPlayedAt,
+			})
+		}
+	}
+	return found, nil
+}
+
+// listeningHourMilestones 按播放时间顺序累加曲目时长，达成时间取累计时长越过门槛的那次播放
+func (r *milestoneRepository) listeningHourMilestones(ctx context.Context, userID string, existing map[string]bool) ([]scene_audio_route_models.MilestoneMetadata, error) {
+	var pending []int
+	for _, threshold := range scene_audio_route_models.MilestoneThresholds[scene_audio_route_models.MilestoneListeningHours] {
+		if !existing[milestoneKey(scene_audio_route_models.MilestoneListeningHours, "", threshold)] {
+			pending = append(pending, threshold)
+		}
+	}
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioScenePlayHistory).Aggregate(ctx, []bson.D{
+		{{Key: "$match", Value: bson.M{"user_id": userID}}},
+		{{Key: "$sort", Value: bson.D{{Key: "played_at", Value: 1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: domain.CollectionFileEntityAudioSceneMediaFile},
+			{Key: "localField", Value: "media_file_id"},
+			{Key: "foreignField", Value: "_id"},
+			{Key: "as", Value: "media"},
+		}}},
+		{{Key: "$project", Value: bson.D{
+			{Key: "played_at", Value: 1},
+			{Key: "duration", Value: bson.D{{Key: "$ifNull", Value: bson.A{bson.D{{Key: "$arrayElemAt", Value: bson.A{"$media.duration", 0}}}, 0}}}},
+		}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("aggregate listening time failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var (
+		found   []scene_audio_route_models.MilestoneMetadata
+		seconds float64
+	)
+	for len(pending) > 0 && cursor.Next(ctx) {
+		var row struct {
+			PlayedAt time.Time `bson:"played_at"`
+			Duration float64   `bson:"duration"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			return nil, fmt.Errorf("decode listening time failed: %w", err)
+		}
+		// 媒体时长按 taglib 原样以纳秒存储
+		seconds += time.Duration(row.Duration).Seconds()
+		for len(pending) > 0 && seconds >= float64(pending[0])*3600 {
+			found = append(found, scene_audio_route_models.MilestoneMetadata{
+				Type:       scene_audio_route_models.MilestoneListeningHours,
+				Threshold:  pending[0],
+				AchievedAt: row.PlayedAt,
+			})
+			pending = pending[1:]
+		}
+	}
+	return found, nil
+}
+
+// insert 补全艺术家名称后写入，唯一索引保证并发刷新时不会重复记录；返回实际新增的里程碑
+func (r *milestoneRepository) insert(ctx context.Context, userID string, candidates []scene_audio_route_models.MilestoneMetadata) ([]scene_audio_route_models.MilestoneMetadata, error) {
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	names, err := r.artistNames(ctx, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	coll := r.db.Collection(r.collection)
+	now := time.Now().UTC()
+	var inserted []scene_audio_route_models.MilestoneMetadata
+	for _, m := range candidates {
+		m.ID = primitive.NewObjectID()
+		m.UserID = userID
+		m.ItemName = names[m.ItemID]
+		m.CreatedAt = now
+		result, err := coll.UpdateOne(ctx,
+			bson.M{"user_id": userID, "type": m.Type, "item_id": m.ItemID, "threshold": m.Threshold},
+			bson.M{"$setOnInsert": m},
+			options.Update().SetUpsert(true),
+		)
+		if err != nil {
+			return inserted, fmt.Errorf("save milestone failed: %w", err)
+		}
+		if result.UpsertedID != nil {
+			inserted = append(inserted, m)
+		}
+	}
+	return inserted, nil
+}
+
+func (r *milestoneRepository) artistNames(ctx context.Context, milestones []scene_audio_route_models.MilestoneMetadata) (map[string]string, error) {
+	var ids []primitive.ObjectID
+	for _, m := range milestones {
+		if oid, err := primitive.ObjectIDFromHex(m.ItemID); err == nil {
+			ids = append(ids, oid)
+		}
+	}
+	names := make(map[string]string, len(ids))
+	if len(ids) == 0 {
+		return names, nil
+	}
+
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneArtist).Find(ctx,
+		bson.M{"_id": bson.M{"$in": ids}},
+		options.Find().SetProjection(bson.M{"_id": 1, "name": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("query artist names failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var artists []struct {
+		ID   primitive.ObjectID `bson:"_id"`
+		Name string             `bson:"name"`
+	}
+	if err := cursor.All(ctx, &artists); err != nil {
+		return nil, fmt.Errorf("decode artist names failed: %w", err)
+	}
+	for _, a := range artists {
+		names[a.ID.Hex()] = a.Name
+	}
+	return names, nil
+}
@@ -0,0 +1,168 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_auth"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type nowPlayingRepository struct {
+	db         mongo.Database
+	collection string
+}
+
+func NewNowPlayingRepository(db mongo.Database, collection string) scene_audio_route_interface.NowPlayingRepository {
+	return &nowPlayingRepository{
+		db:         db,
+		collection: collection,
+	}
+}
+
+func (r *nowPlayingRepository) Report(ctx context.Context, entry scene_audio_route_models.NowPlayingMetadata) (*scene_audio_route_models.NowPlayingMetadata, error) {
+	coll := r.db.Collection(r.collection)
+	now := time.Now().UTC()
+
+	filter := bson.M{"user_id": entry.UserID, "client": entry.Client}
+	update := bson.M{
+		"$set": bson.M{
+			"media_file_id": entry.MediaFileID,
+			"state":         entry.State,
+			"position":      entry.Position,
+			"updated_at":    now,
+			"expires_at":    now.Add(scene_audio_route_models.NowPlayingTTL),
+		},
+		"$setOnInsert": bson.M{
+			"_id":     primitive.NewObjectID(),
+			"user_id": entry.UserID,
+			"client":  entry.Client,
+		},
+	}
+	if _, err := coll.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true)); err != nil {
+		return nil, fmt.Errorf("upsert failed: %w", err)
+	}
+
+	var saved scene_audio_route_models.NowPlayingMetadata
+	if err := coll.FindOne(ctx, filter).Decode(&saved); err != nil {
+		if errors.Is(err, driver.ErrNoDocuments) {
+			return nil, fmt.Errorf("now playing %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("fetch now playing failed: %w", err)
+	}
+	return &saved, nil
+}
+
+func (r *nowPlayingRepository) Clear(ctx context.Context, userId string, client string) error {
+	filter := bson.M{"user_id": userId}
+	if client != "" {
+		filter["client"] = client
+	}
+	if _, err := r.db.Collection(r.collection).DeleteMany(ctx, filter); err != nil {
+		return fmt.Errorf("delete failed: %w", err)
+	}
+	return nil
+}
+
+func (r *nowPlayingRepository) GetNowPlaying(ctx context.Context) ([]scene_audio_route_models.NowPlayingMetadata, error) {
+	coll := r.db.Collection(r.collection)
+
+	// TTL 索引按分钟级周期清理，查询时仍需过滤已过期的记录
+	cursor, err := coll.Find(ctx,
+		bson.M{"expires_at": bson.M{"$gt": time.Now().UTC()}},
+		options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}}))
+	if err != nil {
+		return nil, fmt.Errorf("find operation failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	entries := make([]scene_audio_route_models.NowPlayingMetadata, 0)
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, fmt.Errorf("decode error: %w", err)
+	}
+	if len(entries) == 0 {
+		return entries, nil
+	}
+
+	mediaIDs := make([]primitive.ObjectID, 0, len(entries))
+	userIDs := make([]primitive.ObjectID, 0, len(entries))
+	for _, e := range entries {
+		mediaIDs = append(mediaIDs, e.MediaFileID)
+		if id, err := primitive.ObjectIDFromHex(e.UserID); err == nil {
+			userIDs = append(userIDs, id)
+		}
+	}
+	items, err := r.loadItems(ctx, mediaIDs)
+	if err != nil {
+		return nil, err
+	}
+	names, err := r.loadUserNames(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 曲目已被删除或调用方不可见的记录不再展示
+	result := entries[:0]
+	for _, e := range entries {
+		item, ok := items[e.MediaFileID]
+		if !ok {
+			continue
+		}
+		e.Item = &item
+		e.UserName = names[e.UserID]
+		result = append(result, e)
+	}
+	return result, nil
+}
+
+func (r *nowPlayingRepository) loadItems(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]scene_audio_route_models.MediaFileMetadata, error) {
+	coll := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile)
+	cursor, err := coll.Find(ctx, bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}}, visibleFilter(ctx)})
+	if err != nil {
+		return nil, fmt.Errorf("media query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var files []scene_audio_route_models.MediaFileMetadata
+	if err := cursor.All(ctx, &files); err != nil {
+		return nil, fmt.Errorf("decode error: %w", err)
+	}
+	byID := make(map[primitive.ObjectID]scene_audio_route_models.MediaFileMetadata, len(files))
+	for _, f := range files {
+		byID[f.ID] = f
+	}
+	return byID, nil
+}
+
+func (r *nowPlayingRepository) loadUserNames(ctx context.Context, ids []primitive.ObjectID) (map[string]string, error) {
+	names := make(map[string]string, len(ids))
+	if len(ids) == 0 {
+		return names, nil
+	}
+
+	coll := r.db.Collection(domain.CollectionUser)
+	cursor, err := coll.Find(ctx, bson.M{"_id": bson.M{"$in": ids}},
+		options.Find().SetProjection(bson.M{"name": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("user query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var users []domain_auth.User
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, fmt.Errorf("decode error: %w", err)
+	}
+	for _, u := range users {
+		names[u.ID.Hex()] = u.Name
+	}
+	return names, nil
+}
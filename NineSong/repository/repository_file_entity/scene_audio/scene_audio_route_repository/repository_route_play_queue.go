@@ -0,0 +1,126 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type playQueueRepository struct {
+	db         mongo.Database
+	collection string
+}
+
+func NewPlayQueueRepository(db mongo.Database, collection string) scene_audio_route_interface.PlayQueueRepository {
+	return &playQueueRepository{
+		db:         db,
+		collection: collection,
+	}
+}
+
+func (r *playQueueRepository) GetPlayQueue(ctx context.Context, userId string, client string) (*scene_audio_route_models.PlayQueueMetadata, error) {
+	coll := r.db.Collection(r.collection)
+
+	filter := bson.M{"user_id": userId}
+	if client != "" {
+		filter["client"] = client
+	}
+	cursor, err := coll.Find(ctx, filter, options.Find().
+		SetSort(bson.D{{Key: "updated_at", Value: -1}}).
+		SetLimit(1))
+	if err != nil {
+		return nil, fmt.Errorf("find operation failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var queues []scene_audio_route_models.PlayQueueMetadata
+	if err := cursor.All(ctx, &queues); err != nil {
+		return nil, fmt.Errorf("decode error: %w", err)
+	}
+	if len(queues) == 0 {
+		return nil, fmt.Errorf("play queue %w", domain.ErrNotFound)
+	}
+
+	queue := queues[0]
+	items, err := r.loadItems(ctx, queue.MediaFileIDs)
+	if err != nil {
+		return nil, err
+	}
+	queue.Items = items
+	return &queue, nil
+}
+
+func (r *playQueueRepository) SavePlayQueue(ctx context.Context, queue scene_audio_route_models.PlayQueueMetadata) (*scene_audio_route_models.PlayQueueMetadata, error) {
+	coll := r.db.Collection(r.collection)
+	now := time.Now().UTC()
+
+	filter := bson.M{"user_id": queue.UserID, "client": queue.Client}
+	update := bson.M{
+		"$set": bson.M{
+			"media_file_ids": queue.MediaFileIDs,
+			"current":        queue.Current,
+			"position":       queue.Position,
+			"changed_by":     queue.ChangedBy,
+			"updated_at":     now,
+		},
+		"$setOnInsert": bson.M{
+			"_id":        primitive.NewObjectID(),
+			"user_id":    queue.UserID,
+			"client":     queue.Client,
+			"created_at": now,
+		},
+	}
+	if _, err := coll.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true)); err != nil {
+		return nil, fmt.Errorf("upsert failed: %w", err)
+	}
+
+	var saved scene_audio_route_models.PlayQueueMetadata
+	if err := coll.FindOne(ctx, filter).Decode(&saved); err != nil {
+		if errors.Is(err, driver.ErrNoDocuments) {
+			return nil, fmt.Errorf("play queue %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("fetch saved queue failed: %w", err)
+	}
+	return &saved, nil
+}
+
+// 按队列顺序加载媒体文件，已删除或调用方不可见的文件被忽略
+func (r *playQueueRepository) loadItems(ctx context.Context, ids []primitive.ObjectID) ([]scene_audio_route_models.MediaFileMetadata, error) {
+	if len(ids) == 0 {
+		return []scene_audio_route_models.MediaFileMetadata{}, nil
+	}
+
+	coll := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile)
+	cursor, err := coll.Find(ctx, bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}}, visibleFilter(ctx)})
+	if err != nil {
+		return nil, fmt.Errorf("media query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var files []scene_audio_route_models.MediaFileMetadata
+	if err := cursor.All(ctx, &files); err != nil {
+		return nil, fmt.Errorf("decode error: %w", err)
+	}
+
+	byID := make(map[primitive.ObjectID]scene_audio_route_models.MediaFileMetadata, len(files))
+	for _, f := range files {
+		byID[f.ID] = f
+	}
+	items := make([]scene_audio_route_models.MediaFileMetadata, 0, len(ids))
+	for _, id := range ids {
+		if f, ok := byID[id]; ok {
+			items = append(items, f)
+		}
+	}
+	return items, nil
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/deletion_util"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -27,10 +30,24 @@ func NewPlaylistRepository(db mongo.Database, collection string) scene_audio_rou
 	}
 }
 
-// 获取所有播放列表
-func (p *playlistRepository) GetPlaylistsAll(ctx context.Context) ([]scene_audio_route_models.PlaylistMetadata, error) {
+// 系统生成的播放列表按用户区分，不出现在公共列表中，也不参与名称唯一性校验
+var userPlaylistFilter = bson.E{Key: "system", Value: bson.M{"$in": bson.A{"", nil}}}
+
+// playlistOwnerFilter 只允许访问当前用户的播放列表，以及引入所有者之前创建的无主播放列表
+func playlistOwnerFilter(ctx context.Context) bson.E {
+	return bson.E{Key: "owner_id", Value: bson.M{"$in": bson.A{domain.UserIDFromContext(ctx), "", nil}}}
+}
+
+// 获取所有播放列表，start/end无效时返回全部
+func (p *playlistRepository) GetPlaylistsAll(ctx context.Context, start string, end string) ([]scene_audio_route_models.PlaylistMetadata, error) {
 	coll := p.db.Collection(p.collection)
-	cursor, err := coll.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{"created_at", -1}}))
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+	startInt, err1 := strconv.Atoi(start)
+	endInt, err2 := strconv.Atoi(end)
+	if err1 == nil && err2 == nil && startInt >= 0 && endInt > startInt {
+		opts.SetSkip(int64(startInt)).SetLimit(int64(endInt - startInt))
+	}
+	cursor, err := coll.Find(ctx, bson.D{userPlaylistFilter, playlistOwnerFilter(ctx)}, opts)
 	if err != nil {
 		return nil, fmt.Errorf("find operation failed: %w", err)
 	}
@@ -53,7 +70,7 @@ func (p *playlistRepository) GetPlaylist(ctx context.Context, playlistId string)
 
 	coll := p.db.Collection(p.collection)
 	var dbModel scene_audio_db_models.PlaylistMetadata
-	err = coll.FindOne(ctx, bson.M{"_id": objID}).Decode(&dbModel)
+	err = coll.FindOne(ctx, bson.D{{Key: "_id", Value: objID}, playlistOwnerFilter(ctx)}).Decode(&dbModel)
 	if err != nil {
 		return nil, fmt.Errorf("find one error: %w", err)
 	}
@@ -65,7 +82,9 @@ func (p *playlistRepository) GetPlaylist(ctx context.Context, playlistId string)
 func (p *playlistRepository) CreatePlaylist(ctx context.Context, playlist scene_audio_route_models.PlaylistMetadata) (*scene_audio_route_models.PlaylistMetadata, error) {
 	// 构造新的唯一性校验条件
 	filter := bson.D{
-		{"name", playlist.Name}, // 仅保留name字段校验[3,4](@ref)
+		{Key: "name", Value: playlist.Name}, // 仅保留name字段校验[3,4](@ref)
+		userPlaylistFilter,
+		playlistOwnerFilter(ctx),
 	}
 
 	// 查询重复项
@@ -110,13 +129,12 @@ func (p *playlistRepository) DeletePlaylist(ctx context.Context, playlistId stri
 		return false, errors.New("invalid playlist id format")
 	}
 
-	coll := p.db.Collection(p.collection)
-	_, err = coll.DeleteOne(ctx, bson.M{"_id": objID})
+	deleted, err := deletion_util.DeleteOne(ctx, p.db, p.collection, "playlist", bson.D{{Key: "_id", Value: objID}, playlistOwnerFilter(ctx)})
 	if err != nil {
 		return false, fmt.Errorf("delete failed: %w", err)
 	}
 
-	return true, nil
+	return deleted > 0, nil
 }
 
 // 更新播放列表基本信息
@@ -127,9 +145,11 @@ func (p *playlistRepository) UpdatePlaylistInfo(ctx context.Context, playlistId
 	}
 
 	// 添加名称唯一性检查
-	filter := bson.M{
-		"name": playlist.Name,
-		"_id":  bson.M{"$ne": objID},
+	filter := bson.D{
+		{Key: "name", Value: playlist.Name},
+		{Key: "_id", Value: bson.M{"$ne": objID}},
+		userPlaylistFilter,
+		playlistOwnerFilter(ctx),
 	}
 	count, err := p.db.Collection(p.collection).CountDocuments(ctx, filter)
 	if err != nil {
@@ -149,7 +169,7 @@ func (p *playlistRepository) UpdatePlaylistInfo(ctx context.Context, playlistId
 
 	coll := p.db.Collection(p.collection)
 	// 执行更新操作
-	result, err := coll.UpdateByID(ctx, objID, update)
+	result, err := coll.UpdateOne(ctx, bson.D{{Key: "_id", Value: objID}, playlistOwnerFilter(ctx)}, update)
 	if err != nil {
 		return nil, fmt.Errorf("update failed: %w", err)
 	}
@@ -175,6 +195,7 @@ func convertToDBModel(routeModel scene_audio_route_models.PlaylistMetadata) scen
 		Comment:   routeModel.Comment,
 		CreatedAt: routeModel.CreatedAt,
 		UpdatedAt: routeModel.UpdatedAt,
+		OwnerID:   routeModel.OwnerID,
 	}
 }
 
@@ -190,6 +211,8 @@ func convertToRouteModel(dbModel scene_audio_db_models.PlaylistMetadata) *scene_
 		UpdatedAt: dbModel.UpdatedAt,
 		Path:      dbModel.Path,
 		Size:      dbModel.Size,
+		OwnerID:   dbModel.OwnerID,
+		System:    dbModel.System,
 	}
 }
 
@@ -36,6 +36,9 @@ func (r *playlistTrackRepository) GetPlaylistTrackItems(
 ) ([]scene_audio_route_models.MediaFileMetadata, error) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
+	if err := r.requireOwnedPlaylist(ctx, mustObjectID(playlistId)); err != nil {
+		return nil, err
+	}
 	coll := r.db.Collection(r.collection)
 
 	// 构建完整聚合管道
@@ -61,40 +64,12 @@ func (r *playlistTrackRepository) GetPlaylistTrackItems(
 				{Key: "preserveNullAndEmptyArrays", Value: false},
 			}},
 		},
-		// 关联注解数据
-		{
-			{Key: "$lookup", Value: bson.D{
-				{Key: "from", Value: domain.CollectionFileEntityAudioSceneAnnotation},
-				{Key: "let", Value: bson.D{{Key: "mediaId", Value: "$media_file._id"}}},
-				{Key: "pipeline", Value: []bson.D{
-					{
-						{Key: "$match", Value: bson.D{
-							{Key: "$expr", Value: bson.D{
-								{Key: "$and", Value: bson.A{
-									bson.D{{Key: "$eq", Value: bson.A{"$item_id", "$$mediaId"}}},
-									bson.D{{Key: "$eq", Value: bson.A{"$item_type", "media"}}},
-								}},
-							}},
-						}},
-					},
-				}},
-				{Key: "as", Value: "annotations"},
-			}},
-		},
-		{
-			{Key: "$unwind", Value: bson.D{
-				{Key: "path", Value: "$annotations"},
-				{Key: "preserveNullAndEmptyArrays", Value: true},
-			}},
-		},
+	}
+	pipeline = append(pipeline, annotationFallbackStages(ctx, scene_audio_route_models.AnnotationItemMedia, "media_file.")...)
+	pipeline = append(pipeline, []bson.D{
 		// 合并字段
 		{
 			{Key: "$addFields", Value: bson.D{
-				{Key: "media_file.play_count", Value: "$annotations.play_count"},
-				{Key: "media_file.play_date", Value: "$annotations.play_date"},
-				{Key: "media_file.rating", Value: "$annotations.rating"},
-				{Key: "media_file.starred", Value: "$annotations.starred"},
-				{Key: "media_file.starred_at", Value: "$annotations.starred_at"},
 				{Key: "media_file.index", Value: "$index"}, // 关键修改点
 			}},
 		},
@@ -111,15 +86,15 @@ func (r *playlistTrackRepository) GetPlaylistTrackItems(
 				}},
 			}},
 		},
-	}
+	}...)
 
 	// 构建过滤条件
-	if match := buildMediaMatch(search, starred, albumId, artistId, year); len(match) > 0 {
+	if match := buildMediaMatch(ctx, search, starred, albumId, artistId, year); len(match) > 0 {
 		pipeline = append(pipeline, bson.D{{Key: "$match", Value: match}})
 	}
 
 	// 处理排序
-	validatedSort := validateMediaSortField(sort)
+	validatedSort := sortFieldFor(scene_audio_route_models.SortEntityPlaylistTracks, sort)
 	pipeline = append(pipeline, buildMediaSortStage(validatedSort, order))
 
 	// 分页处理
@@ -168,42 +143,26 @@ func (r *playlistTrackRepository) GetPlaylistTrackFilterItemsCount(
 				{Key: "preserveNullAndEmptyArrays", Value: false},
 			}},
 		},
+	}
+	pipeline = append(pipeline, annotationFallbackStages(ctx, scene_audio_route_models.AnnotationItemMedia, "media_file.")...)
+	pipeline = append(pipeline, []bson.D{
 		{
-			{Key: "$lookup", Value: bson.D{
-				{Key: "from", Value: domain.CollectionFileEntityAudioSceneAnnotation},
-				{Key: "let", Value: bson.D{{Key: "mediaId", Value: "$media_file._id"}}},
-				{Key: "pipeline", Value: []bson.D{
-					{
-						{Key: "$match", Value: bson.D{
-							{Key: "$expr", Value: bson.D{
-								{Key: "$and", Value: bson.A{
-									bson.D{{Key: "$eq", Value: bson.A{"$item_id", "$$mediaId"}}},
-									bson.D{{Key: "$eq", Value: bson.A{"$item_type", "media"}}},
-								}},
-							}},
-						}},
-					},
-				}},
-				{Key: "as", Value: "annotations"},
-			}},
-		},
-		{
-			{Key: "$match", Value: buildMediaBaseMatch(search, albumId, artistId, year)},
+			{Key: "$match", Value: buildMediaBaseMatch(ctx, search, albumId, artistId, year)},
 		},
 		{
 			{Key: "$facet", Value: bson.D{
 				{Key: "total", Value: []bson.D{{{Key: "$count", Value: "count"}}}},
 				{Key: "starred", Value: []bson.D{
-					{{Key: "$match", Value: bson.D{{Key: "annotations.starred", Value: true}}}},
+					{{Key: "$match", Value: bson.D{{Key: "media_file.starred", Value: true}}}},
 					{{Key: "$count", Value: "count"}},
 				}},
 				{Key: "recent_play", Value: []bson.D{
-					{{Key: "$match", Value: bson.D{{Key: "annotations.play_count", Value: bson.D{{Key: "$gt", Value: 0}}}}}},
+					{{Key: "$match", Value: bson.D{{Key: "media_file.play_count", Value: bson.D{{Key: "$gt", Value: 0}}}}}},
 					{{Key: "$count", Value: "count"}},
 				}},
 			}},
 		},
-	}
+	}...)
 
 	cursor, err := coll.Aggregate(ctx, pipeline)
 	if err != nil {
@@ -236,6 +195,19 @@ func (r *playlistTrackRepository) GetPlaylistTrackFilterItemsCount(
 	return counts, nil
 }
 
+// requireOwnedPlaylist 曲目操作前确认播放列表属于当前用户，他人的播放列表按不存在处理
+func (r *playlistTrackRepository) requireOwnedPlaylist(ctx context.Context, playlistID primitive.ObjectID) error {
+	count, err := r.db.Collection(domain.CollectionFileEntityAudioScenePlaylist).CountDocuments(ctx,
+		bson.D{{Key: "_id", Value: playlistID}, playlistOwnerFilter(ctx)})
+	if err != nil {
+		return fmt.Errorf("playlist query failed: %w", err)
+	}
+	if count == 0 {
+		return fmt.Errorf("playlist %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
 // Helper functions
 func mustObjectID(hex string) primitive.ObjectID {
 	objID, err := primitive.ObjectIDFromHex(hex)
@@ -245,12 +217,12 @@ func mustObjectID(hex string) primitive.ObjectID {
 	return objID
 }
 
-func buildMediaBaseMatch(search, albumId, artistId, year string) bson.D {
-	return buildMediaMatch(search, "", albumId, artistId, year)
+func buildMediaBaseMatch(ctx context.Context, search, albumId, artistId, year string) bson.D {
+	return buildMediaMatch(ctx, search, "", albumId, artistId, year)
 }
 
-func buildMediaMatch(search, starred, albumId, artistId, year string) bson.D {
-	filter := bson.D{}
+func buildMediaMatch(ctx context.Context, search, starred, albumId, artistId, year string) bson.D {
+	filter := bson.D{visibleFilter(ctx)}
 
 	// 专辑ID过滤
 	if albumId != "" {
@@ -291,40 +263,6 @@ func buildMediaMatch(search, starred, albumId, artistId, year string) bson.D {
 	return filter
 }
 
-func validateMediaSortField(sort string) string {
-	sortMappings := map[string]string{
-		"title":        "order_title",
-		"album":        "order_album_name",
-		"artist":       "order_artist_name",
-		"album_artist": "order_album_artist_name",
-		"play_count":   "play_count",
-		"year":         "year",
-		"duration":     "duration",
-	}
-
-	if strings.ToLower(sort) == "_id" {
-		return "index"
-	}
-
-	lowerSort := strings.ToLower(sort)
-	if mappedField, exists := sortMappings[lowerSort]; exists {
-		return mappedField
-	}
-
-	validSortFields := map[string]bool{
-		"index": true, "play_count": true, "play_date": true,
-		"title": true, "artist": true, "album": true,
-		"year": true, "duration": true, "bit_rate": true,
-		"size": true, "rating": true, "starred_at": true,
-		"created_at": true, "updated_at": true,
-	}
-	if validSortFields[lowerSort] {
-		return lowerSort
-	}
-
-	return "index"
-}
-
 func buildMediaSortStage(sort, order string) bson.D {
 	if sort == "_id" {
 		sort = "index"
@@ -351,6 +289,9 @@ func (r *playlistTrackRepository) AddPlaylistTrackItems(
 	if err != nil {
 		return false, errors.New("invalid playlist id format")
 	}
+	if err := r.requireOwnedPlaylist(ctx, pID); err != nil {
+		return false, err
+	}
 
 	mediaIDs, err := splitMediaFileIds(mediaFileIds)
 	if err != nil {
@@ -453,6 +394,9 @@ func (r *playlistTrackRepository) RemovePlaylistTrackItems(
 	if err != nil {
 		return false, errors.New("invalid playlist id format")
 	}
+	if err := r.requireOwnedPlaylist(ctx, pID); err != nil {
+		return false, err
+	}
 
 	ids, err := splitMediaFileIds(mediaFileIds)
 	if err != nil {
@@ -483,6 +427,9 @@ func (r *playlistTrackRepository) SortPlaylistTrackItems(
 	if err != nil {
 		return false, errors.New("invalid playlist id format")
 	}
+	if err := r.requireOwnedPlaylist(ctx, pID); err != nil {
+		return false, err
+	}
 
 	ids, err := splitMediaFileIds(mediaFileIds)
 	if err != nil {
@@ -496,7 +443,7 @@ func (r *playlistTrackRepository) SortPlaylistTrackItems(
 			"playlist_id":   pID,
 			"media_file_id": id,
 		}
-		update := bson.M{"$set": bson.M{"position": index + 1}}
+		update := bson.M{"$set": bson.M{"index": index + 1}}
 
 		_, err := coll.UpdateOne(ctx, filter, update)
 		if err != nil {
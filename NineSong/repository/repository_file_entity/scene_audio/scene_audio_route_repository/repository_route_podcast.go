@@ -0,0 +1,376 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const podcastUpsertBatchSize = 500
+
+type podcastRepository struct {
+	db mongo.Database
+}
+
+func NewPodcastRepository(db mongo.Database) scene_audio_route_interface.PodcastRepository {
+	return &podcastRepository{db: db}
+}
+
+func (r *podcastRepository) GetChannels(ctx context.Context, userId string) ([]scene_audio_route_models.PodcastChannel, error) {
+	return r.findChannels(ctx, bson.M{"user_id": userId})
+}
+
+func (r *podcastRepository) AllChannels(ctx context.Context) ([]scene_audio_route_models.PodcastChannel, error) {
+	return r.findChannels(ctx, bson.M{})
+}
+
+func (r *podcastRepository) findChannels(ctx context.Context, filter bson.M) ([]scene_audio_route_models.PodcastChannel, error) {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioScenePodcastChannel).
+		Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "title", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("find operation failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	channels := make([]scene_audio_route_models.PodcastChannel, 0)
+	if err := cursor.All(ctx, &channels); err != nil {
+		return nil, fmt.Errorf("decode error: %w", err)
+	}
+	return channels, nil
+}
+
+func (r *podcastRepository) GetChannel(ctx context.Context, userId, id string) (*scene_audio_route_models.PodcastChannel, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, scene_audio_route_models.ErrInvalidPodcast
+	}
+
+	var channel scene_audio_route_models.PodcastChannel
+	err = r.db.Collection(domain.CollectionFileEntityAudioScenePodcastChannel).
+		FindOne(ctx, bson.M{"_id": objID, "user_id": userId}).Decode(&channel)
+	if err != nil {
+		if errors.Is(err, driver.ErrNoDocuments) {
+			return nil, fmt.Errorf("podcast %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("find one error: %w", err)
+	}
+	return &channel, nil
+}
+
+func (r *podcastRepository) CountChannels(ctx context.Context, userId string) (int64, error) {
+	return r.db.Collection(domain.CollectionFileEntityAudioScenePodcastChannel).CountDocuments(ctx, bson.M{"user_id": userId})
+}
+
+func (r *podcastRepository) CreateChannel(
+	ctx context.Context,
+	channel scene_audio_route_models.PodcastChannel,
+) (*scene_audio_route_models.PodcastChannel, error) {
+	channel.ID = primitive.NewObjectID()
+	channel.CreatedAt = time.Now().UTC()
+
+	if _, err := r.db.Collection(domain.CollectionFileEntityAudioScenePodcastChannel).InsertOne(ctx, channel); err != nil {
+		if driver.IsDuplicateKeyError(err) {
+			return nil, scene_audio_route_models.ErrPodcastExists
+		}
+		return nil, fmt.Errorf("insert failed: %w", err)
+	}
+	return &channel, nil
+}
+
+func (r *podcastRepository) SaveRefresh(ctx context.Context, channel scene_audio_route_models.PodcastChannel) error {
+	_, err := r.db.Collection(domain.CollectionFileEntityAudioScenePodcastChannel).UpdateOne(ctx,
+		bson.M{"_id": channel.ID},
+		bson.M{"$set": bson.M{
+			"title":             channel.Title,
+			"description":       channel.Description,
+			"author":            channel.Author,
+			"image_url":         channel.ImageURL,
+			"site_url":          channel.SiteURL,
+			"episode_count":     channel.EpisodeCount,
+			"last_refreshed_at": channel.LastRefreshedAt,
+			"last_error":        channel.LastError,
+		}},
+	)
+	if err != nil {
+		return fmt.Errorf("update failed: %w", err)
+	}
+	return nil
+}
+
+func (r *podcastRepository) UpdateAutoDownload(ctx context.Context, userId, id string, autoDownload int) (*scene_audio_route_models.PodcastChannel, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, scene_audio_route_models.ErrInvalidPodcast
+	}
+	result, err := r.db.Collection(domain.CollectionFileEntityAudioScenePodcastChannel).UpdateOne(ctx,
+		bson.M{"_id": objID, "user_id": userId},
+		bson.M{"$set": bson.M{"auto_download": autoDownload}},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("update failed: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return nil, fmt.Errorf("podcast %w", domain.ErrNotFound)
+	}
+	return r.GetChannel(ctx, userId, id)
+}
+
+func (r *podcastRepository) DeleteChannel(ctx context.Context, userId, id string) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, scene_audio_route_models.ErrInvalidPodcast
+	}
+	deleted, err := r.db.Collection(domain.CollectionFileEntityAudioScenePodcastChannel).
+		DeleteOne(ctx, bson.M{"_id": objID, "user_id": userId})
+	if err != nil {
+		return false, fmt.Errorf("delete failed: %w", err)
+	}
+	if deleted == 0 {
+		return false, nil
+	}
+	if _, err := r.db.Collection(domain.CollectionFileEntityAudioScenePodcastEpisode).
+		DeleteMany(ctx, bson.M{"channel_id": objID}); err != nil {
+		return true, fmt.Errorf("delete episodes failed: %w", err)
+	}
+	return true, nil
+}
+
+func (r *podcastRepository) UpsertEpisodes(
+	ctx context.Context,
+	channel scene_audio_route_models.PodcastChannel,
+	episodes []scene_audio_route_models.PodcastEpisode,
+) (int, error) {
+	coll := r.db.Collection(domain.CollectionFileEntityAudioScenePodcastEpisode)
+	for i := 0; i < len(episodes); i += podcastUpsertBatchSize {
+		end := i + podcastUpsertBatchSize
+		if end > len(episodes) {
+			end = len(episodes)
+		}
+		models := make([]driver.WriteModel, 0, end-i)
+		for _, episode := range episodes[i:end] {
+			models = append(models, driver.NewUpdateOneModel().
+				SetFilter(bson.M{"channel_id": channel.ID, "guid": episode.GUID}).
+				SetUpdate(bson.M{
+					"$set": bson.M{
+						"title":         episode.Title,
+						"description":   episode.Description,
+						"published_at":  episode.PublishedAt,
+						"duration":      episode.Duration,
+						"enclosure_url": episode.EnclosureURL,
+						"mime_type":     episode.MimeType,
+						"size":          episode.Size,
+					},
+					"$setOnInsert": bson.M{
+						"user_id":         channel.UserID,
+						"download_status": scene_audio_route_models.PodcastDownloadNone,
+						"played":          false,
+						"position":        0,
+					},
+				}).
+				SetUpsert(true))
+		}
+		if _, err := coll.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+			return 0, fmt.Errorf("upsert episodes failed: %w", err)
+		}
+	}
+
+	total, err := coll.CountDocuments(ctx, bson.M{"channel_id": channel.ID})
+	if err != nil {
+		return 0, fmt.Errorf("count episodes failed: %w", err)
+	}
+	return int(total), nil
+}
+
+func (r *podcastRepository) GetEpisodes(
+	ctx context.Context,
+	userId string,
+	query scene_audio_route_models.PodcastEpisodeQuery,
+) ([]scene_audio_route_models.PodcastEpisode, int, error) {
+	channelID, err := primitive.ObjectIDFromHex(query.ChannelID)
+	if err != nil {
+		return nil, 0, scene_audio_route_models.ErrInvalidPodcast
+	}
+	filter := bson.M{"channel_id": channelID, "user_id": userId}
+	if query.Played != nil {
+		filter["played"] = *query.Played
+	}
+
+	coll := r.db.Collection(domain.CollectionFileEntityAudioScenePodcastEpisode)
+	total, err := coll.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("count episodes failed: %w", err)
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "published_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(query.Start))
+	if query.Count > 0 {
+		opts.SetLimit(int64(query.Count))
+	}
+	cursor, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("find operation failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	episodes := make([]scene_audio_route_models.PodcastEpisode, 0)
+	if err := cursor.All(ctx, &episodes); err != nil {
+		return nil, 0, fmt.Errorf("decode error: %w", err)
+	}
+	return episodes, int(total), nil
+}
+
+func (r *podcastRepository) GetEpisode(ctx context.Context, userId, id string) (*scene_audio_route_models.PodcastEpisode, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, scene_audio_route_models.ErrInvalidPodcast
+	}
+
+	var episode scene_audio_route_models.PodcastEpisode
+	err = r.db.Collection(domain.CollectionFileEntityAudioScenePodcastEpisode).
+		FindOne(ctx, bson.M{"_id": objID, "user_id": userId}).Decode(&episode)
+	if err != nil {
+		if errors.Is(err, driver.ErrNoDocuments) {
+			return nil, fmt.Errorf("podcast episode %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("find one error: %w", err)
+	}
+	return &episode, nil
+}
+
+func (r *podcastRepository) LatestEpisodes(ctx context.Context, channelId string, n int) ([]scene_audio_route_models.PodcastEpisode, error) {
+	objID, err := primitive.ObjectIDFromHex(channelId)
+	if err != nil {
+		return nil, scene_audio_route_models.ErrInvalidPodcast
+	}
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioScenePodcastEpisode).Find(ctx,
+		bson.M{"channel_id": objID},
+		options.Find().SetSort(bson.D{{Key: "published_at", Value: -1}, {Key: "_id", Value: -1}}).SetLimit(int64(n)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("find operation failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	episodes := make([]scene_audio_route_models.PodcastEpisode, 0, n)
+	if err := cursor.All(ctx, &episodes); err != nil {
+		return nil, fmt.Errorf("decode error: %w", err)
+	}
+	return episodes, nil
+}
+
+func (r *podcastRepository) DownloadedPaths(ctx context.Context, channelId string) ([]string, error) {
+	objID, err := primitive.ObjectIDFromHex(channelId)
+	if err != nil {
+		return nil, scene_audio_route_models.ErrInvalidPodcast
+	}
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioScenePodcastEpisode).Find(ctx,
+		bson.M{"channel_id": objID, "local_path": bson.M{"$nin": bson.A{"", nil}}},
+		options.Find().SetProjection(bson.M{"local_path": 1}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("find operation failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []struct {
+		LocalPath string `bson:"local_path"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("decode error: %w", err)
+	}
+	paths := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		paths = append(paths, doc.LocalPath)
+	}
+	return paths, nil
+}
+
+func (r *podcastRepository) UpdateProgress(
+	ctx context.Context,
+	userId, id string,
+	progress scene_audio_route_models.PodcastProgress,
+) (*scene_audio_route_models.PodcastEpisode, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, scene_audio_route_models.ErrInvalidPodcast
+	}
+
+	set := bson.M{}
+	if progress.Position != nil {
+		set["position"] = *progress.Position
+	}
+	if progress.Played != nil {
+		set["played"] = *progress.Played
+		if *progress.Played {
+			set["played_at"] = time.Now().UTC()
+		}
+	}
+	update := bson.M{"$set": set}
+	if progress.Played != nil && !*progress.Played {
+		update["$unset"] = bson.M{"played_at": ""}
+	}
+
+	result, err := r.db.Collection(domain.CollectionFileEntityAudioScenePodcastEpisode).
+		UpdateOne(ctx, bson.M{"_id": objID, "user_id": userId}, update)
+	if err != nil {
+		return nil, fmt.Errorf("update failed: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return nil, fmt.Errorf("podcast episode %w", domain.ErrNotFound)
+	}
+	return r.GetEpisode(ctx, userId, id)
+}
+
+func (r *podcastRepository) ClaimDownload(ctx context.Context, id string) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, scene_audio_route_models.ErrInvalidPodcast
+	}
+	result, err := r.db.Collection(domain.CollectionFileEntityAudioScenePodcastEpisode).UpdateOne(ctx,
+		bson.M{"_id": objID, "download_status": bson.M{"$nin": bson.A{
+			scene_audio_route_models.PodcastDownloadQueued,
+			scene_audio_route_models.PodcastDownloadDownloading,
+			scene_audio_route_models.PodcastDownloadCompleted,
+		}}},
+		bson.M{
+			"$set":   bson.M{"download_status": scene_audio_route_models.PodcastDownloadQueued},
+			"$unset": bson.M{"download_error": ""},
+		},
+	)
+	if err != nil {
+		return false, fmt.Errorf("update failed: %w", err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+func (r *podcastRepository) SetDownloadState(ctx context.Context, id, status, localPath, errMsg string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return scene_audio_route_models.ErrInvalidPodcast
+	}
+	result, err := r.db.Collection(domain.CollectionFileEntityAudioScenePodcastEpisode).UpdateOne(ctx,
+		bson.M{"_id": objID},
+		bson.M{"$set": bson.M{
+			"download_status": status,
+			"local_path":      localPath,
+			"download_error":  errMsg,
+		}},
+	)
+	if err != nil {
+		return fmt.Errorf("update failed: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("podcast episode %w", domain.ErrNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,367 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_lastfm/scene_audio_lastfm_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// lastFMSimilarFetch 每次向 Last.fm 请求的相似艺术家数量，多数在本地媒体库中不存在
+const lastFMSimilarFetch = 100
+
+type recommendationRepository struct {
+	db     mongo.Database
+	lastFM scene_audio_lastfm_interface.LastFMClient
+}
+
+// NewRecommendationRepository lastFM 为 nil 时只使用已保存的 Last.fm 相似艺术家
+func NewRecommendationRepository(db mongo.Database, lastFM scene_audio_lastfm_interface.LastFMClient) scene_audio_route_interface.RecommendationRepository {
+	return &recommendationRepository{db: db, lastFM: lastFM}
+}
+
+func (r *recommendationRepository) GetArtistExternalInfo(ctx context.Context, artistId string) (*scene_audio_route_models.ArtistExternalInfo, error) {
+	oid, err := primitive.ObjectIDFromHex(artistId)
+	if err != nil {
+		return nil, errors.New("invalid artist id format")
+	}
+	var artist scene_audio_route_models.ArtistExternalInfo
+	err = r.db.Collection(domain.CollectionFileEntityAudioSceneArtist).FindOne(ctx, bson.M{"_id": oid}).Decode(&artist)
+	if err != nil {
+		if errors.Is(err, driver.ErrNoDocuments) {
+			return nil, fmt.Errorf("artist %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("artist query failed: %w", err)
+	}
+	return &artist, nil
+}
+
+func (r *recommendationRepository) GenreNeighbours(ctx context.Context, artistId string, limit int) ([]scene_audio_route_models.ArtistScore, error) {
+	mediaColl := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile)
+	cursor, err := mediaColl.Aggregate(ctx, []bson.D{
+		{{Key: "$match", Value: bson.D{
+			{Key: "$or", Value: bson.A{
+				bson.M{"artist_id": artistId},
+				bson.M{"all_artist_ids.artist_id": artistId},
+			}},
+			{Key: "genre", Value: bson.M{"$nin": bson.A{"", nil}}},
+		}}},
+		{{Key: "$group", Value: bson.D{{Key: "_id", Value: "$genre"}}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("artist genres query failed: %w", err)
+	}
+	var genreRows []struct {
+		Genre string `bson:"_id"`
+	}
+	err = cursor.All(ctx, &genreRows)
+	_ = cursor.Close(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("decode artist genres failed: %w", err)
+	}
+	if len(genreRows) == 0 {
+		return []scene_audio_route_models.ArtistScore{}, nil
+	}
+	genres := make(bson.A, 0, len(genreRows))
+	for _, row := range genreRows {
+		genres = append(genres, row.Genre)
+	}
+
+	return r.aggregateScores(ctx, mediaColl, []bson.D{
+		{{Key: "$match", Value: bson.D{
+			{Key: "genre", Value: bson.M{"$in": genres}},
+			{Key: "artist_id", Value: bson.M{"$nin": bson.A{artistId, "", nil}}},
+			visibleFilter(ctx),
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$artist_id"},
+			{Key: "genres", Value: bson.M{"$addToSet": "$genre"}},
+			{Key: "tracks", Value: bson.M{"$sum": 1}},
+		}}},
+		{{Key: "$addFields", Value: bson.M{"score": bson.M{"$divide": bson.A{bson.M{"$size": "$genres"}, len(genres)}}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "score", Value: -1}, {Key: "tracks", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+	})
+}
+
+func (r *recommendationRepository) CoPlayNeighbours(ctx context.Context, artistId string, limit int) ([]scene_audio_route_models.ArtistScore, error) {
+	historyColl := r.db.Collection(domain.CollectionFileEntityAudioScenePlayHistory)
+	cursor, err := historyColl.Aggregate(ctx, []bson.D{
+		{{Key: "$match", Value: bson.M{"artist_id": artistId}}},
+		{{Key: "$group", Value: bson.D{{Key: "_id", Value: "$user_id"}}}},
+		{{Key: "$limit", Value: scene_audio_route_models.RecommendationCoPlayUsers}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("artist listeners query failed: %w", err)
+	}
+	var userRows []struct {
+		UserID string `bson:"_id"`
+	}
+	err = cursor.All(ctx, &userRows)
+	_ = cursor.Close(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("decode artist listeners failed: %w", err)
+	}
+	if len(userRows) == 0 {
+		return []scene_audio_route_models.ArtistScore{}, nil
+	}
+	users := make(bson.A, 0, len(userRows))
+	for _, row := range userRows {
+		users = append(users, row.UserID)
+	}
+
+	return r.aggregateScores(ctx, historyColl, []bson.D{
+		{{Key: "$match", Value: bson.D{
+			{Key: "user_id", Value: bson.M{"$in": users}},
+			{Key: "artist_id", Value: bson.M{"$nin": bson.A{artistId, "", nil}}},
+		}}},
+		{{Key: "$group", Value: bson.D{{Key: "_id", Value: bson.D{
+			{Key: "artist_id", Value: "$artist_id"},
+			{Key: "user_id", Value: "$user_id"},
+		}}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$_id.artist_id"},
+			{Key: "listeners", Value: bson.M{"$sum": 1}},
+		}}},
+		{{Key: "$addFields", Value: bson.M{"score": bson.M{"$divide": bson.A{"$listeners", len(users)}}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "score", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+	})
+}
+
+// LastFMNeighbours 保存的结果只有顺序，得分按排名线性递减；获取失败时退回已保存的结果
+func (r *recommendationRepository) LastFMNeighbours(
+	ctx context.Context,
+	artist *scene_audio_route_models.ArtistExternalInfo,
+	limit int,
+) ([]scene_audio_route_models.ArtistScore, error) {
+	ids := artist.SimilarArtistsIDs
+	if r.lastFM != nil && time.Since(artist.ExternalInfoUpdatedAt) > scene_audio_route_models.LastFMSimilarRefresh {
+		fetched, err := r.refreshLastFM(ctx, artist)
+		if err != nil {
+			log.Printf("获取 Last.fm 相似艺术家失败 %s: %v", artist.Name, err)
+		} else {
+			ids = fetched
+		}
+	}
+
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	scores := make([]scene_audio_route_models.ArtistScore, 0, len(ids))
+	for i, id := range ids {
+		scores = append(scores, scene_audio_route_models.ArtistScore{
+			ArtistID: id,
+			Score:    1 - float64(i)/float64(len(ids)),
+		})
+	}
+	return scores, nil
+}
+
+// refreshLastFM 按名称（不区分大小写）或 MusicBrainz ID 匹配本地艺术家并保存到 similar_artists_ids
+func (r *recommendationRepository) refreshLastFM(ctx context.Context, artist *scene_audio_route_models.ArtistExternalInfo) ([]string, error) {
+	similar, err := r.lastFM.SimilarArtists(ctx, artist.Name, artist.MBZArtistID, lastFMSimilarFetch)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(bson.A, 0, len(similar))
+	mbids := make(bson.A, 0, len(similar))
+	for _, s := range similar {
+		names = append(names, strings.ToLower(s.Name))
+		if s.MBID != "" {
+			mbids = append(mbids, s.MBID)
+		}
+	}
+
+	artistColl := r.db.Collection(domain.CollectionFileEntityAudioSceneArtist)
+	ids := make([]string, 0)
+	if len(similar) > 0 {
+		cursor, err := artistColl.Aggregate(ctx, []bson.D{
+			{{Key: "$match", Value: bson.D{
+				{Key: "_id", Value: bson.M{"$ne": artist.ID}},
+				{Key: "$or", Value: bson.A{
+					bson.M{"$expr": bson.M{"$in": bson.A{bson.M{"$toLower": "$name"}, names}}},
+					bson.M{"mbz_artist_id": bson.M{"$in": mbids}},
+				}},
+			}}},
+			{{Key: "$project", Value: bson.M{"name": 1, "mbz_artist_id": 1}}},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("match lastfm artists failed: %w", err)
+		}
+		var local []struct {
+			ID          primitive.ObjectID `bson:"_id"`
+			Name        string             `bson:"name"`
+			MBZArtistID string             `bson:"mbz_artist_id"`
+		}
+		err = cursor.All(ctx, &local)
+		_ = cursor.Close(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("decode lastfm artists failed: %w", err)
+		}
+
+		// 按 Last.fm 的相似度顺序输出
+		seen := make(map[primitive.ObjectID]bool, len(local))
+		for _, s := range similar {
+			for _, l := range local {
+				if seen[l.ID] {
+					continue
+				}
+				if strings.EqualFold(l.Name, s.Name) || (s.MBID != "" && l.MBZArtistID == s.MBID) {
+					seen[l.ID] = true
+					ids = append(ids, l.ID.Hex())
+				}
+			}
+		}
+	}
+
+	_, err = artistColl.UpdateOne(ctx, bson.M{"_id": artist.ID}, bson.M{"$set": bson.M{
+		"similar_artists_ids":      ids,
+		"external_info_updated_at": time.Now(),
+	}})
+	if err != nil {
+		return nil, fmt.Errorf("save lastfm artists failed: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *recommendationRepository) TopPlayedArtists(ctx context.Context, userId string, since time.Time, limit int) ([]scene_audio_route_models.ArtistScore, error) {
+	return r.aggregateScores(ctx, r.db.Collection(domain.CollectionFileEntityAudioScenePlayHistory), []bson.D{
+		{{Key: "$match", Value: bson.M{
+			"user_id":   userId,
+			"artist_id": bson.M{"$nin": bson.A{"", nil}},
+			"played_at": bson.M{"$gte": since},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$artist_id"},
+			{Key: "score", Value: bson.M{"$sum": 1}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "score", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+	})
+}
+
+func (r *recommendationRepository) GetArtistsByIDs(ctx context.Context, ids []string) ([]scene_audio_route_models.ArtistMetadata, error) {
+	artists := make([]scene_audio_route_models.ArtistMetadata, 0, len(ids))
+	oids := objectIDs(ids)
+	if len(oids) == 0 {
+		return artists, nil
+	}
+	scopeCond, err := visibleArtistCondition(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneArtist).Aggregate(ctx, []bson.D{
+		{{Key: "$match", Value: bson.M{"_id": bson.M{"$in": oids}}}},
+		{{Key: "$match", Value: append(bson.D{}, scopeCond...)}},
+		{{Key: "$addFields", Value: bson.M{"_order": bson.M{"$indexOfArray": bson.A{oids, "$_id"}}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_order", Value: 1}}}},
+		{{Key: "$project", Value: bson.M{"_order": 0}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("artists query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+	if err := cursor.All(ctx, &artists); err != nil {
+		return nil, fmt.Errorf("decode artists failed: %w", err)
+	}
+	return artists, nil
+}
+
+func (r *recommendationRepository) UnplayedAlbumsByArtists(
+	ctx context.Context,
+	userId string,
+	artistIds []string,
+	limit int,
+) ([]scene_audio_route_models.AlbumMetadata, error) {
+	albums := make([]scene_audio_route_models.AlbumMetadata, 0)
+	if len(artistIds) == 0 {
+		return albums, nil
+	}
+
+	played, err := r.playedAlbumIDs(ctx, userId, artistIds)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneAlbum).Find(ctx,
+		bson.M{
+			"$or": bson.A{
+				bson.M{"artist_id": bson.M{"$in": artistIds}},
+				bson.M{"album_artist_id": bson.M{"$in": artistIds}},
+			},
+			"_id": bson.M{"$nin": played},
+		},
+		options.Find().SetSort(bson.D{{Key: "play_count", Value: -1}, {Key: "_id", Value: 1}}).SetLimit(int64(limit)))
+	if err != nil {
+		return nil, fmt.Errorf("recommended albums query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+	if err := cursor.All(ctx, &albums); err != nil {
+		return nil, fmt.Errorf("decode recommended albums failed: %w", err)
+	}
+	return albums, nil
+}
+
+// playedAlbumIDs 用户播放过的、属于这些艺术家的专辑
+func (r *recommendationRepository) playedAlbumIDs(ctx context.Context, userId string, artistIds []string) ([]primitive.ObjectID, error) {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioScenePlayHistory).Aggregate(ctx, []bson.D{
+		{{Key: "$match", Value: bson.M{
+			"user_id":   userId,
+			"artist_id": bson.M{"$in": artistIds},
+			"album_id":  bson.M{"$nin": bson.A{"", nil}},
+		}}},
+		{{Key: "$group", Value: bson.D{{Key: "_id", Value: "$album_id"}}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("played albums query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+	var rows []struct {
+		AlbumID string `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("decode played albums failed: %w", err)
+	}
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.AlbumID)
+	}
+	return objectIDs(ids), nil
+}
+
+func (r *recommendationRepository) aggregateScores(ctx context.Context, coll mongo.Collection, pipeline []bson.D) ([]scene_audio_route_models.ArtistScore, error) {
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("artist score aggregate failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+	scores := make([]scene_audio_route_models.ArtistScore, 0)
+	if err := cursor.All(ctx, &scores); err != nil {
+		return nil, fmt.Errorf("decode artist scores failed: %w", err)
+	}
+	return scores, nil
+}
+
+// objectIDs 跳过无效的十六进制ID
+func objectIDs(ids []string) []primitive.ObjectID {
+	oids := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+			oids = append(oids, oid)
+		}
+	}
+	return oids
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
 	"os"
 	"path/filepath"
 )
@@ -31,7 +32,7 @@ func (r *retrievalRepository) GetStreamPath(ctx context.Context, mediaFileId str
 	if cueModel {
 		collection := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFileCue)
 		var result scene_audio_route_models.MediaFileCueMetadata
-		err = collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&result)
+		err = collection.FindOne(ctx, append(bson.D{{Key: "_id", Value: objID}}, visibleCueFilter(ctx)...)).Decode(&result)
 		if err != nil {
 			return "", fmt.Errorf("stream metadata not found: %w", err)
 		}
@@ -39,7 +40,10 @@ func (r *retrievalRepository) GetStreamPath(ctx context.Context, mediaFileId str
 	} else {
 		collection := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile)
 		var result scene_audio_route_models.MediaFileMetadata
-		err = collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&result)
+		err = collection.FindOne(ctx, append(bson.D{{Key: "_id", Value: objID}}, folderScopeMatch(ctx, "")...)).Decode(&result)
+		if errors.Is(err, driver.ErrNoDocuments) {
+			return r.episodeStreamPath(ctx, objID)
+		}
 		if err != nil {
 			return "", fmt.Errorf("stream metadata not found: %w", err)
 		}
@@ -47,6 +51,22 @@ func (r *retrievalRepository) GetStreamPath(ctx context.Context, mediaFileId str
 	}
 }
 
+// episodeStreamPath 播客单集与曲目共用流媒体接口：已下载时返回本地路径，否则返回附件的远程地址
+func (r *retrievalRepository) episodeStreamPath(ctx context.Context, objID primitive.ObjectID) (string, error) {
+	var episode scene_audio_route_models.PodcastEpisode
+	err := r.db.Collection(domain.CollectionFileEntityAudioScenePodcastEpisode).FindOne(ctx, bson.M{
+		"_id":     objID,
+		"user_id": domain.UserIDFromContext(ctx),
+	}).Decode(&episode)
+	if err != nil {
+		return "", fmt.Errorf("stream metadata not found: %w", err)
+	}
+	if episode.DownloadStatus == scene_audio_route_models.PodcastDownloadCompleted && episode.LocalPath != "" {
+		return episode.LocalPath, nil
+	}
+	return episode.EnclosureURL, nil
+}
+
 func (r *retrievalRepository) GetStreamTempPath(ctx context.Context, metadataType string) (string, error) {
 	collection := r.db.Collection(domain.CollectionFileEntityAudioSceneTempMetadata)
 
@@ -65,6 +85,68 @@ func (r *retrievalRepository) GetStreamTempPath(ctx context.Context, metadataTyp
 	return result.FolderPath, nil
 }
 
+func (r *retrievalRepository) GetReplayGain(ctx context.Context, mediaFileId string) (*scene_audio_route_models.RetrievalReplayGainMetadata, error) {
+	objID, err := primitive.ObjectIDFromHex(mediaFileId)
+	if err != nil {
+		return nil, errors.New("invalid media file id format")
+	}
+
+	collection := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile)
+	var result scene_audio_route_models.RetrievalReplayGainMetadata
+	err = collection.FindOne(ctx, append(bson.D{{Key: "_id", Value: objID}}, folderScopeMatch(ctx, "")...)).Decode(&result)
+	if err != nil {
+		return nil, fmt.Errorf("replay gain metadata not found: %w", err)
+	}
+	return &result, nil
+}
+
+// GetCueSegment 读取整轨 CUE 中指定音轨的起止位置；早于起止字段的扫描结果按 INDEX 现场推算
+func (r *retrievalRepository) GetCueSegment(ctx context.Context, mediaFileCueId string, track int) (*scene_audio_route_models.RetrievalCueSegment, error) {
+	objID, err := primitive.ObjectIDFromHex(mediaFileCueId)
+	if err != nil {
+		return nil, errors.New("invalid media file cue id format")
+	}
+
+	var cue scene_audio_db_models.MediaFileCueMetadata
+	err = r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFileCue).FindOne(ctx, append(bson.D{{Key: "_id", Value: objID}}, visibleCueFilter(ctx)...)).Decode(&cue)
+	if err != nil {
+		return nil, fmt.Errorf("cue metadata not found: %w", err)
+	}
+
+	for i, t := range cue.CueTracks {
+		if t.TRACK != track {
+			continue
+		}
+		if t.StartSeconds == 0 && t.DurationSeconds == 0 {
+			scene_audio_db_models.ApplyCueTrackOffsets(cue.CueTracks, 0)
+			t = cue.CueTracks[i]
+		}
+		return &scene_audio_route_models.RetrievalCueSegment{
+			Track:    t.TRACK,
+			Start:    t.StartSeconds,
+			Duration: t.DurationSeconds,
+			Gain:     t.GAIN,
+			Peak:     t.PEAK,
+		}, nil
+	}
+	return nil, fmt.Errorf("cue track %d not found", track)
+}
+
+func (r *retrievalRepository) GetGaplessInfo(ctx context.Context, mediaFileId string) (*scene_audio_route_models.RetrievalGaplessMetadata, error) {
+	objID, err := primitive.ObjectIDFromHex(mediaFileId)
+	if err != nil {
+		return nil, errors.New("invalid media file id format")
+	}
+
+	collection := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile)
+	var result scene_audio_route_models.RetrievalGaplessMetadata
+	err = collection.FindOne(ctx, append(bson.D{{Key: "_id", Value: objID}}, folderScopeMatch(ctx, "")...)).Decode(&result)
+	if err != nil {
+		return nil, fmt.Errorf("gapless metadata not found: %w", err)
+	}
+	return &result, nil
+}
+
 func (r *retrievalRepository) GetDownloadPath(ctx context.Context, mediaFileId string) (string, error) {
 	objID, err := primitive.ObjectIDFromHex(mediaFileId)
 	if err != nil {
@@ -73,7 +155,7 @@ func (r *retrievalRepository) GetDownloadPath(ctx context.Context, mediaFileId s
 
 	collection := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile)
 	var result scene_audio_route_models.MediaFileMetadata
-	err = collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&result)
+	err = collection.FindOne(ctx, append(bson.D{{Key: "_id", Value: objID}}, folderScopeMatch(ctx, "")...)).Decode(&result)
 	if err != nil {
 		return "", fmt.Errorf("download metadata not found: %w", err)
 	}
@@ -146,6 +228,38 @@ func (r *retrievalRepository) GetCoverArtID(ctx context.Context, fileType string
 	}
 }
 
+// folderCoverNames 扫描时未提取到封面的条目，回退使用源文件所在目录中的封面图
+var folderCoverNames = []string{"cover.jpg", "cover.png", "cover.jpeg", "folder.jpg", "folder.png", "front.jpg", "front.png"}
+
+// GetCoverArtByID 依次查找专辑、曲目、艺术家在扫描时保存的封面，均不存在时回退到源文件目录中的封面图
+func (r *retrievalRepository) GetCoverArtByID(ctx context.Context, targetID string) (string, error) {
+	objID, err := primitive.ObjectIDFromHex(targetID)
+	if err != nil {
+		return "", errors.New("invalid target id format")
+	}
+
+	for _, fileType := range []string{"album", "media", "artist"} {
+		if path, err := r.GetCoverArtID(ctx, fileType, targetID); err == nil {
+			return path, nil
+		}
+	}
+
+	var media scene_audio_route_models.MediaFileMetadata
+	err = r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).FindOne(ctx,
+		bson.M{"$or": bson.A{bson.M{"_id": objID}, bson.M{"album_id": targetID}}},
+	).Decode(&media)
+	if err != nil {
+		return "", fmt.Errorf("cover art not found: %w", err)
+	}
+	dir := filepath.Dir(media.Path)
+	for _, name := range folderCoverNames {
+		if path, err := r.checkCoverFile(dir, name); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("cover art not found: %w", os.ErrNotExist)
+}
+
 func (r *retrievalRepository) checkCoverFile(basePath string, fileName string) (string, error) {
 	typePath := filepath.Join(basePath, fileName)
 	fileInfo, err := os.Stat(typePath)
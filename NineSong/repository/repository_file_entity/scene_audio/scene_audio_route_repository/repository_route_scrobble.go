@@ -0,0 +1,110 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type scrobbleRepository struct {
+	db         mongo.Database
+	collection string
+}
+
+func NewScrobbleRepository(db mongo.Database, collection string) scene_audio_route_interface.ScrobbleRepository {
+	return &scrobbleRepository{
+		db:         db,
+		collection: collection,
+	}
+}
+
+func (r *scrobbleRepository) Scrobble(
+	ctx context.Context,
+	history scene_audio_route_models.PlayHistoryMetadata,
+) (*scene_audio_route_models.PlayHistoryMetadata, error) {
+	var media struct {
+		AlbumID  string `bson:"album_id"`
+		ArtistID string `bson:"artist_id"`
+	}
+	err := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).
+		FindOne(ctx, bson.D{{Key: "_id", Value: history.MediaFileID}, visibleFilter(ctx)}).
+		Decode(&media)
+	if err != nil {
+		if errors.Is(err, driver.ErrNoDocuments) {
+			return nil, fmt.Errorf("media file %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("media query failed: %w", err)
+	}
+
+	now := time.Now().UTC()
+	history.ID = primitive.NewObjectID()
+	history.AlbumID = media.AlbumID
+	history.ArtistID = media.ArtistID
+	if history.PlayedAt.IsZero() {
+		history.PlayedAt = now
+	}
+	history.CreatedAt = now
+
+	models := []driver.WriteModel{
+		scrobbleWriteModel(history.UserID, history.MediaFileID, scene_audio_route_models.AnnotationItemMedia, history.Complete, now),
+	}
+	synced := map[scene_audio_route_models.AnnotationItemType]primitive.ObjectID{scene_audio_route_models.AnnotationItemMedia: history.MediaFileID}
+	if albumID, err := primitive.ObjectIDFromHex(media.AlbumID); err == nil {
+		models = append(models, scrobbleWriteModel(history.UserID, albumID, scene_audio_route_models.AnnotationItemAlbum, history.Complete, now))
+		synced[scene_audio_route_models.AnnotationItemAlbum] = albumID
+	}
+	if artistID, err := primitive.ObjectIDFromHex(media.ArtistID); err == nil {
+		models = append(models, scrobbleWriteModel(history.UserID, artistID, scene_audio_route_models.AnnotationItemArtist, history.Complete, now))
+		synced[scene_audio_route_models.AnnotationItemArtist] = artistID
+	}
+
+	// 三条注释更新互不依赖，无序批量写入一次往返完成
+	_, err = r.db.Collection(domain.CollectionFileEntityAudioSceneAnnotation).
+		BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return nil, fmt.Errorf("annotation bulk write failed: %w", err)
+	}
+	for itemType, itemID := range synced {
+		syncItemAnnotationsQuietly(ctx, r.db, itemType, itemID)
+	}
+
+	if _, err := r.db.Collection(r.collection).InsertOne(ctx, history); err != nil {
+		return nil, fmt.Errorf("insert play history failed: %w", err)
+	}
+	return &history, nil
+}
+
+func scrobbleWriteModel(userID string, itemID primitive.ObjectID, itemType scene_audio_route_models.AnnotationItemType, complete bool, now time.Time) driver.WriteModel {
+	inc := bson.M{"play_count": 1}
+	if complete {
+		inc["play_complete_count"] = 1
+	}
+
+	return driver.NewUpdateOneModel().
+		SetFilter(bson.M{"user_id": userID, "item_id": itemID, "item_type": itemType}).
+		SetUpdate(bson.M{
+			"$inc": inc,
+			"$set": bson.M{
+				"play_date":  now,
+				"updated_at": now,
+			},
+			// 字段缺失时 $min 直接写入，只在首次播放时生效
+			"$min": bson.M{"first_play_date": now},
+			"$setOnInsert": bson.M{
+				"created_at": now,
+				"starred":    false,
+				"rating":     0,
+			},
+		}).
+		SetUpsert(true)
+}
@@ -0,0 +1,222 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type searchRepository struct {
+	db mongo.Database
+
+	engineOnce sync.Once
+	engine     string
+}
+
+func NewSearchRepository(db mongo.Database) scene_audio_route_interface.SearchRepository {
+	return &searchRepository{db: db}
+}
+
+// searchTarget 单个集合的检索配置
+type searchTarget struct {
+	collection string
+	itemType   scene_audio_route_models.AnnotationItemType // 注解中的 item_type
+	paths      []string                                    // Atlas Search 检索字段
+	// filter 附加过滤条件，按调用方的媒体库权限计算
+	filter func(ctx context.Context, db mongo.Database) (bson.D, error)
+}
+
+var (
+	searchSongTarget = searchTarget{
+		collection: domain.CollectionFileEntityAudioSceneMediaFile,
+		itemType:   scene_audio_route_models.AnnotationItemMedia,
+		paths:      []string{"title", "artist", "album", "album_artist", "composer"},
+		filter: func(ctx context.Context, _ mongo.Database) (bson.D, error) {
+			return bson.D{visibleFilter(ctx)}, nil
+		},
+	}
+	searchAlbumTarget = searchTarget{
+		collection: domain.CollectionFileEntityAudioSceneAlbum,
+		itemType:   scene_audio_route_models.AnnotationItemAlbum,
+		paths:      []string{"name", "artist", "album_artist"},
+		filter:     visibleAlbumCondition,
+	}
+	searchArtistTarget = searchTarget{
+		collection: domain.CollectionFileEntityAudioSceneArtist,
+		itemType:   scene_audio_route_models.AnnotationItemArtist,
+		paths:      []string{"name", "aliases.name"},
+		filter:     visibleArtistCondition,
+	}
+)
+
+func (r *searchRepository) Search(
+	ctx context.Context,
+	query string,
+	paging scene_audio_route_models.SearchPaging,
+) (*scene_audio_route_models.SearchResult, error) {
+	result := &scene_audio_route_models.SearchResult{
+		Songs:         make([]scene_audio_route_models.MediaFileMetadata, 0),
+		Albums:        make([]scene_audio_route_models.AlbumMetadata, 0),
+		Artists:       make([]scene_audio_route_models.ArtistMetadata, 0),
+		RadioStations: make([]scene_audio_route_models.InternetRadioStation, 0),
+		Engine:        r.detectEngine(ctx),
+	}
+
+	var err error
+	if result.SongTotal, err = r.searchGroup(ctx, searchSongTarget, query, result.Engine, paging.SongOffset, paging.SongCount, &result.Songs); err != nil {
+		return nil, err
+	}
+	if result.AlbumTotal, err = r.searchGroup(ctx, searchAlbumTarget, query, result.Engine, paging.AlbumOffset, paging.AlbumCount, &result.Albums); err != nil {
+		return nil, err
+	}
+	if result.ArtistTotal, err = r.searchGroup(ctx, searchArtistTarget, query, result.Engine, paging.ArtistOffset, paging.ArtistCount, &result.Artists); err != nil {
+		return nil, err
+	}
+	if result.RadioTotal, err = r.searchRadio(ctx, query, paging.RadioOffset, paging.RadioCount, &result.RadioStations); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// searchRadio 电台为用户私有且数量很少，不建全文索引，按名称不区分大小写包含匹配
+func (r *searchRepository) searchRadio(
+	ctx context.Context,
+	query string,
+	offset, count int,
+	out *[]scene_audio_route_models.InternetRadioStation,
+) (int, error) {
+	userID := domain.UserIDFromContext(ctx)
+	if count <= 0 || userID == "" {
+		return 0, nil
+	}
+	coll := r.db.Collection(domain.CollectionFileEntityAudioSceneInternetRadio)
+	filter := bson.M{
+		"user_id": userID,
+		"name":    primitive.Regex{Pattern: regexp.QuoteMeta(query), Options: "i"},
+	}
+
+	total, err := coll.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("radio search failed: %w", err)
+	}
+	cursor, err := coll.Find(ctx, filter, options.Find().
+		SetSort(bson.D{{Key: "name", Value: 1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(count)))
+	if err != nil {
+		return 0, fmt.Errorf("radio search failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+	if err := cursor.All(ctx, out); err != nil {
+		return 0, fmt.Errorf("decode radio search error: %w", err)
+	}
+	return int(total), nil
+}
+
+// detectEngine 首次调用时检测三个集合是否都建有 Atlas Search 索引，非 Atlas 部署不支持 $listSearchIndexes 会直接报错
+func (r *searchRepository) detectEngine(ctx context.Context) string {
+	r.engineOnce.Do(func() {
+		r.engine = scene_audio_route_models.SearchEngineAtlas
+		for _, target := range []searchTarget{searchSongTarget, searchAlbumTarget, searchArtistTarget} {
+			cursor, err := r.db.Collection(target.collection).Aggregate(ctx, []bson.D{
+				{{Key: "$listSearchIndexes", Value: bson.D{{Key: "name", Value: scene_audio_route_models.SearchAtlasIndexName}}}},
+			})
+			if err != nil {
+				r.engine = scene_audio_route_models.SearchEngineText
+				return
+			}
+			var indexes []bson.M
+			err = cursor.All(ctx, &indexes)
+			_ = cursor.Close(ctx)
+			if err != nil || len(indexes) == 0 {
+				r.engine = scene_audio_route_models.SearchEngineText
+				return
+			}
+		}
+	})
+	return r.engine
+}
+
+func (r *searchRepository) searchGroup(
+	ctx context.Context,
+	target searchTarget,
+	query, engine string,
+	offset, count int,
+	out interface{},
+) (int, error) {
+	if count <= 0 {
+		return 0, nil
+	}
+	filter, err := target.filter(ctx, r.db)
+	if err != nil {
+		return 0, fmt.Errorf("%s search filter failed: %w", target.itemType, err)
+	}
+
+	var pipeline []bson.D
+	if engine == scene_audio_route_models.SearchEngineAtlas {
+		// $search 结果本身即按相关度排序
+		pipeline = []bson.D{
+			{{Key: "$search", Value: bson.D{
+				{Key: "index", Value: scene_audio_route_models.SearchAtlasIndexName},
+				{Key: "text", Value: bson.D{
+					{Key: "query", Value: query},
+					{Key: "path", Value: target.paths},
+					{Key: "fuzzy", Value: bson.D{{Key: "maxEdits", Value: 1}}},
+				}},
+			}}},
+			{{Key: "$addFields", Value: bson.D{{Key: "score", Value: bson.D{{Key: "$meta", Value: "searchScore"}}}}}},
+		}
+		if len(filter) > 0 {
+			pipeline = append(pipeline, bson.D{{Key: "$match", Value: filter}})
+		}
+	} else {
+		pipeline = []bson.D{
+			{{Key: "$match", Value: append(bson.D{{Key: "$text", Value: bson.D{{Key: "$search", Value: query}}}}, filter...)}},
+			{{Key: "$addFields", Value: bson.D{{Key: "score", Value: bson.D{{Key: "$meta", Value: "textScore"}}}}}},
+			{{Key: "$sort", Value: bson.D{{Key: "score", Value: -1}, {Key: "_id", Value: 1}}}},
+		}
+	}
+
+	pipeline = append(pipeline, bson.D{
+		{Key: "$facet", Value: bson.D{
+			{Key: "items", Value: append([]bson.D{
+				{{Key: "$skip", Value: offset}},
+				{{Key: "$limit", Value: count}},
+			}, annotationFallbackStages(ctx, target.itemType, "")...)},
+			{Key: "total", Value: []bson.D{
+				{{Key: "$count", Value: "count"}},
+			}},
+		}},
+	})
+
+	cursor, err := r.db.Collection(target.collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, fmt.Errorf("%s search failed: %w", target.itemType, err)
+	}
+	defer cursor.Close(ctx)
+
+	var facets []struct {
+		Items bson.RawValue    `bson:"items"`
+		Total []map[string]int `bson:"total"`
+	}
+	if err := cursor.All(ctx, &facets); err != nil {
+		return 0, fmt.Errorf("decode %s search error: %w", target.itemType, err)
+	}
+	if len(facets) == 0 {
+		return 0, nil
+	}
+
+	if err := facets[0].Items.Unmarshal(out); err != nil {
+		return 0, fmt.Errorf("decode %s search items error: %w", target.itemType, err)
+	}
+	return extractCount(facets[0].Total), nil
+}
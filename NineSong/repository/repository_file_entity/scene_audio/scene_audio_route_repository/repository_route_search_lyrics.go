@@ -0,0 +1,142 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/lyrics_util"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// lyricsSearchDoc 命中的曲目文档，附带歌词原文
+type lyricsSearchDoc struct {
+	scene_audio_route_models.MediaFileMetadata `bson:",inline"`
+
+	MatchedLyrics string `bson:"matched_lyrics"`
+}
+
+func (r *searchRepository) SearchLyrics(
+	ctx context.Context,
+	query string,
+	offset, count int,
+) (*scene_audio_route_models.LyricsSearchResult, error) {
+	result := &scene_audio_route_models.LyricsSearchResult{
+		Matches: make([]scene_audio_route_models.LyricsSearchMatch, 0),
+	}
+	terms := lyricsSearchTerms(query)
+	if count <= 0 || len(terms) == 0 {
+		return result, nil
+	}
+
+	// 文本索引按空白分词，中日韩歌词整句为一个词，改为逐词包含匹配
+	var pipeline []bson.D
+	if hasCJK(query) {
+		conditions := make(bson.A, 0, len(terms))
+		for _, term := range terms {
+			conditions = append(conditions, bson.D{{Key: "lyrics", Value: primitive.Regex{Pattern: regexp.QuoteMeta(term), Options: "i"}}})
+		}
+		pipeline = []bson.D{
+			{{Key: "$match", Value: bson.D{{Key: "$and", Value: conditions}}}},
+			{{Key: "$sort", Value: bson.D{{Key: "updated_at", Value: -1}, {Key: "_id", Value: 1}}}},
+		}
+	} else {
+		pipeline = []bson.D{
+			{{Key: "$match", Value: bson.D{{Key: "$text", Value: bson.D{{Key: "$search", Value: query}}}}}},
+			{{Key: "$addFields", Value: bson.D{{Key: "score", Value: bson.D{{Key: "$meta", Value: "textScore"}}}}}},
+			{{Key: "$sort", Value: bson.D{{Key: "score", Value: -1}, {Key: "_id", Value: 1}}}},
+		}
+	}
+
+	mediaOID := bson.D{{Key: "$convert", Value: bson.D{
+		{Key: "input", Value: "$media_id"}, {Key: "to", Value: "objectId"}, {Key: "onError", Value: nil}, {Key: "onNull", Value: nil},
+	}}}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: domain.CollectionFileEntityAudioSceneMediaFile},
+			{Key: "let", Value: bson.D{{Key: "oid", Value: mediaOID}}},
+			{Key: "pipeline", Value: bson.A{
+				bson.D{{Key: "$match", Value: bson.D{{Key: "$expr", Value: bson.D{{Key: "$eq", Value: bson.A{"$_id", "$$oid"}}}}}}},
+				bson.D{{Key: "$match", Value: bson.D{visibleFilter(ctx)}}},
+			}},
+			{Key: "as", Value: "lyrics_media"},
+		}}},
+		bson.D{{Key: "$unwind", Value: "$lyrics_media"}},
+		bson.D{{Key: "$replaceRoot", Value: bson.D{{Key: "newRoot", Value: bson.D{{Key: "$mergeObjects", Value: bson.A{
+			"$lyrics_media",
+			bson.D{{Key: "matched_lyrics", Value: "$lyrics"}},
+		}}}}}}},
+		bson.D{{Key: "$facet", Value: bson.D{
+			{Key: "items", Value: append([]bson.D{
+				{{Key: "$skip", Value: offset}},
+				{{Key: "$limit", Value: count}},
+			}, annotationFallbackStages(ctx, scene_audio_route_models.AnnotationItemMedia, "")...)},
+			{Key: "total", Value: []bson.D{
+				{{Key: "$count", Value: "count"}},
+			}},
+		}}},
+	)
+
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaLyricsMetadata).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("lyrics search failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var facets []struct {
+		Items []lyricsSearchDoc `bson:"items"`
+		Total []map[string]int  `bson:"total"`
+	}
+	if err := cursor.All(ctx, &facets); err != nil {
+		return nil, fmt.Errorf("decode lyrics search error: %w", err)
+	}
+	if len(facets) == 0 {
+		return result, nil
+	}
+
+	result.Total = extractCount(facets[0].Total)
+	for _, doc := range facets[0].Items {
+		match := scene_audio_route_models.LyricsSearchMatch{
+			MediaFile:  doc.MediaFileMetadata,
+			Highlights: make([]scene_audio_route_models.LyricsHighlight, 0),
+		}
+		if found, ok := lyrics_util.FindMatch(doc.MatchedLyrics, terms); ok {
+			match.Snippet = found.Snippet
+			for _, h := range found.Highlights {
+				match.Highlights = append(match.Highlights, scene_audio_route_models.LyricsHighlight{Start: h[0], End: h[1]})
+			}
+			if found.TimeMs >= 0 {
+				timeMs := found.TimeMs
+				match.Time = &timeMs
+			}
+		}
+		result.Matches = append(result.Matches, match)
+	}
+	return result, nil
+}
+
+// lyricsSearchTerms 拆分检索词用于高亮，去掉文本检索语法中的引号与排除词
+func lyricsSearchTerms(query string) []string {
+	var terms []string
+	for _, field := range strings.Fields(strings.ReplaceAll(query, `"`, " ")) {
+		if strings.HasPrefix(field, "-") {
+			continue
+		}
+		terms = append(terms, field)
+	}
+	return terms
+}
+
+func hasCJK(s string) bool {
+	for _, r := range s {
+		if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+			return true
+		}
+	}
+	return false
+}
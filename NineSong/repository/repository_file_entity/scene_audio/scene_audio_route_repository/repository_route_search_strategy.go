@@ -0,0 +1,205 @@
+package scene_audio_route_repository
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// searchStrategy 列表接口 search 参数的匹配方式
+type searchStrategy interface {
+	// condition 构建关键字条件；fields 为参与匹配的字段，extra 为别名艺术家等附加的“或”条件
+	condition(search string, fields []string, extra ...bson.D) bson.D
+	// leading 条件是否必须作为管道首个 $match 阶段（$text 的限制）
+	leading() bool
+}
+
+type regexSearch struct{}
+
+func (regexSearch) condition(search string, fields []string, extra ...bson.D) bson.D {
+	branches := make(bson.A, 0, len(fields)+len(extra))
+	for _, field := range fields {
+		branches = append(branches, bson.D{{Key: field, Value: bson.D{{Key: "$regex", Value: search}, {Key: "$options", Value: "i"}}}})
+	}
+	for _, e := range extra {
+		branches = append(branches, e)
+	}
+	return bson.D{{Key: "$or", Value: branches}}
+}
+
+func (regexSearch) leading() bool { return false }
+
+// prefixSearch 锚定且不带 i 选项的正则可以转换为索引范围扫描
+type prefixSearch struct{}
+
+func (prefixSearch) condition(search string, fields []string, extra ...bson.D) bson.D {
+	pattern := "^" + regexp.QuoteMeta(search)
+	branches := make(bson.A, 0, len(fields)+len(extra))
+	for _, field := range fields {
+		branches = append(branches, bson.D{{Key: field, Value: bson.D{{Key: "$regex", Value: pattern}}}})
+	}
+	for _, e := range extra {
+		branches = append(branches, e)
+	}
+	return bson.D{{Key: "$or", Value: branches}}
+}
+
+func (prefixSearch) leading() bool { return false }
+
+// textSearch 字段由文本索引决定，fields 仅作说明；$or 中的其他分支必须都有索引
+type textSearch struct{}
+
+func (textSearch) condition(search string, _ []string, extra ...bson.D) bson.D {
+	text := bson.D{{Key: "$text", Value: bson.D{{Key: "$search", Value: search}}}}
+	if len(extra) == 0 {
+		return text
+	}
+	branches := bson.A{text}
+	for _, e := range extra {
+		branches = append(branches, e)
+	}
+	return bson.D{{Key: "$or", Value: branches}}
+}
+
+func (textSearch) leading() bool { return true }
+
+var searchStrategies = map[string]searchStrategy{
+	scene_audio_route_models.SearchStrategyRegex:  regexSearch{},
+	scene_audio_route_models.SearchStrategyPrefix: prefixSearch{},
+	scene_audio_route_models.SearchStrategyText:   textSearch{},
+}
+
+var searchEndpoints = map[string]bool{
+	scene_audio_route_models.SearchEndpointAlbums:     true,
+	scene_audio_route_models.SearchEndpointArtists:    true,
+	scene_audio_route_models.SearchEndpointMediaFiles: true,
+}
+
+var (
+	searchStrategyMu      sync.RWMutex
+	defaultSearchStrategy = scene_audio_route_models.SearchStrategyRegex
+	endpointStrategies    = map[string]string{}
+)
+
+// ConfigureSearchStrategies 设置全局搜索策略及按接口覆盖，overrides 形如 "albums=text,media_files=prefix"；
+// 任一配置无效时返回错误且保持原配置
+func ConfigureSearchStrategies(global, overrides string) error {
+	global = strings.ToLower(strings.TrimSpace(global))
+	if global == "" {
+		global = scene_audio_route_models.SearchStrategyRegex
+	}
+	if _, ok := searchStrategies[global]; !ok {
+		return fmt.Errorf("unknown search strategy: %s", global)
+	}
+
+	endpoints := make(map[string]string)
+	for _, item := range strings.Split(overrides, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		endpoint, strategy, ok := strings.Cut(item, "=")
+		endpoint = strings.ToLower(strings.TrimSpace(endpoint))
+		strategy = strings.ToLower(strings.TrimSpace(strategy))
+		if !ok || !searchEndpoints[endpoint] {
+			return fmt.Errorf("invalid search strategy override: %s", item)
+		}
+		if _, known := searchStrategies[strategy]; !known {
+			return fmt.Errorf("unknown search strategy for %s: %s", endpoint, strategy)
+		}
+		endpoints[endpoint] = strategy
+	}
+
+	searchStrategyMu.Lock()
+	defer searchStrategyMu.Unlock()
+	defaultSearchStrategy = global
+	endpointStrategies = endpoints
+	return nil
+}
+
+func searchStrategyFor(endpoint string) searchStrategy {
+	searchStrategyMu.RLock()
+	defer searchStrategyMu.RUnlock()
+	if name, ok := endpointStrategies[endpoint]; ok {
+		return searchStrategies[name]
+	}
+	return searchStrategies[defaultSearchStrategy]
+}
+
+// splitSearchStage 需要前置的条件单独作为首个阶段返回，其余策略的条件并入普通过滤
+func splitSearchStage(strategy searchStrategy, cond bson.D) ([]bson.D, bson.D) {
+	if len(cond) == 0 || !strategy.leading() {
+		return []bson.D{}, cond
+	}
+	return []bson.D{{{Key: "$match", Value: cond}}}, nil
+}
+
+// fieldClause search 字段级语法中的单个条件
+type fieldClause struct {
+	field string
+	mode  string
+	value string
+}
+
+// parseFieldSearch 每个条件的字段都在 fields 内时才视为字段级语法
+func parseFieldSearch(search string, fields []string) ([]fieldClause, bool) {
+	allowed := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		allowed[f] = true
+	}
+
+	var clauses []fieldClause
+	for _, item := range strings.Split(search, scene_audio_route_models.SearchClauseSeparator) {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		idx := strings.Index(item, "=")
+		if idx <= 0 {
+			return nil, false
+		}
+		mode := scene_audio_route_models.SearchMatchExact
+		field := item[:idx]
+		switch item[idx-1] {
+		case '^':
+			mode, field = scene_audio_route_models.SearchMatchPrefix, item[:idx-1]
+		case '~':
+			mode, field = scene_audio_route_models.SearchMatchContains, item[:idx-1]
+		}
+		field = strings.ToLower(strings.TrimSpace(field))
+		value := strings.TrimSpace(item[idx+1:])
+		if !allowed[field] || value == "" {
+			return nil, false
+		}
+		clauses = append(clauses, fieldClause{field: field, mode: mode, value: value})
+	}
+	return clauses, len(clauses) > 0
+}
+
+func (c fieldClause) condition() bson.D {
+	switch c.mode {
+	case scene_audio_route_models.SearchMatchPrefix:
+		return bson.D{{Key: c.field, Value: bson.D{{Key: "$regex", Value: "^" + regexp.QuoteMeta(c.value)}}}}
+	case scene_audio_route_models.SearchMatchContains:
+		return bson.D{{Key: c.field, Value: bson.D{{Key: "$regex", Value: regexp.QuoteMeta(c.value)}, {Key: "$options", Value: "i"}}}}
+	default:
+		return bson.D{{Key: c.field, Value: c.value}}
+	}
+}
+
+// fieldSearch search 为字段级语法时返回各条件的“与”，此时不使用接口的搜索策略与别名扩展；
+// 返回的策略不要求前置，以便与其他过滤条件合并
+func fieldSearch(search string, fields []string) (searchStrategy, bson.D, bool) {
+	clauses, ok := parseFieldSearch(search, fields)
+	if !ok {
+		return nil, nil, false
+	}
+	conds := make(bson.A, 0, len(clauses))
+	for _, c := range clauses {
+		conds = append(conds, c.condition())
+	}
+	return regexSearch{}, bson.D{{Key: "$and", Value: conds}}, true
+}
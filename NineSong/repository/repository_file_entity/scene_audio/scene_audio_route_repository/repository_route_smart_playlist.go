@@ -0,0 +1,596 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type smartPlaylistRepository struct {
+	db         mongo.Database
+	collection string
+}
+
+func NewSmartPlaylistRepository(db mongo.Database, collection string) scene_audio_route_interface.SmartPlaylistRepository {
+	return &smartPlaylistRepository{
+		db:         db,
+		collection: collection,
+	}
+}
+
+// 智能播放列表与普通播放列表共用集合，以rules字段非空区分；只返回当前用户或无主的播放列表
+func smartPlaylistFilter(ctx context.Context) bson.D {
+	return bson.D{{Key: "rules", Value: bson.M{"$nin": bson.A{"", nil}}}, playlistOwnerFilter(ctx)}
+}
+
+func (r *smartPlaylistRepository) GetSmartPlaylists(ctx context.Context) ([]scene_audio_route_models.SmartPlaylistMetadata, error) {
+	coll := r.db.Collection(r.collection)
+	cursor, err := coll.Find(ctx, smartPlaylistFilter(ctx), options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		return nil, fmt.Errorf("find operation failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var dbModels []scene_audio_db_models.PlaylistMetadata
+	if err := cursor.All(ctx, &dbModels); err != nil {
+		return nil, fmt.Errorf("decode error: %w", err)
+	}
+
+	results := make([]scene_audio_route_models.SmartPlaylistMetadata, 0, len(dbModels))
+	for _, m := range dbModels {
+		sp, err := convertToSmartPlaylist(m)
+		if err != nil {
+			continue
+		}
+		results = append(results, *sp)
+	}
+	return results, nil
+}
+
+func (r *smartPlaylistRepository) GetSmartPlaylist(ctx context.Context, playlistId string) (*scene_audio_route_models.SmartPlaylistMetadata, error) {
+	objID, err := primitive.ObjectIDFromHex(playlistId)
+	if err != nil {
+		return nil, errors.New("invalid playlist id format")
+	}
+
+	filter := append(bson.D{{Key: "_id", Value: objID}}, smartPlaylistFilter(ctx)...)
+
+	var dbModel scene_audio_db_models.PlaylistMetadata
+	if err := r.db.Collection(r.collection).FindOne(ctx, filter).Decode(&dbModel); err != nil {
+		return nil, fmt.Errorf("find one error: %w", err)
+	}
+	return convertToSmartPlaylist(dbModel)
+}
+
+func (r *smartPlaylistRepository) CreateSmartPlaylist(ctx context.Context, playlist scene_audio_route_models.SmartPlaylistMetadata) (*scene_audio_route_models.SmartPlaylistMetadata, error) {
+	coll := r.db.Collection(r.collection)
+
+	count, err := coll.CountDocuments(ctx, bson.D{{Key: "name", Value: playlist.Name}, playlistOwnerFilter(ctx)})
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %w", err)
+	}
+	if count > 0 {
+		return nil, errors.New("playlist name already exists")
+	}
+
+	rulesJSON, err := json.Marshal(playlist.Rules)
+	if err != nil {
+		return nil, fmt.Errorf("encode rules failed: %w", err)
+	}
+
+	now := time.Now().UTC()
+	dbModel := scene_audio_db_models.PlaylistMetadata{
+		ID:        primitive.NewObjectID(),
+		Name:      playlist.Name,
+		Comment:   playlist.Comment,
+		OwnerID:   playlist.OwnerID,
+		Rules:     string(rulesJSON),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if _, err := coll.InsertOne(ctx, dbModel); err != nil {
+		return nil, fmt.Errorf("insert failed: %w", err)
+	}
+	created, err := convertToSmartPlaylist(dbModel)
+	if err != nil {
+		return nil, err
+	}
+	r.refreshStatsOrLog(ctx, created)
+	return r.GetSmartPlaylist(ctx, created.ID.Hex())
+}
+
+func (r *smartPlaylistRepository) UpdateSmartPlaylist(ctx context.Context, playlistId string, playlist scene_audio_route_models.SmartPlaylistMetadata) (*scene_audio_route_models.SmartPlaylistMetadata, error) {
+	objID, err := primitive.ObjectIDFromHex(playlistId)
+	if err != nil {
+		return nil, errors.New("invalid playlist id format")
+	}
+
+	coll := r.db.Collection(r.collection)
+	count, err := coll.CountDocuments(ctx, bson.D{
+		{Key: "name", Value: playlist.Name},
+		{Key: "_id", Value: bson.M{"$ne": objID}},
+		playlistOwnerFilter(ctx),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("name check failed: %w", err)
+	}
+	if count > 0 {
+		return nil, errors.New("playlist name already exists")
+	}
+
+	rulesJSON, err := json.Marshal(playlist.Rules)
+	if err != nil {
+		return nil, fmt.Errorf("encode rules failed: %w", err)
+	}
+
+	result, err := coll.UpdateOne(ctx, append(bson.D{{Key: "_id", Value: objID}}, smartPlaylistFilter(ctx)...), bson.M{
+		"$set": bson.M{
+			"name":       playlist.Name,
+			"comment":    playlist.Comment,
+			"rules":      string(rulesJSON),
+			"updated_at": time.Now().UTC(),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("update failed: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return nil, errors.New("document not found")
+	}
+	updated, err := r.GetSmartPlaylist(ctx, playlistId)
+	if err != nil {
+		return nil, err
+	}
+	r.refreshStatsOrLog(ctx, updated)
+	return r.GetSmartPlaylist(ctx, playlistId)
+}
+
+func (r *smartPlaylistRepository) DeleteSmartPlaylist(ctx context.Context, playlistId string) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(playlistId)
+	if err != nil {
+		return false, errors.New("invalid playlist id format")
+	}
+
+	filter := append(bson.D{{Key: "_id", Value: objID}}, smartPlaylistFilter(ctx)...)
+	deleted, err := r.db.Collection(r.collection).DeleteOne(ctx, filter)
+	if err != nil {
+		return false, fmt.Errorf("delete failed: %w", err)
+	}
+	return deleted > 0, nil
+}
+
+// 按存储的规则求值；歌曲数与时长在保存规则时统计，此处不再回写
+func (r *smartPlaylistRepository) GetSmartPlaylistTracks(ctx context.Context, playlistId string, start string, end string) ([]scene_audio_route_models.MediaFileMetadata, error) {
+	playlist, err := r.GetSmartPlaylist(ctx, playlistId)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.PreviewSmartPlaylist(ctx, playlist.Rules, start, end)
+}
+
+func (r *smartPlaylistRepository) PreviewSmartPlaylist(ctx context.Context, rules scene_audio_route_models.SmartPlaylistRules, start string, end string) ([]scene_audio_route_models.MediaFileMetadata, error) {
+	rules, err := r.expandGenreTrees(ctx, rules)
+	if err != nil {
+		return nil, err
+	}
+	pipeline, err := buildSmartPlaylistPipeline(ctx, rules)
+	if err != nil {
+		return nil, err
+	}
+	if paginationStages := buildMediaPaginationStage(start, end); paginationStages != nil {
+		pipeline = append(pipeline, paginationStages...)
+	}
+
+	coll := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile)
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []scene_audio_route_models.MediaFileMetadata
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("decode error: %w", err)
+	}
+	return results, nil
+}
+
+// refreshStatsOrLog 统计失败不影响规则保存，下次保存时重新统计
+func (r *smartPlaylistRepository) refreshStatsOrLog(ctx context.Context, playlist *scene_audio_route_models.SmartPlaylistMetadata) {
+	if err := r.refreshStats(ctx, playlist); err != nil {
+		log.Printf("smart playlist stats update failed: %v", err)
+	}
+}
+
+func (r *smartPlaylistRepository) refreshStats(ctx context.Context, playlist *scene_audio_route_models.SmartPlaylistMetadata) error {
+	rules, err := r.expandGenreTrees(ctx, playlist.Rules)
+	if err != nil {
+		return err
+	}
+	pipeline, err := buildSmartPlaylistPipeline(ctx, rules)
+	if err != nil {
+		return err
+	}
+	pipeline = append(pipeline, bson.D{
+		{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: nil},
+			{Key: "song_count", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "duration", Value: bson.D{{Key: "$sum", Value: "$duration"}}},
+		}},
+	})
+
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).Aggregate(ctx, pipeline)
+	if err != nil {
+		return fmt.Errorf("stats query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var stats []struct {
+		SongCount float64 `bson:"song_count"`
+		Duration  float64 `bson:"duration"`
+	}
+	if err := cursor.All(ctx, &stats); err != nil {
+		return fmt.Errorf("decode stats error: %w", err)
+	}
+
+	var songCount, duration float64
+	if len(stats) > 0 {
+		songCount, duration = stats[0].SongCount, stats[0].Duration
+	}
+	_, err = r.db.Collection(r.collection).UpdateByID(ctx, playlist.ID, bson.M{
+		"$set": bson.M{
+			"song_count":   songCount,
+			"duration":     duration,
+			"evaluated_at": time.Now().UTC(),
+		},
+	})
+	return err
+}
+
+// expandGenreTrees 将 in_genre_tree 规则的值展开为流派子树名称列表，未使用该运算符时不查询流派表
+func (r *smartPlaylistRepository) expandGenreTrees(ctx context.Context, rules scene_audio_route_models.SmartPlaylistRules) (scene_audio_route_models.SmartPlaylistRules, error) {
+	if !usesGenreTree(rules.SmartPlaylistRuleGroup) {
+		return rules, nil
+	}
+	genres, err := loadGenres(ctx, r.db)
+	if err != nil {
+		return rules, err
+	}
+	rules.SmartPlaylistRuleGroup = expandGenreTreeGroup(rules.SmartPlaylistRuleGroup, genres)
+	return rules, nil
+}
+
+func usesGenreTree(group scene_audio_route_models.SmartPlaylistRuleGroup) bool {
+	for _, rule := range group.Rules {
+		if strings.ToLower(rule.Operator) == "in_genre_tree" {
+			return true
+		}
+	}
+	for _, sub := range group.Groups {
+		if usesGenreTree(sub) {
+			return true
+		}
+	}
+	return false
+}
+
+func expandGenreTreeGroup(group scene_audio_route_models.SmartPlaylistRuleGroup, genres []scene_audio_route_models.GenreMetadata) scene_audio_route_models.SmartPlaylistRuleGroup {
+	rules := make([]scene_audio_route_models.SmartPlaylistRule, len(group.Rules))
+	for i, rule := range group.Rules {
+		if strings.ToLower(rule.Operator) == "in_genre_tree" {
+			var names []string
+			for _, root := range smartRuleStrings(rule.Value) {
+				names = append(names, genreSubtree(genres, root)...)
+			}
+			rule.Value = names
+		}
+		rules[i] = rule
+	}
+	groups := make([]scene_audio_route_models.SmartPlaylistRuleGroup, len(group.Groups))
+	for i, sub := range group.Groups {
+		groups[i] = expandGenreTreeGroup(sub, genres)
+	}
+	group.Rules = rules
+	group.Groups = groups
+	return group
+}
+
+func convertToSmartPlaylist(dbModel scene_audio_db_models.PlaylistMetadata) (*scene_audio_route_models.SmartPlaylistMetadata, error) {
+	var rules scene_audio_route_models.SmartPlaylistRules
+	if err := json.Unmarshal([]byte(dbModel.Rules), &rules); err != nil {
+		return nil, fmt.Errorf("decode rules failed: %w", err)
+	}
+	return &scene_audio_route_models.SmartPlaylistMetadata{
+		ID:          dbModel.ID,
+		Name:        dbModel.Name,
+		Comment:     dbModel.Comment,
+		OwnerID:     dbModel.OwnerID,
+		Rules:       rules,
+		SongCount:   dbModel.SongCount,
+		Duration:    dbModel.Duration,
+		CreatedAt:   dbModel.CreatedAt,
+		UpdatedAt:   dbModel.UpdatedAt,
+		EvaluatedAt: dbModel.EvaluatedAt,
+	}, nil
+}
+
+// 规则编译：注释关联 -> 规则匹配 -> 排序 -> 数量限制；播放、收藏与评分规则按请求用户的注解计算
+func buildSmartPlaylistPipeline(ctx context.Context, rules scene_audio_route_models.SmartPlaylistRules) ([]bson.D, error) {
+	match, err := compileSmartRuleGroup(rules.SmartPlaylistRuleGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline := []bson.D{
+		{{Key: "$match", Value: bson.D{visibleFilter(ctx)}}},
+	}
+	pipeline = append(pipeline, annotationFallbackStages(ctx, scene_audio_route_models.AnnotationItemMedia, "")...)
+
+	if len(match) > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: match}})
+	}
+
+	sortField := "_id"
+	if len(rules.Sort) > 0 {
+		if rules.Sort == "random" {
+			sortField = ""
+		} else {
+			sortField = validateSortField(rules.Sort, "")
+		}
+	}
+	if sortField == "" {
+		size := rules.Limit
+		if size <= 0 || size > scene_audio_route_models.SmartPlaylistMaxLimit {
+			size = scene_audio_route_models.SmartPlaylistMaxLimit
+		}
+		pipeline = append(pipeline, bson.D{{Key: "$sample", Value: bson.D{{Key: "size", Value: size}}}})
+	} else {
+		pipeline = append(pipeline, buildSortStage(sortField, rules.Order))
+	}
+
+	limit := rules.Limit
+	if limit <= 0 || limit > scene_audio_route_models.SmartPlaylistMaxLimit {
+		limit = scene_audio_route_models.SmartPlaylistMaxLimit
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$limit", Value: limit}})
+
+	return pipeline, nil
+}
+
+func compileSmartRuleGroup(group scene_audio_route_models.SmartPlaylistRuleGroup) (bson.D, error) {
+	var conditions bson.A
+	for _, rule := range group.Rules {
+		cond, err := compileSmartRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, cond)
+	}
+	for _, sub := range group.Groups {
+		cond, err := compileSmartRuleGroup(sub)
+		if err != nil {
+			return nil, err
+		}
+		if len(cond) > 0 {
+			conditions = append(conditions, cond)
+		}
+	}
+
+	if len(conditions) == 0 {
+		return bson.D{}, nil
+	}
+	op := "$and"
+	if strings.ToLower(group.Combinator) == "any" {
+		op = "$or"
+	}
+	return bson.D{{Key: op, Value: conditions}}, nil
+}
+
+// smartFieldScales 规则值与存储单位不同的数值字段：时长规则以秒填写，曲目时长按纳秒存储
+var smartFieldScales = map[string]float64{
+	"duration": float64(time.Second),
+}
+
+func compileSmartRule(rule scene_audio_route_models.SmartPlaylistRule) (bson.D, error) {
+	field, ok := scene_audio_route_models.SmartPlaylistFields[strings.ToLower(rule.Field)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported rule field: %s", rule.Field)
+	}
+	key := field.Key
+	operator := strings.ToLower(rule.Operator)
+
+	switch field.Type {
+	case scene_audio_route_models.SmartFieldString:
+		value := fmt.Sprint(rule.Value)
+		quoted := regexp.QuoteMeta(value)
+		regex := func(pattern string) bson.D {
+			return bson.D{{Key: "$regex", Value: pattern}, {Key: "$options", Value: "i"}}
+		}
+		switch operator {
+		case "is":
+			return bson.D{{Key: key, Value: regex("^" + quoted + "$")}}, nil
+		case "is_not":
+			return bson.D{{Key: key, Value: bson.D{{Key: "$not", Value: primitive.Regex{Pattern: "^" + quoted + "$", Options: "i"}}}}}, nil
+		case "contains":
+			return bson.D{{Key: key, Value: regex(quoted)}}, nil
+		case "not_contains":
+			return bson.D{{Key: key, Value: bson.D{{Key: "$not", Value: primitive.Regex{Pattern: quoted, Options: "i"}}}}}, nil
+		case "starts_with":
+			return bson.D{{Key: key, Value: regex("^" + quoted)}}, nil
+		case "ends_with":
+			return bson.D{{Key: key, Value: regex(quoted + "$")}}, nil
+		case "in_genre_tree":
+			names := smartRuleStrings(rule.Value)
+			patterns := make(bson.A, 0, len(names))
+			for _, name := range names {
+				patterns = append(patterns, primitive.Regex{Pattern: "^" + regexp.QuoteMeta(name) + "$", Options: "i"})
+			}
+			return bson.D{{Key: key, Value: bson.D{{Key: "$in", Value: patterns}}}}, nil
+		}
+
+	case scene_audio_route_models.SmartFieldNumber:
+		scale := 1.0
+		if s, ok := smartFieldScales[key]; ok {
+			scale = s
+		}
+		switch operator {
+		case "is", "is_not", "gt", "lt":
+			value, ok := smartRuleFloat(rule.Value)
+			if !ok {
+				return nil, fmt.Errorf("rule %s: value must be a number", rule.Field)
+			}
+			mongoOp := map[string]string{"is": "$eq", "is_not": "$ne", "gt": "$gt", "lt": "$lt"}[operator]
+			return bson.D{{Key: key, Value: bson.D{{Key: mongoOp, Value: value * scale}}}}, nil
+		case "in_range":
+			values, ok := smartRuleValues(rule.Value)
+			if !ok || len(values) != 2 {
+				return nil, fmt.Errorf("rule %s: in_range requires [min, max]", rule.Field)
+			}
+			lo, ok1 := smartRuleFloat(values[0])
+			hi, ok2 := smartRuleFloat(values[1])
+			if !ok1 || !ok2 {
+				return nil, fmt.Errorf("rule %s: in_range values must be numbers", rule.Field)
+			}
+			return bson.D{{Key: key, Value: bson.D{{Key: "$gte", Value: lo * scale}, {Key: "$lte", Value: hi * scale}}}}, nil
+		}
+
+	case scene_audio_route_models.SmartFieldBool:
+		value, ok := rule.Value.(bool)
+		if !ok {
+			parsed, err := strconv.ParseBool(fmt.Sprint(rule.Value))
+			if err != nil {
+				return nil, fmt.Errorf("rule %s: value must be a boolean", rule.Field)
+			}
+			value = parsed
+		}
+		switch operator {
+		case "is":
+			if !value {
+				return bson.D{{Key: key, Value: bson.D{{Key: "$ne", Value: true}}}}, nil
+			}
+			return bson.D{{Key: key, Value: true}}, nil
+		case "is_not":
+			if value {
+				return bson.D{{Key: key, Value: bson.D{{Key: "$ne", Value: true}}}}, nil
+			}
+			return bson.D{{Key: key, Value: true}}, nil
+		}
+
+	case scene_audio_route_models.SmartFieldDate:
+		switch operator {
+		case "gt", "lt":
+			value, ok := smartRuleTime(rule.Value)
+			if !ok {
+				return nil, fmt.Errorf("rule %s: value must be a date", rule.Field)
+			}
+			return bson.D{{Key: key, Value: bson.D{{Key: "$" + operator, Value: value}}}}, nil
+		case "in_range":
+			values, ok := smartRuleValues(rule.Value)
+			if !ok || len(values) != 2 {
+				return nil, fmt.Errorf("rule %s: in_range requires [from, to]", rule.Field)
+			}
+			from, ok1 := smartRuleTime(values[0])
+			to, ok2 := smartRuleTime(values[1])
+			if !ok1 || !ok2 {
+				return nil, fmt.Errorf("rule %s: in_range values must be dates", rule.Field)
+			}
+			return bson.D{{Key: key, Value: bson.D{{Key: "$gte", Value: from}, {Key: "$lte", Value: to}}}}, nil
+		case "in_the_last", "not_in_the_last":
+			days, ok := smartRuleFloat(rule.Value)
+			if !ok || days <= 0 {
+				return nil, fmt.Errorf("rule %s: value must be a positive number of days", rule.Field)
+			}
+			since := time.Now().UTC().Add(-time.Duration(days * float64(24*time.Hour)))
+			if operator == "in_the_last" {
+				return bson.D{{Key: key, Value: bson.D{{Key: "$gte", Value: since}}}}, nil
+			}
+			return bson.D{{Key: "$or", Value: bson.A{
+				bson.D{{Key: key, Value: bson.D{{Key: "$lt", Value: since}}}},
+				bson.D{{Key: key, Value: bson.D{{Key: "$exists", Value: false}}}},
+			}}}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("operator %s is not supported for field %s", rule.Operator, rule.Field)
+}
+
+// smartRuleValues 规则值来自 JSON（[]interface{}）、BSON（primitive.A）或展开后的 []string
+func smartRuleValues(v interface{}) ([]interface{}, bool) {
+	switch val := v.(type) {
+	case []interface{}:
+		return val, true
+	case primitive.A:
+		return val, true
+	case []string:
+		values := make([]interface{}, len(val))
+		for i, s := range val {
+			values[i] = s
+		}
+		return values, true
+	}
+	return nil, false
+}
+
+// smartRuleStrings 单个值视为只有一个元素的列表，空字符串被忽略
+func smartRuleStrings(v interface{}) []string {
+	values, ok := smartRuleValues(v)
+	if !ok {
+		values = []interface{}{v}
+	}
+	names := make([]string, 0, len(values))
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+		if name := strings.TrimSpace(fmt.Sprint(value)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func smartRuleFloat(v interface{}) (float64, bool) {
+	switch val := v.(type) {
+	case float64:
+		return val, true
+	case int:
+		return float64(val), true
+	case int32:
+		return float64(val), true
+	case int64:
+		return float64(val), true
+	case string:
+		f, err := strconv.ParseFloat(val, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func smartRuleTime(v interface{}) (time.Time, bool) {
+	s, ok := v.(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,184 @@
+package scene_audio_route_repository
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCompileSmartRule(t *testing.T) {
+	ci := func(pattern string) bson.D {
+		return bson.D{{Key: "$regex", Value: pattern}, {Key: "$options", Value: "i"}}
+	}
+	genrePatterns := func(names ...string) bson.D {
+		patterns := make(bson.A, 0, len(names))
+		for _, name := range names {
+			patterns = append(patterns, primitive.Regex{Pattern: "^" + name + "$", Options: "i"})
+		}
+		return bson.D{{Key: "genre", Value: bson.D{{Key: "$in", Value: patterns}}}}
+	}
+
+	tests := []struct {
+		name string
+		rule scene_audio_route_models.SmartPlaylistRule
+		want bson.D
+	}{
+		{
+			name: "string is escapes regex",
+			rule: scene_audio_route_models.SmartPlaylistRule{Field: "artist", Operator: "is", Value: "AC/DC (Live)"},
+			want: bson.D{{Key: "artist", Value: ci(`^AC/DC \(Live\)$`)}},
+		},
+		{
+			name: "string not_contains",
+			rule: scene_audio_route_models.SmartPlaylistRule{Field: "title", Operator: "not_contains", Value: "remix"},
+			want: bson.D{{Key: "title", Value: bson.D{{Key: "$not", Value: primitive.Regex{Pattern: "remix", Options: "i"}}}}},
+		},
+		{
+			name: "field and operator are case insensitive",
+			rule: scene_audio_route_models.SmartPlaylistRule{Field: "Album", Operator: "STARTS_WITH", Value: "The"},
+			want: bson.D{{Key: "album", Value: ci("^The")}},
+		},
+		{
+			name: "number from string",
+			rule: scene_audio_route_models.SmartPlaylistRule{Field: "year", Operator: "gt", Value: "1999"},
+			want: bson.D{{Key: "year", Value: bson.D{{Key: "$gt", Value: 1999.0}}}},
+		},
+		{
+			name: "number in_range from bson array",
+			rule: scene_audio_route_models.SmartPlaylistRule{Field: "rating", Operator: "in_range", Value: primitive.A{int32(3), int64(5)}},
+			want: bson.D{{Key: "rating", Value: bson.D{{Key: "$gte", Value: 3.0}, {Key: "$lte", Value: 5.0}}}},
+		},
+		{
+			name: "duration seconds scaled to stored nanoseconds",
+			rule: scene_audio_route_models.SmartPlaylistRule{Field: "duration", Operator: "gt", Value: 300},
+			want: bson.D{{Key: "duration", Value: bson.D{{Key: "$gt", Value: 300e9}}}},
+		},
+		{
+			name: "duration in_range scaled",
+			rule: scene_audio_route_models.SmartPlaylistRule{Field: "duration", Operator: "in_range", Value: []interface{}{60, 90.5}},
+			want: bson.D{{Key: "duration", Value: bson.D{{Key: "$gte", Value: 60e9}, {Key: "$lte", Value: 90.5e9}}}},
+		},
+		{
+			name: "bool false matches missing field",
+			rule: scene_audio_route_models.SmartPlaylistRule{Field: "starred", Operator: "is", Value: "false"},
+			want: bson.D{{Key: "starred", Value: bson.D{{Key: "$ne", Value: true}}}},
+		},
+		{
+			name: "bool is_not false",
+			rule: scene_audio_route_models.SmartPlaylistRule{Field: "compilation", Operator: "is_not", Value: false},
+			want: bson.D{{Key: "compilation", Value: true}},
+		},
+		{
+			name: "date in_range",
+			rule: scene_audio_route_models.SmartPlaylistRule{Field: "created_at", Operator: "in_range", Value: []interface{}{"2024-01-01", "2024-12-31T23:59:59Z"}},
+			want: bson.D{{Key: "created_at", Value: bson.D{
+				{Key: "$gte", Value: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+				{Key: "$lte", Value: time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC)},
+			}}},
+		},
+		{
+			name: "genre tree single name",
+			rule: scene_audio_route_models.SmartPlaylistRule{Field: "genre", Operator: "in_genre_tree", Value: "Rock"},
+			want: genrePatterns("Rock"),
+		},
+		{
+			name: "genre tree expanded names",
+			rule: scene_audio_route_models.SmartPlaylistRule{Field: "genre", Operator: "in_genre_tree", Value: []string{"Rock", "Punk"}},
+			want: genrePatterns("Rock", "Punk"),
+		},
+		{
+			name: "genre tree json array",
+			rule: scene_audio_route_models.SmartPlaylistRule{Field: "genre", Operator: "in_genre_tree", Value: []interface{}{"Rock", "Hard Rock"}},
+			want: genrePatterns("Rock", "Hard Rock"),
+		},
+		{
+			name: "genre tree bson array",
+			rule: scene_audio_route_models.SmartPlaylistRule{Field: "genre", Operator: "in_genre_tree", Value: primitive.A{"Jazz"}},
+			want: genrePatterns("Jazz"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := compileSmartRule(tt.rule)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCompileSmartRuleErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		rule scene_audio_route_models.SmartPlaylistRule
+	}{
+		{name: "unknown field", rule: scene_audio_route_models.SmartPlaylistRule{Field: "mood", Operator: "is", Value: "happy"}},
+		{name: "operator not valid for type", rule: scene_audio_route_models.SmartPlaylistRule{Field: "year", Operator: "contains", Value: "19"}},
+		{name: "non numeric value", rule: scene_audio_route_models.SmartPlaylistRule{Field: "year", Operator: "is", Value: "nineteen"}},
+		{name: "range needs two values", rule: scene_audio_route_models.SmartPlaylistRule{Field: "year", Operator: "in_range", Value: []interface{}{1990}}},
+		{name: "invalid boolean", rule: scene_audio_route_models.SmartPlaylistRule{Field: "starred", Operator: "is", Value: "maybe"}},
+		{name: "invalid date", rule: scene_audio_route_models.SmartPlaylistRule{Field: "play_date", Operator: "gt", Value: "yesterday"}},
+		{name: "non positive days", rule: scene_audio_route_models.SmartPlaylistRule{Field: "play_date", Operator: "in_the_last", Value: 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := compileSmartRule(tt.rule)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCompileSmartRuleGroup(t *testing.T) {
+	var rules scene_audio_route_models.SmartPlaylistRules
+	err := json.Unmarshal([]byte(`{
+		"combinator": "any",
+		"rules": [{"field": "year", "operator": "in_range", "value": [1970, 1979]}],
+		"groups": [
+			{"combinator": "all", "rules": [{"field": "genre", "operator": "in_genre_tree", "value": ["Rock", "Punk"]}]},
+			{"combinator": "all"}
+		]
+	}`), &rules)
+	assert.NoError(t, err)
+
+	got, err := compileSmartRuleGroup(rules.SmartPlaylistRuleGroup)
+	assert.NoError(t, err)
+	assert.Equal(t, bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: "year", Value: bson.D{{Key: "$gte", Value: 1970.0}, {Key: "$lte", Value: 1979.0}}}},
+		bson.D{{Key: "$and", Value: bson.A{
+			bson.D{{Key: "genre", Value: bson.D{{Key: "$in", Value: bson.A{
+				primitive.Regex{Pattern: "^Rock$", Options: "i"},
+				primitive.Regex{Pattern: "^Punk$", Options: "i"},
+			}}}}},
+		}}},
+	}}}, got)
+
+	empty, err := compileSmartRuleGroup(scene_audio_route_models.SmartPlaylistRuleGroup{})
+	assert.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func TestExpandGenreTreeGroup(t *testing.T) {
+	genres := []scene_audio_route_models.GenreMetadata{
+		{Name: "Rock"},
+		{Name: "Punk", Parent: "rock"},
+		{Name: "Hardcore", Parent: "Punk"},
+		{Name: "Jazz"},
+	}
+	group := scene_audio_route_models.SmartPlaylistRuleGroup{
+		Rules: []scene_audio_route_models.SmartPlaylistRule{
+			{Field: "genre", Operator: "in_genre_tree", Value: []interface{}{"Rock", "Jazz"}},
+			{Field: "title", Operator: "contains", Value: "live"},
+		},
+	}
+
+	expanded := expandGenreTreeGroup(group, genres)
+	assert.Equal(t, []string{"Rock", "Punk", "Hardcore", "Jazz"}, expanded.Rules[0].Value)
+	assert.Equal(t, "live", expanded.Rules[1].Value)
+	assert.Equal(t, []interface{}{"Rock", "Jazz"}, group.Rules[0].Value, "input group must not be modified")
+}
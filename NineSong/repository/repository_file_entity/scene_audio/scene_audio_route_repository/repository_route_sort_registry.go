@@ -0,0 +1,305 @@
+package scene_audio_route_repository
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// sortSpec 单个列表接口的排序白名单
+type sortSpec struct {
+	aliases      map[string]string // 请求中的排序名 -> 文档字段
+	fields       map[string]bool   // 可直接按字段名排序的文档字段
+	defaultField string            // 排序名无效时使用
+	customTags   map[string]bool   // 可筛选与排序的自定义标签（custom_tags 下的键）
+}
+
+func (s sortSpec) clone() sortSpec {
+	c := sortSpec{
+		aliases:      make(map[string]string, len(s.aliases)),
+		fields:       make(map[string]bool, len(s.fields)),
+		defaultField: s.defaultField,
+		customTags:   make(map[string]bool, len(s.customTags)),
+	}
+	for k, v := range s.aliases {
+		c.aliases[k] = v
+	}
+	for k := range s.fields {
+		c.fields[k] = true
+	}
+	for k := range s.customTags {
+		c.customTags[k] = true
+	}
+	return c
+}
+
+func (s sortSpec) lookup(sort string) (string, bool) {
+	lowerSort := strings.ToLower(sort)
+	if field, ok := s.aliases[lowerSort]; ok {
+		return field, true
+	}
+	if s.fields[lowerSort] {
+		return lowerSort, true
+	}
+	return "", false
+}
+
+func fieldSet(fields ...string) map[string]bool {
+	set := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		set[f] = true
+	}
+	return set
+}
+
+// builtinSortSpecs 内置排序白名单，SORT_FIELDS 与 SORT_DEFAULTS 在此基础上扩展
+var builtinSortSpecs = map[string]sortSpec{
+	scene_audio_route_models.SortEntityMediaFiles: {
+		aliases: map[string]string{
+			"title":           "order_title",
+			"album":           "order_album_name",
+			"artist":          "order_artist_name",
+			"album_artist":    "order_album_artist_name",
+			"year":            "year",
+			"original_year":   "original_year",
+			"rating":          "rating",
+			"starred_at":      "starred_at",
+			"rated_at":        "rated_at",
+			"genre":           "genre",
+			"play_count":      "play_count",
+			"play_date":       "play_date",
+			"first_play_date": "first_play_date",
+			"duration":        "duration",
+			"bit_rate":        "bit_rate",
+			"size":            "size",
+			"created_at":      "created_at",
+			"updated_at":      "updated_at",
+		},
+		fields:       fieldSet(),
+		defaultField: "_id",
+	},
+	scene_audio_route_models.SortEntityAlbums: {
+		aliases: map[string]string{
+			"name":            "order_album_name",
+			"artist":          "artist",
+			"album_artist":    "album_artist",
+			"min_year":        "min_year",
+			"max_year":        "max_year",
+			"original_year":   "original_year",
+			"rating":          "rating",
+			"starred_at":      "starred_at",
+			"rated_at":        "rated_at",
+			"genre":           "genre",
+			"song_count":      "song_count",
+			"duration":        "duration",
+			"size":            "size",
+			"play_count":      "play_count",
+			"play_date":       "play_date",
+			"first_play_date": "first_play_date",
+			"created_at":      "created_at",
+			"updated_at":      "updated_at",
+		},
+		fields:       fieldSet("order_album_name"),
+		defaultField: "_id",
+	},
+	scene_audio_route_models.SortEntityArtists: {
+		aliases: map[string]string{
+			"name":        "order_artist_name",
+			"album_count": "album_count",
+			"song_count":  "song_count",
+			"play_count":  "play_count",
+			"play_date":   "play_date",
+			"rating":      "rating",
+			"starred_at":  "starred_at",
+			"rated_at":    "rated_at",
+			"size":        "size",
+			"created_at":  "created_at",
+			"updated_at":  "updated_at",
+		},
+		fields:       fieldSet("order_artist_name"),
+		defaultField: "_id",
+	},
+	scene_audio_route_models.SortEntityPlaylistTracks: {
+		aliases: map[string]string{
+			"_id":          "index",
+			"title":        "order_title",
+			"album":        "order_album_name",
+			"artist":       "order_artist_name",
+			"album_artist": "order_album_artist_name",
+			"play_count":   "play_count",
+			"year":         "year",
+			"duration":     "duration",
+		},
+		fields: fieldSet(
+			"index", "play_count", "play_date",
+			"year", "duration", "bit_rate",
+			"size", "rating", "starred_at",
+			"rated_at", "created_at", "updated_at",
+		),
+		defaultField: "index",
+	},
+	scene_audio_route_models.SortEntityMediaFileCues: {
+		aliases: map[string]string{
+			"title":           "title",
+			"performer":       "performer",
+			"year":            "rem.date", // 使用REM中的日期字段
+			"rating":          "rating",
+			"starred_at":      "starred_at",
+			"rated_at":        "rated_at",
+			"genre":           "rem.genre",
+			"play_count":      "play_count",
+			"play_date":       "play_date",
+			"size":            "size",
+			"created_at":      "created_at",
+			"updated_at":      "updated_at",
+			"cue_track_count": "cue_track_count",
+		},
+		fields:       fieldSet(),
+		defaultField: "_id",
+	},
+}
+
+// sortFieldPattern 配置的字段只允许字母数字、下划线与嵌套路径，避免注入 $ 操作符
+var sortFieldPattern = regexp.MustCompile(`^[A-Za-z0-9_]+(\.[A-Za-z0-9_]+)*$`)
+
+// customTagPattern 自定义标签名，与扫描时写入 custom_tags 的小写键一致
+var customTagPattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// customTagEntities 文档中带有 custom_tags 的列表接口
+var customTagEntities = map[string]bool{
+	scene_audio_route_models.SortEntityMediaFiles: true,
+	scene_audio_route_models.SortEntityAlbums:     true,
+}
+
+var (
+	sortSpecsMu sync.RWMutex
+	sortSpecs   = cloneSortSpecs(builtinSortSpecs)
+)
+
+func cloneSortSpecs(specs map[string]sortSpec) map[string]sortSpec {
+	c := make(map[string]sortSpec, len(specs))
+	for entity, spec := range specs {
+		c[entity] = spec.clone()
+	}
+	return c
+}
+
+// ConfigureSortFields 在内置白名单上追加排序字段并覆盖默认排序；
+// fields 形如 "media_files.bpm=bpm,albums.label"（带 = 为别名，否则按字段名排序），
+// defaults 形如 "albums=created_at"，customTags 形如 "media_files.label,albums.label"，
+// 登记的自定义标签可按标签名排序并通过 custom 参数筛选。任一配置无效时返回错误且保持原配置
+func ConfigureSortFields(fields, defaults, customTags string) error {
+	specs := cloneSortSpecs(builtinSortSpecs)
+
+	for _, item := range splitSortConfig(customTags) {
+		entity, name, ok := strings.Cut(item, ".")
+		entity = strings.ToLower(strings.TrimSpace(entity))
+		name = strings.ToLower(strings.TrimSpace(name))
+		spec, known := specs[entity]
+		if !ok || !known || !customTagEntities[entity] || !customTagPattern.MatchString(name) {
+			return fmt.Errorf("invalid custom tag field: %s", item)
+		}
+		if _, exists := spec.lookup(name); exists {
+			return fmt.Errorf("custom tag conflicts with sort field: %s", item)
+		}
+		spec.aliases[name] = "custom_tags." + name
+		spec.customTags[name] = true
+	}
+
+	for _, item := range splitSortConfig(fields) {
+		key, field, hasAlias := strings.Cut(item, "=")
+		entity, name, ok := strings.Cut(key, ".")
+		entity = strings.ToLower(strings.TrimSpace(entity))
+		name = strings.ToLower(strings.TrimSpace(name))
+		spec, known := specs[entity]
+		if !ok || !known || name == "" {
+			return fmt.Errorf("invalid sort field: %s", item)
+		}
+		if !hasAlias {
+			if !sortFieldPattern.MatchString(name) {
+				return fmt.Errorf("invalid sort field name: %s", item)
+			}
+			spec.fields[name] = true
+			continue
+		}
+		field = strings.TrimSpace(field)
+		if !sortFieldPattern.MatchString(field) {
+			return fmt.Errorf("invalid sort field name: %s", item)
+		}
+		spec.aliases[name] = field
+	}
+
+	for _, item := range splitSortConfig(defaults) {
+		entity, sort, ok := strings.Cut(item, "=")
+		entity = strings.ToLower(strings.TrimSpace(entity))
+		spec, known := specs[entity]
+		if !ok || !known {
+			return fmt.Errorf("invalid sort default: %s", item)
+		}
+		field, valid := spec.lookup(strings.TrimSpace(sort))
+		if !valid {
+			return fmt.Errorf("sort default for %s is not in whitelist: %s", entity, sort)
+		}
+		spec.defaultField = field
+		specs[entity] = spec
+	}
+
+	sortSpecsMu.Lock()
+	defer sortSpecsMu.Unlock()
+	sortSpecs = specs
+	return nil
+}
+
+func splitSortConfig(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// lookupSortField 排序名不在白名单内时返回 false
+func lookupSortField(entity, sort string) (string, bool) {
+	sortSpecsMu.RLock()
+	defer sortSpecsMu.RUnlock()
+	return sortSpecs[entity].lookup(sort)
+}
+
+// sortFieldFor 返回排序名对应的文档字段，无效时使用该接口的默认排序
+func sortFieldFor(entity, sort string) string {
+	sortSpecsMu.RLock()
+	defer sortSpecsMu.RUnlock()
+	spec := sortSpecs[entity]
+	if field, ok := spec.lookup(sort); ok {
+		return field
+	}
+	return spec.defaultField
+}
+
+// customTagCondition 将 custom 参数（形如 "label:Warp,vocalist:Foo"）转换为 custom_tags 的精确匹配条件，
+// 只允许登记过的自定义标签
+func customTagCondition(entity, custom string) (bson.D, error) {
+	if custom == "" {
+		return nil, nil
+	}
+	sortSpecsMu.RLock()
+	registered := sortSpecs[entity].customTags
+	sortSpecsMu.RUnlock()
+
+	var cond bson.D
+	for _, item := range splitSortConfig(custom) {
+		name, value, ok := strings.Cut(item, ":")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !ok || !registered[name] {
+			return nil, fmt.Errorf("custom tag is not filterable: %s", item)
+		}
+		cond = append(cond, bson.E{Key: "custom_tags." + name, Value: strings.TrimSpace(value)})
+	}
+	return cond, nil
+}
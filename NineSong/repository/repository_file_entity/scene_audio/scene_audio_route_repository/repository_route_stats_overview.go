@@ -0,0 +1,213 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"golang.org/x/sync/errgroup"
+)
+
+type statsOverviewRepository struct {
+	db mongo.Database
+}
+
+func NewStatsOverviewRepository(db mongo.Database) scene_audio_route_interface.StatsOverviewRepository {
+	return &statsOverviewRepository{db: db}
+}
+
+// GetOverview 一次按单曲汇总（时长、去重数与流派），一次按播放事件汇总（热力图与日期），两次聚合并行执行
+func (r *statsOverviewRepository) GetOverview(
+	ctx context.Context,
+	userId string,
+	since time.Time,
+	loc *time.Location,
+) (*scene_audio_route_models.StatsOverviewRaw, error) {
+	match := bson.D{{Key: "user_id", Value: userId}}
+	if !since.IsZero() {
+		match = append(match, bson.E{Key: "played_at", Value: bson.M{"$gte": since}})
+	}
+	// MongoDB 只接受 Olson 名称或偏移量，time.Local 的名称为 "Local"
+	timezone := time.Now().In(loc).Format("-07:00")
+	raw := &scene_audio_route_models.StatsOverviewRaw{
+		Genres:  make([]scene_audio_route_models.StatsGenreShare, 0),
+		Heatmap: make([]scene_audio_route_models.StatsHeatmapCell, 0),
+		Days:    make([]string, 0),
+	}
+
+	// 调用方受限时只统计可访问媒体库中曲目的播放记录
+	head := []bson.D{{{Key: "$match", Value: match}}}
+	if visible := visibleMediaStages(ctx, "media_file_id", "visible_media"); visible != nil {
+		head = append(append(head, visible...), bson.D{{Key: "$unset", Value: "visible_media"}})
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		return r.mediaTotals(gctx, head, raw)
+	})
+	g.Go(func() error {
+		return r.eventTotals(gctx, head, timezone, raw)
+	})
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// mediaTotals 未上报播放时长的记录按单曲时长计入
+func (r *statsOverviewRepository) mediaTotals(ctx context.Context, head []bson.D, raw *scene_audio_route_models.StatsOverviewRaw) error {
+	firstOf := func(field string) bson.D {
+		return bson.D{{Key: "$arrayElemAt", Value: bson.A{"$media." + field, 0}}}
+	}
+	// head 由两个查询并发共用，拼接时复制而不是原地追加
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioScenePlayHistory).Aggregate(ctx, append(append([]bson.D{}, head...), []bson.D{
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$media_file_id"},
+			{Key: "plays", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "reported", Value: bson.D{{Key: "$sum", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$duration_played", 0}}}}}},
+			{Key: "unreported", Value: bson.D{{Key: "$sum", Value: bson.D{{Key: "$cond", Value: bson.A{
+				bson.D{{Key: "$gt", Value: bson.A{bson.D{{Key: "$ifNull", Value: bson.A{"$duration_played", 0}}}, 0}}}, 0, 1,
+			}}}}}},
+			{Key: "album_id", Value: bson.D{{Key: "$first", Value: "$album_id"}}},
+			{Key: "artist_id", Value: bson.D{{Key: "$first", Value: "$artist_id"}}},
+		}}},
+		{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: domain.CollectionFileEntityAudioSceneMediaFile},
+			{Key: "localField", Value: "_id"},
+			{Key: "foreignField", Value: "_id"},
+			{Key: "as", Value: "media"},
+		}}},
+		{{Key: "$project", Value: bson.D{
+			{Key: "plays", Value: 1},
+			{Key: "album_id", Value: 1},
+			{Key: "artist_id", Value: 1},
+			{Key: "genre", Value: firstOf("genre")},
+			{Key: "seconds", Value: bson.D{{Key: "$add", Value: bson.A{
+				"$reported",
+				// 曲目时长以纳秒存储，换算为秒后与上报的播放秒数相加
+				bson.D{{Key: "$multiply", Value: bson.A{"$unreported", bson.D{{Key: "$divide", Value: bson.A{
+					bson.D{{Key: "$ifNull", Value: bson.A{firstOf("duration"), 0}}}, float64(time.Second),
+				}}}}}},
+			}}}},
+		}}},
+		{{Key: "$facet", Value: bson.D{
+			{Key: "totals", Value: []bson.D{
+				{{Key: "$group", Value: bson.D{
+					{Key: "_id", Value: nil},
+					{Key: "plays", Value: bson.D{{Key: "$sum", Value: "$plays"}}},
+					{Key: "listening_seconds", Value: bson.D{{Key: "$sum", Value: "$seconds"}}},
+					{Key: "unique_media_files", Value: bson.D{{Key: "$sum", Value: 1}}},
+					{Key: "albums", Value: bson.D{{Key: "$addToSet", Value: "$album_id"}}},
+					{Key: "artists", Value: bson.D{{Key: "$addToSet", Value: "$artist_id"}}},
+				}}},
+				{{Key: "$project", Value: bson.D{
+					{Key: "plays", Value: 1},
+					{Key: "listening_seconds", Value: 1},
+					{Key: "unique_media_files", Value: 1},
+					{Key: "unique_albums", Value: bson.D{{Key: "$size", Value: bson.D{{Key: "$setDifference", Value: bson.A{"$albums", bson.A{"", nil}}}}}}},
+					{Key: "unique_artists", Value: bson.D{{Key: "$size", Value: bson.D{{Key: "$setDifference", Value: bson.A{"$artists", bson.A{"", nil}}}}}}},
+				}}},
+			}},
+			{Key: "genres", Value: []bson.D{
+				{{Key: "$match", Value: bson.D{{Key: "genre", Value: bson.D{{Key: "$nin", Value: bson.A{"", nil}}}}}}},
+				{{Key: "$group", Value: bson.D{
+					{Key: "_id", Value: "$genre"},
+					{Key: "plays", Value: bson.D{{Key: "$sum", Value: "$plays"}}},
+				}}},
+				{{Key: "$sort", Value: bson.D{{Key: "plays", Value: -1}, {Key: "_id", Value: 1}}}},
+				{{Key: "$limit", Value: scene_audio_route_models.StatsOverviewTopGenres}},
+			}},
+		}}},
+	}...))
+	if err != nil {
+		return fmt.Errorf("stats media totals query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var result []struct {
+		Totals []scene_audio_route_models.StatsOverviewRaw `bson:"totals"`
+		Genres []scene_audio_route_models.StatsGenreShare  `bson:"genres"`
+	}
+	if err := cursor.All(ctx, &result); err != nil {
+		return fmt.Errorf("decode stats media totals failed: %w", err)
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	if len(result[0].Totals) > 0 {
+		t := result[0].Totals[0]
+		raw.Plays = t.Plays
+		raw.ListeningSeconds = t.ListeningSeconds
+		raw.UniqueMediaFiles = t.UniqueMediaFiles
+		raw.UniqueAlbums = t.UniqueAlbums
+		raw.UniqueArtists = t.UniqueArtists
+	}
+	if result[0].Genres != nil {
+		raw.Genres = result[0].Genres
+	}
+	return nil
+}
+
+func (r *statsOverviewRepository) eventTotals(ctx context.Context, head []bson.D, timezone string, raw *scene_audio_route_models.StatsOverviewRaw) error {
+	dateOf := func(op string) bson.D {
+		return bson.D{{Key: op, Value: bson.D{{Key: "date", Value: "$played_at"}, {Key: "timezone", Value: timezone}}}}
+	}
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioScenePlayHistory).Aggregate(ctx, append(append([]bson.D{}, head...), []bson.D{
+		{{Key: "$facet", Value: bson.D{
+			{Key: "heatmap", Value: []bson.D{
+				{{Key: "$group", Value: bson.D{
+					{Key: "_id", Value: bson.D{
+						// $dayOfWeek 以周日为 1
+						{Key: "weekday", Value: bson.D{{Key: "$subtract", Value: bson.A{dateOf("$dayOfWeek"), 1}}}},
+						{Key: "hour", Value: dateOf("$hour")},
+					}},
+					{Key: "plays", Value: bson.D{{Key: "$sum", Value: 1}}},
+				}}},
+				{{Key: "$project", Value: bson.D{
+					{Key: "_id", Value: 0},
+					{Key: "weekday", Value: "$_id.weekday"},
+					{Key: "hour", Value: "$_id.hour"},
+					{Key: "plays", Value: 1},
+				}}},
+				{{Key: "$sort", Value: bson.D{{Key: "weekday", Value: 1}, {Key: "hour", Value: 1}}}},
+			}},
+			{Key: "days", Value: []bson.D{
+				{{Key: "$group", Value: bson.D{{Key: "_id", Value: bson.D{{Key: "$dateToString", Value: bson.D{
+					{Key: "format", Value: "%Y-%m-%d"},
+					{Key: "date", Value: "$played_at"},
+					{Key: "timezone", Value: timezone},
+				}}}}}}},
+				{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+			}},
+		}}},
+	}...))
+	if err != nil {
+		return fmt.Errorf("stats event totals query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var result []struct {
+		Heatmap []scene_audio_route_models.StatsHeatmapCell `bson:"heatmap"`
+		Days    []struct {
+			Day string `bson:"_id"`
+		} `bson:"days"`
+	}
+	if err := cursor.All(ctx, &result); err != nil {
+		return fmt.Errorf("decode stats event totals failed: %w", err)
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	if result[0].Heatmap != nil {
+		raw.Heatmap = result[0].Heatmap
+	}
+	for _, d := range result[0].Days {
+		raw.Days = append(raw.Days, d.Day)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package scene_audio_route_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type userPreferenceRepository struct {
+	db         mongo.Database
+	collection string
+}
+
+func NewUserPreferenceRepository(db mongo.Database, collection string) scene_audio_route_interface.UserPreferenceRepository {
+	return &userPreferenceRepository{
+		db:         db,
+		collection: collection,
+	}
+}
+
+// 获取用户偏好，未设置过时返回默认值
+func (r *userPreferenceRepository) GetUserPreference(ctx context.Context, userId string) (*scene_audio_route_models.UserPreferenceMetadata, error) {
+	coll := r.db.Collection(r.collection)
+	var pref scene_audio_route_models.UserPreferenceMetadata
+	err := coll.FindOne(ctx, bson.M{"user_id": userId}).Decode(&pref)
+	if err != nil {
+		if errors.Is(err, driver.ErrNoDocuments) {
+			return &scene_audio_route_models.UserPreferenceMetadata{
+				UserID:         userId,
+				ReplayGainMode: "off",
+			}, nil
+		}
+		return nil, fmt.Errorf("find one error: %w", err)
+	}
+	return &pref, nil
+}
+
+func (r *userPreferenceRepository) UpdateReplayGainMode(ctx context.Context, userId string, mode string) (*scene_audio_route_models.UserPreferenceMetadata, error) {
+	return r.upsert(ctx, userId, bson.M{"replay_gain_mode": mode})
+}
+
+func (r *userPreferenceRepository) UpdateHomeSections(ctx context.Context, userId string, sections []scene_audio_route_models.HomeSectionConfig) (*scene_audio_route_models.UserPreferenceMetadata, error) {
+	if len(sections) == 0 {
+		return r.upsert(ctx, userId, bson.M{"home_sections": nil})
+	}
+	return r.upsert(ctx, userId, bson.M{"home_sections": sections})
+}
+
+func (r *userPreferenceRepository) upsert(ctx context.Context, userId string, fields bson.M) (*scene_audio_route_models.UserPreferenceMetadata, error) {
+	coll := r.db.Collection(r.collection)
+	now := time.Now().UTC()
+
+	set := bson.M{"updated_at": now}
+	for k, v := range fields {
+		set[k] = v
+	}
+	update := bson.M{
+		"$set": set,
+		"$setOnInsert": bson.M{
+			"_id":        primitive.NewObjectID(),
+			"user_id":    userId,
+			"created_at": now,
+		},
+	}
+
+	if _, err := coll.UpdateOne(ctx, bson.M{"user_id": userId}, update, options.Update().SetUpsert(true)); err != nil {
+		return nil, fmt.Errorf("upsert failed: %w", err)
+	}
+	return r.GetUserPreference(ctx, userId)
+}
@@ -0,0 +1,175 @@
+package scene_audio_subsonic_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_subsonic/scene_audio_subsonic_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_subsonic/scene_audio_subsonic_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type subsonicForwardRepository struct {
+	db mongo.Database
+}
+
+func NewSubsonicForwardRepository(db mongo.Database) scene_audio_subsonic_interface.SubsonicForwardRepository {
+	return &subsonicForwardRepository{db: db}
+}
+
+func (r *subsonicForwardRepository) EnqueueTask(ctx context.Context, task *scene_audio_subsonic_models.SubsonicForwardTask) error {
+	if task.ID.IsZero() {
+		task.ID = primitive.NewObjectID()
+	}
+	if _, err := r.db.Collection(domain.CollectionFileEntityAudioSceneSubsonicForwardQueue).InsertOne(ctx, task); err != nil {
+		return fmt.Errorf("enqueue forward task failed: %w", err)
+	}
+	return nil
+}
+
+func (r *subsonicForwardRepository) GetDueTasks(
+	ctx context.Context,
+	now time.Time,
+	limit int64,
+) ([]scene_audio_subsonic_models.SubsonicForwardTask, error) {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneSubsonicForwardQueue).Find(ctx,
+		bson.M{
+			"status":          scene_audio_subsonic_models.ForwardStatusPending,
+			"next_attempt_at": bson.M{"$lte": now},
+		},
+		options.Find().SetSort(bson.D{{Key: "next_attempt_at", Value: 1}, {Key: "_id", Value: 1}}).SetLimit(limit),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("forward queue query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var tasks []scene_audio_subsonic_models.SubsonicForwardTask
+	if err := cursor.All(ctx, &tasks); err != nil {
+		return nil, fmt.Errorf("decode forward tasks failed: %w", err)
+	}
+	return tasks, nil
+}
+
+func (r *subsonicForwardRepository) ClaimTask(
+	ctx context.Context,
+	task scene_audio_subsonic_models.SubsonicForwardTask,
+	until time.Time,
+) (bool, error) {
+	result, err := r.db.Collection(domain.CollectionFileEntityAudioSceneSubsonicForwardQueue).UpdateOne(ctx,
+		bson.M{"_id": task.ID, "next_attempt_at": task.NextAttemptAt},
+		bson.M{"$set": bson.M{"next_attempt_at": until}},
+	)
+	if err != nil {
+		return false, fmt.Errorf("claim forward task failed: %w", err)
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+func (r *subsonicForwardRepository) UpdateTask(ctx context.Context, task scene_audio_subsonic_models.SubsonicForwardTask) error {
+	if _, err := r.db.Collection(domain.CollectionFileEntityAudioSceneSubsonicForwardQueue).UpdateOne(ctx,
+		bson.M{"_id": task.ID},
+		bson.M{"$set": bson.M{
+			"status":          task.Status,
+			"attempts":        task.Attempts,
+			"last_error":      task.LastError,
+			"next_attempt_at": task.NextAttemptAt,
+		}},
+	); err != nil {
+		return fmt.Errorf("update forward task failed: %w", err)
+	}
+	return nil
+}
+
+func (r *subsonicForwardRepository) DeleteTask(ctx context.Context, id primitive.ObjectID) error {
+	if _, err := r.db.Collection(domain.CollectionFileEntityAudioSceneSubsonicForwardQueue).DeleteOne(ctx, bson.M{"_id": id}); err != nil {
+		return fmt.Errorf("delete forward task failed: %w", err)
+	}
+	return nil
+}
+
+func (r *subsonicForwardRepository) GetMapping(
+	ctx context.Context,
+	itemID, itemType string,
+) (*scene_audio_subsonic_models.SubsonicItemMapping, error) {
+	var mapping scene_audio_subsonic_models.SubsonicItemMapping
+	err := r.db.Collection(domain.CollectionFileEntityAudioSceneSubsonicMapping).
+		FindOne(ctx, bson.M{"item_id": itemID, "item_type": itemType}).
+		Decode(&mapping)
+	if err != nil {
+		if errors.Is(err, driver.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("mapping query failed: %w", err)
+	}
+	return &mapping, nil
+}
+
+func (r *subsonicForwardRepository) SaveMapping(ctx context.Context, mapping scene_audio_subsonic_models.SubsonicItemMapping) error {
+	mapping.UpdatedAt = time.Now().UTC()
+	if _, err := r.db.Collection(domain.CollectionFileEntityAudioSceneSubsonicMapping).UpdateOne(ctx,
+		bson.M{"item_id": mapping.ItemID, "item_type": mapping.ItemType},
+		bson.M{"$set": mapping},
+		options.Update().SetUpsert(true),
+	); err != nil {
+		return fmt.Errorf("save mapping failed: %w", err)
+	}
+	return nil
+}
+
+func (r *subsonicForwardRepository) GetLocalItem(
+	ctx context.Context,
+	itemID, itemType string,
+) (*scene_audio_subsonic_models.SubsonicLocalItem, error) {
+	id, err := primitive.ObjectIDFromHex(itemID)
+	if err != nil {
+		return nil, errors.New("invalid item id")
+	}
+
+	item := &scene_audio_subsonic_models.SubsonicLocalItem{ItemID: itemID, ItemType: itemType}
+	switch itemType {
+	case "media":
+		var doc struct {
+			Title      string `bson:"title"`
+			Artist     string `bson:"artist"`
+			Album      string `bson:"album"`
+			Path       string `bson:"path"`
+			MbzTrackID string `bson:"mbz_track_id"`
+			ISRC       string `bson:"isrc"`
+		}
+		err = r.db.Collection(domain.CollectionFileEntityAudioSceneMediaFile).FindOne(ctx, bson.M{"_id": id}).Decode(&doc)
+		item.Title, item.Artist, item.Album, item.Path, item.MBID = doc.Title, doc.Artist, doc.Album, doc.Path, doc.MbzTrackID
+		item.ISRC = doc.ISRC
+	case "album":
+		var doc struct {
+			Name       string `bson:"name"`
+			Artist     string `bson:"artist"`
+			MbzAlbumID string `bson:"mbz_album_id"`
+		}
+		err = r.db.Collection(domain.CollectionFileEntityAudioSceneAlbum).FindOne(ctx, bson.M{"_id": id}).Decode(&doc)
+		item.Title, item.Artist, item.MBID = doc.Name, doc.Artist, doc.MbzAlbumID
+	case "artist":
+		var doc struct {
+			Name        string `bson:"name"`
+			MbzArtistID string `bson:"mbz_artist_id"`
+		}
+		err = r.db.Collection(domain.CollectionFileEntityAudioSceneArtist).FindOne(ctx, bson.M{"_id": id}).Decode(&doc)
+		item.Title, item.MBID = doc.Name, doc.MbzArtistID
+	default:
+		return nil, fmt.Errorf("unsupported item type: %s", itemType)
+	}
+	if err != nil {
+		if errors.Is(err, driver.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("local item query failed: %w", err)
+	}
+	return item, nil
+}
@@ -0,0 +1,129 @@
+package scene_audio_subsonic_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_subsonic/scene_audio_subsonic_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_subsonic/scene_audio_subsonic_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type numericIDRepository struct {
+	db mongo.Database
+}
+
+func NewNumericIDRepository(db mongo.Database) scene_audio_subsonic_interface.NumericIDRepository {
+	return &numericIDRepository{db: db}
+}
+
+func (r *numericIDRepository) GetOrCreate(ctx context.Context, itemType string, itemIDs []string) (map[string]int64, error) {
+	ids := make(map[string]int64, len(itemIDs))
+	if len(itemIDs) == 0 {
+		return ids, nil
+	}
+	if err := r.loadExisting(ctx, itemType, itemIDs, ids); err != nil {
+		return nil, err
+	}
+
+	for _, itemID := range itemIDs {
+		if _, ok := ids[itemID]; ok {
+			continue
+		}
+		id, err := r.allocate(ctx, itemType, itemID)
+		if err != nil {
+			return nil, err
+		}
+		ids[itemID] = id
+	}
+	return ids, nil
+}
+
+func (r *numericIDRepository) Resolve(ctx context.Context, id int64) (*scene_audio_subsonic_models.NumericIDMapping, error) {
+	var mapping scene_audio_subsonic_models.NumericIDMapping
+	err := r.db.Collection(domain.CollectionFileEntityAudioSceneNumericID).
+		FindOne(ctx, bson.M{"_id": id}).
+		Decode(&mapping)
+	if err != nil {
+		if errors.Is(err, driver.ErrNoDocuments) {
+			return nil, fmt.Errorf("numeric id %d %w", id, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("numeric id query failed: %w", err)
+	}
+	return &mapping, nil
+}
+
+func (r *numericIDRepository) loadExisting(ctx context.Context, itemType string, itemIDs []string, into map[string]int64) error {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneNumericID).Find(ctx,
+		bson.M{"item_type": itemType, "item_id": bson.M{"$in": itemIDs}})
+	if err != nil {
+		return fmt.Errorf("numeric id query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var mappings []scene_audio_subsonic_models.NumericIDMapping
+	if err := cursor.All(ctx, &mappings); err != nil {
+		return fmt.Errorf("decode numeric ids failed: %w", err)
+	}
+	for _, m := range mappings {
+		into[m.ItemID] = m.ID
+	}
+	return nil
+}
+
+// allocate 取当前最大ID加一写入；并发时主键或 item 唯一索引冲突，
+// 先查是否已被其他请求映射，否则重新取号
+func (r *numericIDRepository) allocate(ctx context.Context, itemType, itemID string) (int64, error) {
+	coll := r.db.Collection(domain.CollectionFileEntityAudioSceneNumericID)
+	for attempt := 0; attempt < scene_audio_subsonic_models.NumericIDAllocateAttempts; attempt++ {
+		last, err := r.lastID(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		mapping := scene_audio_subsonic_models.NumericIDMapping{
+			ID:        last + 1,
+			ItemID:    itemID,
+			ItemType:  itemType,
+			CreatedAt: time.Now().UTC(),
+		}
+		if _, err := coll.InsertOne(ctx, mapping); err == nil {
+			return mapping.ID, nil
+		} else if !driver.IsDuplicateKeyError(err) {
+			return 0, fmt.Errorf("insert numeric id failed: %w", err)
+		}
+
+		existing := make(map[string]int64, 1)
+		if err := r.loadExisting(ctx, itemType, []string{itemID}, existing); err != nil {
+			return 0, err
+		}
+		if id, ok := existing[itemID]; ok {
+			return id, nil
+		}
+	}
+	return 0, fmt.Errorf("allocate numeric id for %s %s: too many conflicts", itemType, itemID)
+}
+
+func (r *numericIDRepository) lastID(ctx context.Context) (int64, error) {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneNumericID).Find(ctx, bson.M{},
+		options.Find().SetSort(bson.D{{Key: "_id", Value: -1}}).SetProjection(bson.M{"_id": 1}).SetLimit(1))
+	if err != nil {
+		return 0, fmt.Errorf("numeric id query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var last []scene_audio_subsonic_models.NumericIDMapping
+	if err := cursor.All(ctx, &last); err != nil {
+		return 0, fmt.Errorf("decode numeric ids failed: %w", err)
+	}
+	if len(last) == 0 {
+		return 0, nil
+	}
+	return last[0].ID, nil
+}
@@ -0,0 +1,96 @@
+package repository_system
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/sync/errgroup"
+)
+
+type dashboardRepo struct {
+	db mongo.Database
+}
+
+func NewDashboardRepository(db mongo.Database) domain_system.DashboardRepository {
+	return &dashboardRepo{db: db}
+}
+
+func (r *dashboardRepo) GetLibraryCounts(ctx context.Context) (domain_system.LibraryCounts, error) {
+	var counts domain_system.LibraryCounts
+	targets := []struct {
+		collection string
+		filter     bson.M
+		out        *int64
+	}{
+		{domain.CollectionFileEntityAudioSceneArtist, bson.M{}, &counts.Artists},
+		{domain.CollectionFileEntityAudioSceneAlbum, bson.M{}, &counts.Albums},
+		{domain.CollectionFileEntityAudioSceneMediaFile, bson.M{}, &counts.MediaFiles},
+		{domain.CollectionFileEntityAudioSceneMediaFileCue, bson.M{}, &counts.MediaFileCues},
+		{domain.CollectionFileEntityAudioSceneGenre, bson.M{}, &counts.Genres},
+		{domain.CollectionFileEntityAudioScenePlaylist, bson.M{}, &counts.Playlists},
+		{domain.CollectionUser, bson.M{}, &counts.Users},
+		{domain.CollectionFileEntityAudioSceneMediaFile, bson.M{"review_status": scene_audio_db_models.ReviewStatusPending}, &counts.PendingReviews},
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	for _, t := range targets {
+		g.Go(func() error {
+			n, err := r.db.Collection(t.collection).CountDocuments(gctx, t.filter)
+			if err != nil {
+				return fmt.Errorf("count %s failed: %w", t.collection, err)
+			}
+			*t.out = n
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return domain_system.LibraryCounts{}, err
+	}
+	return counts, nil
+}
+
+func (r *dashboardRepo) GetActiveSessions(ctx context.Context, since time.Time) ([]domain_system.ActiveSession, error) {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioScenePlayQueue).Find(ctx,
+		bson.M{"updated_at": bson.M{"$gte": since}},
+		options.Find().
+			SetProjection(bson.M{"user_id": 1, "client": 1, "updated_at": 1}).
+			SetSort(bson.D{{Key: "updated_at", Value: -1}}))
+	if err != nil {
+		return nil, fmt.Errorf("active sessions query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	sessions := make([]domain_system.ActiveSession, 0)
+	if err := cursor.All(ctx, &sessions); err != nil {
+		return nil, fmt.Errorf("decode active sessions failed: %w", err)
+	}
+	return sessions, nil
+}
+
+func (r *dashboardRepo) GetCacheFolders(ctx context.Context, types ...string) (map[string]string, error) {
+	cursor, err := r.db.Collection(domain.CollectionFileEntityAudioSceneTempMetadata).Find(ctx,
+		bson.M{"metadata_type": bson.M{"$in": types}})
+	if err != nil {
+		return nil, fmt.Errorf("temp folders query failed: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var resources []scene_audio_db_models.ExternalResource
+	if err := cursor.All(ctx, &resources); err != nil {
+		return nil, fmt.Errorf("decode temp folders failed: %w", err)
+	}
+	folders := make(map[string]string, len(resources))
+	for _, res := range resources {
+		if res.FolderPath != "" {
+			folders[res.MetadataType] = res.FolderPath
+		}
+	}
+	return folders, nil
+}
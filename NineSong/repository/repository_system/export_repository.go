@@ -0,0 +1,66 @@
+package repository_system
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type exportRepo struct {
+	db mongo.Database
+}
+
+// exportCollections 导出实体类型对应的集合
+var exportCollections = map[string]string{
+	"artist": domain.CollectionFileEntityAudioSceneArtist,
+	"album":  domain.CollectionFileEntityAudioSceneAlbum,
+	"media":  domain.CollectionFileEntityAudioSceneMediaFile,
+}
+
+// exportExcludedFields 检索与排序用的派生字段，外部索引系统可自行生成
+var exportExcludedFields = bson.M{
+	"full_text":           0,
+	"name_pinyin":         0,
+	"title_pinyin":        0,
+	"album_pinyin":        0,
+	"artist_pinyin":       0,
+	"album_artist_pinyin": 0,
+	"acoust_fingerprint":  0,
+}
+
+func NewExportRepository(db mongo.Database) domain_system.ExportRepository {
+	return &exportRepo{db: db}
+}
+
+func (r *exportRepo) GetPage(ctx context.Context, itemType string, after primitive.ObjectID, limit int) ([]bson.M, error) {
+	collection, ok := exportCollections[itemType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported export type: %s", itemType)
+	}
+
+	filter := bson.M{}
+	if !after.IsZero() {
+		filter["_id"] = bson.M{"$gt": after}
+	}
+	cursor, err := r.db.Collection(collection).Find(ctx, filter,
+		options.Find().
+			SetSort(bson.D{{Key: "_id", Value: 1}}).
+			SetLimit(int64(limit)).
+			SetProjection(exportExcludedFields))
+	if err != nil {
+		return nil, fmt.Errorf("export %s query failed: %w", itemType, err)
+	}
+	defer cursor.Close(ctx)
+
+	docs := make([]bson.M, 0, limit)
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("export %s decode failed: %w", itemType, err)
+	}
+	return docs, nil
+}
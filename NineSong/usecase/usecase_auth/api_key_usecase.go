@@ -0,0 +1,84 @@
+package usecase_auth
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_auth"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/internal_system/token_util"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// apiKeyHintLength 列表中展示的明文末尾字符数
+const apiKeyHintLength = 4
+
+type apiKeyUsecase struct {
+	apiKeyRepository domain_auth.APIKeyRepository
+	contextTimeout   time.Duration
+}
+
+func NewAPIKeyUsecase(apiKeyRepository domain_auth.APIKeyRepository, timeout time.Duration) domain_auth.APIKeyUsecase {
+	return &apiKeyUsecase{
+		apiKeyRepository: apiKeyRepository,
+		contextTimeout:   timeout,
+	}
+}
+
+func (au *apiKeyUsecase) Create(c context.Context, userID, name string) (*domain_auth.APIKeyCreateResponse, error) {
+	ctx, cancel := context.WithTimeout(c, au.contextTimeout)
+	defer cancel()
+
+	owner, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := token_util.GenerateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	key := domain_auth.APIKey{
+		ID:        primitive.NewObjectID(),
+		UserID:    owner,
+		Name:      name,
+		Hint:      plain[len(plain)-apiKeyHintLength:],
+		KeyHash:   token_util.HashAPIKey(plain),
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := au.apiKeyRepository.Create(ctx, &key); err != nil {
+		return nil, err
+	}
+	return &domain_auth.APIKeyCreateResponse{APIKey: key, Key: plain}, nil
+}
+
+func (au *apiKeyUsecase) FetchByUserID(c context.Context, userID string) ([]domain_auth.APIKey, error) {
+	ctx, cancel := context.WithTimeout(c, au.contextTimeout)
+	defer cancel()
+	return au.apiKeyRepository.FetchByUserID(ctx, userID)
+}
+
+func (au *apiKeyUsecase) Delete(c context.Context, userID, id string) error {
+	ctx, cancel := context.WithTimeout(c, au.contextTimeout)
+	defer cancel()
+	return au.apiKeyRepository.Delete(ctx, userID, id)
+}
+
+// Authenticate 最近使用时间写入失败不影响本次认证
+func (au *apiKeyUsecase) Authenticate(c context.Context, key string) (string, error) {
+	ctx, cancel := context.WithTimeout(c, au.contextTimeout)
+	defer cancel()
+
+	stored, err := au.apiKeyRepository.GetByHash(ctx, token_util.HashAPIKey(key))
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now().UTC()
+	if now.Sub(stored.LastUsedAt) >= domain_auth.APIKeyTouchInterval {
+		if err := au.apiKeyRepository.TouchLastUsed(ctx, stored.ID, now); err != nil {
+			log.Printf("update api key last used failed: %v", err)
+		}
+	}
+	return stored.UserID.Hex(), nil
+}
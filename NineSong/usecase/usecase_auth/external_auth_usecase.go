@@ -0,0 +1,198 @@
+package usecase_auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_auth"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/internal_system/ldap_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/internal_system/oidc_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/internal_system/token_util"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ExternalAuthConfig LDAP 或 OIDC 为空表示未启用对应登录方式
+type ExternalAuthConfig struct {
+	LDAP *ldap_util.Config
+	OIDC *oidc_util.Client
+	// AdminGroups 非空时每次登录按组重新映射角色，否则只在首次登录时分配普通用户角色
+	AdminGroups []string
+	// AllowedGroups 非空时只允许其中任一组的成员登录
+	AllowedGroups []string
+}
+
+type externalAuthUsecase struct {
+	userRepository domain_auth.UserRepository
+	cfg            ExternalAuthConfig
+	contextTimeout time.Duration
+}
+
+func NewExternalAuthUsecase(userRepository domain_auth.UserRepository, cfg ExternalAuthConfig, timeout time.Duration) domain_auth.ExternalAuthUsecase {
+	return &externalAuthUsecase{
+		userRepository: userRepository,
+		cfg:            cfg,
+		contextTimeout: timeout,
+	}
+}
+
+func (uc *externalAuthUsecase) LoginLDAP(c context.Context, username, password string) (domain_auth.User, error) {
+	if uc.cfg.LDAP == nil {
+		return domain_auth.User{}, domain_auth.ErrProviderDisabled
+	}
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	entry, err := ldap_util.Authenticate(ctx, *uc.cfg.LDAP, username, password)
+	if errors.Is(err, ldap_util.ErrInvalidCredentials) {
+		return domain_auth.User{}, domain_auth.ErrInvalidLogin
+	}
+	if err != nil {
+		return domain_auth.User{}, err
+	}
+
+	name := entry.First(uc.cfg.LDAP.NameAttr)
+	if name == "" {
+		name = username
+	}
+	return uc.provision(ctx, domain_auth.ExternalIdentity{
+		Source:  domain_auth.AuthSourceLDAP,
+		Subject: strings.ToLower(entry.DN),
+		Email:   entry.First(uc.cfg.LDAP.EmailAttr),
+		Name:    name,
+		Groups:  entry.All(uc.cfg.LDAP.GroupAttr),
+	})
+}
+
+func (uc *externalAuthUsecase) OIDCAuthURL(c context.Context) (string, *domain_auth.OIDCLoginState, error) {
+	if uc.cfg.OIDC == nil {
+		return "", nil, domain_auth.ErrProviderDisabled
+	}
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	state, nonce, verifier := oidc_util.NewState()
+	authURL, err := uc.cfg.OIDC.AuthURL(ctx, state, nonce, verifier)
+	if err != nil {
+		return "", nil, err
+	}
+	return authURL, &domain_auth.OIDCLoginState{State: state, Nonce: nonce, Verifier: verifier}, nil
+}
+
+func (uc *externalAuthUsecase) LoginOIDC(c context.Context, code string, state *domain_auth.OIDCLoginState) (domain_auth.User, error) {
+	if uc.cfg.OIDC == nil {
+		return domain_auth.User{}, domain_auth.ErrProviderDisabled
+	}
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	claims, err := uc.cfg.OIDC.Exchange(ctx, code, state.Nonce, state.Verifier)
+	if err != nil {
+		return domain_auth.User{}, err
+	}
+	return uc.provision(ctx, domain_auth.ExternalIdentity{
+		Source:  domain_auth.AuthSourceOIDC,
+		Subject: claims.Subject,
+		Email:   claims.Email,
+		Name:    claims.Name,
+		Groups:  claims.Groups,
+	})
+}
+
+func (uc *externalAuthUsecase) CreateAccessToken(user *domain_auth.User, secret string, expiry int) (accessToken string, err error) {
+	return token_util.CreateAccessToken(user, secret, expiry)
+}
+
+func (uc *externalAuthUsecase) CreateRefreshToken(user *domain_auth.User, secret string, expiry int) (refreshToken string, err error) {
+	return token_util.CreateRefreshToken(user, secret, expiry)
+}
+
+// provision 首次登录时创建本地账号，之后按组映射同步角色
+func (uc *externalAuthUsecase) provision(ctx context.Context, identity domain_auth.ExternalIdentity) (domain_auth.User, error) {
+	if len(uc.cfg.AllowedGroups) > 0 && !memberOf(identity.Groups, uc.cfg.AllowedGroups) {
+		return domain_auth.User{}, domain_auth.ErrGroupNotAllowed
+	}
+	var mapped []string
+	if len(uc.cfg.AdminGroups) > 0 {
+		mapped = []string{domain_auth.RoleUser}
+		if memberOf(identity.Groups, uc.cfg.AdminGroups) {
+			mapped = []string{domain_auth.RoleAdmin}
+		}
+	}
+
+	user, err := uc.userRepository.GetByExternalID(ctx, identity.Source, identity.Subject)
+	if err == nil {
+		if mapped != nil && !sameRoles(user.Roles, mapped) {
+			if err := uc.userRepository.UpdateRoles(ctx, user.ID.Hex(), mapped); err != nil {
+				return domain_auth.User{}, err
+			}
+			user.Roles = mapped
+			user.Admin = user.HasRole(domain_auth.RoleAdmin)
+		}
+		return user, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return domain_auth.User{}, err
+	}
+
+	// 不按邮箱合并已有账号，避免外部来源未验证的邮箱接管本地账号
+	if identity.Email != "" {
+		if _, err := uc.userRepository.GetByEmail(ctx, identity.Email); err == nil {
+			return domain_auth.User{}, domain_auth.ErrEmailTaken
+		} else if !errors.Is(err, mongo.ErrNoDocuments) {
+			return domain_auth.User{}, err
+		}
+	}
+	if mapped == nil {
+		mapped = []string{domain_auth.RoleUser}
+	}
+	name := identity.Name
+	if name == "" {
+		name = identity.Email
+	}
+	user = domain_auth.User{
+		ID:         primitive.NewObjectID(),
+		Name:       name,
+		Email:      identity.Email,
+		Roles:      mapped,
+		Source:     identity.Source,
+		ExternalID: identity.Subject,
+	}
+	user.Admin = user.HasRole(domain_auth.RoleAdmin)
+	if err := uc.userRepository.Create(ctx, &user); err != nil {
+		return domain_auth.User{}, err
+	}
+	return user, nil
+}
+
+// memberOf 组名不区分大小写；LDAP 返回的组 DN 同时按首个 RDN 的值比较，如 cn=admins,ou=groups 匹配 admins
+func memberOf(groups, wanted []string) bool {
+	for _, group := range groups {
+		short := group
+		if rdn, _, ok := strings.Cut(group, ","); ok || strings.Contains(group, "=") {
+			if _, value, ok := strings.Cut(rdn, "="); ok {
+				short = value
+			}
+		}
+		for _, w := range wanted {
+			if strings.EqualFold(group, w) || strings.EqualFold(short, w) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func sameRoles(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
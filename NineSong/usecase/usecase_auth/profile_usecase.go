@@ -27,5 +27,5 @@ func (pu *profileUsecase) GetProfileByID(c context.Context, userID string) (*dom
 		return nil, err
 	}
 
-	return &domain_auth.Profile{Name: user.Name, Email: user.Email}, nil
+	return &domain_auth.Profile{Name: user.Name, Email: user.Email, Roles: summarize(user).Roles}, nil
 }
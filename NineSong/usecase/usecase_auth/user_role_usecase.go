@@ -0,0 +1,76 @@
+package usecase_auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_auth"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type userRoleUsecase struct {
+	userRepository domain_auth.UserRepository
+	contextTimeout time.Duration
+}
+
+func NewUserRoleUsecase(userRepository domain_auth.UserRepository, timeout time.Duration) domain_auth.UserRoleUsecase {
+	return &userRoleUsecase{
+		userRepository: userRepository,
+		contextTimeout: timeout,
+	}
+}
+
+func (uc *userRoleUsecase) FetchUsers(c context.Context) ([]domain_auth.UserSummary, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	users, err := uc.userRepository.Fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	summaries := make([]domain_auth.UserSummary, 0, len(users))
+	for _, user := range users {
+		summaries = append(summaries, summarize(user))
+	}
+	return summaries, nil
+}
+
+func (uc *userRoleUsecase) UpdateRoles(c context.Context, operatorID, userID string, roles []string) (*domain_auth.UserSummary, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	roles, err := domain_auth.NormalizeRoles(roles)
+	if err != nil {
+		return nil, err
+	}
+	user, err := uc.userRepository.GetByID(ctx, userID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, domain_auth.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	user.Roles, user.Admin = roles, false
+	if operatorID == userID && !user.HasRole(domain_auth.RoleAdmin) {
+		return nil, domain_auth.ErrLastAdmin
+	}
+
+	if err := uc.userRepository.UpdateRoles(ctx, userID, roles); err != nil {
+		return nil, err
+	}
+	summary := summarize(user)
+	return &summary, nil
+}
+
+// summarize 旧版用户未补写角色时按 admin 标记推断
+func summarize(user domain_auth.User) domain_auth.UserSummary {
+	roles := user.Roles
+	if len(roles) == 0 {
+		roles = []string{domain_auth.RoleUser}
+		if user.Admin {
+			roles = []string{domain_auth.RoleAdmin}
+		}
+	}
+	return domain_auth.UserSummary{ID: user.ID.Hex(), Name: user.Name, Email: user.Email, Roles: roles}
+}
@@ -0,0 +1,75 @@
+package usecase_file_entity
+
+import (
+	"context"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+)
+
+// attachmentSyncInterval 同一专辑目录在此时间内只同步一次，避免专辑内每首曲目重复读取目录
+const attachmentSyncInterval = 10 * time.Minute
+
+// processAlbumAttachments 同步曲目所在目录中的 PDF 内页、说明文本等附件，扩展名与文件头不匹配的文件跳过
+func (uc *FileUsecase) processAlbumAttachments(ctx context.Context, mediaFile *scene_audio_db_models.MediaFileMetadata) {
+	if uc.attachmentRepo == nil || mediaFile == nil || mediaFile.AlbumID == "" {
+		return
+	}
+	dir := filepath.Dir(mediaFile.Path)
+	key := mediaFile.AlbumID + "|" + dir
+	if last, ok := uc.attachmentSynced.Load(key); ok && time.Since(last.(time.Time)) < attachmentSyncInterval {
+		return
+	}
+	uc.attachmentSynced.Store(key, time.Now())
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		log.Printf("读取专辑目录失败: %s | %v", dir, err)
+		return
+	}
+	var found []scene_audio_db_models.AlbumAttachmentMetadata
+	for _, entry := range entries {
+		if entry.IsDir() || !scene_audio_db_models.IsAttachmentExt(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || info.Size() > scene_audio_db_models.AttachmentMaxSize {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		contentType, err := sniffAttachment(path)
+		if err != nil {
+			continue
+		}
+		found = append(found, scene_audio_db_models.AlbumAttachmentMetadata{
+			AlbumID:     mediaFile.AlbumID,
+			Name:        entry.Name(),
+			Path:        path,
+			ContentType: contentType,
+			Size:        info.Size(),
+		})
+	}
+	if err := uc.attachmentRepo.SyncFolder(ctx, mediaFile.AlbumID, dir, found); err != nil {
+		log.Printf("专辑附件同步失败: %s | %v", dir, err)
+	}
+}
+
+// sniffAttachment 读取文件头校验附件类型
+func sniffAttachment(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", err
+	}
+	return scene_audio_db_models.DetectAttachmentType(path, head[:n])
+}
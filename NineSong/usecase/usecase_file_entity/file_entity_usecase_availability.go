@@ -0,0 +1,141 @@
+package usecase_file_entity
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cache_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/lock_util"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const availabilityCheckInterval = 10 * time.Minute
+
+type AvailabilityUsecase struct {
+	folderRepo       domain_file_entity.FolderRepository
+	availabilityRepo scene_audio_db_interface.AvailabilityRepository
+	tempRepo         scene_audio_db_interface.TempRepository
+	timeout          time.Duration
+}
+
+// NewAvailabilityUsecase 定期检查媒体库与源文件是否可访问，结果写回条目供列表展示与过滤
+func NewAvailabilityUsecase(
+	folderRepo domain_file_entity.FolderRepository,
+	availabilityRepo scene_audio_db_interface.AvailabilityRepository,
+	tempRepo scene_audio_db_interface.TempRepository,
+	timeout time.Duration,
+) *AvailabilityUsecase {
+	return &AvailabilityUsecase{
+		folderRepo:       folderRepo,
+		availabilityRepo: availabilityRepo,
+		tempRepo:         tempRepo,
+		timeout:          timeout,
+	}
+}
+
+// Start 启动后台检查协程，ctx 取消时退出
+func (uc *AvailabilityUsecase) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(availabilityCheckInterval)
+		defer ticker.Stop()
+		for {
+			if err := lock_util.RunExclusive(ctx, "availability", uc.Check); err != nil {
+				log.Printf("媒体源可用性检查失败: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Check 检查全部音乐媒体库，有曲目状态变化时重新计算专辑状态并清理计数缓存
+func (uc *AvailabilityUsecase) Check(ctx context.Context) error {
+	folders, err := uc.folderRepo.GetAllByType(ctx, int(domain_file_entity.MusicLibrary))
+	if err != nil {
+		return err
+	}
+	// 流媒体缓存目录未配置时不判定缓存状态
+	cacheDir, _ := uc.tempRepo.GetTempPath(ctx, "stream")
+
+	var changed int64
+	for _, folder := range folders {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		n, err := uc.checkLibrary(ctx, folder.FolderPath, cacheDir)
+		if err != nil {
+			log.Printf("检查媒体库可用性失败 %s: %v", folder.FolderPath, err)
+			continue
+		}
+		changed += n
+	}
+	if changed == 0 {
+		return nil
+	}
+
+	refreshCtx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+	if _, err := uc.availabilityRepo.RefreshAlbumAvailability(refreshCtx); err != nil {
+		return err
+	}
+	cache_util.Invalidate(ctx, cache_util.NamespaceFilterCounts, cache_util.NamespaceLists)
+	log.Printf("媒体源可用状态更新 %d 首", changed)
+	return nil
+}
+
+func (uc *AvailabilityUsecase) checkLibrary(ctx context.Context, libraryPath, cacheDir string) (int64, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, uc.timeout)
+	sources, err := uc.availabilityRepo.GetMediaSources(queryCtx, libraryPath)
+	cancel()
+	if err != nil {
+		return 0, err
+	}
+
+	// 媒体库根目录不可访问（如外置硬盘未挂载）时，不再逐个检查文件
+	_, rootErr := os.Stat(libraryPath)
+	libraryOffline := rootErr != nil
+
+	updates := make(map[string][]primitive.ObjectID)
+	for _, source := range sources {
+		availability := scene_audio_db_models.AvailabilityOnline
+		if libraryOffline || !fileExists(source.Path) {
+			availability = scene_audio_db_models.AvailabilityMissing
+			if cacheDir != "" && fileExists(filepath.Join(cacheDir, scene_audio_db_models.CachedStreamFileName(source.ID.Hex()))) {
+				availability = scene_audio_db_models.AvailabilityCached
+			}
+		}
+		current := source.Availability
+		if current == "" {
+			current = scene_audio_db_models.AvailabilityOnline
+		}
+		if current != availability {
+			updates[availability] = append(updates[availability], source.ID)
+		}
+	}
+
+	var changed int64
+	for availability, ids := range updates {
+		updateCtx, cancel := context.WithTimeout(ctx, uc.timeout)
+		n, err := uc.availabilityRepo.SetMediaAvailability(updateCtx, ids, availability)
+		cancel()
+		if err != nil {
+			return changed, err
+		}
+		changed += n
+	}
+	return changed, nil
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
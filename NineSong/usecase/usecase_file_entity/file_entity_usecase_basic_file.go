@@ -11,14 +11,21 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_interface"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cache_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/event_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/image_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/lock_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository/repository_app/repository_app_config"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_db_usecase"
 	"github.com/dhowden/tag"
 	"go.mongodb.org/mongo-driver/bson"
@@ -39,9 +46,12 @@ type taskProgress struct {
 	totalFiles     int32      // 改为原子类型
 	walkedFiles    int32      // 原子计数：已遍历文件数
 	processedFiles int32      // 原子计数：已处理文件数
+	skippedFiles   int32      // 原子计数：增量扫描跳过的未变文件数
 	mu             sync.Mutex // 新增互斥锁保护非原子字段
 	initialized    bool       // 新增：标记是否已初始化
 	status         string     // 新增：任务状态
+	incremental    bool       // 增量扫描：大小与修改时间未变的文件跳过标签提取
+	startedAt      time.Time
 }
 
 func NewScanManager() *ScanManager {
@@ -95,6 +105,22 @@ func (sm *ScanManager) TryStartConcurrentScan(taskID string) (bool, context.Canc
 	}
 }
 
+// TryStartMaintenance 没有任何扫描运行时独占执行维护任务，期间拒绝新的扫描；与全局扫描不同，不会中断运行中的扫描
+func (sm *ScanManager) TryStartMaintenance() (bool, func()) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if sm.globalScanRunning || sm.concurrentScanCount > 0 {
+		return false, nil
+	}
+	sm.globalScanRunning = true
+	return true, func() {
+		sm.mu.Lock()
+		sm.globalScanRunning = false
+		sm.mu.Unlock()
+	}
+}
+
 // RegisterCancelFunc 注册取消函数
 func (sm *ScanManager) RegisterCancelFunc(taskID string, cancel context.CancelFunc) {
 	sm.mu.Lock()
@@ -141,6 +167,16 @@ type FileUsecase struct {
 	mediaRepo      scene_audio_db_interface.MediaFileRepository
 	tempRepo       scene_audio_db_interface.TempRepository
 	mediaCueRepo   scene_audio_db_interface.MediaFileCueRepository
+	lyricsRepo     scene_audio_db_interface.MediaLyricsRepository
+	attachmentRepo scene_audio_db_interface.AlbumAttachmentRepository
+	fingerprintUc  *FingerprintUsecase // 为空时不做声纹识别
+
+	attachmentSynced sync.Map // 专辑目录最近一次同步附件的时间
+
+	appConfigRepo  repository_app_config.AppConfigRepository
+	reviewRequired atomic.Bool // 新入库歌曲是否进入待审核状态
+
+	protectionRepo scene_audio_db_interface.DeletionProtectionRepository // 为空时扫描清理不做删除保护
 }
 
 func NewFileUsecase(
@@ -154,6 +190,11 @@ func NewFileUsecase(
 	mediaRepo scene_audio_db_interface.MediaFileRepository,
 	tempRepo scene_audio_db_interface.TempRepository,
 	mediaCueRepo scene_audio_db_interface.MediaFileCueRepository,
+	lyricsRepo scene_audio_db_interface.MediaLyricsRepository,
+	attachmentRepo scene_audio_db_interface.AlbumAttachmentRepository,
+	appConfigRepo repository_app_config.AppConfigRepository,
+	fingerprintUc *FingerprintUsecase,
+	protectionRepo scene_audio_db_interface.DeletionProtectionRepository,
 ) *FileUsecase {
 	workerCount := runtime.NumCPU() * 2
 	if workerCount < 4 {
@@ -170,11 +211,17 @@ func NewFileUsecase(
 		scanManager:   NewScanManager(),               // 初始化扫描管理器
 		activeTasks:   make(map[string]*taskProgress), // 新增初始化
 
-		artistRepo:   artistRepo,
-		albumRepo:    albumRepo,
-		mediaRepo:    mediaRepo,
-		tempRepo:     tempRepo,
-		mediaCueRepo: mediaCueRepo,
+		artistRepo:     artistRepo,
+		albumRepo:      albumRepo,
+		mediaRepo:      mediaRepo,
+		tempRepo:       tempRepo,
+		mediaCueRepo:   mediaCueRepo,
+		lyricsRepo:     lyricsRepo,
+		attachmentRepo: attachmentRepo,
+		fingerprintUc:  fingerprintUc,
+
+		appConfigRepo:  appConfigRepo,
+		protectionRepo: protectionRepo,
 	}
 }
 
@@ -231,6 +278,57 @@ func (uc *FileUsecase) GetScanProgress() (float32, time.Time, int, map[string]st
 	return avgProgress, uc.lastScanStart, taskCount, taskStatuses
 }
 
+// GetScanStatus 返回整体进度与各运行中任务的计数
+func (uc *FileUsecase) GetScanStatus() domain_file_entity.ScanStatus {
+	progress, startTime, _, _ := uc.GetScanProgress()
+
+	uc.activeTasksMu.RLock()
+	defer uc.activeTasksMu.RUnlock()
+	status := domain_file_entity.ScanStatus{
+		Scanning:  len(uc.activeTasks) > 0,
+		Progress:  progress,
+		StartTime: startTime,
+		Tasks:     make([]domain_file_entity.ScanTaskStatus, 0, len(uc.activeTasks)),
+	}
+	for id, task := range uc.activeTasks {
+		status.Tasks = append(status.Tasks, domain_file_entity.ScanTaskStatus{
+			ID:             id,
+			Status:         task.status,
+			Incremental:    task.incremental,
+			StartedAt:      task.startedAt,
+			TotalFiles:     int(atomic.LoadInt32(&task.totalFiles)),
+			WalkedFiles:    int(atomic.LoadInt32(&task.walkedFiles)),
+			ProcessedFiles: int(atomic.LoadInt32(&task.processedFiles)),
+			SkippedFiles:   int(atomic.LoadInt32(&task.skippedFiles)),
+		})
+	}
+	sort.Slice(status.Tasks, func(i, j int) bool {
+		return status.Tasks[i].StartedAt.Before(status.Tasks[j].StartedAt)
+	})
+	return status
+}
+
+// publishScanStatus 扫描期间按 ScanEventInterval 推送进度，返回的函数停止推送并发布当前状态
+func (uc *FileUsecase) publishScanStatus() func() {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(domain_file_entity.ScanEventInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				event_util.Publish(context.Background(), event_util.TopicScan, uc.GetScanStatus())
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		event_util.Publish(context.Background(), event_util.TopicScan, uc.GetScanStatus())
+	}
+}
+
 func (uc *FileUsecase) ProcessDirectory(
 	ctx context.Context,
 	dirPaths []string,
@@ -286,6 +384,17 @@ func (uc *FileUsecase) ProcessDirectory(
 		uc.scanningPathsMu.Unlock()
 	}()
 
+	// 4. 多实例共享媒体库时，在集群范围内再次加锁
+	releaseLocks, err := uc.acquireClusterScanLocks(ctx, dirPaths, ScanModel == 3)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		releaseLocks()
+		// 扫描中途失败也可能已写入部分数据
+		cache_util.Invalidate(context.Background(), cache_util.NamespaceFilterCounts, cache_util.NamespaceLists)
+	}()
+
 	// 重置进度计数器
 	uc.scanMutex.Lock()
 	uc.activeScanCount++
@@ -347,15 +456,21 @@ func (uc *FileUsecase) ProcessDirectory(
 
 	// 修复：在正确位置初始化任务进度跟踪器
 	taskProg := &taskProgress{
-		id:     taskID,
-		status: "preparing", // 初始状态
+		id:          taskID,
+		status:      "preparing", // 初始状态
+		incremental: ScanModel == 0,
+		startedAt:   time.Now(),
 	}
 
+	// 推送进度；最终状态须在任务注销后发布，因此先于注销注册 defer
+	defer uc.publishScanStatus()()
+
 	// 注册任务
+	uc.activeTasksMu.Lock()
 	uc.activeTasks[taskID] = taskProg
 	// 开始统计文件数
 	taskProg.status = "counting_files"
-	uc.activeTasks[taskID] = taskProg
+	uc.activeTasksMu.Unlock()
 
 	// 任务结束时清理
 	defer func() {
@@ -537,6 +652,14 @@ func (uc *FileUsecase) ProcessMusicDirectory(
 
 	coverTempPath, _ := uc.tempRepo.GetTempPath(ctx, "cover")
 
+	// 加载艺术家别名，使别名标签归并到同一艺术家
+	if aliases, err := uc.artistRepo.GetAliasMap(ctx); err != nil {
+		log.Printf("艺术家别名加载失败: %v", err)
+	} else {
+		uc.audioExtractor.SetArtistAliases(aliases)
+	}
+	uc.loadReviewSetting(ctx)
+
 	var libraryFolderNewInfos []struct {
 		libraryFolderID        primitive.ObjectID
 		libraryFolderPath      string
@@ -780,42 +903,7 @@ func (uc *FileUsecase) ProcessMusicDirectory(
 		maxConcurrency := 50
 		sem := make(chan struct{}, maxConcurrency)
 		var wgUpdate sync.WaitGroup
-		counters := []struct {
-			countMethod func(context.Context, string) (int64, error)
-			counterName string
-			countType   string
-		}{
-			{
-				countMethod: uc.albumRepo.AlbumCountByArtist,
-				counterName: "album_count",
-				countType:   "专辑",
-			},
-			{
-				countMethod: uc.albumRepo.GuestAlbumCountByArtist,
-				counterName: "guest_album_count",
-				countType:   "合作专辑",
-			},
-			{
-				countMethod: uc.mediaRepo.MediaCountByArtist,
-				counterName: "song_count",
-				countType:   "单曲",
-			},
-			{
-				countMethod: uc.mediaRepo.GuestMediaCountByArtist,
-				counterName: "guest_song_count",
-				countType:   "合作单曲",
-			},
-			{
-				countMethod: uc.mediaCueRepo.MediaCueCountByArtist,
-				counterName: "cue_count",
-				countType:   "光盘",
-			},
-			{
-				countMethod: uc.mediaCueRepo.GuestMediaCueCountByArtist,
-				counterName: "guest_cue_count",
-				countType:   "合作光盘",
-			},
-		}
+		counters := uc.artistCounters()
 
 		// 更新当前阶段为统计阶段
 		uc.scanMutex.Lock()
@@ -862,6 +950,10 @@ func (uc *FileUsecase) ProcessMusicDirectory(
 
 	// 区域3: 媒体库重构（仅当libraryRefactoring为true时执行）
 	if libraryRefactoring {
+		// 删除保护：受保护条目视为仍然有效，下方统计与清理保持一致
+		regularAudioPaths = uc.protectScanPaths(ctx, "media", libraryFolders, regularAudioPaths)
+		cueAudioPaths = uc.protectScanPaths(ctx, "media_cue", libraryFolders, cueAudioPaths)
+
 		// 更新当前阶段为重构阶段
 		uc.scanMutex.Lock()
 		uc.scanProgress = uc.scanStageWeights.traversal + uc.scanStageWeights.statistics
@@ -1215,6 +1307,156 @@ func (uc *FileUsecase) ProcessMusicDirectory(
 }
 
 // 使用快速目录统计函数
+type artistCounter struct {
+	countMethod func(context.Context, string) (int64, error)
+	counterName string
+	countType   string
+}
+
+func (uc *FileUsecase) artistCounters() []artistCounter {
+	return []artistCounter{
+		{
+			countMethod: uc.albumRepo.AlbumCountByArtist,
+			counterName: "album_count",
+			countType:   "专辑",
+		},
+		{
+			countMethod: uc.albumRepo.GuestAlbumCountByArtist,
+			counterName: "guest_album_count",
+			countType:   "合作专辑",
+		},
+		{
+			countMethod: uc.mediaRepo.MediaCountByArtist,
+			counterName: "song_count",
+			countType:   "单曲",
+		},
+		{
+			countMethod: uc.mediaRepo.GuestMediaCountByArtist,
+			counterName: "guest_song_count",
+			countType:   "合作单曲",
+		},
+		{
+			countMethod: uc.mediaCueRepo.MediaCueCountByArtist,
+			counterName: "cue_count",
+			countType:   "光盘",
+		},
+		{
+			countMethod: uc.mediaCueRepo.GuestMediaCueCountByArtist,
+			counterName: "guest_cue_count",
+			countType:   "合作光盘",
+		},
+	}
+}
+
+// ProcessFiles 定向扫描指定文件（如上传的文件），只刷新受影响艺术家的统计，返回 路径 -> 媒体ID
+func (uc *FileUsecase) ProcessFiles(
+	ctx context.Context,
+	folder *domain_file_entity.LibraryFolderMetadata,
+	paths []string,
+) (map[string]string, error) {
+	taskID := fmt.Sprintf("files-%v", time.Now().UnixNano())
+	allowed, cancel := uc.scanManager.TryStartConcurrentScan(taskID)
+	if !allowed {
+		return nil, errors.New("全局扫描任务运行中，无法启动并发扫描")
+	}
+	ctx, cancelTask := context.WithCancel(ctx)
+	uc.scanManager.RegisterCancelFunc(taskID, cancelTask)
+	defer cancel()
+	defer cancelTask()
+
+	libraryFolderPath := strings.Replace(folder.FolderPath, "/", "\\", -1)
+	if !strings.HasSuffix(libraryFolderPath, "\\") {
+		libraryFolderPath += "\\"
+	}
+	coverTempPath, _ := uc.tempRepo.GetTempPath(ctx, "cover")
+	if aliases, err := uc.artistRepo.GetAliasMap(ctx); err != nil {
+		log.Printf("艺术家别名加载失败: %v", err)
+	} else {
+		uc.audioExtractor.SetArtistAliases(aliases)
+	}
+	uc.loadReviewSetting(ctx)
+
+	taskProg := &taskProgress{id: taskID, status: "processing"}
+	taskProg.AddTotalFiles(len(paths))
+
+	var wg sync.WaitGroup
+	errChan := make(chan error, len(paths))
+	for _, path := range paths {
+		wg.Add(1)
+		go uc.processFile(ctx, nil, path, libraryFolderPath, coverTempPath, folder.ID, &wg, errChan, taskProg)
+	}
+	wg.Wait()
+	close(errChan)
+
+	var finalErr error
+	for err := range errChan {
+		log.Printf("文件处理错误: %v", err)
+		finalErr = errors.Join(finalErr, err)
+	}
+
+	mediaIDs := uc.recountArtistsOf(ctx, paths)
+	cache_util.Invalidate(ctx, cache_util.NamespaceFilterCounts, cache_util.NamespaceLists)
+	return mediaIDs, finalErr
+}
+
+// recountArtistsOf 重新统计这些文件涉及的艺术家计数，返回路径到媒体ID的映射
+func (uc *FileUsecase) recountArtistsOf(ctx context.Context, paths []string) map[string]string {
+	mediaIDs := make(map[string]string, len(paths))
+	artistIDs := make(map[string]struct{})
+	for _, path := range paths {
+		mediaFile, err := uc.mediaRepo.GetByPath(ctx, path)
+		if err != nil || mediaFile == nil {
+			continue
+		}
+		mediaIDs[path] = mediaFile.ID.Hex()
+		for _, id := range []string{mediaFile.ArtistID, mediaFile.AlbumArtistID} {
+			if id != "" {
+				artistIDs[id] = struct{}{}
+			}
+		}
+		for _, pair := range mediaFile.AllArtistIDs {
+			if pair.ArtistID != "" {
+				artistIDs[pair.ArtistID] = struct{}{}
+			}
+		}
+	}
+
+	for strID := range artistIDs {
+		id, err := primitive.ObjectIDFromHex(strID)
+		if err != nil {
+			continue
+		}
+		for _, counter := range uc.artistCounters() {
+			count, err := counter.countMethod(ctx, strID)
+			if err != nil {
+				log.Printf("艺术家%s%s统计失败: %v", strID, counter.countType, err)
+				continue
+			}
+			if _, err = uc.artistRepo.UpdateCounter(ctx, id, counter.counterName, int(count)); err != nil {
+				log.Printf("艺术家%s%s计数更新失败: %v", strID, counter.countType, err)
+			}
+		}
+	}
+	return mediaIDs
+}
+
+// loadReviewSetting 读取审核队列开关，读取失败时视为关闭
+func (uc *FileUsecase) loadReviewSetting(ctx context.Context) {
+	enabled := false
+	if uc.appConfigRepo != nil {
+		configs, err := uc.appConfigRepo.GetAll(ctx)
+		if err != nil && !errors.Is(err, domain.ErrEmptyCollection) {
+			log.Printf("审核配置读取失败: %v", err)
+		}
+		for _, cfg := range configs {
+			if cfg.ConfigKey == scene_audio_db_models.ReviewConfigKey {
+				enabled = strings.EqualFold(cfg.ConfigValue, "true")
+			}
+		}
+	}
+	uc.reviewRequired.Store(enabled)
+}
+
 func fastCountFilesInFolder(rootPath string) (int, error) {
 	count := 0
 	err := filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
@@ -1292,6 +1534,12 @@ func (uc *FileUsecase) processFile(
 		return
 	}
 
+	// 增量扫描：CUE 分轨依赖整张 CUE 解析，始终完整处理
+	if taskProg.incremental && res == nil && fileType == domain_file_entity.Audio && uc.skipUnchanged(ctx, path) {
+		atomic.AddInt32(&taskProg.skippedFiles, 1)
+		return
+	}
+
 	// 创建基础元数据
 	metadata, err := uc.createMetadataBasicInfo(path, libraryFolderID)
 	if err != nil {
@@ -1313,10 +1561,18 @@ func (uc *FileUsecase) processFile(
 		if err != nil {
 			return
 		}
+		if mediaFile != nil && uc.reviewRequired.Load() {
+			mediaFile.ReviewStatus = scene_audio_db_models.ReviewStatusPending
+		}
 
 		if err := uc.processAudioHierarchy(ctx, artists, album, mediaFile, mediaFileCue); err != nil {
 			return
 		}
+		uc.processLyrics(ctx, mediaFile)
+		uc.processAlbumAttachments(ctx, mediaFile)
+		if uc.fingerprintUc != nil {
+			uc.fingerprintUc.Identify(ctx, mediaFile)
+		}
 
 		if err := uc.processAudioMediaFilesAndAlbumCover(
 			ctx,
@@ -1343,12 +1599,14 @@ func (uc *FileUsecase) createMetadataBasicInfo(
 		return nil, fmt.Errorf("路径查询失败: %w", err)
 	}
 
-	// 2. 已存在则直接返回
+	// 2. 已存在且大小、修改时间未变则直接返回
 	if existingFile != nil {
-		return existingFile, nil
+		if stat, err := os.Stat(path); err == nil && sameFileStat(existingFile, stat) {
+			return existingFile, nil
+		}
 	}
 
-	// 3. 不存在时执行原流程
+	// 3. 新文件或已修改时重新计算
 	file, err := os.Open(path)
 	if err != nil {
 		log.Printf("文件打开失败: %s | %v", path, err)
@@ -1379,6 +1637,15 @@ func (uc *FileUsecase) createMetadataBasicInfo(
 		return nil, err
 	}
 
+	if existingFile != nil {
+		existingFile.FolderID = libraryFolderID
+		existingFile.Size = stat.Size()
+		existingFile.ModTime = stat.ModTime()
+		existingFile.Checksum = fmt.Sprintf("%x", hash.Sum(nil))
+		existingFile.UpdatedAt = time.Now()
+		return existingFile, nil
+	}
+
 	normalizedPath := filepath.ToSlash(filepath.Clean(path))
 
 	return &domain_file_entity.FileMetadata{
@@ -1394,6 +1661,40 @@ func (uc *FileUsecase) createMetadataBasicInfo(
 	}, nil
 }
 
+// sameFileStat 数据库中的时间只精确到毫秒，按毫秒比较修改时间
+func sameFileStat(file *domain_file_entity.FileMetadata, stat os.FileInfo) bool {
+	return file.Size == stat.Size() &&
+		file.ModTime.Truncate(time.Millisecond).Equal(stat.ModTime().Truncate(time.Millisecond))
+}
+
+// skipUnchanged 文件大小与修改时间均未变且已有单曲记录时跳过标签提取；
+// 扫描前专辑与艺术家计数已被重置，跳过的文件仍按已入库的单曲累加计数
+func (uc *FileUsecase) skipUnchanged(ctx context.Context, path string) bool {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	existing, err := uc.fileRepo.FindByPath(ctx, path)
+	if err != nil || existing == nil || !sameFileStat(existing, stat) {
+		return false
+	}
+	mediaFile, err := uc.mediaRepo.GetByPath(ctx, path)
+	if err != nil || mediaFile == nil {
+		return false
+	}
+
+	var artists []*scene_audio_db_models.ArtistMetadata
+	if artistID, err := primitive.ObjectIDFromHex(mediaFile.ArtistID); err == nil {
+		artists = append(artists, &scene_audio_db_models.ArtistMetadata{ID: artistID})
+	}
+	var album *scene_audio_db_models.AlbumMetadata
+	if albumID, err := primitive.ObjectIDFromHex(mediaFile.AlbumID); err == nil {
+		album = &scene_audio_db_models.AlbumMetadata{ID: albumID}
+	}
+	uc.updateAudioArtistAndAlbumStatistics(artists, album, mediaFile, nil)
+	return true
+}
+
 func (uc *FileUsecase) processAudioMediaFilesAndAlbumCover(
 	ctx context.Context,
 	media *scene_audio_db_models.MediaFileMetadata,
@@ -1462,6 +1763,7 @@ func (uc *FileUsecase) processAudioMediaFilesAndAlbumCover(
 			"$set": bson.M{
 				"medium_image_url": coverPath,
 				"has_cover_art":    coverPath != "",
+				"art_version":      coverArtVersion(coverPath),
 			},
 		}
 		if _, err := uc.mediaRepo.UpdateByID(ctx, media.ID, mediaUpdate); err != nil {
@@ -1601,6 +1903,7 @@ func (uc *FileUsecase) processAlbumCover(album *scene_audio_db_models.AlbumMetad
 			"$set": bson.M{
 				"medium_image_url": albumCoverPath,
 				"has_cover_art":    true,
+				"art_version":      coverArtVersion(albumCoverPath),
 				"updated_at":       time.Now().UTC(),
 			},
 		}
@@ -1611,6 +1914,34 @@ func (uc *FileUsecase) processAlbumCover(album *scene_audio_db_models.AlbumMetad
 	}
 }
 
+// coverArtVersion 封面内容哈希，封面变化时客户端通过新的 ?v= 参数重新获取；无封面时为空
+func coverArtVersion(coverPath string) string {
+	if coverPath == "" {
+		return ""
+	}
+	version, err := image_util.FileVersion(coverPath)
+	if err != nil {
+		log.Printf("[WARN] 封面版本计算失败 | 路径:%s | 错误:%v", coverPath, err)
+		return ""
+	}
+	return version
+}
+
+// publishMediaAdded 仓库仅在插入时把 CreatedAt 设为与 UpdatedAt 相同的写入时间，以此区分新入库与重新扫描
+func publishMediaAdded(ctx context.Context, mediaFile *scene_audio_db_models.MediaFileMetadata) {
+	if mediaFile.ID.IsZero() || !mediaFile.CreatedAt.Equal(mediaFile.UpdatedAt) {
+		return
+	}
+	event_util.Publish(ctx, event_util.TopicMediaAdded, domain_file_entity.MediaAddedEvent{
+		MediaFileID: mediaFile.ID.Hex(),
+		Title:       mediaFile.Title,
+		Artist:      mediaFile.Artist,
+		Album:       mediaFile.Album,
+		ArtistID:    mediaFile.ArtistID,
+		AlbumID:     mediaFile.AlbumID,
+	})
+}
+
 func (uc *FileUsecase) processAudioHierarchy(ctx context.Context,
 	artists []*scene_audio_db_models.ArtistMetadata,
 	album *scene_audio_db_models.AlbumMetadata,
@@ -1635,6 +1966,7 @@ func (uc *FileUsecase) processAudioHierarchy(ctx context.Context,
 				log.Printf("歌曲保存失败: %s | %v", mediaFile.Path, err)
 				return fmt.Errorf("歌曲元数据保存失败 | 路径:%s | %w", mediaFile.Path, err)
 			}
+			publishMediaAdded(ctx, mediaFile)
 		}
 		if mediaFileCue != nil {
 			if mediaFileCue, err := uc.mediaCueRepo.Upsert(ctx, mediaFileCue); err != nil {
@@ -1684,6 +2016,7 @@ func (uc *FileUsecase) processAudioHierarchy(ctx context.Context,
 			log.Printf("最终保存失败: %s | %v", errorInfo, err)
 			return fmt.Errorf("歌曲写入失败 %s | %w", errorInfo, err)
 		}
+		publishMediaAdded(ctx, mediaFile)
 	}
 	if mediaFileCue != nil {
 		if mediaFileCue, err := uc.mediaCueRepo.Upsert(ctx, mediaFileCue); err != nil {
@@ -1814,3 +2147,36 @@ func (uc *FileUsecase) updateAudioAlbumMetadata(ctx context.Context, album *scen
 
 	return nil
 }
+
+// acquireClusterScanLocks 占用各路径的集群锁，全局扫描额外占用全局锁；并发扫描在其他实例执行全局扫描或修复时拒绝启动
+func (uc *FileUsecase) acquireClusterScanLocks(ctx context.Context, dirPaths []string, global bool) (func(), error) {
+	keys := make([]string, 0, len(dirPaths)+1)
+	if global {
+		keys = append(keys, lock_util.KeyScanGlobal)
+	} else if held, err := lock_util.Held(ctx, lock_util.KeyScanGlobal); err != nil {
+		return nil, fmt.Errorf("检查扫描锁失败: %w", err)
+	} else if held {
+		return nil, errors.New("其他实例正在执行全局扫描或修复，无法启动并发扫描")
+	}
+	for _, path := range dirPaths {
+		keys = append(keys, lock_util.KeyScanPath+filepath.Clean(path))
+	}
+
+	release, err := lock_util.Acquire(ctx, keys...)
+	// 全局扫描刚中断的本机并发扫描可能尚未释放路径锁，稍作等待
+	for wait := 0; global && errors.Is(err, lock_util.ErrLocked) && wait < 20; wait++ {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(500 * time.Millisecond):
+		}
+		release, err = lock_util.Acquire(ctx, keys...)
+	}
+	if errors.Is(err, lock_util.ErrLocked) {
+		return nil, errors.New("其他实例正在扫描相同路径或执行全局扫描")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("获取扫描锁失败: %w", err)
+	}
+	return release, nil
+}
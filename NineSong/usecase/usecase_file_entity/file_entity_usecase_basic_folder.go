@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_auth"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cache_util"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"path/filepath"
 )
@@ -19,6 +22,10 @@ type LibraryUsecase interface {
 		newName string,
 		newFolderPath string) error
 	GetLibraries(ctx context.Context) ([]*domain_file_entity.LibraryFolderMetadata, error)
+	// UpdateLibraryAccess users 与 roles 均为空时对所有用户开放
+	UpdateLibraryAccess(ctx context.Context, id string, users, roles []string) error
+	// UpdateLibraryAudiobook 仅音乐媒体库可标记为有声书库
+	UpdateLibraryAudiobook(ctx context.Context, id string, audiobook bool) error
 }
 
 type libraryUsecase struct {
@@ -82,6 +89,60 @@ func (uc *libraryUsecase) UpdateLibrary(
 	return uc.folderRepo.Update(ctx, id, newName, newFolderPath)
 }
 
+// GetLibraries 受限用户只能看到可访问的音乐媒体库
 func (uc *libraryUsecase) GetLibraries(ctx context.Context) ([]*domain_file_entity.LibraryFolderMetadata, error) {
-	return uc.folderRepo.GetAll(ctx)
+	libraries, err := uc.folderRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	scope := domain.FolderScopeFromContext(ctx)
+	if scope == nil {
+		return libraries, nil
+	}
+	visible := make([]*domain_file_entity.LibraryFolderMetadata, 0, len(libraries))
+	for _, library := range libraries {
+		if library.FolderType != int(domain_file_entity.MusicLibrary) || scope.Allows(library.ID.Hex()) {
+			visible = append(visible, library)
+		}
+	}
+	return visible, nil
+}
+
+func (uc *libraryUsecase) UpdateLibraryAccess(ctx context.Context, id string, users, roles []string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid library ID")
+	}
+	for _, user := range users {
+		if _, err := primitive.ObjectIDFromHex(user); err != nil {
+			return fmt.Errorf("invalid user ID %q", user)
+		}
+	}
+	if len(roles) > 0 {
+		if roles, err = domain_auth.NormalizeRoles(roles); err != nil {
+			return err
+		}
+	}
+
+	if err := uc.folderRepo.UpdateAccess(ctx, objID, users, roles); err != nil {
+		return err
+	}
+	// 列表缓存按用户区分，可见范围变化后需要失效
+	cache_util.Invalidate(ctx, cache_util.NamespaceFilterCounts, cache_util.NamespaceLists)
+	return nil
+}
+
+func (uc *libraryUsecase) UpdateLibraryAudiobook(ctx context.Context, id string, audiobook bool) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid library ID")
+	}
+	library, err := uc.folderRepo.GetByID(ctx, objID)
+	if err != nil {
+		return err
+	}
+	if library.FolderType != int(domain_file_entity.MusicLibrary) {
+		return domain_file_entity.ErrLibraryNotMusic
+	}
+	return uc.folderRepo.UpdateAudiobook(ctx, objID, audiobook)
 }
@@ -0,0 +1,196 @@
+package usecase_file_entity
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity"
+)
+
+// BeetsUsecase 将 beets 导出的曲库转换为导入请求，沿用 IngestUsecase 的校验、写入与对账
+type BeetsUsecase struct {
+	ingestUc *IngestUsecase
+	pathMap  []beetsPathRule
+}
+
+type beetsPathRule struct {
+	from string
+	to   string
+}
+
+// NewBeetsUsecase pathMap 为逗号分隔的“beets 路径前缀=服务器路径前缀”
+func NewBeetsUsecase(ingestUc *IngestUsecase, pathMap string) *BeetsUsecase {
+	return &BeetsUsecase{
+		ingestUc: ingestUc,
+		pathMap:  parseBeetsPathMap(pathMap),
+	}
+}
+
+func parseBeetsPathMap(raw string) []beetsPathRule {
+	var rules []beetsPathRule
+	for _, pair := range strings.Split(raw, ",") {
+		from, to, ok := strings.Cut(pair, "=")
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if !ok || from == "" || to == "" {
+			continue
+		}
+		rules = append(rules, beetsPathRule{
+			from: strings.TrimSuffix(filepath.ToSlash(strings.ReplaceAll(from, `\`, "/")), "/"),
+			to:   strings.TrimSuffix(filepath.ToSlash(strings.ReplaceAll(to, `\`, "/")), "/"),
+		})
+	}
+	// 前缀最长的规则优先匹配
+	sort.SliceStable(rules, func(i, j int) bool { return len(rules[i].from) > len(rules[j].from) })
+	return rules
+}
+
+// Import 接收 `beet export` 的 JSON 数组或每行一个对象的 JSON Lines，分块导入后合并对账报告
+func (uc *BeetsUsecase) Import(ctx context.Context, body []byte) (*domain_file_entity.IngestReport, error) {
+	items, err := parseBeetsExport(body)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, domain_file_entity.ErrIngestEmpty
+	}
+	if len(items) > domain_file_entity.BeetsMaxItems {
+		return nil, domain_file_entity.ErrBeetsTooManyItems
+	}
+
+	tracks := make([]domain_file_entity.IngestTrack, len(items))
+	for i, item := range items {
+		tracks[i] = beetsToIngestTrack(item, uc.rewritePath(item.Path))
+	}
+
+	report := &domain_file_entity.IngestReport{Results: make([]domain_file_entity.IngestItemResult, 0, len(tracks))}
+	for start := 0; start < len(tracks); start += domain_file_entity.IngestMaxTracks {
+		end := min(start+domain_file_entity.IngestMaxTracks, len(tracks))
+		chunk, err := uc.ingestUc.Ingest(ctx, tracks[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("第%d-%d首导入失败: %w", start+1, end, err)
+		}
+		report.Results = append(report.Results, chunk.Results...)
+		report.Created += chunk.Created
+		report.Updated += chunk.Updated
+		report.Unchanged += chunk.Unchanged
+		report.Failed += chunk.Failed
+	}
+	return report, nil
+}
+
+func parseBeetsExport(body []byte) ([]domain_file_entity.BeetsItem, error) {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return nil, nil
+	}
+
+	var items []domain_file_entity.BeetsItem
+	if body[0] == '[' {
+		if err := json.Unmarshal(body, &items); err != nil {
+			return nil, fmt.Errorf("%w: %v", domain_file_entity.ErrBeetsInvalidExport, err)
+		}
+		return items, nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024) // 内嵌歌词的单行可能较长
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		var item domain_file_entity.BeetsItem
+		if err := json.Unmarshal(text, &item); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", domain_file_entity.ErrBeetsInvalidExport, line, err)
+		}
+		items = append(items, item)
+		if len(items) > domain_file_entity.BeetsMaxItems {
+			return nil, domain_file_entity.ErrBeetsTooManyItems
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain_file_entity.ErrBeetsInvalidExport, err)
+	}
+	return items, nil
+}
+
+// rewritePath 将 beets 机器上的路径映射到服务器路径，未命中规则时原样使用
+func (uc *BeetsUsecase) rewritePath(path string) string {
+	normalized := strings.ReplaceAll(strings.TrimSpace(path), `\`, "/")
+	for _, rule := range uc.pathMap {
+		if normalized == rule.from || strings.HasPrefix(normalized, rule.from+"/") {
+			return filepath.FromSlash(rule.to + normalized[len(rule.from):])
+		}
+	}
+	return filepath.FromSlash(normalized)
+}
+
+func beetsToIngestTrack(item domain_file_entity.BeetsItem, path string) domain_file_entity.IngestTrack {
+	genre := item.Genre
+	if genres := nonEmpty(item.Genres); len(genres) > 0 {
+		genre = strings.Join(genres, "; ")
+	}
+
+	var originalDate string
+	switch {
+	case item.OriginalYear > 0 && item.OriginalMonth > 0 && item.OriginalDay > 0:
+		originalDate = fmt.Sprintf("%04d-%02d-%02d", item.OriginalYear, item.OriginalMonth, item.OriginalDay)
+	case item.OriginalYear > 0:
+		originalDate = fmt.Sprintf("%04d", item.OriginalYear)
+	}
+
+	track := domain_file_entity.IngestTrack{
+		Path:              path,
+		Title:             item.Title,
+		Artist:            item.Artist,
+		AlbumArtist:       item.AlbumArtist,
+		Album:             item.Album,
+		Genre:             genre,
+		Composer:          item.Composer,
+		Comment:           item.Comments,
+		Lyrics:            item.Lyrics,
+		Year:              item.Year,
+		OriginalDate:      originalDate,
+		TrackNumber:       item.Track,
+		TrackTotal:        item.TrackTotal,
+		DiscNumber:        item.Disc,
+		DiscTotal:         item.DiscTotal,
+		ISRC:              item.ISRC,
+		Barcode:           item.Barcode,
+		Duration:          item.Length,
+		BitRate:           item.BitRate / 1000,
+		SampleRate:        item.SampleRate,
+		Channels:          item.Channels,
+		Compilation:       item.Comp,
+		MBZTrackID:        item.MBTrackID,
+		MBZReleaseTrackID: item.MBReleaseTrackID,
+		MBZAlbumID:        item.MBAlbumID,
+		MBZArtistID:       item.MBArtistID,
+		MBZAlbumArtistID:  item.MBAlbumArtistID,
+		MBZAlbumType:      item.AlbumType,
+		RGTrackGain:       item.RGTrackGain,
+		RGTrackPeak:       item.RGTrackPeak,
+		RGAlbumGain:       item.RGAlbumGain,
+		RGAlbumPeak:       item.RGAlbumPeak,
+	}
+	if label := strings.TrimSpace(item.Label); label != "" {
+		track.Tags = map[string]string{"LABEL": label}
+	}
+	return track
+}
+
+func nonEmpty(values []string) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
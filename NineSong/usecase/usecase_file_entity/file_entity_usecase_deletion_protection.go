@@ -0,0 +1,94 @@
+package usecase_file_entity
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+)
+
+// deletionProtection 读取当前删除保护规则，配置读取失败时使用默认规则
+func (uc *FileUsecase) deletionProtection(ctx context.Context) scene_audio_db_models.DeletionProtection {
+	values := make(map[string]string)
+	if uc.appConfigRepo != nil {
+		configs, err := uc.appConfigRepo.GetAll(ctx)
+		if err != nil && !errors.Is(err, domain.ErrEmptyCollection) {
+			log.Printf("删除保护配置读取失败，使用默认规则: %v", err)
+		}
+		for _, cfg := range configs {
+			values[cfg.ConfigKey] = cfg.ConfigValue
+		}
+	}
+	return scene_audio_db_models.ParseDeletionProtection(values)
+}
+
+// protectScanPaths 扫描清理会删除路径不在 validPaths 中的全部条目（itemType 为 "media" 或 "media_cue"）；
+// 按删除保护规则把受保护条目的路径并入，使其既不被删除也计入艺术家与专辑统计。
+// 待删除数量超过确认阈值时本次扫描不清理，需通过一致性修复确认后删除；查询失败时同样保留全部条目
+func (uc *FileUsecase) protectScanPaths(
+	ctx context.Context,
+	itemType string,
+	libraryFolders []*domain_file_entity.LibraryFolderMetadata,
+	validPaths []string,
+) []string {
+	if uc.protectionRepo == nil {
+		return validPaths
+	}
+	rules := uc.deletionProtection(ctx)
+	keepAll := func(reason string, args ...interface{}) []string {
+		log.Printf("扫描清理 %s 已跳过: "+reason, append([]interface{}{itemType}, args...)...)
+		all, err := uc.protectionRepo.GetPathsOutside(ctx, itemType, nil)
+		if err != nil {
+			// 连全部路径都无法读取时，删除也不会成功
+			log.Printf("扫描清理 %s 读取全部路径失败: %v", itemType, err)
+			return validPaths
+		}
+		return append(validPaths, all...)
+	}
+
+	keep := append([]string(nil), validPaths...)
+	if rules.LibraryOnly {
+		// 只清理本次扫描且根目录可访问的媒体库，外置存储未挂载时不会误删
+		var roots []string
+		for _, folder := range libraryFolders {
+			if _, err := os.Stat(folder.FolderPath); err != nil {
+				continue
+			}
+			root := strings.Replace(folder.FolderPath, "/", "\\", -1)
+			if !strings.HasSuffix(root, "\\") {
+				root += "\\"
+			}
+			roots = append(roots, root)
+		}
+		if len(roots) == 0 {
+			return keepAll("没有可访问的媒体库")
+		}
+		outside, err := uc.protectionRepo.GetPathsOutside(ctx, itemType, roots)
+		if err != nil {
+			return keepAll("读取媒体库外路径失败: %v", err)
+		}
+		keep = append(keep, outside...)
+	}
+	if rules.ProtectStarred {
+		starred, err := uc.protectionRepo.GetStarredPaths(ctx, itemType)
+		if err != nil {
+			return keepAll("读取收藏条目失败: %v", err)
+		}
+		keep = append(keep, starred...)
+	}
+	if rules.ConfirmThreshold > 0 {
+		invalid, err := uc.protectionRepo.CountInvalid(ctx, itemType, keep)
+		if err != nil {
+			return keepAll("统计待删除条目失败: %v", err)
+		}
+		if rules.RequiresConfirmation(int(invalid)) {
+			return keepAll("待删除 %d 条超过确认阈值 %d，请通过一致性修复确认后删除", invalid, rules.ConfirmThreshold)
+		}
+	}
+	return keep
+}
@@ -0,0 +1,291 @@
+package usecase_file_entity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_musicbrainz/scene_audio_musicbrainz_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_musicbrainz/scene_audio_musicbrainz_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cache_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/lock_util"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	enrichmentInterval     = time.Hour
+	enrichmentBatchSize    = 50
+	enrichmentRecheckAfter = 30 * 24 * time.Hour
+	// enrichmentMinScore MusicBrainz 搜索匹配度低于该值时不关联，避免同名条目误配
+	enrichmentMinScore = 90
+)
+
+type EnrichmentUsecase struct {
+	repo        scene_audio_db_interface.EnrichmentRepository
+	musicBrainz scene_audio_musicbrainz_interface.MusicBrainzClient
+	timeout     time.Duration
+}
+
+// NewEnrichmentUsecase 按标签搜索 MusicBrainz，为缺少 mbz_* 字段的专辑、艺术家与曲目补全 ID
+func NewEnrichmentUsecase(
+	repo scene_audio_db_interface.EnrichmentRepository,
+	musicBrainz scene_audio_musicbrainz_interface.MusicBrainzClient,
+	timeout time.Duration,
+) *EnrichmentUsecase {
+	return &EnrichmentUsecase{
+		repo:        repo,
+		musicBrainz: musicBrainz,
+		timeout:     timeout,
+	}
+}
+
+// Start 启动后台匹配协程，ctx 取消时退出
+func (uc *EnrichmentUsecase) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(enrichmentInterval)
+		defer ticker.Stop()
+		for {
+			if err := lock_util.RunExclusive(ctx, "enrichment", uc.runBatch); err != nil {
+				log.Printf("MusicBrainz 元数据匹配失败: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// runBatch 每轮处理一批待匹配的专辑与艺术家，MusicBrainz 客户端自身限速
+func (uc *EnrichmentUsecase) runBatch(ctx context.Context) error {
+	checkedBefore := time.Now().Add(-enrichmentRecheckAfter)
+
+	albums, err := uc.repo.GetPendingAlbums(ctx, checkedBefore, enrichmentBatchSize)
+	if err != nil {
+		return err
+	}
+	artists, err := uc.repo.GetPendingArtists(ctx, checkedBefore, enrichmentBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var linked int
+	for i := range albums {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		result, err := uc.enrichAlbum(ctx, &albums[i])
+		if err != nil {
+			log.Printf("专辑 %s 匹配失败: %v", albums[i].ID.Hex(), err)
+			continue
+		}
+		if result.MBID != "" {
+			linked++
+		}
+	}
+	for i := range artists {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		result, err := uc.enrichArtist(ctx, &artists[i])
+		if err != nil {
+			log.Printf("艺术家 %s 匹配失败: %v", artists[i].ID.Hex(), err)
+			continue
+		}
+		if result.MBID != "" {
+			linked++
+		}
+	}
+
+	if linked > 0 {
+		cache_util.Invalidate(ctx, cache_util.NamespaceFilterCounts, cache_util.NamespaceLists)
+		log.Printf("MusicBrainz 元数据匹配完成: 关联 %d 个条目", linked)
+	}
+	return nil
+}
+
+// Refresh 重新匹配单个条目，曲目ID会刷新其所属专辑；已有的 mbz_* 字段不作为匹配依据
+func (uc *EnrichmentUsecase) Refresh(ctx context.Context, id string) (*scene_audio_db_models.EnrichmentResult, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id: %s", id)
+	}
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	itemType, err := uc.repo.GetItemType(ctx, oid)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *scene_audio_db_models.EnrichmentResult
+	switch itemType {
+	case scene_audio_db_models.EnrichmentItemArtist:
+		artist, err := uc.repo.GetArtist(ctx, oid)
+		if err != nil {
+			return nil, err
+		}
+		artist.MBZArtistID = ""
+		if result, err = uc.enrichArtist(ctx, artist); err != nil {
+			return nil, err
+		}
+	default:
+		albumID := oid
+		if itemType == scene_audio_db_models.EnrichmentItemMedia {
+			if albumID, err = uc.repo.GetMediaAlbumID(ctx, oid); err != nil {
+				return nil, err
+			}
+		}
+		album, err := uc.repo.GetAlbum(ctx, albumID)
+		if err != nil {
+			return nil, err
+		}
+		album.MBZAlbumID = ""
+		if result, err = uc.enrichAlbum(ctx, album); err != nil {
+			return nil, err
+		}
+	}
+
+	if result.MBID != "" {
+		cache_util.Invalidate(ctx, cache_util.NamespaceFilterCounts, cache_util.NamespaceLists)
+	}
+	return result, nil
+}
+
+// enrichAlbum 搜索发行版并关联专辑，再按碟号与音轨号（其次按标题）关联其下曲目
+func (uc *EnrichmentUsecase) enrichAlbum(ctx context.Context, album *scene_audio_db_models.EnrichmentAlbum) (*scene_audio_db_models.EnrichmentResult, error) {
+	result := &scene_audio_db_models.EnrichmentResult{
+		ItemID:   album.ID.Hex(),
+		ItemType: scene_audio_db_models.EnrichmentItemAlbum,
+	}
+	if album.Name == "" {
+		return result, uc.repo.MarkChecked(ctx, result.ItemType, album.ID)
+	}
+
+	candidates, err := uc.musicBrainz.SearchReleases(ctx, album.Name, album.AlbumArtist)
+	if err != nil {
+		return nil, err
+	}
+	best := bestReleaseMatch(candidates, album.SongCount, album.ReleaseCountry)
+	if best == nil {
+		return result, uc.repo.MarkChecked(ctx, result.ItemType, album.ID)
+	}
+
+	release, err := uc.musicBrainz.GetRelease(ctx, best.ID)
+	if err != nil {
+		if errors.Is(err, scene_audio_musicbrainz_models.ErrNotFound) {
+			return result, uc.repo.MarkChecked(ctx, result.ItemType, album.ID)
+		}
+		return nil, err
+	}
+	if err := uc.repo.LinkAlbum(ctx, album.ID, release.ID, best.ArtistID, releaseEdition(album, release)); err != nil {
+		return nil, err
+	}
+	result.MBID = release.ID
+
+	tracks, err := uc.repo.GetAlbumTracks(ctx, album.ID)
+	if err != nil {
+		return nil, err
+	}
+	if result.TracksLinked, err = uc.repo.LinkTracks(ctx, release.ID, matchReleaseTracks(tracks, release.Tracks)); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (uc *EnrichmentUsecase) enrichArtist(ctx context.Context, artist *scene_audio_db_models.EnrichmentArtist) (*scene_audio_db_models.EnrichmentResult, error) {
+	result := &scene_audio_db_models.EnrichmentResult{
+		ItemID:   artist.ID.Hex(),
+		ItemType: scene_audio_db_models.EnrichmentItemArtist,
+	}
+	if artist.Name == "" {
+		return result, uc.repo.MarkChecked(ctx, result.ItemType, artist.ID)
+	}
+
+	candidates, err := uc.musicBrainz.SearchArtists(ctx, artist.Name)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range candidates {
+		if c.Score >= enrichmentMinScore && strings.EqualFold(strings.TrimSpace(c.Title), strings.TrimSpace(artist.Name)) {
+			if err := uc.repo.LinkArtist(ctx, artist.ID, c.ID); err != nil {
+				return nil, err
+			}
+			result.MBID = c.ID
+			return result, nil
+		}
+	}
+	return result, uc.repo.MarkChecked(ctx, result.ItemType, artist.ID)
+}
+
+// bestReleaseMatch 取匹配度达标的候选：本地已知发行国家时，国家与曲目数均一致的优先，其次曲目数一致，再次国家一致；
+// 同名专辑的日版与欧版曲目数常有差异，仅按曲目数取首个候选容易关联到其他版本
+func bestReleaseMatch(candidates []scene_audio_musicbrainz_models.MusicBrainzSearchResult, songCount int, country string) *scene_audio_musicbrainz_models.MusicBrainzSearchResult {
+	var best *scene_audio_musicbrainz_models.MusicBrainzSearchResult
+	bestRank := -1
+	for i := range candidates {
+		c := &candidates[i]
+		if c.Score < enrichmentMinScore {
+			continue
+		}
+		rank := 0
+		if songCount > 0 && c.TrackCount == songCount {
+			rank += 2
+		}
+		if country != "" && strings.EqualFold(c.Country, country) {
+			rank++
+		}
+		if rank > bestRank {
+			best, bestRank = c, rank
+		}
+	}
+	return best
+}
+
+// releaseEdition 发行版的国家、附注与目录编号，只补全专辑中为空的字段
+func releaseEdition(album *scene_audio_db_models.EnrichmentAlbum, release *scene_audio_musicbrainz_models.MusicBrainzRelease) scene_audio_db_models.EnrichmentAlbumEdition {
+	var edition scene_audio_db_models.EnrichmentAlbumEdition
+	if album.ReleaseCountry == "" {
+		edition.ReleaseCountry = strings.ToUpper(release.Country)
+	}
+	if album.MBZAlbumComment == "" {
+		edition.MBZAlbumComment = release.Disambiguation
+	}
+	if album.CatalogNum == "" {
+		edition.CatalogNum = release.CatalogNumber
+	}
+	return edition
+}
+
+// matchReleaseTracks 碟号与音轨号一致即视为同一曲目，缺少音轨号时退回标题比较
+func matchReleaseTracks(tracks []scene_audio_db_models.EnrichmentTrack, releaseTracks []scene_audio_musicbrainz_models.MusicBrainzTrack) []scene_audio_db_models.EnrichmentTrackMatch {
+	byPosition := make(map[[2]int]scene_audio_musicbrainz_models.MusicBrainzTrack, len(releaseTracks))
+	byTitle := make(map[string]scene_audio_musicbrainz_models.MusicBrainzTrack, len(releaseTracks))
+	for _, t := range releaseTracks {
+		byPosition[[2]int{max(t.DiscNumber, 1), t.TrackNumber}] = t
+		byTitle[strings.ToLower(strings.TrimSpace(t.Title))] = t
+	}
+
+	var matches []scene_audio_db_models.EnrichmentTrackMatch
+	for _, track := range tracks {
+		rt, ok := byPosition[[2]int{max(track.DiscNumber, 1), track.TrackNumber}]
+		if track.TrackNumber <= 0 || !ok {
+			rt, ok = byTitle[strings.ToLower(strings.TrimSpace(track.Title))]
+		}
+		if !ok {
+			continue
+		}
+		matches = append(matches, scene_audio_db_models.EnrichmentTrackMatch{
+			ID:             track.ID,
+			RecordingID:    rt.RecordingID,
+			ReleaseTrackID: rt.ID,
+		})
+	}
+	return matches
+}
@@ -0,0 +1,138 @@
+package usecase_file_entity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_acoustid/scene_audio_acoustid_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_db_usecase"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// fingerprintAutoMatchScore 匹配度不低于该值时直接写入录音ID，否则进入人工确认
+	fingerprintAutoMatchScore = 0.9
+	fingerprintMaxPageSize    = 500
+)
+
+// junkTitlePattern 抓轨软件生成的占位标题，如 "Track 01"、"未知标题"
+var junkTitlePattern = regexp.MustCompile(`(?i)^(track|audiotrack|untitled|unknown|未知|未知标题|音轨)[\s_-]*\d*$`)
+
+var junkArtists = map[string]bool{
+	"":               true,
+	"unknown":        true,
+	"unknown artist": true,
+	"未知":             true,
+	"未知艺术家":          true,
+}
+
+type FingerprintUsecase struct {
+	repo       scene_audio_db_interface.FingerprintRepository
+	acoustID   scene_audio_acoustid_interface.AcoustIDClient
+	fpcalcPath string
+	timeout    time.Duration
+}
+
+// NewFingerprintUsecase 为缺少有效标签的曲目计算 Chromaprint 声纹并通过 AcoustID 识别录音
+func NewFingerprintUsecase(
+	repo scene_audio_db_interface.FingerprintRepository,
+	acoustID scene_audio_acoustid_interface.AcoustIDClient,
+	fpcalcPath string,
+	timeout time.Duration,
+) *FingerprintUsecase {
+	return &FingerprintUsecase{
+		repo:       repo,
+		acoustID:   acoustID,
+		fpcalcPath: fpcalcPath,
+		timeout:    timeout,
+	}
+}
+
+// NeedsFingerprint 标题或艺术家缺失、或为占位内容时需要声纹识别
+func NeedsFingerprint(mediaFile *scene_audio_db_models.MediaFileMetadata) bool {
+	title := strings.TrimSpace(mediaFile.Title)
+	base := strings.TrimSuffix(filepath.Base(mediaFile.Path), filepath.Ext(mediaFile.Path))
+	if title == "" || strings.EqualFold(title, base) || junkTitlePattern.MatchString(title) {
+		return true
+	}
+	return junkArtists[strings.ToLower(strings.TrimSpace(mediaFile.Artist))]
+}
+
+// Identify 扫描时调用，已识别过的曲目不再重复查询；失败只记录日志，不影响入库
+func (uc *FingerprintUsecase) Identify(ctx context.Context, mediaFile *scene_audio_db_models.MediaFileMetadata) {
+	if mediaFile == nil || mediaFile.ID.IsZero() || !NeedsFingerprint(mediaFile) {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	done, err := uc.repo.IsFingerprinted(ctx, mediaFile.ID)
+	if err != nil || done {
+		return
+	}
+
+	fingerprint, duration, err := scene_audio_db_usecase.ComputeFingerprint(ctx, uc.fpcalcPath, mediaFile.Path)
+	if err != nil {
+		log.Printf("声纹计算失败: %s | %v", mediaFile.Path, err)
+		return
+	}
+	matches, err := uc.acoustID.Lookup(ctx, fingerprint, duration)
+	if err != nil {
+		log.Printf("AcoustID 查询失败: %s | %v", mediaFile.Path, err)
+		return
+	}
+
+	result := &scene_audio_db_models.FingerprintResult{
+		Fingerprint: fingerprint,
+		Duration:    duration,
+		Status:      scene_audio_db_models.FingerprintStatusUnmatched,
+		CheckedAt:   time.Now().UTC(),
+	}
+	if len(matches) > 0 {
+		best := matches[0]
+		result.AcoustID = best.AcoustID
+		result.RecordingID = best.RecordingID
+		result.Score = best.Score
+		result.Title = best.Title
+		result.Artist = best.Artist
+		result.Status = scene_audio_db_models.FingerprintStatusReview
+		if best.Score >= fingerprintAutoMatchScore {
+			result.Status = scene_audio_db_models.FingerprintStatusMatched
+		}
+	}
+	if err := uc.repo.SaveResult(ctx, mediaFile.ID, result); err != nil {
+		log.Printf("声纹结果保存失败: %s | %v", mediaFile.Path, err)
+	}
+}
+
+// ListReview 返回待确认的低匹配度识别结果
+func (uc *FingerprintUsecase) ListReview(ctx context.Context, start, end int) ([]scene_audio_db_models.FingerprintReviewItem, int64, error) {
+	if start < 0 || end <= start {
+		return nil, 0, errors.New("invalid pagination range")
+	}
+	if end-start > fingerprintMaxPageSize {
+		end = start + fingerprintMaxPageSize
+	}
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+	return uc.repo.GetReviewItems(ctx, int64(start), int64(end-start))
+}
+
+// Resolve 确认或否决候选录音；确认后标签仍需通过审核接口修改
+func (uc *FingerprintUsecase) Resolve(ctx context.Context, id string, accept bool) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid media file id: %s", id)
+	}
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+	return uc.repo.ResolveReview(ctx, oid, accept)
+}
@@ -0,0 +1,429 @@
+package usecase_file_entity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cache_util"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.senan.xyz/taglib"
+)
+
+type IngestUsecase struct {
+	fileUsecase *FileUsecase
+	folderRepo  domain_file_entity.FolderRepository
+}
+
+func NewIngestUsecase(fileUsecase *FileUsecase, folderRepo domain_file_entity.FolderRepository) *IngestUsecase {
+	return &IngestUsecase{
+		fileUsecase: fileUsecase,
+		folderRepo:  folderRepo,
+	}
+}
+
+// Ingest 校验并写入外部提交的曲目元数据；单曲失败不影响其他曲目，结果按提交顺序逐条返回
+func (uc *IngestUsecase) Ingest(
+	ctx context.Context,
+	tracks []domain_file_entity.IngestTrack,
+) (*domain_file_entity.IngestReport, error) {
+	if len(tracks) == 0 {
+		return nil, domain_file_entity.ErrIngestEmpty
+	}
+	if len(tracks) > domain_file_entity.IngestMaxTracks {
+		return nil, domain_file_entity.ErrIngestTooManyTracks
+	}
+
+	folders, err := uc.folderRepo.GetAllByType(ctx, int(domain_file_entity.MusicLibrary))
+	if err != nil {
+		return nil, fmt.Errorf("folder query failed: %w", err)
+	}
+
+	report := &domain_file_entity.IngestReport{Results: make([]domain_file_entity.IngestItemResult, len(tracks))}
+	valid := make([]ingestItem, 0, len(tracks))
+	for i, track := range tracks {
+		track.Path = filepath.Clean(strings.TrimSpace(track.Path))
+		report.Results[i].Path = track.Path
+		item, err := validateIngestTrack(track, folders)
+		if err != nil {
+			report.Results[i].Status = domain_file_entity.IngestStatusFailed
+			report.Results[i].Error = err.Error()
+			continue
+		}
+		item.index = i
+		valid = append(valid, item)
+	}
+
+	if len(valid) > 0 {
+		if err := uc.fileUsecase.ingestTracks(ctx, valid, report.Results); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, result := range report.Results {
+		switch result.Status {
+		case domain_file_entity.IngestStatusCreated:
+			report.Created++
+		case domain_file_entity.IngestStatusUpdated:
+			report.Updated++
+		case domain_file_entity.IngestStatusUnchanged:
+			report.Unchanged++
+		default:
+			report.Failed++
+		}
+	}
+	return report, nil
+}
+
+type ingestItem struct {
+	index  int
+	track  domain_file_entity.IngestTrack
+	folder *domain_file_entity.LibraryFolderMetadata
+	stat   os.FileInfo
+}
+
+func validateIngestTrack(
+	track domain_file_entity.IngestTrack,
+	folders []*domain_file_entity.LibraryFolderMetadata,
+) (ingestItem, error) {
+	if !filepath.IsAbs(track.Path) {
+		return ingestItem{}, errors.New("path must be absolute")
+	}
+	ext := strings.ToLower(filepath.Ext(track.Path))
+	if ext == ".cue" || !slices.Contains(domain_file_entity.AudioExtensions, ext) {
+		return ingestItem{}, errors.New("unsupported audio file type")
+	}
+	if strings.TrimSpace(track.Title) == "" {
+		return ingestItem{}, errors.New("title is required")
+	}
+	if track.Year < 0 || track.TrackNumber < 0 || track.TrackTotal < 0 || track.DiscNumber < 0 || track.DiscTotal < 0 ||
+		track.Duration < 0 || track.BitRate < 0 || track.SampleRate < 0 || track.Channels < 0 {
+		return ingestItem{}, errors.New("numeric fields cannot be negative")
+	}
+
+	folder := ingestLibraryOf(track.Path, folders)
+	if folder == nil {
+		return ingestItem{}, errors.New("path is not inside a music library")
+	}
+	stat, err := os.Stat(track.Path)
+	if err != nil || !stat.Mode().IsRegular() {
+		return ingestItem{}, errors.New("file not found")
+	}
+	return ingestItem{track: track, folder: folder, stat: stat}, nil
+}
+
+// ingestLibraryOf 取路径最长的匹配媒体库，与审核改标签时的定位规则一致
+func ingestLibraryOf(path string, folders []*domain_file_entity.LibraryFolderMetadata) *domain_file_entity.LibraryFolderMetadata {
+	normalized := strings.ToLower(filepath.ToSlash(path))
+	var match *domain_file_entity.LibraryFolderMetadata
+	for _, folder := range folders {
+		prefix := strings.TrimSuffix(strings.ToLower(filepath.ToSlash(folder.FolderPath)), "/") + "/"
+		if strings.HasPrefix(normalized, prefix) && (match == nil || len(folder.FolderPath) > len(match.FolderPath)) {
+			match = folder
+		}
+	}
+	return match
+}
+
+// ingestTracks 与定向扫描共用并发扫描槽，避免与全局扫描同时写入；
+// 文件记录按当前大小与修改时间保存，之后的增量扫描会跳过这些文件而保留导入的元数据
+func (uc *FileUsecase) ingestTracks(ctx context.Context, items []ingestItem, results []domain_file_entity.IngestItemResult) error {
+	taskID := fmt.Sprintf("ingest-%v", time.Now().UnixNano())
+	allowed, cancel := uc.scanManager.TryStartConcurrentScan(taskID)
+	if !allowed {
+		return errors.New("全局扫描任务运行中，无法导入")
+	}
+	ctx, cancelTask := context.WithCancel(ctx)
+	uc.scanManager.RegisterCancelFunc(taskID, cancelTask)
+	defer cancel()
+	defer cancelTask()
+
+	if aliases, err := uc.artistRepo.GetAliasMap(ctx); err != nil {
+		log.Printf("艺术家别名加载失败: %v", err)
+	} else {
+		uc.audioExtractor.SetArtistAliases(aliases)
+	}
+	uc.loadReviewSetting(ctx)
+
+	paths := make([]string, 0, len(items))
+	albumIDs := make(map[string]struct{})
+	for _, item := range items {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		result := &results[item.index]
+		mediaFile, previousAlbumID, status, changes, err := uc.ingestTrack(ctx, item)
+		if err != nil {
+			log.Printf("导入失败: %s | %v", item.track.Path, err)
+			result.Status = domain_file_entity.IngestStatusFailed
+			result.Error = err.Error()
+			continue
+		}
+		result.Status = status
+		result.MediaFileID = mediaFile.ID.Hex()
+		result.Changes = changes
+		paths = append(paths, item.track.Path)
+		for _, id := range []string{mediaFile.AlbumID, previousAlbumID} {
+			if id != "" {
+				albumIDs[id] = struct{}{}
+			}
+		}
+	}
+
+	uc.rebuildAlbums(ctx, albumIDs)
+	uc.recountArtistsOf(ctx, paths)
+	cache_util.Invalidate(ctx, cache_util.NamespaceFilterCounts, cache_util.NamespaceLists)
+	return nil
+}
+
+// ingestTrack 返回写入后的单曲与导入前所属的专辑ID，专辑变化时两张专辑都需重建统计
+func (uc *FileUsecase) ingestTrack(
+	ctx context.Context,
+	item ingestItem,
+) (*scene_audio_db_models.MediaFileMetadata, string, string, []string, error) {
+	existing, err := uc.mediaRepo.GetByPath(ctx, item.track.Path)
+	if err != nil {
+		return nil, "", "", nil, err
+	}
+	var previousAlbumID string
+	if existing != nil {
+		previousAlbumID = existing.AlbumID
+	}
+
+	fileMetadata, err := uc.fileRepo.FindByPath(ctx, item.track.Path)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, "", "", nil, fmt.Errorf("路径查询失败: %w", err)
+	}
+	now := time.Now().UTC()
+	if fileMetadata == nil {
+		fileMetadata = &domain_file_entity.FileMetadata{
+			ID:        primitive.NewObjectID(),
+			CreatedAt: now,
+		}
+	}
+	libraryPath := strings.Replace(item.folder.FolderPath, "/", "\\", -1)
+	if !strings.HasSuffix(libraryPath, "\\") {
+		libraryPath += "\\"
+	}
+	fileMetadata.FolderID = item.folder.ID
+	fileMetadata.FilePath = item.track.Path
+	fileMetadata.FileType = domain_file_entity.Audio
+	fileMetadata.FileName = filepath.Base(item.track.Path)
+	fileMetadata.LibraryPath = libraryPath
+	fileMetadata.Size = item.stat.Size()
+	fileMetadata.ModTime = item.stat.ModTime().UTC()
+	fileMetadata.UpdatedAt = now
+	if err := uc.fileRepo.Upsert(ctx, fileMetadata); err != nil {
+		return nil, "", "", nil, fmt.Errorf("文件写入失败: %w", err)
+	}
+
+	mediaFile, album, artists := uc.audioExtractor.BuildFromTags(fileMetadata, ingestTags(item.track), ingestProperties(item.track))
+	if mediaFile == nil {
+		return nil, "", "", nil, errors.New("metadata build failed")
+	}
+	if existing == nil && uc.reviewRequired.Load() {
+		mediaFile.ReviewStatus = scene_audio_db_models.ReviewStatusPending
+	}
+	applyIngestIdentifiers(item.track, mediaFile, album, artists)
+	if err := uc.saveIngestedHierarchy(ctx, artists, album, mediaFile); err != nil {
+		return nil, "", "", nil, err
+	}
+
+	if existing == nil {
+		return mediaFile, previousAlbumID, domain_file_entity.IngestStatusCreated, nil, nil
+	}
+	changes := ingestChanges(existing, mediaFile)
+	if len(changes) == 0 {
+		return mediaFile, previousAlbumID, domain_file_entity.IngestStatusUnchanged, nil, nil
+	}
+	return mediaFile, previousAlbumID, domain_file_entity.IngestStatusUpdated, changes, nil
+}
+
+// saveIngestedHierarchy 与 processAudioHierarchy 相同的写入顺序，但不累加专辑与艺术家计数：
+// 导入的单曲可能已计入统计，计数在整批写入后由 rebuildAlbums 与 recountArtistsOf 重新计算
+func (uc *FileUsecase) saveIngestedHierarchy(
+	ctx context.Context,
+	artists []*scene_audio_db_models.ArtistMetadata,
+	album *scene_audio_db_models.AlbumMetadata,
+	mediaFile *scene_audio_db_models.MediaFileMetadata,
+) error {
+	for _, artist := range artists {
+		if artist.Name == "" {
+			artist.Name = "Unknown"
+		}
+		if err := uc.updateAudioArtistMetadata(ctx, artist); err != nil {
+			return fmt.Errorf("艺术家处理失败 | 原因:%w", err)
+		}
+	}
+	if album != nil {
+		if album.Name == "" {
+			album.Name = "Unknown"
+		}
+		if err := uc.updateAudioAlbumMetadata(ctx, album); err != nil {
+			return fmt.Errorf("专辑处理失败 | 名称:%s | 原因:%w", album.Name, err)
+		}
+	}
+	if _, err := uc.mediaRepo.Upsert(ctx, mediaFile); err != nil {
+		return fmt.Errorf("歌曲写入失败 %s | %w", mediaFile.Path, err)
+	}
+	publishMediaAdded(ctx, mediaFile)
+	return nil
+}
+
+// rebuildAlbums 按现有单曲重算专辑统计；单曲已全部移出且没有 CUE 分轨的专辑直接删除
+func (uc *FileUsecase) rebuildAlbums(ctx context.Context, albumIDs map[string]struct{}) {
+	for strID := range albumIDs {
+		id, err := primitive.ObjectIDFromHex(strID)
+		if err != nil {
+			continue
+		}
+		count, err := uc.albumRepo.RebuildStatistics(ctx, id)
+		if err != nil {
+			log.Printf("专辑%s统计重建失败: %v", strID, err)
+			continue
+		}
+		if count > 0 {
+			continue
+		}
+		if cueCount, err := uc.mediaCueRepo.MediaCountByAlbum(ctx, strID); err != nil || cueCount > 0 {
+			continue
+		}
+		if err := uc.albumRepo.DeleteByID(ctx, id); err != nil {
+			log.Printf("空专辑%s删除失败: %v", strID, err)
+		}
+	}
+}
+
+// applyIngestIdentifiers 写入提交的 MusicBrainz 标识与合辑标记，艺术家按名称对应单曲艺术家或专辑艺术家
+func applyIngestIdentifiers(
+	track domain_file_entity.IngestTrack,
+	mediaFile *scene_audio_db_models.MediaFileMetadata,
+	album *scene_audio_db_models.AlbumMetadata,
+	artists []*scene_audio_db_models.ArtistMetadata,
+) {
+	setIfPresent := func(dst *string, value string) {
+		if value = strings.TrimSpace(value); value != "" {
+			*dst = value
+		}
+	}
+	setIfPresent(&mediaFile.MBZTrackID, track.MBZTrackID)
+	setIfPresent(&mediaFile.MBZReleaseTrackID, track.MBZReleaseTrackID)
+	setIfPresent(&mediaFile.MBZAlbumID, track.MBZAlbumID)
+	setIfPresent(&mediaFile.MBZArtistID, track.MBZArtistID)
+	setIfPresent(&mediaFile.MBZAlbumArtistID, track.MBZAlbumArtistID)
+	setIfPresent(&mediaFile.MBZAlbumType, track.MBZAlbumType)
+	if track.Compilation {
+		mediaFile.Compilation = true
+	}
+
+	if album != nil {
+		setIfPresent(&album.MBZAlbumID, track.MBZAlbumID)
+		setIfPresent(&album.MBZAlbumArtistID, track.MBZAlbumArtistID)
+		setIfPresent(&album.MBZAlbumType, track.MBZAlbumType)
+		if track.Compilation {
+			album.Compilation = true
+		}
+	}
+
+	for _, artist := range artists {
+		switch {
+		case artist.Name == mediaFile.Artist:
+			setIfPresent(&artist.MBZArtistID, track.MBZArtistID)
+		case artist.Name == mediaFile.AlbumArtist:
+			setIfPresent(&artist.MBZArtistID, track.MBZAlbumArtistID)
+		}
+	}
+}
+
+// ingestTags 转换为与文件标签相同的键值，交给扫描使用的同一套构建逻辑处理
+func ingestTags(track domain_file_entity.IngestTrack) map[string][]string {
+	tags := make(map[string][]string, len(track.Tags)+16)
+	for key, value := range track.Tags {
+		if value = strings.TrimSpace(value); value != "" {
+			tags[strings.ToUpper(key)] = []string{value}
+		}
+	}
+
+	set := func(key, value string) {
+		if value = strings.TrimSpace(value); value != "" {
+			tags[key] = []string{value}
+		}
+	}
+	setPair := func(key string, n, total int) {
+		switch {
+		case n > 0 && total > 0:
+			tags[key] = []string{fmt.Sprintf("%d/%d", n, total)}
+		case n > 0:
+			tags[key] = []string{strconv.Itoa(n)}
+		}
+	}
+	setGain := func(key string, value *float64) {
+		if value != nil {
+			tags[key] = []string{strconv.FormatFloat(*value, 'f', -1, 64)}
+		}
+	}
+
+	set(taglib.Title, track.Title)
+	set(taglib.Artist, track.Artist)
+	set(taglib.AlbumArtist, track.AlbumArtist)
+	set(taglib.Album, track.Album)
+	set(taglib.Genre, track.Genre)
+	set(taglib.Composer, track.Composer)
+	set(taglib.Comment, track.Comment)
+	set(taglib.Lyrics, track.Lyrics)
+	set(taglib.OriginalDate, track.OriginalDate)
+	set(taglib.ISRC, track.ISRC)
+	set(taglib.Barcode, track.Barcode)
+	if track.Year > 0 {
+		tags[taglib.Date] = []string{strconv.Itoa(track.Year)}
+	}
+	setPair(taglib.TrackNumber, track.TrackNumber, track.TrackTotal)
+	setPair(taglib.DiscNumber, track.DiscNumber, track.DiscTotal)
+	setGain("REPLAYGAIN_TRACK_GAIN", track.RGTrackGain)
+	setGain("REPLAYGAIN_TRACK_PEAK", track.RGTrackPeak)
+	setGain("REPLAYGAIN_ALBUM_GAIN", track.RGAlbumGain)
+	setGain("REPLAYGAIN_ALBUM_PEAK", track.RGAlbumPeak)
+	return tags
+}
+
+func ingestProperties(track domain_file_entity.IngestTrack) taglib.Properties {
+	return taglib.Properties{
+		Length:     time.Duration(track.Duration * float64(time.Second)),
+		Channels:   uint(track.Channels),
+		SampleRate: uint(track.SampleRate),
+		Bitrate:    uint(track.BitRate),
+	}
+}
+
+// ingestChanges 对账时比较的主要字段
+func ingestChanges(before, after *scene_audio_db_models.MediaFileMetadata) []string {
+	var changes []string
+	check := func(field string, changed bool) {
+		if changed {
+			changes = append(changes, field)
+		}
+	}
+	check("title", before.Title != after.Title)
+	check("artist", before.Artist != after.Artist)
+	check("album_artist", before.AlbumArtist != after.AlbumArtist)
+	check("album", before.Album != after.Album)
+	check("genre", before.Genre != after.Genre)
+	check("year", before.Year != after.Year)
+	check("original_date", before.OriginalDate != after.OriginalDate)
+	check("track_number", before.TrackNumber != after.TrackNumber)
+	check("disc_number", before.DiscNumber != after.DiscNumber)
+	check("composer", before.Composer != after.Composer)
+	check("isrc", before.ISRC != after.ISRC)
+	check("duration", before.Duration != after.Duration)
+	return changes
+}
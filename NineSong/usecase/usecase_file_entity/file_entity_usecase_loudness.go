@@ -0,0 +1,148 @@
+package usecase_file_entity
+
+import (
+	"context"
+	"log"
+	"math"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cache_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/lock_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_db_usecase"
+)
+
+const (
+	loudnessInterval     = time.Hour
+	loudnessBatchAlbums  = 20
+	loudnessBatchSingles = 100
+	loudnessTrackTimeout = 5 * time.Minute
+)
+
+type LoudnessUsecase struct {
+	repo scene_audio_db_interface.LoudnessRepository
+}
+
+// NewLoudnessUsecase 为标签中没有 ReplayGain 的曲目计算 EBU R128 响度并补全曲目与专辑增益
+func NewLoudnessUsecase(repo scene_audio_db_interface.LoudnessRepository) *LoudnessUsecase {
+	return &LoudnessUsecase{repo: repo}
+}
+
+// Start 启动后台分析协程，ctx 取消时退出
+func (uc *LoudnessUsecase) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(loudnessInterval)
+		defer ticker.Stop()
+		for {
+			if err := lock_util.RunExclusive(ctx, "loudness", uc.runBatch); err != nil {
+				log.Printf("响度分析失败: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (uc *LoudnessUsecase) runBatch(ctx context.Context) error {
+	albumIDs, err := uc.repo.GetPendingAlbumIDs(ctx, loudnessBatchAlbums)
+	if err != nil {
+		return err
+	}
+
+	var analyzed int
+	for _, albumID := range albumIDs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		n, err := uc.analyzeAlbum(ctx, albumID)
+		if err != nil {
+			log.Printf("专辑 %s 响度分析失败: %v", albumID, err)
+		}
+		analyzed += n
+	}
+
+	if analyzed > 0 {
+		cache_util.Invalidate(ctx, cache_util.NamespaceLists)
+		log.Printf("响度分析完成: %d 首曲目", analyzed)
+	}
+	return nil
+}
+
+// analyzeAlbum 分析专辑内尚未分析的曲目，全部曲目有结果后按时长加权的能量平均计算专辑响度
+func (uc *LoudnessUsecase) analyzeAlbum(ctx context.Context, albumID string) (int, error) {
+	tracks, err := uc.repo.GetAlbumTracks(ctx, albumID, loudnessBatchSingles)
+	if err != nil {
+		return 0, err
+	}
+
+	var analyzed int
+	for i := range tracks {
+		track := &tracks[i]
+		if track.Loudness != nil || track.Error != "" {
+			continue
+		}
+		if track.RGTrackGain != 0 && track.RGAlbumGain != 0 {
+			// 标签已完整提供 ReplayGain
+			continue
+		}
+		if ctx.Err() != nil {
+			return analyzed, ctx.Err()
+		}
+
+		trackCtx, cancel := context.WithTimeout(ctx, loudnessTrackTimeout)
+		loudness, peak, err := scene_audio_db_usecase.AnalyzeLoudness(trackCtx, track.Path)
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				return analyzed, ctx.Err()
+			}
+			log.Printf("曲目 %s 响度分析失败: %v", track.ID.Hex(), err)
+			if err := uc.repo.MarkFailed(ctx, track.ID, err.Error()); err != nil {
+				return analyzed, err
+			}
+			track.Error = err.Error()
+			continue
+		}
+
+		// 标签已提供曲目增益时只记录响度
+		if err := uc.repo.SaveTrackLoudness(ctx, track.ID, loudness, peak, track.RGTrackGain == 0); err != nil {
+			return analyzed, err
+		}
+		track.Loudness, track.TruePeak = &loudness, &peak
+		analyzed++
+	}
+
+	if albumID == "" {
+		return analyzed, nil
+	}
+
+	var energy, duration, albumPeak float64
+	for _, track := range tracks {
+		loudness, peak := track.Loudness, track.TruePeak
+		if loudness == nil && track.Error == "" && track.RGTrackGain != 0 {
+			// 未分析但带曲目增益标签的曲目按标签反推响度
+			derived := scene_audio_db_models.LoudnessReferenceLUFS - track.RGTrackGain
+			loudness, peak = &derived, &track.RGTrackPeak
+		}
+		if loudness == nil {
+			// 存在分析失败或尚未分析的曲目时专辑增益不准确，保持原值
+			return analyzed, nil
+		}
+		weight := math.Max(track.Duration, 1)
+		energy += weight * math.Pow(10, *loudness/10)
+		duration += weight
+		albumPeak = math.Max(albumPeak, *peak)
+	}
+	if duration == 0 {
+		return analyzed, nil
+	}
+	albumLoudness := 10 * math.Log10(energy/duration)
+	if _, err := uc.repo.SaveAlbumGain(ctx, albumID, scene_audio_db_models.ReplayGainFromLoudness(albumLoudness), albumPeak); err != nil {
+		return analyzed, err
+	}
+	return analyzed, nil
+}
@@ -0,0 +1,64 @@
+package usecase_file_entity
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/lyrics_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase/usecase_file_entity/scene_audio/scene_audio_db_usecase"
+)
+
+// processLyrics 保存曲目歌词：同名 .lrc 外挂文件优先，其次为标签中的内嵌歌词；均不存在时删除旧的本地歌词记录
+func (uc *FileUsecase) processLyrics(ctx context.Context, mediaFile *scene_audio_db_models.MediaFileMetadata) {
+	if uc.lyricsRepo == nil || mediaFile == nil || mediaFile.ID.IsZero() {
+		return
+	}
+	mediaID := mediaFile.ID.Hex()
+
+	lyrics := &scene_audio_db_models.MediaLyricsMetadata{MediaID: mediaID}
+	if path, text := readSidecarLyrics(mediaFile.Path); text != "" {
+		lyrics.Type = scene_audio_db_models.LyricsTypeSidecar
+		lyrics.Path = path
+		lyrics.Lyrics = text
+	} else if text := strings.TrimSpace(mediaFile.Lyrics); text != "" {
+		lyrics.Type = scene_audio_db_models.LyricsTypeEmbedded
+		lyrics.Lyrics = text
+	} else {
+		if err := uc.lyricsRepo.DeleteLocalByMediaID(ctx, mediaID); err != nil {
+			log.Printf("歌词清理失败: %s | %v", mediaFile.Path, err)
+		}
+		return
+	}
+
+	sum := md5.Sum([]byte(lyrics.Lyrics))
+	lyrics.Hash = hex.EncodeToString(sum[:])
+	lyrics.Synced = lyrics_util.IsSynced(lyrics.Lyrics)
+	if err := uc.lyricsRepo.Upsert(ctx, lyrics); err != nil {
+		log.Printf("歌词保存失败: %s | %v", mediaFile.Path, err)
+	}
+}
+
+// readSidecarLyrics 读取与音频同名的 .lrc 文件，非 UTF-8 内容按 GBK 解码
+func readSidecarLyrics(audioPath string) (string, string) {
+	base := strings.TrimSuffix(audioPath, filepath.Ext(audioPath))
+	for _, ext := range []string{".lrc", ".LRC"} {
+		path := base + ext
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		text := string(data)
+		if !utf8.Valid(data) {
+			text = scene_audio_db_usecase.UTF8ToGBK(text)
+		}
+		return path, strings.TrimSpace(text)
+	}
+	return "", ""
+}
@@ -0,0 +1,370 @@
+package usecase_file_entity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cache_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/lock_util"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type MaintenanceUsecase struct {
+	fileUsecase *FileUsecase
+	folderRepo  domain_file_entity.FolderRepository
+	repo        scene_audio_db_interface.MaintenanceRepository
+
+	mu         sync.RWMutex
+	lastReport *scene_audio_db_models.MaintenanceReport
+}
+
+// NewMaintenanceUsecase 清理孤立数据，与扫描共用 FileUsecase 的任务管理以保证互斥
+func NewMaintenanceUsecase(
+	fileUsecase *FileUsecase,
+	folderRepo domain_file_entity.FolderRepository,
+	repo scene_audio_db_interface.MaintenanceRepository,
+) *MaintenanceUsecase {
+	return &MaintenanceUsecase{
+		fileUsecase: fileUsecase,
+		folderRepo:  folderRepo,
+		repo:        repo,
+	}
+}
+
+// Start 在后台执行一次修复，dryRun 为 true 时只统计不删除，并将完整变更集保存为可审阅的报告；
+// confirmToken 为上次报告中给出的确认令牌，单步删除数超过确认阈值时必须提供。
+// 已有扫描或修复运行时返回 scene_audio_db_models.ErrMaintenanceBusy
+func (uc *MaintenanceUsecase) Start(dryRun bool, confirmToken string) (*scene_audio_db_models.MaintenanceReport, error) {
+	report := &scene_audio_db_models.MaintenanceReport{
+		Job:    scene_audio_db_models.MaintenanceJobRepair,
+		DryRun: dryRun,
+	}
+	if dryRun {
+		report.ID = primitive.NewObjectID()
+	}
+	return uc.launch(report, func() error { return nil }, func(ctx context.Context) {
+		uc.run(ctx, report, confirmToken, nil)
+	})
+}
+
+// Apply 按试运行报告执行删除：只删除报告中列出且当前仍满足清理条件的条目，审阅报告即视为确认，
+// 不再检查确认阈值。报告只能应用一次
+func (uc *MaintenanceUsecase) Apply(ctx context.Context, reportID string) (*scene_audio_db_models.MaintenanceReport, error) {
+	id, err := primitive.ObjectIDFromHex(reportID)
+	if err != nil {
+		return nil, fmt.Errorf("maintenance report %w", domain.ErrNotFound)
+	}
+	source, err := uc.repo.GetReport(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !source.DryRun {
+		return nil, scene_audio_db_models.ErrMaintenanceReportNotDryRun
+	}
+	if source.AppliedAt != nil {
+		return nil, scene_audio_db_models.ErrMaintenanceReportApplied
+	}
+
+	plan := make(maintenancePlan, len(source.Changes))
+	for _, change := range source.Changes {
+		plan[change.Step] = change.IDs
+	}
+	report := &scene_audio_db_models.MaintenanceReport{
+		Job:            source.Job,
+		SourceReportID: reportID,
+	}
+	// 取得任务锁后再标记已应用，避免任务忙时报告被占用
+	markApplied := func() error {
+		marked, err := uc.repo.MarkApplied(ctx, id, time.Now())
+		if err != nil {
+			return err
+		}
+		if !marked {
+			return scene_audio_db_models.ErrMaintenanceReportApplied
+		}
+		return nil
+	}
+	return uc.launch(report, markApplied, func(ctx context.Context) {
+		uc.run(ctx, report, "", plan)
+	})
+}
+
+// launch 取得扫描互斥与集群锁后在后台执行任务，before 失败时释放锁并返回其错误
+func (uc *MaintenanceUsecase) launch(
+	report *scene_audio_db_models.MaintenanceReport,
+	before func() error,
+	job func(context.Context),
+) (*scene_audio_db_models.MaintenanceReport, error) {
+	allowed, release := uc.fileUsecase.scanManager.TryStartMaintenance()
+	if !allowed {
+		return nil, scene_audio_db_models.ErrMaintenanceBusy
+	}
+	// 集群锁只与全局扫描互斥并阻止新的并发扫描，其他实例上已在运行的并发扫描无法感知
+	releaseLock, err := lock_util.Acquire(context.Background(), lock_util.KeyScanGlobal)
+	if err != nil {
+		release()
+		if errors.Is(err, lock_util.ErrLocked) {
+			return nil, scene_audio_db_models.ErrMaintenanceBusy
+		}
+		return nil, err
+	}
+	if err := before(); err != nil {
+		releaseLock()
+		release()
+		return nil, err
+	}
+
+	report.StartedAt = time.Now()
+	report.Running = true
+	uc.mu.Lock()
+	uc.lastReport = report
+	snapshot := *report
+	uc.mu.Unlock()
+
+	go func() {
+		defer release()
+		defer releaseLock()
+		job(context.Background())
+	}()
+	return &snapshot, nil
+}
+
+// Report 返回保存的试运行报告，包含完整变更集
+func (uc *MaintenanceUsecase) Report(ctx context.Context, reportID string) (*scene_audio_db_models.MaintenanceReport, error) {
+	id, err := primitive.ObjectIDFromHex(reportID)
+	if err != nil {
+		return nil, fmt.Errorf("maintenance report %w", domain.ErrNotFound)
+	}
+	return uc.repo.GetReport(ctx, id)
+}
+
+// Reports 返回未过期的试运行报告摘要
+func (uc *MaintenanceUsecase) Reports(ctx context.Context) ([]scene_audio_db_models.MaintenanceReport, error) {
+	return uc.repo.ListReports(ctx, scene_audio_db_models.MaintenanceReportListLimit)
+}
+
+// LastReport 返回最近一次修复的结果，运行中时为当前进度
+func (uc *MaintenanceUsecase) LastReport() *scene_audio_db_models.MaintenanceReport {
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+	if uc.lastReport == nil {
+		return nil
+	}
+	snapshot := *uc.lastReport
+	snapshot.SkippedLibraries = append([]string(nil), uc.lastReport.SkippedLibraries...)
+	snapshot.Errors = append([]string(nil), uc.lastReport.Errors...)
+	snapshot.Changes = append([]scene_audio_db_models.MaintenanceChange(nil), uc.lastReport.Changes...)
+	return &snapshot
+}
+
+// maintenancePlan 试运行报告中各步骤的待删除ID，应用报告时删除范围不超出该集合
+type maintenancePlan map[string][]primitive.ObjectID
+
+// run 依次清理缺失源文件的曲目、无曲目的专辑与艺术家、指向已删除条目的注解；单步失败记录后继续。
+// 已收藏条目按删除保护规则保留；某一步超过确认阈值且令牌不匹配时停止，后续步骤依赖前一步的删除结果。
+// plan 非空时只删除其中仍满足条件的条目；试运行时专辑与艺术家步骤看不到前面步骤的删除，
+// 因曲目删除而新变空的专辑与艺术家留待下一次清理
+func (uc *MaintenanceUsecase) run(ctx context.Context, report *scene_audio_db_models.MaintenanceReport, confirmToken string, plan maintenancePlan) {
+	update := func(apply func()) {
+		uc.mu.Lock()
+		apply()
+		uc.mu.Unlock()
+	}
+	fail := func(step string, err error) {
+		log.Printf("一致性修复失败 %s: %v", step, err)
+		update(func() { report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", step, err)) })
+	}
+
+	rules := uc.fileUsecase.deletionProtection(ctx)
+	missingPaths := make(map[primitive.ObjectID]string)
+	findMissing := func(ctx context.Context) ([]primitive.ObjectID, error) {
+		missing, skipped, err := uc.findMissingMedia(ctx)
+		update(func() { report.SkippedLibraries = skipped })
+		ids := make([]primitive.ObjectID, 0, len(missing))
+		for _, source := range missing {
+			ids = append(ids, source.ID)
+			missingPaths[source.ID] = source.Path
+		}
+		return ids, err
+	}
+
+	// starredType 为注解中的条目类型，为空时不做收藏保护
+	steps := []struct {
+		name        string
+		starredType string
+		find        func(context.Context) ([]primitive.ObjectID, error)
+		delete      func(context.Context, []primitive.ObjectID) (int64, error)
+		count       *int64
+	}{
+		{"media_files", "media", findMissing, uc.repo.DeleteMediaFiles, &report.MissingMediaFiles},
+		{"albums", "album", uc.repo.FindEmptyAlbums, uc.repo.DeleteAlbums, &report.EmptyAlbums},
+		{"artists", "artist", uc.repo.FindEmptyArtists, uc.repo.DeleteArtists, &report.EmptyArtists},
+		{"annotations", "", uc.repo.FindDanglingAnnotations, uc.repo.DeleteAnnotations, &report.DanglingAnnotations},
+	}
+	for _, step := range steps {
+		ids, err := step.find(ctx)
+		if err != nil {
+			fail(step.name, err)
+			continue
+		}
+		if rules.ProtectStarred && step.starredType != "" {
+			kept, protected, err := uc.dropStarred(ctx, step.starredType, ids)
+			if err != nil {
+				// 无法确认收藏状态时不删除该步骤的任何条目
+				fail(step.name, err)
+				continue
+			}
+			ids = kept
+			update(func() { report.ProtectedStarred += protected })
+		}
+		if plan != nil {
+			ids = intersectIDs(ids, plan[step.name])
+		} else if rules.RequiresConfirmation(len(ids)) {
+			token := scene_audio_db_models.DeletionConfirmToken(step.name, ids)
+			if token != confirmToken {
+				update(func() {
+					if report.Confirmation == nil {
+						report.Confirmation = &scene_audio_db_models.DeletionConfirmation{Step: step.name, Count: len(ids), Token: token}
+					}
+				})
+				if !report.DryRun {
+					break
+				}
+			}
+		}
+		if report.DryRun {
+			change := scene_audio_db_models.MaintenanceChange{Step: step.name, IDs: ids}
+			if step.name == "media_files" {
+				change.Paths = make([]string, 0, len(ids))
+				for _, id := range ids {
+					change.Paths = append(change.Paths, missingPaths[id])
+				}
+			}
+			update(func() { report.Changes = append(report.Changes, change) })
+		}
+		n, err := uc.remove(ctx, report.DryRun, ids, step.delete)
+		if err != nil {
+			fail(step.name, err)
+		}
+		update(func() { *step.count = n })
+	}
+
+	if !report.DryRun {
+		cache_util.Invalidate(ctx, cache_util.NamespaceFilterCounts, cache_util.NamespaceLists)
+	}
+	var saved scene_audio_db_models.MaintenanceReport
+	update(func() {
+		report.Running = false
+		report.FinishedAt = time.Now()
+		if report.DryRun {
+			report.ExpiresAt = report.FinishedAt.Add(scene_audio_db_models.MaintenanceReportRetention)
+		}
+		saved = *report
+	})
+	if saved.DryRun {
+		if err := uc.repo.SaveReport(ctx, &saved); err != nil {
+			fail("report", err)
+		}
+	}
+	log.Printf("一致性修复完成: 曲目 %d, 专辑 %d, 艺术家 %d, 注解 %d (dry_run=%v)",
+		report.MissingMediaFiles, report.EmptyAlbums, report.EmptyArtists, report.DanglingAnnotations, report.DryRun)
+}
+
+// dropStarred 去掉已收藏的条目，返回保留下来待删除的ID与被保护的数量
+func (uc *MaintenanceUsecase) dropStarred(ctx context.Context, itemType string, ids []primitive.ObjectID) ([]primitive.ObjectID, int64, error) {
+	if len(ids) == 0 || uc.fileUsecase.protectionRepo == nil {
+		return ids, 0, nil
+	}
+	starred, err := uc.fileUsecase.protectionRepo.FindStarred(ctx, itemType, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(starred) == 0 {
+		return ids, 0, nil
+	}
+	protected := make(map[primitive.ObjectID]bool, len(starred))
+	for _, id := range starred {
+		protected[id] = true
+	}
+	kept := make([]primitive.ObjectID, 0, len(ids)-len(starred))
+	for _, id := range ids {
+		if !protected[id] {
+			kept = append(kept, id)
+		}
+	}
+	return kept, int64(len(ids) - len(kept)), nil
+}
+
+func (uc *MaintenanceUsecase) remove(
+	ctx context.Context,
+	dryRun bool,
+	ids []primitive.ObjectID,
+	deleteFn func(context.Context, []primitive.ObjectID) (int64, error),
+) (int64, error) {
+	if dryRun || len(ids) == 0 {
+		return int64(len(ids)), nil
+	}
+	return deleteFn(ctx, ids)
+}
+
+// intersectIDs 保留 ids 中同时出现在 allowed 中的ID
+func intersectIDs(ids, allowed []primitive.ObjectID) []primitive.ObjectID {
+	set := make(map[primitive.ObjectID]bool, len(allowed))
+	for _, id := range allowed {
+		set[id] = true
+	}
+	kept := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if set[id] {
+			kept = append(kept, id)
+		}
+	}
+	return kept
+}
+
+// findMissingMedia 只在媒体库根目录可访问时检查其中的源文件；无法确认不存在的文件（如权限错误）不视为缺失
+func (uc *MaintenanceUsecase) findMissingMedia(ctx context.Context) ([]scene_audio_db_models.MediaSource, []string, error) {
+	folders, err := uc.folderRepo.GetAll(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		missing []scene_audio_db_models.MediaSource
+		skipped []string
+	)
+	for _, folder := range folders {
+		if folder.FolderType != int(domain_file_entity.MusicLibrary) {
+			continue
+		}
+		if _, err := os.Stat(folder.FolderPath); err != nil {
+			skipped = append(skipped, folder.FolderPath)
+			continue
+		}
+
+		libraryPath := strings.Replace(folder.FolderPath, "/", "\\", -1)
+		if !strings.HasSuffix(libraryPath, "\\") {
+			libraryPath += "\\"
+		}
+		sources, err := uc.repo.GetMediaSources(ctx, libraryPath)
+		if err != nil {
+			return nil, skipped, err
+		}
+		for _, source := range sources {
+			if _, err := os.Stat(source.Path); os.IsNotExist(err) {
+				missing = append(missing, source)
+			}
+		}
+	}
+	return missing, skipped, nil
+}
@@ -0,0 +1,108 @@
+package usecase_file_entity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 媒体库扫描模式，与 ProcessDirectory 的 ScanModel 对应
+const (
+	scanModelIncremental = 0
+	scanModelOverwrite   = 2
+	scanModelRemove      = 3
+)
+
+// MusicFolderUsecase 管理多个音乐媒体库根目录，增删与重扫均复用 FileUsecase 的扫描流程
+type MusicFolderUsecase struct {
+	fileUsecase *FileUsecase
+	folderRepo  domain_file_entity.FolderRepository
+}
+
+func NewMusicFolderUsecase(fileUsecase *FileUsecase, folderRepo domain_file_entity.FolderRepository) *MusicFolderUsecase {
+	return &MusicFolderUsecase{
+		fileUsecase: fileUsecase,
+		folderRepo:  folderRepo,
+	}
+}
+
+// AddFolder 登记新的音乐媒体库并在后台执行首次扫描
+func (uc *MusicFolderUsecase) AddFolder(ctx context.Context, name, path string) (*domain_file_entity.LibraryFolderMetadata, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("无法访问目录: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, errors.New("path is not a directory")
+	}
+	if name == "" {
+		name = filepath.Base(filepath.Clean(path))
+	}
+
+	folder := &domain_file_entity.LibraryFolderMetadata{
+		Name:       name,
+		FolderPath: path,
+		FolderType: int(domain_file_entity.MusicLibrary),
+	}
+	if err := uc.folderRepo.Create(ctx, folder); err != nil {
+		return nil, err
+	}
+	uc.scanInBackground(folder.FolderPath, scanModelIncremental)
+	return folder, nil
+}
+
+// RemoveFolder 在后台删除该媒体库及其曲目，并重建其余媒体库的专辑与艺术家
+func (uc *MusicFolderUsecase) RemoveFolder(ctx context.Context, id string) error {
+	folder, err := uc.musicFolder(ctx, id)
+	if err != nil {
+		return err
+	}
+	if folder.Status == domain_file_entity.StatusScanning {
+		return domain_file_entity.ErrLibraryInUse
+	}
+	uc.scanInBackground(folder.FolderPath, scanModelRemove)
+	return nil
+}
+
+// RescanFolder 仅扫描该媒体库，full 为 true 时覆盖全部元数据
+func (uc *MusicFolderUsecase) RescanFolder(ctx context.Context, id string, full bool) error {
+	folder, err := uc.musicFolder(ctx, id)
+	if err != nil {
+		return err
+	}
+	scanModel := scanModelIncremental
+	if full {
+		scanModel = scanModelOverwrite
+	}
+	uc.scanInBackground(folder.FolderPath, scanModel)
+	return nil
+}
+
+func (uc *MusicFolderUsecase) musicFolder(ctx context.Context, id string) (*domain_file_entity.LibraryFolderMetadata, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.New("invalid folder id format")
+	}
+	folder, err := uc.folderRepo.GetByID(ctx, objID)
+	if err != nil {
+		return nil, err
+	}
+	if folder.FolderType != int(domain_file_entity.MusicLibrary) {
+		return nil, domain_file_entity.ErrLibraryNotFound
+	}
+	return folder, nil
+}
+
+func (uc *MusicFolderUsecase) scanInBackground(path string, scanModel int) {
+	go func() {
+		if err := uc.fileUsecase.ProcessDirectory(context.Background(), []string{path}, int(domain_file_entity.MusicLibrary), scanModel); err != nil {
+			log.Printf("媒体库扫描失败 %s: %v", path, err)
+		}
+	}()
+}
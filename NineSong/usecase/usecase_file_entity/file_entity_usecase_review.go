@@ -0,0 +1,172 @@
+package usecase_file_entity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cache_util"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.senan.xyz/taglib"
+)
+
+const reviewMaxPageSize = 200
+
+type ReviewUsecase struct {
+	fileUsecase *FileUsecase
+	folderRepo  domain_file_entity.FolderRepository
+	mediaRepo   scene_audio_db_interface.MediaFileRepository
+}
+
+func NewReviewUsecase(
+	fileUsecase *FileUsecase,
+	folderRepo domain_file_entity.FolderRepository,
+	mediaRepo scene_audio_db_interface.MediaFileRepository,
+) *ReviewUsecase {
+	return &ReviewUsecase{
+		fileUsecase: fileUsecase,
+		folderRepo:  folderRepo,
+		mediaRepo:   mediaRepo,
+	}
+}
+
+// List 按入库时间列出指定审核状态的歌曲，start/end 为左闭右开区间
+func (uc *ReviewUsecase) List(
+	ctx context.Context,
+	status string,
+	start, end int,
+) ([]*scene_audio_db_models.MediaFileMetadata, int64, error) {
+	if status == "" {
+		status = scene_audio_db_models.ReviewStatusPending
+	}
+	if status != scene_audio_db_models.ReviewStatusPending && status != scene_audio_db_models.ReviewStatusRejected {
+		return nil, 0, fmt.Errorf("invalid review status: %s", status)
+	}
+	if start < 0 || end <= start {
+		return nil, 0, errors.New("invalid pagination range")
+	}
+	if end-start > reviewMaxPageSize {
+		end = start + reviewMaxPageSize
+	}
+	return uc.mediaRepo.GetByReviewStatus(ctx, status, int64(start), int64(end-start))
+}
+
+func (uc *ReviewUsecase) Approve(ctx context.Context, ids []string) (int64, error) {
+	objectIDs, err := parseReviewIDs(ids)
+	if err != nil {
+		return 0, err
+	}
+	updated, err := uc.mediaRepo.SetReviewStatus(ctx, objectIDs, "")
+	if err == nil {
+		cache_util.Invalidate(ctx, cache_util.NamespaceFilterCounts, cache_util.NamespaceLists)
+	}
+	return updated, err
+}
+
+func (uc *ReviewUsecase) Reject(ctx context.Context, ids []string) (int64, error) {
+	objectIDs, err := parseReviewIDs(ids)
+	if err != nil {
+		return 0, err
+	}
+	updated, err := uc.mediaRepo.SetReviewStatus(ctx, objectIDs, scene_audio_db_models.ReviewStatusRejected)
+	if err == nil {
+		cache_util.Invalidate(ctx, cache_util.NamespaceFilterCounts, cache_util.NamespaceLists)
+	}
+	return updated, err
+}
+
+// EditTags 将修改写回文件标签并重新扫描该文件，保证之后的全量扫描结果一致；审核状态保持不变
+func (uc *ReviewUsecase) EditTags(
+	ctx context.Context,
+	id string,
+	edits map[string]string,
+) (*scene_audio_db_models.MediaFileMetadata, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.New("invalid media file id")
+	}
+	if len(edits) == 0 {
+		return nil, errors.New("no tags to update")
+	}
+
+	tags := make(map[string][]string, len(edits))
+	for field, value := range edits {
+		tagName, ok := scene_audio_db_models.ReviewTagFields[field]
+		if !ok {
+			return nil, fmt.Errorf("tag %s cannot be edited", field)
+		}
+		value = strings.TrimSpace(value)
+		switch field {
+		case "year", "track_number", "disc_number":
+			if value != "" {
+				if _, err := strconv.Atoi(value); err != nil {
+					return nil, fmt.Errorf("tag %s must be a number", field)
+				}
+			}
+		}
+		tags[tagName] = []string{value}
+	}
+
+	mediaFile, err := uc.mediaRepo.GetByID(ctx, objectID)
+	if err != nil {
+		return nil, err
+	}
+	if mediaFile == nil {
+		return nil, errors.New("media file not found")
+	}
+
+	folder, err := uc.libraryOf(ctx, mediaFile.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := taglib.WriteTags(mediaFile.Path, tags, 0); err != nil {
+		return nil, fmt.Errorf("写入标签失败: %w", err)
+	}
+	if _, err := uc.fileUsecase.ProcessFiles(ctx, folder, []string{mediaFile.Path}); err != nil {
+		return nil, fmt.Errorf("重新扫描失败: %w", err)
+	}
+	return uc.mediaRepo.GetByPath(ctx, mediaFile.Path)
+}
+
+// libraryOf 查找包含该文件的媒体库（取路径最长的匹配项）
+func (uc *ReviewUsecase) libraryOf(ctx context.Context, path string) (*domain_file_entity.LibraryFolderMetadata, error) {
+	folders, err := uc.folderRepo.GetAllByType(ctx, int(domain_file_entity.MusicLibrary))
+	if err != nil {
+		return nil, fmt.Errorf("folder query failed: %w", err)
+	}
+
+	normalized := strings.ToLower(filepath.ToSlash(path))
+	var match *domain_file_entity.LibraryFolderMetadata
+	for _, folder := range folders {
+		prefix := strings.TrimSuffix(strings.ToLower(filepath.ToSlash(folder.FolderPath)), "/") + "/"
+		if strings.HasPrefix(normalized, prefix) && (match == nil || len(folder.FolderPath) > len(match.FolderPath)) {
+			match = folder
+		}
+	}
+	if match == nil {
+		return nil, errors.New("media library not found for file")
+	}
+	return match, nil
+}
+
+func parseReviewIDs(ids []string) ([]primitive.ObjectID, error) {
+	if len(ids) == 0 {
+		return nil, errors.New("media file ids are required")
+	}
+	objectIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		objectID, err := primitive.ObjectIDFromHex(strings.TrimSpace(id))
+		if err != nil {
+			return nil, fmt.Errorf("invalid media file id: %s", id)
+		}
+		objectIDs = append(objectIDs, objectID)
+	}
+	return objectIDs, nil
+}
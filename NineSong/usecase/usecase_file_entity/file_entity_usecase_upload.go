@@ -0,0 +1,273 @@
+package usecase_file_entity
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_interface"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type UploadUsecase struct {
+	fileUsecase *FileUsecase
+	folderRepo  domain_file_entity.FolderRepository
+	tempRepo    scene_audio_db_interface.TempRepository
+	detector    domain_file_entity.FileDetector
+
+	// 串行化同一用户的配额检查与写入
+	userLocks sync.Map
+}
+
+func NewUploadUsecase(
+	fileUsecase *FileUsecase,
+	folderRepo domain_file_entity.FolderRepository,
+	tempRepo scene_audio_db_interface.TempRepository,
+	detector domain_file_entity.FileDetector,
+) *UploadUsecase {
+	return &UploadUsecase{
+		fileUsecase: fileUsecase,
+		folderRepo:  folderRepo,
+		tempRepo:    tempRepo,
+		detector:    detector,
+	}
+}
+
+// Upload 将文件写入用户收件箱目录并定向扫描，返回每个文件对应的媒体ID
+func (uc *UploadUsecase) Upload(
+	ctx context.Context,
+	userID string,
+	files []domain_file_entity.UploadFile,
+) (*domain_file_entity.UploadResult, error) {
+	if userID == "" {
+		return nil, errors.New("user id is required")
+	}
+	if len(files) == 0 {
+		return nil, errors.New("no files to upload")
+	}
+	if len(files) > domain_file_entity.UploadMaxFiles {
+		return nil, fmt.Errorf("at most %d files per upload", domain_file_entity.UploadMaxFiles)
+	}
+
+	inboxRoot, err := uc.inboxRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	folder, err := uc.inboxLibrary(ctx, inboxRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	lock, _ := uc.userLocks.LoadOrStore(userID, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	userDir := filepath.Join(inboxRoot, filepath.Base(filepath.Clean(userID)))
+	if err := os.MkdirAll(userDir, 0755); err != nil {
+		return nil, fmt.Errorf("创建收件箱目录失败: %w", err)
+	}
+	used, err := dirSize(userDir)
+	if err != nil {
+		return nil, fmt.Errorf("统计收件箱占用失败: %w", err)
+	}
+
+	result := &domain_file_entity.UploadResult{
+		Files:      make([]domain_file_entity.UploadedFile, 0, len(files)),
+		QuotaLimit: domain_file_entity.UploadUserQuota,
+	}
+	var paths []string
+	for _, file := range files {
+		uploaded := domain_file_entity.UploadedFile{Name: file.Name, Size: file.Size}
+		path, written, err := uc.saveFile(userDir, file, domain_file_entity.UploadUserQuota-used)
+		if err != nil {
+			uploaded.Error = err.Error()
+			if errors.Is(err, domain_file_entity.ErrUploadQuotaExceeded) {
+				uploaded.ErrorCode = "QUOTA_EXCEEDED"
+			}
+		} else {
+			used += written
+			uploaded.Path = path
+			uploaded.Size = written
+			paths = append(paths, path)
+		}
+		result.Files = append(result.Files, uploaded)
+	}
+	result.QuotaUsed = used
+
+	if len(paths) > 0 {
+		mediaIDs, err := uc.fileUsecase.ProcessFiles(ctx, folder, paths)
+		if err != nil {
+			log.Printf("上传文件扫描失败: %v", err)
+		}
+		for i := range result.Files {
+			f := &result.Files[i]
+			if f.Error != "" {
+				continue
+			}
+			if id, ok := mediaIDs[f.Path]; ok {
+				f.MediaFileID = id
+			} else {
+				f.Error = "file saved but metadata scan failed"
+			}
+		}
+	}
+
+	for _, f := range result.Files {
+		if f.MediaFileID != "" {
+			result.Succeeded++
+		} else {
+			result.Failed++
+		}
+	}
+	return result, nil
+}
+
+// saveFile 校验并写入单个文件，先写临时文件再重命名，避免扫描读到半成品
+func (uc *UploadUsecase) saveFile(userDir string, file domain_file_entity.UploadFile, remaining int64) (string, int64, error) {
+	name := filepath.Base(filepath.Clean(strings.ReplaceAll(file.Name, "\\", "/")))
+	if name == "." || name == "/" || name == ".." || strings.HasPrefix(name, ".") {
+		return "", 0, errors.New("invalid file name")
+	}
+
+	fileType, err := uc.detector.DetectMediaType(name)
+	if err != nil || fileType != domain_file_entity.Audio || strings.EqualFold(filepath.Ext(name), ".cue") {
+		return "", 0, domain_file_entity.ErrUploadUnsupported
+	}
+	if file.Size > domain_file_entity.UploadMaxFileSize {
+		return "", 0, domain_file_entity.ErrUploadTooLarge
+	}
+	if file.Size > remaining {
+		return "", 0, domain_file_entity.ErrUploadQuotaExceeded
+	}
+
+	// 按内容嗅探拒绝伪装成音频扩展名的文本文件
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file.Reader, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", 0, fmt.Errorf("读取上传文件失败: %w", err)
+	}
+	head = head[:n]
+	if strings.HasPrefix(http.DetectContentType(head), "text/") {
+		return "", 0, domain_file_entity.ErrUploadUnsupported
+	}
+
+	target := uniquePath(filepath.Join(userDir, name))
+	partial := target + domain_file_entity.UploadPartSuffix
+	out, err := os.Create(partial)
+	if err != nil {
+		return "", 0, fmt.Errorf("创建文件失败: %w", err)
+	}
+
+	limit := remaining
+	if limit > domain_file_entity.UploadMaxFileSize {
+		limit = domain_file_entity.UploadMaxFileSize
+	}
+	reader := io.MultiReader(bytes.NewReader(head), file.Reader)
+	written, copyErr := io.Copy(out, io.LimitReader(reader, limit+1))
+	closeErr := out.Close()
+	switch {
+	case copyErr != nil:
+		err = fmt.Errorf("写入文件失败: %w", copyErr)
+	case closeErr != nil:
+		err = fmt.Errorf("写入文件失败: %w", closeErr)
+	case written > limit && limit == domain_file_entity.UploadMaxFileSize:
+		err = domain_file_entity.ErrUploadTooLarge
+	case written > limit:
+		err = domain_file_entity.ErrUploadQuotaExceeded
+	}
+	if err != nil {
+		_ = os.Remove(partial)
+		return "", 0, err
+	}
+
+	if err := os.Rename(partial, target); err != nil {
+		_ = os.Remove(partial)
+		return "", 0, fmt.Errorf("保存文件失败: %w", err)
+	}
+	return target, written, nil
+}
+
+// inboxRoot 读取收件箱根目录，未配置时在封面目录同级创建 Upload 目录并记录
+func (uc *UploadUsecase) inboxRoot(ctx context.Context) (string, error) {
+	if path, err := uc.tempRepo.GetTempPath(ctx, domain_file_entity.UploadTempMetadataType); err == nil {
+		return path, nil
+	}
+
+	coverPath, err := uc.tempRepo.GetTempPath(ctx, "cover")
+	if err != nil {
+		return "", domain_file_entity.ErrUploadInboxNotConfigured
+	}
+	path := filepath.Join(filepath.Dir(coverPath), "Upload")
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return "", fmt.Errorf("创建收件箱目录失败: %w", err)
+	}
+	if _, err := uc.tempRepo.UpdateTempPath(ctx, domain_file_entity.UploadTempMetadataType, path); err != nil {
+		return "", fmt.Errorf("保存收件箱路径失败: %w", err)
+	}
+	return path, nil
+}
+
+// inboxLibrary 收件箱根目录作为一个音乐媒体库登记，便于后续全量扫描覆盖
+func (uc *UploadUsecase) inboxLibrary(ctx context.Context, inboxRoot string) (*domain_file_entity.LibraryFolderMetadata, error) {
+	folder, err := uc.folderRepo.FindLibrary(ctx, inboxRoot, int(domain_file_entity.MusicLibrary))
+	if err != nil {
+		return nil, fmt.Errorf("folder query failed: %w", err)
+	}
+	if folder != nil {
+		return folder, nil
+	}
+
+	now := time.Now()
+	folder = &domain_file_entity.LibraryFolderMetadata{
+		ID:          primitive.NewObjectID(),
+		Name:        "Upload",
+		FolderPath:  inboxRoot,
+		FolderType:  int(domain_file_entity.MusicLibrary),
+		Status:      domain_file_entity.StatusActive,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		LastScanned: now,
+	}
+	if err := uc.folderRepo.Insert(ctx, folder); err != nil {
+		return nil, fmt.Errorf("folder creation failed: %w", err)
+	}
+	return folder, nil
+}
+
+func uniquePath(path string) string {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return path
+	}
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	for i := 1; ; i++ {
+		candidate := fmt.Sprintf("%s (%d)%s", base, i, ext)
+		if _, err := os.Stat(candidate); os.IsNotExist(err) {
+			return candidate
+		}
+	}
+}
+
+func dirSize(root string) (int64, error) {
+	var size int64
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
@@ -0,0 +1,115 @@
+package scene_audio_acoustid_usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_acoustid/scene_audio_acoustid_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_acoustid/scene_audio_acoustid_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/http_util"
+)
+
+const (
+	acoustIDLookupURL = "https://api.acoustid.org/v2/lookup"
+	// AcoustID 要求每秒不超过三次请求，频率按来源IP计算
+	acoustIDInterval = time.Second / 3
+	// 同一声纹的匹配结果在重复扫描间基本不变
+	acoustIDCacheTTL = 24 * time.Hour
+)
+
+type acoustIDUsecase struct {
+	client *http_util.Client
+	apiKey string
+}
+
+// NewAcoustIDUsecase apiKey 为在 acoustid.org 注册的应用密钥
+func NewAcoustIDUsecase(apiKey string, timeout time.Duration) scene_audio_acoustid_interface.AcoustIDClient {
+	return &acoustIDUsecase{
+		client: http_util.NewClient(http_util.Provider{
+			Name:       "acoustid",
+			Interval:   acoustIDInterval,
+			CacheTTL:   acoustIDCacheTTL,
+			MaxRetries: 2,
+		}, timeout),
+		apiKey: apiKey,
+	}
+}
+
+type acoustIDResponse struct {
+	Status string `json:"status"`
+	Error  struct {
+		Message string `json:"message"`
+	} `json:"error"`
+	Results []struct {
+		ID         string  `json:"id"`
+		Score      float64 `json:"score"`
+		Recordings []struct {
+			ID      string `json:"id"`
+			Title   string `json:"title"`
+			Artists []struct {
+				Name string `json:"name"`
+			} `json:"artists"`
+		} `json:"recordings"`
+	} `json:"results"`
+}
+
+func (uc *acoustIDUsecase) Lookup(
+	ctx context.Context,
+	fingerprint string,
+	duration int,
+) ([]scene_audio_acoustid_models.AcoustIDMatch, error) {
+	// 声纹较长，按表单提交
+	form := url.Values{}
+	form.Set("client", uc.apiKey)
+	form.Set("meta", "recordings")
+	form.Set("duration", strconv.Itoa(duration))
+	form.Set("fingerprint", fingerprint)
+
+	res, err := uc.client.Do(ctx, http_util.Request{
+		Method: http.MethodPost,
+		URL:    acoustIDLookupURL,
+		Header: http.Header{
+			"Content-Type": {"application/x-www-form-urlencoded"},
+			"Accept":       {"application/json"},
+		},
+		Body: []byte(form.Encode()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp acoustIDResponse
+	if err := json.Unmarshal(res.Body, &resp); err != nil {
+		return nil, fmt.Errorf("解析acoustid响应失败: %w", err)
+	}
+	if res.StatusCode != http.StatusOK || resp.Status != "ok" {
+		return nil, fmt.Errorf("acoustid返回错误 %d: %s", res.StatusCode, resp.Error.Message)
+	}
+
+	var matches []scene_audio_acoustid_models.AcoustIDMatch
+	for _, r := range resp.Results {
+		for _, rec := range r.Recordings {
+			match := scene_audio_acoustid_models.AcoustIDMatch{
+				AcoustID:    r.ID,
+				Score:       r.Score,
+				RecordingID: rec.ID,
+				Title:       rec.Title,
+			}
+			names := make([]string, 0, len(rec.Artists))
+			for _, a := range rec.Artists {
+				names = append(names, a.Name)
+			}
+			match.Artist = strings.Join(names, ", ")
+			matches = append(matches, match)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	return matches, nil
+}
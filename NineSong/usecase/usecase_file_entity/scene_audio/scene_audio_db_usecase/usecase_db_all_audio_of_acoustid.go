@@ -1 +1,37 @@
 package scene_audio_db_usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os/exec"
+)
+
+// ComputeFingerprint 调用 Chromaprint 的 fpcalc 计算音频声纹，返回声纹与时长（秒）
+func ComputeFingerprint(ctx context.Context, fpcalcPath, audioPath string) (string, int, error) {
+	if fpcalcPath == "" {
+		fpcalcPath = "fpcalc"
+	}
+	out, err := exec.CommandContext(ctx, fpcalcPath, "-json", audioPath).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", 0, fmt.Errorf("fpcalc失败: %s", exitErr.Stderr)
+		}
+		return "", 0, fmt.Errorf("fpcalc失败: %w", err)
+	}
+
+	var result struct {
+		Duration    float64 `json:"duration"`
+		Fingerprint string  `json:"fingerprint"`
+	}
+	if err := json.Unmarshal(out, &result); err != nil {
+		return "", 0, fmt.Errorf("解析fpcalc输出失败: %w", err)
+	}
+	if result.Fingerprint == "" {
+		return "", 0, errors.New("fpcalc未生成声纹")
+	}
+	return result.Fingerprint, int(math.Round(result.Duration)), nil
+}
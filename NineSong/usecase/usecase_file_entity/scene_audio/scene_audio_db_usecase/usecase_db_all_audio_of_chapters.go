@@ -0,0 +1,45 @@
+package scene_audio_db_usecase
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/tidwall/gjson"
+)
+
+// chapterFormats 可能带有内嵌章节的格式：MP4 章节轨道、ID3 CHAP 帧、Vorbis CHAPTERxxx 注释
+var chapterFormats = map[string]bool{
+	"m4a": true, "m4b": true, "mp4": true, "mp3": true,
+	"ogg": true, "opus": true, "flac": true,
+}
+
+// parseChapters 从 ffprobe 的 chapters 输出中读取章节，缺少标题时按序号命名；
+// 少于两个章节时视为无章节，避免把整段音频当作一章
+func parseChapters(metadataJson string) []scene_audio_db_models.MediaChapter {
+	if metadataJson == "" {
+		return nil
+	}
+	raw := gjson.Get(metadataJson, "chapters").Array()
+	if len(raw) < 2 {
+		return nil
+	}
+
+	chapters := make([]scene_audio_db_models.MediaChapter, 0, len(raw))
+	for i, chapter := range raw {
+		start := chapter.Get("start_time").Float()
+		end := chapter.Get("end_time").Float()
+		if end <= start {
+			continue
+		}
+		title := strings.TrimSpace(chapter.Get("tags.title").String())
+		if title == "" {
+			title = fmt.Sprintf("Chapter %d", i+1)
+		}
+		chapters = append(chapters, scene_audio_db_models.MediaChapter{Title: title, Start: start, End: end})
+	}
+	if len(chapters) < 2 {
+		return nil
+	}
+	return chapters
+}
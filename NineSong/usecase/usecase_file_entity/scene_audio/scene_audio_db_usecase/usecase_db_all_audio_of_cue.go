@@ -0,0 +1,42 @@
+package scene_audio_db_usecase
+
+import (
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+)
+
+// fillCueTrackExtended 为整轨中的每个音轨生成虚拟曲目元数据，播放时按起止位置截取整轨文件
+func fillCueTrackExtended(
+	track *scene_audio_db_models.CueTrack,
+	cue *scene_audio_db_models.MediaFileCueMetadata,
+	albumArtist string,
+	year int,
+) {
+	artist, artistID := track.Performer, track.PerformerID
+	if artist == "" {
+		artist, artistID = albumArtist, cue.PerformerID
+	}
+
+	track.Extended = scene_audio_db_models.MediaFileMetadata{
+		ID:          cue.ID,
+		Path:        cue.Path,
+		Suffix:      cue.Suffix,
+		Title:       track.Title,
+		Album:       cue.Title,
+		Artist:      artist,
+		ArtistID:    artistID,
+		AlbumArtist: albumArtist,
+		Genre:       cue.Rem.GENRE,
+		Year:        year,
+		TrackNumber: track.TRACK,
+		DiscNumber:  1,
+		HasCoverArt: cue.HasCoverArt,
+		SampleRate:  cue.CueSampleRate,
+		Duration:    float64(time.Duration(track.DurationSeconds * float64(time.Second))), // 与 taglib 曲目一致按纳秒存储
+		BitRate:     cue.CueBitRate,
+		Channels:    cue.CueChannels,
+		RGTrackGain: track.GAIN,
+		RGTrackPeak: track.PEAK,
+	}
+}
@@ -0,0 +1,74 @@
+package scene_audio_db_usecase
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// gaplessFormats 需要探测编码器延迟与精确采样数的格式
+var gaplessFormats = map[string]bool{
+	"mp3": true, "m4a": true, "m4b": true, "aac": true, "mp4": true,
+	"ogg": true, "opus": true, "flac": true,
+}
+
+// parseGaplessInfo 从 ffprobe 输出中读取编码器延迟、填充与精确采样数：
+// 优先使用 iTunes 的 iTunSMPB 标签，其次为音频流的 initial_padding / start_time 与 duration_ts
+func parseGaplessInfo(metadataJson string) (delay, padding int, samples int64) {
+	if metadataJson == "" {
+		return 0, 0, 0
+	}
+
+	var audioStream gjson.Result
+	for _, stream := range gjson.Get(metadataJson, "streams").Array() {
+		if stream.Get("codec_type").String() == "audio" {
+			audioStream = stream
+			break
+		}
+	}
+
+	smpb := gjson.Get(metadataJson, "format.tags.iTunSMPB").String()
+	if smpb == "" && audioStream.Exists() {
+		smpb = audioStream.Get("tags.iTunSMPB").String()
+	}
+	if d, p, n, ok := parseITunSMPB(smpb); ok {
+		return d, p, n
+	}
+	if !audioStream.Exists() {
+		return 0, 0, 0
+	}
+
+	sampleRate := audioStream.Get("sample_rate").Float()
+	if padding := audioStream.Get("initial_padding").Int(); padding > 0 {
+		delay = int(padding)
+	} else if start := audioStream.Get("start_time").Float(); start > 0 && sampleRate > 0 {
+		// MP3 的 LAME 头部延迟由 ffmpeg 换算为起始时间
+		delay = int(math.Round(start * sampleRate))
+	}
+
+	// 时间基为 1/采样率时 duration_ts 即采样数，否则按时长换算
+	if audioStream.Get("time_base").String() == "1/"+audioStream.Get("sample_rate").String() {
+		samples = audioStream.Get("duration_ts").Int()
+	}
+	if samples <= 0 && sampleRate > 0 {
+		samples = int64(math.Round(audioStream.Get("duration").Float() * sampleRate))
+	}
+	return delay, 0, samples
+}
+
+// parseITunSMPB 解析形如 " 00000000 00000840 000001CA 00000000003F31F6 ..." 的 iTunSMPB 值
+func parseITunSMPB(value string) (delay, padding int, samples int64, ok bool) {
+	fields := strings.Fields(value)
+	if len(fields) < 4 {
+		return 0, 0, 0, false
+	}
+	d, err1 := strconv.ParseInt(fields[1], 16, 64)
+	p, err2 := strconv.ParseInt(fields[2], 16, 64)
+	n, err3 := strconv.ParseInt(fields[3], 16, 64)
+	if err1 != nil || err2 != nil || err3 != nil || n <= 0 {
+		return 0, 0, 0, false
+	}
+	return int(d), int(p), n, true
+}
@@ -0,0 +1,62 @@
+package scene_audio_db_usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	ebur128IntegratedPattern = regexp.MustCompile(`I:\s+(-?[0-9.]+) LUFS`)
+	ebur128PeakPattern       = regexp.MustCompile(`Peak:\s+(-?[0-9.]+|-inf) dBFS`)
+)
+
+// AnalyzeLoudness 使用 FFmpeg 的 ebur128 滤镜测量 EBU R128 综合响度（LUFS）与真峰值（线性）
+func AnalyzeLoudness(ctx context.Context, audioPath string) (float64, float64, error) {
+	cmd := exec.CommandContext(ctx, "ffmpeg",
+		"-hide_banner", "-nostats",
+		"-i", audioPath,
+		"-map", "0:a:0",
+		"-af", "ebur128=peak=true",
+		"-f", "null", "-",
+	)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return 0, 0, ctx.Err()
+		}
+		return 0, 0, fmt.Errorf("ffmpeg响度分析失败: %w", err)
+	}
+
+	// 只解析最后的汇总段，之前的逐帧输出也包含 I: 与 Peak:
+	output := stderr.String()
+	idx := strings.LastIndex(output, "Summary:")
+	if idx < 0 {
+		return 0, 0, errors.New("ffmpeg未输出响度汇总")
+	}
+	summary := output[idx:]
+
+	m := ebur128IntegratedPattern.FindStringSubmatch(summary)
+	if m == nil {
+		return 0, 0, errors.New("无法解析综合响度")
+	}
+	integrated, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("无法解析综合响度: %w", err)
+	}
+
+	peak := 0.0
+	if m := ebur128PeakPattern.FindStringSubmatch(summary); m != nil && m[1] != "-inf" {
+		if dbfs, err := strconv.ParseFloat(m[1], 64); err == nil {
+			peak = math.Round(math.Pow(10, dbfs/20)*1e6) / 1e6
+		}
+	}
+	return integrated, peak, nil
+}
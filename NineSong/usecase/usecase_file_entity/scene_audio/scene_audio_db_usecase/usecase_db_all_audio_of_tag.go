@@ -141,6 +141,7 @@ func (e *AudioMetadataExtractorTag) buildMediaFile(
 		Path:      fm.FilePath,
 		Suffix:    strings.ToLower(strings.TrimPrefix(filepath.Ext(path), ".")),
 		Size:      int(fm.Size),
+		FolderID:  fm.FolderID.Hex(),
 
 		// 基础元数据 (github.com/dhowden/tag、go.senan.xyz/taglib)
 		Title:       m.Title(),
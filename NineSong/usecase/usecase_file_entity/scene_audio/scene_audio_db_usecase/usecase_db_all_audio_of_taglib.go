@@ -59,8 +59,9 @@ func (e *AudioMetadataExtractorTaglib) Extract(
 	}
 	properties, err = taglib.ReadProperties(path)
 
-	if readError != nil || suffix == "m4a" {
-		metadataJson, err := GetMediaMetadata(path)
+	var metadataJson string
+	if readError != nil || suffix == "m4a" || suffix == "m4b" {
+		metadataJson, err = GetMediaMetadata(path)
 		if err != nil {
 			metadataJson = ""
 		}
@@ -72,6 +73,7 @@ func (e *AudioMetadataExtractorTaglib) Extract(
 				taglib.Title:                     "title",
 				taglib.Comment:                   "comment",
 				taglib.Date:                      "date",
+				taglib.OriginalDate:              "originaldate",
 				taglib.Genre:                     "genre",
 				taglib.TrackNumber:               "track",
 				taglib.DiscNumber:                "disc",
@@ -104,6 +106,13 @@ func (e *AudioMetadataExtractorTaglib) Extract(
 				}
 			}
 
+			// 自定义标签键名大小写不固定，统一转为 taglib 的大写键
+			for key, value := range formatTags {
+				if isCustomTag(key) && len(value.String()) > 0 {
+					tags[strings.ToUpper(key)] = []string{value.String()}
+				}
+			}
+
 			// 特殊处理歌词字段（动态匹配lyrics-前缀）
 			for key, value := range formatTags {
 				if strings.HasPrefix(key, "lyrics-") {
@@ -191,6 +200,52 @@ func (e *AudioMetadataExtractorTaglib) Extract(
 		}
 	}
 
+	mediaFile, album, artist, mediaFileCue := e.buildHierarchy(tags, properties, fileMetadata, suffix, res)
+
+	if mediaFile != nil && (gaplessFormats[suffix] || chapterFormats[suffix]) {
+		if metadataJson == "" {
+			metadataJson, _ = GetMediaMetadata(path)
+		}
+		if gaplessFormats[suffix] {
+			mediaFile.EncoderDelay, mediaFile.EncoderPadding, mediaFile.TotalSamples = parseGaplessInfo(metadataJson)
+		}
+		mediaFile.Chapters = parseChapters(metadataJson)
+	}
+
+	if mediaFileCue != nil {
+		return nil, nil, artist, mediaFileCue, nil
+	}
+	return mediaFile, album, artist, nil, nil
+}
+
+// BuildFromTags 由外部提交的标签直接构建单曲、专辑与艺术家，不读取文件本身，供批量导入使用
+func (e *AudioMetadataExtractorTaglib) BuildFromTags(
+	fileMetadata *domain_file_entity.FileMetadata,
+	tags map[string][]string,
+	properties taglib.Properties,
+) (
+	*scene_audio_db_models.MediaFileMetadata,
+	*scene_audio_db_models.AlbumMetadata,
+	[]*scene_audio_db_models.ArtistMetadata,
+) {
+	suffix := strings.ToLower(strings.TrimPrefix(filepath.Ext(fileMetadata.FilePath), "."))
+	mediaFile, album, artist, _ := e.buildHierarchy(tags, properties, fileMetadata, suffix, nil)
+	return mediaFile, album, artist
+}
+
+// buildHierarchy 按标签生成单曲（或 CUE 分轨）、专辑与艺术家，艺术家与专辑ID由名称确定性生成
+func (e *AudioMetadataExtractorTaglib) buildHierarchy(
+	tags map[string][]string,
+	properties taglib.Properties,
+	fileMetadata *domain_file_entity.FileMetadata,
+	suffix string,
+	res *scene_audio_db_models.CueConfig,
+) (
+	*scene_audio_db_models.MediaFileMetadata,
+	*scene_audio_db_models.AlbumMetadata,
+	[]*scene_audio_db_models.ArtistMetadata,
+	*scene_audio_db_models.MediaFileCueMetadata,
+) {
 	if tags == nil {
 		tags = make(map[string][]string)
 	}
@@ -247,7 +302,7 @@ func (e *AudioMetadataExtractorTaglib) Extract(
 		albumArtistSortTag = e.getTagString(tags, taglib.AlbumArtistSort)
 		albumSortTag = e.getTagString(tags, taglib.AlbumSort)
 
-		if suffix == "m4a" {
+		if suffix == "m4a" || suffix == "m4b" {
 			if len(artistSortTag) > len(artistTag) {
 				artistTag = artistSortTag
 			}
@@ -260,7 +315,17 @@ func (e *AudioMetadataExtractorTaglib) Extract(
 		}
 	}
 
-	albumID = generateDeterministicID(artistTag + albumTag)
+	artistTag = e.resolveArtistAlias(artistTag)
+	albumArtistTag = e.resolveArtistAlias(albumArtistTag)
+
+	// 版本分组ID即不区分发行版时的专辑ID，开启按发行版拆分后同名专辑的各发行版仍可归为一组
+	versionGroupID := generateDeterministicID(artistTag + albumTag)
+	albumID = versionGroupID
+	if splitAlbumEditions {
+		if edition := e.getAlbumEditionKey(tags); edition != "" {
+			albumID = generateDeterministicID(artistTag + albumTag + "\x00" + edition)
+		}
+	}
 	artistID = generateDeterministicID(artistTag)
 	albumArtistID = generateDeterministicID(albumArtistTag)
 
@@ -277,7 +342,7 @@ func (e *AudioMetadataExtractorTaglib) Extract(
 		)
 
 	album = e.buildAlbum(
-		tags, now, artistID, albumID, albumArtistID,
+		tags, now, artistID, albumID, albumArtistID, versionGroupID,
 		compilationArtist,
 		formattedArtist, allArtistIDs,
 		formattedAlbumArtist, allAlbumArtistIDs,
@@ -315,10 +380,7 @@ func (e *AudioMetadataExtractorTaglib) Extract(
 		)
 	}
 
-	if mediaFileCue != nil {
-		return nil, nil, artist, mediaFileCue, nil
-	}
-	return mediaFile, album, artist, nil, nil
+	return mediaFile, album, artist, mediaFileCue
 }
 
 func (e *AudioMetadataExtractorTaglib) enrichFileMetadata(
@@ -391,6 +453,7 @@ func (e *AudioMetadataExtractorTaglib) buildMediaFileCue(
 		tags["Title"] = []string{title}
 	}
 	if performer, ok := globalMeta["PERFORMER"]; ok {
+		performer = e.resolveArtistAlias(performer)
 		albumArtistTag = performer
 		artistTag = performer
 	}
@@ -440,6 +503,7 @@ func (e *AudioMetadataExtractorTaglib) buildMediaFileCue(
 		LibraryPath: fileMetadata.LibraryPath,
 	}
 
+	scene_audio_db_models.ApplyCueTrackOffsets(tracks, properties.Length.Seconds())
 	mediaFileCue.CueTracks = tracks
 	mediaFileCue.CueTrackCount = len(tracks)
 
@@ -449,8 +513,8 @@ func (e *AudioMetadataExtractorTaglib) buildMediaFileCue(
 		DISCID:  globalMeta["DISCID"],
 		COMMENT: globalMeta["COMMENT"],
 	}
-	mediaFileCue.Performer = globalMeta["PERFORMER"]
-	mediaFileCue.PerformerID = generateDeterministicID(globalMeta["PERFORMER"]).Hex()
+	mediaFileCue.Performer = e.resolveArtistAlias(globalMeta["PERFORMER"])
+	mediaFileCue.PerformerID = generateDeterministicID(mediaFileCue.Performer).Hex()
 	mediaFileCue.Title = globalMeta["TITLE"]
 	mediaFileCue.File = scene_audio_db_models.CueFile{
 		FilePath: globalMeta["FILE"],
@@ -466,6 +530,11 @@ func (e *AudioMetadataExtractorTaglib) buildMediaFileCue(
 	mediaFileCue.Compilation = compilationArtist
 	mediaFileCue.AllArtistIDs = allArtistIDs
 
+	year, _ := strconv.Atoi(globalMeta["DATE"])
+	for i := range mediaFileCue.CueTracks {
+		fillCueTrackExtended(&mediaFileCue.CueTracks[i], mediaFileCue, formattedArtist, year)
+	}
+
 	return mediaFileCue, albumTag, formattedArtist, albumArtistTag, allArtistIDs
 }
 
@@ -527,6 +596,8 @@ func (e *AudioMetadataExtractorTaglib) buildMediaFile(
 	}
 	fullText := strings.Join(parts, " ")
 
+	originalDate, originalYear := e.getOriginalDate(tags)
+
 	titlePinyin := pinyin.LazyConvert(titleTag, nil)
 	albumPinyin := pinyin.LazyConvert(albumTag, nil)
 	artistPinyin := pinyin.LazyConvert(formattedArtist, nil)
@@ -543,6 +614,7 @@ func (e *AudioMetadataExtractorTaglib) buildMediaFile(
 			Size:        int(fileMetadata.Size),
 			FileName:    fileMetadata.FileName,
 			LibraryPath: fileMetadata.LibraryPath,
+			FolderID:    fileMetadata.FolderID.Hex(),
 
 			// 基础元数据 (github.com/dhowden/tag、go.senan.xyz/taglib)
 			Title:       titleTag,
@@ -555,16 +627,18 @@ func (e *AudioMetadataExtractorTaglib) buildMediaFile(
 			ArtistPinyin:      artistPinyin,
 			AlbumArtistPinyin: albumArtistPinyin,
 
-			Genre:       e.getTagString(tags, taglib.Genre),
-			Year:        e.getTagInt(tags, taglib.Date),
-			TrackNumber: currentTrack,
-			DiscNumber:  currentDisc,
-			TotalTracks: totalTracks,
-			TotalDiscs:  totalDiscs,
-			Composer:    e.getTagString(tags, taglib.Composer),
-			Comment:     e.getTagString(tags, taglib.Comment),
-			Lyrics:      e.getTagString(tags, taglib.Lyrics),
-			Compilation: compilationArtist,
+			Genre:        e.getTagString(tags, taglib.Genre),
+			Year:         e.getTagInt(tags, taglib.Date),
+			OriginalDate: originalDate,
+			OriginalYear: originalYear,
+			TrackNumber:  currentTrack,
+			DiscNumber:   currentDisc,
+			TotalTracks:  totalTracks,
+			TotalDiscs:   totalDiscs,
+			Composer:     e.getTagString(tags, taglib.Composer),
+			Comment:      e.getTagString(tags, taglib.Comment),
+			Lyrics:       e.getTagString(tags, taglib.Lyrics),
+			Compilation:  compilationArtist,
 
 			// 基础元数据: 关系ID索引
 			ArtistID:          artistID.Hex(),
@@ -594,6 +668,17 @@ func (e *AudioMetadataExtractorTaglib) buildMediaFile(
 			Channels:   int(properties.Channels),
 
 			EncodingFormat: e.getTagString(tags, "EncodingFormat"),
+
+			// ReplayGain (REPLAYGAIN_* 标签，值形如 "-6.54 dB")
+			RGAlbumGain: e.getTagFloat(tags, "REPLAYGAIN_ALBUM_GAIN"),
+			RGAlbumPeak: e.getTagFloat(tags, "REPLAYGAIN_ALBUM_PEAK"),
+			RGTrackGain: e.getTagFloat(tags, "REPLAYGAIN_TRACK_GAIN"),
+			RGTrackPeak: e.getTagFloat(tags, "REPLAYGAIN_TRACK_PEAK"),
+
+			// 版权与发行
+			ISRC: scene_audio_db_models.NormalizeISRC(e.getTagString(tags, taglib.ISRC)),
+
+			CustomTags: e.getCustomTags(tags),
 		},
 		compilationArtist,
 		formattedArtist, allArtistIDs,
@@ -604,13 +689,14 @@ func (e *AudioMetadataExtractorTaglib) buildMediaFile(
 func (e *AudioMetadataExtractorTaglib) buildAlbum(
 	tags map[string][]string,
 	now time.Time,
-	artistID, albumID, albumArtistID primitive.ObjectID,
+	artistID, albumID, albumArtistID, versionGroupID primitive.ObjectID,
 	compilationArtist bool,
 	formattedArtist string, allArtistIDs []scene_audio_db_models.ArtistIDPair,
 	formattedAlbumArtist string, allAlbumArtistIDs []scene_audio_db_models.ArtistIDPair,
 	albumPinyin, artistPinyin, albumArtistPinyin []string,
 ) *scene_audio_db_models.AlbumMetadata {
 	albumTag := e.getTagString(tags, taglib.Album)
+	originalDate, originalYear := e.getOriginalDate(tags)
 
 	return &scene_audio_db_models.AlbumMetadata{
 		// 系统保留字段 (综合)
@@ -632,7 +718,16 @@ func (e *AudioMetadataExtractorTaglib) buildAlbum(
 		Size:              0,
 		MinYear:           e.getTagInt(tags, taglib.Date),
 		MaxYear:           e.getTagInt(tags, taglib.Date),
+		OriginalDate:      originalDate,
+		OriginalYear:      originalYear,
 		Compilation:       compilationArtist,
+		Barcode:           e.getBarcode(tags),
+
+		// 发行版信息
+		MBZAlbumComment: e.getTagString(tags, "MUSICBRAINZ_ALBUMCOMMENT"),
+		CatalogNum:      e.getTagString(tags, taglib.CatalogNumber),
+		ReleaseCountry:  e.getReleaseCountry(tags),
+		VersionGroupID:  versionGroupID.Hex(),
 
 		// 关系ID索引
 		ArtistID:          artistID.Hex(),
@@ -646,6 +741,8 @@ func (e *AudioMetadataExtractorTaglib) buildAlbum(
 		SortAlbumArtistName:  e.getSortAlbumArtistName(formattedAlbumArtist),
 		OrderAlbumName:       e.getOrderAlbumName(albumTag),
 		OrderAlbumArtistName: e.getOrderAlbumArtistName(formattedAlbumArtist),
+
+		CustomTags: e.getCustomTags(tags),
 	}
 }
 
@@ -689,6 +786,54 @@ func (e *AudioMetadataExtractorTaglib) buildArtist(
 
 type AudioMetadataExtractorTaglib struct {
 	mediaID primitive.ObjectID
+
+	artistAliases map[string]string // 小写别名 -> 艺术家名称，扫描开始前加载，扫描期间只读
+}
+
+// customTags 需要提取的自定义标签名（小写），启动时配置，扫描期间只读
+var customTags []string
+
+// ConfigureCustomTags 设置扫描时额外提取的非标准标签，如 "vocalist,label,source"
+func ConfigureCustomTags(tags string) {
+	var names []string
+	for _, name := range strings.Split(tags, ",") {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			names = append(names, name)
+		}
+	}
+	customTags = names
+}
+
+// splitAlbumEditions 同一艺术家的同名专辑是否按发行版拆分为不同专辑，启动时配置，扫描期间只读
+var splitAlbumEditions bool
+
+// ConfigureAlbumEditions 开启后按 MusicBrainz 发行版ID或发行国家与目录编号拆分同名专辑；
+// 会改变已有专辑的ID，需重新扫描生效
+func ConfigureAlbumEditions(split bool) {
+	splitAlbumEditions = split
+}
+
+func isCustomTag(key string) bool {
+	key = strings.ToLower(key)
+	for _, name := range customTags {
+		if name == key {
+			return true
+		}
+	}
+	return false
+}
+
+// SetArtistAliases 设置扫描使用的艺术家别名映射
+func (e *AudioMetadataExtractorTaglib) SetArtistAliases(aliases map[string]string) {
+	e.artistAliases = aliases
+}
+
+// resolveArtistAlias 别名命中时返回归并后的艺术家名称，以保证生成的艺术家ID一致
+func (e *AudioMetadataExtractorTaglib) resolveArtistAlias(name string) string {
+	if canonical, ok := e.artistAliases[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return canonical
+	}
+	return name
 }
 
 func generateDeterministicID(seed string) primitive.ObjectID {
@@ -809,6 +954,20 @@ func (e *AudioMetadataExtractorTaglib) getTagString(tags map[string][]string, ke
 	return ""
 }
 
+// getCustomTags 提取 CUSTOM_TAGS 配置的非标准标签，键为小写标签名；均不存在时返回 nil
+func (e *AudioMetadataExtractorTaglib) getCustomTags(tags map[string][]string) map[string]string {
+	var custom map[string]string
+	for _, name := range customTags {
+		if value := e.getTagString(tags, strings.ToUpper(name)); value != "" {
+			if custom == nil {
+				custom = make(map[string]string)
+			}
+			custom[name] = value
+		}
+	}
+	return custom
+}
+
 func (e *AudioMetadataExtractorTaglib) getTagInt(tags map[string][]string, key string) int {
 	value := e.getTagString(tags, key)
 	if value != "" {
@@ -820,6 +979,63 @@ func (e *AudioMetadataExtractorTaglib) getTagInt(tags map[string][]string, key s
 	return 0
 }
 
+// getOriginalDate 读取原始发行日期（ID3v2.4 TDOR 由 taglib 映射为 ORIGINALDATE，部分标签器只写 ORIGINALYEAR），
+// 年份缺失时回退到 DATE，保证按原始年份排序时未标注的专辑仍有位置
+func (e *AudioMetadataExtractorTaglib) getOriginalDate(tags map[string][]string) (string, int) {
+	date := e.getTagString(tags, taglib.OriginalDate)
+	if date == "" {
+		date = e.getTagString(tags, "ORIGINALYEAR")
+	}
+	year := e.getTagInt(tags, taglib.OriginalDate)
+	if year == 0 {
+		year = e.getTagInt(tags, "ORIGINALYEAR")
+	}
+	if year == 0 {
+		year = e.getTagInt(tags, taglib.Date)
+	}
+	return date, year
+}
+
+// getBarcode 读取 BARCODE 标签，部分标签器写入 UPC 或 EAN
+func (e *AudioMetadataExtractorTaglib) getBarcode(tags map[string][]string) string {
+	for _, key := range []string{taglib.Barcode, "UPC", "EAN"} {
+		if barcode := scene_audio_db_models.NormalizeBarcode(e.getTagString(tags, key)); barcode != "" {
+			return barcode
+		}
+	}
+	return ""
+}
+
+// getReleaseCountry 读取 RELEASECOUNTRY 标签，统一为大写国家代码
+func (e *AudioMetadataExtractorTaglib) getReleaseCountry(tags map[string][]string) string {
+	return strings.ToUpper(e.getTagString(tags, taglib.ReleaseCountry))
+}
+
+// getAlbumEditionKey 区分同名专辑发行版的标识：优先 MusicBrainz 发行版ID，其次为发行国家与目录编号；
+// 均缺失时返回空，该曲目归入不区分发行版的专辑
+func (e *AudioMetadataExtractorTaglib) getAlbumEditionKey(tags map[string][]string) string {
+	if mbid := strings.ToLower(e.getTagString(tags, taglib.MusicBrainzAlbumID)); mbid != "" {
+		return mbid
+	}
+	country := e.getReleaseCountry(tags)
+	catalog := strings.ToUpper(strings.Join(strings.Fields(e.getTagString(tags, taglib.CatalogNumber)), ""))
+	if country == "" && catalog == "" {
+		return ""
+	}
+	return country + "|" + catalog
+}
+
+func (e *AudioMetadataExtractorTaglib) getTagFloat(tags map[string][]string, key string) float64 {
+	value := e.getTagString(tags, key)
+	if value != "" {
+		var result float64
+		if _, err := fmt.Sscanf(value, "%f", &result); err == nil {
+			return result
+		}
+	}
+	return 0
+}
+
 func (e *AudioMetadataExtractorTaglib) getTagIntPair(tags map[string][]string, key string) (int, int) {
 	value := e.getTagString(tags, key)
 	if value != "" {
@@ -1057,8 +1273,8 @@ func GetMediaMetadata(filePath string) (string, error) {
 		return "", fmt.Errorf("等待资源超时")
 	}
 
-	// 同步执行Probe获取原始元数据
-	data, err := ffmpeggo.Probe(filePath)
+	// 同步执行Probe获取原始元数据，同时输出内嵌章节
+	data, err := ffmpeggo.Probe(filePath, ffmpeggo.KwArgs{"show_chapters": ""})
 	if err != nil {
 		return "", fmt.Errorf("ffprobe执行失败: %w", err)
 	}
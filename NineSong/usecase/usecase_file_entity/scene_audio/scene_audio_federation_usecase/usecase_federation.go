@@ -0,0 +1,455 @@
+package scene_audio_federation_usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_federation/scene_audio_federation_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_federation/scene_audio_federation_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 远程条目中需要改写为命名空间ID的字段
+var remoteIDFields = []string{"ID", "AlbumID", "ArtistID", "AlbumArtistID"}
+
+// 远程条目中的艺术家列表字段，元素内的 ArtistID 同样需要改写
+var remoteArtistListFields = []string{"AllArtistIDs", "AllAlbumArtistIDs"}
+
+type FederationUsecase struct {
+	peerRepo   scene_audio_federation_interface.FederationPeerRepository
+	client     scene_audio_federation_interface.FederationClient
+	albums     scene_audio_route_interface.AlbumRepository
+	artists    scene_audio_route_interface.ArtistRepository
+	mediaFiles scene_audio_route_interface.MediaFileRepository
+	mediaRepo  scene_audio_db_interface.MediaFileRepository
+	publicURL  string
+	name       string
+	timeout    time.Duration
+}
+
+// NewFederationUsecase publicURL 为对方访问本实例的地址，为空时不能发起或接受关联
+func NewFederationUsecase(
+	peerRepo scene_audio_federation_interface.FederationPeerRepository,
+	client scene_audio_federation_interface.FederationClient,
+	albums scene_audio_route_interface.AlbumRepository,
+	artists scene_audio_route_interface.ArtistRepository,
+	mediaFiles scene_audio_route_interface.MediaFileRepository,
+	mediaRepo scene_audio_db_interface.MediaFileRepository,
+	publicURL, name string,
+	timeout time.Duration,
+) *FederationUsecase {
+	if name == "" {
+		name = "NineSong"
+	}
+	return &FederationUsecase{
+		peerRepo:   peerRepo,
+		client:     client,
+		albums:     albums,
+		artists:    artists,
+		mediaFiles: mediaFiles,
+		mediaRepo:  mediaRepo,
+		publicURL:  strings.TrimRight(publicURL, "/"),
+		name:       name,
+		timeout:    timeout,
+	}
+}
+
+// Name 本实例在联邦中的显示名称
+func (uc *FederationUsecase) Name() string {
+	return uc.name
+}
+
+// CreateInvite 生成待握手的关联，令牌只在此处返回一次，库中仅保存摘要
+func (uc *FederationUsecase) CreateInvite(
+	ctx context.Context,
+	name string,
+	allowStream bool,
+	streamKbps int,
+) (*scene_audio_federation_models.FederationInvite, error) {
+	if uc.publicURL == "" {
+		return nil, scene_audio_federation_models.ErrFederationNotConfigured
+	}
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+	peer := &scene_audio_federation_models.FederationPeer{
+		ID:               primitive.NewObjectID(),
+		Name:             name,
+		Status:           scene_audio_federation_models.PeerStatusPending,
+		InboundTokenHash: hashToken(token),
+		AllowStream:      allowStream,
+		StreamKbps:       normalizeKbps(streamKbps),
+	}
+	if err := uc.peerRepo.Insert(ctx, peer); err != nil {
+		return nil, err
+	}
+	return &scene_audio_federation_models.FederationInvite{
+		PeerID:  peer.ID.Hex(),
+		BaseURL: uc.publicURL,
+		Token:   token,
+	}, nil
+}
+
+// Link 使用对方的邀请完成握手，双方各自保存对方的访问令牌
+func (uc *FederationUsecase) Link(
+	ctx context.Context,
+	name, baseURL, inviteToken string,
+	allowStream bool,
+	streamKbps int,
+) (*scene_audio_federation_models.FederationPeer, error) {
+	if uc.publicURL == "" {
+		return nil, scene_audio_federation_models.ErrFederationNotConfigured
+	}
+	if err := validateBaseURL(baseURL); err != nil {
+		return nil, err
+	}
+	if inviteToken == "" {
+		return nil, scene_audio_federation_models.ErrInvalidInvite
+	}
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.client.Handshake(ctx, baseURL, scene_audio_federation_models.FederationHandshake{
+		InviteToken: inviteToken,
+		Token:       token,
+		Name:        uc.name,
+		BaseURL:     uc.publicURL,
+	}); err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = baseURL
+	}
+	peer := &scene_audio_federation_models.FederationPeer{
+		ID:               primitive.NewObjectID(),
+		Name:             name,
+		BaseURL:          strings.TrimRight(baseURL, "/"),
+		Status:           scene_audio_federation_models.PeerStatusActive,
+		OutboundToken:    inviteToken,
+		InboundTokenHash: hashToken(token),
+		AllowStream:      allowStream,
+		StreamKbps:       normalizeKbps(streamKbps),
+	}
+	if err := uc.peerRepo.Insert(ctx, peer); err != nil {
+		return nil, err
+	}
+	return peer, nil
+}
+
+// Handshake 处理对方提交的握手，邀请令牌只能使用一次
+func (uc *FederationUsecase) Handshake(ctx context.Context, req scene_audio_federation_models.FederationHandshake) error {
+	if uc.publicURL == "" {
+		return scene_audio_federation_models.ErrFederationNotConfigured
+	}
+	if req.Token == "" {
+		return scene_audio_federation_models.ErrInvalidInvite
+	}
+	if err := validateBaseURL(req.BaseURL); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	peer, err := uc.peerRepo.GetByInboundTokenHash(ctx, hashToken(req.InviteToken))
+	if errors.Is(err, scene_audio_federation_models.ErrPeerNotFound) {
+		return scene_audio_federation_models.ErrInvalidInvite
+	}
+	if err != nil {
+		return err
+	}
+	if peer.Status != scene_audio_federation_models.PeerStatusPending {
+		return scene_audio_federation_models.ErrInvalidInvite
+	}
+
+	if peer.Name == "" {
+		peer.Name = req.Name
+	}
+	peer.BaseURL = strings.TrimRight(req.BaseURL, "/")
+	peer.OutboundToken = req.Token
+	peer.Status = scene_audio_federation_models.PeerStatusActive
+	return uc.peerRepo.Update(ctx, peer)
+}
+
+// Authenticate 校验对方携带的令牌，只接受已完成握手的关联
+func (uc *FederationUsecase) Authenticate(ctx context.Context, token string) (*scene_audio_federation_models.FederationPeer, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	peer, err := uc.peerRepo.GetByInboundTokenHash(ctx, hashToken(token))
+	if errors.Is(err, scene_audio_federation_models.ErrPeerNotFound) {
+		return nil, scene_audio_federation_models.ErrInvalidPeerToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if peer.Status != scene_audio_federation_models.PeerStatusActive {
+		return nil, scene_audio_federation_models.ErrInvalidPeerToken
+	}
+	return peer, nil
+}
+
+func (uc *FederationUsecase) GetPeers(ctx context.Context) ([]scene_audio_federation_models.FederationPeer, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+	return uc.peerRepo.GetAll(ctx)
+}
+
+// UpdatePeer 调整对方串流本实例的权限与带宽
+func (uc *FederationUsecase) UpdatePeer(
+	ctx context.Context,
+	id string,
+	allowStream bool,
+	streamKbps int,
+) (*scene_audio_federation_models.FederationPeer, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	peer, err := uc.peerRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	peer.AllowStream = allowStream
+	peer.StreamKbps = normalizeKbps(streamKbps)
+	if err := uc.peerRepo.Update(ctx, peer); err != nil {
+		return nil, err
+	}
+	return peer, nil
+}
+
+// DeletePeer 删除本地关联并尽量通知对方，对方不可达时不影响删除
+func (uc *FederationUsecase) DeletePeer(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	peer, err := uc.peerRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if peer.Status == scene_audio_federation_models.PeerStatusActive {
+		if err := uc.client.Unlink(ctx, peer); err != nil {
+			log.Printf("通知联邦实例解除关联失败 %s: %v", peer.BaseURL, err)
+		}
+	}
+	if _, err := uc.peerRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Unlink 对方主动解除关联
+func (uc *FederationUsecase) Unlink(ctx context.Context, peer *scene_audio_federation_models.FederationPeer) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+	_, err := uc.peerRepo.Delete(ctx, peer.ID.Hex())
+	return err
+}
+
+// ListLocal 供对方浏览的本地条目，去掉文件路径等本机信息
+func (uc *FederationUsecase) ListLocal(
+	ctx context.Context,
+	kind string,
+	query scene_audio_federation_models.RemoteQuery,
+) ([]scene_audio_federation_models.RemoteItem, error) {
+	var (
+		items interface{}
+		err   error
+	)
+	switch kind {
+	case scene_audio_federation_models.RemoteKindAlbums:
+		items, err = uc.albums.GetAlbumItems(ctx, query.Start, query.End, query.Sort, query.Order,
+			query.Search, "", query.ArtistID, "", "", "", "", "true", "")
+	case scene_audio_federation_models.RemoteKindArtists:
+		items, err = uc.artists.GetArtistItems(ctx, query.Start, query.End, query.Sort, query.Order,
+			query.Search, "")
+	case scene_audio_federation_models.RemoteKindMediaFiles:
+		items, err = uc.mediaFiles.GetMediaFileItems(ctx, query.Start, query.End, query.Sort, query.Order,
+			query.Search, "", query.AlbumID, query.ArtistID, "", "", "true", "")
+	default:
+		return nil, fmt.Errorf("unsupported federation kind: %s", kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	result := []scene_audio_federation_models.RemoteItem{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	for _, item := range result {
+		delete(item, "Path")
+		delete(item, "LibraryPath")
+	}
+	return result, nil
+}
+
+// Browse 浏览远程媒体库，过滤参数中的远程ID需属于同一实例
+func (uc *FederationUsecase) Browse(
+	ctx context.Context,
+	peerID, kind string,
+	query scene_audio_federation_models.RemoteQuery,
+) ([]scene_audio_federation_models.RemoteItem, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	peer, err := uc.activePeer(ctx, peerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range []*string{&query.AlbumID, &query.ArtistID} {
+		if *id == "" {
+			continue
+		}
+		owner, remoteID, err := scene_audio_federation_models.ParseRemoteItemID(*id)
+		if err != nil || owner != peerID {
+			return nil, scene_audio_federation_models.ErrInvalidRemoteID
+		}
+		*id = remoteID
+	}
+
+	items, _, err := uc.client.List(ctx, peer, kind, query)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		namespaceItem(peer, item)
+	}
+	if items == nil {
+		items = []scene_audio_federation_models.RemoteItem{}
+	}
+	return items, nil
+}
+
+// StreamRemote 打开远程曲目的音频流，由调用方关闭响应体
+func (uc *FederationUsecase) StreamRemote(ctx context.Context, itemID string, header http.Header) (*http.Response, error) {
+	peerID, remoteID, err := scene_audio_federation_models.ParseRemoteItemID(itemID)
+	if err != nil {
+		return nil, err
+	}
+	lookupCtx, cancel := context.WithTimeout(ctx, uc.timeout)
+	peer, err := uc.activePeer(lookupCtx, peerID)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	return uc.client.Stream(ctx, peer, remoteID, header)
+}
+
+// LocalStreamPath 返回对方可串流的本地文件及限速，待审核与已拒绝的曲目不对外提供
+func (uc *FederationUsecase) LocalStreamPath(
+	ctx context.Context,
+	peer *scene_audio_federation_models.FederationPeer,
+	mediaFileID string,
+) (string, int, error) {
+	if !peer.AllowStream {
+		return "", 0, scene_audio_federation_models.ErrStreamNotAllowed
+	}
+	objID, err := primitive.ObjectIDFromHex(mediaFileID)
+	if err != nil {
+		return "", 0, errors.New("invalid media_file_id")
+	}
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	media, err := uc.mediaRepo.GetByID(ctx, objID)
+	if err != nil {
+		return "", 0, err
+	}
+	if media == nil ||
+		media.ReviewStatus == scene_audio_db_models.ReviewStatusPending ||
+		media.ReviewStatus == scene_audio_db_models.ReviewStatusRejected {
+		return "", 0, errors.New("media file not found")
+	}
+	return media.Path, normalizeKbps(peer.StreamKbps), nil
+}
+
+func (uc *FederationUsecase) activePeer(ctx context.Context, id string) (*scene_audio_federation_models.FederationPeer, error) {
+	peer, err := uc.peerRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if peer.Status != scene_audio_federation_models.PeerStatusActive {
+		return nil, scene_audio_federation_models.ErrPeerNotFound
+	}
+	return peer, nil
+}
+
+// namespaceItem 改写ID并标记来源，避免与本地条目混淆
+func namespaceItem(peer *scene_audio_federation_models.FederationPeer, item scene_audio_federation_models.RemoteItem) {
+	peerID := peer.ID.Hex()
+	for _, field := range remoteIDFields {
+		if id, ok := item[field].(string); ok && id != "" {
+			item[field] = scene_audio_federation_models.RemoteItemID(peerID, id)
+		}
+	}
+	for _, field := range remoteArtistListFields {
+		list, ok := item[field].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, entry := range list {
+			if pair, ok := entry.(map[string]interface{}); ok {
+				if id, ok := pair["ArtistID"].(string); ok && id != "" {
+					pair["ArtistID"] = scene_audio_federation_models.RemoteItemID(peerID, id)
+				}
+			}
+		}
+	}
+	item["PeerID"] = peerID
+	item["PeerName"] = peer.Name
+	item["Remote"] = true
+	item["Availability"] = scene_audio_db_models.AvailabilityRemote
+}
+
+func normalizeKbps(kbps int) int {
+	if kbps <= 0 {
+		return scene_audio_federation_models.DefaultStreamKbps
+	}
+	return kbps
+}
+
+func validateBaseURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("invalid base_url")
+	}
+	return nil
+}
+
+func newToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate federation token failed: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,165 @@
+package scene_audio_federation_usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_federation/scene_audio_federation_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_federation/scene_audio_federation_models"
+)
+
+type federationClient struct {
+	client *http.Client
+	// 串流请求不设整体超时，由调用方 ctx 控制
+	streamClient *http.Client
+}
+
+func NewFederationClient(timeout time.Duration) scene_audio_federation_interface.FederationClient {
+	return &federationClient{
+		client:       &http.Client{Timeout: timeout},
+		streamClient: &http.Client{},
+	}
+}
+
+type federationEnvelope struct {
+	Response struct {
+		Status string                                     `json:"status"`
+		Items  []scene_audio_federation_models.RemoteItem `json:"items"`
+		Count  int                                        `json:"count"`
+		Error  *struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	} `json:"ninesong-response"`
+}
+
+func (c *federationClient) Handshake(ctx context.Context, baseURL string, req scene_audio_federation_models.FederationHandshake) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint(baseURL, "handshake"), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	// 对方以 401 拒绝表示邀请令牌无效或已被使用
+	if _, err = c.do(httpReq); errors.Is(err, scene_audio_federation_models.ErrInvalidPeerToken) {
+		return scene_audio_federation_models.ErrInvalidInvite
+	}
+	return err
+}
+
+func (c *federationClient) List(
+	ctx context.Context,
+	peer *scene_audio_federation_models.FederationPeer,
+	kind string,
+	query scene_audio_federation_models.RemoteQuery,
+) ([]scene_audio_federation_models.RemoteItem, int, error) {
+	params := url.Values{}
+	for key, value := range map[string]string{
+		"start":     query.Start,
+		"end":       query.End,
+		"sort":      query.Sort,
+		"order":     query.Order,
+		"search":    query.Search,
+		"album_id":  query.AlbumID,
+		"artist_id": query.ArtistID,
+	} {
+		if value != "" {
+			params.Set(key, value)
+		}
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		endpoint(peer.BaseURL, "api/"+kind)+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	httpReq.Header.Set(scene_audio_federation_models.FederationTokenHeader, peer.OutboundToken)
+	resp, err := c.do(httpReq)
+	if err != nil {
+		return nil, 0, err
+	}
+	return resp.Response.Items, resp.Response.Count, nil
+}
+
+func (c *federationClient) Stream(
+	ctx context.Context,
+	peer *scene_audio_federation_models.FederationPeer,
+	remoteID string,
+	header http.Header,
+) (*http.Response, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		endpoint(peer.BaseURL, "api/stream")+"?media_file_id="+url.QueryEscape(remoteID), nil)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set(scene_audio_federation_models.FederationTokenHeader, peer.OutboundToken)
+	// 透传 Range，保证远程曲目同样可以拖动进度
+	if r := header.Get("Range"); r != "" {
+		httpReq.Header.Set("Range", r)
+	}
+
+	resp, err := c.streamClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", scene_audio_federation_models.ErrRemoteUnavailable, err)
+	}
+	if resp.StatusCode == http.StatusForbidden {
+		resp.Body.Close()
+		return nil, scene_audio_federation_models.ErrStreamNotAllowed
+	}
+	if resp.StatusCode >= 400 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: remote returned %d", scene_audio_federation_models.ErrRemoteUnavailable, resp.StatusCode)
+	}
+	return resp, nil
+}
+
+func (c *federationClient) Unlink(ctx context.Context, peer *scene_audio_federation_models.FederationPeer) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete, endpoint(peer.BaseURL, "api/link"), nil)
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set(scene_audio_federation_models.FederationTokenHeader, peer.OutboundToken)
+	_, err = c.do(httpReq)
+	return err
+}
+
+func (c *federationClient) do(req *http.Request) (*federationEnvelope, error) {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", scene_audio_federation_models.ErrRemoteUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	var envelope federationEnvelope
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("%w: decode response failed (status %d)", scene_audio_federation_models.ErrRemoteUnavailable, resp.StatusCode)
+	}
+	if e := envelope.Response.Error; e != nil || resp.StatusCode >= 400 {
+		message := fmt.Sprintf("remote returned %d", resp.StatusCode)
+		if e != nil {
+			message = e.Message
+		}
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			return nil, fmt.Errorf("%w: %s", scene_audio_federation_models.ErrInvalidPeerToken, message)
+		case http.StatusForbidden:
+			return nil, fmt.Errorf("%w: %s", scene_audio_federation_models.ErrStreamNotAllowed, message)
+		}
+		return nil, fmt.Errorf("%w: %s", scene_audio_federation_models.ErrRemoteUnavailable, message)
+	}
+	return &envelope, nil
+}
+
+func endpoint(baseURL, path string) string {
+	return strings.TrimRight(baseURL, "/") + "/federation/" + path
+}
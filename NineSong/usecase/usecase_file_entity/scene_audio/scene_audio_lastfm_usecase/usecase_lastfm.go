@@ -0,0 +1,93 @@
+package scene_audio_lastfm_usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_lastfm/scene_audio_lastfm_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_lastfm/scene_audio_lastfm_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/http_util"
+)
+
+const (
+	lastFMAPIURL = "https://ws.audioscrobbler.com/2.0/"
+	// Last.fm 建议每秒不超过五次请求，频率按来源IP计算
+	lastFMInterval = time.Second / 5
+	lastFMCacheTTL = 12 * time.Hour
+)
+
+type lastFMUsecase struct {
+	client *http_util.Client
+	apiKey string
+}
+
+// NewLastFMUsecase apiKey 为在 last.fm/api 注册的应用密钥
+func NewLastFMUsecase(apiKey string, timeout time.Duration) scene_audio_lastfm_interface.LastFMClient {
+	return &lastFMUsecase{
+		client: http_util.NewClient(http_util.Provider{
+			Name:       "lastfm",
+			Interval:   lastFMInterval,
+			CacheTTL:   lastFMCacheTTL,
+			MaxRetries: 2,
+		}, timeout),
+		apiKey: apiKey,
+	}
+}
+
+type lastFMSimilarResponse struct {
+	Error         int    `json:"error"`
+	Message       string `json:"message"`
+	SimilarArtist struct {
+		Artist []struct {
+			Name  string `json:"name"`
+			MBID  string `json:"mbid"`
+			Match string `json:"match"`
+		} `json:"artist"`
+	} `json:"similarartists"`
+}
+
+func (uc *lastFMUsecase) SimilarArtists(
+	ctx context.Context,
+	name, mbid string,
+	limit int,
+) ([]scene_audio_lastfm_models.LastFMSimilarArtist, error) {
+	query := url.Values{}
+	query.Set("method", "artist.getsimilar")
+	query.Set("api_key", uc.apiKey)
+	query.Set("format", "json")
+	query.Set("autocorrect", "1")
+	query.Set("limit", strconv.Itoa(limit))
+	if mbid != "" {
+		query.Set("mbid", mbid)
+	} else {
+		query.Set("artist", name)
+	}
+
+	res, err := uc.client.Do(ctx, http_util.Request{
+		URL:    lastFMAPIURL + "?" + query.Encode(),
+		Header: http.Header{"Accept": {"application/json"}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp lastFMSimilarResponse
+	if err := json.Unmarshal(res.Body, &resp); err != nil {
+		return nil, fmt.Errorf("解析lastfm响应失败: %w", err)
+	}
+	if res.StatusCode != http.StatusOK || resp.Error != 0 {
+		return nil, fmt.Errorf("lastfm返回错误 %d: %s", res.StatusCode, resp.Message)
+	}
+
+	artists := make([]scene_audio_lastfm_models.LastFMSimilarArtist, 0, len(resp.SimilarArtist.Artist))
+	for _, a := range resp.SimilarArtist.Artist {
+		match, _ := strconv.ParseFloat(a.Match, 64)
+		artists = append(artists, scene_audio_lastfm_models.LastFMSimilarArtist{Name: a.Name, MBID: a.MBID, Match: match})
+	}
+	return artists, nil
+}
@@ -0,0 +1,83 @@
+package scene_audio_lyrics_usecase
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_lyrics/scene_audio_lyrics_models"
+)
+
+const geniusBaseURL = "https://api.genius.com"
+
+var (
+	geniusContainerPattern = regexp.MustCompile(`(?s)<div[^>]*data-lyrics-container="true"[^>]*>(.*?)</div>`)
+	geniusBreakPattern     = regexp.MustCompile(`(?i)<br\s*/?>`)
+	geniusTagPattern       = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+// geniusProvider Genius 接口只返回歌词页地址，歌词从页面中提取，只有纯文本
+type geniusProvider struct {
+	client *lyricsClient
+	token  string
+}
+
+func (p *geniusProvider) Name() string {
+	return scene_audio_lyrics_models.ProviderGenius
+}
+
+func (p *geniusProvider) FetchLyrics(ctx context.Context, query scene_audio_lyrics_models.LyricsQuery) (string, error) {
+	params := url.Values{}
+	params.Set("q", strings.TrimSpace(query.Title+" "+query.Artist))
+	var search struct {
+		Response struct {
+			Hits []struct {
+				Type   string `json:"type"`
+				Result struct {
+					Title         string `json:"title"`
+					URL           string `json:"url"`
+					PrimaryArtist struct {
+						Name string `json:"name"`
+					} `json:"primary_artist"`
+				} `json:"result"`
+			} `json:"hits"`
+		} `json:"response"`
+	}
+	header := http.Header{"Authorization": []string{"Bearer " + p.token}}
+	if err := p.client.getJSON(ctx, fmt.Sprintf("%s/search?%s", geniusBaseURL, params.Encode()), header, &search); err != nil {
+		return "", err
+	}
+
+	pageURL := ""
+	for _, hit := range search.Response.Hits {
+		if hit.Type == "song" && sameTitle(hit.Result.Title, query.Title) &&
+			(query.Artist == "" || strings.Contains(strings.ToLower(hit.Result.PrimaryArtist.Name), strings.ToLower(query.Artist))) {
+			pageURL = hit.Result.URL
+			break
+		}
+	}
+	if pageURL == "" {
+		return "", scene_audio_lyrics_models.ErrNotFound
+	}
+
+	page, err := p.client.get(ctx, pageURL, nil)
+	if err != nil {
+		return "", err
+	}
+	var parts []string
+	for _, match := range geniusContainerPattern.FindAllSubmatch(page, -1) {
+		text := geniusBreakPattern.ReplaceAllString(string(match[1]), "\n")
+		text = html.UnescapeString(geniusTagPattern.ReplaceAllString(text, ""))
+		if text = strings.TrimSpace(text); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	if len(parts) == 0 {
+		return "", scene_audio_lyrics_models.ErrNotFound
+	}
+	return strings.Join(parts, "\n"), nil
+}
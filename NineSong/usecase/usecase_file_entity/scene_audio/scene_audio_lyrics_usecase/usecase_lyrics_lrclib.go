@@ -0,0 +1,75 @@
+package scene_audio_lyrics_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_lyrics/scene_audio_lyrics_models"
+)
+
+const lrcLibBaseURL = "https://lrclib.net/api"
+
+type lrcLibProvider struct {
+	client *lyricsClient
+}
+
+type lrcLibRecord struct {
+	Instrumental bool   `json:"instrumental"`
+	PlainLyrics  string `json:"plainLyrics"`
+	SyncedLyrics string `json:"syncedLyrics"`
+}
+
+func (p *lrcLibProvider) Name() string {
+	return scene_audio_lyrics_models.ProviderLrcLib
+}
+
+// FetchLyrics 时长已知时精确匹配（LrcLib 允许 ±2 秒误差），否则取搜索结果中的第一条；同步歌词优先
+func (p *lrcLibProvider) FetchLyrics(ctx context.Context, query scene_audio_lyrics_models.LyricsQuery) (string, error) {
+	params := url.Values{}
+	params.Set("track_name", query.Title)
+	params.Set("artist_name", query.Artist)
+
+	if query.Duration > 0 {
+		params.Set("album_name", query.Album)
+		params.Set("duration", strconv.Itoa(int(math.Round(query.Duration))))
+		var record lrcLibRecord
+		err := p.client.getJSON(ctx, fmt.Sprintf("%s/get?%s", lrcLibBaseURL, params.Encode()), nil, &record)
+		if err == nil {
+			return lrcLibText(record)
+		}
+		if !errors.Is(err, scene_audio_lyrics_models.ErrNotFound) {
+			return "", err
+		}
+		params.Del("album_name")
+		params.Del("duration")
+	}
+
+	var records []lrcLibRecord
+	if err := p.client.getJSON(ctx, fmt.Sprintf("%s/search?%s", lrcLibBaseURL, params.Encode()), nil, &records); err != nil {
+		return "", err
+	}
+	for _, record := range records {
+		if text, err := lrcLibText(record); err == nil {
+			return text, nil
+		}
+	}
+	return "", scene_audio_lyrics_models.ErrNotFound
+}
+
+func lrcLibText(record lrcLibRecord) (string, error) {
+	if record.Instrumental {
+		return "", scene_audio_lyrics_models.ErrNotFound
+	}
+	if text := strings.TrimSpace(record.SyncedLyrics); text != "" {
+		return text, nil
+	}
+	if text := strings.TrimSpace(record.PlainLyrics); text != "" {
+		return text, nil
+	}
+	return "", scene_audio_lyrics_models.ErrNotFound
+}
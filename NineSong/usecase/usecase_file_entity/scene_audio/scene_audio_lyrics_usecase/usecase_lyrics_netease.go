@@ -0,0 +1,77 @@
+package scene_audio_lyrics_usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_lyrics/scene_audio_lyrics_models"
+)
+
+const (
+	netEaseBaseURL = "https://music.163.com/api"
+	// 候选曲目时长与本地相差超过该秒数时视为不同版本
+	netEaseDurationTolerance = 3
+)
+
+type netEaseProvider struct {
+	client *lyricsClient
+}
+
+func (p *netEaseProvider) Name() string {
+	return scene_audio_lyrics_models.ProviderNetEase
+}
+
+// FetchLyrics 先按“曲名 艺术家”搜索，取曲名一致且时长相近的第一首，再读取其 LRC 歌词
+func (p *netEaseProvider) FetchLyrics(ctx context.Context, query scene_audio_lyrics_models.LyricsQuery) (string, error) {
+	header := http.Header{"Referer": []string{"https://music.163.com/"}}
+
+	params := url.Values{}
+	params.Set("s", strings.TrimSpace(query.Title+" "+query.Artist))
+	params.Set("type", "1")
+	params.Set("limit", "10")
+	var search struct {
+		Result struct {
+			Songs []struct {
+				ID       int64  `json:"id"`
+				Name     string `json:"name"`
+				Duration int64  `json:"duration"` // 毫秒
+			} `json:"songs"`
+		} `json:"result"`
+	}
+	if err := p.client.getJSON(ctx, fmt.Sprintf("%s/search/get/web?%s", netEaseBaseURL, params.Encode()), header, &search); err != nil {
+		return "", err
+	}
+
+	var songID int64
+	for _, song := range search.Result.Songs {
+		if !sameTitle(song.Name, query.Title) {
+			continue
+		}
+		if query.Duration > 0 && math.Abs(float64(song.Duration)/1000-query.Duration) > netEaseDurationTolerance {
+			continue
+		}
+		songID = song.ID
+		break
+	}
+	if songID == 0 {
+		return "", scene_audio_lyrics_models.ErrNotFound
+	}
+
+	var lyric struct {
+		Lrc struct {
+			Lyric string `json:"lyric"`
+		} `json:"lrc"`
+	}
+	endpoint := fmt.Sprintf("%s/song/lyric?id=%d&lv=1", netEaseBaseURL, songID)
+	if err := p.client.getJSON(ctx, endpoint, header, &lyric); err != nil {
+		return "", err
+	}
+	if text := strings.TrimSpace(lyric.Lrc.Lyric); text != "" {
+		return text, nil
+	}
+	return "", scene_audio_lyrics_models.ErrNotFound
+}
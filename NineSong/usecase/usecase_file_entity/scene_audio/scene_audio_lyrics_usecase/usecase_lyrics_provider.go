@@ -0,0 +1,86 @@
+package scene_audio_lyrics_usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_lyrics/scene_audio_lyrics_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_lyrics/scene_audio_lyrics_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/http_util"
+)
+
+const (
+	// 页面类响应（Genius 歌词页）可能较大，超出部分不读取
+	lyricsMaxBodySize = 4 << 20
+	// 各歌词源均未公布配额，保守限制为每秒两次
+	lyricsInterval = time.Second / 2
+	lyricsCacheTTL = 6 * time.Hour
+)
+
+// NewLyricsProviders 按 names（如 "lrclib,netease,genius"）的顺序创建在线歌词源；
+// 未知名称与缺少访问令牌的 Genius 跳过并记录日志
+func NewLyricsProviders(names string, timeout time.Duration, geniusToken string) []scene_audio_lyrics_interface.LyricsProvider {
+	var providers []scene_audio_lyrics_interface.LyricsProvider
+	for _, name := range strings.Split(names, ",") {
+		switch name = strings.ToLower(strings.TrimSpace(name)); name {
+		case "":
+		case scene_audio_lyrics_models.ProviderLrcLib:
+			providers = append(providers, &lrcLibProvider{client: newLyricsClient(name, timeout)})
+		case scene_audio_lyrics_models.ProviderNetEase:
+			providers = append(providers, &netEaseProvider{client: newLyricsClient(name, timeout)})
+		case scene_audio_lyrics_models.ProviderGenius:
+			if geniusToken == "" {
+				log.Printf("未配置 GENIUS_ACCESS_TOKEN，跳过 Genius 歌词源")
+				continue
+			}
+			providers = append(providers, &geniusProvider{client: newLyricsClient(name, timeout), token: geniusToken})
+		default:
+			log.Printf("未知的在线歌词源: %s", name)
+		}
+	}
+	return providers
+}
+
+// lyricsClient 每个歌词源独立节流与统计，404 统一转换为 ErrNotFound
+type lyricsClient struct {
+	http *http_util.Client
+}
+
+func newLyricsClient(provider string, timeout time.Duration) *lyricsClient {
+	return &lyricsClient{http: http_util.NewClient(http_util.Provider{
+		Name:        "lyrics_" + provider,
+		Interval:    lyricsInterval,
+		CacheTTL:    lyricsCacheTTL,
+		MaxRetries:  1,
+		MaxBodySize: lyricsMaxBodySize,
+	}, timeout)}
+}
+
+func (c *lyricsClient) get(ctx context.Context, endpoint string, header http.Header) ([]byte, error) {
+	body, err := c.http.Get(ctx, endpoint, header)
+	if http_util.IsStatus(err, http.StatusNotFound) {
+		return nil, scene_audio_lyrics_models.ErrNotFound
+	}
+	return body, err
+}
+
+func (c *lyricsClient) getJSON(ctx context.Context, endpoint string, header http.Header, out interface{}) error {
+	body, err := c.get(ctx, endpoint, header)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("解析歌词响应失败: %w", err)
+	}
+	return nil
+}
+
+// sameTitle 忽略大小写与首尾空白比较曲名
+func sameTitle(a, b string) bool {
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
@@ -0,0 +1,240 @@
+package scene_audio_musicbrainz_usecase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_musicbrainz/scene_audio_musicbrainz_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_musicbrainz/scene_audio_musicbrainz_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/http_util"
+)
+
+const (
+	musicBrainzBaseURL = "https://musicbrainz.org/ws/2"
+	// MusicBrainz 要求匿名客户端每秒不超过一次请求，频率按来源IP计算
+	musicBrainzInterval = time.Second
+	// 发行与艺术家数据极少变动
+	musicBrainzCacheTTL = 24 * time.Hour
+)
+
+var mbidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+type musicBrainzUsecase struct {
+	client *http_util.Client
+}
+
+func NewMusicBrainzUsecase(timeout time.Duration) scene_audio_musicbrainz_interface.MusicBrainzClient {
+	return &musicBrainzUsecase{
+		client: http_util.NewClient(http_util.Provider{
+			Name:       "musicbrainz",
+			Interval:   musicBrainzInterval,
+			CacheTTL:   musicBrainzCacheTTL,
+			MaxRetries: 3,
+		}, timeout),
+	}
+}
+
+type mbReleaseResponse struct {
+	ID             string `json:"id"`
+	Title          string `json:"title"`
+	Country        string `json:"country"`
+	Disambiguation string `json:"disambiguation"`
+	LabelInfo      []struct {
+		CatalogNumber string `json:"catalog-number"`
+	} `json:"label-info"`
+	Media []struct {
+		Position int `json:"position"`
+		Tracks   []struct {
+			ID        string `json:"id"`
+			Position  int    `json:"position"`
+			Title     string `json:"title"`
+			Length    int    `json:"length"`
+			Recording struct {
+				ID string `json:"id"`
+			} `json:"recording"`
+		} `json:"tracks"`
+	} `json:"media"`
+}
+
+func (uc *musicBrainzUsecase) GetRelease(
+	ctx context.Context,
+	releaseID string,
+) (*scene_audio_musicbrainz_models.MusicBrainzRelease, error) {
+	if !mbidPattern.MatchString(releaseID) {
+		return nil, scene_audio_musicbrainz_models.ErrInvalidMBID
+	}
+
+	query := url.Values{}
+	query.Set("inc", "recordings+labels")
+	query.Set("fmt", "json")
+	endpoint := fmt.Sprintf("%s/release/%s?%s", musicBrainzBaseURL, releaseID, query.Encode())
+
+	var resp mbReleaseResponse
+	if err := uc.getJSON(ctx, endpoint, &resp); err != nil {
+		return nil, err
+	}
+
+	release := &scene_audio_musicbrainz_models.MusicBrainzRelease{
+		ID:             resp.ID,
+		Title:          resp.Title,
+		Tracks:         make([]scene_audio_musicbrainz_models.MusicBrainzTrack, 0),
+		FetchedAt:      time.Now().UTC(),
+		Country:        resp.Country,
+		Disambiguation: resp.Disambiguation,
+	}
+	for _, label := range resp.LabelInfo {
+		if label.CatalogNumber != "" {
+			release.CatalogNumber = label.CatalogNumber
+			break
+		}
+	}
+	for _, medium := range resp.Media {
+		for _, t := range medium.Tracks {
+			release.Tracks = append(release.Tracks, scene_audio_musicbrainz_models.MusicBrainzTrack{
+				ID:          t.ID,
+				RecordingID: t.Recording.ID,
+				DiscNumber:  medium.Position,
+				TrackNumber: t.Position,
+				Title:       t.Title,
+				Length:      t.Length,
+			})
+		}
+	}
+	release.TrackCount = len(release.Tracks)
+	return release, nil
+}
+
+func (uc *musicBrainzUsecase) GetArtistAliases(ctx context.Context, artistID string) ([]string, error) {
+	if !mbidPattern.MatchString(artistID) {
+		return nil, scene_audio_musicbrainz_models.ErrInvalidMBID
+	}
+
+	query := url.Values{}
+	query.Set("inc", "aliases")
+	query.Set("fmt", "json")
+	endpoint := fmt.Sprintf("%s/artist/%s?%s", musicBrainzBaseURL, artistID, query.Encode())
+
+	var resp struct {
+		Name    string `json:"name"`
+		Aliases []struct {
+			Name     string `json:"name"`
+			SortName string `json:"sort-name"`
+		} `json:"aliases"`
+	}
+	if err := uc.getJSON(ctx, endpoint, &resp); err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{strings.ToLower(resp.Name): true}
+	aliases := make([]string, 0, len(resp.Aliases))
+	for _, a := range resp.Aliases {
+		key := strings.ToLower(strings.TrimSpace(a.Name))
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		aliases = append(aliases, strings.TrimSpace(a.Name))
+	}
+	return aliases, nil
+}
+
+// musicBrainzSearchLimit 搜索只取前几条候选，匹配度靠后的结果基本不可用
+const musicBrainzSearchLimit = 5
+
+// luceneQuote 将值转义为 Lucene 短语，避免引号与反斜杠破坏查询
+func luceneQuote(value string) string {
+	value = strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(strings.TrimSpace(value))
+	return `"` + value + `"`
+}
+
+func (uc *musicBrainzUsecase) SearchReleases(
+	ctx context.Context,
+	title, artist string,
+) ([]scene_audio_musicbrainz_models.MusicBrainzSearchResult, error) {
+	q := "release:" + luceneQuote(title)
+	if strings.TrimSpace(artist) != "" {
+		q += " AND artist:" + luceneQuote(artist)
+	}
+	query := url.Values{}
+	query.Set("query", q)
+	query.Set("limit", fmt.Sprint(musicBrainzSearchLimit))
+	query.Set("fmt", "json")
+
+	var resp struct {
+		Releases []struct {
+			ID           string `json:"id"`
+			Title        string `json:"title"`
+			Score        int    `json:"score"`
+			TrackCount   int    `json:"track-count"`
+			Country      string `json:"country"`
+			ArtistCredit []struct {
+				Artist struct {
+					ID string `json:"id"`
+				} `json:"artist"`
+			} `json:"artist-credit"`
+		} `json:"releases"`
+	}
+	if err := uc.getJSON(ctx, fmt.Sprintf("%s/release?%s", musicBrainzBaseURL, query.Encode()), &resp); err != nil {
+		return nil, err
+	}
+
+	results := make([]scene_audio_musicbrainz_models.MusicBrainzSearchResult, 0, len(resp.Releases))
+	for _, r := range resp.Releases {
+		result := scene_audio_musicbrainz_models.MusicBrainzSearchResult{
+			ID:         r.ID,
+			Title:      r.Title,
+			Score:      r.Score,
+			TrackCount: r.TrackCount,
+			Country:    r.Country,
+		}
+		if len(r.ArtistCredit) > 0 {
+			result.ArtistID = r.ArtistCredit[0].Artist.ID
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (uc *musicBrainzUsecase) SearchArtists(
+	ctx context.Context,
+	name string,
+) ([]scene_audio_musicbrainz_models.MusicBrainzSearchResult, error) {
+	query := url.Values{}
+	query.Set("query", "artist:"+luceneQuote(name))
+	query.Set("limit", fmt.Sprint(musicBrainzSearchLimit))
+	query.Set("fmt", "json")
+
+	var resp struct {
+		Artists []struct {
+			ID    string `json:"id"`
+			Name  string `json:"name"`
+			Score int    `json:"score"`
+		} `json:"artists"`
+	}
+	if err := uc.getJSON(ctx, fmt.Sprintf("%s/artist?%s", musicBrainzBaseURL, query.Encode()), &resp); err != nil {
+		return nil, err
+	}
+
+	results := make([]scene_audio_musicbrainz_models.MusicBrainzSearchResult, 0, len(resp.Artists))
+	for _, a := range resp.Artists {
+		results = append(results, scene_audio_musicbrainz_models.MusicBrainzSearchResult{
+			ID:    a.ID,
+			Title: a.Name,
+			Score: a.Score,
+		})
+	}
+	return results, nil
+}
+
+func (uc *musicBrainzUsecase) getJSON(ctx context.Context, endpoint string, out interface{}) error {
+	err := uc.client.GetJSON(ctx, endpoint, http.Header{"Accept": {"application/json"}}, out)
+	if http_util.IsStatus(err, http.StatusNotFound) {
+		return scene_audio_musicbrainz_models.ErrNotFound
+	}
+	return err
+}
@@ -3,11 +3,16 @@ package scene_audio_route_usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cache_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -26,7 +31,7 @@ func NewAlbumUsecase(repo scene_audio_route_interface.AlbumRepository, timeout t
 func (uc *AlbumUsecase) GetAlbumItems(
 	ctx context.Context,
 	start, end, sort, order, search, starred, artistId string,
-	minYear, maxYear string,
+	minYear, maxYear, decade, folderId, available, custom string,
 ) ([]scene_audio_route_models.AlbumMetadata, error) {
 	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
 	defer cancel()
@@ -47,12 +52,7 @@ func (uc *AlbumUsecase) GetAlbumItems(
 		},
 		// 艺术家ID格式验证
 		func() error {
-			if artistId != "" {
-				if _, err := primitive.ObjectIDFromHex(artistId); err != nil {
-					return errors.New("invalid artist id format")
-				}
-			}
-			return nil
+			return validateArtistIDs(artistId)
 		},
 		// 年份格式验证
 		func() error {
@@ -71,6 +71,9 @@ func (uc *AlbumUsecase) GetAlbumItems(
 			}
 			return nil
 		},
+		func() error {
+			return validateDecade(decade)
+		},
 		// Starred参数验证
 		func() error {
 			if starred != "" {
@@ -80,6 +83,12 @@ func (uc *AlbumUsecase) GetAlbumItems(
 			}
 			return nil
 		},
+		func() error {
+			return validateFolderID(folderId)
+		},
+		func() error {
+			return validateAvailable(available)
+		},
 	}
 
 	for _, validate := range validations {
@@ -88,12 +97,29 @@ func (uc *AlbumUsecase) GetAlbumItems(
 		}
 	}
 
-	return uc.repo.GetAlbumItems(ctx, start, end, sort, order, search, starred, artistId, minYear, maxYear)
+	key := cache_util.Key("album", domain.UserIDFromContext(ctx), start, end, sort, order, search, starred, artistId, minYear, maxYear, decade, folderId, available, custom)
+	albums, err := cache_util.GetOrLoad(ctx, cache_util.NamespaceLists, key, cache_util.DefaultTTL(),
+		func() ([]scene_audio_route_models.AlbumMetadata, error) {
+			return cache_util.GetOrStale(ctx, cache_util.NamespaceStaleLists, key, cache_util.StaleListTTL,
+				func() ([]scene_audio_route_models.AlbumMetadata, error) {
+					return uc.repo.GetAlbumItems(ctx, start, end, sort, order, search, starred, artistId, minYear, maxYear, decade, folderId, available, custom)
+				}, mongo.IsUnavailable)
+		})
+	if err != nil {
+		return nil, err
+	}
+	for i := range albums {
+		if albums[i].Availability == "" {
+			albums[i].Availability = scene_audio_db_models.AvailabilityOnline
+		}
+	}
+	return albums, nil
 }
 
 func (uc *AlbumUsecase) GetAlbumFilterItemsCount(
 	ctx context.Context,
-	search, starred, artistId, minYear, maxYear string,
+	search, starred, artistId, minYear, maxYear, decade, folderId, available, custom string,
+	genres string,
 ) (*scene_audio_route_models.AlbumFilterCounts, error) {
 	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
 	defer cancel()
@@ -109,12 +135,7 @@ func (uc *AlbumUsecase) GetAlbumFilterItemsCount(
 			return nil
 		},
 		func() error {
-			if artistId != "" {
-				if _, err := primitive.ObjectIDFromHex(artistId); err != nil {
-					return errors.New("invalid artist id format")
-				}
-			}
-			return nil
+			return validateArtistIDs(artistId)
 		},
 		func() error {
 			if minYear != "" {
@@ -132,6 +153,64 @@ func (uc *AlbumUsecase) GetAlbumFilterItemsCount(
 			}
 			return nil
 		},
+		func() error {
+			return validateDecade(decade)
+		},
+		func() error {
+			return validateFolderID(folderId)
+		},
+		func() error {
+			return validateAvailable(available)
+		},
+		func() error {
+			if genres != "" {
+				n, err := strconv.Atoi(genres)
+				if err != nil || n < 0 || n > scene_audio_route_models.AlbumFilterGenresMax {
+					return fmt.Errorf("genres must be between 0-%d", scene_audio_route_models.AlbumFilterGenresMax)
+				}
+			}
+			return nil
+		},
+	}
+
+	for _, validate := range validations {
+		if err := validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	key := cache_util.Key("album", domain.UserIDFromContext(ctx), search, starred, artistId, minYear, maxYear, decade, folderId, available, custom, genres)
+	return cache_util.GetOrLoad(ctx, cache_util.NamespaceFilterCounts, key, cache_util.DefaultTTL(),
+		func() (*scene_audio_route_models.AlbumFilterCounts, error) {
+			return uc.repo.GetAlbumFilterItemsCount(ctx, search, starred, artistId, minYear, maxYear, decade, folderId, available, custom, genres)
+		})
+}
+
+func (uc *AlbumUsecase) GetAlbumYears(
+	ctx context.Context,
+	search, starred, artistId, folderId, available, custom string,
+) (*scene_audio_route_models.AlbumYearCounts, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	validations := []func() error{
+		func() error {
+			if starred != "" {
+				if _, err := strconv.ParseBool(starred); err != nil {
+					return errors.New("invalid starred parameter")
+				}
+			}
+			return nil
+		},
+		func() error {
+			return validateArtistIDs(artistId)
+		},
+		func() error {
+			return validateFolderID(folderId)
+		},
+		func() error {
+			return validateAvailable(available)
+		},
 	}
 
 	for _, validate := range validations {
@@ -140,5 +219,108 @@ func (uc *AlbumUsecase) GetAlbumFilterItemsCount(
 		}
 	}
 
-	return uc.repo.GetAlbumFilterItemsCount(ctx, search, starred, artistId, minYear, maxYear)
+	key := cache_util.Key("album_years", domain.UserIDFromContext(ctx), search, starred, artistId, folderId, available, custom)
+	return cache_util.GetOrLoad(ctx, cache_util.NamespaceFilterCounts, key, cache_util.DefaultTTL(),
+		func() (*scene_audio_route_models.AlbumYearCounts, error) {
+			return uc.repo.GetAlbumYears(ctx, search, starred, artistId, folderId, available, custom)
+		})
+}
+
+func (uc *AlbumUsecase) GetAlbumShelves(
+	ctx context.Context,
+	limits scene_audio_route_models.AlbumShelfLimits,
+) (*scene_audio_route_models.AlbumShelves, error) {
+	for _, limit := range []int{limits.RecentlyAdded, limits.RecentlyPlayed, limits.MostPlayed, limits.Random, limits.TopRated} {
+		if limit < 0 || limit > scene_audio_route_models.AlbumShelfMaxLimit {
+			return nil, fmt.Errorf("shelf limit must be between 0-%d", scene_audio_route_models.AlbumShelfMaxLimit)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	shelves, err := uc.repo.GetAlbumShelves(ctx, limits)
+	if err != nil {
+		return nil, err
+	}
+	for _, list := range [][]scene_audio_route_models.AlbumMetadata{
+		shelves.RecentlyAdded, shelves.RecentlyPlayed, shelves.MostPlayed, shelves.Random, shelves.TopRated,
+	} {
+		for i := range list {
+			if list[i].Availability == "" {
+				list[i].Availability = scene_audio_db_models.AvailabilityOnline
+			}
+		}
+	}
+	return shelves, nil
+}
+
+func (uc *AlbumUsecase) GetAlbumTracks(ctx context.Context, albumId string) (*scene_audio_route_models.AlbumTracks, error) {
+	if _, err := primitive.ObjectIDFromHex(albumId); err != nil {
+		return nil, errors.New("invalid album id format")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	result, err := uc.repo.GetAlbumTracks(ctx, albumId)
+	if err != nil {
+		return nil, err
+	}
+	if result.Album.Availability == "" {
+		result.Album.Availability = scene_audio_db_models.AvailabilityOnline
+	}
+	for _, disc := range result.Discs {
+		for i := range disc.Tracks {
+			if disc.Tracks[i].Availability == "" {
+				disc.Tracks[i].Availability = scene_audio_db_models.AvailabilityOnline
+			}
+		}
+	}
+	return result, nil
+}
+
+func (uc *AlbumUsecase) GetAlbumDetail(ctx context.Context, albumId, userId string) (*scene_audio_route_models.AlbumDetail, error) {
+	if _, err := primitive.ObjectIDFromHex(albumId); err != nil {
+		return nil, errors.New("invalid album id format")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	result, err := uc.repo.GetAlbumDetail(ctx, albumId, userId)
+	if err != nil {
+		return nil, err
+	}
+	if result.Album.Availability == "" {
+		result.Album.Availability = scene_audio_db_models.AvailabilityOnline
+	}
+	for _, disc := range result.Discs {
+		for i := range disc.Tracks {
+			if disc.Tracks[i].Availability == "" {
+				disc.Tracks[i].Availability = scene_audio_db_models.AvailabilityOnline
+			}
+		}
+	}
+	for i := range result.SimilarAlbums {
+		if result.SimilarAlbums[i].Availability == "" {
+			result.SimilarAlbums[i].Availability = scene_audio_db_models.AvailabilityOnline
+		}
+	}
+	for i := range result.Versions {
+		if result.Versions[i].Availability == "" {
+			result.Versions[i].Availability = scene_audio_db_models.AvailabilityOnline
+		}
+	}
+	return result, nil
+}
+
+// validateDecade decade 为年代起始年，须为 10 的整数倍
+func validateDecade(decade string) error {
+	if decade != "" {
+		if year, err := strconv.Atoi(decade); err != nil || year < 0 || year%10 != 0 {
+			return errors.New("invalid decade parameter, must be a year divisible by 10")
+		}
+	}
+	return nil
 }
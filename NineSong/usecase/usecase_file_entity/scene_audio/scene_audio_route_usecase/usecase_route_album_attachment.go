@@ -0,0 +1,183 @@
+package scene_audio_route_usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type albumAttachmentUsecase struct {
+	repo     scene_audio_route_interface.AlbumAttachmentRepository
+	tempRepo scene_audio_route_interface.RetrievalRepository
+	timeout  time.Duration
+}
+
+func NewAlbumAttachmentUsecase(
+	repo scene_audio_route_interface.AlbumAttachmentRepository,
+	tempRepo scene_audio_route_interface.RetrievalRepository,
+	timeout time.Duration,
+) scene_audio_route_interface.AlbumAttachmentUsecase {
+	return &albumAttachmentUsecase{repo: repo, tempRepo: tempRepo, timeout: timeout}
+}
+
+func (uc *albumAttachmentUsecase) List(ctx context.Context, albumId string) ([]scene_audio_db_models.AlbumAttachmentMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if err := uc.requireAlbum(ctx, albumId); err != nil {
+		return nil, err
+	}
+	return uc.repo.GetByAlbum(ctx, albumId)
+}
+
+func (uc *albumAttachmentUsecase) Open(ctx context.Context, albumId, attachmentId string) (*scene_audio_db_models.AlbumAttachmentMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	attachment, err := uc.repo.Get(ctx, albumId, attachmentId)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(attachment.Path)
+	if err != nil {
+		return nil, fmt.Errorf("attachment file %w", domain.ErrNotFound)
+	}
+	defer f.Close()
+
+	// 目录附件可能在两次扫描之间被替换，按当前内容重新校验
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("read attachment failed: %w", err)
+	}
+	if attachment.ContentType, err = scene_audio_db_models.DetectAttachmentType(attachment.Name, head[:n]); err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+func (uc *albumAttachmentUsecase) Upload(ctx context.Context, albumId, userId, name string, size int64, r io.Reader) (*scene_audio_db_models.AlbumAttachmentMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	name = filepath.Base(filepath.Clean(strings.TrimSpace(name)))
+	if name == "" || name == "." || name == string(filepath.Separator) {
+		return nil, errors.New("attachment name is required")
+	}
+	if size > scene_audio_db_models.AttachmentMaxSize {
+		return nil, scene_audio_db_models.ErrAttachmentTooLarge
+	}
+	if err := uc.requireAlbum(ctx, albumId); err != nil {
+		return nil, err
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("read upload failed: %w", err)
+	}
+	head = head[:n]
+	contentType, err := scene_audio_db_models.DetectAttachmentType(name, head)
+	if err != nil {
+		return nil, err
+	}
+
+	coverDir, err := uc.tempRepo.GetStreamTempPath(ctx, "cover")
+	if err != nil || coverDir == "" {
+		return nil, errors.New("cover temp folder is not configured")
+	}
+	attachment := &scene_audio_db_models.AlbumAttachmentMetadata{
+		ID:          primitive.NewObjectID(),
+		AlbumID:     albumId,
+		Name:        name,
+		ContentType: contentType,
+		Source:      scene_audio_db_models.AttachmentSourceUpload,
+		UploadedBy:  userId,
+	}
+	dir := filepath.Join(coverDir, "attachments", albumId)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create attachment folder failed: %w", err)
+	}
+	attachment.Path = filepath.Join(dir, attachment.ID.Hex()+strings.ToLower(filepath.Ext(name)))
+	if attachment.Size, err = writeAttachment(attachment.Path, io.MultiReader(bytes.NewReader(head), r)); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	attachment.CreatedAt = now
+	attachment.UpdatedAt = now
+	if err := uc.repo.Insert(ctx, attachment); err != nil {
+		_ = os.Remove(attachment.Path)
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// writeAttachment 先写入临时文件，超过上限时删除，完整写入后再重命名
+func writeAttachment(path string, r io.Reader) (int64, error) {
+	tmp := path + ".partial"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return 0, fmt.Errorf("create attachment failed: %w", err)
+	}
+	written, err := io.Copy(f, io.LimitReader(r, scene_audio_db_models.AttachmentMaxSize+1))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && written > scene_audio_db_models.AttachmentMaxSize {
+		err = scene_audio_db_models.ErrAttachmentTooLarge
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		if errors.Is(err, scene_audio_db_models.ErrAttachmentTooLarge) {
+			return 0, err
+		}
+		return 0, fmt.Errorf("write attachment failed: %w", err)
+	}
+	return written, nil
+}
+
+func (uc *albumAttachmentUsecase) Delete(ctx context.Context, albumId, attachmentId string) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	attachment, err := uc.repo.Get(ctx, albumId, attachmentId)
+	if err != nil {
+		return err
+	}
+	if attachment.Source != scene_audio_db_models.AttachmentSourceUpload {
+		return errors.New("folder attachments are removed by deleting the file and rescanning")
+	}
+	if err := uc.repo.Delete(ctx, attachmentId); err != nil {
+		return err
+	}
+	if err := os.Remove(attachment.Path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove attachment file failed: %w", err)
+	}
+	return nil
+}
+
+func (uc *albumAttachmentUsecase) requireAlbum(ctx context.Context, albumId string) error {
+	exists, err := uc.repo.AlbumExists(ctx, albumId)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("album %w", domain.ErrNotFound)
+	}
+	return nil
+}
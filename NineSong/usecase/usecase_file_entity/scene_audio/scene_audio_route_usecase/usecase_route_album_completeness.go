@@ -0,0 +1,72 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 每页专辑都可能触发 MusicBrainz 请求（每秒一次），限制单次比对数量
+const maxCompletenessPageSize = 50
+
+type albumCompletenessUsecase struct {
+	repo    scene_audio_route_interface.AlbumCompletenessRepository
+	timeout time.Duration
+}
+
+func NewAlbumCompletenessUsecase(repo scene_audio_route_interface.AlbumCompletenessRepository, timeout time.Duration) scene_audio_route_interface.AlbumCompletenessRepository {
+	return &albumCompletenessUsecase{
+		repo:    repo,
+		timeout: timeout,
+	}
+}
+
+func (uc *albumCompletenessUsecase) GetIncompleteAlbums(
+	ctx context.Context,
+	start, end string,
+) ([]scene_audio_route_models.AlbumCompletenessMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	startInt, err := strconv.Atoi(start)
+	if err != nil || startInt < 0 {
+		return nil, errors.New("invalid start parameter")
+	}
+	endInt, err := strconv.Atoi(end)
+	if err != nil || endInt <= startInt {
+		return nil, errors.New("invalid end parameter")
+	}
+	if endInt-startInt > maxCompletenessPageSize {
+		return nil, errors.New("page size exceeds maximum of 50 albums")
+	}
+
+	albums, err := uc.repo.GetIncompleteAlbums(ctx, start, end)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to compare album tracks")
+	}
+	return albums, nil
+}
+
+func (uc *albumCompletenessUsecase) GetAlbumMissingTracks(
+	ctx context.Context,
+	albumId string,
+) (*scene_audio_route_models.AlbumCompletenessMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if _, err := primitive.ObjectIDFromHex(albumId); err != nil {
+		return nil, errors.New("invalid album id format")
+	}
+
+	album, err := uc.repo.GetAlbumMissingTracks(ctx, albumId)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to compare album tracks")
+	}
+	return album, nil
+}
@@ -7,27 +7,70 @@ import (
 	"time"
 
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_subsonic/scene_audio_subsonic_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_subsonic/scene_audio_subsonic_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cache_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/event_util"
 )
 
 type annotationUsecase struct {
-	repo    scene_audio_route_interface.AnnotationRepository
-	timeout time.Duration
+	repo      scene_audio_route_interface.AnnotationRepository
+	timeout   time.Duration
+	forwarder scene_audio_subsonic_interface.SubsonicForwarder
 }
 
-func NewAnnotationUsecase(repo scene_audio_route_interface.AnnotationRepository, timeout time.Duration) scene_audio_route_interface.AnnotationRepository {
+func NewAnnotationUsecase(
+	repo scene_audio_route_interface.AnnotationRepository,
+	timeout time.Duration,
+	forwarder scene_audio_subsonic_interface.SubsonicForwarder,
+) scene_audio_route_interface.AnnotationRepository {
 	return &annotationUsecase{
-		repo:    repo,
-		timeout: timeout,
+		repo:      repo,
+		timeout:   timeout,
+		forwarder: forwarder,
 	}
 }
 
 func (uc *annotationUsecase) validateItemType(itemType string) error {
-	validTypes := map[string]bool{"artist": true, "album": true, "media": true}
-	if !validTypes[itemType] {
-		return errors.New("invalid item_type, must be artist/album/media")
+	_, err := scene_audio_route_models.ParseAnnotationItemType(itemType)
+	return err
+}
+
+// forwardItemTypes 上游 Subsonic 支持收藏的条目类型，其他类型只记录本地注解
+var forwardItemTypes = map[scene_audio_route_models.AnnotationItemType]bool{
+	scene_audio_route_models.AnnotationItemMedia:  true,
+	scene_audio_route_models.AnnotationItemAlbum:  true,
+	scene_audio_route_models.AnnotationItemArtist: true,
+}
+
+func (uc *annotationUsecase) forwardStarred(ctx context.Context, itemId string, itemType scene_audio_route_models.AnnotationItemType, starred bool) {
+	if !forwardItemTypes[itemType] {
+		return
 	}
-	return nil
+	action := scene_audio_subsonic_models.ForwardActionStar
+	if !starred {
+		action = scene_audio_subsonic_models.ForwardActionUnstar
+	}
+	uc.forwarder.Forward(ctx, action, itemId, string(itemType), time.Time{})
+}
+
+// invalidateFilterCounts 收藏、评分与播放记录会影响列表筛选计数及列表排序结果
+func invalidateFilterCounts(ctx context.Context, err error) {
+	if err == nil {
+		cache_util.Invalidate(ctx, cache_util.NamespaceFilterCounts, cache_util.NamespaceLists)
+	}
+}
+
+// publishAnnotation 通知在线客户端刷新收藏与评分状态
+func publishAnnotation(ctx context.Context, itemId, itemType string, starred *bool, rating *int) {
+	event_util.Publish(ctx, event_util.TopicAnnotation, scene_audio_route_models.AnnotationEvent{
+		ItemID:   itemId,
+		ItemType: itemType,
+		Starred:  starred,
+		Rating:   rating,
+	})
 }
+
 func validateRating(rating int) error {
 	if rating < 0 || rating > 5 {
 		return errors.New("rating must be between 0-5")
@@ -46,7 +89,79 @@ func (uc *annotationUsecase) UpdateStarred(
 	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
 	defer cancel()
 
-	return uc.repo.UpdateStarred(ctx, itemId, itemType)
+	updated, err := uc.repo.UpdateStarred(ctx, itemId, itemType)
+	invalidateFilterCounts(ctx, err)
+	if err == nil {
+		uc.forwardStarred(ctx, itemId, scene_audio_route_models.AnnotationItemType(itemType), true)
+		starred := true
+		publishAnnotation(ctx, itemId, itemType, &starred, nil)
+	}
+	return updated, err
+}
+
+func (uc *annotationUsecase) SetStarred(
+	ctx context.Context,
+	itemId, itemType string,
+	starred bool,
+) (*scene_audio_route_models.AnnotationMetadata, error) {
+	if err := uc.validateItemType(itemType); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	annotation, err := uc.repo.SetStarred(ctx, itemId, itemType, starred)
+	invalidateFilterCounts(ctx, err)
+	if err == nil {
+		uc.forwardStarred(ctx, itemId, scene_audio_route_models.AnnotationItemType(itemType), starred)
+		publishAnnotation(ctx, itemId, itemType, &starred, nil)
+	}
+	return annotation, err
+}
+
+func (uc *annotationUsecase) GetAnnotation(
+	ctx context.Context,
+	itemId string,
+	itemType scene_audio_route_models.AnnotationItemType,
+) (*scene_audio_route_models.AnnotationMetadata, error) {
+	if err := uc.validateItemType(string(itemType)); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	return uc.repo.GetAnnotation(ctx, itemId, itemType)
+}
+
+func (uc *annotationUsecase) SetAnnotation(
+	ctx context.Context,
+	itemId string,
+	itemType scene_audio_route_models.AnnotationItemType,
+	update scene_audio_route_models.AnnotationUpdate,
+) (*scene_audio_route_models.AnnotationMetadata, error) {
+	if err := uc.validateItemType(string(itemType)); err != nil {
+		return nil, err
+	}
+	if update.Rating != nil {
+		if err := validateRating(*update.Rating); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	annotation, err := uc.repo.SetAnnotation(ctx, itemId, itemType, update)
+	invalidateFilterCounts(ctx, err)
+	if err == nil && update.Starred != nil {
+		uc.forwardStarred(ctx, itemId, itemType, *update.Starred)
+	}
+	if err == nil && (update.Starred != nil || update.Rating != nil) {
+		publishAnnotation(ctx, itemId, string(itemType), update.Starred, update.Rating)
+	}
+	return annotation, err
 }
 
 func (uc *annotationUsecase) UpdateUnStarred(
@@ -60,7 +175,14 @@ func (uc *annotationUsecase) UpdateUnStarred(
 	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
 	defer cancel()
 
-	return uc.repo.UpdateUnStarred(ctx, itemId, itemType)
+	updated, err := uc.repo.UpdateUnStarred(ctx, itemId, itemType)
+	invalidateFilterCounts(ctx, err)
+	if err == nil {
+		uc.forwardStarred(ctx, itemId, scene_audio_route_models.AnnotationItemType(itemType), false)
+		starred := false
+		publishAnnotation(ctx, itemId, itemType, &starred, nil)
+	}
+	return updated, err
 }
 
 func (uc *annotationUsecase) UpdateRating(
@@ -79,7 +201,32 @@ func (uc *annotationUsecase) UpdateRating(
 	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
 	defer cancel()
 
-	return uc.repo.UpdateRating(ctx, itemId, itemType, rating)
+	updated, err := uc.repo.UpdateRating(ctx, itemId, itemType, rating)
+	invalidateFilterCounts(ctx, err)
+	if err == nil {
+		publishAnnotation(ctx, itemId, itemType, nil, &rating)
+	}
+	return updated, err
+}
+
+func (uc *annotationUsecase) RateMediaFile(
+	ctx context.Context,
+	mediaFileId string,
+	rating int,
+) (*scene_audio_route_models.MediaFileRating, error) {
+	if err := validateRating(rating); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	result, err := uc.repo.RateMediaFile(ctx, mediaFileId, rating)
+	invalidateFilterCounts(ctx, err)
+	if err == nil {
+		publishAnnotation(ctx, mediaFileId, string(scene_audio_route_models.AnnotationItemMedia), nil, &rating)
+	}
+	return result, err
 }
 
 func (uc *annotationUsecase) UpdateScrobble(
@@ -93,7 +240,9 @@ func (uc *annotationUsecase) UpdateScrobble(
 	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
 	defer cancel()
 
-	return uc.repo.UpdateScrobble(ctx, itemId, itemType)
+	updated, err := uc.repo.UpdateScrobble(ctx, itemId, itemType)
+	invalidateFilterCounts(ctx, err)
+	return updated, err
 }
 
 func (uc *annotationUsecase) UpdateCompleteScrobble(
@@ -107,7 +256,9 @@ func (uc *annotationUsecase) UpdateCompleteScrobble(
 	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
 	defer cancel()
 
-	return uc.repo.UpdateCompleteScrobble(ctx, itemId, itemType)
+	updated, err := uc.repo.UpdateCompleteScrobble(ctx, itemId, itemType)
+	invalidateFilterCounts(ctx, err)
+	return updated, err
 }
 
 func (uc *annotationUsecase) UpdateTagSource(
@@ -6,8 +6,11 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cache_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
 )
 
 type ArtistUsecase struct {
@@ -58,7 +61,14 @@ func (uc *ArtistUsecase) GetArtistItems(
 		}
 	}
 
-	return uc.repo.GetArtistItems(ctx, start, end, sort, order, search, starred)
+	key := cache_util.Key("artist", domain.UserIDFromContext(ctx), start, end, sort, order, search, starred)
+	return cache_util.GetOrLoad(ctx, cache_util.NamespaceLists, key, cache_util.DefaultTTL(),
+		func() ([]scene_audio_route_models.ArtistMetadata, error) {
+			return cache_util.GetOrStale(ctx, cache_util.NamespaceStaleLists, key, cache_util.StaleListTTL,
+				func() ([]scene_audio_route_models.ArtistMetadata, error) {
+					return uc.repo.GetArtistItems(ctx, start, end, sort, order, search, starred)
+				}, mongo.IsUnavailable)
+		})
 }
 
 func (uc *ArtistUsecase) GetArtistFilterItemsCount(
@@ -77,3 +87,9 @@ func (uc *ArtistUsecase) GetArtistFilterItemsCount(
 
 	return uc.repo.GetArtistFilterItemsCount(ctx, search, starred)
 }
+
+func (uc *ArtistUsecase) GetArtist(ctx context.Context, artistId string) (*scene_audio_route_models.ArtistMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+	return uc.repo.GetArtist(ctx, artistId)
+}
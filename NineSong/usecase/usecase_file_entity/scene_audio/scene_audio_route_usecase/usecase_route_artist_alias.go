@@ -0,0 +1,150 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cache_util"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const maxArtistAliasLength = 255
+
+type artistAliasUsecase struct {
+	repo    scene_audio_route_interface.ArtistAliasRepository
+	timeout time.Duration
+}
+
+func NewArtistAliasUsecase(repo scene_audio_route_interface.ArtistAliasRepository, timeout time.Duration) scene_audio_route_interface.ArtistAliasRepository {
+	return &artistAliasUsecase{
+		repo:    repo,
+		timeout: timeout,
+	}
+}
+
+func (uc *artistAliasUsecase) GetArtistAliases(ctx context.Context, artistId string) ([]scene_audio_route_models.ArtistAlias, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if err := validateArtistID(artistId); err != nil {
+		return nil, err
+	}
+
+	aliases, err := uc.repo.GetArtistAliases(ctx, artistId)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to fetch artist aliases")
+	}
+	return aliases, nil
+}
+
+func (uc *artistAliasUsecase) AddArtistAlias(
+	ctx context.Context,
+	artistId string,
+	alias scene_audio_route_models.ArtistAlias,
+) ([]scene_audio_route_models.ArtistAlias, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	alias.Name = strings.TrimSpace(alias.Name)
+	if alias.Source == "" {
+		alias.Source = scene_audio_route_models.ArtistAliasSourceManual
+	}
+
+	validations := []func() error{
+		func() error { return validateArtistID(artistId) },
+		func() error {
+			if alias.Name == "" {
+				return errors.New("alias name is required")
+			}
+			if len(alias.Name) > maxArtistAliasLength {
+				return errors.New("alias name too long")
+			}
+			return nil
+		},
+	}
+	for _, validate := range validations {
+		if err := validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	aliases, err := uc.repo.AddArtistAlias(ctx, artistId, alias)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to add artist alias")
+	}
+	return aliases, nil
+}
+
+func (uc *artistAliasUsecase) RemoveArtistAlias(ctx context.Context, artistId string, name string) ([]scene_audio_route_models.ArtistAlias, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if err := validateArtistID(artistId); err != nil {
+		return nil, err
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("alias name is required")
+	}
+
+	aliases, err := uc.repo.RemoveArtistAlias(ctx, artistId, name)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to remove artist alias")
+	}
+	return aliases, nil
+}
+
+func (uc *artistAliasUsecase) SyncMusicBrainzAliases(ctx context.Context, artistId string) ([]scene_audio_route_models.ArtistAlias, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if err := validateArtistID(artistId); err != nil {
+		return nil, err
+	}
+
+	aliases, err := uc.repo.SyncMusicBrainzAliases(ctx, artistId)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to sync musicbrainz aliases")
+	}
+	return aliases, nil
+}
+
+func (uc *artistAliasUsecase) MergeArtists(ctx context.Context, sourceId string, targetId string) (*scene_audio_route_models.ArtistMergeResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	validations := []func() error{
+		func() error { return validateArtistID(sourceId) },
+		func() error { return validateArtistID(targetId) },
+		func() error {
+			if sourceId == targetId {
+				return errors.New("cannot merge an artist into itself")
+			}
+			return nil
+		},
+	}
+	for _, validate := range validations {
+		if err := validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := uc.repo.MergeArtists(ctx, sourceId, targetId)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to merge artists")
+	}
+	cache_util.Invalidate(ctx, cache_util.NamespaceFilterCounts, cache_util.NamespaceLists)
+	return result, nil
+}
+
+func validateArtistID(artistId string) error {
+	if _, err := primitive.ObjectIDFromHex(artistId); err != nil {
+		return errors.New("invalid artist id format")
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/sync/errgroup"
+)
+
+type artistPageUsecase struct {
+	artists        scene_audio_route_interface.ArtistRepository
+	albums         scene_audio_route_interface.AlbumRepository
+	mediaFiles     scene_audio_route_interface.MediaFileRepository
+	recommendation scene_audio_route_interface.RecommendationUsecase
+	timeout        time.Duration
+}
+
+func NewArtistPageUsecase(
+	artists scene_audio_route_interface.ArtistRepository,
+	albums scene_audio_route_interface.AlbumRepository,
+	mediaFiles scene_audio_route_interface.MediaFileRepository,
+	recommendation scene_audio_route_interface.RecommendationUsecase,
+	timeout time.Duration,
+) scene_audio_route_interface.ArtistPageUsecase {
+	return &artistPageUsecase{
+		artists:        artists,
+		albums:         albums,
+		mediaFiles:     mediaFiles,
+		recommendation: recommendation,
+		timeout:        timeout,
+	}
+}
+
+// GetArtistPage 四部分并行查询，任一失败即整体失败，艺术家不存在时返回其 not found 错误
+func (uc *artistPageUsecase) GetArtistPage(
+	ctx context.Context,
+	artistId string,
+	limits scene_audio_route_models.ArtistPageLimits,
+) (*scene_audio_route_models.ArtistPage, error) {
+	if _, err := primitive.ObjectIDFromHex(artistId); err != nil {
+		return nil, errors.New("invalid artist id format")
+	}
+	albumLimit := clampPageLimit(limits.Albums, scene_audio_route_models.ArtistPageDefaultAlbums, scene_audio_route_models.ArtistPageMaxAlbums)
+	songLimit := clampPageLimit(limits.TopSongs, scene_audio_route_models.ArtistPageDefaultTopSongs, scene_audio_route_models.ArtistPageMaxTopSongs)
+
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	page := &scene_audio_route_models.ArtistPage{
+		Albums:         make([]scene_audio_route_models.AlbumMetadata, 0),
+		TopSongs:       make([]scene_audio_route_models.MediaFileMetadata, 0),
+		SimilarArtists: make([]scene_audio_route_models.SimilarArtist, 0),
+	}
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		artist, err := uc.artists.GetArtist(gctx, artistId)
+		if err != nil {
+			return err
+		}
+		page.Artist = *artist
+		return nil
+	})
+	g.Go(func() error {
+		albums, err := uc.albums.GetAlbumItems(gctx, "0", strconv.Itoa(albumLimit), "original_year", "desc",
+			"", "", artistId, "", "", "", "", "", "")
+		if albums != nil {
+			page.Albums = albums
+		}
+		return err
+	})
+	g.Go(func() error {
+		songs, err := uc.mediaFiles.GetMediaFileItems(gctx, "0", strconv.Itoa(songLimit), "play_count", "desc",
+			"", "", "", artistId, "", "", "", "")
+		if songs != nil {
+			page.TopSongs = songs
+		}
+		return err
+	})
+	g.Go(func() error {
+		similar, err := uc.recommendation.GetSimilarArtists(gctx, artistId, limits.Similar)
+		if similar != nil {
+			page.SimilarArtists = similar
+		}
+		return err
+	})
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+func clampPageLimit(limit, defaultLimit, maxLimit int) int {
+	if limit <= 0 {
+		return defaultLimit
+	}
+	return min(limit, maxLimit)
+}
@@ -0,0 +1,117 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type audiobookUsecase struct {
+	repo    scene_audio_route_interface.AudiobookRepository
+	timeout time.Duration
+}
+
+func NewAudiobookUsecase(repo scene_audio_route_interface.AudiobookRepository, timeout time.Duration) scene_audio_route_interface.AudiobookUsecase {
+	return &audiobookUsecase{repo: repo, timeout: timeout}
+}
+
+func (uc *audiobookUsecase) GetAudiobooks(
+	ctx context.Context,
+	userId, sort string,
+	start, count int,
+) ([]scene_audio_route_models.Audiobook, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if userId == "" {
+		return nil, 0, errors.New("user id is required")
+	}
+	switch sort {
+	case "":
+		sort = scene_audio_route_models.AudiobookSortReading
+	case scene_audio_route_models.AudiobookSortReading, scene_audio_route_models.AudiobookSortRecent:
+	default:
+		return nil, 0, fmt.Errorf("%w: unsupported sort %q", scene_audio_route_models.ErrInvalidAudiobook, sort)
+	}
+	if start < 0 || count < 0 {
+		return nil, 0, fmt.Errorf("%w: invalid paging", scene_audio_route_models.ErrInvalidAudiobook)
+	}
+
+	books, total, err := uc.repo.GetAudiobooks(ctx, userId, sort, start, count)
+	if err != nil {
+		return nil, 0, domain.WrapDomainError(err, "failed to fetch audiobooks")
+	}
+	return books, total, nil
+}
+
+func (uc *audiobookUsecase) GetAudiobook(ctx context.Context, userId, id string) (*scene_audio_route_models.Audiobook, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return nil, scene_audio_route_models.ErrInvalidAudiobook
+	}
+	return uc.repo.GetAudiobook(ctx, userId, id)
+}
+
+func (uc *audiobookUsecase) SaveProgress(
+	ctx context.Context,
+	userId, bookId, mediaFileId string,
+	position float64,
+	finished bool,
+) (*scene_audio_route_models.AudiobookProgress, error) {
+	if math.IsNaN(position) || math.IsInf(position, 0) || position < 0 {
+		return nil, fmt.Errorf("%w: invalid position", scene_audio_route_models.ErrInvalidAudiobook)
+	}
+	book, err := uc.GetAudiobook(ctx, userId, bookId)
+	if err != nil {
+		return nil, err
+	}
+
+	index := -1
+	for i, track := range book.Tracks {
+		if track.ID.Hex() == mediaFileId {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, scene_audio_route_models.ErrAudiobookTrackMismatch
+	}
+	// 进度按秒上报，曲目时长按纳秒存储
+	duration := time.Duration(book.Tracks[index].Duration).Seconds()
+	if duration > 0 && position > duration {
+		position = duration
+	}
+	if index == len(book.Tracks)-1 && duration > 0 &&
+		duration-position < scene_audio_route_models.AudiobookFinishThreshold {
+		finished = true
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+	return uc.repo.SaveProgress(ctx, scene_audio_route_models.AudiobookProgress{
+		UserID:      userId,
+		BookID:      bookId,
+		MediaFileID: mediaFileId,
+		Position:    position,
+		Finished:    finished,
+	})
+}
+
+func (uc *audiobookUsecase) DeleteProgress(ctx context.Context, userId, bookId string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if _, err := primitive.ObjectIDFromHex(bookId); err != nil {
+		return false, scene_audio_route_models.ErrInvalidAudiobook
+	}
+	return uc.repo.DeleteProgress(ctx, userId, bookId)
+}
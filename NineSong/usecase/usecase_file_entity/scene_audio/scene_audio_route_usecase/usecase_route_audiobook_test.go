@@ -0,0 +1,69 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type audiobookRepoStub struct {
+	book  *scene_audio_route_models.Audiobook
+	saved scene_audio_route_models.AudiobookProgress
+}
+
+func (s *audiobookRepoStub) GetAudiobooks(context.Context, string, string, int, int) ([]scene_audio_route_models.Audiobook, int, error) {
+	return nil, 0, nil
+}
+
+func (s *audiobookRepoStub) GetAudiobook(context.Context, string, string) (*scene_audio_route_models.Audiobook, error) {
+	return s.book, nil
+}
+
+func (s *audiobookRepoStub) SaveProgress(_ context.Context, progress scene_audio_route_models.AudiobookProgress) (*scene_audio_route_models.AudiobookProgress, error) {
+	s.saved = progress
+	return &progress, nil
+}
+
+func (s *audiobookRepoStub) DeleteProgress(context.Context, string, string) (bool, error) {
+	return false, nil
+}
+
+func TestAudiobookSaveProgressSeconds(t *testing.T) {
+	first, last := primitive.NewObjectID(), primitive.NewObjectID()
+	// 曲目时长与扫描写入一致，按纳秒存储
+	book := &scene_audio_route_models.Audiobook{
+		ID: primitive.NewObjectID().Hex(),
+		Tracks: []scene_audio_route_models.MediaFileMetadata{
+			{ID: first, Duration: float64(10 * time.Minute)},
+			{ID: last, Duration: float64(20 * time.Minute)},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		mediaFileID  string
+		position     float64
+		wantPosition float64
+		wantFinished bool
+	}{
+		{name: "position within track kept", mediaFileID: first.Hex(), position: 120, wantPosition: 120},
+		{name: "position clamped to track seconds", mediaFileID: first.Hex(), position: 900, wantPosition: 600},
+		{name: "middle of last track not finished", mediaFileID: last.Hex(), position: 600, wantPosition: 600},
+		{name: "near end of last track finished", mediaFileID: last.Hex(), position: 1190, wantPosition: 1190, wantFinished: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &audiobookRepoStub{book: book}
+			uc := NewAudiobookUsecase(repo, time.Second)
+			_, err := uc.SaveProgress(context.Background(), "user", book.ID, tt.mediaFileID, tt.position, false)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPosition, repo.saved.Position)
+			assert.Equal(t, tt.wantFinished, repo.saved.Finished)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type bookmarkUsecase struct {
+	repo    scene_audio_route_interface.BookmarkRepository
+	timeout time.Duration
+}
+
+func NewBookmarkUsecase(repo scene_audio_route_interface.BookmarkRepository, timeout time.Duration) scene_audio_route_interface.BookmarkUsecase {
+	return &bookmarkUsecase{repo: repo, timeout: timeout}
+}
+
+func (uc *bookmarkUsecase) GetBookmarks(
+	ctx context.Context,
+	userId, itemType string,
+	start, count int,
+) ([]scene_audio_route_models.Bookmark, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if userId == "" {
+		return nil, 0, errors.New("user id is required")
+	}
+	if itemType != "" && !validBookmarkItemType(itemType) {
+		return nil, 0, fmt.Errorf("%w: unsupported item type %q", scene_audio_route_models.ErrInvalidBookmark, itemType)
+	}
+	if start < 0 || count < 0 {
+		return nil, 0, fmt.Errorf("%w: invalid paging", scene_audio_route_models.ErrInvalidBookmark)
+	}
+
+	bookmarks, total, err := uc.repo.GetBookmarks(ctx, userId, itemType, start, count)
+	if err != nil {
+		return nil, 0, domain.WrapDomainError(err, "failed to fetch bookmarks")
+	}
+	return bookmarks, total, nil
+}
+
+func (uc *bookmarkUsecase) GetBookmark(ctx context.Context, userId, itemId string) (*scene_audio_route_models.Bookmark, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if _, err := primitive.ObjectIDFromHex(itemId); err != nil {
+		return nil, fmt.Errorf("%w: invalid item id", scene_audio_route_models.ErrInvalidBookmark)
+	}
+	return uc.repo.GetBookmark(ctx, userId, itemId)
+}
+
+func (uc *bookmarkUsecase) SaveBookmark(
+	ctx context.Context,
+	userId, itemType, itemId string,
+	position float64,
+	comment string,
+) (*scene_audio_route_models.Bookmark, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if itemType == "" {
+		itemType = scene_audio_route_models.BookmarkItemMedia
+	}
+	if !validBookmarkItemType(itemType) {
+		return nil, fmt.Errorf("%w: unsupported item type %q", scene_audio_route_models.ErrInvalidBookmark, itemType)
+	}
+	if _, err := primitive.ObjectIDFromHex(itemId); err != nil {
+		return nil, fmt.Errorf("%w: invalid item id", scene_audio_route_models.ErrInvalidBookmark)
+	}
+	if math.IsNaN(position) || math.IsInf(position, 0) || position < 0 {
+		return nil, fmt.Errorf("%w: invalid position", scene_audio_route_models.ErrInvalidBookmark)
+	}
+	comment = strings.TrimSpace(comment)
+	if utf8.RuneCountInString(comment) > scene_audio_route_models.BookmarkCommentMaxLength {
+		return nil, fmt.Errorf("%w: comment exceeds %d characters",
+			scene_audio_route_models.ErrInvalidBookmark, scene_audio_route_models.BookmarkCommentMaxLength)
+	}
+
+	duration, err := uc.repo.GetItemDuration(ctx, userId, itemType, itemId)
+	if err != nil {
+		return nil, err
+	}
+	if duration > 0 && position > duration {
+		position = duration
+	}
+	return uc.repo.SaveBookmark(ctx, scene_audio_route_models.Bookmark{
+		UserID:   userId,
+		ItemID:   itemId,
+		ItemType: itemType,
+		Position: position,
+		Comment:  comment,
+	})
+}
+
+func (uc *bookmarkUsecase) DeleteBookmark(ctx context.Context, userId, itemId string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if _, err := primitive.ObjectIDFromHex(itemId); err != nil {
+		return false, fmt.Errorf("%w: invalid item id", scene_audio_route_models.ErrInvalidBookmark)
+	}
+	return uc.repo.DeleteBookmark(ctx, userId, itemId)
+}
+
+func validBookmarkItemType(itemType string) bool {
+	return itemType == scene_audio_route_models.BookmarkItemMedia ||
+		itemType == scene_audio_route_models.BookmarkItemPodcastEpisode
+}
@@ -0,0 +1,398 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_auth"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_transcode/scene_audio_transcode_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cast_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/internal_system/token_util"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// castNativeTypes 默认媒体接收端可直接播放的格式，其他格式投屏时转码为 MP3
+var castNativeTypes = map[string]string{
+	"mp3":  "audio/mpeg",
+	"flac": "audio/flac",
+	"m4a":  "audio/mp4",
+	"m4b":  "audio/mp4",
+	"mp4":  "audio/mp4",
+	"aac":  "audio/aac",
+	"ogg":  "audio/ogg",
+	"oga":  "audio/ogg",
+	"opus": "audio/ogg",
+	"wav":  "audio/wav",
+	"webm": "audio/webm",
+}
+
+const castFallbackFormat = "mp3"
+
+type castSession struct {
+	client     *cast_util.Client
+	device     scene_audio_route_models.CastDevice
+	mediaFile  *scene_audio_route_models.MediaFileMetadata
+	transcoded bool
+}
+
+// castUsecase 设备列表与投屏会话只保存在内存中；每台设备一个会话，由所有用户共享控制，
+// 设备断开后会话自动移除
+type castUsecase struct {
+	repo        scene_audio_route_interface.CastRepository
+	baseURL     string
+	tokenSecret string
+	timeout     time.Duration
+
+	mu           sync.Mutex
+	devices      []scene_audio_route_models.CastDevice
+	discoveredAt time.Time
+	sessions     map[string]*castSession
+}
+
+// NewCastUsecase baseURL 为设备访问本服务的地址，串流与封面地址以此拼接
+func NewCastUsecase(
+	repo scene_audio_route_interface.CastRepository,
+	baseURL string,
+	tokenSecret string,
+	timeout time.Duration,
+) scene_audio_route_interface.CastUsecase {
+	return &castUsecase{
+		repo:        repo,
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+		tokenSecret: tokenSecret,
+		timeout:     timeout,
+		sessions:    make(map[string]*castSession),
+	}
+}
+
+func (uc *castUsecase) Devices(ctx context.Context, refresh bool) ([]scene_audio_route_models.CastDevice, error) {
+	uc.mu.Lock()
+	cached := uc.devices
+	fresh := time.Since(uc.discoveredAt) < scene_audio_route_models.CastDeviceCacheTTL*time.Second
+	uc.mu.Unlock()
+
+	if refresh || !fresh || cached == nil {
+		found, err := cast_util.Discover(ctx, scene_audio_route_models.CastDiscoveryTimeout*time.Second)
+		if err != nil {
+			return nil, err
+		}
+		devices := make([]scene_audio_route_models.CastDevice, 0, len(found))
+		for _, d := range found {
+			devices = append(devices, scene_audio_route_models.CastDevice{
+				ID:    d.ID,
+				Name:  d.Name,
+				Model: d.Model,
+				Host:  d.Host,
+				Port:  d.Port,
+			})
+		}
+		uc.mu.Lock()
+		uc.devices, uc.discoveredAt = devices, time.Now()
+		uc.mu.Unlock()
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	devices := make([]scene_audio_route_models.CastDevice, len(uc.devices))
+	for i, d := range uc.devices {
+		_, d.Session = uc.sessions[d.ID]
+		devices[i] = d
+	}
+	// 搜索未应答但仍保持连接的设备同样列出
+	for id, session := range uc.sessions {
+		if !containsCastDevice(devices, id) {
+			device := session.device
+			device.Session = true
+			devices = append(devices, device)
+		}
+	}
+	return devices, nil
+}
+
+func (uc *castUsecase) Status(ctx context.Context, deviceId string) (*scene_audio_route_models.CastStatus, error) {
+	session, err := uc.session(deviceId)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+	if err := session.client.Refresh(ctx); err != nil {
+		log.Printf("Cast 状态查询失败: %s | %v", session.device.Name, err)
+	}
+	return uc.statusOf(session), nil
+}
+
+func (uc *castUsecase) Load(
+	ctx context.Context,
+	userId, deviceId, mediaFileId string,
+	opts scene_audio_route_models.CastLoadOptions,
+) (*scene_audio_route_models.CastStatus, error) {
+	userObjID, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		return nil, scene_audio_route_models.ErrInvalidCastParams
+	}
+	if _, err := primitive.ObjectIDFromHex(mediaFileId); err != nil || opts.Position < 0 || opts.MaxBitRate < 0 {
+		return nil, scene_audio_route_models.ErrInvalidCastParams
+	}
+
+	lookupCtx, cancel := context.WithTimeout(ctx, uc.timeout)
+	file, err := uc.repo.GetMediaFile(lookupCtx, mediaFileId)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+
+	media, transcoded, err := uc.castMedia(file, userObjID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := uc.connect(ctx, deviceId)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel = context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+	if err := session.client.Load(ctx, media, opts.Position); err != nil {
+		return nil, err
+	}
+
+	uc.mu.Lock()
+	session.mediaFile, session.transcoded = file, transcoded
+	uc.mu.Unlock()
+	return uc.statusOf(session), nil
+}
+
+func (uc *castUsecase) Play(ctx context.Context, deviceId string) (*scene_audio_route_models.CastStatus, error) {
+	return uc.command(ctx, deviceId, func(ctx context.Context, c *cast_util.Client) error {
+		return c.Play(ctx)
+	})
+}
+
+func (uc *castUsecase) Pause(ctx context.Context, deviceId string) (*scene_audio_route_models.CastStatus, error) {
+	return uc.command(ctx, deviceId, func(ctx context.Context, c *cast_util.Client) error {
+		return c.Pause(ctx)
+	})
+}
+
+func (uc *castUsecase) Seek(ctx context.Context, deviceId string, position float64) (*scene_audio_route_models.CastStatus, error) {
+	if position < 0 {
+		return nil, scene_audio_route_models.ErrInvalidCastParams
+	}
+	return uc.command(ctx, deviceId, func(ctx context.Context, c *cast_util.Client) error {
+		return c.Seek(ctx, position)
+	})
+}
+
+func (uc *castUsecase) SetVolume(ctx context.Context, deviceId string, volume *int, muted *bool) (*scene_audio_route_models.CastStatus, error) {
+	if (volume == nil && muted == nil) || (volume != nil && (*volume < 0 || *volume > 100)) {
+		return nil, scene_audio_route_models.ErrInvalidCastParams
+	}
+	return uc.command(ctx, deviceId, func(ctx context.Context, c *cast_util.Client) error {
+		if volume != nil {
+			if err := c.SetVolume(ctx, float64(*volume)/100); err != nil {
+				return err
+			}
+		}
+		if muted != nil {
+			return c.SetMuted(ctx, *muted)
+		}
+		return nil
+	})
+}
+
+func (uc *castUsecase) Stop(ctx context.Context, deviceId string) error {
+	session, err := uc.session(deviceId)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+	err = session.client.Stop(ctx)
+	session.client.Close()
+	if errors.Is(err, cast_util.ErrDisconnected) {
+		return nil
+	}
+	return err
+}
+
+func (uc *castUsecase) command(
+	ctx context.Context,
+	deviceId string,
+	fn func(ctx context.Context, c *cast_util.Client) error,
+) (*scene_audio_route_models.CastStatus, error) {
+	session, err := uc.session(deviceId)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+	if err := fn(ctx, session.client); err != nil {
+		if errors.Is(err, cast_util.ErrNoMedia) || errors.Is(err, cast_util.ErrDisconnected) {
+			return nil, scene_audio_route_models.ErrCastNoSession
+		}
+		return nil, err
+	}
+	return uc.statusOf(session), nil
+}
+
+func (uc *castUsecase) session(deviceId string) (*castSession, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	session, ok := uc.sessions[deviceId]
+	if !ok {
+		return nil, scene_audio_route_models.ErrCastNoSession
+	}
+	return session, nil
+}
+
+// connect 复用设备已有的连接，没有时按缓存的设备地址建立，设备不在缓存中时重新搜索一次
+func (uc *castUsecase) connect(ctx context.Context, deviceId string) (*castSession, error) {
+	if session, err := uc.session(deviceId); err == nil {
+		return session, nil
+	}
+
+	device, ok := uc.cachedDevice(deviceId)
+	if !ok {
+		if _, err := uc.Devices(ctx, true); err != nil {
+			return nil, err
+		}
+		if device, ok = uc.cachedDevice(deviceId); !ok {
+			return nil, scene_audio_route_models.ErrCastDeviceNotFound
+		}
+	}
+
+	client, err := cast_util.Dial(ctx, device.Host, device.Port)
+	if err != nil {
+		return nil, err
+	}
+	session := &castSession{client: client, device: device}
+
+	uc.mu.Lock()
+	if existing, ok := uc.sessions[deviceId]; ok {
+		// 并发请求已先建立连接
+		uc.mu.Unlock()
+		client.Close()
+		return existing, nil
+	}
+	uc.sessions[deviceId] = session
+	uc.mu.Unlock()
+
+	go func() {
+		<-client.Done()
+		uc.mu.Lock()
+		if uc.sessions[deviceId] == session {
+			delete(uc.sessions, deviceId)
+		}
+		uc.mu.Unlock()
+		log.Printf("Cast 会话结束: %s", device.Name)
+	}()
+	return session, nil
+}
+
+func (uc *castUsecase) cachedDevice(deviceId string) (scene_audio_route_models.CastDevice, bool) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	for _, d := range uc.devices {
+		if d.ID == deviceId {
+			return d, true
+		}
+	}
+	return scene_audio_route_models.CastDevice{}, false
+}
+
+// castMedia 设备无法登录，串流地址携带为当前用户签发的短期访问令牌
+func (uc *castUsecase) castMedia(
+	file *scene_audio_route_models.MediaFileMetadata,
+	userID primitive.ObjectID,
+	opts scene_audio_route_models.CastLoadOptions,
+) (cast_util.Media, bool, error) {
+	token, err := token_util.CreateAccessToken(&domain_auth.User{ID: userID}, uc.tokenSecret, scene_audio_route_models.CastStreamTokenExpiryHour)
+	if err != nil {
+		return cast_util.Media{}, false, err
+	}
+
+	query := url.Values{}
+	query.Set("media_file_id", file.ID.Hex())
+	query.Set("access_token", token)
+
+	format := opts.Format
+	contentType, native := castNativeTypes[strings.ToLower(strings.TrimPrefix(file.Suffix, "."))]
+	if !scene_audio_transcode_models.IsTranscodeFormat(format) && (!native || opts.MaxBitRate > 0) {
+		format = castFallbackFormat
+	}
+	transcoded := scene_audio_transcode_models.IsTranscodeFormat(format)
+	if transcoded {
+		profile, err := scene_audio_transcode_models.NewTranscodeProfile(format, opts.MaxBitRate)
+		if err != nil {
+			return cast_util.Media{}, false, scene_audio_route_models.ErrInvalidCastParams
+		}
+		query.Set("format", profile.Format.Name)
+		if opts.MaxBitRate > 0 {
+			query.Set("maxBitRate", strconv.Itoa(profile.BitRate))
+		}
+		contentType = profile.Format.MimeType
+	}
+
+	coverID := file.AlbumID
+	if coverID == "" {
+		coverID = file.ID.Hex()
+	}
+	return cast_util.Media{
+		ContentID:   uc.baseURL + "/media/stream?" + query.Encode(),
+		ContentType: contentType,
+		Title:       file.Title,
+		Artist:      file.Artist,
+		Album:       file.Album,
+		ImageURL:    uc.baseURL + "/coverart/" + url.PathEscape(coverID) + "?access_token=" + url.QueryEscape(token),
+		Duration:    time.Duration(file.Duration).Seconds(),
+	}, transcoded, nil
+}
+
+func (uc *castUsecase) statusOf(session *castSession) *scene_audio_route_models.CastStatus {
+	uc.mu.Lock()
+	file, transcoded := session.mediaFile, session.transcoded
+	uc.mu.Unlock()
+
+	s := session.client.Status()
+	status := &scene_audio_route_models.CastStatus{
+		DeviceID:    session.device.ID,
+		DeviceName:  session.device.Name,
+		PlayerState: s.PlayerState,
+		IdleReason:  s.IdleReason,
+		Position:    s.CurrentTime,
+		Duration:    s.Duration,
+		Volume:      int(s.Volume*100 + 0.5),
+		Muted:       s.Muted,
+		Transcoded:  transcoded,
+	}
+	if status.PlayerState == "" {
+		status.PlayerState = cast_util.PlayerStateIdle
+	}
+	if file != nil {
+		status.MediaFileID = file.ID.Hex()
+		status.Title = file.Title
+		status.Artist = file.Artist
+		if status.Duration == 0 {
+			status.Duration = time.Duration(file.Duration).Seconds()
+		}
+	}
+	return status
+}
+
+func containsCastDevice(devices []scene_audio_route_models.CastDevice, id string) bool {
+	for _, d := range devices {
+		if d.ID == id {
+			return true
+		}
+	}
+	return false
+}
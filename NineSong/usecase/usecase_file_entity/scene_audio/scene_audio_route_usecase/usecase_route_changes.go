@@ -0,0 +1,249 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 令牌格式：v2:<since> 为完整令牌，v2:<since>:<until>:<phase>:<cursor时间>:<cursor ID> 为续页令牌；时间均为毫秒时间戳。
+// 兼容旧版 v1:<since> 令牌
+const (
+	syncTokenPrefix       = "v2:"
+	legacySyncTokenPrefix = "v1:"
+)
+
+// syncToken 解码后的同步令牌；Resume 为 true 时从 Phase 的 After 之后继续，until 保持首页的快照时间
+type syncToken struct {
+	Since  time.Time
+	Resume bool
+	Until  time.Time
+	Phase  int // 0..len(ChangeFeedTypes)-1 为各实体类型，len(ChangeFeedTypes) 为删除日志
+	After  *scene_audio_route_models.ChangeCursor
+}
+
+type changesUsecase struct {
+	repo    scene_audio_route_interface.ChangesRepository
+	timeout time.Duration
+}
+
+func NewChangesUsecase(repo scene_audio_route_interface.ChangesRepository, timeout time.Duration) scene_audio_route_interface.ChangesUsecase {
+	return &changesUsecase{
+		repo:    repo,
+		timeout: timeout,
+	}
+}
+
+func (uc *changesUsecase) GetChanges(ctx context.Context, since string) (*scene_audio_route_models.ChangeFeed, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	// 先取令牌时间，查询期间的新写入留给下一次同步
+	now := time.Now().UTC()
+	since = strings.TrimSpace(since)
+	if since == "" {
+		return fullSyncFeed(now), nil
+	}
+	token, err := decodeSyncToken(since)
+	if err != nil {
+		return nil, err
+	}
+	if now.Sub(token.Since) > scene_audio_route_models.DeletionLogRetention {
+		return fullSyncFeed(now), nil
+	}
+
+	until, phase, after := now, 0, (*scene_audio_route_models.ChangeCursor)(nil)
+	if token.Resume {
+		until, phase, after = token.Until, token.Phase, token.After
+	}
+	from := token.Since.Add(-scene_audio_route_models.ChangesClockSkew)
+
+	feed := &scene_audio_route_models.ChangeFeed{
+		Token:   encodeSyncToken(syncToken{Since: until}),
+		Changes: make(map[string]scene_audio_route_models.EntityChanges),
+	}
+	for _, itemType := range scene_audio_route_models.ChangeFeedTypes {
+		feed.Changes[itemType] = scene_audio_route_models.EntityChanges{
+			Created: make([]string, 0),
+			Updated: make([]string, 0),
+			Deleted: make([]string, 0),
+		}
+	}
+
+	// 多取一条判断是否截断
+	remaining := scene_audio_route_models.ChangesPageSize
+	resume := func(phase int, after *scene_audio_route_models.ChangeCursor) *scene_audio_route_models.ChangeFeed {
+		feed.HasMore = true
+		feed.Token = encodeSyncToken(syncToken{Since: token.Since, Resume: true, Until: until, Phase: phase, After: after})
+		return feed
+	}
+
+	for ; phase < len(scene_audio_route_models.ChangeFeedTypes); phase, after = phase+1, nil {
+		itemType := scene_audio_route_models.ChangeFeedTypes[phase]
+		items, err := uc.repo.GetChangedItems(ctx, itemType, from, until, after, remaining+1)
+		if err != nil {
+			return nil, domain.WrapDomainError(err, "changes query failed")
+		}
+		truncated := len(items) > remaining
+		if truncated {
+			items = items[:remaining]
+		}
+
+		changes := feed.Changes[itemType]
+		for _, item := range items {
+			if item.CreatedAt.Before(from) {
+				changes.Updated = append(changes.Updated, item.ID.Hex())
+			} else {
+				changes.Created = append(changes.Created, item.ID.Hex())
+			}
+		}
+		feed.Changes[itemType] = changes
+		remaining -= len(items)
+
+		if truncated {
+			if len(items) > 0 {
+				last := items[len(items)-1]
+				after = &scene_audio_route_models.ChangeCursor{Time: last.UpdatedAt, ID: last.ID}
+			}
+			return resume(phase, after), nil
+		}
+	}
+
+	records, err := uc.repo.GetDeletedItems(ctx, from, until, after, remaining+1)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "changes query failed")
+	}
+	truncated := len(records) > remaining
+	if truncated {
+		records = records[:remaining]
+	}
+
+	deleted := make(map[string][]string)
+	seen := make(map[string]bool)
+	for _, record := range records {
+		key := record.ItemType + ":" + record.ItemID
+		if _, ok := feed.Changes[record.ItemType]; ok && !seen[key] {
+			seen[key] = true
+			deleted[record.ItemType] = append(deleted[record.ItemType], record.ItemID)
+		}
+	}
+	for itemType, ids := range deleted {
+		// 重建媒体库时条目会先删除再以相同ID写回，仍存在的条目不下发删除
+		existing, err := uc.repo.GetExistingItems(ctx, itemType, ids)
+		if err != nil {
+			return nil, domain.WrapDomainError(err, "changes query failed")
+		}
+		changes := feed.Changes[itemType]
+		for _, id := range ids {
+			if !existing[id] {
+				changes.Deleted = append(changes.Deleted, id)
+			}
+		}
+		feed.Changes[itemType] = changes
+	}
+
+	if truncated {
+		if len(records) > 0 {
+			last := records[len(records)-1]
+			after = &scene_audio_route_models.ChangeCursor{Time: last.DeletedAt, ID: last.ID}
+		}
+		return resume(phase, after), nil
+	}
+	return feed, nil
+}
+
+func fullSyncFeed(now time.Time) *scene_audio_route_models.ChangeFeed {
+	return &scene_audio_route_models.ChangeFeed{
+		Token:    encodeSyncToken(syncToken{Since: now}),
+		FullSync: true,
+		Changes:  make(map[string]scene_audio_route_models.EntityChanges),
+	}
+}
+
+// encodeSyncToken 令牌对客户端不透明
+func encodeSyncToken(token syncToken) string {
+	parts := []string{strconv.FormatInt(token.Since.UnixMilli(), 10)}
+	if token.Resume {
+		afterTime, afterID := "0", ""
+		if token.After != nil {
+			afterTime, afterID = strconv.FormatInt(token.After.Time.UnixMilli(), 10), token.After.ID.Hex()
+		}
+		parts = append(parts,
+			strconv.FormatInt(token.Until.UnixMilli(), 10),
+			strconv.Itoa(token.Phase),
+			afterTime,
+			afterID,
+		)
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(syncTokenPrefix + strings.Join(parts, ":")))
+}
+
+func decodeSyncToken(raw string) (syncToken, error) {
+	invalid := syncToken{}
+	decoded, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return invalid, scene_audio_route_models.ErrInvalidSyncToken
+	}
+	text := string(decoded)
+	var parts []string
+	switch {
+	case strings.HasPrefix(text, syncTokenPrefix):
+		parts = strings.Split(strings.TrimPrefix(text, syncTokenPrefix), ":")
+	case strings.HasPrefix(text, legacySyncTokenPrefix):
+		parts = []string{strings.TrimPrefix(text, legacySyncTokenPrefix)}
+	default:
+		return invalid, scene_audio_route_models.ErrInvalidSyncToken
+	}
+	if len(parts) != 1 && len(parts) != 5 {
+		return invalid, scene_audio_route_models.ErrInvalidSyncToken
+	}
+
+	latest := time.Now().Add(scene_audio_route_models.ChangesClockSkew)
+	parseTime := func(s string) (time.Time, bool) {
+		ms, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || ms < 0 {
+			return time.Time{}, false
+		}
+		t := time.UnixMilli(ms).UTC()
+		return t, !t.After(latest)
+	}
+
+	since, ok := parseTime(parts[0])
+	if !ok {
+		return invalid, scene_audio_route_models.ErrInvalidSyncToken
+	}
+	token := syncToken{Since: since}
+	if len(parts) == 1 {
+		return token, nil
+	}
+
+	until, ok := parseTime(parts[1])
+	if !ok || until.Before(since) {
+		return invalid, scene_audio_route_models.ErrInvalidSyncToken
+	}
+	phase, err := strconv.Atoi(parts[2])
+	if err != nil || phase < 0 || phase > len(scene_audio_route_models.ChangeFeedTypes) {
+		return invalid, scene_audio_route_models.ErrInvalidSyncToken
+	}
+	token.Resume, token.Until, token.Phase = true, until, phase
+	if parts[4] == "" {
+		return token, nil
+	}
+	afterTime, ok := parseTime(parts[3])
+	if !ok {
+		return invalid, scene_audio_route_models.ErrInvalidSyncToken
+	}
+	afterID, err := primitive.ObjectIDFromHex(parts[4])
+	if err != nil {
+		return invalid, scene_audio_route_models.ErrInvalidSyncToken
+	}
+	token.After = &scene_audio_route_models.ChangeCursor{Time: afterTime, ID: afterID}
+	return token, nil
+}
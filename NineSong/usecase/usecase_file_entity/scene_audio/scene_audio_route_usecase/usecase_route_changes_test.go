@@ -0,0 +1,234 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSyncTokenRoundTrip(t *testing.T) {
+	since := time.Now().Add(-time.Hour).Truncate(time.Millisecond).UTC()
+	until := since.Add(30 * time.Minute)
+	cursorID := primitive.NewObjectID()
+
+	tests := []struct {
+		name  string
+		token syncToken
+	}{
+		{name: "complete", token: syncToken{Since: since}},
+		{name: "resume without cursor", token: syncToken{Since: since, Resume: true, Until: until, Phase: 2}},
+		{
+			name: "resume with cursor",
+			token: syncToken{Since: since, Resume: true, Until: until, Phase: 1,
+				After: &scene_audio_route_models.ChangeCursor{Time: since.Add(time.Minute), ID: cursorID}},
+		},
+		{
+			name: "resume in deletion phase",
+			token: syncToken{Since: since, Resume: true, Until: until, Phase: len(scene_audio_route_models.ChangeFeedTypes),
+				After: &scene_audio_route_models.ChangeCursor{Time: until.Add(-time.Second), ID: cursorID}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded, err := decodeSyncToken(encodeSyncToken(tt.token))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.token, decoded)
+		})
+	}
+}
+
+func TestDecodeSyncTokenLegacy(t *testing.T) {
+	since := time.Now().Add(-time.Hour).Truncate(time.Millisecond).UTC()
+	raw := base64.RawURLEncoding.EncodeToString([]byte("v1:" + formatMillis(since)))
+
+	decoded, err := decodeSyncToken(raw)
+	assert.NoError(t, err)
+	assert.Equal(t, syncToken{Since: since}, decoded)
+}
+
+func TestDecodeSyncTokenInvalid(t *testing.T) {
+	now := time.Now()
+	past := formatMillis(now.Add(-time.Hour))
+	later := formatMillis(now.Add(-time.Minute))
+	future := formatMillis(now.Add(time.Hour))
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "not base64", token: "***"},
+		{name: "unknown version", token: encode("v9:" + past)},
+		{name: "not a number", token: encode("v2:abc")},
+		{name: "negative", token: encode("v2:-5")},
+		{name: "future", token: encode("v2:" + future)},
+		{name: "wrong part count", token: encode("v2:" + past + ":" + later)},
+		{name: "until before since", token: encode("v2:" + later + ":" + past + ":0:0:")},
+		{name: "phase out of range", token: encode("v2:" + past + ":" + later + ":9:0:")},
+		{name: "negative phase", token: encode("v2:" + past + ":" + later + ":-1:0:")},
+		{name: "bad cursor id", token: encode("v2:" + past + ":" + later + ":0:" + past + ":zz")},
+		{name: "bad cursor time", token: encode("v2:" + past + ":" + later + ":0:x:" + primitive.NewObjectID().Hex())},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodeSyncToken(tt.token)
+			assert.ErrorIs(t, err, scene_audio_route_models.ErrInvalidSyncToken)
+		})
+	}
+}
+
+func TestGetChangesPaging(t *testing.T) {
+	base := time.Now().Add(-time.Hour).Truncate(time.Millisecond).UTC()
+	repo := &stubChangesRepository{items: map[string][]scene_audio_route_models.ChangedItem{}}
+
+	// 同一毫秒内大量写入，分页只能依靠 _id 续页
+	total := scene_audio_route_models.ChangesPageSize + 10
+	for i := 0; i < total; i++ {
+		repo.items["media"] = append(repo.items["media"], scene_audio_route_models.ChangedItem{
+			ID:        primitive.NewObjectID(),
+			CreatedAt: base.Add(-24 * time.Hour),
+			UpdatedAt: base.Add(time.Minute),
+		})
+	}
+	album := primitive.NewObjectID()
+	repo.items["album"] = []scene_audio_route_models.ChangedItem{{ID: album, CreatedAt: base.Add(2 * time.Minute), UpdatedAt: base.Add(2 * time.Minute)}}
+	rebuilt, removed := primitive.NewObjectID(), primitive.NewObjectID()
+	repo.items["artist"] = []scene_audio_route_models.ChangedItem{{ID: rebuilt, CreatedAt: base.Add(3 * time.Minute), UpdatedAt: base.Add(3 * time.Minute)}}
+	repo.deleted = []scene_audio_route_models.DeletionRecord{
+		{ID: primitive.NewObjectID(), ItemType: "artist", ItemID: rebuilt.Hex(), DeletedAt: base.Add(time.Minute)},
+		{ID: primitive.NewObjectID(), ItemType: "artist", ItemID: removed.Hex(), DeletedAt: base.Add(time.Minute)},
+		{ID: primitive.NewObjectID(), ItemType: "artist", ItemID: removed.Hex(), DeletedAt: base.Add(time.Minute)},
+	}
+
+	uc := NewChangesUsecase(repo, time.Second)
+	first, err := uc.GetChanges(context.Background(), encodeSyncToken(syncToken{Since: base}))
+	assert.NoError(t, err)
+	assert.True(t, first.HasMore)
+	// 专辑与艺术家排在媒体之前，先占用本页配额
+	assert.Equal(t, []string{album.Hex()}, first.Changes["album"].Created)
+	assert.Equal(t, []string{rebuilt.Hex()}, first.Changes["artist"].Created)
+	assert.Len(t, first.Changes["media"].Updated, scene_audio_route_models.ChangesPageSize-2)
+
+	second, err := uc.GetChanges(context.Background(), first.Token)
+	assert.NoError(t, err)
+	assert.False(t, second.HasMore)
+	assert.Len(t, second.Changes["media"].Updated, 12)
+	assert.Empty(t, second.Changes["album"].Created)
+	assert.Equal(t, []string{removed.Hex()}, second.Changes["artist"].Deleted)
+
+	seen := make(map[string]bool)
+	for _, id := range append(first.Changes["media"].Updated, second.Changes["media"].Updated...) {
+		assert.False(t, seen[id], "duplicate id across pages")
+		seen[id] = true
+	}
+	assert.Len(t, seen, total)
+
+	next, err := decodeSyncToken(second.Token)
+	assert.NoError(t, err)
+	assert.False(t, next.Resume)
+	firstPage, _ := decodeSyncToken(first.Token)
+	assert.Equal(t, firstPage.Until, next.Since, "completed sync continues from the first page snapshot")
+}
+
+func TestGetChangesFullSync(t *testing.T) {
+	uc := NewChangesUsecase(&stubChangesRepository{}, time.Second)
+
+	feed, err := uc.GetChanges(context.Background(), "")
+	assert.NoError(t, err)
+	assert.True(t, feed.FullSync)
+
+	expired := encodeSyncToken(syncToken{Since: time.Now().Add(-scene_audio_route_models.DeletionLogRetention - time.Hour)})
+	feed, err = uc.GetChanges(context.Background(), expired)
+	assert.NoError(t, err)
+	assert.True(t, feed.FullSync)
+}
+
+func formatMillis(t time.Time) string {
+	return strconv.FormatInt(t.UnixMilli(), 10)
+}
+
+// stubChangesRepository 按接口约定在内存中过滤、排序与截断
+type stubChangesRepository struct {
+	items   map[string][]scene_audio_route_models.ChangedItem
+	deleted []scene_audio_route_models.DeletionRecord
+}
+
+func inChangeRange(t time.Time, id primitive.ObjectID, since, until time.Time, after *scene_audio_route_models.ChangeCursor) bool {
+	if t.Before(since) || !t.Before(until) {
+		return false
+	}
+	if after == nil {
+		return true
+	}
+	return t.After(after.Time) || (t.Equal(after.Time) && id.Hex() > after.ID.Hex())
+}
+
+func (r *stubChangesRepository) GetChangedItems(
+	_ context.Context,
+	itemType string,
+	since, until time.Time,
+	after *scene_audio_route_models.ChangeCursor,
+	limit int,
+) ([]scene_audio_route_models.ChangedItem, error) {
+	var result []scene_audio_route_models.ChangedItem
+	for _, item := range r.items[itemType] {
+		if inChangeRange(item.UpdatedAt, item.ID, since, until, after) {
+			result = append(result, item)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].UpdatedAt.Equal(result[j].UpdatedAt) {
+			return result[i].UpdatedAt.Before(result[j].UpdatedAt)
+		}
+		return result[i].ID.Hex() < result[j].ID.Hex()
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (r *stubChangesRepository) GetDeletedItems(
+	_ context.Context,
+	since, until time.Time,
+	after *scene_audio_route_models.ChangeCursor,
+	limit int,
+) ([]scene_audio_route_models.DeletionRecord, error) {
+	var result []scene_audio_route_models.DeletionRecord
+	for _, record := range r.deleted {
+		if inChangeRange(record.DeletedAt, record.ID, since, until, after) {
+			result = append(result, record)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].DeletedAt.Equal(result[j].DeletedAt) {
+			return result[i].DeletedAt.Before(result[j].DeletedAt)
+		}
+		return result[i].ID.Hex() < result[j].ID.Hex()
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (r *stubChangesRepository) GetExistingItems(_ context.Context, itemType string, ids []string) (map[string]bool, error) {
+	existing := make(map[string]bool)
+	for _, item := range r.items[itemType] {
+		for _, id := range ids {
+			if item.ID.Hex() == id {
+				existing[id] = true
+			}
+		}
+	}
+	return existing, nil
+}
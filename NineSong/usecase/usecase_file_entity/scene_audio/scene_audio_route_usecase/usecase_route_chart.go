@@ -0,0 +1,87 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+type ChartUsecase struct {
+	repo    scene_audio_route_interface.ChartRepository
+	timeout time.Duration
+}
+
+func NewChartUsecase(repo scene_audio_route_interface.ChartRepository, timeout time.Duration) *ChartUsecase {
+	return &ChartUsecase{repo: repo, timeout: timeout}
+}
+
+// chartParams 解析窗口与数量，窗口为空时使用默认的 30 天
+func chartParams(window string, limit int) (time.Time, int, error) {
+	if window == "" {
+		window = scene_audio_route_models.ChartDefaultWindow
+	}
+	since, err := scene_audio_route_models.ChartSince(window, time.Now())
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	if limit <= 0 {
+		limit = scene_audio_route_models.ChartDefaultLimit
+	}
+	return since, min(limit, scene_audio_route_models.ChartMaxLimit), nil
+}
+
+func (uc *ChartUsecase) GetTopMediaFiles(ctx context.Context, window string, limit int) ([]scene_audio_route_models.ChartMediaFile, error) {
+	since, limit, err := chartParams(window, limit)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	items, err := uc.repo.TopMediaFiles(ctx, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		items[i].Rank = i + 1
+	}
+	return items, nil
+}
+
+func (uc *ChartUsecase) GetTopAlbums(ctx context.Context, window string, limit int) ([]scene_audio_route_models.ChartAlbum, error) {
+	since, limit, err := chartParams(window, limit)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	items, err := uc.repo.TopAlbums(ctx, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		items[i].Rank = i + 1
+	}
+	return items, nil
+}
+
+func (uc *ChartUsecase) GetTopArtists(ctx context.Context, window string, limit int) ([]scene_audio_route_models.ChartArtist, error) {
+	since, limit, err := chartParams(window, limit)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	items, err := uc.repo.TopArtists(ctx, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		items[i].Rank = i + 1
+	}
+	return items, nil
+}
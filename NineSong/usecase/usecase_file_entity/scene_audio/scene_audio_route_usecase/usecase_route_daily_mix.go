@@ -0,0 +1,167 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/lock_util"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// dailyMixGenerateTimeout 一次为全部活跃用户生成歌单的总耗时上限
+const dailyMixGenerateTimeout = 30 * time.Minute
+
+type DailyMixUsecase struct {
+	repo         scene_audio_route_interface.DailyMixRepository
+	discoverRepo scene_audio_route_interface.DiscoverRepository
+	timeout      time.Duration
+}
+
+func NewDailyMixUsecase(
+	repo scene_audio_route_interface.DailyMixRepository,
+	discoverRepo scene_audio_route_interface.DiscoverRepository,
+	timeout time.Duration,
+) *DailyMixUsecase {
+	return &DailyMixUsecase{repo: repo, discoverRepo: discoverRepo, timeout: timeout}
+}
+
+func (uc *DailyMixUsecase) GetMixes(ctx context.Context, userId string) ([]scene_audio_route_models.DailyMix, error) {
+	if userId == "" {
+		return nil, errors.New("user id is required")
+	}
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	mixes, err := uc.repo.GetMixes(ctx, userId)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to fetch daily mixes")
+	}
+	return mixes, nil
+}
+
+// Start 启动后台生成协程：当天尚未生成时立即生成一次，之后每天零点（服务器本地时间）重新生成
+func (uc *DailyMixUsecase) Start(ctx context.Context) {
+	go func() {
+		if last, err := uc.repo.LastGeneratedAt(ctx); err != nil {
+			log.Printf("读取每日推荐生成时间失败: %v", err)
+		} else if last.Before(startOfDay(time.Now())) {
+			uc.generateAndLog(ctx)
+		}
+		for {
+			timer := time.NewTimer(time.Until(startOfDay(time.Now()).AddDate(0, 0, 1)))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			uc.generateAndLog(ctx)
+		}
+	}()
+}
+
+// generateAndLog 多实例部署时只由抢到锁的实例生成
+func (uc *DailyMixUsecase) generateAndLog(ctx context.Context) {
+	users := -1
+	err := lock_util.RunExclusive(ctx, "daily_mix", func(ctx context.Context) error {
+		var err error
+		users, err = uc.Generate(ctx)
+		return err
+	})
+	if err != nil {
+		log.Printf("每日推荐生成失败: %v", err)
+		return
+	}
+	if users >= 0 {
+		log.Printf("已为 %d 位用户生成每日推荐", users)
+	}
+}
+
+// Generate 为近期有播放记录的用户重新生成全部歌单，单个用户失败只记录日志；返回成功的用户数
+func (uc *DailyMixUsecase) Generate(ctx context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, dailyMixGenerateTimeout)
+	defer cancel()
+
+	now := time.Now().UTC()
+	users, err := uc.repo.ActiveUsers(ctx, now.Add(-scene_audio_route_models.DailyMixWindow))
+	if err != nil {
+		return 0, err
+	}
+	generated := 0
+	for _, userId := range users {
+		if ctx.Err() != nil {
+			return generated, ctx.Err()
+		}
+		mixes, err := uc.buildMixes(ctx, userId, now)
+		if err == nil {
+			err = uc.repo.ReplaceMixes(ctx, userId, mixes)
+		}
+		if err != nil {
+			log.Printf("生成用户 %s 的每日推荐失败: %v", userId, err)
+			continue
+		}
+		generated++
+	}
+	return generated, nil
+}
+
+func (uc *DailyMixUsecase) buildMixes(ctx context.Context, userId string, now time.Time) ([]scene_audio_route_models.DailyMixDraft, error) {
+	var mixes []scene_audio_route_models.DailyMixDraft
+
+	genres, err := uc.repo.TopGenres(ctx, userId, now.Add(-scene_audio_route_models.DailyMixWindow), scene_audio_route_models.DailyMixGenres)
+	if err != nil {
+		return nil, err
+	}
+	for _, genre := range genres {
+		ids, err := uc.repo.GenreMediaFiles(ctx, genre, scene_audio_route_models.DailyMixTracks)
+		if err != nil {
+			return nil, err
+		}
+		mixes = append(mixes, scene_audio_route_models.DailyMixDraft{
+			Type:         scene_audio_route_models.DailyMixTypeGenre,
+			Name:         fmt.Sprintf("Daily Mix: %s", genre),
+			Comment:      fmt.Sprintf("Tracks from %s, one of your most played genres", genre),
+			MediaFileIDs: ids,
+		})
+	}
+
+	forgotten, err := uc.discoverRepo.GetForgottenMediaFiles(ctx, userId,
+		now.AddDate(0, -scene_audio_route_models.DailyMixRediscoverMonths, 0),
+		scene_audio_route_models.ForgottenDefaultMinPlays, scene_audio_route_models.DailyMixTracks)
+	if err != nil {
+		return nil, err
+	}
+	forgottenIDs := make([]primitive.ObjectID, 0, len(forgotten))
+	for _, mediaFile := range forgotten {
+		forgottenIDs = append(forgottenIDs, mediaFile.ID)
+	}
+	mixes = append(mixes, scene_audio_route_models.DailyMixDraft{
+		Type:         scene_audio_route_models.DailyMixTypeRediscover,
+		Name:         "Rediscover",
+		Comment:      "Favorites you have not played in a while",
+		MediaFileIDs: forgottenIDs,
+	})
+
+	newIDs, err := uc.repo.NewMediaFiles(ctx, userId, now.Add(-scene_audio_route_models.DailyMixNewAdditionsWindow), scene_audio_route_models.DailyMixTracks)
+	if err != nil {
+		return nil, err
+	}
+	mixes = append(mixes, scene_audio_route_models.DailyMixDraft{
+		Type:         scene_audio_route_models.DailyMixTypeNewAdditions,
+		Name:         "New Additions",
+		Comment:      "Recently added tracks you have not heard yet",
+		MediaFileIDs: newIDs,
+	})
+	return mixes, nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
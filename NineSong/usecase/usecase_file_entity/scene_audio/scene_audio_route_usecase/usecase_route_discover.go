@@ -0,0 +1,70 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"golang.org/x/sync/errgroup"
+)
+
+type discoverUsecase struct {
+	repo    scene_audio_route_interface.DiscoverRepository
+	timeout time.Duration
+}
+
+func NewDiscoverUsecase(repo scene_audio_route_interface.DiscoverRepository, timeout time.Duration) scene_audio_route_interface.DiscoverUsecase {
+	return &discoverUsecase{
+		repo:    repo,
+		timeout: timeout,
+	}
+}
+
+func (uc *discoverUsecase) GetForgotten(ctx context.Context, userId string, months, minPlays, limit int) (*scene_audio_route_models.ForgottenResult, error) {
+	if userId == "" {
+		return nil, errors.New("user id is required")
+	}
+	if months == 0 {
+		months = scene_audio_route_models.ForgottenDefaultMonths
+	}
+	if minPlays == 0 {
+		minPlays = scene_audio_route_models.ForgottenDefaultMinPlays
+	}
+	if limit == 0 {
+		limit = scene_audio_route_models.ForgottenDefaultLimit
+	}
+	if months < 0 || months > scene_audio_route_models.ForgottenMaxMonths {
+		return nil, fmt.Errorf("months must be between 1-%d", scene_audio_route_models.ForgottenMaxMonths)
+	}
+	if minPlays < 0 {
+		return nil, errors.New("min_plays must be positive")
+	}
+	if limit < 0 || limit > scene_audio_route_models.ForgottenMaxLimit {
+		return nil, fmt.Errorf("limit must be between 1-%d", scene_audio_route_models.ForgottenMaxLimit)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	since := time.Now().UTC().AddDate(0, -months, 0)
+	result := &scene_audio_route_models.ForgottenResult{}
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		mediaFiles, err := uc.repo.GetForgottenMediaFiles(gctx, userId, since, minPlays, limit)
+		result.MediaFiles = mediaFiles
+		return err
+	})
+	g.Go(func() error {
+		albums, err := uc.repo.GetForgottenAlbums(gctx, userId, since, minPlays, limit)
+		result.Albums = albums
+		return err
+	})
+	if err := g.Wait(); err != nil {
+		return nil, domain.WrapDomainError(err, "failed to fetch forgotten favorites")
+	}
+	return result, nil
+}
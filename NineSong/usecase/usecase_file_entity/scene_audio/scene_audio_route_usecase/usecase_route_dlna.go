@@ -0,0 +1,360 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// dlnaUsecase 将内容目录的对象树映射到现有的专辑、艺术家与单曲查询；
+// DLNA 客户端不登录，可见范围为整个媒体库
+type dlnaUsecase struct {
+	repo       scene_audio_route_interface.DLNARepository
+	albums     scene_audio_route_interface.AlbumRepository
+	artists    scene_audio_route_interface.ArtistRepository
+	mediaFiles scene_audio_route_interface.MediaFileRepository
+	retrieval  scene_audio_route_interface.RetrievalRepository
+	timeout    time.Duration
+}
+
+func NewDLNAUsecase(
+	repo scene_audio_route_interface.DLNARepository,
+	albums scene_audio_route_interface.AlbumRepository,
+	artists scene_audio_route_interface.ArtistRepository,
+	mediaFiles scene_audio_route_interface.MediaFileRepository,
+	retrieval scene_audio_route_interface.RetrievalRepository,
+	timeout time.Duration,
+) scene_audio_route_interface.DLNAUsecase {
+	return &dlnaUsecase{
+		repo:       repo,
+		albums:     albums,
+		artists:    artists,
+		mediaFiles: mediaFiles,
+		retrieval:  retrieval,
+		timeout:    timeout,
+	}
+}
+
+func (uc *dlnaUsecase) Browse(
+	ctx context.Context,
+	objectId string,
+	children bool,
+	start, count int,
+) (*scene_audio_route_models.DLNABrowseResult, error) {
+	if start < 0 || count < 0 {
+		return nil, scene_audio_route_models.ErrDLNAInvalidArgs
+	}
+	if count == 0 || count > scene_audio_route_models.DLNAMaxBrowseCount {
+		count = scene_audio_route_models.DLNAMaxBrowseCount
+	}
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	updateID, err := uc.repo.GetSystemUpdateID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := &scene_audio_route_models.DLNABrowseResult{UpdateID: updateID}
+
+	if !children {
+		object, err := uc.metadata(ctx, objectId)
+		if err != nil {
+			return nil, err
+		}
+		result.Objects = []scene_audio_route_models.DLNAObject{*object}
+		result.TotalMatches = 1
+		return result, nil
+	}
+
+	result.Objects, result.TotalMatches, err = uc.children(ctx, objectId, start, count)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (uc *dlnaUsecase) GetSystemUpdateID(ctx context.Context) (uint32, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+	return uc.repo.GetSystemUpdateID(ctx)
+}
+
+func (uc *dlnaUsecase) GetStreamPath(ctx context.Context, mediaFileId string) (string, error) {
+	if _, err := primitive.ObjectIDFromHex(mediaFileId); err != nil {
+		return "", scene_audio_route_models.ErrDLNANoSuchObject
+	}
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+	return uc.retrieval.GetDownloadPath(ctx, mediaFileId)
+}
+
+func (uc *dlnaUsecase) GetCoverPath(ctx context.Context, albumId string) (string, error) {
+	if _, err := primitive.ObjectIDFromHex(albumId); err != nil {
+		return "", scene_audio_route_models.ErrDLNANoSuchObject
+	}
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+	return uc.retrieval.GetCoverArtID(ctx, "album", albumId)
+}
+
+func (uc *dlnaUsecase) metadata(ctx context.Context, objectId string) (*scene_audio_route_models.DLNAObject, error) {
+	switch objectId {
+	case scene_audio_route_models.DLNARootID:
+		return &scene_audio_route_models.DLNAObject{
+			ID:         scene_audio_route_models.DLNARootID,
+			ParentID:   "-1",
+			Title:      "root",
+			Class:      scene_audio_route_models.DLNAClassFolder,
+			Container:  true,
+			ChildCount: 3,
+		}, nil
+	case scene_audio_route_models.DLNAAlbumsID, scene_audio_route_models.DLNAArtistsID, scene_audio_route_models.DLNATracksID:
+		return uc.topFolder(ctx, objectId)
+	}
+
+	kind, id, err := parseDLNAObjectID(objectId)
+	if err != nil {
+		return nil, err
+	}
+	switch kind {
+	case scene_audio_route_models.DLNAAlbumPrefix:
+		album, err := uc.albums.GetAlbumTracks(ctx, id)
+		if err != nil {
+			return nil, dlnaLookupError(err)
+		}
+		object := dlnaAlbumObject(album.Album, scene_audio_route_models.DLNAAlbumsID)
+		return &object, nil
+	case scene_audio_route_models.DLNAArtistPrefix:
+		artist, err := uc.artists.GetArtist(ctx, id)
+		if err != nil {
+			return nil, dlnaLookupError(err)
+		}
+		object := dlnaArtistObject(*artist)
+		return &object, nil
+	default:
+		file, err := uc.repo.GetMediaFile(ctx, id)
+		if err != nil {
+			return nil, dlnaLookupError(err)
+		}
+		object := dlnaTrackObject(*file, scene_audio_route_models.DLNAAlbumPrefix+file.AlbumID)
+		return &object, nil
+	}
+}
+
+func (uc *dlnaUsecase) children(ctx context.Context, objectId string, start, count int) ([]scene_audio_route_models.DLNAObject, int, error) {
+	startStr, endStr := strconv.Itoa(start), strconv.Itoa(start+count)
+
+	switch objectId {
+	case scene_audio_route_models.DLNARootID:
+		var objects []scene_audio_route_models.DLNAObject
+		for _, id := range []string{scene_audio_route_models.DLNAAlbumsID, scene_audio_route_models.DLNAArtistsID, scene_audio_route_models.DLNATracksID} {
+			folder, err := uc.topFolder(ctx, id)
+			if err != nil {
+				return nil, 0, err
+			}
+			objects = append(objects, *folder)
+		}
+		return pageDLNAObjects(objects, start, count), len(objects), nil
+
+	case scene_audio_route_models.DLNAAlbumsID:
+		return uc.albumChildren(ctx, "", scene_audio_route_models.DLNAAlbumsID, startStr, endStr)
+
+	case scene_audio_route_models.DLNAArtistsID:
+		counts, err := uc.artists.GetArtistFilterItemsCount(ctx, "", "")
+		if err != nil {
+			return nil, 0, err
+		}
+		artists, err := uc.artists.GetArtistItems(ctx, startStr, endStr, "name", "asc", "", "")
+		if err != nil {
+			return nil, 0, err
+		}
+		objects := make([]scene_audio_route_models.DLNAObject, 0, len(artists))
+		for _, artist := range artists {
+			objects = append(objects, dlnaArtistObject(artist))
+		}
+		return objects, counts.Total, nil
+
+	case scene_audio_route_models.DLNATracksID:
+		counts, err := uc.mediaFiles.GetMediaFileFilterItemsCount(ctx, "", "", "", "", "", "", "", "")
+		if err != nil {
+			return nil, 0, err
+		}
+		files, err := uc.mediaFiles.GetMediaFileItems(ctx, startStr, endStr, "title", "asc", "", "", "", "", "", "", "", "")
+		if err != nil {
+			return nil, 0, err
+		}
+		objects := make([]scene_audio_route_models.DLNAObject, 0, len(files))
+		for _, file := range files {
+			objects = append(objects, dlnaTrackObject(file, scene_audio_route_models.DLNATracksID))
+		}
+		return objects, counts.Total, nil
+	}
+
+	kind, id, err := parseDLNAObjectID(objectId)
+	if err != nil {
+		return nil, 0, err
+	}
+	switch kind {
+	case scene_audio_route_models.DLNAAlbumPrefix:
+		album, err := uc.albums.GetAlbumTracks(ctx, id)
+		if err != nil {
+			return nil, 0, dlnaLookupError(err)
+		}
+		var objects []scene_audio_route_models.DLNAObject
+		for _, disc := range album.Discs {
+			for _, track := range disc.Tracks {
+				objects = append(objects, dlnaTrackObject(track, objectId))
+			}
+		}
+		return pageDLNAObjects(objects, start, count), len(objects), nil
+
+	case scene_audio_route_models.DLNAArtistPrefix:
+		if _, err := uc.artists.GetArtist(ctx, id); err != nil {
+			return nil, 0, dlnaLookupError(err)
+		}
+		return uc.albumChildren(ctx, id, objectId, startStr, endStr)
+
+	default:
+		// 单曲没有子对象
+		return []scene_audio_route_models.DLNAObject{}, 0, nil
+	}
+}
+
+func (uc *dlnaUsecase) albumChildren(ctx context.Context, artistId, parentId, start, end string) ([]scene_audio_route_models.DLNAObject, int, error) {
+	counts, err := uc.albums.GetAlbumFilterItemsCount(ctx, "", "", artistId, "", "", "", "", "", "", "")
+	if err != nil {
+		return nil, 0, err
+	}
+	albums, err := uc.albums.GetAlbumItems(ctx, start, end, "name", "asc", "", "", artistId, "", "", "", "", "", "")
+	if err != nil {
+		return nil, 0, err
+	}
+	objects := make([]scene_audio_route_models.DLNAObject, 0, len(albums))
+	for _, album := range albums {
+		objects = append(objects, dlnaAlbumObject(album, parentId))
+	}
+	return objects, counts.Total, nil
+}
+
+// topFolder 根目录下的固定容器，子对象数取自对应列表的总数
+func (uc *dlnaUsecase) topFolder(ctx context.Context, id string) (*scene_audio_route_models.DLNAObject, error) {
+	folder := &scene_audio_route_models.DLNAObject{
+		ID:        id,
+		ParentID:  scene_audio_route_models.DLNARootID,
+		Class:     scene_audio_route_models.DLNAClassFolder,
+		Container: true,
+	}
+	switch id {
+	case scene_audio_route_models.DLNAAlbumsID:
+		counts, err := uc.albums.GetAlbumFilterItemsCount(ctx, "", "", "", "", "", "", "", "", "", "")
+		if err != nil {
+			return nil, err
+		}
+		folder.Title, folder.ChildCount = "Albums", counts.Total
+	case scene_audio_route_models.DLNAArtistsID:
+		counts, err := uc.artists.GetArtistFilterItemsCount(ctx, "", "")
+		if err != nil {
+			return nil, err
+		}
+		folder.Title, folder.ChildCount = "Artists", counts.Total
+	default:
+		counts, err := uc.mediaFiles.GetMediaFileFilterItemsCount(ctx, "", "", "", "", "", "", "", "")
+		if err != nil {
+			return nil, err
+		}
+		folder.Title, folder.ChildCount = "Tracks", counts.Total
+	}
+	return folder, nil
+}
+
+func parseDLNAObjectID(objectId string) (kind, id string, err error) {
+	for _, prefix := range []string{
+		scene_audio_route_models.DLNAAlbumPrefix,
+		scene_audio_route_models.DLNAArtistPrefix,
+		scene_audio_route_models.DLNATrackPrefix,
+	} {
+		if rest, ok := strings.CutPrefix(objectId, prefix); ok {
+			if _, err := primitive.ObjectIDFromHex(rest); err != nil {
+				break
+			}
+			return prefix, rest, nil
+		}
+	}
+	return "", "", scene_audio_route_models.ErrDLNANoSuchObject
+}
+
+// dlnaLookupError 已删除的对象按 UPnP 的 No such object 返回
+func dlnaLookupError(err error) error {
+	if domain.IsNotFound(err) {
+		return scene_audio_route_models.ErrDLNANoSuchObject
+	}
+	return err
+}
+
+func pageDLNAObjects(objects []scene_audio_route_models.DLNAObject, start, count int) []scene_audio_route_models.DLNAObject {
+	if start >= len(objects) {
+		return []scene_audio_route_models.DLNAObject{}
+	}
+	return objects[start:min(start+count, len(objects))]
+}
+
+func dlnaAlbumObject(album scene_audio_route_models.AlbumMetadata, parentId string) scene_audio_route_models.DLNAObject {
+	object := scene_audio_route_models.DLNAObject{
+		ID:          scene_audio_route_models.DLNAAlbumPrefix + album.ID.Hex(),
+		ParentID:    parentId,
+		Title:       album.Name,
+		Class:       scene_audio_route_models.DLNAClassAlbum,
+		Container:   true,
+		ChildCount:  album.SongCount,
+		Artist:      album.Artist,
+		AlbumArtist: album.AlbumArtist,
+		Genre:       album.Genre,
+		Year:        album.MinYear,
+	}
+	if album.HasCoverArt {
+		object.CoverID = album.ID.Hex()
+	}
+	return object
+}
+
+func dlnaArtistObject(artist scene_audio_route_models.ArtistMetadata) scene_audio_route_models.DLNAObject {
+	return scene_audio_route_models.DLNAObject{
+		ID:         scene_audio_route_models.DLNAArtistPrefix + artist.ID.Hex(),
+		ParentID:   scene_audio_route_models.DLNAArtistsID,
+		Title:      artist.Name,
+		Class:      scene_audio_route_models.DLNAClassArtist,
+		Container:  true,
+		ChildCount: artist.AlbumCount,
+	}
+}
+
+func dlnaTrackObject(file scene_audio_route_models.MediaFileMetadata, parentId string) scene_audio_route_models.DLNAObject {
+	object := scene_audio_route_models.DLNAObject{
+		ID:          scene_audio_route_models.DLNATrackPrefix + file.ID.Hex(),
+		ParentID:    parentId,
+		Title:       file.Title,
+		Class:       scene_audio_route_models.DLNAClassTrack,
+		Artist:      file.Artist,
+		AlbumArtist: file.AlbumArtist,
+		Album:       file.Album,
+		Genre:       file.Genre,
+		Year:        file.Year,
+		TrackNumber: file.TrackNumber,
+		Duration:    time.Duration(file.Duration).Seconds(),
+		Size:        file.Size,
+		BitRate:     file.BitRate,
+		Channels:    file.Channels,
+		Suffix:      file.Suffix,
+		MediaFileID: file.ID.Hex(),
+	}
+	if file.HasCoverArt && file.AlbumID != "" {
+		object.CoverID = file.AlbumID
+	}
+	return object
+}
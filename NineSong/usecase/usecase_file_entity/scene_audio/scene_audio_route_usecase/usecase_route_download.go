@@ -0,0 +1,163 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type downloadUsecase struct {
+	albums         scene_audio_route_interface.AlbumRepository
+	playlists      scene_audio_route_interface.PlaylistRepository
+	playlistTracks scene_audio_route_interface.PlaylistTrackRepository
+	retrieval      scene_audio_route_interface.RetrievalRepository
+	timeout        time.Duration
+}
+
+func NewDownloadUsecase(
+	albums scene_audio_route_interface.AlbumRepository,
+	playlists scene_audio_route_interface.PlaylistRepository,
+	playlistTracks scene_audio_route_interface.PlaylistTrackRepository,
+	retrieval scene_audio_route_interface.RetrievalRepository,
+	timeout time.Duration,
+) scene_audio_route_interface.DownloadUsecase {
+	return &downloadUsecase{
+		albums:         albums,
+		playlists:      playlists,
+		playlistTracks: playlistTracks,
+		retrieval:      retrieval,
+		timeout:        timeout,
+	}
+}
+
+// GetAlbumArchive 包内目录为“专辑艺术家 - 专辑名”，多张光盘时按 CD1、CD2 分目录，文件名为“音轨号 - 标题”
+func (uc *downloadUsecase) GetAlbumArchive(ctx context.Context, albumId string) (*scene_audio_route_models.DownloadArchive, error) {
+	if _, err := primitive.ObjectIDFromHex(albumId); err != nil {
+		return nil, scene_audio_route_models.ErrInvalidDownloadID
+	}
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	album, err := uc.albums.GetAlbumTracks(ctx, albumId)
+	if err != nil {
+		return nil, err
+	}
+
+	artist := album.Album.AlbumArtist
+	if artist == "" {
+		artist = album.Album.Artist
+	}
+	folder := sanitizeArchiveName(album.Album.Name)
+	if artist != "" {
+		folder = sanitizeArchiveName(artist + " - " + album.Album.Name)
+	}
+
+	archive := &scene_audio_route_models.DownloadArchive{Name: folder}
+	names := make(map[string]int)
+	for _, disc := range album.Discs {
+		dir := folder
+		if len(album.Discs) > 1 {
+			dir = folder + "/CD" + strconv.Itoa(disc.DiscNumber)
+		}
+		for _, track := range disc.Tracks {
+			name := sanitizeArchiveName(track.Title)
+			if track.TrackNumber > 0 {
+				name = fmt.Sprintf("%02d - %s", track.TrackNumber, name)
+			}
+			archive.Entries = append(archive.Entries, scene_audio_route_models.DownloadEntry{
+				Path: track.Path,
+				Name: uniqueArchiveName(names, dir+"/"+name, filepath.Ext(track.Path)),
+			})
+		}
+	}
+	return archive, nil
+}
+
+// GetPlaylistArchive 按播放列表顺序编号，文件名为“序号 - 艺术家 - 标题”
+func (uc *downloadUsecase) GetPlaylistArchive(ctx context.Context, playlistId string) (*scene_audio_route_models.DownloadArchive, error) {
+	if _, err := primitive.ObjectIDFromHex(playlistId); err != nil {
+		return nil, scene_audio_route_models.ErrInvalidDownloadID
+	}
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	playlist, err := uc.playlists.GetPlaylist(ctx, playlistId)
+	if err != nil {
+		return nil, err
+	}
+	tracks, err := uc.playlistTracks.GetPlaylistTrackItems(ctx, "", "", "index", "asc", "", "", "", "", "", playlistId)
+	if err != nil {
+		return nil, err
+	}
+	if len(tracks) == 0 {
+		return nil, fmt.Errorf("playlist tracks %w", domain.ErrNotFound)
+	}
+
+	folder := sanitizeArchiveName(playlist.Name)
+	width := max(len(strconv.Itoa(len(tracks))), 2)
+	archive := &scene_audio_route_models.DownloadArchive{Name: folder}
+	names := make(map[string]int)
+	for i, track := range tracks {
+		name := sanitizeArchiveName(track.Title)
+		if track.Artist != "" {
+			name = sanitizeArchiveName(track.Artist + " - " + track.Title)
+		}
+		archive.Entries = append(archive.Entries, scene_audio_route_models.DownloadEntry{
+			Path: track.Path,
+			Name: uniqueArchiveName(names, fmt.Sprintf("%s/%0*d - %s", folder, width, i+1, name), filepath.Ext(track.Path)),
+		})
+	}
+	return archive, nil
+}
+
+// GetTrack 单曲下载保留原始文件名
+func (uc *downloadUsecase) GetTrack(ctx context.Context, mediaFileId string) (*scene_audio_route_models.DownloadEntry, error) {
+	if _, err := primitive.ObjectIDFromHex(mediaFileId); err != nil {
+		return nil, scene_audio_route_models.ErrInvalidDownloadID
+	}
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	path, err := uc.retrieval.GetDownloadPath(ctx, mediaFileId)
+	if err != nil {
+		return nil, err
+	}
+	return &scene_audio_route_models.DownloadEntry{Path: path, Name: filepath.Base(path)}, nil
+}
+
+// sanitizeArchiveName 替换各平台文件名中的非法字符，避免解压时生成意外的目录层级
+func sanitizeArchiveName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|':
+			return '_'
+		}
+		if r < 0x20 {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.Trim(strings.TrimSpace(name), ".")
+	if name == "" {
+		return "Unknown"
+	}
+	return name
+}
+
+// uniqueArchiveName 同名文件依次追加 (2)、(3)，防止解压时相互覆盖
+func uniqueArchiveName(seen map[string]int, base, ext string) string {
+	key := strings.ToLower(base + ext)
+	seen[key]++
+	if n := seen[key]; n > 1 {
+		return fmt.Sprintf("%s (%d)%s", base, n, ext)
+	}
+	return base + ext
+}
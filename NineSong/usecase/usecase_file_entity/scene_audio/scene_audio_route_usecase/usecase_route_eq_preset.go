@@ -0,0 +1,141 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+const maxEqBands = 31
+
+type eqPresetUsecase struct {
+	repo    scene_audio_route_interface.EqPresetRepository
+	timeout time.Duration
+}
+
+func NewEqPresetUsecase(repo scene_audio_route_interface.EqPresetRepository, timeout time.Duration) scene_audio_route_interface.EqPresetRepository {
+	return &eqPresetUsecase{
+		repo:    repo,
+		timeout: timeout,
+	}
+}
+
+func (uc *eqPresetUsecase) GetEqPresets(ctx context.Context, userId string) ([]scene_audio_route_models.EqPresetMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if userId == "" {
+		return nil, errors.New("user id is required")
+	}
+
+	presets, err := uc.repo.GetEqPresets(ctx, userId)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to fetch eq presets")
+	}
+	return presets, nil
+}
+
+func (uc *eqPresetUsecase) GetEqPreset(ctx context.Context, userId string, name string) (*scene_audio_route_models.EqPresetMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if userId == "" {
+		return nil, errors.New("user id is required")
+	}
+	if strings.TrimSpace(name) == "" {
+		return nil, errors.New("preset name cannot be empty")
+	}
+
+	preset, err := uc.repo.GetEqPreset(ctx, userId, name)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, err
+		}
+		return nil, domain.WrapDomainError(err, "failed to fetch eq preset")
+	}
+	return preset, nil
+}
+
+func (uc *eqPresetUsecase) SaveEqPreset(ctx context.Context, preset scene_audio_route_models.EqPresetMetadata) (*scene_audio_route_models.EqPresetMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	preset.Name = strings.TrimSpace(preset.Name)
+
+	validations := []func() error{
+		func() error {
+			if preset.UserID == "" {
+				return errors.New("user id is required")
+			}
+			return nil
+		},
+		func() error {
+			if preset.Name == "" || len(preset.Name) > 64 {
+				return errors.New("preset name must be 1-64 characters")
+			}
+			return nil
+		},
+		func() error {
+			if len(preset.Bands) == 0 || len(preset.Bands) > maxEqBands {
+				return fmt.Errorf("preset must contain 1-%d bands", maxEqBands)
+			}
+			return nil
+		},
+		func() error {
+			if preset.PreAmp < -24 || preset.PreAmp > 24 {
+				return errors.New("pre_amp must be between -24 and 24 dB")
+			}
+			return nil
+		},
+		func() error {
+			for i, band := range preset.Bands {
+				if band.Frequency < 20 || band.Frequency > 20000 {
+					return fmt.Errorf("band %d: frequency must be between 20 and 20000 Hz", i)
+				}
+				if band.Width <= 0 || band.Width > 40 {
+					return fmt.Errorf("band %d: width must be between 0 and 40", i)
+				}
+				if band.Gain < -24 || band.Gain > 24 {
+					return fmt.Errorf("band %d: gain must be between -24 and 24 dB", i)
+				}
+			}
+			return nil
+		},
+	}
+
+	for _, validate := range validations {
+		if err := validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	saved, err := uc.repo.SaveEqPreset(ctx, preset)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to save eq preset")
+	}
+	return saved, nil
+}
+
+func (uc *eqPresetUsecase) DeleteEqPreset(ctx context.Context, userId string, name string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if userId == "" {
+		return false, errors.New("user id is required")
+	}
+	if strings.TrimSpace(name) == "" {
+		return false, errors.New("preset name cannot be empty")
+	}
+
+	deleted, err := uc.repo.DeleteEqPreset(ctx, userId, name)
+	if err != nil {
+		return false, domain.WrapDomainError(err, "failed to delete eq preset")
+	}
+	return deleted, nil
+}
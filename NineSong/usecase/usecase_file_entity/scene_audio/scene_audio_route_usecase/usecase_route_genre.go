@@ -0,0 +1,100 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cache_util"
+)
+
+type genreUsecase struct {
+	repo    scene_audio_route_interface.GenreRepository
+	timeout time.Duration
+}
+
+func NewGenreUsecase(repo scene_audio_route_interface.GenreRepository, timeout time.Duration) scene_audio_route_interface.GenreRepository {
+	return &genreUsecase{
+		repo:    repo,
+		timeout: timeout,
+	}
+}
+
+func (uc *genreUsecase) GetGenreTree(ctx context.Context, locale string) ([]scene_audio_route_models.GenreNode, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	locale = strings.TrimSpace(locale)
+	// 曲目与专辑计数受媒体库权限限定，可见范围相同的用户共用缓存
+	key := cache_util.Key("genre_tree", domain.FolderScopeFromContext(ctx).Key(), locale)
+	tree, err := cache_util.GetOrLoad(ctx, cache_util.NamespaceLists, key, cache_util.DefaultTTL(),
+		func() ([]scene_audio_route_models.GenreNode, error) {
+			return uc.repo.GetGenreTree(ctx, locale)
+		})
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to get genre tree")
+	}
+	return tree, nil
+}
+
+func (uc *genreUsecase) SaveGenre(
+	ctx context.Context,
+	genre scene_audio_route_models.GenreMetadata,
+) (*scene_audio_route_models.GenreMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	genre.Name = strings.TrimSpace(genre.Name)
+	genre.Parent = strings.TrimSpace(genre.Parent)
+
+	validations := []func() error{
+		func() error {
+			if genre.Name == "" {
+				return errors.New("genre name cannot be empty")
+			}
+			if len(genre.Name) > 100 {
+				return errors.New("genre name exceeds maximum length")
+			}
+			return nil
+		},
+		func() error {
+			if strings.EqualFold(genre.Name, genre.Parent) {
+				return scene_audio_route_models.ErrGenreCycle
+			}
+			return nil
+		},
+	}
+	for _, validate := range validations {
+		if err := validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	saved, err := uc.repo.SaveGenre(ctx, genre)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to save genre")
+	}
+	cache_util.Invalidate(ctx, cache_util.NamespaceLists)
+	return saved, nil
+}
+
+func (uc *genreUsecase) DeleteGenre(ctx context.Context, name string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return false, errors.New("genre name cannot be empty")
+	}
+
+	deleted, err := uc.repo.DeleteGenre(ctx, name)
+	if err != nil {
+		return false, domain.WrapDomainError(err, "failed to delete genre")
+	}
+	cache_util.Invalidate(ctx, cache_util.NamespaceLists)
+	return deleted, nil
+}
@@ -0,0 +1,74 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type HistoryUsecase struct {
+	repo    scene_audio_route_interface.HistoryRepository
+	timeout time.Duration
+}
+
+func NewHistoryUsecase(repo scene_audio_route_interface.HistoryRepository, timeout time.Duration) *HistoryUsecase {
+	return &HistoryUsecase{repo: repo, timeout: timeout}
+}
+
+func (uc *HistoryUsecase) GetHistory(
+	ctx context.Context,
+	userId, mediaFileId, from, to, start, end string,
+) ([]scene_audio_route_models.PlayHistoryItem, int64, error) {
+	filter := scene_audio_route_models.PlayHistoryFilter{UserID: userId, MediaFileID: mediaFileId}
+	if mediaFileId != "" {
+		if _, err := primitive.ObjectIDFromHex(mediaFileId); err != nil {
+			return nil, 0, errors.New("invalid media file id format")
+		}
+	}
+
+	var err error
+	if filter.From, err = parseHistoryTime(from, false); err != nil {
+		return nil, 0, fmt.Errorf("invalid from parameter: %w", err)
+	}
+	if filter.To, err = parseHistoryTime(to, true); err != nil {
+		return nil, 0, fmt.Errorf("invalid to parameter: %w", err)
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.To.After(filter.From) {
+		return nil, 0, errors.New("to must be after from")
+	}
+
+	startInt, err := strconv.Atoi(start)
+	if err != nil || startInt < 0 {
+		return nil, 0, errors.New("invalid start parameter")
+	}
+	endInt, err := strconv.Atoi(end)
+	if err != nil || endInt <= startInt {
+		return nil, 0, errors.New("invalid end parameter")
+	}
+	filter.Skip = startInt
+	filter.Limit = min(endInt-startInt, scene_audio_route_models.HistoryMaxLimit)
+
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+	return uc.repo.GetHistory(ctx, filter)
+}
+
+// parseHistoryTime 接受 YYYY-MM-DD（按服务器时区）或 RFC3339；endOfDay 为 true 时日期取次日零点，使当天包含在内
+func parseHistoryTime(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, value, time.Local); err == nil {
+		if endOfDay {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
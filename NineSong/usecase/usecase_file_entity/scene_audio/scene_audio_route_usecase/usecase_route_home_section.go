@@ -0,0 +1,94 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+type homeSectionUsecase struct {
+	repo            scene_audio_route_interface.HomeSectionRepository
+	prefRepo        scene_audio_route_interface.UserPreferenceRepository
+	defaultSections []scene_audio_route_models.HomeSectionConfig
+	timeout         time.Duration
+}
+
+func NewHomeSectionUsecase(
+	repo scene_audio_route_interface.HomeSectionRepository,
+	prefRepo scene_audio_route_interface.UserPreferenceRepository,
+	defaultSections []scene_audio_route_models.HomeSectionConfig,
+	timeout time.Duration,
+) scene_audio_route_interface.HomeSectionUsecase {
+	return &homeSectionUsecase{
+		repo:            repo,
+		prefRepo:        prefRepo,
+		defaultSections: defaultSections,
+		timeout:         timeout,
+	}
+}
+
+func (uc *homeSectionUsecase) GetHome(
+	ctx context.Context,
+	userId string,
+	sections []scene_audio_route_models.HomeSectionConfig,
+) ([]scene_audio_route_models.HomeSection, error) {
+	if userId == "" {
+		return nil, errors.New("user id is required")
+	}
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if len(sections) == 0 {
+		sections = uc.defaultSections
+		pref, err := uc.prefRepo.GetUserPreference(ctx, userId)
+		if err != nil {
+			log.Printf("读取用户首页布局失败，使用默认布局: %v", err)
+		} else if len(pref.HomeSections) > 0 {
+			sections = pref.HomeSections
+		}
+	}
+
+	now := time.Now().UTC()
+	results := make([]scene_audio_route_models.HomeSection, len(sections))
+	var wg sync.WaitGroup
+	for i, section := range sections {
+		wg.Add(1)
+		go func(i int, section scene_audio_route_models.HomeSectionConfig) {
+			defer wg.Done()
+			results[i] = scene_audio_route_models.HomeSection{ID: section.ID}
+			if err := uc.loadSection(ctx, userId, now, section, &results[i]); err != nil {
+				log.Printf("首页分区 %s 加载失败: %v", section.ID, err)
+			}
+		}(i, section)
+	}
+	wg.Wait()
+	return results, nil
+}
+
+func (uc *homeSectionUsecase) loadSection(
+	ctx context.Context,
+	userId string,
+	now time.Time,
+	section scene_audio_route_models.HomeSectionConfig,
+	out *scene_audio_route_models.HomeSection,
+) error {
+	var err error
+	switch section.ID {
+	case scene_audio_route_models.HomeSectionRecentlyAdded:
+		out.Albums, err = uc.repo.GetRecentlyAddedAlbums(ctx, section.Limit)
+	case scene_audio_route_models.HomeSectionContinueListening:
+		out.Albums, err = uc.repo.GetContinueListeningAlbums(ctx, userId, section.Limit)
+	case scene_audio_route_models.HomeSectionDailyMixes:
+		out.Mixes, err = uc.repo.GetDailyMixes(ctx, userId, now, section.Limit)
+	case scene_audio_route_models.HomeSectionFavorites:
+		out.MediaFiles, err = uc.repo.GetFavoriteMediaFiles(ctx, userId, section.Limit)
+	case scene_audio_route_models.HomeSectionOnThisDay:
+		out.MediaFiles, err = uc.repo.GetOnThisDayMediaFiles(ctx, userId, now, section.Limit)
+	}
+	return err
+}
@@ -0,0 +1,151 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/image_util"
+	"golang.org/x/sync/singleflight"
+)
+
+// imageProxyMaxRedirects 跳转目标同样须在白名单内
+const imageProxyMaxRedirects = 3
+
+type imageProxyUsecase struct {
+	repo    scene_audio_route_interface.RetrievalRepository
+	hosts   []string
+	client  *http.Client
+	timeout time.Duration
+	flights singleflight.Group
+}
+
+// NewImageProxyUsecase hosts 为逗号分隔的允许域名，子域名同样允许；为空时代理关闭
+func NewImageProxyUsecase(repo scene_audio_route_interface.RetrievalRepository, hosts string, timeout time.Duration) scene_audio_route_interface.ImageProxyUsecase {
+	uc := &imageProxyUsecase{repo: repo, timeout: timeout}
+	for _, host := range strings.Split(hosts, ",") {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			uc.hosts = append(uc.hosts, host)
+		}
+	}
+	uc.client = &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= imageProxyMaxRedirects {
+				return errors.New("too many redirects")
+			}
+			if !uc.hostAllowed(req.URL) {
+				return scene_audio_route_models.ErrImageProxyHostNotAllowed
+			}
+			return nil
+		},
+	}
+	return uc
+}
+
+func (uc *imageProxyUsecase) GetImage(ctx context.Context, rawURL string, size int) (string, error) {
+	if len(uc.hosts) == 0 {
+		return "", scene_audio_route_models.ErrImageProxyDisabled
+	}
+	target, err := url.Parse(rawURL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" || target.User != nil {
+		return "", scene_audio_route_models.ErrImageProxyInvalidURL
+	}
+	if !uc.hostAllowed(target) {
+		return "", scene_audio_route_models.ErrImageProxyHostNotAllowed
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	coverFolderPath, err := uc.repo.GetStreamTempPath(ctx, "cover")
+	if err != nil {
+		return "", fmt.Errorf("cover cache folder not configured: %w", err)
+	}
+	sum := sha1.Sum([]byte(target.String()))
+	key := hex.EncodeToString(sum[:])
+	dir := filepath.Join(coverFolderPath, "proxy", key[:2])
+	cachePath := filepath.Join(dir, fmt.Sprintf("%s_%d.jpg", key, size))
+	if info, err := os.Stat(cachePath); err == nil && time.Since(info.ModTime()) < scene_audio_route_models.ImageProxyCacheTTL {
+		return cachePath, nil
+	}
+
+	// 同一图片的并发请求只下载一次
+	_, err, _ = uc.flights.Do(cachePath, func() (interface{}, error) {
+		return nil, uc.fetch(ctx, target.String(), dir, cachePath, size)
+	})
+	if err != nil {
+		return "", err
+	}
+	return cachePath, nil
+}
+
+// fetch 下载原图到临时文件并解码重新编码为 JPEG，无法解码的内容不会写入缓存
+func (uc *imageProxyUsecase) fetch(ctx context.Context, target, dir, cachePath string, size int) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return scene_audio_route_models.ErrImageProxyInvalidURL
+	}
+	req.Header.Set("Accept", "image/*")
+	resp, err := uc.client.Do(req)
+	if err != nil {
+		if errors.Is(err, scene_audio_route_models.ErrImageProxyHostNotAllowed) {
+			return scene_audio_route_models.ErrImageProxyHostNotAllowed
+		}
+		return fmt.Errorf("fetch image failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetch image failed: status %d", resp.StatusCode)
+	}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); !strings.HasPrefix(mediaType, "image/") {
+		return scene_audio_route_models.ErrImageProxyNotImage
+	}
+	if resp.ContentLength > scene_audio_route_models.ImageProxyMaxBytes {
+		return scene_audio_route_models.ErrImageProxyTooLarge
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".download-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	n, err := io.Copy(tmp, io.LimitReader(resp.Body, scene_audio_route_models.ImageProxyMaxBytes+1))
+	_ = tmp.Close()
+	if err != nil {
+		return fmt.Errorf("download image failed: %w", err)
+	}
+	if n > scene_audio_route_models.ImageProxyMaxBytes {
+		return scene_audio_route_models.ErrImageProxyTooLarge
+	}
+
+	if err := image_util.ResizeToJPEG(tmp.Name(), cachePath, size); err != nil {
+		return fmt.Errorf("%w: %v", scene_audio_route_models.ErrImageProxyNotImage, err)
+	}
+	return nil
+}
+
+func (uc *imageProxyUsecase) hostAllowed(target *url.URL) bool {
+	host := strings.ToLower(target.Hostname())
+	for _, allowed := range uc.hosts {
+		if host == allowed || strings.HasSuffix(host, "."+allowed) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package scene_audio_route_usecase
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageProxyHostAllowed(t *testing.T) {
+	uc := NewImageProxyUsecase(nil, " Coverartarchive.org, ,i.scdn.co ", time.Second).(*imageProxyUsecase)
+
+	tests := []struct {
+		name   string
+		rawURL string
+		want   bool
+	}{
+		{name: "exact host", rawURL: "https://coverartarchive.org/release/1/front", want: true},
+		{name: "subdomain", rawURL: "https://ia800.coverartarchive.org/x.jpg", want: true},
+		{name: "host is case insensitive", rawURL: "https://I.SCDN.CO/image/abc", want: true},
+		{name: "port ignored", rawURL: "http://i.scdn.co:8080/image/abc", want: true},
+		{name: "suffix without dot boundary", rawURL: "https://evilcoverartarchive.org/x.jpg", want: false},
+		{name: "allowed host as subdomain of other domain", rawURL: "https://coverartarchive.org.evil.com/x.jpg", want: false},
+		{name: "userinfo does not count as host", rawURL: "https://coverartarchive.org@evil.com/x.jpg", want: false},
+		{name: "unlisted host", rawURL: "https://example.com/x.jpg", want: false},
+		{name: "empty host", rawURL: "file:///etc/passwd", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := url.Parse(tt.rawURL)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, uc.hostAllowed(target))
+		})
+	}
+}
+
+func TestImageProxyHostsParsed(t *testing.T) {
+	uc := NewImageProxyUsecase(nil, " Coverartarchive.org, ,i.scdn.co ", time.Second).(*imageProxyUsecase)
+	assert.Equal(t, []string{"coverartarchive.org", "i.scdn.co"}, uc.hosts)
+
+	disabled := NewImageProxyUsecase(nil, "", time.Second).(*imageProxyUsecase)
+	assert.Empty(t, disabled.hosts)
+}
@@ -0,0 +1,204 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/http_util"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type internetRadioUsecase struct {
+	repo    scene_audio_route_interface.InternetRadioRepository
+	client  *http.Client
+	timeout time.Duration
+}
+
+// NewInternetRadioUsecase 串流地址由用户填写，探测只允许连接公网地址，避免借此探测服务端所在网络
+func NewInternetRadioUsecase(repo scene_audio_route_interface.InternetRadioRepository, timeout time.Duration) scene_audio_route_interface.InternetRadioUsecase {
+	return &internetRadioUsecase{
+		repo:    repo,
+		client:  http_util.NewPublicClient(scene_audio_route_models.RadioProbeTimeout * time.Second),
+		timeout: timeout,
+	}
+}
+
+func (uc *internetRadioUsecase) GetStations(ctx context.Context, userId string) ([]scene_audio_route_models.InternetRadioStation, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if userId == "" {
+		return nil, errors.New("user id is required")
+	}
+	stations, err := uc.repo.GetStations(ctx, userId)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to fetch internet radio stations")
+	}
+	return stations, nil
+}
+
+func (uc *internetRadioUsecase) GetStation(ctx context.Context, userId, id string) (*scene_audio_route_models.InternetRadioStation, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return nil, scene_audio_route_models.ErrInvalidRadioStation
+	}
+	return uc.repo.GetStation(ctx, userId, id)
+}
+
+func (uc *internetRadioUsecase) CreateStation(
+	ctx context.Context,
+	station scene_audio_route_models.InternetRadioStation,
+	skipCheck bool,
+) (*scene_audio_route_models.InternetRadioStation, error) {
+	if err := normalizeRadioStation(&station); err != nil {
+		return nil, err
+	}
+	if !skipCheck {
+		if err := uc.requireReachable(ctx, station.StreamURL); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+	count, err := uc.repo.CountStations(ctx, station.UserID)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to count internet radio stations")
+	}
+	if count >= scene_audio_route_models.RadioMaxStations {
+		return nil, scene_audio_route_models.ErrRadioStationLimit
+	}
+	return uc.repo.CreateStation(ctx, station)
+}
+
+func (uc *internetRadioUsecase) UpdateStation(
+	ctx context.Context,
+	station scene_audio_route_models.InternetRadioStation,
+	skipCheck bool,
+) (*scene_audio_route_models.InternetRadioStation, error) {
+	if station.ID.IsZero() {
+		return nil, scene_audio_route_models.ErrInvalidRadioStation
+	}
+	if err := normalizeRadioStation(&station); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+	existing, err := uc.repo.GetStation(ctx, station.UserID, station.ID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	// 串流地址未改动时不重复检测，电台临时离线也能修改名称
+	if !skipCheck && existing.StreamURL != station.StreamURL {
+		if err := uc.requireReachable(ctx, station.StreamURL); err != nil {
+			return nil, err
+		}
+	}
+	return uc.repo.UpdateStation(ctx, station)
+}
+
+func (uc *internetRadioUsecase) DeleteStation(ctx context.Context, userId, id string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return false, scene_audio_route_models.ErrInvalidRadioStation
+	}
+	return uc.repo.DeleteStation(ctx, userId, id)
+}
+
+// CheckStream 只读取响应头判断是否为音频流；返回网页的地址视为不可用，通常是填成了电台主页
+func (uc *internetRadioUsecase) CheckStream(ctx context.Context, streamURL string) (*scene_audio_route_models.RadioStreamCheck, error) {
+	streamURL, err := normalizeRadioURL(streamURL)
+	if err != nil || streamURL == "" {
+		return nil, scene_audio_route_models.ErrInvalidRadioStation
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, streamURL, nil)
+	if err != nil {
+		return nil, scene_audio_route_models.ErrInvalidRadioStation
+	}
+	req.Header.Set("Icy-MetaData", "0")
+	resp, err := uc.client.Do(req)
+	if err != nil {
+		// SHOUTcast v1 以 "ICY 200 OK" 作为状态行，标准 HTTP 客户端无法解析，但说明串流在线
+		if strings.Contains(err.Error(), "ICY 200") {
+			return &scene_audio_route_models.RadioStreamCheck{Reachable: true, StatusCode: http.StatusOK}, nil
+		}
+		// 不回传原始错误，连接被拒、超时等细节可用于判断内网端口是否开放
+		if errors.Is(err, http_util.ErrPrivateAddress) {
+			return &scene_audio_route_models.RadioStreamCheck{Error: "url points to a private network address"}, nil
+		}
+		return &scene_audio_route_models.RadioStreamCheck{Error: "stream is unreachable"}, nil
+	}
+	defer resp.Body.Close()
+
+	check := &scene_audio_route_models.RadioStreamCheck{
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+	}
+	mediaType, _, _ := mime.ParseMediaType(check.ContentType)
+	switch {
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		check.Error = fmt.Sprintf("unexpected status %d", resp.StatusCode)
+	case mediaType == "text/html":
+		check.Error = "url returns a web page, not an audio stream"
+	default:
+		check.Reachable = true
+	}
+	return check, nil
+}
+
+func (uc *internetRadioUsecase) requireReachable(ctx context.Context, streamURL string) error {
+	check, err := uc.CheckStream(ctx, streamURL)
+	if err != nil {
+		return err
+	}
+	if !check.Reachable {
+		return fmt.Errorf("%w: %s", scene_audio_route_models.ErrRadioStreamUnreachable, check.Error)
+	}
+	return nil
+}
+
+func normalizeRadioStation(station *scene_audio_route_models.InternetRadioStation) error {
+	station.Name = strings.TrimSpace(station.Name)
+	if station.UserID == "" || station.Name == "" || len([]rune(station.Name)) > scene_audio_route_models.RadioNameMaxLength {
+		return scene_audio_route_models.ErrInvalidRadioStation
+	}
+	var err error
+	if station.StreamURL, err = normalizeRadioURL(station.StreamURL); err != nil || station.StreamURL == "" {
+		return scene_audio_route_models.ErrInvalidRadioStation
+	}
+	if station.HomePageURL, err = normalizeRadioURL(station.HomePageURL); err != nil {
+		return scene_audio_route_models.ErrInvalidRadioStation
+	}
+	return nil
+}
+
+// normalizeRadioURL 只接受 http 与 https 地址，空字符串原样返回
+func normalizeRadioURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", nil
+	}
+	if len(raw) > scene_audio_route_models.RadioURLMaxLength {
+		return "", scene_audio_route_models.ErrInvalidRadioStation
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", scene_audio_route_models.ErrInvalidRadioStation
+	}
+	return u.String(), nil
+}
@@ -0,0 +1,269 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/event_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/jukebox_util"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// jukeboxUsecase 点唱机状态只保存在内存中，服务重启后队列清空；
+// 所有命令串行执行，播放器命令在持锁期间下发以保证状态与实际播放一致
+type jukeboxUsecase struct {
+	repo    scene_audio_route_interface.JukeboxRepository
+	backend jukebox_util.Backend
+	timeout time.Duration
+
+	mu      sync.Mutex
+	queue   []scene_audio_route_models.MediaFileMetadata
+	current int
+	playing bool
+	loaded  bool // 当前曲目已载入播放器
+	volume  int
+}
+
+func NewJukeboxUsecase(
+	repo scene_audio_route_interface.JukeboxRepository,
+	backend jukebox_util.Backend,
+	timeout time.Duration,
+) scene_audio_route_interface.JukeboxUsecase {
+	uc := &jukeboxUsecase{
+		repo:    repo,
+		backend: backend,
+		timeout: timeout,
+		volume:  scene_audio_route_models.JukeboxDefaultVolume,
+	}
+	go uc.watchEnded()
+	return uc
+}
+
+func (uc *jukeboxUsecase) Status(ctx context.Context) (*scene_audio_route_models.JukeboxStatus, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	return uc.statusLocked(), nil
+}
+
+func (uc *jukeboxUsecase) SetQueue(ctx context.Context, mediaFileIds []string, index int) (*scene_audio_route_models.JukeboxStatus, error) {
+	items, err := uc.loadItems(ctx, mediaFileIds)
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 || (len(items) > 0 && index >= len(items)) || (len(items) == 0 && index != 0) {
+		return nil, scene_audio_route_models.ErrInvalidJukeboxParams
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if uc.loaded {
+		if err := uc.backend.Stop(); err != nil {
+			return nil, err
+		}
+		uc.loaded = false
+	}
+	uc.queue = items
+	uc.current = index
+	if uc.playing && len(items) > 0 {
+		if err := uc.startLocked(); err != nil {
+			return nil, err
+		}
+	} else {
+		uc.playing = false
+	}
+	return uc.publishLocked(ctx), nil
+}
+
+func (uc *jukeboxUsecase) Add(ctx context.Context, mediaFileIds []string) (*scene_audio_route_models.JukeboxStatus, error) {
+	items, err := uc.loadItems(ctx, mediaFileIds)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if len(uc.queue)+len(items) > scene_audio_route_models.JukeboxMaxQueueSize {
+		return nil, scene_audio_route_models.ErrInvalidJukeboxParams
+	}
+	uc.queue = append(uc.queue, items...)
+	return uc.publishLocked(ctx), nil
+}
+
+func (uc *jukeboxUsecase) Play(ctx context.Context, index int) (*scene_audio_route_models.JukeboxStatus, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if len(uc.queue) == 0 {
+		return nil, scene_audio_route_models.ErrJukeboxQueueEmpty
+	}
+	if index >= len(uc.queue) {
+		return nil, scene_audio_route_models.ErrInvalidJukeboxParams
+	}
+
+	switch {
+	case index >= 0 && (index != uc.current || !uc.loaded):
+		uc.current = index
+		if err := uc.startLocked(); err != nil {
+			return nil, err
+		}
+	case uc.loaded:
+		if err := uc.backend.SetPause(false); err != nil {
+			return nil, err
+		}
+		uc.playing = true
+	default:
+		if err := uc.startLocked(); err != nil {
+			return nil, err
+		}
+	}
+	return uc.publishLocked(ctx), nil
+}
+
+func (uc *jukeboxUsecase) Pause(ctx context.Context) (*scene_audio_route_models.JukeboxStatus, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if uc.loaded && uc.playing {
+		if err := uc.backend.SetPause(true); err != nil {
+			return nil, err
+		}
+	}
+	uc.playing = false
+	return uc.publishLocked(ctx), nil
+}
+
+func (uc *jukeboxUsecase) Skip(ctx context.Context, offset int) (*scene_audio_route_models.JukeboxStatus, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if len(uc.queue) == 0 {
+		return nil, scene_audio_route_models.ErrJukeboxQueueEmpty
+	}
+	next := uc.current + offset
+	if offset == 0 || next < 0 || next >= len(uc.queue) {
+		return nil, scene_audio_route_models.ErrInvalidJukeboxParams
+	}
+
+	uc.current = next
+	if uc.playing {
+		if err := uc.startLocked(); err != nil {
+			return nil, err
+		}
+	} else if uc.loaded {
+		// 暂停时切歌只移动位置，下次播放时再载入
+		if err := uc.backend.Stop(); err != nil {
+			return nil, err
+		}
+		uc.loaded = false
+	}
+	return uc.publishLocked(ctx), nil
+}
+
+func (uc *jukeboxUsecase) SetVolume(ctx context.Context, volume int) (*scene_audio_route_models.JukeboxStatus, error) {
+	if volume < 0 || volume > 100 {
+		return nil, scene_audio_route_models.ErrInvalidJukeboxParams
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if uc.loaded {
+		if err := uc.backend.SetVolume(volume); err != nil {
+			return nil, err
+		}
+	}
+	uc.volume = volume
+	return uc.publishLocked(ctx), nil
+}
+
+func (uc *jukeboxUsecase) loadItems(ctx context.Context, mediaFileIds []string) ([]scene_audio_route_models.MediaFileMetadata, error) {
+	if len(mediaFileIds) > scene_audio_route_models.JukeboxMaxQueueSize {
+		return nil, scene_audio_route_models.ErrInvalidJukeboxParams
+	}
+	ids := make([]primitive.ObjectID, 0, len(mediaFileIds))
+	for _, idStr := range mediaFileIds {
+		id, err := primitive.ObjectIDFromHex(idStr)
+		if err != nil {
+			return nil, scene_audio_route_models.ErrInvalidJukeboxParams
+		}
+		ids = append(ids, id)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+	return uc.repo.GetMediaFiles(ctx, ids)
+}
+
+// startLocked 从头播放当前索引的曲目
+func (uc *jukeboxUsecase) startLocked() error {
+	if err := uc.backend.Load(uc.queue[uc.current].Path); err != nil {
+		uc.loaded, uc.playing = false, false
+		return err
+	}
+	uc.loaded = true
+	if err := uc.backend.SetVolume(uc.volume); err != nil {
+		return err
+	}
+	if err := uc.backend.SetPause(false); err != nil {
+		return err
+	}
+	uc.playing = true
+	return nil
+}
+
+// watchEnded 曲目自然结束时顺序播放下一首，队列播完后停在开头
+func (uc *jukeboxUsecase) watchEnded() {
+	for range uc.backend.Ended() {
+		uc.mu.Lock()
+		if uc.playing && uc.loaded {
+			uc.loaded = false
+			if uc.current+1 < len(uc.queue) {
+				uc.current++
+				if err := uc.startLocked(); err != nil {
+					log.Printf("点唱机播放下一首失败: %v", err)
+				}
+			} else {
+				uc.current = 0
+				uc.playing = false
+			}
+			uc.publishLocked(context.Background())
+		}
+		uc.mu.Unlock()
+	}
+}
+
+func (uc *jukeboxUsecase) statusLocked() *scene_audio_route_models.JukeboxStatus {
+	status := &scene_audio_route_models.JukeboxStatus{
+		Playing:      uc.playing,
+		CurrentIndex: uc.current,
+		Volume:       uc.volume,
+		Queue:        append([]scene_audio_route_models.MediaFileMetadata{}, uc.queue...),
+	}
+	if uc.loaded {
+		if pos, err := uc.backend.Position(); err == nil {
+			status.Position = pos
+		}
+	}
+	return status
+}
+
+func (uc *jukeboxUsecase) publishLocked(ctx context.Context) *scene_audio_route_models.JukeboxStatus {
+	status := uc.statusLocked()
+	event := scene_audio_route_models.JukeboxEvent{
+		Playing:      status.Playing,
+		CurrentIndex: status.CurrentIndex,
+		Volume:       status.Volume,
+		QueueSize:    len(status.Queue),
+	}
+	if status.CurrentIndex < len(status.Queue) {
+		event.MediaFileID = status.Queue[status.CurrentIndex].ID.Hex()
+	}
+	event_util.Publish(ctx, event_util.TopicJukebox, event)
+	return status
+}
@@ -0,0 +1,52 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+type lookupUsecase struct {
+	repo    scene_audio_route_interface.LookupRepository
+	timeout time.Duration
+}
+
+func NewLookupUsecase(repo scene_audio_route_interface.LookupRepository, timeout time.Duration) scene_audio_route_interface.LookupUsecase {
+	return &lookupUsecase{repo: repo, timeout: timeout}
+}
+
+// Lookup 标识符按入库时的规则规范化后精确匹配，两者均提供时分别查询
+func (uc *lookupUsecase) Lookup(ctx context.Context, isrc, barcode string) (*scene_audio_route_models.LookupResult, error) {
+	normalizedISRC := scene_audio_db_models.NormalizeISRC(isrc)
+	normalizedBarcode := scene_audio_db_models.NormalizeBarcode(barcode)
+	if (isrc != "" && normalizedISRC == "") || (barcode != "" && normalizedBarcode == "") ||
+		(normalizedISRC == "" && normalizedBarcode == "") {
+		return nil, scene_audio_route_models.ErrInvalidLookup
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	result := &scene_audio_route_models.LookupResult{
+		MediaFiles: make([]scene_audio_route_models.MediaFileMetadata, 0),
+		Albums:     make([]scene_audio_route_models.AlbumMetadata, 0),
+	}
+	if normalizedISRC != "" {
+		mediaFiles, err := uc.repo.GetMediaFilesByISRC(ctx, normalizedISRC)
+		if err != nil {
+			return nil, err
+		}
+		result.MediaFiles = mediaFiles
+	}
+	if normalizedBarcode != "" {
+		albums, err := uc.repo.GetAlbumsByBarcode(ctx, normalizedBarcode)
+		if err != nil {
+			return nil, err
+		}
+		result.Albums = albums
+	}
+	return result, nil
+}
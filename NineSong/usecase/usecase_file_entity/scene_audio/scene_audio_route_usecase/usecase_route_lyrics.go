@@ -0,0 +1,57 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type lyricsUsecase struct {
+	repo    scene_audio_route_interface.LyricsRepository
+	timeout time.Duration
+}
+
+func NewLyricsUsecase(repo scene_audio_route_interface.LyricsRepository, timeout time.Duration) scene_audio_route_interface.LyricsRepository {
+	return &lyricsUsecase{
+		repo:    repo,
+		timeout: timeout,
+	}
+}
+
+func (uc *lyricsUsecase) GetLyrics(ctx context.Context, mediaFileId string) (*scene_audio_route_models.MediaLyrics, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if _, err := primitive.ObjectIDFromHex(mediaFileId); err != nil {
+		return nil, errors.New("invalid media file id format")
+	}
+	return uc.repo.GetLyrics(ctx, mediaFileId)
+}
+
+func (uc *lyricsUsecase) PinLyrics(ctx context.Context, mediaFileId, lyrics string) (*scene_audio_route_models.MediaLyrics, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if _, err := primitive.ObjectIDFromHex(mediaFileId); err != nil {
+		return nil, errors.New("invalid media file id format")
+	}
+	if strings.TrimSpace(lyrics) == "" {
+		return nil, errors.New("lyrics must not be empty")
+	}
+	return uc.repo.PinLyrics(ctx, mediaFileId, lyrics)
+}
+
+func (uc *lyricsUsecase) UnpinLyrics(ctx context.Context, mediaFileId string) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if _, err := primitive.ObjectIDFromHex(mediaFileId); err != nil {
+		return errors.New("invalid media file id format")
+	}
+	return uc.repo.UnpinLyrics(ctx, mediaFileId)
+}
@@ -3,11 +3,16 @@ package scene_audio_route_usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cache_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -25,7 +30,7 @@ func NewMediaFileUsecase(repo scene_audio_route_interface.MediaFileRepository, t
 
 func (uc *mediaFileUsecase) GetMediaFileItems(
 	ctx context.Context,
-	start, end, sort, order, search, starred, albumId, artistId, year string,
+	start, end, sort, order, search, starred, albumId, artistId, year, folderId, available, custom string,
 ) ([]scene_audio_route_models.MediaFileMetadata, error) {
 	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
 	defer cancel()
@@ -53,12 +58,7 @@ func (uc *mediaFileUsecase) GetMediaFileItems(
 			return nil
 		},
 		func() error {
-			if artistId != "" {
-				if _, err := primitive.ObjectIDFromHex(artistId); err != nil {
-					return errors.New("invalid artist id format")
-				}
-			}
-			return nil
+			return validateArtistIDs(artistId)
 		},
 		func() error {
 			if year != "" {
@@ -68,6 +68,12 @@ func (uc *mediaFileUsecase) GetMediaFileItems(
 			}
 			return nil
 		},
+		func() error {
+			return validateFolderID(folderId)
+		},
+		func() error {
+			return validateAvailable(available)
+		},
 	}
 
 	for _, validate := range validations {
@@ -76,15 +82,76 @@ func (uc *mediaFileUsecase) GetMediaFileItems(
 		}
 	}
 
-	return uc.mediaFileRepo.GetMediaFileItems(ctx, start, end, sort, order, search, starred, albumId, artistId, year)
+	key := cache_util.Key("media_file", domain.UserIDFromContext(ctx), start, end, sort, order, search, starred, albumId, artistId, year, folderId, available, custom)
+	mediaFiles, err := cache_util.GetOrStale(ctx, cache_util.NamespaceStaleLists, key, cache_util.StaleListTTL,
+		func() ([]scene_audio_route_models.MediaFileMetadata, error) {
+			return uc.mediaFileRepo.GetMediaFileItems(ctx, start, end, sort, order, search, starred, albumId, artistId, year, folderId, available, custom)
+		}, mongo.IsUnavailable)
+	if err != nil {
+		return nil, err
+	}
+	for i := range mediaFiles {
+		if mediaFiles[i].Availability == "" {
+			mediaFiles[i].Availability = scene_audio_db_models.AvailabilityOnline
+		}
+	}
+	return mediaFiles, nil
 }
 
 func (uc *mediaFileUsecase) GetMediaFileFilterItemsCount(
 	ctx context.Context,
-	search, starred, albumId, artistId, year string,
+	search, starred, albumId, artistId, year, folderId, available, custom string,
 ) (*scene_audio_route_models.MediaFileFilterCounts, error) {
 	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
 	defer cancel()
 
-	return uc.mediaFileRepo.GetMediaFileFilterItemsCount(ctx, search, starred, albumId, artistId, year)
+	if err := validateArtistIDs(artistId); err != nil {
+		return nil, err
+	}
+	if err := validateFolderID(folderId); err != nil {
+		return nil, err
+	}
+	if err := validateAvailable(available); err != nil {
+		return nil, err
+	}
+
+	key := cache_util.Key("media_file", domain.UserIDFromContext(ctx), search, starred, albumId, artistId, year, folderId, available, custom)
+	return cache_util.GetOrLoad(ctx, cache_util.NamespaceFilterCounts, key, cache_util.DefaultTTL(),
+		func() (*scene_audio_route_models.MediaFileFilterCounts, error) {
+			return uc.mediaFileRepo.GetMediaFileFilterItemsCount(ctx, search, starred, albumId, artistId, year, folderId, available, custom)
+		})
+}
+
+// validateArtistIDs artist_id 可为逗号分隔的多个艺术家ID
+func validateArtistIDs(artistId string) error {
+	ids := scene_audio_route_models.ParseArtistIDs(artistId)
+	if len(ids) > scene_audio_route_models.ArtistFilterMaxIDs {
+		return fmt.Errorf("at most %d artist ids are allowed", scene_audio_route_models.ArtistFilterMaxIDs)
+	}
+	for _, id := range ids {
+		if _, err := primitive.ObjectIDFromHex(id); err != nil {
+			return errors.New("invalid artist id format")
+		}
+	}
+	return nil
+}
+
+// validateAvailable available 仅接受布尔值，true 时隐藏源文件不可访问的条目
+func validateAvailable(available string) error {
+	if available != "" {
+		if _, err := strconv.ParseBool(available); err != nil {
+			return errors.New("invalid available parameter, must be true/false")
+		}
+	}
+	return nil
+}
+
+// validateFolderID folder_id 为媒体库ID
+func validateFolderID(folderId string) error {
+	if folderId != "" {
+		if _, err := primitive.ObjectIDFromHex(folderId); err != nil {
+			return errors.New("invalid folder id format")
+		}
+	}
+	return nil
 }
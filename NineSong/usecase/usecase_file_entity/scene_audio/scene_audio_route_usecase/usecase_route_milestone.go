@@ -0,0 +1,126 @@
+package scene_audio_route_usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/lock_util"
+)
+
+const (
+	milestoneRefreshInterval = 15 * time.Minute
+	// milestoneRefreshTimeout 首次运行要为全部用户回溯计算，耗时远超普通查询
+	milestoneRefreshTimeout = 10 * time.Minute
+)
+
+type MilestoneUsecase struct {
+	repo       scene_audio_route_interface.MilestoneRepository
+	timeout    time.Duration
+	webhookURL string
+	client     *http.Client
+}
+
+// NewMilestoneUsecase webhookURL 非空时，新达成的里程碑以 JSON POST 推送到该地址
+func NewMilestoneUsecase(repo scene_audio_route_interface.MilestoneRepository, timeout time.Duration, webhookURL string) *MilestoneUsecase {
+	return &MilestoneUsecase{
+		repo:       repo,
+		timeout:    timeout,
+		webhookURL: webhookURL,
+		client:     &http.Client{Timeout: timeout},
+	}
+}
+
+func (uc *MilestoneUsecase) GetMilestones(ctx context.Context, userId, milestoneType string) ([]scene_audio_route_models.MilestoneMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if userId == "" {
+		return nil, errors.New("user id is required")
+	}
+	if _, ok := scene_audio_route_models.MilestoneThresholds[milestoneType]; milestoneType != "" && !ok {
+		return nil, fmt.Errorf("invalid milestone type: %s", milestoneType)
+	}
+
+	milestones, err := uc.repo.GetMilestones(ctx, userId, milestoneType)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to fetch milestones")
+	}
+	return milestones, nil
+}
+
+func (uc *MilestoneUsecase) RefreshMilestones(ctx context.Context, since time.Time) ([]scene_audio_route_models.MilestoneMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, milestoneRefreshTimeout)
+	defer cancel()
+	return uc.repo.RefreshMilestones(ctx, since)
+}
+
+// Start 启动后台计算协程：启动时为全部用户回溯一次，之后只处理上一轮以来有播放记录的用户
+func (uc *MilestoneUsecase) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(milestoneRefreshInterval)
+		defer ticker.Stop()
+		var since time.Time
+		for {
+			started := time.Now().UTC()
+			// 多实例部署时只由抢到锁的实例计算并推送，避免重复通知
+			var achieved []scene_audio_route_models.MilestoneMetadata
+			err := lock_util.RunExclusive(ctx, "milestones", func(ctx context.Context) error {
+				var err error
+				achieved, err = uc.RefreshMilestones(ctx, since)
+				return err
+			})
+			if err != nil {
+				log.Printf("收听里程碑计算失败: %v", err)
+			} else {
+				since = started
+			}
+			uc.notify(ctx, achieved)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// notify 推送失败只记录日志，里程碑已落库，客户端仍可通过接口查询
+func (uc *MilestoneUsecase) notify(ctx context.Context, achieved []scene_audio_route_models.MilestoneMetadata) {
+	if uc.webhookURL == "" {
+		return
+	}
+	for _, m := range achieved {
+		body, err := json.Marshal(struct {
+			UserID string `json:"user_id"`
+			scene_audio_route_models.MilestoneMetadata
+		}{UserID: m.UserID, MilestoneMetadata: m})
+		if err != nil {
+			continue
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, uc.webhookURL, bytes.NewReader(body))
+		if err != nil {
+			log.Printf("里程碑通知构建失败: %v", err)
+			return
+		}
+		req.Header.Set("Content-Type", "application/json")
+		res, err := uc.client.Do(req)
+		if err != nil {
+			log.Printf("里程碑通知发送失败: %v", err)
+			continue
+		}
+		_ = res.Body.Close()
+		if res.StatusCode >= http.StatusBadRequest {
+			log.Printf("里程碑通知返回状态码 %d", res.StatusCode)
+		}
+	}
+}
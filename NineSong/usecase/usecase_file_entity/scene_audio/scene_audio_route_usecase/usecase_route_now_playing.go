@@ -0,0 +1,95 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/event_util"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type nowPlayingUsecase struct {
+	repo    scene_audio_route_interface.NowPlayingRepository
+	timeout time.Duration
+}
+
+func NewNowPlayingUsecase(repo scene_audio_route_interface.NowPlayingRepository, timeout time.Duration) scene_audio_route_interface.NowPlayingUsecase {
+	return &nowPlayingUsecase{
+		repo:    repo,
+		timeout: timeout,
+	}
+}
+
+func (uc *nowPlayingUsecase) Report(ctx context.Context, userId, client, mediaFileId, state string, position int64) (*scene_audio_route_models.NowPlayingMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if userId == "" {
+		return nil, errors.New("user id is required")
+	}
+	mediaID, err := primitive.ObjectIDFromHex(mediaFileId)
+	if err != nil {
+		return nil, scene_audio_route_models.ErrInvalidNowPlaying
+	}
+	if position < 0 {
+		return nil, scene_audio_route_models.ErrInvalidNowPlaying
+	}
+	switch state {
+	case "":
+		state = scene_audio_route_models.NowPlayingStatePlaying
+	case scene_audio_route_models.NowPlayingStatePlaying, scene_audio_route_models.NowPlayingStatePaused:
+	default:
+		return nil, scene_audio_route_models.ErrInvalidNowPlaying
+	}
+
+	saved, err := uc.repo.Report(ctx, scene_audio_route_models.NowPlayingMetadata{
+		UserID:      userId,
+		Client:      client,
+		MediaFileID: mediaID,
+		State:       state,
+		Position:    position,
+	})
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to report now playing")
+	}
+	event_util.Publish(ctx, event_util.TopicNowPlaying, scene_audio_route_models.NowPlayingEvent{
+		UserID:      saved.UserID,
+		Client:      saved.Client,
+		MediaFileID: saved.MediaFileID.Hex(),
+		Position:    saved.Position,
+		UpdatedAt:   saved.UpdatedAt,
+	})
+	return saved, nil
+}
+
+func (uc *nowPlayingUsecase) Clear(ctx context.Context, userId string, client string) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if userId == "" {
+		return errors.New("user id is required")
+	}
+	if err := uc.repo.Clear(ctx, userId, client); err != nil {
+		return domain.WrapDomainError(err, "failed to clear now playing")
+	}
+	return nil
+}
+
+func (uc *nowPlayingUsecase) GetNowPlaying(ctx context.Context) ([]scene_audio_route_models.NowPlayingMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	entries, err := uc.repo.GetNowPlaying(ctx)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to fetch now playing")
+	}
+	now := time.Now().UTC()
+	for i := range entries {
+		entries[i].EstimatedPosition = entries[i].Estimate(now)
+	}
+	return entries, nil
+}
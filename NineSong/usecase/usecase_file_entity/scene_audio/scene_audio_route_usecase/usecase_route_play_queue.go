@@ -0,0 +1,95 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/event_util"
+)
+
+const maxPlayQueueSize = 5000
+
+type playQueueUsecase struct {
+	repo    scene_audio_route_interface.PlayQueueRepository
+	timeout time.Duration
+}
+
+func NewPlayQueueUsecase(repo scene_audio_route_interface.PlayQueueRepository, timeout time.Duration) scene_audio_route_interface.PlayQueueRepository {
+	return &playQueueUsecase{
+		repo:    repo,
+		timeout: timeout,
+	}
+}
+
+func (uc *playQueueUsecase) GetPlayQueue(ctx context.Context, userId string, client string) (*scene_audio_route_models.PlayQueueMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if userId == "" {
+		return nil, errors.New("user id is required")
+	}
+
+	queue, err := uc.repo.GetPlayQueue(ctx, userId, client)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to fetch play queue")
+	}
+	return queue, nil
+}
+
+func (uc *playQueueUsecase) SavePlayQueue(ctx context.Context, queue scene_audio_route_models.PlayQueueMetadata) (*scene_audio_route_models.PlayQueueMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	validations := []func() error{
+		func() error {
+			if queue.UserID == "" {
+				return errors.New("user id is required")
+			}
+			return nil
+		},
+		func() error {
+			if len(queue.MediaFileIDs) > maxPlayQueueSize {
+				return errors.New("play queue exceeds maximum size")
+			}
+			return nil
+		},
+		func() error {
+			if queue.Current < 0 || (len(queue.MediaFileIDs) > 0 && queue.Current >= len(queue.MediaFileIDs)) {
+				return errors.New("current index out of range")
+			}
+			return nil
+		},
+		func() error {
+			if queue.Position < 0 {
+				return errors.New("position cannot be negative")
+			}
+			return nil
+		},
+	}
+
+	for _, validate := range validations {
+		if err := validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	saved, err := uc.repo.SavePlayQueue(ctx, queue)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to save play queue")
+	}
+	// 客户端切歌与暂停时都会保存队列，以此作为正在播放的通知
+	if saved.Current < len(saved.MediaFileIDs) {
+		event_util.Publish(ctx, event_util.TopicNowPlaying, scene_audio_route_models.NowPlayingEvent{
+			UserID:      saved.UserID,
+			Client:      saved.Client,
+			MediaFileID: saved.MediaFileIDs[saved.Current].Hex(),
+			Position:    saved.Position,
+			UpdatedAt:   saved.UpdatedAt,
+		})
+	}
+	return saved, nil
+}
@@ -24,11 +24,11 @@ func NewPlaylistUsecase(repo scene_audio_route_interface.PlaylistRepository, tim
 	}
 }
 
-func (uc *playlistUsecase) GetPlaylistsAll(ctx context.Context) ([]scene_audio_route_models.PlaylistMetadata, error) {
+func (uc *playlistUsecase) GetPlaylistsAll(ctx context.Context, start string, end string) ([]scene_audio_route_models.PlaylistMetadata, error) {
 	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
 	defer cancel()
 
-	playlists, err := uc.repo.GetPlaylistsAll(ctx)
+	playlists, err := uc.repo.GetPlaylistsAll(ctx, start, end)
 	if err != nil {
 		return nil, domain.WrapDomainError(err, "failed to fetch playlists")
 	}
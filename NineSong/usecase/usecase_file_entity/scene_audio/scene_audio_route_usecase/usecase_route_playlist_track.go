@@ -74,6 +74,7 @@ func (uc *playlistTrackUsecase) GetPlaylistTrackItems(
 		"year":       true,
 		"rating":     true,
 		"starred_at": true,
+		"rated_at":   true,
 		"genre":      true,
 		"play_count": true, "play_date": true,
 		"duration": true, "bit_rate": true, "size": true,
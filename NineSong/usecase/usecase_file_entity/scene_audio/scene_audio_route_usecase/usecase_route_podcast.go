@@ -0,0 +1,526 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/http_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/lock_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/podcast_util"
+)
+
+const (
+	podcastFetchTimeout = 30 * time.Second
+	// podcastRefreshTimeout 一轮刷新要依次抓取全部订阅源
+	podcastRefreshTimeout = 30 * time.Minute
+)
+
+type podcastUsecase struct {
+	repo            scene_audio_route_interface.PodcastRepository
+	feeds           *http_util.Client
+	downloads       *http.Client
+	downloadDir     string
+	refreshInterval time.Duration
+	timeout         time.Duration
+	slots           chan struct{}
+}
+
+// NewPodcastUsecase downloadDir 为空时不支持下载，单集只能在线播放；refreshInterval 不大于 0 时使用默认间隔
+func NewPodcastUsecase(
+	repo scene_audio_route_interface.PodcastRepository,
+	downloadDir string,
+	refreshInterval time.Duration,
+	timeout time.Duration,
+) scene_audio_route_interface.PodcastUsecase {
+	if refreshInterval <= 0 {
+		refreshInterval = scene_audio_route_models.PodcastDefaultRefreshInterval
+	}
+	return &podcastUsecase{
+		repo: repo,
+		feeds: http_util.NewClient(http_util.Provider{
+			Name:        "podcast",
+			MaxRetries:  2,
+			MaxBodySize: scene_audio_route_models.PodcastFeedMaxSize,
+			PublicOnly:  true,
+		}, podcastFetchTimeout),
+		downloads:       newEpisodeDownloadClient(),
+		downloadDir:     downloadDir,
+		refreshInterval: refreshInterval,
+		timeout:         timeout,
+		slots:           make(chan struct{}, scene_audio_route_models.PodcastDownloadConcurrency),
+	}
+}
+
+// newEpisodeDownloadClient 单集文件较大，只限制连接建立与响应头，不限制整体下载时长；
+// 订阅源与附件地址由用户提供，只允许连接公网地址
+func newEpisodeDownloadClient() *http.Client {
+	transport := http_util.PublicTransport()
+	transport.ResponseHeaderTimeout = podcastFetchTimeout
+	transport.TLSHandshakeTimeout = podcastFetchTimeout
+	return &http.Client{Transport: transport, CheckRedirect: http_util.CheckPublicRedirect}
+}
+
+func (uc *podcastUsecase) GetChannels(ctx context.Context, userId string) ([]scene_audio_route_models.PodcastChannel, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if userId == "" {
+		return nil, errors.New("user id is required")
+	}
+	channels, err := uc.repo.GetChannels(ctx, userId)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to fetch podcasts")
+	}
+	return channels, nil
+}
+
+func (uc *podcastUsecase) GetChannel(ctx context.Context, userId, id string) (*scene_audio_route_models.PodcastChannel, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+	return uc.repo.GetChannel(ctx, userId, id)
+}
+
+func (uc *podcastUsecase) Subscribe(ctx context.Context, userId, feedURL string, autoDownload int) (*scene_audio_route_models.PodcastChannel, error) {
+	feedURL, err := normalizePodcastURL(feedURL)
+	if err != nil || userId == "" {
+		return nil, scene_audio_route_models.ErrInvalidPodcast
+	}
+	if err := uc.checkAutoDownload(autoDownload); err != nil {
+		return nil, err
+	}
+
+	countCtx, cancel := context.WithTimeout(ctx, uc.timeout)
+	count, err := uc.repo.CountChannels(countCtx, userId)
+	cancel()
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to count podcasts")
+	}
+	if count >= scene_audio_route_models.PodcastMaxChannels {
+		return nil, scene_audio_route_models.ErrPodcastLimit
+	}
+
+	feed, err := uc.fetchFeed(ctx, feedURL)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel = context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+	channel, err := uc.repo.CreateChannel(ctx, scene_audio_route_models.PodcastChannel{
+		UserID:       userId,
+		FeedURL:      feedURL,
+		AutoDownload: autoDownload,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.applyFeed(ctx, channel, feed); err != nil {
+		return nil, err
+	}
+	return channel, nil
+}
+
+func (uc *podcastUsecase) UpdateChannel(ctx context.Context, userId, id string, autoDownload int) (*scene_audio_route_models.PodcastChannel, error) {
+	if err := uc.checkAutoDownload(autoDownload); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	channel, err := uc.repo.UpdateAutoDownload(ctx, userId, id, autoDownload)
+	if err != nil {
+		return nil, err
+	}
+	uc.autoDownload(ctx, *channel)
+	return channel, nil
+}
+
+func (uc *podcastUsecase) Unsubscribe(ctx context.Context, userId, id string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	channel, err := uc.repo.GetChannel(ctx, userId, id)
+	if err != nil {
+		if domain.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	paths, err := uc.repo.DownloadedPaths(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	deleted, err := uc.repo.DeleteChannel(ctx, userId, id)
+	if err != nil || !deleted {
+		return deleted, err
+	}
+	for _, p := range paths {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			log.Printf("删除播客下载文件失败 %s: %v", p, err)
+		}
+	}
+	if uc.downloadDir != "" {
+		_ = os.Remove(filepath.Join(uc.downloadDir, channel.ID.Hex()))
+	}
+	return true, nil
+}
+
+func (uc *podcastUsecase) RefreshChannel(ctx context.Context, userId, id string) (*scene_audio_route_models.PodcastChannel, error) {
+	getCtx, cancel := context.WithTimeout(ctx, uc.timeout)
+	channel, err := uc.repo.GetChannel(getCtx, userId, id)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.refresh(ctx, channel); err != nil {
+		return nil, err
+	}
+	return channel, nil
+}
+
+func (uc *podcastUsecase) GetEpisodes(
+	ctx context.Context,
+	userId string,
+	query scene_audio_route_models.PodcastEpisodeQuery,
+) ([]scene_audio_route_models.PodcastEpisode, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if query.Start < 0 || query.Count < 0 {
+		return nil, 0, scene_audio_route_models.ErrInvalidPodcast
+	}
+	if _, err := uc.repo.GetChannel(ctx, userId, query.ChannelID); err != nil {
+		return nil, 0, err
+	}
+	return uc.repo.GetEpisodes(ctx, userId, query)
+}
+
+func (uc *podcastUsecase) GetEpisode(ctx context.Context, userId, id string) (*scene_audio_route_models.PodcastEpisode, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+	return uc.repo.GetEpisode(ctx, userId, id)
+}
+
+func (uc *podcastUsecase) UpdateProgress(
+	ctx context.Context,
+	userId, id string,
+	progress scene_audio_route_models.PodcastProgress,
+) (*scene_audio_route_models.PodcastEpisode, error) {
+	if progress.Position == nil && progress.Played == nil {
+		return nil, scene_audio_route_models.ErrInvalidPodcast
+	}
+	if progress.Position != nil && *progress.Position < 0 {
+		return nil, scene_audio_route_models.ErrInvalidPodcast
+	}
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+	return uc.repo.UpdateProgress(ctx, userId, id, progress)
+}
+
+func (uc *podcastUsecase) DownloadEpisode(ctx context.Context, userId, id string) (*scene_audio_route_models.PodcastEpisode, error) {
+	if uc.downloadDir == "" {
+		return nil, scene_audio_route_models.ErrPodcastDownloadDisabled
+	}
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	episode, err := uc.repo.GetEpisode(ctx, userId, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.queueDownload(ctx, *episode); err != nil {
+		return nil, err
+	}
+	return uc.repo.GetEpisode(ctx, userId, id)
+}
+
+func (uc *podcastUsecase) DeleteDownload(ctx context.Context, userId, id string) (*scene_audio_route_models.PodcastEpisode, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	episode, err := uc.repo.GetEpisode(ctx, userId, id)
+	if err != nil {
+		return nil, err
+	}
+	// 下载进行中时由下载协程收尾，这里不抢占
+	if episode.DownloadStatus == scene_audio_route_models.PodcastDownloadQueued ||
+		episode.DownloadStatus == scene_audio_route_models.PodcastDownloadDownloading {
+		return episode, nil
+	}
+	if episode.LocalPath != "" {
+		if err := os.Remove(episode.LocalPath); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("删除播客下载文件失败: %w", err)
+		}
+	}
+	if err := uc.repo.SetDownloadState(ctx, id, scene_audio_route_models.PodcastDownloadNone, "", ""); err != nil {
+		return nil, err
+	}
+	return uc.repo.GetEpisode(ctx, userId, id)
+}
+
+// Start 多实例部署时只由抢到锁的实例刷新；距上次刷新不足一个间隔的订阅跳过，重启不会重复抓取
+func (uc *podcastUsecase) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(uc.refreshInterval)
+		defer ticker.Stop()
+		for {
+			err := lock_util.RunExclusive(ctx, "podcasts", func(ctx context.Context) error {
+				ctx, cancel := context.WithTimeout(ctx, podcastRefreshTimeout)
+				defer cancel()
+				return uc.refreshDue(ctx)
+			})
+			if err != nil {
+				log.Printf("播客订阅刷新失败: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (uc *podcastUsecase) refreshDue(ctx context.Context) error {
+	channels, err := uc.repo.AllChannels(ctx)
+	if err != nil {
+		return err
+	}
+	// 留出少量余量，避免刚好在间隔边界上被跳过一轮
+	due := time.Now().Add(-uc.refreshInterval + time.Minute)
+	for i := range channels {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if channels[i].LastRefreshedAt.After(due) {
+			continue
+		}
+		if err := uc.refresh(ctx, &channels[i]); err != nil {
+			log.Printf("播客订阅刷新失败 %s: %v", channels[i].FeedURL, err)
+		}
+	}
+	return nil
+}
+
+// refresh 抓取失败时记录到订阅的 last_error，已有单集保持不变
+func (uc *podcastUsecase) refresh(ctx context.Context, channel *scene_audio_route_models.PodcastChannel) error {
+	feed, err := uc.fetchFeed(ctx, channel.FeedURL)
+	if err != nil {
+		channel.LastRefreshedAt = time.Now().UTC()
+		channel.LastError = err.Error()
+		if saveErr := uc.repo.SaveRefresh(ctx, *channel); saveErr != nil {
+			log.Printf("保存播客刷新结果失败: %v", saveErr)
+		}
+		return err
+	}
+	return uc.applyFeed(ctx, channel, feed)
+}
+
+// applyFeed 写入频道信息与单集，然后按设置排队自动下载
+func (uc *podcastUsecase) applyFeed(ctx context.Context, channel *scene_audio_route_models.PodcastChannel, feed *podcast_util.Feed) error {
+	episodes := make([]scene_audio_route_models.PodcastEpisode, 0, len(feed.Episodes))
+	for _, e := range feed.Episodes {
+		episodes = append(episodes, scene_audio_route_models.PodcastEpisode{
+			GUID:         e.GUID,
+			Title:        e.Title,
+			Description:  e.Description,
+			PublishedAt:  e.PublishedAt,
+			Duration:     e.Duration.Seconds(),
+			EnclosureURL: e.EnclosureURL,
+			MimeType:     e.MimeType,
+			Size:         e.Size,
+		})
+	}
+	count, err := uc.repo.UpsertEpisodes(ctx, *channel, episodes)
+	if err != nil {
+		return err
+	}
+
+	channel.Title = feed.Title
+	channel.Description = feed.Description
+	channel.Author = feed.Author
+	channel.ImageURL = feed.ImageURL
+	channel.SiteURL = feed.SiteURL
+	channel.EpisodeCount = count
+	channel.LastRefreshedAt = time.Now().UTC()
+	channel.LastError = ""
+	if err := uc.repo.SaveRefresh(ctx, *channel); err != nil {
+		return err
+	}
+	uc.autoDownload(ctx, *channel)
+	return nil
+}
+
+func (uc *podcastUsecase) fetchFeed(ctx context.Context, feedURL string) (*podcast_util.Feed, error) {
+	body, err := uc.feeds.Get(ctx, feedURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", scene_audio_route_models.ErrPodcastFeedUnavailable, err)
+	}
+	feed, err := podcast_util.Parse(body, scene_audio_route_models.PodcastMaxEpisodes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", scene_audio_route_models.ErrPodcastFeedUnavailable, err)
+	}
+	return feed, nil
+}
+
+// autoDownload 只补下载最新 N 个单集中尚未下载的，旧的下载不自动清理
+func (uc *podcastUsecase) autoDownload(ctx context.Context, channel scene_audio_route_models.PodcastChannel) {
+	if channel.AutoDownload <= 0 || uc.downloadDir == "" {
+		return
+	}
+	latest, err := uc.repo.LatestEpisodes(ctx, channel.ID.Hex(), channel.AutoDownload)
+	if err != nil {
+		log.Printf("读取播客最新单集失败 %s: %v", channel.FeedURL, err)
+		return
+	}
+	for _, episode := range latest {
+		// 下载失败的单集不在每轮刷新时反复重试，需手动重新下载
+		if episode.DownloadStatus != scene_audio_route_models.PodcastDownloadNone {
+			continue
+		}
+		if err := uc.queueDownload(ctx, episode); err != nil {
+			log.Printf("播客单集排队下载失败 %s: %v", episode.Title, err)
+		}
+	}
+}
+
+// queueDownload 已排队、下载中或已下载的单集直接返回
+func (uc *podcastUsecase) queueDownload(ctx context.Context, episode scene_audio_route_models.PodcastEpisode) error {
+	claimed, err := uc.repo.ClaimDownload(ctx, episode.ID.Hex())
+	if err != nil || !claimed {
+		return err
+	}
+	go uc.download(episode)
+	return nil
+}
+
+func (uc *podcastUsecase) download(episode scene_audio_route_models.PodcastEpisode) {
+	uc.slots <- struct{}{}
+	defer func() { <-uc.slots }()
+
+	ctx := context.Background()
+	id := episode.ID.Hex()
+	if err := uc.repo.SetDownloadState(ctx, id, scene_audio_route_models.PodcastDownloadDownloading, "", ""); err != nil {
+		log.Printf("更新播客下载状态失败: %v", err)
+	}
+
+	localPath, err := uc.fetchEnclosure(ctx, episode)
+	if err != nil {
+		log.Printf("播客单集下载失败 %s: %v", episode.EnclosureURL, err)
+		if err := uc.repo.SetDownloadState(ctx, id, scene_audio_route_models.PodcastDownloadFailed, "", err.Error()); err != nil {
+			log.Printf("更新播客下载状态失败: %v", err)
+		}
+		return
+	}
+	// 下载期间取消了订阅时单集记录已不存在，删除刚下载的文件
+	if err := uc.repo.SetDownloadState(ctx, id, scene_audio_route_models.PodcastDownloadCompleted, localPath, ""); err != nil {
+		log.Printf("更新播客下载状态失败: %v", err)
+		_ = os.Remove(localPath)
+	}
+}
+
+// fetchEnclosure 先写入临时文件，完整下载后再改名，中断时不会留下残缺文件
+func (uc *podcastUsecase) fetchEnclosure(ctx context.Context, episode scene_audio_route_models.PodcastEpisode) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, episode.EnclosureURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", http_util.DefaultUserAgent)
+	resp, err := uc.downloads.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	dir := filepath.Join(uc.downloadDir, episode.ChannelID.Hex())
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	target := filepath.Join(dir, episode.ID.Hex()+enclosureExtension(episode))
+	tmp, err := os.CreateTemp(dir, ".download-*")
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(tmp, resp.Body); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	return target, nil
+}
+
+// podcastMimeExtensions 订阅源常见的附件类型；系统 MIME 表中一个类型常对应多个扩展名，不直接使用
+var podcastMimeExtensions = map[string]string{
+	"audio/mpeg":  ".mp3",
+	"audio/mp3":   ".mp3",
+	"audio/mp4":   ".m4a",
+	"audio/x-m4a": ".m4a",
+	"audio/aac":   ".aac",
+	"audio/ogg":   ".ogg",
+	"audio/opus":  ".opus",
+	"audio/flac":  ".flac",
+	"audio/wav":   ".wav",
+	"video/mp4":   ".mp4",
+}
+
+// enclosureExtension 优先取附件地址中的音频扩展名，其次按 MIME 类型推断，流媒体接口据此判断格式
+func enclosureExtension(episode scene_audio_route_models.PodcastEpisode) string {
+	if u, err := url.Parse(episode.EnclosureURL); err == nil {
+		ext := strings.ToLower(path.Ext(u.Path))
+		if slices.Contains(domain_file_entity.AudioExtensions, ext) || ext == ".mp4" {
+			return ext
+		}
+	}
+	mediaType, _, _ := mime.ParseMediaType(episode.MimeType)
+	if ext, ok := podcastMimeExtensions[strings.ToLower(mediaType)]; ok {
+		return ext
+	}
+	return ".mp3"
+}
+
+func (uc *podcastUsecase) checkAutoDownload(autoDownload int) error {
+	if autoDownload < 0 || autoDownload > scene_audio_route_models.PodcastMaxAutoDownload {
+		return scene_audio_route_models.ErrInvalidPodcast
+	}
+	if autoDownload > 0 && uc.downloadDir == "" {
+		return scene_audio_route_models.ErrPodcastDownloadDisabled
+	}
+	return nil
+}
+
+func normalizePodcastURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", scene_audio_route_models.ErrInvalidPodcast
+	}
+	return u.String(), nil
+}
@@ -0,0 +1,270 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/sync/errgroup"
+)
+
+type RecommendationUsecase struct {
+	repo    scene_audio_route_interface.RecommendationRepository
+	timeout time.Duration
+}
+
+func NewRecommendationUsecase(repo scene_audio_route_interface.RecommendationRepository, timeout time.Duration) *RecommendationUsecase {
+	return &RecommendationUsecase{repo: repo, timeout: timeout}
+}
+
+// scoredArtist 合并各项依据后的候选艺术家
+type scoredArtist struct {
+	id        string
+	score     float64
+	reasons   []string
+	becauseOf []string
+}
+
+func clampRecommendationLimit(limit int) int {
+	if limit <= 0 {
+		return scene_audio_route_models.SimilarArtistsDefaultLimit
+	}
+	return min(limit, scene_audio_route_models.SimilarArtistsMaxLimit)
+}
+
+func (uc *RecommendationUsecase) GetSimilarArtists(ctx context.Context, artistId string, limit int) ([]scene_audio_route_models.SimilarArtist, error) {
+	if _, err := primitive.ObjectIDFromHex(artistId); err != nil {
+		return nil, errors.New("invalid artist id format")
+	}
+	limit = clampRecommendationLimit(limit)
+
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	artist, err := uc.repo.GetArtistExternalInfo(ctx, artistId)
+	if err != nil {
+		return nil, err
+	}
+	scored, err := uc.similarScores(ctx, artist, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(scored) > limit {
+		scored = scored[:limit]
+	}
+	return uc.loadSimilarArtists(ctx, scored)
+}
+
+// GetRecommendations 以用户近期常听的艺术家为种子，按种子的播放占比加权合并各自的相似艺术家，
+// 再推荐这些艺术家中用户从未播放过的专辑
+func (uc *RecommendationUsecase) GetRecommendations(ctx context.Context, userId string, limit int) (*scene_audio_route_models.Recommendations, error) {
+	limit = clampRecommendationLimit(limit)
+
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	result := &scene_audio_route_models.Recommendations{
+		Seeds:   make([]scene_audio_route_models.ArtistMetadata, 0),
+		Artists: make([]scene_audio_route_models.SimilarArtist, 0),
+		Albums:  make([]scene_audio_route_models.RecommendedAlbum, 0),
+	}
+	seeds, err := uc.repo.TopPlayedArtists(ctx, userId,
+		time.Now().Add(-scene_audio_route_models.RecommendationWindow), scene_audio_route_models.RecommendationSeedArtists)
+	if err != nil {
+		return nil, err
+	}
+	if len(seeds) == 0 {
+		return result, nil
+	}
+
+	seedIDs := make([]string, 0, len(seeds))
+	var totalPlays float64
+	for _, seed := range seeds {
+		seedIDs = append(seedIDs, seed.ArtistID)
+		totalPlays += seed.Score
+	}
+	if result.Seeds, err = uc.repo.GetArtistsByIDs(ctx, seedIDs); err != nil {
+		return nil, err
+	}
+	seedNames := make(map[string]string, len(result.Seeds))
+	for _, artist := range result.Seeds {
+		seedNames[artist.ID.Hex()] = artist.Name
+	}
+
+	merged := make(map[string]*scoredArtist)
+	for _, seed := range seeds {
+		artist, err := uc.repo.GetArtistExternalInfo(ctx, seed.ArtistID)
+		if err != nil {
+			continue // 播放记录中的艺术家可能已被删除或合并
+		}
+		scored, err := uc.similarScores(ctx, artist, limit)
+		if err != nil {
+			return nil, err
+		}
+		weight := seed.Score / totalPlays
+		for _, s := range scored {
+			if _, isSeed := seedNames[s.id]; isSeed {
+				continue
+			}
+			m, ok := merged[s.id]
+			if !ok {
+				m = &scoredArtist{id: s.id}
+				merged[s.id] = m
+			}
+			m.score += weight * s.score
+			m.reasons = appendUnique(m.reasons, s.reasons...)
+			if name := seedNames[seed.ArtistID]; name != "" {
+				m.becauseOf = appendUnique(m.becauseOf, name)
+			}
+		}
+	}
+	ranked := rankScored(merged)
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	if result.Artists, err = uc.loadSimilarArtists(ctx, ranked); err != nil {
+		return nil, err
+	}
+
+	artistIDs := make([]string, 0, len(ranked))
+	byID := make(map[string]*scoredArtist, len(ranked))
+	for _, s := range ranked {
+		artistIDs = append(artistIDs, s.id)
+		byID[s.id] = s
+	}
+	albums, err := uc.repo.UnplayedAlbumsByArtists(ctx, userId, artistIDs, scene_audio_route_models.RecommendationAlbumLimit*2)
+	if err != nil {
+		return nil, err
+	}
+	for _, album := range albums {
+		s := byID[album.AlbumArtistID]
+		if s == nil {
+			s = byID[album.ArtistID]
+		}
+		if s == nil {
+			continue
+		}
+		if album.Availability == "" {
+			album.Availability = scene_audio_db_models.AvailabilityOnline
+		}
+		result.Albums = append(result.Albums, scene_audio_route_models.RecommendedAlbum{
+			Album:     album,
+			Score:     s.score,
+			BecauseOf: s.becauseOf,
+		})
+	}
+	sort.SliceStable(result.Albums, func(i, j int) bool { return result.Albums[i].Score > result.Albums[j].Score })
+	if len(result.Albums) > scene_audio_route_models.RecommendationAlbumLimit {
+		result.Albums = result.Albums[:scene_audio_route_models.RecommendationAlbumLimit]
+	}
+	return result, nil
+}
+
+// similarScores 并行计算流派、共同收听与 Last.fm 三项依据并按权重合并，按得分降序
+func (uc *RecommendationUsecase) similarScores(
+	ctx context.Context,
+	artist *scene_audio_route_models.ArtistExternalInfo,
+	limit int,
+) ([]*scoredArtist, error) {
+	artistId := artist.ID.Hex()
+	candidates := limit * 2
+	var genre, coPlay, lastFM []scene_audio_route_models.ArtistScore
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() (err error) {
+		genre, err = uc.repo.GenreNeighbours(gctx, artistId, candidates)
+		return err
+	})
+	g.Go(func() (err error) {
+		coPlay, err = uc.repo.CoPlayNeighbours(gctx, artistId, candidates)
+		return err
+	})
+	g.Go(func() (err error) {
+		lastFM, err = uc.repo.LastFMNeighbours(gctx, artist, candidates)
+		return err
+	})
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	merged := make(map[string]*scoredArtist)
+	add := func(scores []scene_audio_route_models.ArtistScore, weight float64, reason string) {
+		for _, s := range scores {
+			if s.ArtistID == artistId {
+				continue
+			}
+			m, ok := merged[s.ArtistID]
+			if !ok {
+				m = &scoredArtist{id: s.ArtistID}
+				merged[s.ArtistID] = m
+			}
+			m.score += weight * s.Score
+			m.reasons = append(m.reasons, reason)
+		}
+	}
+	add(genre, scene_audio_route_models.RecommendWeightGenre, scene_audio_route_models.RecommendReasonGenre)
+	add(coPlay, scene_audio_route_models.RecommendWeightCoPlay, scene_audio_route_models.RecommendReasonCoPlay)
+	add(lastFM, scene_audio_route_models.RecommendWeightLastFM, scene_audio_route_models.RecommendReasonLastFM)
+	return rankScored(merged), nil
+}
+
+// loadSimilarArtists 按得分顺序读取艺术家，已删除的候选跳过
+func (uc *RecommendationUsecase) loadSimilarArtists(ctx context.Context, scored []*scoredArtist) ([]scene_audio_route_models.SimilarArtist, error) {
+	ids := make([]string, 0, len(scored))
+	for _, s := range scored {
+		ids = append(ids, s.id)
+	}
+	artists, err := uc.repo.GetArtistsByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]scene_audio_route_models.ArtistMetadata, len(artists))
+	for _, artist := range artists {
+		byID[artist.ID.Hex()] = artist
+	}
+
+	result := make([]scene_audio_route_models.SimilarArtist, 0, len(scored))
+	for _, s := range scored {
+		artist, ok := byID[s.id]
+		if !ok {
+			continue
+		}
+		result = append(result, scene_audio_route_models.SimilarArtist{Artist: artist, Score: s.score, Reasons: s.reasons})
+	}
+	return result, nil
+}
+
+func rankScored(merged map[string]*scoredArtist) []*scoredArtist {
+	ranked := make([]*scoredArtist, 0, len(merged))
+	for _, s := range merged {
+		ranked = append(ranked, s)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return ranked[i].id < ranked[j].id
+	})
+	return ranked
+}
+
+func appendUnique(values []string, items ...string) []string {
+	for _, item := range items {
+		exists := false
+		for _, v := range values {
+			if v == item {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			values = append(values, item)
+		}
+	}
+	return values
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"time"
 )
@@ -37,6 +38,39 @@ func (uc *retrievalUsecase) GetStreamTempPath(ctx context.Context, metadataType
 	return uc.repo.GetStreamTempPath(ctx, metadataType)
 }
 
+func (uc *retrievalUsecase) GetReplayGain(ctx context.Context, mediaFileId string) (*scene_audio_route_models.RetrievalReplayGainMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if _, err := primitive.ObjectIDFromHex(mediaFileId); err != nil {
+		return nil, errors.New("invalid media file id format")
+	}
+	return uc.repo.GetReplayGain(ctx, mediaFileId)
+}
+
+func (uc *retrievalUsecase) GetCueSegment(ctx context.Context, mediaFileCueId string, track int) (*scene_audio_route_models.RetrievalCueSegment, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if _, err := primitive.ObjectIDFromHex(mediaFileCueId); err != nil {
+		return nil, errors.New("invalid media file cue id format")
+	}
+	if track <= 0 {
+		return nil, errors.New("invalid cue track number")
+	}
+	return uc.repo.GetCueSegment(ctx, mediaFileCueId, track)
+}
+
+func (uc *retrievalUsecase) GetGaplessInfo(ctx context.Context, mediaFileId string) (*scene_audio_route_models.RetrievalGaplessMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if _, err := primitive.ObjectIDFromHex(mediaFileId); err != nil {
+		return nil, errors.New("invalid media file id format")
+	}
+	return uc.repo.GetGaplessInfo(ctx, mediaFileId)
+}
+
 func (uc *retrievalUsecase) GetDownloadPath(ctx context.Context, mediaFileId string) (string, error) {
 	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
 	defer cancel()
@@ -63,6 +97,16 @@ func (uc *retrievalUsecase) GetCoverArtID(ctx context.Context, fileType string,
 	return uc.repo.GetCoverArtID(ctx, fileType, targetID)
 }
 
+func (uc *retrievalUsecase) GetCoverArtByID(ctx context.Context, targetID string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if _, err := primitive.ObjectIDFromHex(targetID); err != nil {
+		return "", errors.New("invalid target id format")
+	}
+	return uc.repo.GetCoverArtByID(ctx, targetID)
+}
+
 func (uc *retrievalUsecase) GetLyricsLrcMetaData(ctx context.Context, mediaFileId string) (string, error) {
 	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
 	defer cancel()
@@ -0,0 +1,71 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_subsonic/scene_audio_subsonic_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_subsonic/scene_audio_subsonic_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cache_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/event_util"
+)
+
+const scrobbleClockSkew = 5 * time.Minute
+
+type scrobbleUsecase struct {
+	repo      scene_audio_route_interface.ScrobbleRepository
+	timeout   time.Duration
+	forwarder scene_audio_subsonic_interface.SubsonicForwarder
+}
+
+func NewScrobbleUsecase(
+	repo scene_audio_route_interface.ScrobbleRepository,
+	timeout time.Duration,
+	forwarder scene_audio_subsonic_interface.SubsonicForwarder,
+) scene_audio_route_interface.ScrobbleRepository {
+	return &scrobbleUsecase{
+		repo:      repo,
+		timeout:   timeout,
+		forwarder: forwarder,
+	}
+}
+
+func (uc *scrobbleUsecase) Scrobble(
+	ctx context.Context,
+	history scene_audio_route_models.PlayHistoryMetadata,
+) (*scene_audio_route_models.PlayHistoryMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if history.MediaFileID.IsZero() {
+		return nil, errors.New("media file id is required")
+	}
+	if history.DurationPlayed < 0 {
+		return nil, errors.New("duration played must not be negative")
+	}
+	// 离线播放补报时使用客户端时间，允许少量时钟偏差
+	if history.PlayedAt.After(time.Now().Add(scrobbleClockSkew)) {
+		return nil, errors.New("played at must not be in the future")
+	}
+
+	saved, err := uc.repo.Scrobble(ctx, history)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to scrobble")
+	}
+	cache_util.Invalidate(ctx, cache_util.NamespaceFilterCounts, cache_util.NamespaceLists)
+	uc.forwarder.Forward(ctx, scene_audio_subsonic_models.ForwardActionScrobble, saved.MediaFileID.Hex(), "media", saved.PlayedAt)
+	event_util.Publish(ctx, event_util.TopicScrobble, scene_audio_route_models.ScrobbleEvent{
+		UserID:      saved.UserID,
+		MediaFileID: saved.MediaFileID.Hex(),
+		AlbumID:     saved.AlbumID,
+		ArtistID:    saved.ArtistID,
+		Client:      saved.Client,
+		Complete:    saved.Complete,
+		PlayedAt:    saved.PlayedAt,
+	})
+	return saved, nil
+}
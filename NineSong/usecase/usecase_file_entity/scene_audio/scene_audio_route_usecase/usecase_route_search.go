@@ -0,0 +1,101 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+)
+
+type searchUsecase struct {
+	repo    scene_audio_route_interface.SearchRepository
+	timeout time.Duration
+}
+
+func NewSearchUsecase(repo scene_audio_route_interface.SearchRepository, timeout time.Duration) scene_audio_route_interface.SearchRepository {
+	return &searchUsecase{
+		repo:    repo,
+		timeout: timeout,
+	}
+}
+
+func (uc *searchUsecase) Search(
+	ctx context.Context,
+	query string,
+	paging scene_audio_route_models.SearchPaging,
+) (*scene_audio_route_models.SearchResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	query = strings.TrimSpace(query)
+
+	validations := []func() error{
+		func() error {
+			if query == "" {
+				return errors.New("search query cannot be empty")
+			}
+			if len(query) > 200 {
+				return errors.New("search query exceeds maximum length")
+			}
+			return nil
+		},
+		func() error {
+			for _, offset := range []int{paging.SongOffset, paging.AlbumOffset, paging.ArtistOffset, paging.RadioOffset} {
+				if offset < 0 {
+					return errors.New("offset cannot be negative")
+				}
+			}
+			for _, count := range []int{paging.SongCount, paging.AlbumCount, paging.ArtistCount, paging.RadioCount} {
+				if count < 0 || count > scene_audio_route_models.SearchMaxCount {
+					return fmt.Errorf("count must be between 0 and %d", scene_audio_route_models.SearchMaxCount)
+				}
+			}
+			return nil
+		},
+	}
+	for _, validate := range validations {
+		if err := validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := uc.repo.Search(ctx, query, paging)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "search failed")
+	}
+	return result, nil
+}
+
+func (uc *searchUsecase) SearchLyrics(
+	ctx context.Context,
+	query string,
+	offset, count int,
+) (*scene_audio_route_models.LyricsSearchResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, errors.New("search query cannot be empty")
+	}
+	if len(query) > 200 {
+		return nil, errors.New("search query exceeds maximum length")
+	}
+	if offset < 0 {
+		return nil, errors.New("offset cannot be negative")
+	}
+	if count < 0 || count > scene_audio_route_models.SearchMaxCount {
+		return nil, fmt.Errorf("count must be between 0 and %d", scene_audio_route_models.SearchMaxCount)
+	}
+
+	result, err := uc.repo.SearchLyrics(ctx, query, offset, count)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "search failed")
+	}
+	return result, nil
+}
@@ -0,0 +1,201 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 规则嵌套深度上限，防止生成过深的查询
+const smartPlaylistMaxDepth = 5
+
+type smartPlaylistUsecase struct {
+	repo    scene_audio_route_interface.SmartPlaylistRepository
+	timeout time.Duration
+}
+
+func NewSmartPlaylistUsecase(repo scene_audio_route_interface.SmartPlaylistRepository, timeout time.Duration) scene_audio_route_interface.SmartPlaylistRepository {
+	return &smartPlaylistUsecase{
+		repo:    repo,
+		timeout: timeout,
+	}
+}
+
+func (uc *smartPlaylistUsecase) GetSmartPlaylists(ctx context.Context) ([]scene_audio_route_models.SmartPlaylistMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	playlists, err := uc.repo.GetSmartPlaylists(ctx)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to fetch smart playlists")
+	}
+	return playlists, nil
+}
+
+func (uc *smartPlaylistUsecase) GetSmartPlaylist(ctx context.Context, playlistId string) (*scene_audio_route_models.SmartPlaylistMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if _, err := primitive.ObjectIDFromHex(playlistId); err != nil {
+		return nil, errors.New("invalid playlist id format")
+	}
+
+	playlist, err := uc.repo.GetSmartPlaylist(ctx, playlistId)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "smart playlist not found")
+	}
+	return playlist, nil
+}
+
+func (uc *smartPlaylistUsecase) CreateSmartPlaylist(ctx context.Context, playlist scene_audio_route_models.SmartPlaylistMetadata) (*scene_audio_route_models.SmartPlaylistMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if err := validateSmartPlaylist(playlist); err != nil {
+		return nil, err
+	}
+
+	created, err := uc.repo.CreateSmartPlaylist(ctx, playlist)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to create smart playlist")
+	}
+	return created, nil
+}
+
+func (uc *smartPlaylistUsecase) UpdateSmartPlaylist(ctx context.Context, playlistId string, playlist scene_audio_route_models.SmartPlaylistMetadata) (*scene_audio_route_models.SmartPlaylistMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if _, err := primitive.ObjectIDFromHex(playlistId); err != nil {
+		return nil, errors.New("invalid playlist id format")
+	}
+	if err := validateSmartPlaylist(playlist); err != nil {
+		return nil, err
+	}
+
+	updated, err := uc.repo.UpdateSmartPlaylist(ctx, playlistId, playlist)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to update smart playlist")
+	}
+	return updated, nil
+}
+
+func (uc *smartPlaylistUsecase) DeleteSmartPlaylist(ctx context.Context, playlistId string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if _, err := primitive.ObjectIDFromHex(playlistId); err != nil {
+		return false, errors.New("invalid playlist id format")
+	}
+
+	success, err := uc.repo.DeleteSmartPlaylist(ctx, playlistId)
+	if err != nil {
+		return false, domain.WrapDomainError(err, "delete operation failed")
+	}
+	return success, nil
+}
+
+func (uc *smartPlaylistUsecase) GetSmartPlaylistTracks(ctx context.Context, playlistId string, start string, end string) ([]scene_audio_route_models.MediaFileMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if _, err := primitive.ObjectIDFromHex(playlistId); err != nil {
+		return nil, errors.New("invalid playlist id format")
+	}
+
+	tracks, err := uc.repo.GetSmartPlaylistTracks(ctx, playlistId, start, end)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to evaluate smart playlist")
+	}
+	return tracks, nil
+}
+
+func (uc *smartPlaylistUsecase) PreviewSmartPlaylist(ctx context.Context, rules scene_audio_route_models.SmartPlaylistRules, start string, end string) ([]scene_audio_route_models.MediaFileMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if err := validateSmartRules(rules); err != nil {
+		return nil, err
+	}
+
+	tracks, err := uc.repo.PreviewSmartPlaylist(ctx, rules, start, end)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to preview smart playlist")
+	}
+	return tracks, nil
+}
+
+func validateSmartPlaylist(playlist scene_audio_route_models.SmartPlaylistMetadata) error {
+	if strings.TrimSpace(playlist.Name) == "" {
+		return errors.New("playlist name cannot be empty")
+	}
+	if len(playlist.Name) > 100 {
+		return errors.New("playlist name exceeds maximum length")
+	}
+	return validateSmartRules(playlist.Rules)
+}
+
+func validateSmartRules(rules scene_audio_route_models.SmartPlaylistRules) error {
+	if rules.Limit < 0 || rules.Limit > scene_audio_route_models.SmartPlaylistMaxLimit {
+		return fmt.Errorf("limit must be between 0 and %d", scene_audio_route_models.SmartPlaylistMaxLimit)
+	}
+	if rules.Order != "" && rules.Order != "asc" && rules.Order != "desc" {
+		return errors.New("order must be asc or desc")
+	}
+	if len(rules.Rules) == 0 && len(rules.Groups) == 0 {
+		return errors.New("smart playlist requires at least one rule")
+	}
+	return validateSmartRuleGroup(rules.SmartPlaylistRuleGroup, 1)
+}
+
+func validateSmartRuleGroup(group scene_audio_route_models.SmartPlaylistRuleGroup, depth int) error {
+	if depth > smartPlaylistMaxDepth {
+		return fmt.Errorf("rule groups nested deeper than %d levels", smartPlaylistMaxDepth)
+	}
+	switch strings.ToLower(group.Combinator) {
+	case "", "all", "any":
+	default:
+		return fmt.Errorf("invalid combinator: %s", group.Combinator)
+	}
+
+	for _, rule := range group.Rules {
+		field, ok := scene_audio_route_models.SmartPlaylistFields[strings.ToLower(rule.Field)]
+		if !ok {
+			return fmt.Errorf("unsupported rule field: %s", rule.Field)
+		}
+		types, ok := scene_audio_route_models.SmartPlaylistOperators[strings.ToLower(rule.Operator)]
+		if !ok {
+			return fmt.Errorf("unsupported rule operator: %s", rule.Operator)
+		}
+		supported := false
+		for _, t := range types {
+			if t == field.Type {
+				supported = true
+				break
+			}
+		}
+		if strings.ToLower(rule.Operator) == "in_genre_tree" && strings.ToLower(rule.Field) != "genre" {
+			supported = false
+		}
+		if !supported {
+			return fmt.Errorf("operator %s is not supported for field %s", rule.Operator, rule.Field)
+		}
+		if rule.Value == nil {
+			return fmt.Errorf("rule %s: value is required", rule.Field)
+		}
+	}
+
+	for _, sub := range group.Groups {
+		if err := validateSmartRuleGroup(sub, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cache_util"
+)
+
+type StatsOverviewUsecase struct {
+	repo    scene_audio_route_interface.StatsOverviewRepository
+	timeout time.Duration
+}
+
+func NewStatsOverviewUsecase(repo scene_audio_route_interface.StatsOverviewRepository, timeout time.Duration) *StatsOverviewUsecase {
+	return &StatsOverviewUsecase{repo: repo, timeout: timeout}
+}
+
+// GetOverview window 取值同排行榜（7d/30d/365d/all），为空时统计近一年
+func (uc *StatsOverviewUsecase) GetOverview(ctx context.Context, userId, window string) (*scene_audio_route_models.StatsOverview, error) {
+	if window == "" {
+		window = scene_audio_route_models.ChartWindowYear
+	}
+	now := time.Now()
+	since, err := scene_audio_route_models.ChartSince(window, now)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	key := cache_util.Key("overview", userId, window)
+	return cache_util.GetOrLoad(ctx, cache_util.NamespaceStats, key, scene_audio_route_models.StatsOverviewTTL,
+		func() (*scene_audio_route_models.StatsOverview, error) {
+			raw, err := uc.repo.GetOverview(ctx, userId, since, time.Local)
+			if err != nil {
+				return nil, err
+			}
+
+			overview := &scene_audio_route_models.StatsOverview{
+				Window:           window,
+				Plays:            raw.Plays,
+				ListeningSeconds: raw.ListeningSeconds,
+				UniqueMediaFiles: raw.UniqueMediaFiles,
+				UniqueAlbums:     raw.UniqueAlbums,
+				UniqueArtists:    raw.UniqueArtists,
+				ActiveDays:       len(raw.Days),
+				Genres:           raw.Genres,
+				Heatmap:          raw.Heatmap,
+				Streaks:          listeningStreaks(raw.Days, now),
+			}
+			if !since.IsZero() {
+				overview.Since = &since
+			}
+			for i := range overview.Genres {
+				if raw.Plays > 0 {
+					overview.Genres[i].Share = float64(overview.Genres[i].Plays) / float64(raw.Plays)
+				}
+			}
+			return overview, nil
+		})
+}
+
+// listeningStreaks days 为升序的 YYYY-MM-DD；当前连续天数在今天或昨天仍有播放时才计算
+func listeningStreaks(days []string, now time.Time) scene_audio_route_models.StatsStreaks {
+	var streaks scene_audio_route_models.StatsStreaks
+	var run int
+	var runStart, prev time.Time
+	for _, day := range days {
+		d, err := time.ParseInLocation(time.DateOnly, day, now.Location())
+		if err != nil {
+			continue
+		}
+		if run > 0 && d.Equal(prev.AddDate(0, 0, 1)) {
+			run++
+		} else {
+			run, runStart = 1, d
+		}
+		prev = d
+		if run > streaks.Longest {
+			streaks.Longest = run
+			streaks.LongestStart = runStart.Format(time.DateOnly)
+			streaks.LongestEnd = d.Format(time.DateOnly)
+		}
+	}
+	today := startOfDay(now)
+	if run > 0 && !prev.Before(today.AddDate(0, 0, -1)) {
+		streaks.Current = run
+	}
+	return streaks
+}
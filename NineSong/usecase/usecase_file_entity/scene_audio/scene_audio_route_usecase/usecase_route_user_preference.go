@@ -0,0 +1,78 @@
+package scene_audio_route_usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_transcode/scene_audio_transcode_models"
+)
+
+type userPreferenceUsecase struct {
+	repo    scene_audio_route_interface.UserPreferenceRepository
+	timeout time.Duration
+}
+
+func NewUserPreferenceUsecase(repo scene_audio_route_interface.UserPreferenceRepository, timeout time.Duration) scene_audio_route_interface.UserPreferenceRepository {
+	return &userPreferenceUsecase{
+		repo:    repo,
+		timeout: timeout,
+	}
+}
+
+func (uc *userPreferenceUsecase) GetUserPreference(ctx context.Context, userId string) (*scene_audio_route_models.UserPreferenceMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if userId == "" {
+		return nil, errors.New("user id is required")
+	}
+
+	pref, err := uc.repo.GetUserPreference(ctx, userId)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to fetch user preference")
+	}
+	return pref, nil
+}
+
+func (uc *userPreferenceUsecase) UpdateReplayGainMode(ctx context.Context, userId string, mode string) (*scene_audio_route_models.UserPreferenceMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if userId == "" {
+		return nil, errors.New("user id is required")
+	}
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if !scene_audio_transcode_models.IsValidReplayGainMode(mode) {
+		return nil, errors.New("replay_gain_mode must be track, album or off")
+	}
+
+	pref, err := uc.repo.UpdateReplayGainMode(ctx, userId, mode)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to update replay gain mode")
+	}
+	return pref, nil
+}
+
+func (uc *userPreferenceUsecase) UpdateHomeSections(ctx context.Context, userId string, sections []scene_audio_route_models.HomeSectionConfig) (*scene_audio_route_models.UserPreferenceMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if userId == "" {
+		return nil, errors.New("user id is required")
+	}
+	sections, err := scene_audio_route_models.NormalizeHomeSections(sections)
+	if err != nil {
+		return nil, err
+	}
+
+	pref, err := uc.repo.UpdateHomeSections(ctx, userId, sections)
+	if err != nil {
+		return nil, domain.WrapDomainError(err, "failed to update home sections")
+	}
+	return pref, nil
+}
@@ -0,0 +1,156 @@
+package scene_audio_subsonic_usecase
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_subsonic/scene_audio_subsonic_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_subsonic/scene_audio_subsonic_models"
+)
+
+const (
+	subsonicClientName = "NineSong"
+	// subsonicErrNotFound Subsonic 协议中"数据未找到"的错误码
+	subsonicErrNotFound = 70
+)
+
+type subsonicClient struct {
+	baseURL  string
+	username string
+	password string
+	client   *http.Client
+}
+
+func NewSubsonicClient(baseURL, username, password string, timeout time.Duration) scene_audio_subsonic_interface.SubsonicClient {
+	return &subsonicClient{
+		baseURL:  strings.TrimRight(baseURL, "/"),
+		username: username,
+		password: password,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+type subsonicEnvelope struct {
+	Response struct {
+		Status string `json:"status"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+		SearchResult3 *struct {
+			Song   []scene_audio_subsonic_models.SubsonicItem `json:"song"`
+			Album  []scene_audio_subsonic_models.SubsonicItem `json:"album"`
+			Artist []scene_audio_subsonic_models.SubsonicItem `json:"artist"`
+		} `json:"searchResult3"`
+	} `json:"subsonic-response"`
+}
+
+func (c *subsonicClient) Search(ctx context.Context, query string) (*scene_audio_subsonic_models.SubsonicSearchResult, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("songCount", "20")
+	params.Set("albumCount", "20")
+	params.Set("artistCount", "20")
+
+	resp, err := c.call(ctx, "search3", params)
+	if err != nil {
+		return nil, err
+	}
+	result := &scene_audio_subsonic_models.SubsonicSearchResult{}
+	if sr := resp.Response.SearchResult3; sr != nil {
+		result.Songs, result.Albums, result.Artists = sr.Song, sr.Album, sr.Artist
+	}
+	return result, nil
+}
+
+func (c *subsonicClient) Scrobble(ctx context.Context, upstreamID string, playedAt time.Time) error {
+	params := url.Values{}
+	params.Set("id", upstreamID)
+	params.Set("submission", "true")
+	if !playedAt.IsZero() {
+		params.Set("time", strconv.FormatInt(playedAt.UnixMilli(), 10))
+	}
+	_, err := c.call(ctx, "scrobble", params)
+	return err
+}
+
+func (c *subsonicClient) Star(ctx context.Context, itemType, upstreamID string) error {
+	_, err := c.call(ctx, "star", starParams(itemType, upstreamID))
+	return err
+}
+
+func (c *subsonicClient) Unstar(ctx context.Context, itemType, upstreamID string) error {
+	_, err := c.call(ctx, "unstar", starParams(itemType, upstreamID))
+	return err
+}
+
+// starParams star/unstar 按条目类型使用不同的参数名
+func starParams(itemType, upstreamID string) url.Values {
+	params := url.Values{}
+	switch itemType {
+	case "album":
+		params.Set("albumId", upstreamID)
+	case "artist":
+		params.Set("artistId", upstreamID)
+	default:
+		params.Set("id", upstreamID)
+	}
+	return params
+}
+
+// call 使用 token+salt 认证，避免明文密码出现在URL中
+func (c *subsonicClient) call(ctx context.Context, method string, params url.Values) (*subsonicEnvelope, error) {
+	saltBytes := make([]byte, 8)
+	if _, err := rand.Read(saltBytes); err != nil {
+		return nil, fmt.Errorf("生成认证盐值失败: %w", err)
+	}
+	salt := hex.EncodeToString(saltBytes)
+	token := md5.Sum([]byte(c.password + salt))
+
+	params.Set("u", c.username)
+	params.Set("t", hex.EncodeToString(token[:]))
+	params.Set("s", salt)
+	params.Set("v", scene_audio_subsonic_models.SubsonicAPIVersion)
+	params.Set("c", subsonicClientName)
+	params.Set("f", "json")
+
+	endpoint := fmt.Sprintf("%s/rest/%s.view?%s", c.baseURL, method, params.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("构建请求失败: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", scene_audio_subsonic_models.ErrUpstreamFailed, err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: 状态码 %d", scene_audio_subsonic_models.ErrUpstreamFailed, res.StatusCode)
+	}
+
+	var envelope subsonicEnvelope
+	if err := json.NewDecoder(res.Body).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("解析上游响应失败: %w", err)
+	}
+	if envelope.Response.Status != "ok" {
+		if e := envelope.Response.Error; e != nil {
+			if e.Code == subsonicErrNotFound {
+				return nil, scene_audio_subsonic_models.ErrUpstreamNotFound
+			}
+			return nil, fmt.Errorf("%w: %d %s", scene_audio_subsonic_models.ErrUpstreamFailed, e.Code, e.Message)
+		}
+		return nil, scene_audio_subsonic_models.ErrUpstreamFailed
+	}
+	return &envelope, nil
+}
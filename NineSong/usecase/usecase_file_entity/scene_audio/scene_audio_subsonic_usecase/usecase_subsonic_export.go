@@ -0,0 +1,196 @@
+package scene_audio_subsonic_usecase
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_route/scene_audio_route_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_subsonic/scene_audio_subsonic_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_subsonic/scene_audio_subsonic_models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type subsonicExportUsecase struct {
+	numericIDs     scene_audio_subsonic_interface.NumericIDRepository
+	playlists      scene_audio_route_interface.PlaylistRepository
+	playlistTracks scene_audio_route_interface.PlaylistTrackRepository
+	radios         scene_audio_route_interface.InternetRadioRepository
+	timeout        time.Duration
+}
+
+func NewSubsonicExportUsecase(
+	numericIDs scene_audio_subsonic_interface.NumericIDRepository,
+	playlists scene_audio_route_interface.PlaylistRepository,
+	playlistTracks scene_audio_route_interface.PlaylistTrackRepository,
+	radios scene_audio_route_interface.InternetRadioRepository,
+	timeout time.Duration,
+) scene_audio_subsonic_interface.SubsonicExportUsecase {
+	return &subsonicExportUsecase{
+		numericIDs:     numericIDs,
+		playlists:      playlists,
+		playlistTracks: playlistTracks,
+		radios:         radios,
+		timeout:        timeout,
+	}
+}
+
+func (uc *subsonicExportUsecase) ResolveItemID(ctx context.Context, itemType, rawID string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+	return uc.resolve(ctx, itemType, rawID)
+}
+
+func (uc *subsonicExportUsecase) GetPlaylist(ctx context.Context, rawID string) (*scene_audio_subsonic_models.SubsonicPlaylist, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	playlistId, err := uc.resolve(ctx, scene_audio_subsonic_models.NumericItemPlaylist, rawID)
+	if err != nil {
+		return nil, err
+	}
+	playlist, err := uc.playlists.GetPlaylist(ctx, playlistId)
+	if err != nil {
+		return nil, err
+	}
+	tracks, err := uc.playlistTracks.GetPlaylistTrackItems(ctx, "", "", "index", "asc", "", "", "", "", "", playlistId)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := uc.mapIDs(ctx, playlistId, tracks)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &scene_audio_subsonic_models.SubsonicPlaylist{
+		ID:        ids.format(scene_audio_subsonic_models.NumericItemPlaylist, playlistId),
+		Name:      playlist.Name,
+		Comment:   playlist.Comment,
+		SongCount: len(tracks),
+		Created:   playlist.CreatedAt,
+		Changed:   playlist.UpdatedAt,
+		Entry:     make([]scene_audio_subsonic_models.SubsonicSong, 0, len(tracks)),
+	}
+	for _, track := range tracks {
+		albumID := ids.format(scene_audio_subsonic_models.NumericItemAlbum, track.AlbumID)
+		// 库中时长以纳秒存储，Subsonic 使用整秒
+		seconds := int(time.Duration(track.Duration).Seconds())
+		result.Duration += seconds
+		result.Entry = append(result.Entry, scene_audio_subsonic_models.SubsonicSong{
+			ID:         ids.format(scene_audio_subsonic_models.NumericItemMedia, track.ID.Hex()),
+			Parent:     albumID,
+			Title:      track.Title,
+			Album:      track.Album,
+			Artist:     track.Artist,
+			Track:      track.TrackNumber,
+			DiscNumber: track.DiscNumber,
+			Year:       track.Year,
+			Genre:      track.Genre,
+			Size:       int64(track.Size),
+			Suffix:     track.Suffix,
+			Duration:   seconds,
+			BitRate:    track.BitRate,
+			Path:       track.Path,
+			AlbumID:    albumID,
+			ArtistID:   ids.format(scene_audio_subsonic_models.NumericItemArtist, track.ArtistID),
+			Type:       "music",
+		})
+	}
+	return result, nil
+}
+
+func (uc *subsonicExportUsecase) GetInternetRadioStations(ctx context.Context, userId string) ([]scene_audio_subsonic_models.SubsonicInternetRadioStation, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	stations, err := uc.radios.GetStations(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	itemIDs := make([]string, 0, len(stations))
+	for _, station := range stations {
+		itemIDs = append(itemIDs, station.ID.Hex())
+	}
+	ids := numericIDSet{}
+	if len(itemIDs) > 0 {
+		mapped, err := uc.numericIDs.GetOrCreate(ctx, scene_audio_subsonic_models.NumericItemRadio, itemIDs)
+		if err != nil {
+			return nil, err
+		}
+		ids[scene_audio_subsonic_models.NumericItemRadio] = mapped
+	}
+
+	result := make([]scene_audio_subsonic_models.SubsonicInternetRadioStation, 0, len(stations))
+	for _, station := range stations {
+		result = append(result, scene_audio_subsonic_models.SubsonicInternetRadioStation{
+			ID:          ids.format(scene_audio_subsonic_models.NumericItemRadio, station.ID.Hex()),
+			Name:        station.Name,
+			StreamURL:   station.StreamURL,
+			HomePageURL: station.HomePageURL,
+		})
+	}
+	return result, nil
+}
+
+// resolve 整数ID需与期望的条目类型一致，避免把专辑ID当作播放列表使用
+func (uc *subsonicExportUsecase) resolve(ctx context.Context, itemType, rawID string) (string, error) {
+	if _, err := primitive.ObjectIDFromHex(rawID); err == nil {
+		return rawID, nil
+	}
+	id, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil || id <= 0 {
+		return "", scene_audio_subsonic_models.ErrInvalidNumericID
+	}
+	mapping, err := uc.numericIDs.Resolve(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if mapping.ItemType != itemType {
+		return "", fmt.Errorf("%s %d %w", itemType, id, domain.ErrNotFound)
+	}
+	return mapping.ItemID, nil
+}
+
+// numericIDSet 按条目类型分组的映射结果
+type numericIDSet map[string]map[string]int64
+
+// format 未映射的条目（如缺失的专辑ID）输出空字符串
+func (s numericIDSet) format(itemType, itemID string) string {
+	if id, ok := s[itemType][itemID]; ok {
+		return strconv.FormatInt(id, 10)
+	}
+	return ""
+}
+
+func (uc *subsonicExportUsecase) mapIDs(ctx context.Context, playlistId string, tracks []scene_audio_route_models.MediaFileMetadata) (numericIDSet, error) {
+	pending := map[string][]string{
+		scene_audio_subsonic_models.NumericItemPlaylist: {playlistId},
+	}
+	seen := make(map[string]bool)
+	add := func(itemType, itemID string) {
+		if itemID == "" || seen[itemType+":"+itemID] {
+			return
+		}
+		seen[itemType+":"+itemID] = true
+		pending[itemType] = append(pending[itemType], itemID)
+	}
+	for _, track := range tracks {
+		add(scene_audio_subsonic_models.NumericItemMedia, track.ID.Hex())
+		add(scene_audio_subsonic_models.NumericItemAlbum, track.AlbumID)
+		add(scene_audio_subsonic_models.NumericItemArtist, track.ArtistID)
+	}
+
+	set := make(numericIDSet, len(pending))
+	for itemType, itemIDs := range pending {
+		ids, err := uc.numericIDs.GetOrCreate(ctx, itemType, itemIDs)
+		if err != nil {
+			return nil, err
+		}
+		set[itemType] = ids
+	}
+	return set, nil
+}
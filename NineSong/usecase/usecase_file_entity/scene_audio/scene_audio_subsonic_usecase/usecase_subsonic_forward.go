@@ -0,0 +1,269 @@
+package scene_audio_subsonic_usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_db/scene_audio_db_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_subsonic/scene_audio_subsonic_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_subsonic/scene_audio_subsonic_models"
+)
+
+const (
+	forwardPollInterval = 30 * time.Second
+	forwardBatchSize    = 50
+	// forwardClaimLease 领取任务后的租期，处理中途崩溃时任务在租期后重新可见
+	forwardClaimLease = 5 * time.Minute
+	forwardMaxBackoff = 6 * time.Hour
+)
+
+type SubsonicForwardUsecase struct {
+	repo    scene_audio_subsonic_interface.SubsonicForwardRepository
+	client  scene_audio_subsonic_interface.SubsonicClient
+	timeout time.Duration
+	wake    chan struct{}
+}
+
+// NewSubsonicForwardUsecase 所有操作先落库再异步发送，上游不可用时按指数退避重试
+func NewSubsonicForwardUsecase(
+	repo scene_audio_subsonic_interface.SubsonicForwardRepository,
+	client scene_audio_subsonic_interface.SubsonicClient,
+	timeout time.Duration,
+) *SubsonicForwardUsecase {
+	return &SubsonicForwardUsecase{
+		repo:    repo,
+		client:  client,
+		timeout: timeout,
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// Start 启动后台转发协程，ctx 取消时退出
+func (f *SubsonicForwardUsecase) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(forwardPollInterval)
+		defer ticker.Stop()
+		for {
+			f.drain(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-f.wake:
+			}
+		}
+	}()
+}
+
+func (f *SubsonicForwardUsecase) Forward(ctx context.Context, action, itemID, itemType string, playedAt time.Time) {
+	now := time.Now().UTC()
+	task := &scene_audio_subsonic_models.SubsonicForwardTask{
+		Action:        action,
+		ItemID:        itemID,
+		ItemType:      itemType,
+		PlayedAt:      playedAt,
+		Status:        scene_audio_subsonic_models.ForwardStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+	// 入队失败不影响本地操作结果
+	if err := f.repo.EnqueueTask(ctx, task); err != nil {
+		log.Printf("上游转发入队失败 %s %s: %v", action, itemID, err)
+		return
+	}
+	select {
+	case f.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (f *SubsonicForwardUsecase) drain(ctx context.Context) {
+	for {
+		tasks, err := f.repo.GetDueTasks(ctx, time.Now().UTC(), forwardBatchSize)
+		if err != nil {
+			log.Printf("读取上游转发队列失败: %v", err)
+			return
+		}
+		for _, task := range tasks {
+			if ctx.Err() != nil {
+				return
+			}
+			f.process(ctx, task)
+		}
+		if len(tasks) < forwardBatchSize {
+			return
+		}
+	}
+}
+
+func (f *SubsonicForwardUsecase) process(ctx context.Context, task scene_audio_subsonic_models.SubsonicForwardTask) {
+	claimed, err := f.repo.ClaimTask(ctx, task, time.Now().UTC().Add(forwardClaimLease))
+	if err != nil || !claimed {
+		return
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, f.timeout)
+	err = f.send(callCtx, task)
+	cancel()
+
+	if err == nil {
+		if err := f.repo.DeleteTask(ctx, task.ID); err != nil {
+			log.Printf("删除已转发任务失败 %s: %v", task.ID.Hex(), err)
+		}
+		return
+	}
+
+	task.Attempts++
+	task.LastError = err.Error()
+	if task.Attempts >= scene_audio_subsonic_models.ForwardTaskMaxAttempts {
+		task.Status = scene_audio_subsonic_models.ForwardStatusFailed
+		log.Printf("上游转发放弃 %s %s: %v", task.Action, task.ItemID, err)
+	} else {
+		backoff := time.Minute << (task.Attempts - 1)
+		if backoff > forwardMaxBackoff {
+			backoff = forwardMaxBackoff
+		}
+		task.NextAttemptAt = time.Now().UTC().Add(backoff)
+	}
+	if err := f.repo.UpdateTask(ctx, task); err != nil {
+		log.Printf("更新转发任务失败 %s: %v", task.ID.Hex(), err)
+	}
+}
+
+func (f *SubsonicForwardUsecase) send(ctx context.Context, task scene_audio_subsonic_models.SubsonicForwardTask) error {
+	upstreamID, err := f.resolve(ctx, task.ItemID, task.ItemType)
+	if err != nil {
+		return err
+	}
+
+	switch task.Action {
+	case scene_audio_subsonic_models.ForwardActionScrobble:
+		return f.client.Scrobble(ctx, upstreamID, task.PlayedAt)
+	case scene_audio_subsonic_models.ForwardActionStar:
+		return f.client.Star(ctx, task.ItemType, upstreamID)
+	case scene_audio_subsonic_models.ForwardActionUnstar:
+		return f.client.Unstar(ctx, task.ItemType, upstreamID)
+	}
+	return fmt.Errorf("unsupported forward action: %s", task.Action)
+}
+
+// resolve 优先使用已保存的映射，否则在上游搜索并按 MBID、ISRC、路径、标签依次匹配
+func (f *SubsonicForwardUsecase) resolve(ctx context.Context, itemID, itemType string) (string, error) {
+	mapping, err := f.repo.GetMapping(ctx, itemID, itemType)
+	if err != nil {
+		return "", err
+	}
+	if mapping != nil {
+		return mapping.UpstreamID, nil
+	}
+
+	local, err := f.repo.GetLocalItem(ctx, itemID, itemType)
+	if err != nil {
+		return "", err
+	}
+	if local == nil {
+		return "", fmt.Errorf("local %s %s not found", itemType, itemID)
+	}
+
+	result, err := f.client.Search(ctx, local.Title)
+	if err != nil {
+		return "", err
+	}
+	candidates := result.Songs
+	switch itemType {
+	case "album":
+		candidates = result.Albums
+	case "artist":
+		candidates = result.Artists
+	}
+
+	upstreamID, matchedBy := matchCandidate(*local, candidates)
+	if upstreamID == "" {
+		return "", scene_audio_subsonic_models.ErrUpstreamNotFound
+	}
+	if err := f.repo.SaveMapping(ctx, scene_audio_subsonic_models.SubsonicItemMapping{
+		ItemID:     itemID,
+		ItemType:   itemType,
+		UpstreamID: upstreamID,
+		MatchedBy:  matchedBy,
+	}); err != nil {
+		log.Printf("保存上游映射失败 %s: %v", itemID, err)
+	}
+	return upstreamID, nil
+}
+
+func matchCandidate(local scene_audio_subsonic_models.SubsonicLocalItem, candidates []scene_audio_subsonic_models.SubsonicItem) (string, string) {
+	if local.MBID != "" {
+		for _, c := range candidates {
+			if strings.EqualFold(c.MusicBrainzID, local.MBID) {
+				return c.ID, scene_audio_subsonic_models.MatchedByMBID
+			}
+		}
+	}
+
+	// 同一录音的不同发行版共享 ISRC，仅在候选中唯一时采用
+	if local.ISRC != "" {
+		var matched []string
+		for _, c := range candidates {
+			for _, isrc := range c.ISRC {
+				if scene_audio_db_models.NormalizeISRC(isrc) == local.ISRC {
+					matched = append(matched, c.ID)
+					break
+				}
+			}
+		}
+		if len(matched) == 1 {
+			return matched[0], scene_audio_subsonic_models.MatchedByISRC
+		}
+	}
+
+	// 上游路径相对其媒体库根目录，本地路径以其结尾即视为同一文件
+	if local.Path != "" {
+		localPath := strings.ToLower(filepath.ToSlash(local.Path))
+		for _, c := range candidates {
+			if c.Path == "" {
+				continue
+			}
+			upstreamPath := strings.ToLower(strings.TrimPrefix(filepath.ToSlash(c.Path), "/"))
+			if strings.HasSuffix(localPath, "/"+upstreamPath) {
+				return c.ID, scene_audio_subsonic_models.MatchedByPath
+			}
+		}
+	}
+
+	// 标签匹配需唯一，存在歧义时宁可不转发
+	var matched []string
+	for _, c := range candidates {
+		title := c.Title
+		if local.ItemType != "media" {
+			title = c.Name
+		}
+		if !strings.EqualFold(title, local.Title) {
+			continue
+		}
+		if local.Artist != "" && !strings.EqualFold(c.Artist, local.Artist) {
+			continue
+		}
+		if local.Album != "" && !strings.EqualFold(c.Album, local.Album) {
+			continue
+		}
+		matched = append(matched, c.ID)
+	}
+	if len(matched) == 1 {
+		return matched[0], scene_audio_subsonic_models.MatchedByTags
+	}
+	return "", ""
+}
+
+// noopForwarder 未配置上游服务器时使用
+type noopForwarder struct{}
+
+func NewNoopForwarder() scene_audio_subsonic_interface.SubsonicForwarder {
+	return noopForwarder{}
+}
+
+func (noopForwarder) Forward(context.Context, string, string, string, time.Time) {}
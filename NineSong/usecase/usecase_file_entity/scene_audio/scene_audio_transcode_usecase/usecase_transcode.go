@@ -0,0 +1,230 @@
+package scene_audio_transcode_usecase
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_transcode/scene_audio_transcode_interface"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity/scene_audio/scene_audio_transcode/scene_audio_transcode_models"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cache_util"
+	ffmpeggo "github.com/u2takey/ffmpeg-go"
+)
+
+type transcodeUsecase struct {
+	timeout time.Duration
+
+	hashMemo cache_util.Cache // path|size|mtime -> 文件内容哈希，LRU淘汰
+
+	writingMutex sync.Mutex
+	writing      map[string]struct{} // 正在写入的缓存文件
+}
+
+const (
+	fileHashMemoNamespace = "transcode_file_hash"
+	fileHashMemoCapacity  = 4096
+	fileHashMemoTTL       = 24 * time.Hour
+)
+
+// NewTranscodeUsecase timeout为单次转码的最长执行时间
+func NewTranscodeUsecase(timeout time.Duration) scene_audio_transcode_interface.TranscodeService {
+	return &transcodeUsecase{
+		timeout:  timeout,
+		hashMemo: cache_util.NewMemoryCache(fileHashMemoCapacity),
+		writing:  make(map[string]struct{}),
+	}
+}
+
+func (uc *transcodeUsecase) CachedPath(
+	ctx context.Context,
+	inputPath string,
+	cacheDir string,
+	profile scene_audio_transcode_models.TranscodeProfile,
+) (string, bool, error) {
+	cachePath, err := uc.cachePath(inputPath, cacheDir, profile)
+	if err != nil {
+		return "", false, err
+	}
+
+	info, err := os.Stat(cachePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cachePath, false, nil
+		}
+		return "", false, fmt.Errorf("检查缓存文件失败: %w", err)
+	}
+	if info.Size() == 0 {
+		return cachePath, false, nil
+	}
+	return cachePath, true, nil
+}
+
+func (uc *transcodeUsecase) Stream(
+	ctx context.Context,
+	w io.Writer,
+	inputPath string,
+	cacheDir string,
+	profile scene_audio_transcode_models.TranscodeProfile,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	cachePath, err := uc.cachePath(inputPath, cacheDir, profile)
+	if err != nil {
+		return err
+	}
+
+	// 同一缓存文件只允许一个写入者，其余请求直接转码不落盘
+	var cacheFile *os.File
+	partPath := cachePath + ".part"
+	if uc.acquireWriting(cachePath) {
+		defer uc.releaseWriting(cachePath)
+		if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err == nil {
+			cacheFile, err = os.Create(partPath)
+			if err != nil {
+				log.Printf("创建转码缓存失败: %v", err)
+				cacheFile = nil
+			}
+		}
+	}
+
+	out := w
+	if cacheFile != nil {
+		out = io.MultiWriter(w, cacheFile)
+	}
+
+	runErr := uc.run(ctx, inputPath, out, profile)
+
+	if cacheFile != nil {
+		closeErr := cacheFile.Close()
+		if runErr != nil || closeErr != nil {
+			os.Remove(partPath)
+		} else if err := os.Rename(partPath, cachePath); err != nil {
+			os.Remove(partPath)
+			log.Printf("写入转码缓存失败: %v", err)
+		}
+	}
+
+	if runErr != nil {
+		return fmt.Errorf("转码失败: %w", runErr)
+	}
+	return nil
+}
+
+func (uc *transcodeUsecase) run(
+	ctx context.Context,
+	inputPath string,
+	out io.Writer,
+	profile scene_audio_transcode_models.TranscodeProfile,
+) error {
+	args := ffmpeggo.KwArgs{
+		"vn":  "",
+		"c:a": profile.Format.Codec,
+		"b:a": fmt.Sprintf("%dk", profile.BitRate),
+		"f":   profile.Format.Container,
+	}
+	if profile.SampleRate > 0 {
+		args["ar"] = profile.SampleRate
+	}
+	if profile.Channels > 0 {
+		args["ac"] = profile.Channels
+	}
+	if len(profile.AudioFilters) > 0 {
+		args["af"] = strings.Join(profile.AudioFilters, ",")
+	}
+	if profile.Duration > 0 {
+		args["t"] = strconv.FormatFloat(profile.Duration, 'f', 3, 64)
+	}
+	inputArgs := ffmpeggo.KwArgs{}
+	if profile.StartTime > 0 {
+		// 输入前定位，避免从头解码整轨文件
+		inputArgs["ss"] = strconv.FormatFloat(profile.StartTime, 'f', 3, 64)
+	}
+
+	stream := ffmpeggo.Input(inputPath, inputArgs).Output("pipe:1", args)
+	stream.Context = ctx
+	cmd := stream.WithOutput(out).Compile()
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("转码超时")
+		}
+		return err
+	}
+	return nil
+}
+
+// 缓存路径: cacheDir/transcode/{文件哈希前2位}/{文件哈希}_{配置哈希}{扩展名}
+func (uc *transcodeUsecase) cachePath(
+	inputPath string,
+	cacheDir string,
+	profile scene_audio_transcode_models.TranscodeProfile,
+) (string, error) {
+	fileHash, err := uc.fileHash(inputPath)
+	if err != nil {
+		return "", err
+	}
+	profileSum := sha1.Sum([]byte(profile.Key()))
+	profileHash := hex.EncodeToString(profileSum[:])[:16]
+
+	return filepath.Join(
+		cacheDir,
+		"transcode",
+		fileHash[:2],
+		fileHash+"_"+profileHash+profile.Format.Extension,
+	), nil
+}
+
+// 文件内容哈希，按路径、大小与修改时间记忆，文件变动后自动重算，旧记录随LRU淘汰
+func (uc *transcodeUsecase) fileHash(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("读取文件信息失败: %w", err)
+	}
+	memoKey := fmt.Sprintf("%s|%d|%d", path, info.Size(), info.ModTime().UnixNano())
+
+	if hash, ok := uc.hashMemo.Get(context.Background(), fileHashMemoNamespace, memoKey); ok {
+		return string(hash), nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("打开文件失败: %w", err)
+	}
+	defer file.Close()
+
+	hasher := sha1.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return "", fmt.Errorf("计算文件哈希失败: %w", err)
+	}
+	hash := hex.EncodeToString(hasher.Sum(nil))
+
+	uc.hashMemo.Set(context.Background(), fileHashMemoNamespace, memoKey, []byte(hash), fileHashMemoTTL)
+	return hash, nil
+}
+
+func (uc *transcodeUsecase) acquireWriting(key string) bool {
+	uc.writingMutex.Lock()
+	defer uc.writingMutex.Unlock()
+	if _, ok := uc.writing[key]; ok {
+		return false
+	}
+	uc.writing[key] = struct{}{}
+	return true
+}
+
+func (uc *transcodeUsecase) releaseWriting(key string) {
+	uc.writingMutex.Lock()
+	delete(uc.writing, key)
+	uc.writingMutex.Unlock()
+}
@@ -0,0 +1,133 @@
+package usecase_system
+
+import (
+	"context"
+	"io/fs"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_file_entity"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/cache_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/http_util"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/internal_system/status_util"
+)
+
+// dashboardCacheTypes 统计占用的临时目录类型，转码缓存位于 stream 目录下
+var dashboardCacheTypes = []string{"stream", "cover"}
+
+type dashboardUsecase struct {
+	repo       domain_system.DashboardRepository
+	scan       domain_system.ScanStatusProvider
+	version    string
+	apiVersion string
+	timeout    time.Duration
+}
+
+func NewDashboardUsecase(
+	repo domain_system.DashboardRepository,
+	scan domain_system.ScanStatusProvider,
+	version, apiVersion string,
+	timeout time.Duration,
+) domain_system.DashboardUsecase {
+	return &dashboardUsecase{repo: repo, scan: scan, version: version, apiVersion: apiVersion, timeout: timeout}
+}
+
+// Get 库统计查询失败时返回错误；会话、缓存目录等辅助信息失败只记录日志，面板仍可展示其余状态
+func (uc *dashboardUsecase) Get(ctx context.Context) (*domain_system.Dashboard, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	library, err := uc.repo.GetLibraryCounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dashboard := &domain_system.Dashboard{
+		Version:        uc.version,
+		APIVersion:     uc.apiVersion,
+		StartedAt:      status_util.StartedAt(),
+		UptimeSeconds:  int64(status_util.Uptime().Seconds()),
+		ActiveSessions: make([]domain_system.ActiveSession, 0),
+		Caches:         domain_system.DashboardCaches{Disk: make([]domain_system.DiskCacheUsage, 0)},
+		RecentErrors:   make([]domain_system.DashboardError, 0),
+		ExternalAPIs:   make([]domain_system.ExternalAPIUsage, 0),
+		Library:        library,
+	}
+	if uc.scan != nil {
+		dashboard.Scan = uc.scan.GetScanStatus()
+	} else {
+		dashboard.Scan = domain_file_entity.ScanStatus{Tasks: make([]domain_file_entity.ScanTaskStatus, 0)}
+	}
+
+	if sessions, err := uc.repo.GetActiveSessions(ctx, time.Now().Add(-domain_system.DashboardActiveWindow)); err != nil {
+		log.Printf("面板读取活跃会话失败: %v", err)
+	} else {
+		dashboard.ActiveSessions = sessions
+	}
+
+	stats, err := cache_util.CurrentStats(ctx)
+	if err != nil {
+		log.Printf("面板读取缓存统计失败: %v", err)
+	}
+	dashboard.Caches.Response = domain_system.ResponseCacheUsage{
+		Backend:  stats.Backend,
+		Entries:  stats.Entries,
+		Capacity: stats.Capacity,
+	}
+	if folders, err := uc.repo.GetCacheFolders(ctx, dashboardCacheTypes...); err != nil {
+		log.Printf("面板读取临时目录失败: %v", err)
+	} else {
+		for _, cacheType := range dashboardCacheTypes {
+			if path, ok := folders[cacheType]; ok {
+				dashboard.Caches.Disk = append(dashboard.Caches.Disk, diskUsage(ctx, cacheType, path))
+			}
+		}
+	}
+
+	for _, p := range http_util.Snapshot() {
+		dashboard.ExternalAPIs = append(dashboard.ExternalAPIs, domain_system.ExternalAPIUsage{
+			Provider:     p.Provider,
+			Requests:     p.Requests,
+			CacheHits:    p.CacheHits,
+			Retries:      p.Retries,
+			RateLimited:  p.RateLimited,
+			Failures:     p.Failures,
+			AvgLatencyMs: p.AvgLatencyMs,
+			LastError:    p.LastError,
+			LastErrorAt:  p.LastErrorAt,
+		})
+	}
+
+	for _, e := range status_util.RecentErrors() {
+		dashboard.RecentErrors = append(dashboard.RecentErrors, domain_system.DashboardError{
+			Time:    e.Time,
+			Method:  e.Method,
+			Path:    e.Path,
+			Status:  e.Status,
+			Code:    e.Code,
+			Message: e.Message,
+		})
+	}
+	return dashboard, nil
+}
+
+// diskUsage 统计目录下的文件数与字节数，超时后返回已统计的部分
+func diskUsage(ctx context.Context, cacheType, path string) domain_system.DiskCacheUsage {
+	usage := domain_system.DiskCacheUsage{Type: cacheType, Path: path}
+	_ = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			usage.Files++
+			usage.Bytes += info.Size()
+		}
+		return nil
+	})
+	return usage
+}
@@ -0,0 +1,76 @@
+package usecase_system
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_system"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/event_util"
+)
+
+// userScopedTopics 载荷含 user_id 的主题，只投递给对应用户
+var userScopedTopics = map[string]bool{
+	event_util.TopicScrobble:   true,
+	event_util.TopicNowPlaying: true,
+}
+
+type eventUsecase struct{}
+
+func NewEventUsecase() domain_system.EventUsecase {
+	return &eventUsecase{}
+}
+
+func (uc *eventUsecase) Subscribe(userID string, topics []string) (<-chan domain_system.StreamEvent, func(), error) {
+	for _, topic := range topics {
+		if !slices.Contains(domain_system.EventTopics, topic) {
+			return nil, nil, fmt.Errorf("%w: %s", domain_system.ErrInvalidEventTopic, topic)
+		}
+	}
+	if len(topics) == 0 {
+		topics = domain_system.EventTopics
+	}
+
+	sub := event_util.Subscribe(topics...)
+	out := make(chan domain_system.StreamEvent)
+	done := make(chan struct{})
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-done:
+				return
+			case event, ok := <-sub.C:
+				if !ok {
+					return
+				}
+				if userScopedTopics[event.Topic] && eventUserID(event.Payload) != userID {
+					continue
+				}
+				select {
+				case out <- domain_system.StreamEvent{Topic: event.Topic, Data: event.Payload, Time: event.Time}:
+				case <-done:
+					return
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			close(done)
+			sub.Close()
+		})
+	}
+	return out, cancel, nil
+}
+
+func eventUserID(payload json.RawMessage) string {
+	var owner struct {
+		UserID string `json:"user_id"`
+	}
+	_ = json.Unmarshal(payload, &owner)
+	return owner.UserID
+}
@@ -0,0 +1,60 @@
+package usecase_system
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/domain_system"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type exportUsecase struct {
+	repo    domain_system.ExportRepository
+	timeout time.Duration
+}
+
+func NewExportUsecase(repo domain_system.ExportRepository, timeout time.Duration) domain_system.ExportUsecase {
+	return &exportUsecase{repo: repo, timeout: timeout}
+}
+
+// Export 整个目录的导出耗时远超单次请求超时，超时只作用于每一页的查询
+func (uc *exportUsecase) Export(ctx context.Context, types []string, emit func([]domain_system.ExportRecord) error) error {
+	for _, itemType := range types {
+		var after primitive.ObjectID
+		for {
+			docs, err := uc.getPage(ctx, itemType, after)
+			if err != nil {
+				return err
+			}
+			if len(docs) == 0 {
+				break
+			}
+
+			records := make([]domain_system.ExportRecord, 0, len(docs))
+			for _, doc := range docs {
+				records = append(records, domain_system.ExportRecord{Type: itemType, Data: doc})
+			}
+			if err := emit(records); err != nil {
+				return err
+			}
+
+			id, ok := docs[len(docs)-1]["_id"].(primitive.ObjectID)
+			if !ok {
+				return fmt.Errorf("export %s: unexpected _id type %T", itemType, docs[len(docs)-1]["_id"])
+			}
+			after = id
+			if len(docs) < domain_system.ExportPageSize {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (uc *exportUsecase) getPage(ctx context.Context, itemType string, after primitive.ObjectID) ([]bson.M, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+	return uc.repo.GetPage(ctx, itemType, after, domain_system.ExportPageSize)
+}